@@ -7095,34 +7095,44 @@ def go_deps():
             "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/jwt/com_github_nats_io_jwt-v0.3.2.zip",
         ],
     )
+    go_repository(
+        name = "com_github_nats_io_jwt_v2",
+        build_file_proto_mode = "disable_global",
+        importpath = "github.com/nats-io/jwt/v2",
+        sha256 = "f387205c696c1da0dedb60a6b2556a89c8d8c18d8a3b2e7721e90f5db835c325",
+        strip_prefix = "github.com/nats-io/jwt/v2@v2.5.7",
+        urls = [
+            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/jwt/v2/com_github_nats_io_jwt_v2-v2.5.7.zip",
+        ],
+    )
     go_repository(
         name = "com_github_nats_io_nats_go",
         build_file_proto_mode = "disable_global",
         importpath = "github.com/nats-io/nats.go",
-        sha256 = "34a735d158d70685faad1fc3153f08da0ddc21c0ae42f6a0cb09430d638364b2",
-        strip_prefix = "github.com/nats-io/nats.go@v1.9.1",
+        sha256 = "3cb91adc6c85c2eb2cd55775bc9a857b74ec203cf52c78ff2a60f50a4593a907",
+        strip_prefix = "github.com/nats-io/nats.go@v1.37.0",
         urls = [
-            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nats.go/com_github_nats_io_nats_go-v1.9.1.zip",
+            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nats.go/com_github_nats_io_nats_go-v1.37.0.zip",
         ],
     )
     go_repository(
         name = "com_github_nats_io_nats_server_v2",
         build_file_proto_mode = "disable_global",
         importpath = "github.com/nats-io/nats-server/v2",
-        sha256 = "cf6c4affe3eae3f43b67b5ecc401f41819280136d6ef9209198a5f44b62d3280",
-        strip_prefix = "github.com/nats-io/nats-server/v2@v2.1.2",
+        sha256 = "8e2d9bb608b506c2625dd3adac648fa19fbc8610c1364899cc6d9addb49046a6",
+        strip_prefix = "github.com/nats-io/nats-server/v2@v2.10.16",
         urls = [
-            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nats-server/v2/com_github_nats_io_nats_server_v2-v2.1.2.zip",
+            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nats-server/v2/com_github_nats_io_nats_server_v2-v2.10.16.zip",
         ],
     )
     go_repository(
         name = "com_github_nats_io_nkeys",
         build_file_proto_mode = "disable_global",
         importpath = "github.com/nats-io/nkeys",
-        sha256 = "291930a7abcd84edcaffc2cadc75aeb830ebf561144313ead6b8e1fcc03b124f",
-        strip_prefix = "github.com/nats-io/nkeys@v0.1.3",
+        sha256 = "b5ea0fc3e87853935f2903cd8222f6ad92944625b795ba3bf8c99c2cfc499b5b",
+        strip_prefix = "github.com/nats-io/nkeys@v0.4.7",
         urls = [
-            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nkeys/com_github_nats_io_nkeys-v0.1.3.zip",
+            "https://storage.googleapis.com/cockroach-godeps/gomod/github.com/nats-io/nkeys/com_github_nats_io_nkeys-v0.4.7.zip",
         ],
     )
     go_repository(
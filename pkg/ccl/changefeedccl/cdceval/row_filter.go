@@ -0,0 +1,198 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cdceval
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rangefeedfilter"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// RowFilterForExpression returns the filter that the rangefeeds and the scans
+// of a changefeed can send to the server so that the rows that don't satisfy
+// the WHERE clause of the given (normalized) expression, and the columns that
+// it doesn't reference, don't leave the server. Returns nil if no part of the
+// expression can be pushed down.
+//
+// The filter is an optimization: the expression is still evaluated on every
+// event by the Evaluator. Only the parts of the expression that are evaluated
+// the same way by the server are pushed down:
+//   - the WHERE clause, if it's a combination of comparisons of columns of the
+//     target family (or of the primary key) with constants;
+//   - the projection, if the expression only references columns by name;
+//   - the target family, if the table has several of them.
+func RowFilterForExpression(
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	target jobspb.ChangefeedTargetSpecification,
+	sc *tree.SelectClause,
+) (*kvpb.RangeFeedFilter, error) {
+	family, err := getTargetFamilyDescriptor(desc, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var predicate string
+	var fetchColumnIDs []descpb.ColumnID
+	if sc.Where != nil {
+		predicate, fetchColumnIDs = pushdownPredicate(desc, family, sc.Where.Expr)
+	}
+	projection := projectedColumns(desc, family, sc)
+	if predicate == "" && projection == nil && desc.NumFamilies() == 1 {
+		return nil, nil
+	}
+
+	filter, err := rangefeedfilter.MakeFilter(codec, desc, fetchColumnIDs, predicate)
+	if err != nil {
+		return nil, err
+	}
+	if desc.NumFamilies() > 1 {
+		filter.FamilyIDs = []catid.FamilyID{family.ID}
+	}
+	filter.ColumnIDs = projection
+	return filter, nil
+}
+
+// pushdownPredicate returns the given predicate with its columns replaced by
+// ordinal references to the returned columns, if it can be evaluated by the
+// rangefeed filter. Otherwise, it returns an empty predicate.
+//
+// The rangefeed filter evaluates the predicate with an empty session, without
+// user-defined types, and on each column family separately, so the predicate
+// is only pushed down if it's a combination of comparisons with constants,
+// whose result doesn't depend on the session, of key columns and columns of
+// the target family.
+func pushdownPredicate(
+	desc catalog.TableDescriptor, family *descpb.ColumnFamilyDescriptor, expr tree.Expr,
+) (string, []descpb.ColumnID) {
+	familyCols := catalog.MakeTableColSet(family.ColumnIDs...)
+	keyCols := desc.GetPrimaryIndex().CollectKeyColumnIDs()
+
+	var fetchColumnIDs []descpb.ColumnID
+	ordinals := make(map[descpb.ColumnID]int)
+	errUnsupported := errors.New("unsupported predicate")
+	pushed, err := tree.SimpleVisit(expr, func(expr tree.Expr) (bool, tree.Expr, error) {
+		switch e := expr.(type) {
+		case *tree.AndExpr, *tree.OrExpr, *tree.NotExpr, *tree.ParenExpr, *tree.IsNullExpr,
+			*tree.IsNotNullExpr, *tree.RangeCond, *tree.Tuple, *tree.NumVal, *tree.StrVal, tree.Datum:
+			return true, expr, nil
+		case *tree.ComparisonExpr:
+			switch e.Operator.Symbol {
+			case treecmp.EQ, treecmp.NE, treecmp.LT, treecmp.LE, treecmp.GT, treecmp.GE,
+				treecmp.In, treecmp.NotIn, treecmp.IsDistinctFrom, treecmp.IsNotDistinctFrom:
+				return true, expr, nil
+			}
+		case *tree.UnresolvedName:
+			vn, err := e.NormalizeVarName()
+			if err != nil {
+				return false, expr, errUnsupported
+			}
+			// Qualified names may refer to the previous row (cdc_prev).
+			item, ok := vn.(*tree.ColumnItem)
+			if !ok || item.TableName != nil {
+				return false, expr, errUnsupported
+			}
+			col, err := catalog.MustFindColumnByTreeName(desc, item.ColumnName)
+			if err != nil || col.IsVirtual() || !isPushdownType(col.GetType()) ||
+				!(familyCols.Contains(col.GetID()) || keyCols.Contains(col.GetID())) {
+				return false, expr, errUnsupported
+			}
+			ord, ok := ordinals[col.GetID()]
+			if !ok {
+				ord = len(fetchColumnIDs)
+				ordinals[col.GetID()] = ord
+				fetchColumnIDs = append(fetchColumnIDs, col.GetID())
+			}
+			return false, tree.NewOrdinalReference(ord), nil
+		}
+		return false, expr, errUnsupported
+	})
+	if err != nil {
+		return "", nil
+	}
+	return tree.Serialize(pushed), fetchColumnIDs
+}
+
+// isPushdownType returns whether the comparisons of values of the given type
+// with constants can be pushed down: the constants of these types are parsed
+// the same way regardless of the session.
+func isPushdownType(typ *types.T) bool {
+	switch typ.Family() {
+	case types.BoolFamily, types.IntFamily, types.FloatFamily, types.DecimalFamily,
+		types.StringFamily, types.BytesFamily, types.UuidFamily:
+		return !typ.UserDefined()
+	default:
+		return false
+	}
+}
+
+// projectedColumns returns the columns of the target family that the
+// expression needs, along with the non-nullable columns, which the decoders
+// of the changefeed expect. Returns nil if the expression may need all the
+// columns, e.g. if it uses a star or refers to the row as a tuple.
+func projectedColumns(
+	desc catalog.TableDescriptor, family *descpb.ColumnFamilyDescriptor, sc *tree.SelectClause,
+) []descpb.ColumnID {
+	var referenced catalog.TableColSet
+	needsAll := false
+	_, err := tree.SimpleStmtVisit(sc, func(expr tree.Expr) (bool, tree.Expr, error) {
+		switch e := expr.(type) {
+		case tree.UnqualifiedStar, *tree.AllColumnsSelector, *tree.TupleStar:
+			needsAll = true
+		case *tree.UnresolvedName:
+			vn, err := e.NormalizeVarName()
+			if err != nil {
+				return false, expr, err
+			}
+			item, ok := vn.(*tree.ColumnItem)
+			if !ok {
+				needsAll = true
+				return false, expr, nil
+			}
+			col, err := catalog.MustFindColumnByTreeName(desc, item.ColumnName)
+			if err != nil {
+				// The name refers to something else than a column, such as
+				// cdc_prev or the table itself.
+				needsAll = true
+				return false, expr, nil
+			}
+			referenced.Add(col.GetID())
+			return false, expr, nil
+		}
+		return !needsAll, expr, nil
+	})
+	if err != nil || needsAll {
+		return nil
+	}
+
+	var projection []descpb.ColumnID
+	for _, id := range family.ColumnIDs {
+		col, err := catalog.MustFindColumnByID(desc, id)
+		if err != nil {
+			return nil
+		}
+		if referenced.Contains(id) || !col.IsNullable() {
+			projection = append(projection, id)
+		}
+	}
+	if len(projection) == len(family.ColumnIDs) {
+		return nil
+	}
+	if len(projection) == 0 {
+		// An empty projection would emit all the columns, so keep the first
+		// one.
+		projection = append(projection, family.ColumnIDs[0])
+	}
+	return projection
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cdceval
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowFilterForExpression(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(context.Background())
+	s := srv.ApplicationLayer()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.ExecMultiple(t,
+		`CREATE TYPE status AS ENUM ('open', 'closed')`,
+		`CREATE TABLE foo (
+a INT PRIMARY KEY,
+b STRING,
+c INT NOT NULL,
+d TIMESTAMPTZ,
+e status,
+extra STRING,
+FAMILY main (a, b, c, d, e),
+FAMILY extra (extra)
+)`,
+		`CREATE TABLE bar (a INT PRIMARY KEY, b STRING)`,
+	)
+
+	codec := s.ExecutorConfig().(sql.ExecutorConfig).Codec
+	fooDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "foo")
+	barDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "bar")
+
+	for _, tc := range []struct {
+		name         string
+		desc         catalog.TableDescriptor
+		targetFamily string
+		stmt         string
+		// noFilter is set if nothing is pushed down.
+		noFilter  bool
+		predicate string
+		fetched   []string
+		families  []catid.FamilyID
+		columns   []string
+	}{
+		{
+			name:      "filter and projection",
+			desc:      fooDesc,
+			stmt:      "SELECT a, b FROM foo WHERE b = 'x'",
+			predicate: "@1 = 'x'",
+			fetched:   []string{"b"},
+			families:  []catid.FamilyID{0},
+			columns:   []string{"a", "b", "c"},
+		},
+		{
+			name:      "star",
+			desc:      fooDesc,
+			stmt:      "SELECT * FROM foo WHERE c IN (1, 2) AND (a > 1 OR b IS NULL)",
+			predicate: "@1 IN (1, 2) AND (@2 > 1 OR @3 IS NULL)",
+			fetched:   []string{"c", "a", "b"},
+			families:  []catid.FamilyID{0},
+		},
+		{
+			name:     "session dependent constant",
+			desc:     fooDesc,
+			stmt:     "SELECT a FROM foo WHERE d > '2020-01-01'",
+			families: []catid.FamilyID{0},
+			columns:  []string{"a", "c", "d"},
+		},
+		{
+			name:     "user-defined type",
+			desc:     fooDesc,
+			stmt:     "SELECT a FROM foo WHERE e = 'open'",
+			families: []catid.FamilyID{0},
+			columns:  []string{"a", "c", "e"},
+		},
+		{
+			name:     "function",
+			desc:     fooDesc,
+			stmt:     "SELECT a, lower(b) FROM foo WHERE length(b) > 2",
+			families: []catid.FamilyID{0},
+			columns:  []string{"a", "b", "c"},
+		},
+		{
+			name:     "previous value column",
+			desc:     fooDesc,
+			stmt:     "SELECT a FROM foo WHERE cdc_prev.b = 'x'",
+			families: []catid.FamilyID{0},
+			columns:  []string{"a", "b", "c"},
+		},
+		{
+			name:     "previous row",
+			desc:     fooDesc,
+			stmt:     "SELECT a, cdc_prev FROM foo WHERE b = 'x'",
+			families: []catid.FamilyID{0},
+			// The predicate is pushed down, but not the projection.
+			predicate: "@1 = 'x'",
+			fetched:   []string{"b"},
+		},
+		{
+			name:         "other family",
+			desc:         fooDesc,
+			targetFamily: "extra",
+			stmt:         "SELECT extra FROM foo WHERE extra != 'x'",
+			predicate:    "@1 != 'x'",
+			fetched:      []string{"extra"},
+			families:     []catid.FamilyID{1},
+		},
+		{
+			name:     "single family",
+			desc:     barDesc,
+			stmt:     "SELECT * FROM bar WHERE length(b) > 2",
+			noFilter: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, err := ParseChangefeedExpression(tc.stmt)
+			require.NoError(t, err)
+			target := jobspb.ChangefeedTargetSpecification{
+				Type:    jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+				TableID: tc.desc.GetID(),
+			}
+			if tc.targetFamily != "" {
+				target.Type = jobspb.ChangefeedTargetSpecification_COLUMN_FAMILY
+				target.FamilyName = tc.targetFamily
+			}
+
+			filter, err := RowFilterForExpression(codec, tc.desc, target, sc)
+			require.NoError(t, err)
+			if tc.noFilter {
+				require.Nil(t, filter)
+				return
+			}
+			require.NotNil(t, filter)
+
+			if tc.predicate == "" {
+				require.Empty(t, filter.Predicate)
+			} else {
+				expected, err := parser.ParseExpr(tc.predicate)
+				require.NoError(t, err)
+				require.Equal(t, tree.Serialize(expected), filter.Predicate)
+			}
+			var fetched []string
+			for _, col := range filter.IndexFetchSpec.FetchedColumns {
+				fetched = append(fetched, col.Name)
+			}
+			require.Equal(t, tc.fetched, fetched)
+			require.Equal(t, tc.families, filter.FamilyIDs)
+			var columns []string
+			for _, id := range filter.ColumnIDs {
+				col, err := catalog.MustFindColumnByID(tc.desc, id)
+				require.NoError(t, err)
+				columns = append(columns, col.GetName())
+			}
+			require.Equal(t, tc.columns, columns)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cdctest
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// protoMessage is the schema of a message in a .proto file produced by the
+// changefeed protobuf encoder. Only the subset of the language the encoder
+// emits is supported: nested message definitions and fields that are either
+// optional scalars or nested messages.
+type protoMessage struct {
+	name   string
+	parent *protoMessage
+	nested []*protoMessage
+	fields map[protowire.Number]protoField
+}
+
+type protoField struct {
+	name string
+	// typ is either a scalar type name or the name of a message visible from
+	// the message declaring the field.
+	typ string
+}
+
+// resolve returns the message with the given name that is visible from m:
+// a message nested in m or in one of its enclosing messages.
+func (m *protoMessage) resolve(name string) *protoMessage {
+	for s := m; s != nil; s = s.parent {
+		for _, n := range s.nested {
+			if n.name == name {
+				return n
+			}
+		}
+	}
+	return nil
+}
+
+// parseProtoSchema parses the .proto schema and returns a pseudo-message whose
+// nested messages are the top-level messages of the schema.
+func parseProtoSchema(schema string) (*protoMessage, error) {
+	root := &protoMessage{}
+	cur := root
+	for _, line := range strings.Split(schema, "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == ``,
+			strings.HasPrefix(line, `syntax `),
+			strings.HasPrefix(line, `package `):
+		case strings.HasPrefix(line, `message `) && strings.HasSuffix(line, `{`):
+			m := &protoMessage{
+				name:   strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(line, `message `), `{`)),
+				parent: cur,
+				fields: make(map[protowire.Number]protoField),
+			}
+			cur.nested = append(cur.nested, m)
+			cur = m
+		case line == `}`:
+			if cur.parent == nil {
+				return nil, errors.Errorf(`unbalanced braces in schema:\n%s`, schema)
+			}
+			cur = cur.parent
+		default:
+			tokens := strings.Fields(strings.TrimSuffix(line, `;`))
+			if len(tokens) > 0 && tokens[0] == `optional` {
+				tokens = tokens[1:]
+			}
+			if len(tokens) != 4 || tokens[2] != `=` || cur == root {
+				return nil, errors.Errorf(`unexpected line in schema: %q`, line)
+			}
+			num, err := strconv.Atoi(tokens[3])
+			if err != nil {
+				return nil, errors.Wrapf(err, `unexpected field number in schema: %q`, line)
+			}
+			cur.fields[protowire.Number(num)] = protoField{name: tokens[1], typ: tokens[0]}
+		}
+	}
+	if cur != root {
+		return nil, errors.Errorf(`unbalanced braces in schema:\n%s`, schema)
+	}
+	return root, nil
+}
+
+// decode decodes the binary protobuf message into its native Go
+// representation. Fields absent from the message are omitted.
+func (m *protoMessage) decode(b []byte) (map[string]interface{}, error) {
+	native := make(map[string]interface{})
+	for len(b) > 0 {
+		num, wireType, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		field, ok := m.fields[num]
+		if !ok {
+			return nil, errors.Errorf(`unknown field number %d in message %s`, num, m.name)
+		}
+		expectWireType := protowire.BytesType
+		switch field.typ {
+		case `int64`, `bool`:
+			expectWireType = protowire.VarintType
+		case `double`:
+			expectWireType = protowire.Fixed64Type
+		}
+		if wireType != expectWireType {
+			return nil, errors.Errorf(`unexpected wire type %d for field %s.%s`, wireType, m.name, field.name)
+		}
+
+		switch wireType {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			b = b[n:]
+			if field.typ == `bool` {
+				native[field.name] = protowire.DecodeBool(v)
+			} else {
+				native[field.name] = int64(v)
+			}
+		case protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			b = b[n:]
+			native[field.name] = math.Float64frombits(v)
+		default:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			b = b[n:]
+			switch field.typ {
+			case `string`:
+				native[field.name] = string(v)
+			case `bytes`:
+				native[field.name] = v
+			default:
+				nested := m.resolve(field.typ)
+				if nested == nil {
+					return nil, errors.Errorf(`unknown message type %s for field %s.%s`, field.typ, m.name, field.name)
+				}
+				var err error
+				if native[field.name], err = nested.decode(v); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return native, nil
+}
+
+// EncodedProtobufToNative decodes bytes that were previously encoded by the
+// confluent protobuf encoder, into GO native representation. The message type
+// is located in the registered schema using the message index list from the
+// Confluent wire format header.
+func (r *SchemaRegistry) EncodedProtobufToNative(b []byte) (map[string]interface{}, error) {
+	if len(b) == 0 || b[0] != changefeedbase.ConfluentAvroWireFormatMagic {
+		return nil, errors.Errorf(`bad magic byte`)
+	}
+	b = b[1:]
+	if len(b) < 4 {
+		return nil, errors.Errorf(`missing registry id`)
+	}
+	id := int32(binary.BigEndian.Uint32(b[:4]))
+	b = b[4:]
+
+	r.mu.Lock()
+	schema, ok := r.mu.schemas[id]
+	schemaType := r.mu.schemaTypes[id]
+	r.mu.Unlock()
+	if !ok {
+		return nil, errors.Errorf(`unknown registry id %d`, id)
+	}
+	if schemaType != `PROTOBUF` {
+		return nil, errors.Errorf(`registry id %d has schema type %q`, id, schemaType)
+	}
+
+	// The message index list is a zig-zag varint count followed by that many
+	// zig-zag varint indexes. The common case of the first top-level message is
+	// abbreviated to a count of zero.
+	readIndex := func() (int, error) {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			return 0, errors.Wrap(protowire.ParseError(n), `decoding message indexes`)
+		}
+		b = b[n:]
+		return int(protowire.DecodeZigZag(v)), nil
+	}
+	count, err := readIndex()
+	if err != nil {
+		return nil, err
+	}
+	indexes := []int{0}
+	if count > 0 {
+		indexes = indexes[:0]
+		for i := 0; i < count; i++ {
+			idx, err := readIndex()
+			if err != nil {
+				return nil, err
+			}
+			indexes = append(indexes, idx)
+		}
+	}
+
+	msg, err := parseProtoSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, idx := range indexes {
+		if idx < 0 || idx >= len(msg.nested) {
+			return nil, errors.Errorf(`message index %v not found in schema:\n%s`, indexes, schema)
+		}
+		msg = msg.nested[idx]
+	}
+	return msg.decode(b)
+}
+
+// ProtobufToJSON converts protobuf bytes to their JSON representation.
+func (r *SchemaRegistry) ProtobufToJSON(protoBytes []byte) ([]byte, error) {
+	if len(protoBytes) == 0 {
+		return nil, nil
+	}
+	native, err := r.EncodedProtobufToNative(protoBytes)
+	if err != nil {
+		return nil, err
+	}
+	// json.Marshal sorts object keys, so the output is deterministic.
+	return json.Marshal(native)
+}
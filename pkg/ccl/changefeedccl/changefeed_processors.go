@@ -14,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdceval"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcutils"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/checkpoint"
@@ -24,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/closedts"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -522,6 +524,11 @@ func (ca *changeAggregator) makeKVFeedCfg(
 		return kvfeed.Config{}, err
 	}
 
+	var makeRowFilter func(context.Context, hlc.Timestamp) (*kvpb.RangeFeedFilter, error)
+	if ca.spec.Select.Expr != "" && changefeedbase.PushDownExpressionFilters.Get(&cfg.Settings.SV) {
+		makeRowFilter = ca.makeRowFilter
+	}
+
 	return kvfeed.Config{
 		Writer:               buf,
 		Settings:             cfg.Settings,
@@ -538,6 +545,7 @@ func (ca *changeAggregator) makeKVFeedCfg(
 		WithDiff:             filters.WithDiff,
 		WithFiltering:        filters.WithFiltering,
 		WithFrontierQuantize: changefeedbase.Quantize.Get(&cfg.Settings.SV),
+		MakeRowFilter:        makeRowFilter,
 		NeedsInitialScan:     needsInitialScan,
 		SchemaChangeEvents:   schemaChange.EventClass,
 		SchemaChangePolicy:   schemaChange.Policy,
@@ -549,6 +557,32 @@ func (ca *changeAggregator) makeKVFeedCfg(
 	}, nil
 }
 
+// makeRowFilter returns the row filter that pushes the filter and the
+// projection of the changefeed expression down to the rangefeeds and the
+// scans of the kvfeed, using the schema of the target table as of the given
+// timestamp.
+func (ca *changeAggregator) makeRowFilter(
+	ctx context.Context, schemaTS hlc.Timestamp,
+) (*kvpb.RangeFeedFilter, error) {
+	details := ca.spec.Feed
+	// The expressions of the changefeeds created prior to 23.1 are rewritten
+	// by the evaluator; don't bother pushing them down.
+	if details.SessionData == nil || len(details.TargetSpecifications) != 1 {
+		return nil, nil
+	}
+	sc, err := cdceval.ParseChangefeedExpression(ca.spec.Select.Expr)
+	if err != nil {
+		return nil, err
+	}
+	execCfg := ca.FlowCtx.Cfg.ExecutorConfig.(*sql.ExecutorConfig)
+	descs, err := fetchTableDescriptors(ctx, execCfg, AllTargets(details), schemaTS)
+	if err != nil {
+		return nil, err
+	}
+	return cdceval.RowFilterForExpression(
+		execCfg.Codec, descs[0], details.TargetSpecifications[0], sc)
+}
+
 func makeKVFeedMonitoringCfg(
 	ctx context.Context,
 	sliMetrics *sliMetrics,
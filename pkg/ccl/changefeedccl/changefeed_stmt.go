@@ -1046,6 +1046,7 @@ func validateSink(
 			sinkTypePubsub:         {},
 			sinkTypeKafka:          {},
 			sinkTypeWebhook:        {},
+			sinkTypeNATS:           {},
 			sinkTypeSinklessBuffer: {},
 		}
 		if _, ok := allowedSinkTypes[sinkTy]; !ok {
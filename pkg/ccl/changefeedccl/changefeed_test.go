@@ -88,6 +88,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/cidr"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
@@ -6653,7 +6654,7 @@ func TestChangefeedErrors(t *testing.T) {
 		`nodelocal://.`)
 
 	sqlDB.ExpectErrWithTimeout(
-		t, `headers_json_column_name is only usable with format=json/avro`,
+		t, `headers_json_column_name is only usable with format=json/avro/protobuf`,
 		`CREATE CHANGEFEED FOR foo into $1 WITH headers_json_column_name='j', format=csv, initial_scan='only'`,
 		`kafka://nope`)
 
@@ -6662,12 +6663,12 @@ func TestChangefeedErrors(t *testing.T) {
 		`CREATE CHANGEFEED INTO 'null://' WITH envelope=enriched AS SELECT * from foo`,
 	)
 	sqlDB.ExpectErrWithTimeout(
-		t, `envelope=enriched is only usable with format=json/avro`,
+		t, `envelope=enriched is only usable with format=json/avro/protobuf`,
 		`CREATE CHANGEFEED FOR foo INTO 'null://' WITH envelope=enriched, format=csv, initial_scan='only'`,
 	)
 	sqlDB.ExpectErrWithTimeout(
 		// I also would have accepted "this sink is incompatible with envelope=enriched".
-		t, `envelope=enriched is only usable with format=json/avro`,
+		t, `envelope=enriched is only usable with format=json/avro/protobuf`,
 		`CREATE CHANGEFEED FOR foo INTO 'nodelocal://.' WITH envelope=enriched, format=parquet`,
 	)
 	sqlDB.ExpectErrWithTimeout(
@@ -6699,7 +6700,7 @@ func TestChangefeedErrors(t *testing.T) {
 		sqlDB.Exec(t, `PAUSE JOB $1`, jobID)
 		waitForJobState(sqlDB, t, catpb.JobID(jobID), jobs.StatePaused)
 		sqlDB.ExpectErrWithTimeout(
-			t, `envelope=enriched is only usable with format=json/avro`,
+			t, `envelope=enriched is only usable with format=json/avro/protobuf`,
 			`ALTER CHANGEFEED $1 SET format=parquet`, jobIDStr,
 		)
 	})
@@ -9081,6 +9082,83 @@ INSERT INTO foo (a, b, e) VALUES (3, 'tres', 'closed'); -- should be emitted
 	})
 }
 
+// TestChangefeedExpressionPushDown verifies that the rows and the columns that
+// a changefeed expression filters out are omitted by the servers: they never
+// reach the change aggregator, neither during the initial scan nor afterwards.
+func TestChangefeedExpressionPushDown(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	var mu struct {
+		syncutil.Mutex
+		values []roachpb.Value
+	}
+	knobsFn := func(knobs *base.TestingKnobs) {
+		if knobs.DistSQL == nil {
+			knobs.DistSQL = &execinfra.TestingKnobs{}
+		}
+		if knobs.DistSQL.(*execinfra.TestingKnobs).Changefeed == nil {
+			knobs.DistSQL.(*execinfra.TestingKnobs).Changefeed = &TestingKnobs{}
+		}
+		cfKnobs := knobs.DistSQL.(*execinfra.TestingKnobs).Changefeed.(*TestingKnobs)
+		cfKnobs.MakeKVFeedToAggregatorBufferKnobs = func() kvevent.BlockingBufferTestingKnobs {
+			return kvevent.BlockingBufferTestingKnobs{
+				BeforeAdd: func(ctx context.Context, e kvevent.Event) (context.Context, kvevent.Event) {
+					if e.Type() == kvevent.TypeKV && e.KV().Value.IsPresent() {
+						v := e.KV().Value
+						v.RawBytes = append([]byte(nil), v.RawBytes...)
+						mu.Lock()
+						mu.values = append(mu.values, v)
+						mu.Unlock()
+					}
+					return ctx, e
+				},
+			}
+		}
+	}
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c INT)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'one', 1), (2, 'two', 2), (3, 'three', 3)`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED AS SELECT a, c FROM foo WHERE c > 2`)
+		defer closeFeed(t, foo)
+		assertPayloads(t, foo, []string{
+			`foo: [3]->{"a": 3, "c": 3}`,
+		})
+
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (4, 'four', 4), (5, 'five', 0)`)
+		sqlDB.Exec(t, `UPDATE foo SET c = 10 WHERE a = 1`)
+		sqlDB.Exec(t, `UPDATE foo SET b = 'deux' WHERE a = 2`)
+		assertPayloads(t, foo, []string{
+			`foo: [4]->{"a": 4, "c": 4}`,
+			`foo: [1]->{"a": 1, "c": 10}`,
+		})
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.NotEmpty(t, mu.values)
+		for _, v := range mu.values {
+			// The values only contain the column c, and satisfy the predicate.
+			b, err := v.GetTuple()
+			require.NoError(t, err)
+			var colID uint32
+			for len(b) > 0 {
+				_, _, colIDDelta, _, err := encoding.DecodeValueTag(b)
+				require.NoError(t, err)
+				colID += colIDDelta
+				require.Equal(t, uint32(3), colID, "column %d was not projected out", colID)
+				var c int64
+				b, c, err = encoding.DecodeIntValue(b)
+				require.NoError(t, err)
+				require.Greater(t, c, int64(2), "row with c = %d was not filtered out", c)
+			}
+		}
+	}
+	cdcTest(t, testFn, withKnobsFn(knobsFn))
+}
+
 // Some predicates and projections can be verified when creating changefeed.
 // The types of errors that can be detected early on is restricted to simple checks
 // (such as type checking, non-existent columns, etc).  More complex errors detected
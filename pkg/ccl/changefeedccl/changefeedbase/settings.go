@@ -283,6 +283,16 @@ var PerEventElasticCPUControlEnabled = settings.RegisterBoolSetting(
 	true,
 )
 
+// PushDownExpressionFilters determines whether the changefeeds with a CDC
+// query push down its filter and projection to the rangefeeds and scans.
+var PushDownExpressionFilters = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"changefeed.expression_push_down.enabled",
+	"if enabled, the rows and columns that a changefeed query filters out are omitted "+
+		"by the servers, before they're sent to the changefeed",
+	true,
+)
+
 // RequireExternalConnectionSink is used to restrict non-admins with the CHANGEFEED privilege
 // to create changefeeds to external connections only.
 var RequireExternalConnectionSink = settings.RegisterBoolSetting(
@@ -0,0 +1,321 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/protobuf"
+	"github.com/cockroachdb/cockroach/pkg/util/cache"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// confluentProtobufEncoder encodes changefeed entries as protobuf messages
+// using Confluent's wire format for protobuf. Keys are the primary key columns
+// in a record. Values are all columns in a record.
+type confluentProtobufEncoder struct {
+	schemaRegistry                         schemaRegistry
+	schemaPrefix                           string
+	updatedField, beforeField, sourceField bool
+	mvccTimestampField                     bool
+	targets                                changefeedbase.Targets
+	envelopeType                           changefeedbase.EnvelopeType
+	customKeyColumn                        string
+	headersJSONColumnName                  string
+
+	keyCache   *cache.UnorderedCache // [tableIDAndVersion]confluentRegisteredKeyMessage
+	valueCache *cache.UnorderedCache // [tableIDAndVersionPair]confluentRegisteredEnvelopeMessage
+
+	enrichedSourceProvider *enrichedSourceProvider
+
+	// resolvedCache doesn't need to be bounded like the other caches because the number of topics
+	// is fixed per changefeed.
+	resolvedCache map[string]confluentRegisteredEnvelopeMessage
+}
+
+type confluentRegisteredKeyMessage struct {
+	schema     *protobuf.DataMessage
+	registryID int32
+}
+
+type confluentRegisteredEnvelopeMessage struct {
+	schema     *protobuf.EnvelopeMessage
+	registryID int32
+}
+
+var _ Encoder = &confluentProtobufEncoder{}
+
+func newConfluentProtobufEncoder(
+	opts changefeedbase.EncodingOptions,
+	targets changefeedbase.Targets,
+	p externalConnectionProvider,
+	sliMetrics *sliMetrics,
+	enrichedSourceProvider *enrichedSourceProvider,
+) (*confluentProtobufEncoder, error) {
+	e := &confluentProtobufEncoder{
+		schemaPrefix: opts.AvroSchemaPrefix,
+		targets:      targets,
+		envelopeType: opts.Envelope,
+	}
+
+	e.updatedField = opts.UpdatedTimestamps
+	e.beforeField = opts.Diff
+	e.sourceField = inSet(changefeedbase.EnrichedPropertySource, opts.EnrichedProperties)
+	e.customKeyColumn = opts.CustomKeyColumn
+	e.headersJSONColumnName = opts.HeadersJSONColName
+	e.mvccTimestampField = opts.MVCCTimestamps
+	e.enrichedSourceProvider = enrichedSourceProvider
+
+	if opts.KeyInValue {
+		return nil, errors.Errorf(`%s is not supported with %s=%s`,
+			changefeedbase.OptKeyInValue, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+	if opts.TopicInValue {
+		return nil, errors.Errorf(`%s is not supported with %s=%s`,
+			changefeedbase.OptTopicInValue, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+	if len(opts.SchemaRegistryURI) == 0 {
+		return nil, errors.Errorf(`WITH option %s is required for %s=%s`,
+			changefeedbase.OptConfluentSchemaRegistry, changefeedbase.OptFormat, changefeedbase.OptFormatProtobuf)
+	}
+
+	reg, err := newConfluentSchemaRegistry(opts.SchemaRegistryURI, p, sliMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	e.schemaRegistry = reg
+	e.keyCache = cache.NewUnorderedCache(encoderCacheConfig)
+	e.valueCache = cache.NewUnorderedCache(encoderCacheConfig)
+	e.resolvedCache = make(map[string]confluentRegisteredEnvelopeMessage)
+	return e, nil
+}
+
+// EncodeKey implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeKey(
+	ctx context.Context, row cdcevent.Row,
+) ([]byte, error) {
+	// No familyID in the cache key for keys because it's the same schema for all families
+	cacheKey := tableIDAndVersion{tableID: row.TableID, version: row.Version}
+
+	var registered confluentRegisteredKeyMessage
+	v, ok := e.keyCache.Get(cacheKey)
+	if ok {
+		registered = v.(confluentRegisteredKeyMessage)
+		if err := registered.schema.RefreshTypeMetadata(row); err != nil {
+			return nil, err
+		}
+	} else {
+		tableName, err := getTableName(e.targets, e.schemaPrefix, row.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		if e.customKeyColumn == "" {
+			registered.schema, err = protobuf.PrimaryIndexToMessage(row, tableName)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			it, err := row.DatumNamed(e.customKeyColumn)
+			if err != nil {
+				return nil, err
+			}
+			registered.schema, err = protobuf.NewMessageForRow(it, tableName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := changefeedbase.SQLNameToKafkaName(tableName) + confluentSubjectSuffixKey
+		registered.registryID, err = e.register(ctx, &registered.schema.Message, subject)
+		if err != nil {
+			return nil, err
+		}
+		e.keyCache.Add(cacheKey, registered)
+	}
+
+	header := confluentProtobufHeader(registered.registryID)
+	if e.customKeyColumn != "" {
+		it, err := row.DatumNamed(e.customKeyColumn)
+		if err != nil {
+			return nil, err
+		}
+		return registered.schema.BinaryFromRow(header, it)
+	}
+	return registered.schema.BinaryFromRow(header, row.ForEachKeyColumn())
+}
+
+// EncodeValue implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeValue(
+	ctx context.Context, evCtx eventContext, updatedRow cdcevent.Row, prevRow cdcevent.Row,
+) ([]byte, error) {
+	if e.envelopeType == changefeedbase.OptEnvelopeKeyOnly {
+		return nil, nil
+	}
+
+	var cacheKey tableIDAndVersionPair
+	if e.beforeField && prevRow.IsInitialized() {
+		cacheKey[0] = tableIDAndVersion{
+			tableID: prevRow.TableID, version: prevRow.Version, familyID: prevRow.FamilyID,
+		}
+	}
+	cacheKey[1] = tableIDAndVersion{
+		tableID: updatedRow.TableID, version: updatedRow.Version, familyID: updatedRow.FamilyID,
+	}
+
+	var registered confluentRegisteredEnvelopeMessage
+	v, ok := e.valueCache.Get(cacheKey)
+	if ok {
+		registered = v.(confluentRegisteredEnvelopeMessage)
+		if prevRow.IsInitialized() && registered.schema.Before != nil {
+			if err := registered.schema.Before.RefreshTypeMetadata(prevRow); err != nil {
+				return nil, err
+			}
+		}
+		if registered.schema.After != nil {
+			if err := registered.schema.After.RefreshTypeMetadata(updatedRow); err != nil {
+				return nil, err
+			}
+		}
+		if registered.schema.Rec != nil {
+			if err := registered.schema.Rec.RefreshTypeMetadata(updatedRow); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		var beforeDataSchema, afterDataSchema, recordDataSchema *protobuf.DataMessage
+		var sourceDataSchema *protobuf.FunctionalMessage
+		if e.beforeField && prevRow.IsInitialized() {
+			var err error
+			beforeDataSchema, err = protobuf.TableToMessage(prevRow, `before`, e.headersJSONColumnName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		currentSchema, err := protobuf.TableToMessage(updatedRow, protobuf.MessageNoSuffix, e.headersJSONColumnName)
+		if err != nil {
+			return nil, err
+		}
+
+		var opts protobuf.EnvelopeOpts
+
+		// The envelopes mirror the ones produced by format=avro: in the wrapped
+		// envelope, row data goes in the "after" field, and in the bare envelope
+		// it goes in the "record" field. The key_only envelope is handled above.
+		switch e.envelopeType {
+		case changefeedbase.OptEnvelopeWrapped:
+			opts = protobuf.EnvelopeOpts{AfterField: true, BeforeField: e.beforeField, UpdatedField: e.updatedField, MVCCTimestampField: e.mvccTimestampField}
+			afterDataSchema = currentSchema
+		case changefeedbase.OptEnvelopeBare:
+			opts = protobuf.EnvelopeOpts{RecordField: true, UpdatedField: e.updatedField, MVCCTimestampField: e.mvccTimestampField}
+			recordDataSchema = currentSchema
+		case changefeedbase.OptEnvelopeEnriched:
+			afterDataSchema = currentSchema
+			opts = protobuf.EnvelopeOpts{AfterField: true, BeforeField: e.beforeField, OpField: true, TsField: true, SourceField: e.sourceField}
+			if e.sourceField {
+				sourceDataSchema = e.enrichedSourceProvider.GetProtobuf()
+			}
+		default:
+			return nil, errors.AssertionFailedf(`unknown envelope type: %s`, e.envelopeType)
+		}
+
+		name, err := getTableName(e.targets, e.schemaPrefix, updatedRow.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		registered.schema, err = protobuf.NewEnvelopeMessage(name, opts, beforeDataSchema, afterDataSchema, recordDataSchema, sourceDataSchema)
+		if err != nil {
+			return nil, err
+		}
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := changefeedbase.SQLNameToKafkaName(name) + confluentSubjectSuffixValue
+		registered.registryID, err = e.register(ctx, &registered.schema.Message, subject)
+		if err != nil {
+			return nil, err
+		}
+		e.valueCache.Add(cacheKey, registered)
+	}
+
+	meta := protobuf.Metadata{}
+	if registered.schema.Opts.UpdatedField {
+		meta[`updated`] = evCtx.updated
+	}
+	if registered.schema.Opts.MVCCTimestampField {
+		meta[`mvcc_timestamp`] = evCtx.mvcc
+	}
+	if registered.schema.Opts.OpField {
+		meta[`op`] = string(deduceOp(updatedRow, prevRow))
+	}
+	if registered.schema.Opts.TsField {
+		meta[`ts_ns`] = timeutil.Now().UnixNano()
+	}
+
+	header := confluentProtobufHeader(registered.registryID)
+	return registered.schema.BinaryFromRow(header, meta, prevRow, updatedRow, updatedRow)
+}
+
+// EncodeResolvedTimestamp implements the Encoder interface.
+func (e *confluentProtobufEncoder) EncodeResolvedTimestamp(
+	ctx context.Context, topic string, resolved hlc.Timestamp,
+) ([]byte, error) {
+	registered, ok := e.resolvedCache[topic]
+	if !ok {
+		opts := protobuf.EnvelopeOpts{ResolvedField: true}
+		var err error
+		registered.schema, err = protobuf.NewEnvelopeMessage(topic, opts, nil /* before */, nil /* after */, nil /* record */, nil /* source */)
+		if err != nil {
+			return nil, err
+		}
+
+		// NB: This uses the kafka name escaper because it has to match the name
+		// of the kafka topic.
+		subject := changefeedbase.SQLNameToKafkaName(topic) + confluentSubjectSuffixValue
+		registered.registryID, err = e.register(ctx, &registered.schema.Message, subject)
+		if err != nil {
+			return nil, err
+		}
+
+		e.resolvedCache[topic] = registered
+	}
+	meta := protobuf.Metadata{`resolved`: resolved}
+	header := confluentProtobufHeader(registered.registryID)
+	var nilRow cdcevent.Row
+	return registered.schema.BinaryFromRow(header, meta, nilRow, nilRow, nilRow)
+}
+
+func (e *confluentProtobufEncoder) register(
+	ctx context.Context, msg *protobuf.Message, subject string,
+) (int32, error) {
+	return e.schemaRegistry.RegisterTypedSchemaForSubject(
+		ctx, subject, confluentSchemaTypeProtobuf, msg.Schema(e.schemaPrefix))
+}
+
+// confluentProtobufHeader returns the header that precedes every protobuf
+// message in Confluent's wire format: the magic byte, the registered schema
+// ID, and the list of message indexes identifying the message type within the
+// schema. Our schemas always contain exactly one top-level message, which is
+// encoded as the abbreviated list consisting of a single zero byte.
+//
+//	https://docs.confluent.io/platform/current/schema-registry/fundamentals/serdes-develop/index.html#wire-format
+func confluentProtobufHeader(registryID int32) []byte {
+	header := []byte{
+		changefeedbase.ConfluentAvroWireFormatMagic,
+		0, 0, 0, 0, // Placeholder for the ID.
+		0, // Message indexes.
+	}
+	binary.BigEndian.PutUint32(header[1:5], uint32(registryID))
+	return header
+}
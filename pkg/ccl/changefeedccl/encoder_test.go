@@ -9,8 +9,10 @@ import (
 	"context"
 	gosql "database/sql"
 	"encoding/base64"
+	"encoding/binary"
 	gojson "encoding/json"
 	"fmt"
+	"math"
 	"math/rand"
 	"net/url"
 	"strings"
@@ -41,6 +43,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/workload/ledger"
 	"github.com/cockroachdb/cockroach/pkg/workload/workloadsql"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
 )
 
 func TestEncoders(t *testing.T) {
@@ -918,6 +921,287 @@ func TestAvroLedger(t *testing.T) {
 	cdcTest(t, testFn, feedTestForceSink("kafka"))
 }
 
+// protobufToString renders a Confluent protobuf message produced by the
+// protobuf encoder as a compact string. Fields with one of the given numbers
+// are rendered as nested messages.
+func protobufToString(
+	t *testing.T, reg *cdctest.SchemaRegistry, b []byte, nested ...protowire.Number,
+) string {
+	t.Helper()
+	if len(b) == 0 {
+		return ``
+	}
+	require.GreaterOrEqual(t, len(b), 6)
+	require.Equal(t, changefeedbase.ConfluentAvroWireFormatMagic, b[0])
+	id := binary.BigEndian.Uint32(b[1:5])
+	require.Less(t, int(id), reg.RegistrationCount())
+	// Message index list for the first (and only) top-level message.
+	require.Equal(t, byte(0), b[5])
+
+	var render func(b []byte, nested map[protowire.Number]struct{}) string
+	render = func(b []byte, nested map[protowire.Number]struct{}) string {
+		var parts []string
+		for len(b) > 0 {
+			num, typ, n := protowire.ConsumeTag(b)
+			require.GreaterOrEqual(t, n, 0)
+			b = b[n:]
+			switch typ {
+			case protowire.VarintType:
+				v, n := protowire.ConsumeVarint(b)
+				require.GreaterOrEqual(t, n, 0)
+				b = b[n:]
+				parts = append(parts, fmt.Sprintf(`%d:%d`, num, int64(v)))
+			case protowire.Fixed64Type:
+				v, n := protowire.ConsumeFixed64(b)
+				require.GreaterOrEqual(t, n, 0)
+				b = b[n:]
+				parts = append(parts, fmt.Sprintf(`%d:%v`, num, math.Float64frombits(v)))
+			case protowire.BytesType:
+				v, n := protowire.ConsumeBytes(b)
+				require.GreaterOrEqual(t, n, 0)
+				b = b[n:]
+				if _, ok := nested[num]; ok {
+					parts = append(parts, fmt.Sprintf(`%d:%s`, num, render(v, nil)))
+				} else {
+					parts = append(parts, fmt.Sprintf(`%d:%q`, num, v))
+				}
+			default:
+				t.Fatalf(`unexpected wire type %d`, typ)
+			}
+		}
+		return `{` + strings.Join(parts, ` `) + `}`
+	}
+	nestedSet := make(map[protowire.Number]struct{}, len(nested))
+	for _, n := range nested {
+		nestedSet[n] = struct{}{}
+	}
+	return render(b[6:], nestedSet)
+}
+
+func TestProtobufEncoder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	tableDesc, err := parseTableDesc(`CREATE TABLE foo (a INT PRIMARY KEY, b STRING, c FLOAT, d DECIMAL)`)
+	require.NoError(t, err)
+	row := rowenc.EncDatumRow{
+		rowenc.EncDatum{Datum: tree.NewDInt(1)},
+		rowenc.EncDatum{Datum: tree.NewDString(`bar`)},
+		rowenc.EncDatum{Datum: tree.NewDFloat(1.5)},
+		rowenc.EncDatum{Datum: tree.DNull},
+	}
+	ts := hlc.Timestamp{WallTime: 1, Logical: 2}
+	targets := changefeedbase.Targets{}
+	targets.Add(changefeedbase.Target{
+		Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		TableID:           tableDesc.GetID(),
+		StatementTimeName: changefeedbase.StatementTimeName(tableDesc.GetName()),
+	})
+	// Field numbers of the nested row and source messages in the envelope.
+	envelopeNested := []protowire.Number{1, 2, 3, 7}
+
+	for _, tc := range []struct {
+		opts    changefeedbase.EncodingOptions
+		insert  string
+		delete  string
+		message string
+	}{
+		{
+			opts:    changefeedbase.EncodingOptions{Envelope: changefeedbase.OptEnvelopeWrapped},
+			insert:  `{1:1}->{2:{1:1 2:"bar" 3:1.5}}`,
+			delete:  `{1:1}->{}`,
+			message: `foo after = 2;`,
+		},
+		{
+			opts: changefeedbase.EncodingOptions{
+				Envelope: changefeedbase.OptEnvelopeWrapped, Diff: true, UpdatedTimestamps: true,
+			},
+			insert:  `{1:1}->{2:{1:1 2:"bar" 3:1.5} 4:"1.0000000002"}`,
+			delete:  `{1:1}->{1:{1:1 2:"bar" 3:1.5} 4:"1.0000000002"}`,
+			message: `foo_before before = 1;`,
+		},
+		{
+			opts:    changefeedbase.EncodingOptions{Envelope: changefeedbase.OptEnvelopeBare},
+			insert:  `{1:1}->{3:{1:1 2:"bar" 3:1.5}}`,
+			delete:  `{1:1}->{3:{1:1 2:"bar" 3:1.5}}`,
+			message: `foo record = 3;`,
+		},
+		{
+			opts:    changefeedbase.EncodingOptions{Envelope: changefeedbase.OptEnvelopeKeyOnly},
+			insert:  `{1:1}->`,
+			delete:  `{1:1}->`,
+			message: ``,
+		},
+	} {
+		t.Run(string(tc.opts.Envelope), func(t *testing.T) {
+			reg := cdctest.StartTestSchemaRegistry()
+			defer reg.Close()
+			tc.opts.Format = changefeedbase.OptFormatProtobuf
+			tc.opts.SchemaRegistryURI = reg.URL()
+			require.NoError(t, tc.opts.Validate())
+
+			e, err := getEncoder(context.Background(), tc.opts, targets, false, nil, nil, getTestingEnrichedSourceProvider(t, tc.opts))
+			require.NoError(t, err)
+			rowString := func(k, v []byte) string {
+				return protobufToString(t, reg, k) + `->` + protobufToString(t, reg, v, envelopeNested...)
+			}
+			evCtx := eventContext{updated: ts}
+
+			rowInsert := cdcevent.TestingMakeEventRow(tableDesc, 0, row, false)
+			prevRow := cdcevent.TestingMakeEventRow(tableDesc, 0, nil, false)
+			key, err := e.EncodeKey(context.Background(), rowInsert)
+			require.NoError(t, err)
+			key = append([]byte(nil), key...)
+			value, err := e.EncodeValue(context.Background(), evCtx, rowInsert, prevRow)
+			require.NoError(t, err)
+			require.Equal(t, tc.insert, rowString(key, value))
+
+			rowDelete := cdcevent.TestingMakeEventRow(tableDesc, 0, row, true)
+			prevRow = cdcevent.TestingMakeEventRow(tableDesc, 0, row, false)
+			key, err = e.EncodeKey(context.Background(), rowDelete)
+			require.NoError(t, err)
+			key = append([]byte(nil), key...)
+			value, err = e.EncodeValue(context.Background(), evCtx, rowDelete, prevRow)
+			require.NoError(t, err)
+			require.Equal(t, tc.delete, rowString(key, value))
+
+			resolved, err := e.EncodeResolvedTimestamp(context.Background(), tableDesc.GetName(), ts)
+			require.NoError(t, err)
+			require.Equal(t, `{6:"1.0000000002"}`, protobufToString(t, reg, resolved))
+
+			require.Equal(t, `PROTOBUF`, reg.SchemaTypeForSubject(`foo-key`))
+			keySchema := reg.SchemaForSubject(`foo-key`)
+			require.Contains(t, keySchema, `syntax = "proto3";`)
+			require.Contains(t, keySchema, `message foo {`)
+			require.Contains(t, keySchema, `optional int64 a = 1;`)
+			if tc.message != `` {
+				valueSchema := reg.SchemaForSubject(`foo-value`)
+				require.Equal(t, `PROTOBUF`, reg.SchemaTypeForSubject(`foo-value`))
+				require.Contains(t, valueSchema, `message foo_envelope {`)
+				require.Contains(t, valueSchema, `optional string b = 2;`)
+				require.Contains(t, valueSchema, `optional double c = 3;`)
+				require.Contains(t, valueSchema, `optional string d = 4;`)
+				require.Contains(t, valueSchema, tc.message)
+			}
+		})
+	}
+
+	t.Run("enriched", func(t *testing.T) {
+		reg := cdctest.StartTestSchemaRegistry()
+		defer reg.Close()
+		opts := changefeedbase.EncodingOptions{
+			Format:             changefeedbase.OptFormatProtobuf,
+			Envelope:           changefeedbase.OptEnvelopeEnriched,
+			SchemaRegistryURI:  reg.URL(),
+			EnrichedProperties: map[changefeedbase.EnrichedProperty]struct{}{changefeedbase.EnrichedPropertySource: {}},
+		}
+		require.NoError(t, opts.Validate())
+		e, err := getEncoder(context.Background(), opts, targets, false, nil, nil, getTestingEnrichedSourceProvider(t, opts))
+		require.NoError(t, err)
+
+		rowInsert := cdcevent.TestingMakeEventRow(tableDesc, 0, row, false)
+		prevRow := cdcevent.TestingMakeEventRow(tableDesc, 0, nil, false)
+		value, err := e.EncodeValue(context.Background(), eventContext{updated: ts}, rowInsert, prevRow)
+		require.NoError(t, err)
+		rendered := protobufToString(t, reg, value, envelopeNested...)
+		require.True(t, strings.HasPrefix(rendered, `{2:{1:1 2:"bar" 3:1.5} 7:{`), rendered)
+		require.Contains(t, rendered, `9:"u"}`)
+
+		valueSchema := reg.SchemaForSubject(`foo-value`)
+		require.Contains(t, valueSchema, `message source {`)
+		require.Contains(t, valueSchema, `optional string job_id = 1;`)
+		require.Contains(t, valueSchema, `optional int64 ts_ns = 8;`)
+		require.Contains(t, valueSchema, `optional string op = 9;`)
+	})
+}
+
+// TestChangefeedProtobuf runs format=protobuf changefeeds end to end. The
+// kafka test feed decodes every message using the schema registered under the
+// ID in its Confluent header, locating the message type using the header's
+// message index list, so any mismatch between the header, the registered schema
+// and the encoded message fails the feed.
+func TestChangefeedProtobuf(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+
+		t.Run(`wrapped`, func(t *testing.T) {
+			sqlDB.Exec(t, `CREATE TABLE wrapped (a INT PRIMARY KEY, b STRING, c FLOAT, d DECIMAL, e BYTES)`)
+			sqlDB.Exec(t, `INSERT INTO wrapped VALUES (1, 'one', 1.5, 1.25, 'x'), (2, NULL, NULL, NULL, NULL)`)
+			foo := feed(t, f, `CREATE CHANGEFEED FOR wrapped WITH format=protobuf, diff, resolved`)
+			defer closeFeed(t, foo)
+			assertPayloads(t, foo, []string{
+				`wrapped: {"a":1}->{"after":{"a":1,"b":"one","c":1.5,"d":"1.25","e":"eA=="}}`,
+				`wrapped: {"a":2}->{"after":{"a":2}}`,
+			})
+			sqlDB.Exec(t, `UPDATE wrapped SET b = 'uno' WHERE a = 1`)
+			sqlDB.Exec(t, `DELETE FROM wrapped WHERE a = 2`)
+			assertPayloads(t, foo, []string{
+				`wrapped: {"a":1}->{"after":{"a":1,"b":"uno","c":1.5,"d":"1.25","e":"eA=="},"before":{"a":1,"b":"one","c":1.5,"d":"1.25","e":"eA=="}}`,
+				`wrapped: {"a":2}->{"before":{"a":2}}`,
+			})
+			expectResolvedTimestamp(t, foo)
+
+			reg := foo.(*kafkaFeed).registry
+			require.Equal(t, `PROTOBUF`, reg.SchemaTypeForSubject(`wrapped-key`))
+			require.Equal(t, `PROTOBUF`, reg.SchemaTypeForSubject(`wrapped-value`))
+		})
+
+		t.Run(`key_only`, func(t *testing.T) {
+			sqlDB.Exec(t, `CREATE TABLE key_only (a INT PRIMARY KEY, b STRING)`)
+			sqlDB.Exec(t, `INSERT INTO key_only VALUES (1, 'one')`)
+			foo := feed(t, f, `CREATE CHANGEFEED FOR key_only WITH format=protobuf, envelope=key_only`)
+			defer closeFeed(t, foo)
+			assertPayloads(t, foo, []string{
+				`key_only: {"a":1}->`,
+			})
+		})
+
+		t.Run(`enriched`, func(t *testing.T) {
+			sqlDB.Exec(t, `CREATE TABLE enriched (a INT PRIMARY KEY, b STRING)`)
+			sqlDB.Exec(t, `INSERT INTO enriched VALUES (1, 'one')`)
+			foo := feed(t, f, `CREATE CHANGEFEED FOR enriched WITH format=protobuf, envelope=enriched, enriched_properties='source'`)
+			defer closeFeed(t, foo)
+			assertPayloadsEnriched(t, foo, []string{
+				`enriched: {"a":1}->{"after": {"a": 1, "b": "one"}, "op": "c"}`,
+			}, func(source map[string]any) {
+				require.NotNil(t, source)
+				require.NotEmpty(t, source[`job_id`])
+			})
+		})
+
+		t.Run(`bare`, func(t *testing.T) {
+			// CDC queries don't carry column IDs, so the fields are numbered by
+			// position. Adding a column renumbers the fields that follow it, and
+			// each message must be decoded using the schema it was encoded with.
+			sqlDB.Exec(t, `CREATE TABLE bare (a INT PRIMARY KEY, b STRING)`)
+			sqlDB.Exec(t, `INSERT INTO bare VALUES (1, 'one')`)
+			foo := feed(t, f, `CREATE CHANGEFEED WITH format=protobuf AS SELECT *, event_op() AS op FROM bare`)
+			defer closeFeed(t, foo)
+			assertPayloads(t, foo, []string{
+				`bare: {"a":1}->{"record":{"a":1,"b":"one","op":"insert"}}`,
+			})
+			reg := foo.(*kafkaFeed).registry
+			valueSchema := reg.SchemaForSubject(`bare-value`)
+			require.Contains(t, valueSchema, `bare record = 3;`)
+			require.Contains(t, valueSchema, `optional string op = 3;`)
+
+			sqlDB.Exec(t, `ALTER TABLE bare ADD COLUMN c STRING`)
+			sqlDB.Exec(t, `INSERT INTO bare VALUES (2, 'two', 'dos')`)
+			assertPayloads(t, foo, []string{
+				`bare: {"a":2}->{"record":{"a":2,"b":"two","c":"dos","op":"insert"}}`,
+			})
+			valueSchema = reg.SchemaForSubject(`bare-value`)
+			require.Contains(t, valueSchema, `optional string c = 3;`)
+			require.Contains(t, valueSchema, `optional string op = 4;`)
+		})
+	}
+
+	cdcTest(t, testFn, feedTestForceSink("kafka"))
+}
+
 func BenchmarkEncoders(b *testing.B) {
 	rng := randutil.NewTestRandWithSeed(2365865412074131521)
 
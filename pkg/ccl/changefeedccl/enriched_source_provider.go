@@ -16,11 +16,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
 	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kcjsonschema"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/protobuf"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
 	"github.com/linkedin/goavro/v2"
 )
 
@@ -169,6 +171,29 @@ func (p *enrichedSourceProvider) GetAvro(
 	return sourceDataSchema, nil
 }
 
+// GetProtobuf returns a protobuf FunctionalMessage for the source data.
+func (p *enrichedSourceProvider) GetProtobuf() *protobuf.FunctionalMessage {
+	fromRow := func(row cdcevent.Row, dest map[string]string) {
+		// If this is the first use of the message (ie the first row the encoder processed), set the fixed fields.
+		if len(dest) == 0 {
+			dest[fieldNameJobID] = p.sourceData.jobID
+			dest[fieldNameChangefeedSink] = p.sourceData.sink
+			dest[fieldNameDBVersion] = p.sourceData.dbVersion
+			dest[fieldNameClusterName] = p.sourceData.clusterName
+			dest[fieldNameClusterID] = p.sourceData.clusterID
+			dest[fieldNameSourceNodeLocality] = p.sourceData.sourceNodeLocality
+			dest[fieldNameNodeName] = p.sourceData.nodeName
+			dest[fieldNameNodeID] = p.sourceData.nodeID
+		}
+
+		if p.opts.mvccTimestamp {
+			dest[fieldNameMVCCTimestamp] = row.MvccTimestamp.AsOfSystemTime()
+		}
+		// TODO(#139661): Add other non fixed fields.
+	}
+	return protobuf.NewFunctionalMessage("source", protobufFields, fromRow)
+}
+
 const (
 	fieldNameJobID              = "job_id"
 	fieldNameChangefeedSink     = "changefeed_sink"
@@ -184,10 +209,15 @@ const (
 type fieldInfo struct {
 	avroSchemaField    avro.SchemaField
 	kafkaConnectSchema kcjsonschema.Schema
+	// protobufFieldNumber is the number of the field in the protobuf source
+	// message. Field numbers are part of the protobuf schema, so they must never
+	// be changed or reused once assigned.
+	protobufFieldNumber int
 }
 
 // allFieldInfo contains all the fields that are part of the source data, and is
-// used to build the avro schema and the kafka connect json schema. Note that
+// used to build the avro schema, the kafka connect json schema and the protobuf
+// message. Note that
 // everything is nullable in avro for better backwards compatibility, whereas we
 // use the optional flag in kafka connect more meaningfully.
 var allFieldInfo = map[string]fieldInfo{
@@ -201,6 +231,7 @@ var allFieldInfo = map[string]fieldInfo{
 			TypeName: kcjsonschema.SchemaTypeString,
 			Optional: false,
 		},
+		protobufFieldNumber: 2,
 	},
 	fieldNameJobID: {
 		avroSchemaField: avro.SchemaField{
@@ -212,6 +243,7 @@ var allFieldInfo = map[string]fieldInfo{
 			TypeName: kcjsonschema.SchemaTypeString,
 			Optional: false,
 		},
+		protobufFieldNumber: 1,
 	},
 	fieldNameDBVersion: {
 		avroSchemaField: avro.SchemaField{
@@ -223,6 +255,7 @@ var allFieldInfo = map[string]fieldInfo{
 			TypeName: kcjsonschema.SchemaTypeString,
 			Optional: false,
 		},
+		protobufFieldNumber: 3,
 	},
 	fieldNameClusterName: {
 		avroSchemaField: avro.SchemaField{
@@ -234,6 +267,7 @@ var allFieldInfo = map[string]fieldInfo{
 			TypeName: kcjsonschema.SchemaTypeString,
 			Optional: false,
 		},
+		protobufFieldNumber: 4,
 	},
 	fieldNameClusterID: {
 		avroSchemaField: avro.SchemaField{
@@ -245,6 +279,7 @@ var allFieldInfo = map[string]fieldInfo{
 			TypeName: kcjsonschema.SchemaTypeString,
 			Optional: false,
 		},
+		protobufFieldNumber: 5,
 	},
 	fieldNameSourceNodeLocality: {
 		avroSchemaField: avro.SchemaField{
@@ -256,6 +291,7 @@ var allFieldInfo = map[string]fieldInfo{
 			TypeName: kcjsonschema.SchemaTypeString,
 			Optional: false,
 		},
+		protobufFieldNumber: 6,
 	},
 	fieldNameNodeName: {
 		avroSchemaField: avro.SchemaField{
@@ -267,6 +303,7 @@ var allFieldInfo = map[string]fieldInfo{
 			TypeName: kcjsonschema.SchemaTypeString,
 			Optional: false,
 		},
+		protobufFieldNumber: 7,
 	},
 	fieldNameNodeID: {
 		avroSchemaField: avro.SchemaField{
@@ -278,6 +315,7 @@ var allFieldInfo = map[string]fieldInfo{
 			TypeName: kcjsonschema.SchemaTypeString,
 			Optional: false,
 		},
+		protobufFieldNumber: 8,
 	},
 	fieldNameMVCCTimestamp: {
 		avroSchemaField: avro.SchemaField{
@@ -289,6 +327,7 @@ var allFieldInfo = map[string]fieldInfo{
 			TypeName: kcjsonschema.SchemaTypeString,
 			Optional: true,
 		},
+		protobufFieldNumber: 9,
 	},
 }
 
@@ -298,6 +337,9 @@ var avroFields []*avro.SchemaField
 // filled in by init() using allFieldInfo
 var jsonFields []string
 
+// filled in by init() using allFieldInfo, in protobuf field number order
+var protobufFields []string
+
 // filled in by init() using allFieldInfo
 var kafkaConnectJSONSchema kcjsonschema.Schema
 
@@ -309,6 +351,16 @@ func init() {
 		jsonFields = append(jsonFields, info.kafkaConnectSchema.Field)
 	}
 
+	protobufFields = make([]string, len(allFieldInfo))
+	for name, info := range allFieldInfo {
+		i := info.protobufFieldNumber - 1
+		if i < 0 || i >= len(protobufFields) || protobufFields[i] != "" {
+			panic(errors.AssertionFailedf("invalid protobuf field number %d for source field %s",
+				info.protobufFieldNumber, name))
+		}
+		protobufFields[i] = name
+	}
+
 	kafkaConnectJSONSchema = kcjsonschema.Schema{
 		Name:     "cockroachdb.source",
 		TypeName: kcjsonschema.SchemaTypeStruct,
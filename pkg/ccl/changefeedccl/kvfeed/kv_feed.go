@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
@@ -96,6 +97,13 @@ type Config struct {
 	// granularity.
 	WithFrontierQuantize time.Duration
 
+	// MakeRowFilter, if set, returns the row filter that the rangefeeds and the
+	// scans of the feed send to the server, using the schema as of the given
+	// timestamp. It's called again every time the feed is restarted after a
+	// schema change. The filter is an optimization: it may return a nil filter,
+	// and the events that reach the writer may still not satisfy it.
+	MakeRowFilter func(ctx context.Context, schemaTS hlc.Timestamp) (*kvpb.RangeFeedFilter, error)
+
 	// Knobs are kvfeed testing knobs.
 	Knobs TestingKnobs
 
@@ -139,6 +147,7 @@ func Run(ctx context.Context, cfg Config) error {
 		cfg.SchemaFeed,
 		sc, pff, bf, cfg.Targets, cfg.ScopedTimers, cfg.Knobs)
 	f.onBackfillCallback = cfg.MonitoringCfg.OnBackfillCallback
+	f.makeRowFilter = cfg.MakeRowFilter
 	f.rangeObserver = startLaggingRangesObserver(g, cfg.MonitoringCfg.LaggingRangesCallback,
 		cfg.MonitoringCfg.LaggingRangesPollingInterval, cfg.MonitoringCfg.LaggingRangesThreshold)
 
@@ -267,6 +276,7 @@ type kvFeed struct {
 
 	onBackfillCallback func() func()
 	rangeObserver      kvcoord.RangeObserver
+	makeRowFilter      func(context.Context, hlc.Timestamp) (*kvpb.RangeFeedFilter, error)
 	schemaChangeEvents changefeedbase.SchemaChangeEventClass
 	schemaChangePolicy changefeedbase.SchemaChangePolicy
 
@@ -561,6 +571,7 @@ func (f *kvFeed) scanIfShould(
 		Spans:     spansToBackfill,
 		Timestamp: scanTime,
 		WithDiff:  !isInitialScan && f.withDiff,
+		RowFilter: f.rowFilter(ctx, scanTime),
 		Knobs:     f.knobs,
 		Boundary:  boundaryType,
 	}); err != nil {
@@ -572,6 +583,21 @@ func (f *kvFeed) scanIfShould(
 	return spansToScan, scanTime, nil
 }
 
+// rowFilter returns the row filter to use for the scans and the rangefeeds
+// that start at the given timestamp, if any. Since the filter is only an
+// optimization, the feed runs without it if it can't be built.
+func (f *kvFeed) rowFilter(ctx context.Context, schemaTS hlc.Timestamp) *kvpb.RangeFeedFilter {
+	if f.makeRowFilter == nil {
+		return nil
+	}
+	filter, err := f.makeRowFilter(ctx, schemaTS)
+	if err != nil {
+		log.Warningf(ctx, "running kv feed without row filter: %v", err)
+		return nil
+	}
+	return filter
+}
+
 // runUntilTableEvent starts rangefeeds for the spans being watched by
 // the kv feed and runs until a table event (schema change) is encountered.
 //
@@ -611,6 +637,7 @@ func (f *kvFeed) runUntilTableEvent(ctx context.Context, resumeFrontier span.Fro
 		WithFiltering:        f.withFiltering,
 		WithFrontierQuantize: f.withFrontierQuantize,
 		ConsumerID:           f.consumerID,
+		RowFilter:            f.rowFilter(ctx, startFrom),
 		Knobs:                f.knobs,
 		Timers:               f.timers,
 		RangeObserver:        f.rangeObserver,
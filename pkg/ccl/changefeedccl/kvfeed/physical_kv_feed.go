@@ -34,6 +34,7 @@ type rangeFeedConfig struct {
 	WithFiltering        bool
 	WithFrontierQuantize time.Duration
 	ConsumerID           int64
+	RowFilter            *kvpb.RangeFeedFilter
 	RangeObserver        kvcoord.RangeObserver
 	Knobs                TestingKnobs
 	Timers               *timers.ScopedTimers
@@ -102,6 +103,9 @@ func (p rangefeedFactory) Run(ctx context.Context, sink kvevent.Writer, cfg rang
 	if cfg.ConsumerID != 0 {
 		rfOpts = append(rfOpts, kvcoord.WithConsumerID(cfg.ConsumerID))
 	}
+	if cfg.RowFilter != nil {
+		rfOpts = append(rfOpts, kvcoord.WithRowFilter(cfg.RowFilter))
+	}
 	if len(cfg.Knobs.RangefeedOptions) != 0 {
 		rfOpts = append(rfOpts, cfg.Knobs.RangefeedOptions...)
 	}
@@ -37,6 +37,7 @@ type scanConfig struct {
 	Spans     []roachpb.Span
 	Timestamp hlc.Timestamp
 	WithDiff  bool
+	RowFilter *kvpb.RangeFeedFilter
 	Knobs     TestingKnobs
 	Boundary  jobspb.ResolvedSpan_BoundaryType
 }
@@ -109,7 +110,7 @@ func (p *scanRequestScanner) Scan(ctx context.Context, sink kvevent.Writer, cfg
 			}
 			defer spanAlloc.Release(ctx)
 
-			err = p.exportSpan(ctx, span, cfg.Timestamp, cfg.Boundary, cfg.WithDiff, sink, cfg.Knobs)
+			err = p.exportSpan(ctx, span, cfg.Timestamp, cfg.Boundary, cfg.WithDiff, cfg.RowFilter, sink, cfg.Knobs)
 			finished := atomic.AddInt64(&atomicFinished, 1)
 			if backfillDec != nil {
 				backfillDec()
@@ -170,6 +171,7 @@ func (p *scanRequestScanner) exportSpan(
 	ts hlc.Timestamp,
 	boundaryType jobspb.ResolvedSpan_BoundaryType,
 	withDiff bool,
+	rowFilter *kvpb.RangeFeedFilter,
 	sink kvevent.Writer,
 	knobs TestingKnobs,
 ) error {
@@ -188,6 +190,7 @@ func (p *scanRequestScanner) exportSpan(
 		b := txn.NewBatch()
 		r := kvpb.NewScan(remaining.Key, remaining.EndKey).(*kvpb.ScanRequest)
 		r.ScanFormat = kvpb.BATCH_RESPONSE
+		r.RowFilter = rowFilter
 		b.Header.TargetBytes = targetBytesPerScan
 		b.Header.ConnectionClass = rpc.RangefeedClass
 		b.AdmissionHeader = kvpb.AdmissionHeader{
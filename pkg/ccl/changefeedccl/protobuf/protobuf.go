@@ -0,0 +1,613 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package protobuf
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The file contains a very specific marriage between protobuf and our SQL
+// schemas. It's not intended to be a general purpose protobuf utility.
+//
+// We map a SQL row to a proto3 message with a 1:1 mapping between columns and
+// message fields. Types with a natural protobuf representation (bools,
+// integers, floats, strings and bytes) use it; everything else is carried as
+// its SQL text representation in a string field. Every scalar field is
+// declared `optional` so that SQL NULLs can be told apart from zero values,
+// which, like the all-nullable Avro schemas, also keeps adjacent schemas for a
+// table compatible with each other.
+//
+// Field numbers are taken from the column IDs of the underlying table when
+// they are available, so that dropping or adding a column never renumbers the
+// remaining fields. The SQL column type is recorded as a comment in the
+// generated .proto so that the original schema can be partially recovered.
+//
+// Messages are registered with a Confluent-compatible schema registry as
+// PROTOBUF schemas. Each registered schema contains a single top-level message
+// with the row messages nested inside it, which lets the wire format use the
+// abbreviated single-zero-byte message index list.
+
+// FieldType is one of the protobuf scalar types used to carry SQL values, or
+// TypeMessage for nested messages.
+type FieldType int
+
+const (
+	// TypeString is the protobuf string type.
+	TypeString FieldType = iota
+	// TypeBool is the protobuf bool type.
+	TypeBool
+	// TypeInt64 is the protobuf int64 type.
+	TypeInt64
+	// TypeDouble is the protobuf double type.
+	TypeDouble
+	// TypeBytes is the protobuf bytes type.
+	TypeBytes
+	// TypeMessage is a nested message.
+	TypeMessage
+)
+
+func (t FieldType) wireType() protowire.Type {
+	switch t {
+	case TypeBool, TypeInt64:
+		return protowire.VarintType
+	case TypeDouble:
+		return protowire.Fixed64Type
+	default:
+		return protowire.BytesType
+	}
+}
+
+// minReservedFieldNumber and maxReservedFieldNumber delimit the range of field
+// numbers that protobuf reserves for its own implementation.
+const (
+	minReservedFieldNumber = 19000
+	maxReservedFieldNumber = 19999
+)
+
+type encodeFn func(buf []byte, datum tree.Datum) []byte
+
+// Field is our representation of the schema of a field in a protobuf message.
+type Field struct {
+	Name   string
+	Number protowire.Number
+	Type   FieldType
+	// Message is set iff Type is TypeMessage.
+	Message *Message
+	// Comment, if set, is emitted alongside the field in the .proto schema.
+	Comment string
+
+	typ      *types.T
+	encodeFn encodeFn
+}
+
+// Message is our representation of the schema of a protobuf message.
+type Message struct {
+	Name   string
+	Fields []*Field
+}
+
+// writeSchema writes the .proto definition of the message, including any
+// messages nested inside of it, at the given indentation level.
+func (m *Message) writeSchema(sb *strings.Builder, indent string) {
+	fmt.Fprintf(sb, "%smessage %s {\n", indent, m.Name)
+	seen := make(map[*Message]struct{})
+	for _, f := range m.Fields {
+		if f.Message == nil {
+			continue
+		}
+		if _, ok := seen[f.Message]; ok {
+			continue
+		}
+		seen[f.Message] = struct{}{}
+		f.Message.writeSchema(sb, indent+"  ")
+	}
+	for _, f := range m.Fields {
+		sb.WriteString(indent + "  ")
+		switch f.Type {
+		case TypeMessage:
+			fmt.Fprintf(sb, "%s %s = %d;", f.Message.Name, f.Name, f.Number)
+		default:
+			fmt.Fprintf(sb, "optional %s %s = %d;", f.Type.protoName(), f.Name, f.Number)
+		}
+		if f.Comment != `` {
+			fmt.Fprintf(sb, " // %s", f.Comment)
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(sb, "%s}\n", indent)
+}
+
+// Schema returns the message as the contents of a proto3 .proto file,
+// suitable for registering with a schema registry.
+func (m *Message) Schema(namespace string) string {
+	var sb strings.Builder
+	sb.WriteString("syntax = \"proto3\";\n")
+	if namespace != `` {
+		fmt.Fprintf(&sb, "package %s;\n", changefeedbase.SQLNameToAvroName(namespace))
+	}
+	sb.WriteString("\n")
+	m.writeSchema(&sb, ``)
+	return sb.String()
+}
+
+func (t FieldType) protoName() string {
+	switch t {
+	case TypeString:
+		return `string`
+	case TypeBool:
+		return `bool`
+	case TypeInt64:
+		return `int64`
+	case TypeDouble:
+		return `double`
+	case TypeBytes:
+		return `bytes`
+	default:
+		panic(errors.AssertionFailedf("unexpected protobuf field type %d", t))
+	}
+}
+
+// DataMessage is a `Message` that represents the schema of a SQL table or
+// index.
+type DataMessage struct {
+	Message
+
+	fieldIdxByName map[string]int
+	omitColumn     string
+	// Reuse this buffer to avoid repeated allocation when encoding nested
+	// messages.
+	scratch []byte
+}
+
+// typeToField converts a database type to a protobuf field.
+func typeToField(typ *types.T) *Field {
+	f := &Field{typ: typ}
+	switch typ.Family() {
+	case types.BoolFamily:
+		f.Type = TypeBool
+		f.encodeFn = func(buf []byte, d tree.Datum) []byte {
+			return protowire.AppendVarint(buf, protowire.EncodeBool(bool(*d.(*tree.DBool))))
+		}
+	case types.IntFamily:
+		f.Type = TypeInt64
+		f.encodeFn = func(buf []byte, d tree.Datum) []byte {
+			return protowire.AppendVarint(buf, uint64(int64(*d.(*tree.DInt))))
+		}
+	case types.FloatFamily:
+		f.Type = TypeDouble
+		f.encodeFn = func(buf []byte, d tree.Datum) []byte {
+			return protowire.AppendFixed64(buf, math.Float64bits(float64(*d.(*tree.DFloat))))
+		}
+	case types.StringFamily:
+		f.Type = TypeString
+		f.encodeFn = func(buf []byte, d tree.Datum) []byte {
+			return protowire.AppendString(buf, string(tree.MustBeDString(d)))
+		}
+	case types.CollatedStringFamily:
+		f.Type = TypeString
+		f.encodeFn = func(buf []byte, d tree.Datum) []byte {
+			return protowire.AppendString(buf, d.(*tree.DCollatedString).Contents)
+		}
+	case types.BytesFamily:
+		f.Type = TypeBytes
+		f.encodeFn = func(buf []byte, d tree.Datum) []byte {
+			return protowire.AppendBytes(buf, []byte(*d.(*tree.DBytes)))
+		}
+	case types.UuidFamily:
+		f.Type = TypeString
+		f.encodeFn = func(buf []byte, d tree.Datum) []byte {
+			return protowire.AppendString(buf, d.(*tree.DUuid).UUID.String())
+		}
+	case types.EnumFamily:
+		f.Type = TypeString
+		f.encodeFn = func(buf []byte, d tree.Datum) []byte {
+			return protowire.AppendString(buf, d.(*tree.DEnum).LogicalRep)
+		}
+	case types.JsonFamily:
+		f.Type = TypeString
+		f.encodeFn = func(buf []byte, d tree.Datum) []byte {
+			return protowire.AppendString(buf, d.(*tree.DJSON).JSON.String())
+		}
+	default:
+		// Everything else (decimals, temporal types, arrays, geospatial types,
+		// etc.) is encoded using its text representation. The text format is
+		// the same one used by format=csv.
+		f.Type = TypeString
+		fmtCtx := tree.NewFmtCtx(tree.FmtExport)
+		f.encodeFn = func(buf []byte, d tree.Datum) []byte {
+			fmtCtx.Reset()
+			fmtCtx.FormatNode(d)
+			return protowire.AppendString(buf, fmtCtx.String())
+		}
+	}
+	return f
+}
+
+// NewMessageForRow constructs a protobuf message for the Row. Only columns
+// returned by the Iterator are used to populate message fields. sqlName can
+// be any string but should uniquely identify a schema.
+func NewMessageForRow(it cdcevent.Iterator, sqlName string) (*DataMessage, error) {
+	msg := &DataMessage{
+		Message:        Message{Name: changefeedbase.SQLNameToAvroName(sqlName)},
+		fieldIdxByName: make(map[string]int),
+	}
+
+	// Prefer the column IDs of the underlying table as field numbers. If any
+	// column isn't a simple reference to a table column (e.g. a projection in a
+	// CDC query), fall back to positional field numbers.
+	useColumnIDs := true
+	seen := make(map[uint32]struct{})
+	if err := it.Col(func(col cdcevent.ResultColumn) error {
+		n := col.PGAttributeNum
+		if _, dup := seen[n]; dup || n == 0 ||
+			(n >= minReservedFieldNumber && n <= maxReservedFieldNumber) ||
+			n > uint32(protowire.MaxValidNumber) {
+			useColumnIDs = false
+		}
+		seen[n] = struct{}{}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := it.Col(func(col cdcevent.ResultColumn) error {
+		field := typeToField(col.Typ)
+		field.Name = changefeedbase.SQLNameToAvroName(col.Name)
+		field.Comment = col.SQLStringNotHumanReadable()
+		if useColumnIDs {
+			field.Number = protowire.Number(col.PGAttributeNum)
+		} else {
+			field.Number = protowire.Number(len(msg.Fields) + 1)
+			if field.Number >= minReservedFieldNumber {
+				field.Number += maxReservedFieldNumber - minReservedFieldNumber + 1
+			}
+		}
+		msg.fieldIdxByName[col.Name] = len(msg.Fields)
+		msg.Fields = append(msg.Fields, field)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// PrimaryIndexToMessage constructs a protobuf message for the primary index.
+func PrimaryIndexToMessage(row cdcevent.Row, sqlName string) (*DataMessage, error) {
+	return NewMessageForRow(row.ForEachKeyColumn(), sqlName)
+}
+
+const (
+	// MessageNoSuffix can be passed to TableToMessage to indicate that no
+	// suffix should be appended to the message's name.
+	MessageNoSuffix = ``
+)
+
+// TableToMessage constructs a protobuf message for the event values. If a
+// name suffix is provided (as opposed to MessageNoSuffix), it will be appended
+// to the end of the message's name.
+func TableToMessage(row cdcevent.Row, nameSuffix string, omitColumn string) (*DataMessage, error) {
+	var sqlName string
+	// For consistency with the avro encoder, schemas for tables with only one
+	// family don't get family-specific names.
+	if row.HasOtherFamilies {
+		sqlName = row.TableName + "." + row.FamilyName
+	} else {
+		sqlName = row.TableName
+	}
+	if nameSuffix != MessageNoSuffix {
+		sqlName = sqlName + `_` + nameSuffix
+	}
+
+	it := row.ForEachColumn()
+	if omitColumn != `` {
+		it = cdcevent.NewSkipIterator(it, omitColumn)
+	}
+	msg, err := NewMessageForRow(it, sqlName)
+	if err != nil {
+		return nil, err
+	}
+	msg.omitColumn = omitColumn
+	return msg, nil
+}
+
+// BinaryFromRow appends the protobuf binary encoding of the given row data to
+// buf.
+func (m *DataMessage) BinaryFromRow(buf []byte, it cdcevent.Iterator) ([]byte, error) {
+	if m.omitColumn != `` {
+		it = cdcevent.NewSkipIterator(it, m.omitColumn)
+	}
+	if err := it.Datum(func(d tree.Datum, col cdcevent.ResultColumn) error {
+		fieldIdx, ok := m.fieldIdxByName[col.Name]
+		if !ok {
+			return changefeedbase.WithTerminalError(
+				errors.AssertionFailedf("could not find protobuf field for column %s", col.Name))
+		}
+		if d == tree.DNull {
+			// Absent optional fields decode as NULL.
+			return nil
+		}
+		field := m.Fields[fieldIdx]
+		buf = protowire.AppendTag(buf, field.Number, field.Type.wireType())
+		buf = field.encodeFn(buf, d)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// appendNested appends the row as a length-delimited nested message field.
+func (m *DataMessage) appendNested(
+	buf []byte, num protowire.Number, it cdcevent.Iterator,
+) ([]byte, error) {
+	var err error
+	m.scratch, err = m.BinaryFromRow(m.scratch[:0], it)
+	if err != nil {
+		return nil, err
+	}
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendBytes(buf, m.scratch), nil
+}
+
+// RefreshTypeMetadata refreshes the metadata for user-defined types on a
+// cached schema. Enums are encoded as their logical representation, so this
+// only needs to keep the recorded types up to date.
+func (m *DataMessage) RefreshTypeMetadata(row cdcevent.Row) error {
+	return row.ForEachUDTColumn().Col(func(col cdcevent.ResultColumn) error {
+		if fieldIdx, ok := m.fieldIdxByName[col.Name]; ok {
+			m.Fields[fieldIdx].typ = col.Typ
+		}
+		return nil
+	})
+}
+
+type functionalBinaryFromRowFn func(row cdcevent.Row, dest map[string]string)
+
+// FunctionalMessage is a message of optional string fields whose values are
+// computed from a row by a function, rather than taken from its columns.
+type FunctionalMessage struct {
+	Message
+
+	binaryFromRowFn functionalBinaryFromRowFn
+	// Reuse these to avoid repeated allocation when encoding.
+	values  map[string]string
+	scratch []byte
+}
+
+// NewFunctionalMessage creates a FunctionalMessage with the given string
+// fields. Field numbers are assigned by position, so new fields must only ever
+// be appended to fieldNames.
+func NewFunctionalMessage(
+	name string, fieldNames []string, binaryFromRowFn functionalBinaryFromRowFn,
+) *FunctionalMessage {
+	msg := &FunctionalMessage{
+		Message:         Message{Name: name},
+		binaryFromRowFn: binaryFromRowFn,
+		values:          make(map[string]string, len(fieldNames)),
+	}
+	for i, n := range fieldNames {
+		msg.Fields = append(msg.Fields, &Field{
+			Name:   n,
+			Number: protowire.Number(i + 1),
+			Type:   TypeString,
+		})
+	}
+	return msg
+}
+
+func (m *FunctionalMessage) appendNested(
+	buf []byte, num protowire.Number, row cdcevent.Row,
+) []byte {
+	m.binaryFromRowFn(row, m.values)
+	m.scratch = m.scratch[:0]
+	for _, f := range m.Fields {
+		if v, ok := m.values[f.Name]; ok {
+			m.scratch = protowire.AppendTag(m.scratch, f.Number, protowire.BytesType)
+			m.scratch = protowire.AppendString(m.scratch, v)
+		}
+	}
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendBytes(buf, m.scratch)
+}
+
+// Metadata is the `EnvelopeMessage` metadata.
+type Metadata map[string]interface{}
+
+// EnvelopeOpts controls which fields in EnvelopeMessage are set.
+type EnvelopeOpts struct {
+	BeforeField, AfterField, RecordField bool
+	UpdatedField, ResolvedField          bool
+	MVCCTimestampField                   bool
+	OpField, TsField, SourceField        bool
+}
+
+// Field numbers of the envelope fields. These are fixed regardless of which
+// fields are enabled so that consumers can rely on them.
+const (
+	envelopeFieldBefore protowire.Number = iota + 1
+	envelopeFieldAfter
+	envelopeFieldRecord
+	envelopeFieldUpdated
+	envelopeFieldMVCCTimestamp
+	envelopeFieldResolved
+	envelopeFieldSource
+	envelopeFieldTsNs
+	envelopeFieldOp
+)
+
+// EnvelopeMessage is a `Message` that wraps a changed SQL row and some
+// metadata.
+type EnvelopeMessage struct {
+	Message
+
+	Opts               EnvelopeOpts
+	Before, After, Rec *DataMessage
+	Source             *FunctionalMessage
+}
+
+// NewEnvelopeMessage creates a protobuf message schema for an envelope
+// containing before and after versions of a row change and metadata about
+// that row change. before is optional, and after can instead be record.
+func NewEnvelopeMessage(
+	topic string, opts EnvelopeOpts, before, after, record *DataMessage, source *FunctionalMessage,
+) (*EnvelopeMessage, error) {
+	msg := &EnvelopeMessage{
+		Message: Message{Name: changefeedbase.SQLNameToAvroName(topic) + `_envelope`},
+		Opts:    opts,
+	}
+	addMessageField := func(name string, num protowire.Number, m *Message) {
+		msg.Fields = append(msg.Fields, &Field{Name: name, Number: num, Type: TypeMessage, Message: m})
+	}
+	addScalarField := func(name string, num protowire.Number, typ FieldType) {
+		msg.Fields = append(msg.Fields, &Field{Name: name, Number: num, Type: typ})
+	}
+
+	if opts.BeforeField {
+		msg.Before = before
+		// If the changefeed has not yet seen a previous version of the row,
+		// there's no before schema and the field is described using the after
+		// schema. It's never populated in that case.
+		if before == nil {
+			before = after
+		}
+		if before == nil {
+			return nil, errors.AssertionFailedf("missing message for envelope field before")
+		}
+		addMessageField(`before`, envelopeFieldBefore, &before.Message)
+	}
+	if opts.AfterField {
+		if after == nil {
+			return nil, errors.AssertionFailedf("missing message for envelope field after")
+		}
+		msg.After = after
+		addMessageField(`after`, envelopeFieldAfter, &after.Message)
+	}
+	if opts.RecordField {
+		if record == nil {
+			return nil, errors.AssertionFailedf("missing message for envelope field record")
+		}
+		msg.Rec = record
+		addMessageField(`record`, envelopeFieldRecord, &record.Message)
+	}
+	if opts.UpdatedField {
+		addScalarField(`updated`, envelopeFieldUpdated, TypeString)
+	}
+	if opts.MVCCTimestampField {
+		addScalarField(`mvcc_timestamp`, envelopeFieldMVCCTimestamp, TypeString)
+	}
+	if opts.ResolvedField {
+		addScalarField(`resolved`, envelopeFieldResolved, TypeString)
+	}
+	if opts.SourceField {
+		if source == nil {
+			return nil, errors.AssertionFailedf("missing message for envelope field source")
+		}
+		msg.Source = source
+		addMessageField(`source`, envelopeFieldSource, &source.Message)
+	}
+	if opts.TsField {
+		addScalarField(`ts_ns`, envelopeFieldTsNs, TypeInt64)
+	}
+	if opts.OpField {
+		addScalarField(`op`, envelopeFieldOp, TypeString)
+	}
+	return msg, nil
+}
+
+// BinaryFromRow appends the protobuf binary encoding of the given metadata and
+// row data to buf.
+func (m *EnvelopeMessage) BinaryFromRow(
+	buf []byte, meta Metadata, beforeRow, afterRow, recordRow cdcevent.Row,
+) ([]byte, error) {
+	var err error
+	if m.Opts.BeforeField && m.Before != nil && beforeRow.HasValues() && !beforeRow.IsDeleted() {
+		if buf, err = m.Before.appendNested(buf, envelopeFieldBefore, beforeRow.ForEachColumn()); err != nil {
+			return nil, err
+		}
+	}
+	if m.Opts.AfterField && afterRow.HasValues() && !afterRow.IsDeleted() {
+		if buf, err = m.After.appendNested(buf, envelopeFieldAfter, afterRow.ForEachColumn()); err != nil {
+			return nil, err
+		}
+	}
+	if m.Opts.RecordField && recordRow.HasValues() {
+		if buf, err = m.Rec.appendNested(buf, envelopeFieldRecord, recordRow.ForEachColumn()); err != nil {
+			return nil, err
+		}
+	}
+
+	appendTimestamp := func(buf []byte, key string, num protowire.Number) ([]byte, error) {
+		u, ok := meta[key]
+		if !ok {
+			return buf, nil
+		}
+		delete(meta, key)
+		ts, ok := u.(hlc.Timestamp)
+		if !ok {
+			return nil, changefeedbase.WithTerminalError(
+				errors.Errorf(`unknown metadata timestamp type: %T`, u))
+		}
+		buf = protowire.AppendTag(buf, num, protowire.BytesType)
+		return protowire.AppendString(buf, ts.AsOfSystemTime()), nil
+	}
+	if m.Opts.UpdatedField {
+		if buf, err = appendTimestamp(buf, `updated`, envelopeFieldUpdated); err != nil {
+			return nil, err
+		}
+	}
+	if m.Opts.MVCCTimestampField {
+		if buf, err = appendTimestamp(buf, `mvcc_timestamp`, envelopeFieldMVCCTimestamp); err != nil {
+			return nil, err
+		}
+	}
+	if m.Opts.ResolvedField {
+		if buf, err = appendTimestamp(buf, `resolved`, envelopeFieldResolved); err != nil {
+			return nil, err
+		}
+	}
+	if m.Opts.SourceField {
+		buf = m.Source.appendNested(buf, envelopeFieldSource, recordRow)
+	}
+	if m.Opts.TsField {
+		if u, ok := meta[`ts_ns`]; ok {
+			delete(meta, `ts_ns`)
+			ts, ok := u.(int64)
+			if !ok {
+				return nil, changefeedbase.WithTerminalError(
+					errors.Errorf(`unknown metadata timestamp type: %T`, u))
+			}
+			buf = protowire.AppendTag(buf, envelopeFieldTsNs, protowire.VarintType)
+			buf = protowire.AppendVarint(buf, uint64(ts))
+		}
+	}
+	if m.Opts.OpField {
+		if u, ok := meta[`op`]; ok {
+			delete(meta, `op`)
+			op, ok := u.(string)
+			if !ok {
+				return nil, changefeedbase.WithTerminalError(
+					errors.Errorf(`unknown metadata operation type: %T`, u))
+			}
+			buf = protowire.AppendTag(buf, envelopeFieldOp, protowire.BytesType)
+			buf = protowire.AppendString(buf, op)
+		}
+	}
+
+	for k := range meta {
+		return nil, changefeedbase.WithTerminalError(errors.AssertionFailedf(`unhandled meta key: %s`, k))
+	}
+	return buf, nil
+}
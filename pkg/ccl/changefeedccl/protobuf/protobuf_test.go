@@ -0,0 +1,117 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package protobuf
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestProtobufSchema(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	colTypes := []*types.T{types.Int, types.String, types.Bool, types.Float, types.Bytes, types.Decimal}
+	row := cdcevent.TestingMakeEventRowFromEncDatums(rowenc.EncDatumRow{
+		rowenc.DatumToEncDatum(types.Int, tree.NewDInt(-7)),
+		rowenc.DatumToEncDatum(types.String, tree.NewDString(`foo`)),
+		rowenc.DatumToEncDatum(types.Bool, tree.DBoolTrue),
+		rowenc.DatumToEncDatum(types.Float, tree.NewDFloat(2.5)),
+		rowenc.DatumToEncDatum(types.Bytes, tree.NewDBytes("\x01")),
+		rowenc.DatumToEncDatum(types.Decimal, tree.DNull),
+	}, colTypes, 1 /* numKeyCols */, false /* deleted */)
+
+	key, err := PrimaryIndexToMessage(row, `randtbl`)
+	require.NoError(t, err)
+	require.Equal(t, `syntax = "proto3";
+
+message randtbl {
+  optional int64 col_int = 1;
+}
+`, key.Schema(``))
+
+	after, err := TableToMessage(row, MessageNoSuffix, `` /* omitColumn */)
+	require.NoError(t, err)
+	env, err := NewEnvelopeMessage(`randtbl`, EnvelopeOpts{AfterField: true, UpdatedField: true},
+		nil /* before */, after, nil /* record */, nil /* source */)
+	require.NoError(t, err)
+	require.Equal(t, `syntax = "proto3";
+package ns;
+
+message randtbl_envelope {
+  message randtbl {
+    optional int64 col_int = 1;
+    optional string col_string = 2;
+    optional bool col_bool = 3;
+    optional double col_float = 4;
+    optional bytes col_bytes = 5;
+    optional string col_decimal = 6;
+  }
+  randtbl after = 2;
+  optional string updated = 4;
+}
+`, env.Schema(`ns`))
+
+	buf, err := env.BinaryFromRow(nil, Metadata{`updated`: hlc.Timestamp{WallTime: 1, Logical: 2}}, row, row, row)
+	require.NoError(t, err)
+
+	num, typ, n := protowire.ConsumeField(buf)
+	require.Equal(t, protowire.Number(2), num)
+	require.Equal(t, protowire.BytesType, typ)
+	tagLen := protowire.SizeTag(num)
+	nested, m := protowire.ConsumeBytes(buf[tagLen:n])
+	require.Equal(t, n-tagLen, m)
+
+	var decoded []interface{}
+	for len(nested) > 0 {
+		num, typ, n := protowire.ConsumeTag(nested)
+		require.GreaterOrEqual(t, n, 0)
+		require.Equal(t, protowire.Number(len(decoded)+1), num)
+		nested = nested[n:]
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(nested)
+			decoded = append(decoded, int64(v))
+			nested = nested[n:]
+		case protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(nested)
+			decoded = append(decoded, math.Float64frombits(v))
+			nested = nested[n:]
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(nested)
+			decoded = append(decoded, string(v))
+			nested = nested[n:]
+		}
+	}
+	// The NULL decimal is omitted.
+	require.Equal(t, []interface{}{int64(-7), `foo`, int64(1), 2.5, "\x01"}, decoded)
+
+	num, typ, m = protowire.ConsumeField(buf[n:])
+	require.Equal(t, protowire.Number(4), num)
+	require.Equal(t, protowire.BytesType, typ)
+	updated, _ := protowire.ConsumeString(buf[n+protowire.SizeTag(num) : n+m])
+	require.Equal(t, `1.0000000002`, updated)
+	require.Len(t, buf, n+m)
+}
+
+func TestProtobufUnhandledMetadata(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	env, err := NewEnvelopeMessage(`t`, EnvelopeOpts{ResolvedField: true},
+		nil /* before */, nil /* after */, nil /* record */, nil /* source */)
+	require.NoError(t, err)
+	var nilRow cdcevent.Row
+	_, err = env.BinaryFromRow(nil, Metadata{`updated`: hlc.Timestamp{}}, nilRow, nilRow, nilRow)
+	require.ErrorContains(t, err, `unhandled meta key: updated`)
+}
@@ -38,10 +38,30 @@ type schemaRegistry interface {
 	// be used in Avro wire messages or in other calls to the
 	// schema registry.
 	RegisterSchemaForSubject(ctx context.Context, subject string, schema string) (int32, error)
+
+	// RegisterTypedSchemaForSubject is like RegisterSchemaForSubject,
+	// but registers a schema of the given type rather than assuming
+	// an Avro schema.
+	RegisterTypedSchemaForSubject(
+		ctx context.Context, subject string, schemaType confluentSchemaType, schema string,
+	) (int32, error)
 }
 
+// confluentSchemaType is the type of a schema registered with a
+// Confluent schema registry.
+type confluentSchemaType string
+
+const (
+	// confluentSchemaTypeAvro is the default schema type. It's omitted
+	// from registration requests for compatibility with registries that
+	// predate support for other schema types.
+	confluentSchemaTypeAvro     confluentSchemaType = ``
+	confluentSchemaTypeProtobuf confluentSchemaType = `PROTOBUF`
+)
+
 type confluentSchemaVersionRequest struct {
-	Schema string `json:"schema"`
+	Schema     string              `json:"schema"`
+	SchemaType confluentSchemaType `json:"schemaType,omitempty"`
 }
 
 type confluentSchemaVersionResponse struct {
@@ -225,13 +245,25 @@ func (r *confluentSchemaRegistry) Ping(ctx context.Context) error {
 //	https://docs.confluent.io/platform/current/schema-registry/develop/api.html#post--subjects-(string-%20subject)-versions
 func (r *confluentSchemaRegistry) RegisterSchemaForSubject(
 	ctx context.Context, subject string, schema string,
+) (int32, error) {
+	return r.RegisterTypedSchemaForSubject(ctx, subject, confluentSchemaTypeAvro, schema)
+}
+
+// RegisterTypedSchemaForSubject registers the given schema of the given
+// type for the given subject.
+func (r *confluentSchemaRegistry) RegisterTypedSchemaForSubject(
+	ctx context.Context, subject string, schemaType confluentSchemaType, schema string,
 ) (int32, error) {
 	u := r.urlForPath(fmt.Sprintf("subjects/%s/versions", subject))
 	if log.V(1) {
-		log.Infof(ctx, "registering avro schema %s %s", u, schema)
+		if schemaType == confluentSchemaTypeAvro {
+			log.Infof(ctx, "registering avro schema %s %s", u, schema)
+		} else {
+			log.Infof(ctx, "registering %s schema %s %s", schemaType, u, schema)
+		}
 	}
 
-	req := confluentSchemaVersionRequest{Schema: schema}
+	req := confluentSchemaVersionRequest{Schema: schema, SchemaType: schemaType}
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(req); err != nil {
 		return 0, err
@@ -313,8 +345,9 @@ func (r *confluentSchemaRegistry) urlForPath(relPath string) string {
 }
 
 type schemaRegistryCacheKey struct {
-	subject string
-	schema  string
+	subject    string
+	schemaType confluentSchemaType
+	schema     string
 }
 
 type schemaRegistryCache struct {
@@ -351,9 +384,16 @@ func (csr *schemaRegistryWithCache) Ping(ctx context.Context) error {
 // RegisterSchemaForSubject implements the schemaRegistry interface.
 func (csr *schemaRegistryWithCache) RegisterSchemaForSubject(
 	ctx context.Context, subject string, schema string,
+) (int32, error) {
+	return csr.RegisterTypedSchemaForSubject(ctx, subject, confluentSchemaTypeAvro, schema)
+}
+
+// RegisterTypedSchemaForSubject implements the schemaRegistry interface.
+func (csr *schemaRegistryWithCache) RegisterTypedSchemaForSubject(
+	ctx context.Context, subject string, schemaType confluentSchemaType, schema string,
 ) (int32, error) {
 	cacheKey := schemaRegistryCacheKey{
-		subject: subject, schema: schema,
+		subject: subject, schemaType: schemaType, schema: schema,
 	}
 	csr.cache.mu.Lock()
 	defer csr.cache.mu.Unlock()
@@ -361,7 +401,7 @@ func (csr *schemaRegistryWithCache) RegisterSchemaForSubject(
 	if ok {
 		return id, nil
 	}
-	id, err := csr.base.RegisterSchemaForSubject(ctx, subject, schema)
+	id, err := csr.base.RegisterTypedSchemaForSubject(ctx, subject, schemaType, schema)
 	if err == nil {
 		csr.cache.Add(cacheKey, id)
 	}
@@ -57,6 +57,7 @@ const (
 	sinkTypeCloudstorage
 	sinkTypeSQL
 	sinkTypePulsar
+	sinkTypeNATS
 )
 
 func (st sinkType) String() string {
@@ -77,6 +78,8 @@ func (st sinkType) String() string {
 		return `sql`
 	case sinkTypePulsar:
 		return `pulsar`
+	case sinkTypeNATS:
+		return `nats`
 	default:
 		return `unknown`
 	}
@@ -264,6 +267,12 @@ func getSink(
 			}
 			return makePulsarSink(ctx, &changefeedbase.SinkURL{URL: u}, encodingOpts, AllTargets(feedCfg), opts.GetKafkaConfigJSON(),
 				serverCfg.Settings, metricsBuilder, testingKnobs)
+		case isNATSSink(u):
+			return validateOptionsAndMakeSink(changefeedbase.NATSValidOptions, func() (Sink, error) {
+				return makeNATSSink(ctx, &changefeedbase.SinkURL{URL: u}, encodingOpts, opts.GetNATSConfigJSON(),
+					AllTargets(feedCfg), numSinkIOWorkers(serverCfg), newCPUPacerFactory(ctx, serverCfg),
+					timeutil.DefaultTimeSource{}, metricsBuilder, serverCfg.Settings)
+			})
 		case isWebhookSink(u):
 			webhookOpts, err := opts.GetWebhookSinkOptions()
 			if err != nil {
@@ -0,0 +1,499 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/admission"
+	"github.com/cockroachdb/cockroach/pkg/util/cidr"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// The NATS sink publishes messages to a NATS JetStream stream using the
+// nats.go JetStream client. Each changefeed topic is published to its own
+// subject, and a batch is considered flushed once JetStream has acknowledged
+// every message in it. Streams are not created by the sink; the subjects a
+// changefeed publishes to must be bound to an existing stream, otherwise the
+// publish fails with a "no response from stream" error.
+//
+// NATS has no notion of a message key. JSON messages wrap the key, value and
+// topic in a single JSON object, like the webhook and pubsub sinks do. The
+// values of the byte formats (avro and protobuf) are published as is, with the
+// base64 encoded key carried in the natsKeyHeader header.
+
+const (
+	natsDefaultPort = `4222`
+	// natsConnectTimeout bounds the time spent establishing a connection,
+	// including the TLS and CONNECT handshakes.
+	natsConnectTimeout = 10 * time.Second
+	// natsAckTimeout bounds the time spent waiting for JetStream to acknowledge
+	// the messages of a batch.
+	natsAckTimeout = 30 * time.Second
+	// natsKeyHeader is the header carrying the base64 encoded key of messages
+	// in one of the byte formats.
+	natsKeyHeader = `Crdb-Key`
+)
+
+// isNATSSink returns true if url contains scheme with valid nats sink.
+func isNATSSink(u *url.URL) bool {
+	return u.Scheme == changefeedbase.SinkSchemeNATS
+}
+
+// sanitizeNATSSubject replaces the characters which are not allowed in a
+// NATS subject token. Periods are kept, which places the column family of a
+// multi-family table in its own subject token.
+func sanitizeNATSSubject(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\r', '\n', '*', '>':
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+type natsSinkClient struct {
+	format   changefeedbase.FormatType
+	batchCfg sinkBatchConfig
+	// serverURL is the URL of the NATS server, without credentials.
+	serverURL string
+	opts      []nats.Option
+
+	mu struct {
+		syncutil.Mutex
+		conn   *nats.Conn
+		js     jetstream.JetStream
+		closed bool
+	}
+}
+
+var _ SinkClient = (*natsSinkClient)(nil)
+var _ SinkPayload = (*natsPayload)(nil)
+
+// natsPayload is a batch of messages to be published to a single subject.
+type natsPayload struct {
+	subject  string
+	messages []*nats.Msg
+}
+
+// natsDialer adapts a cidr.DialContext, which records network metrics, to the
+// nats.CustomDialer interface.
+type natsDialer struct {
+	dial cidr.DialContext
+}
+
+// Dial implements the nats.CustomDialer interface.
+func (d natsDialer) Dial(network, address string) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), natsConnectTimeout)
+	defer cancel()
+	return d.dial(ctx, network, address)
+}
+
+func makeNATSSinkClient(
+	u *changefeedbase.SinkURL,
+	encodingOpts changefeedbase.EncodingOptions,
+	batchCfg sinkBatchConfig,
+	m metricsRecorder,
+) (*natsSinkClient, error) {
+	if u.Scheme != changefeedbase.SinkSchemeNATS {
+		return nil, errors.Errorf("unknown scheme: %s", u.Scheme)
+	}
+
+	switch encodingOpts.Format {
+	case changefeedbase.OptFormatJSON, changefeedbase.OptFormatCSV,
+		changefeedbase.OptFormatAvro, changefeedbase.OptFormatProtobuf:
+	default:
+		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptFormat, encodingOpts.Format)
+	}
+
+	switch encodingOpts.Envelope {
+	case changefeedbase.OptEnvelopeWrapped, changefeedbase.OptEnvelopeBare, changefeedbase.OptEnvelopeEnriched:
+	default:
+		return nil, errors.Errorf(`this sink is incompatible with %s=%s`,
+			changefeedbase.OptEnvelope, encodingOpts.Envelope)
+	}
+
+	if u.Host == `` {
+		return nil, errors.New("missing NATS server address")
+	}
+	addr := u.Host
+	if u.Port() == `` {
+		addr = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+
+	opts := []nats.Option{
+		nats.Name(`cockroachdb-changefeed/` + build.BinaryVersion()),
+		nats.Timeout(natsConnectTimeout),
+		nats.SetCustomDialer(natsDialer{
+			dial: m.netMetrics().Wrap((&net.Dialer{}).DialContext, "nats"),
+		}),
+	}
+	if u.User != nil {
+		// A user without a password is interpreted as an authentication token,
+		// mirroring the URL conventions of the NATS clients.
+		if password, ok := u.User.Password(); ok {
+			opts = append(opts, nats.UserInfo(u.User.Username(), password))
+		} else {
+			opts = append(opts, nats.Token(u.User.Username()))
+		}
+	}
+
+	tlsCfg, err := makeNATSTLSConfig(u)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
+		opts = append(opts, nats.Secure(tlsCfg))
+	}
+
+	return &natsSinkClient{
+		format:    encodingOpts.Format,
+		batchCfg:  batchCfg,
+		serverURL: (&url.URL{Scheme: changefeedbase.SinkSchemeNATS, Host: addr}).String(),
+		opts:      opts,
+	}, nil
+}
+
+// makeNATSTLSConfig returns the TLS configuration described by the sink URL's
+// query parameters, or nil if TLS was not requested.
+func makeNATSTLSConfig(u *changefeedbase.SinkURL) (*tls.Config, error) {
+	var tlsEnabled, tlsSkipVerify bool
+	var caCert, clientCert, clientKey []byte
+	if _, err := u.ConsumeBool(changefeedbase.SinkParamTLSEnabled, &tlsEnabled); err != nil {
+		return nil, err
+	}
+	if _, err := u.ConsumeBool(changefeedbase.SinkParamSkipTLSVerify, &tlsSkipVerify); err != nil {
+		return nil, err
+	}
+	if err := u.DecodeBase64(changefeedbase.SinkParamCACert, &caCert); err != nil {
+		return nil, err
+	}
+	if err := u.DecodeBase64(changefeedbase.SinkParamClientCert, &clientCert); err != nil {
+		return nil, err
+	}
+	if err := u.DecodeBase64(changefeedbase.SinkParamClientKey, &clientKey); err != nil {
+		return nil, err
+	}
+
+	if !tlsEnabled {
+		if tlsSkipVerify || caCert != nil || clientCert != nil || clientKey != nil {
+			return nil, errors.Errorf(`%s, %s, %s and %s require %s=true`,
+				changefeedbase.SinkParamSkipTLSVerify, changefeedbase.SinkParamCACert,
+				changefeedbase.SinkParamClientCert, changefeedbase.SinkParamClientKey,
+				changefeedbase.SinkParamTLSEnabled)
+		}
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		InsecureSkipVerify: tlsSkipVerify,
+		ServerName:         u.Hostname(),
+	}
+	if caCert != nil {
+		caCertPool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load system root CA pool")
+		}
+		if caCertPool == nil {
+			caCertPool = x509.NewCertPool()
+		}
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.Errorf("failed to parse certificate data:%s", string(caCert))
+		}
+		cfg.RootCAs = caCertPool
+	}
+
+	if clientCert != nil && clientKey == nil {
+		return nil, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientCert, changefeedbase.SinkParamClientKey)
+	} else if clientKey != nil && clientCert == nil {
+		return nil, errors.Errorf(`%s requires %s to be set`, changefeedbase.SinkParamClientKey, changefeedbase.SinkParamClientCert)
+	}
+	if clientCert != nil {
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, `invalid client certificate data provided`)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// getJetStream returns the JetStream client of the current connection,
+// connecting if there is no connection or if the current one was closed.
+// Transient disconnections are handled by the NATS client, which reconnects on
+// its own.
+func (sc *natsSinkClient) getJetStream(ctx context.Context) (jetstream.JetStream, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.mu.closed {
+		return nil, errors.New("nats sink client is closed")
+	}
+	if sc.mu.conn != nil {
+		if !sc.mu.conn.IsClosed() {
+			return sc.mu.js, nil
+		}
+		log.Infof(ctx, "reconnecting to NATS server %s after its connection was closed: %v",
+			sc.serverURL, sc.mu.conn.LastError())
+		sc.mu.conn, sc.mu.js = nil, nil
+	}
+	conn, err := nats.Connect(sc.serverURL, sc.opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "connecting to NATS server %s", sc.serverURL)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	sc.mu.conn, sc.mu.js = conn, js
+	return js, nil
+}
+
+// CheckConnection implements the SinkClient interface.
+func (sc *natsSinkClient) CheckConnection(ctx context.Context) error {
+	_, err := sc.getJetStream(ctx)
+	return err
+}
+
+// FlushResolvedPayload implements the SinkClient interface.
+func (sc *natsSinkClient) FlushResolvedPayload(
+	ctx context.Context,
+	body []byte,
+	forEachTopic func(func(topic string) error) error,
+	retryOpts retry.Options,
+) error {
+	return forEachTopic(func(topic string) error {
+		pl := &natsPayload{
+			subject:  topic,
+			messages: []*nats.Msg{{Subject: topic, Data: body}},
+		}
+		return retry.WithMaxAttempts(ctx, retryOpts, retryOpts.MaxRetries+1, func() error {
+			return sc.Flush(ctx, pl)
+		})
+	})
+}
+
+// Flush implements the SinkClient interface. It publishes the messages of the
+// payload asynchronously and waits until all of them have been acknowledged
+// by JetStream.
+func (sc *natsSinkClient) Flush(ctx context.Context, payload SinkPayload) error {
+	pl := payload.(*natsPayload)
+	if len(pl.messages) == 0 {
+		return nil
+	}
+	js, err := sc.getJetStream(ctx)
+	if err != nil {
+		return err
+	}
+
+	acks := make([]jetstream.PubAckFuture, 0, len(pl.messages))
+	for _, msg := range pl.messages {
+		// The batching sink retries failed batches, so don't let the client
+		// retry on its own as well.
+		ack, err := js.PublishMsgAsync(msg, jetstream.WithRetryAttempts(0))
+		if err != nil {
+			return sc.publishError(pl.subject, err)
+		}
+		acks = append(acks, ack)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, natsAckTimeout)
+	defer cancel()
+	for i, ack := range acks {
+		select {
+		case <-ack.Ok():
+		case err := <-ack.Err():
+			return sc.publishError(pl.subject, err)
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "waiting for %d JetStream acknowledgements on subject %s",
+				len(acks)-i, pl.subject)
+		}
+	}
+	return nil
+}
+
+// publishError annotates an error returned while publishing to subject.
+func (sc *natsSinkClient) publishError(subject string, err error) error {
+	err = errors.Wrapf(err, "publishing to subject %s", subject)
+	switch {
+	case errors.Is(err, nats.ErrMaxPayload):
+		// Retrying won't make the message any smaller.
+		return changefeedbase.WithTerminalError(err)
+	case errors.Is(err, jetstream.ErrNoStreamResponse):
+		return errors.WithHint(err,
+			"Create a JetStream stream whose subjects include the changefeed's subjects.")
+	}
+	return err
+}
+
+// Close implements the SinkClient interface.
+func (sc *natsSinkClient) Close() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.mu.closed = true
+	if sc.mu.conn != nil {
+		sc.mu.conn.Close()
+		sc.mu.conn, sc.mu.js = nil, nil
+	}
+	return nil
+}
+
+type natsBuffer struct {
+	sc           *natsSinkClient
+	topic        string
+	topicEncoded []byte
+	messages     []*nats.Msg
+	numBytes     int
+	err          error
+}
+
+var _ BatchBuffer = (*natsBuffer)(nil)
+
+// MakeBatchBuffer implements the SinkClient interface.
+func (sc *natsSinkClient) MakeBatchBuffer(topic string) BatchBuffer {
+	var topicBuffer bytes.Buffer
+	json.FromString(topic).Format(&topicBuffer)
+	return &natsBuffer{
+		sc:           sc,
+		topic:        topic,
+		topicEncoded: topicBuffer.Bytes(),
+		messages:     make([]*nats.Msg, 0, sc.batchCfg.Messages),
+	}
+}
+
+// Append implements the BatchBuffer interface.
+func (nb *natsBuffer) Append(key []byte, value []byte, attributes attributes) {
+	msg := &nats.Msg{Subject: nb.topic}
+	switch nb.sc.format {
+	case changefeedbase.OptFormatJSON:
+		var buffer bytes.Buffer
+		// Grow all at once to avoid reallocations
+		buffer.Grow(26 /* Key/Value/Topic keys */ + len(key) + len(value) + len(nb.topicEncoded))
+		buffer.WriteString("{\"Key\":")
+		buffer.Write(key)
+		buffer.WriteString(",\"Value\":")
+		buffer.Write(value)
+		buffer.WriteString(",\"Topic\":")
+		buffer.Write(nb.topicEncoded)
+		buffer.WriteString("}")
+		msg.Data = buffer.Bytes()
+	case changefeedbase.OptFormatCSV:
+		msg.Data = value
+	case changefeedbase.OptFormatAvro, changefeedbase.OptFormatProtobuf:
+		msg.Data = value
+		msg.Header = nats.Header{natsKeyHeader: []string{base64.StdEncoding.EncodeToString(key)}}
+	}
+
+	for k, v := range attributes.headers {
+		// Headers are written as text lines, so the values can't contain line
+		// breaks, and keys can't contain the separators either.
+		if strings.ContainsAny(k, ":\r\n ") || bytes.ContainsAny(v, "\r\n") {
+			if nb.err == nil {
+				nb.err = changefeedbase.WithTerminalError(
+					errors.Errorf("header %q cannot be represented in a NATS message", k))
+			}
+			continue
+		}
+		if msg.Header == nil {
+			msg.Header = nats.Header{}
+		}
+		msg.Header.Set(k, string(v))
+	}
+
+	nb.messages = append(nb.messages, msg)
+	nb.numBytes += len(msg.Data)
+}
+
+// ShouldFlush implements the BatchBuffer interface.
+func (nb *natsBuffer) ShouldFlush() bool {
+	return shouldFlushBatch(nb.numBytes, len(nb.messages), nb.sc.batchCfg)
+}
+
+// Close implements the BatchBuffer interface.
+func (nb *natsBuffer) Close() (SinkPayload, error) {
+	if nb.err != nil {
+		return nil, nb.err
+	}
+	return &natsPayload{subject: nb.topic, messages: nb.messages}, nil
+}
+
+func makeNATSSink(
+	ctx context.Context,
+	u *changefeedbase.SinkURL,
+	encodingOpts changefeedbase.EncodingOptions,
+	jsonConfig changefeedbase.SinkSpecificJSONConfig,
+	targets changefeedbase.Targets,
+	parallelism int,
+	pacerFactory func() *admission.Pacer,
+	source timeutil.TimeSource,
+	mb metricsRecorderBuilder,
+	settings *cluster.Settings,
+) (Sink, error) {
+	m := mb(requiresResourceAccounting)
+
+	batchCfg, retryOpts, err := getSinkConfigFromJson(jsonConfig, sinkJSONConfig{
+		Flush: sinkBatchConfig{
+			Frequency: jsonDuration(10 * time.Millisecond),
+			Messages:  100,
+			Bytes:     1e6,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sinkClient, err := makeNATSSinkClient(u, encodingOpts, batchCfg, m)
+	if err != nil {
+		return nil, err
+	}
+
+	subjectPrefix := u.ConsumeParam(changefeedbase.SinkParamTopicPrefix)
+	subjectName := u.ConsumeParam(changefeedbase.SinkParamTopicName)
+	topicNamer, err := MakeTopicNamer(targets,
+		WithPrefix(subjectPrefix), WithSingleName(subjectName), WithSanitizeFn(sanitizeNATSSubject))
+	if err != nil {
+		return nil, err
+	}
+
+	if unknownParams := u.RemainingQueryParams(); len(unknownParams) > 0 {
+		return nil, errors.Errorf(
+			`unknown nats sink query parameters: %s`, strings.Join(unknownParams, ", "))
+	}
+
+	return makeBatchingSink(
+		ctx,
+		sinkTypeNATS,
+		sinkClient,
+		time.Duration(batchCfg.Frequency),
+		retryOpts,
+		parallelism,
+		topicNamer,
+		pacerFactory,
+		source,
+		m,
+		settings,
+	), nil
+}
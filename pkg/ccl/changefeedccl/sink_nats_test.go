@@ -0,0 +1,318 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	natsserver "github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+// testNATSSubjectPrefix is the prefix of the subjects captured by the stream
+// of a testNATSServer.
+const testNATSSubjectPrefix = `cdc.`
+
+// testNATSServer is an in-process NATS server with JetStream enabled and a
+// single stream capturing every subject starting with testNATSSubjectPrefix.
+type testNATSServer struct {
+	srv      *natsserver.Server
+	storeDir string
+	conn     *nats.Conn
+	stream   jetstream.Stream
+}
+
+// startTestNATSServer starts a testNATSServer. A maxPayload of zero uses the
+// server's default maximum payload size.
+func startTestNATSServer(maxPayload int32) (_ *testNATSServer, retErr error) {
+	storeDir, err := os.MkdirTemp("", "nats")
+	if err != nil {
+		return nil, err
+	}
+	s := &testNATSServer{storeDir: storeDir}
+	defer func() {
+		if retErr != nil {
+			s.close()
+		}
+	}()
+
+	s.srv, err = natsserver.NewServer(&natsserver.Options{
+		Host:       "127.0.0.1",
+		Port:       natsserver.RANDOM_PORT,
+		JetStream:  true,
+		StoreDir:   storeDir,
+		MaxPayload: maxPayload,
+		NoLog:      true,
+		NoSigs:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	go s.srv.Start()
+	if !s.srv.ReadyForConnections(10 * time.Second) {
+		return nil, errors.New("NATS server did not start")
+	}
+
+	if s.conn, err = nats.Connect(s.srv.ClientURL()); err != nil {
+		return nil, err
+	}
+	js, err := jetstream.New(s.conn)
+	if err != nil {
+		return nil, err
+	}
+	s.stream, err = js.CreateStream(context.Background(), jetstream.StreamConfig{
+		Name:     "CDC",
+		Subjects: []string{testNATSSubjectPrefix + ">"},
+	})
+	return s, err
+}
+
+// addr returns the host:port the server listens on.
+func (s *testNATSServer) addr() string {
+	return s.srv.Addr().String()
+}
+
+// messages returns all the messages stored in the stream.
+func (s *testNATSServer) messages(t *testing.T) []*jetstream.RawStreamMsg {
+	ctx := context.Background()
+	info, err := s.stream.Info(ctx)
+	require.NoError(t, err)
+	var msgs []*jetstream.RawStreamMsg
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq && seq > 0; seq++ {
+		msg, err := s.stream.GetMsg(ctx, seq)
+		require.NoError(t, err)
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+func (s *testNATSServer) close() {
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	if s.srv != nil {
+		s.srv.Shutdown()
+		s.srv.WaitForShutdown()
+	}
+	_ = os.RemoveAll(s.storeDir)
+}
+
+func makeTestNATSSink(
+	t *testing.T, sinkURI string, format changefeedbase.FormatType, jsonConfig string,
+) (Sink, error) {
+	u, err := url.Parse(sinkURI)
+	require.NoError(t, err)
+	encodingOpts := changefeedbase.EncodingOptions{
+		Format:   format,
+		Envelope: changefeedbase.OptEnvelopeWrapped,
+	}
+	s, err := makeNATSSink(context.Background(), &changefeedbase.SinkURL{URL: u}, encodingOpts,
+		changefeedbase.SinkSpecificJSONConfig(jsonConfig), makeChangefeedTargets("t"), 2, nilPacerFactory,
+		timeutil.DefaultTimeSource{}, nilMetricsRecorderBuilder, cluster.MakeTestingClusterSettings())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Dial(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func TestNATSSink(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	server, err := startTestNATSServer(0 /* maxPayload */)
+	require.NoError(t, err)
+	defer server.close()
+
+	sink, err := makeTestNATSSink(t, fmt.Sprintf(`nats://%s?topic_prefix=%s`, server.addr(), testNATSSubjectPrefix),
+		changefeedbase.OptFormatJSON, ``)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sink.Close()) }()
+
+	var pool testAllocPool
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`[1]`), []byte(`{"after":{"a":1}}`),
+		zeroTS, zeroTS, pool.alloc(), nil))
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`[2]`), []byte(`{"after":{"a":2}}`),
+		zeroTS, zeroTS, pool.alloc(), rowHeaders{`origin`: []byte(`east`)}))
+	require.NoError(t, sink.Flush(ctx))
+	require.EqualValues(t, 0, pool.used())
+
+	msgs := server.messages(t)
+	require.Len(t, msgs, 2)
+	for _, msg := range msgs {
+		require.Equal(t, `cdc.t`, msg.Subject)
+	}
+	require.Equal(t, `{"Key":[1],"Value":{"after":{"a":1}},"Topic":"cdc.t"}`, string(msgs[0].Data))
+	require.Empty(t, msgs[0].Header)
+	require.Equal(t, `{"Key":[2],"Value":{"after":{"a":2}},"Topic":"cdc.t"}`, string(msgs[1].Data))
+	require.Equal(t, `east`, msgs[1].Header.Get(`origin`))
+
+	// Headers that can't be represented in a NATS message fail the changefeed.
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`[3]`), []byte(`{"after":{"a":3}}`),
+		zeroTS, zeroTS, zeroAlloc, rowHeaders{`bad`: []byte("a\r\nb")}))
+	err = sink.Flush(ctx)
+	require.ErrorContains(t, err, `header "bad" cannot be represented in a NATS message`)
+	require.True(t, changefeedbase.IsTerminalError(err))
+}
+
+func TestNATSSinkByteFormats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	server, err := startTestNATSServer(0 /* maxPayload */)
+	require.NoError(t, err)
+	defer server.close()
+
+	sink, err := makeTestNATSSink(t, fmt.Sprintf(`nats://%s?topic_prefix=%s`, server.addr(), testNATSSubjectPrefix),
+		changefeedbase.OptFormatAvro, ``)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sink.Close()) }()
+
+	key, value := []byte{0, 0, 0, 0, 1, 2}, []byte{0, 0, 0, 0, 2, 0xff, 0x00}
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), key, value, zeroTS, zeroTS, zeroAlloc, nil))
+	require.NoError(t, sink.Flush(ctx))
+
+	msgs := server.messages(t)
+	require.Len(t, msgs, 1)
+	require.Equal(t, value, msgs[0].Data)
+	require.Equal(t, base64.StdEncoding.EncodeToString(key), msgs[0].Header.Get(natsKeyHeader))
+}
+
+func TestNATSSinkNoStream(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	server, err := startTestNATSServer(0 /* maxPayload */)
+	require.NoError(t, err)
+	defer server.close()
+
+	sink, err := makeTestNATSSink(t, fmt.Sprintf(`nats://%s?topic_name=elsewhere`, server.addr()),
+		changefeedbase.OptFormatJSON, `{"Retry":{"Max":1,"Backoff":"1ms"}}`)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sink.Close()) }()
+
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`[1]`), []byte(`{"after":{"a":1}}`),
+		zeroTS, zeroTS, zeroAlloc, nil))
+	err = sink.Flush(ctx)
+	require.ErrorContains(t, err, `publishing to subject elsewhere`)
+	require.True(t, errors.Is(err, jetstream.ErrNoStreamResponse), "%+v", err)
+}
+
+func TestNATSSinkMaxPayload(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	server, err := startTestNATSServer(1024 /* maxPayload */)
+	require.NoError(t, err)
+	defer server.close()
+
+	sink, err := makeTestNATSSink(t, fmt.Sprintf(`nats://%s?topic_prefix=%s`, server.addr(), testNATSSubjectPrefix),
+		changefeedbase.OptFormatJSON, ``)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sink.Close()) }()
+
+	value := fmt.Sprintf(`{"after":{"a":%q}}`, strings.Repeat(`x`, 2048))
+	require.NoError(t, sink.EmitRow(ctx, topic(`t`), []byte(`[1]`), []byte(value),
+		zeroTS, zeroTS, zeroAlloc, nil))
+	err = sink.Flush(ctx)
+	require.True(t, errors.Is(err, nats.ErrMaxPayload), "%+v", err)
+	require.True(t, changefeedbase.IsTerminalError(err))
+	require.Empty(t, server.messages(t))
+}
+
+func TestNATSSinkParams(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	server, err := startTestNATSServer(0 /* maxPayload */)
+	require.NoError(t, err)
+	defer server.close()
+
+	for _, tc := range []struct {
+		name          string
+		params        string
+		expectedError string
+	}{
+		{
+			name:          "unknown param",
+			params:        `?foo=bar`,
+			expectedError: `unknown nats sink query parameters: foo`,
+		},
+		{
+			name:          "tls params without tls",
+			params:        `?insecure_tls_skip_verify=true`,
+			expectedError: `require tls_enabled=true`,
+		},
+		{
+			name:          "client cert without key",
+			params:        `?tls_enabled=true&client_cert=Zm9v`,
+			expectedError: `client_cert requires client_key to be set`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := makeTestNATSSink(t, fmt.Sprintf(`nats://%s%s`, server.addr(), tc.params),
+				changefeedbase.OptFormatJSON, ``)
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+
+	_, err = makeTestNATSSink(t, `nats://`, changefeedbase.OptFormatJSON, ``)
+	require.ErrorContains(t, err, `missing NATS server address`)
+
+	_, err = makeTestNATSSink(t, fmt.Sprintf(`nats://%s`, server.addr()), changefeedbase.OptFormatParquet, ``)
+	require.ErrorContains(t, err, `this sink is incompatible with format=parquet`)
+}
+
+func TestChangefeedNATS(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testFn := func(t *testing.T, s TestServer, f cdctest.TestFeedFactory) {
+		sqlDB := sqlutils.MakeSQLRunner(s.DB)
+		sqlDB.Exec(t, `CREATE TABLE foo (a INT PRIMARY KEY, b STRING)`)
+		sqlDB.Exec(t, `INSERT INTO foo VALUES (1, 'one'), (2, 'two')`)
+
+		foo := feed(t, f, `CREATE CHANGEFEED FOR foo WITH resolved`)
+		defer closeFeed(t, foo)
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "one"}}`,
+			`foo: [2]->{"after": {"a": 2, "b": "two"}}`,
+		})
+
+		sqlDB.Exec(t, `UPDATE foo SET b = 'uno' WHERE a = 1`)
+		sqlDB.Exec(t, `DELETE FROM foo WHERE a = 2`)
+		assertPayloads(t, foo, []string{
+			`foo: [1]->{"after": {"a": 1, "b": "uno"}}`,
+			`foo: [2]->{"after": null}`,
+		})
+		expectResolvedTimestamp(t, foo)
+	}
+
+	cdcTest(t, testFn, feedTestForceSink("nats"))
+}
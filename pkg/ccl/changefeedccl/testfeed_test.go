@@ -64,6 +64,8 @@ import (
 	"github.com/cockroachdb/redact"
 	"github.com/golang/mock/gomock"
 	"github.com/jackc/pgx/v5"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 	"github.com/twmb/franz-go/pkg/kadm"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"google.golang.org/api/option"
@@ -1976,16 +1978,22 @@ func (k *kafkaFeedFactory) Feed(create string, args ...interface{}) (cdctest.Tes
 	}
 
 	var registry *cdctest.SchemaRegistry
+	var registryToJSON func([]byte) ([]byte, error)
 	for _, opt := range createStmt.Options {
 		if opt.Key == changefeedbase.OptFormat {
 			format, err := exprAsString(opt.Value)
 			if err != nil {
 				return nil, err
 			}
-			if format == string(changefeedbase.OptFormatAvro) {
+			if format == string(changefeedbase.OptFormatAvro) ||
+				format == string(changefeedbase.OptFormatProtobuf) {
 				// Must use confluent schema registry so that we register our schema
 				// in order to be able to decode kafka messages.
 				registry = cdctest.StartTestSchemaRegistry()
+				registryToJSON = registry.AvroToJSON
+				if format == string(changefeedbase.OptFormatProtobuf) {
+					registryToJSON = registry.ProtobufToJSON
+				}
 				registryOption := tree.KVOption{
 					Key:   changefeedbase.OptConfluentSchemaRegistry,
 					Value: tree.NewStrVal(registry.URL()),
@@ -2026,6 +2034,7 @@ func (k *kafkaFeedFactory) Feed(create string, args ...interface{}) (cdctest.Tes
 		source:         feedCh,
 		tg:             tg,
 		registry:       registry,
+		registryToJSON: registryToJSON,
 	}
 
 	if err := k.startFeedJob(c.jobFeed, tree.AsStringWithFlags(createStmt, tree.FmtShowPasswords), args...); err != nil {
@@ -2046,8 +2055,10 @@ type kafkaFeed struct {
 	source chan *sarama.ProducerMessage
 	tg     *teeGroup
 
-	// Registry is set if we're emitting avro.
+	// Registry is set if we're emitting avro or protobuf.
 	registry *cdctest.SchemaRegistry
+	// registryToJSON converts messages encoded using the registry to JSON.
+	registryToJSON func([]byte) ([]byte, error)
 }
 
 var _ cdctest.TestFeed = (*kafkaFeed)(nil)
@@ -2096,8 +2107,8 @@ func (k *kafkaFeed) Next() (*cdctest.TestFeedMessage, error) {
 			if k.registry == nil {
 				*dest = decoded
 			} else {
-				// Convert avro record to json.
-				jsonBytes, err := k.registry.AvroToJSON(decoded)
+				// Convert avro or protobuf record to json.
+				jsonBytes, err := k.registryToJSON(decoded)
 				if err != nil {
 					return err
 				}
@@ -2703,6 +2714,162 @@ func (p *pubsubFeed) Close() error {
 	return nil
 }
 
+type natsFeedFactory struct {
+	enterpriseFeedFactory
+}
+
+var _ cdctest.TestFeedFactory = (*natsFeedFactory)(nil)
+
+// makeNATSFeedFactory returns a TestFeedFactory implementation using the `nats`
+// uri. Each feed publishes to its own in-process NATS server with JetStream
+// enabled.
+func makeNATSFeedFactory(srvOrCluster interface{}, rootDB *gosql.DB) cdctest.TestFeedFactory {
+	s, injectables := getInjectables(srvOrCluster)
+	return &natsFeedFactory{
+		enterpriseFeedFactory: enterpriseFeedFactory{
+			s:      s,
+			db:     rootDB,
+			rootDB: rootDB,
+			di:     newDepInjector(injectables...),
+		},
+	}
+}
+
+// Feed implements cdctest.TestFeedFactory
+func (n *natsFeedFactory) Feed(create string, args ...interface{}) (cdctest.TestFeed, error) {
+	parsed, err := parser.ParseOne(create)
+	if err != nil {
+		return nil, err
+	}
+	createStmt := parsed.AST.(*tree.CreateChangefeed)
+
+	server, err := startTestNATSServer(0 /* maxPayload */)
+	if err != nil {
+		return nil, err
+	}
+	sinkURI := fmt.Sprintf(`nats://%s?topic_prefix=%s`, server.addr(), testNATSSubjectPrefix)
+	if err := setURI(createStmt, sinkURI, false, &args); err != nil {
+		server.close()
+		return nil, err
+	}
+	consumer, err := server.stream.OrderedConsumer(context.Background(), jetstream.OrderedConsumerConfig{})
+	if err != nil {
+		server.close()
+		return nil, err
+	}
+
+	c := &natsFeed{
+		jobFeed:        newJobFeed(n.jobsTableConn(), func(s Sink) Sink { return s }),
+		seenTrackerMap: make(map[string]struct{}),
+		server:         server,
+		consumer:       consumer,
+	}
+	if err := n.startFeedJob(c.jobFeed, tree.AsStringWithFlags(createStmt, tree.FmtShowPasswords), args...); err != nil {
+		server.close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Server implements TestFeedFactory
+func (n *natsFeedFactory) Server() serverutils.ApplicationLayerInterface {
+	return n.s
+}
+
+type natsFeed struct {
+	*jobFeed
+	seenTrackerMap
+	server   *testNATSServer
+	consumer jetstream.Consumer
+}
+
+var _ cdctest.TestFeed = (*natsFeed)(nil)
+
+// Partitions implements TestFeed
+func (n *natsFeed) Partitions() []string {
+	return []string{``}
+}
+
+// Next implements TestFeed
+func (n *natsFeed) Next() (*cdctest.TestFeedMessage, error) {
+	for {
+		msg, err := n.nextMsg()
+		if err != nil {
+			return nil, err
+		}
+		details, err := n.Details()
+		if err != nil {
+			return nil, err
+		}
+
+		m := &cdctest.TestFeedMessage{
+			Topic:      strings.TrimPrefix(msg.Subject(), testNATSSubjectPrefix),
+			RawMessage: msg,
+		}
+		switch v := changefeedbase.FormatType(details.Opts[changefeedbase.OptFormat]); v {
+		case ``, changefeedbase.OptFormatJSON:
+			resolved, err := isResolvedTimestamp(msg.Data())
+			if err != nil {
+				return nil, err
+			}
+			if resolved {
+				m.Resolved = msg.Data()
+				return m, nil
+			}
+			m.Value, m.Key, _, err = extractJSONMessagePubsub(msg.Data())
+			if err != nil {
+				return nil, err
+			}
+		case changefeedbase.OptFormatCSV:
+			m.Value = msg.Data()
+		default:
+			return nil, errors.Errorf(`unknown %s: %s`, changefeedbase.OptFormat, v)
+		}
+		for k, vs := range msg.Headers() {
+			for _, v := range vs {
+				m.Headers = append(m.Headers, cdctest.Header{K: k, V: []byte(v)})
+			}
+		}
+		slices.SortFunc(m.Headers, func(a, b cdctest.Header) int { return strings.Compare(a.K, b.K) })
+		if isNew := n.markSeen(m); !isNew {
+			continue
+		}
+		return m, nil
+	}
+}
+
+// nextMsg waits for the next message stored in the server's stream.
+func (n *natsFeed) nextMsg() (jetstream.Msg, error) {
+	var msg jetstream.Msg
+	err := timeutil.RunWithTimeout(
+		context.Background(), timeoutOp("nats.Next", n.jobID), timeout(),
+		func(ctx context.Context) error {
+			for {
+				var err error
+				msg, err = n.consumer.Next(jetstream.FetchMaxWait(time.Second))
+				if !errors.Is(err, nats.ErrTimeout) {
+					return err
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-n.shutdown:
+					return n.terminalJobError()
+				default:
+				}
+			}
+		},
+	)
+	return msg, err
+}
+
+// Close implements TestFeed
+func (n *natsFeed) Close() error {
+	err := n.jobFeed.Close()
+	n.server.close()
+	return err
+}
+
 type mockPulsarServer struct {
 	msgCh chan *pulsar.ProducerMessage
 }
@@ -993,6 +993,13 @@ func TestReadCommittedLogic_group_join(
 	runLogicTest(t, "group_join")
 }
 
+func TestReadCommittedLogic_grouping_sets(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "grouping_sets")
+}
+
 func TestReadCommittedLogic_hash_join(
 	t *testing.T,
 ) {
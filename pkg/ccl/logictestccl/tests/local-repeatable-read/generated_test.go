@@ -986,6 +986,13 @@ func TestRepeatableReadLogic_group_join(
 	runLogicTest(t, "group_join")
 }
 
+func TestRepeatableReadLogic_grouping_sets(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "grouping_sets")
+}
+
 func TestRepeatableReadLogic_hash_join(
 	t *testing.T,
 ) {
@@ -57,6 +57,36 @@ func TestSetupLogging(t *testing.T) {
 		`flush-trigger-size: 1.0MiB, ` +
 		`max-buffer-size: 50MiB, ` +
 		`format: newline}}`
+	const defaultOTLPConfig = `otlp-defaults: {` +
+		`protocol: grpc, ` +
+		`insecure: false, ` +
+		`unsafe-tls: false, ` +
+		`timeout: 2s, ` +
+		`compression: gzip, ` +
+		`filter: INFO, ` +
+		`format: json, ` +
+		`redactable: true, ` +
+		`exit-on-error: false, ` +
+		`buffering: {max-staleness: 5s, ` +
+		`flush-trigger-size: 1.0MiB, ` +
+		`max-buffer-size: 50MiB, ` +
+		`format: newline}}`
+	const defaultSyslogConfig = `syslog-defaults: {` +
+		`net: tcp, ` +
+		`tls: false, ` +
+		`unsafe-tls: false, ` +
+		`timeout: 5s, ` +
+		`app-name: cockroach, ` +
+		`facility: user, ` +
+		`structured-data-id: cockroach@32473, ` +
+		`filter: INFO, ` +
+		`format: json, ` +
+		`redactable: true, ` +
+		`exit-on-error: false, ` +
+		`buffering: {max-staleness: 5s, ` +
+		`flush-trigger-size: 1.0MiB, ` +
+		`max-buffer-size: 50MiB, ` +
+		`format: newline}}`
 	stdFileDefaultsRe := regexp.MustCompile(
 		`file-defaults: \{` +
 			`dir: (?P<path>[^,]+), ` +
@@ -189,6 +219,8 @@ func TestSetupLogging(t *testing.T) {
 		// Shorten the configuration for legibility during reviews of test changes.
 		actual = strings.ReplaceAll(actual, defaultFluentConfig, "<fluentDefaults>")
 		actual = strings.ReplaceAll(actual, defaultHTTPConfig, "<httpDefaults>")
+		actual = strings.ReplaceAll(actual, defaultOTLPConfig, "<otlpDefaults>")
+		actual = strings.ReplaceAll(actual, defaultSyslogConfig, "<syslogDefaults>")
 		actual = stdFileDefaultsRe.ReplaceAllString(actual, "<stdFileDefaults($path)>")
 		actual = fileDefaultsNoMaxSizeRe.ReplaceAllString(actual, "<fileDefaultsNoMaxSize($path)>")
 		actual = strings.ReplaceAll(actual, fileDefaultsNoDir, "<fileDefaultsNoDir>")
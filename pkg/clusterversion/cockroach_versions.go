@@ -227,6 +227,46 @@ const (
 
 	V25_2_AddSqlActivityFlushJob
 
+	// V25_2_PGReplicationSlots enables logical replication slots and switches
+	// the LSNs reported to pgwire replication clients to an encoding which can
+	// be converted back to a timestamp.
+	V25_2_PGReplicationSlots
+
+	// V25_2_AddPublicationsAndSubscriptions adds publications to database
+	// descriptors and the job which applies the changes of a subscription.
+	V25_2_AddPublicationsAndSubscriptions
+
+	// V25_2_AddDomains adds the domain type descriptor kind.
+	V25_2_AddDomains
+
+	// V25_2_AddNotificationsTable adds the system.notifications table used by
+	// LISTEN and NOTIFY.
+	V25_2_AddNotificationsTable
+
+	// V25_2_DeferrableConstraints adds deferrable foreign key and unique
+	// without index constraints.
+	V25_2_DeferrableConstraints
+
+	// V25_2_ExclusionConstraints adds exclusion constraints, which are stored as
+	// unique without index constraints with comparison operators.
+	V25_2_ExclusionConstraints
+
+	// V25_2_UserDefinedAggregates adds user-defined aggregates, which are stored
+	// as function descriptors with aggregate support functions.
+	V25_2_UserDefinedAggregates
+
+	// V25_2_RangeTypes adds the built-in range and multirange types, which can
+	// be used as column types.
+	V25_2_RangeTypes
+
+	// V25_2_TableSample enables the TABLESAMPLE clause, which relies on
+	// TableReaders that support row-level sampling.
+	V25_2_TableSample
+
+	// V25_2_EventTriggers adds event triggers, which are stored in the database
+	// descriptor.
+	V25_2_EventTriggers
+
 	// *************************************************
 	// Step (1) Add new versions above this comment.
 	// Do not add new versions to a patch release.
@@ -278,8 +318,18 @@ var versionTable = [numKeys]roachpb.Version{
 	V25_1: {Major: 25, Minor: 1, Internal: 0},
 
 	// v25.2 versions. Internal versions must be even.
-	V25_2_Start:                  {Major: 25, Minor: 1, Internal: 2},
-	V25_2_AddSqlActivityFlushJob: {Major: 25, Minor: 1, Internal: 4},
+	V25_2_Start:                           {Major: 25, Minor: 1, Internal: 2},
+	V25_2_AddSqlActivityFlushJob:          {Major: 25, Minor: 1, Internal: 4},
+	V25_2_PGReplicationSlots:              {Major: 25, Minor: 1, Internal: 6},
+	V25_2_AddPublicationsAndSubscriptions: {Major: 25, Minor: 1, Internal: 8},
+	V25_2_AddDomains:                      {Major: 25, Minor: 1, Internal: 10},
+	V25_2_AddNotificationsTable:           {Major: 25, Minor: 1, Internal: 12},
+	V25_2_DeferrableConstraints:           {Major: 25, Minor: 1, Internal: 14},
+	V25_2_ExclusionConstraints:            {Major: 25, Minor: 1, Internal: 16},
+	V25_2_UserDefinedAggregates:           {Major: 25, Minor: 1, Internal: 18},
+	V25_2_RangeTypes:                      {Major: 25, Minor: 1, Internal: 20},
+	V25_2_TableSample:                     {Major: 25, Minor: 1, Internal: 22},
+	V25_2_EventTriggers:                   {Major: 25, Minor: 1, Internal: 24},
 
 	// *************************************************
 	// Step (2): Add new versions above this comment.
@@ -313,6 +313,7 @@ func runParse(
 	b = bytes.Replace(b, []byte("NOTHING_AFTER_RETURNING"), []byte("NOTHING"), -1)
 	b = bytes.Replace(b, []byte("'IDENT'"), []byte("'identifier'"), -1)
 	b = bytes.Replace(b, []byte("_LA"), []byte(""), -1)
+	b = bytes.Replace(b, []byte("NOT_DEFERRABLE"), []byte("NOT"), -1)
 	b = bytes.Replace(b, []byte("INDEX_BEFORE_PAREN"), []byte("INDEX"), -1)
 	b = bytes.Replace(b, []byte("INDEX_BEFORE_NAME_THEN_PAREN"), []byte("INDEX"), -1)
 	b = bytes.Replace(b, []byte("INDEX_AFTER_ORDER_BY_BEFORE_AT"), []byte("INDEX"), -1)
@@ -1396,7 +1397,7 @@ var specs = []stmtSpec{
 	},
 	{
 		name:   "table_ref",
-		inline: []string{"opt_ordinality", "opt_alias_clause", "opt_expr_list", "opt_column_list", "name_list", "alias_clause"},
+		inline: []string{"opt_ordinality", "opt_alias_clause", "opt_tablesample_clause", "opt_repeatable_clause", "opt_expr_list", "opt_column_list", "name_list", "alias_clause"},
 		replace: map[string]string{
 			"select_with_parens": "'(' select_stmt ')'",
 			"opt_index_flags":    "( '@' index_name | )",
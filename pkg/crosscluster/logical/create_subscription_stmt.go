@@ -0,0 +1,273 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package logical
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/crosscluster/streamclient"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	sql.AddPlanHook("create subscription", createSubscriptionPlanHook, createSubscriptionTypeCheck)
+	sql.AddPlanHook("drop subscription", dropSubscriptionPlanHook, dropSubscriptionTypeCheck)
+}
+
+const (
+	subscriptionOptSlotName   = "slot_name"
+	subscriptionOptCreateSlot = "create_slot"
+	subscriptionOptCopyData   = "copy_data"
+)
+
+var subscriptionOptionExpectValues = exprutil.KVOptionValidationMap{
+	subscriptionOptSlotName:   exprutil.KVStringOptRequireValue,
+	subscriptionOptCreateSlot: exprutil.KVStringOptAny,
+	subscriptionOptCopyData:   exprutil.KVStringOptAny,
+}
+
+// mutableSubscriptionDatabase returns a mutable descriptor for the current
+// database, which holds the subscription and the tables it applies changes to.
+func mutableSubscriptionDatabase(ctx context.Context, p sql.PlanHookState) (*dbdesc.Mutable, error) {
+	dbName := p.SessionData().Database
+	if dbName == "" {
+		return nil, sqlerrors.ErrNoDatabase
+	}
+	return p.InternalSQLTxn().Descriptors().MutableByName(p.Txn()).Database(ctx, dbName)
+}
+
+func requireSubscriptionAdmin(ctx context.Context, p sql.PlanHookState, op string) error {
+	isAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return pgerror.Newf(pgcode.InsufficientPrivilege, "must be admin to %s", op)
+	}
+	return nil
+}
+
+func createSubscriptionPlanHook(
+	ctx context.Context, untypedStmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	stmt, ok := untypedStmt.(*tree.CreateSubscription)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	exprEval := p.ExprEvaluator("CREATE SUBSCRIPTION")
+	connURI, err := exprEval.String(ctx, stmt.Connection)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	opts, err := exprEval.KVOptions(ctx, stmt.Options, subscriptionOptionExpectValues)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	fn := func(ctx context.Context, _ chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V25_2_AddPublicationsAndSubscriptions) {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"CREATE SUBSCRIPTION unsupported in mixed-version cluster")
+		}
+		if err := utilccl.CheckEnterpriseEnabled(p.ExecCfg().Settings, "CREATE SUBSCRIPTION"); err != nil {
+			return err
+		}
+		if err := requireSubscriptionAdmin(ctx, p, "create subscriptions"); err != nil {
+			return err
+		}
+
+		name := string(stmt.Name)
+		slotName := name
+		if s, ok := opts[subscriptionOptSlotName]; ok {
+			slotName = s
+		}
+		createSlot, err := parseSubscriptionBoolOption(opts, subscriptionOptCreateSlot, true)
+		if err != nil {
+			return err
+		}
+		copyData, err := parseSubscriptionBoolOption(opts, subscriptionOptCopyData, false)
+		if err != nil {
+			return err
+		}
+		if copyData {
+			return errors.WithHint(
+				unimplemented.New("subscription copy_data", "copying the existing data of published tables is not supported"),
+				"Copy the existing data of the tables before creating the subscription, "+
+					"and create the replication slot with a consistent point at or after the copy.")
+		}
+
+		db, err := mutableSubscriptionDatabase(ctx, p)
+		if err != nil {
+			return err
+		}
+		if _, exists := db.GetSubscription(name); exists {
+			return pgerror.Newf(pgcode.DuplicateObject, "subscription %q already exists", name)
+		}
+
+		// The publisher is named by an external connection so that its
+		// credentials are neither stored in the job nor shown in its
+		// description.
+		configUri, err := streamclient.ParseConfigUri(connURI)
+		if err != nil {
+			return pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid connection URI")
+		}
+		if !configUri.IsExternalOrTestScheme() {
+			return errors.WithHint(
+				pgerror.New(pgcode.InvalidParameterValue, "connection URI must be an external connection"),
+				"Use CREATE EXTERNAL CONNECTION to store the address and credentials of the publisher.")
+		}
+		if _, err := publisherConnConfig(ctx, p.ExecCfg().InternalDB, configUri.Serialize()); err != nil {
+			return err
+		}
+		cleanedURI, err := cloud.SanitizeExternalStorageURI(connURI, nil /* extraParams */)
+		if err != nil {
+			return err
+		}
+
+		registry := p.ExecCfg().JobRegistry
+		jr := jobs.Record{
+			JobID:       registry.MakeJobID(),
+			Description: fmt.Sprintf("SUBSCRIPTION %s TO %s", stmt.Name.String(), cleanedURI),
+			Username:    p.User(),
+			Details: jobspb.PGSubscriptionDetails{
+				Name:          name,
+				ConnectionUri: configUri.Serialize(),
+				Publications:  stmt.Publications.ToStrings(),
+				SlotName:      slotName,
+				CreateSlot:    createSlot,
+				DatabaseID:    db.GetID(),
+			},
+			Progress: jobspb.PGSubscriptionProgress{},
+		}
+		if _, err := registry.CreateAdoptableJobWithTxn(ctx, jr, jr.JobID, p.InternalSQLTxn()); err != nil {
+			return err
+		}
+
+		// The subscription is recorded in the database descriptor, which makes
+		// its name unique within the database.
+		db.AddSubscription(name, descpb.DatabaseDescriptor_SubscriptionInfo{JobID: jr.JobID})
+		if err := p.InternalSQLTxn().Descriptors().WriteDesc(
+			ctx, false /* kvTrace */, db, p.Txn(),
+		); err != nil {
+			return err
+		}
+		telemetry.Count("pg_subscription.created")
+		return nil
+	}
+	return fn, nil, false, nil
+}
+
+func parseSubscriptionBoolOption(opts map[string]string, key string, def bool) (bool, error) {
+	v, ok := opts[key]
+	if !ok {
+		return def, nil
+	}
+	if v == "" {
+		// An option without a value, e.g. WITH (create_slot), is true.
+		return true, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, pgerror.Newf(pgcode.InvalidParameterValue, "%s requires a Boolean value", key)
+	}
+	return b, nil
+}
+
+func createSubscriptionTypeCheck(
+	ctx context.Context, untypedStmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	stmt, ok := untypedStmt.(*tree.CreateSubscription)
+	if !ok {
+		return false, nil, nil
+	}
+	if err := exprutil.TypeCheck(ctx, "CREATE SUBSCRIPTION", p.SemaCtx(),
+		exprutil.Strings{stmt.Connection},
+		&exprutil.KVOptions{KVOptions: stmt.Options, Validation: subscriptionOptionExpectValues},
+	); err != nil {
+		return false, nil, err
+	}
+	return true, nil, nil
+}
+
+func dropSubscriptionPlanHook(
+	ctx context.Context, untypedStmt tree.Statement, p sql.PlanHookState,
+) (sql.PlanHookRowFn, colinfo.ResultColumns, bool, error) {
+	stmt, ok := untypedStmt.(*tree.DropSubscription)
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	fn := func(ctx context.Context, _ chan<- tree.Datums) error {
+		ctx, span := tracing.ChildSpan(ctx, stmt.StatementTag())
+		defer span.Finish()
+
+		if err := requireSubscriptionAdmin(ctx, p, "drop subscriptions"); err != nil {
+			return err
+		}
+		db, err := mutableSubscriptionDatabase(ctx, p)
+		if err != nil {
+			return err
+		}
+		name := string(stmt.Name)
+		sub, exists := db.GetSubscription(name)
+		if !exists {
+			if stmt.IfExists {
+				return nil
+			}
+			return pgerror.Newf(pgcode.UndefinedObject, "subscription %q does not exist", name)
+		}
+
+		// Canceling the job drops the replication slot on the publisher if the
+		// subscription created it. The job may already have failed, in which
+		// case only the subscription is removed.
+		txn := p.InternalSQLTxn()
+		job, err := p.ExecCfg().JobRegistry.LoadJobWithTxn(ctx, sub.JobID, txn)
+		if err != nil {
+			if !jobs.HasJobNotFoundError(err) {
+				return err
+			}
+		} else if !job.State().Terminal() {
+			if err := job.WithTxn(txn).CancelRequested(ctx); err != nil {
+				return err
+			}
+		}
+		db.RemoveSubscription(name)
+		return txn.Descriptors().WriteDesc(ctx, false /* kvTrace */, db, p.Txn())
+	}
+	return fn, nil, false, nil
+}
+
+func dropSubscriptionTypeCheck(
+	ctx context.Context, untypedStmt tree.Statement, p sql.PlanHookState,
+) (matched bool, header colinfo.ResultColumns, _ error) {
+	if _, ok := untypedStmt.(*tree.DropSubscription); !ok {
+		return false, nil, nil
+	}
+	return true, nil, nil
+}
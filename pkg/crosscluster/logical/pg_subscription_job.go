@@ -0,0 +1,726 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package logical
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/crosscluster/streamclient"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/lib/pq/oid"
+)
+
+var pgSubscriptionStatusInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"logical_replication.pg_subscription.status_interval",
+	"the interval at which a subscription confirms its position to the publisher "+
+		"and checkpoints it in the job progress",
+	10*time.Second,
+	settings.PositiveDuration,
+)
+
+// pgSubscriptionResumer streams changes from a logical replication slot of a
+// PostgreSQL-compatible publisher using the pgoutput plugin, and applies them
+// to the tables of the same name in the subscription's database.
+//
+// Changes are applied with the last-write-wins semantics of logical data
+// replication, using the commit time of each upstream transaction as the
+// origin timestamp of its rows.
+type pgSubscriptionResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = (*pgSubscriptionResumer)(nil)
+
+// Resume implements the jobs.Resumer interface.
+func (r *pgSubscriptionResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	jobExecCtx := execCtx.(sql.JobExecContext)
+	err := r.subscribe(ctx, jobExecCtx)
+	if err == nil || ctx.Err() != nil {
+		return err
+	}
+	// As with other logical replication jobs, the subscription pauses on
+	// errors unless they are permanent, so that it can be resumed once the
+	// publisher or the local schema is fixed.
+	if jobs.IsPermanentJobError(err) {
+		r.updateStatusMessage(ctx, redact.Sprintf("permanent error: %s", err.Error()))
+		return err
+	}
+	r.updateStatusMessage(ctx, redact.Sprintf("pausing after error: %s", err.Error()))
+	return jobs.MarkPauseRequestError(err)
+}
+
+func (r *pgSubscriptionResumer) subscribe(ctx context.Context, execCtx sql.JobExecContext) error {
+	var (
+		execCfg  = execCtx.ExecCfg()
+		details  = r.job.Details().(jobspb.PGSubscriptionDetails)
+		progress = *r.job.Progress().Details.(*jobspb.Progress_PgSubscription).PgSubscription
+	)
+
+	conn, err := connectToPublisher(ctx, execCfg.InternalDB, details.ConnectionUri)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := conn.Close(ctx); err != nil {
+			log.Warningf(ctx, "error closing publisher connection: %v", err)
+		}
+	}()
+
+	if details.CreateSlot && !progress.SlotCreated {
+		query := fmt.Sprintf("CREATE_REPLICATION_SLOT %s LOGICAL pgoutput",
+			lexbase.EscapeSQLIdent(details.SlotName))
+		// The slot may have been created by a previous attempt which failed
+		// before recording it.
+		if _, err := conn.Exec(ctx, query).ReadAll(); err != nil &&
+			!isPublisherError(err, pgcode.DuplicateObject) {
+			return errors.Wrapf(err, "creating replication slot %s", details.SlotName)
+		}
+		if err := r.updateProgress(ctx, func(p *jobspb.PGSubscriptionProgress) {
+			p.SlotCreated = true
+		}); err != nil {
+			return err
+		}
+		progress.SlotCreated = true
+	}
+
+	confirmed := lsn.LSN(progress.ConfirmedLSN)
+	if err := startReplication(ctx, conn, details, confirmed); err != nil {
+		return err
+	}
+	r.updateStatusMessage(ctx, redact.Sprintf("streaming from replication slot %s", details.SlotName))
+
+	evalCtx := execCtx.ExtendedEvalContext().Context.Copy()
+	a := newPGSubscriptionApplier(ctx, execCfg, evalCtx, details.DatabaseID, r.job.ID())
+	defer a.querier.ReleaseLeases(ctx)
+
+	checkpointed := confirmed
+	nextStatus := timeutil.Now()
+	for {
+		if now := timeutil.Now(); !now.Before(nextStatus) {
+			if err := sendStandbyStatus(conn, confirmed); err != nil {
+				return err
+			}
+			if confirmed != checkpointed {
+				if err := r.updateProgress(ctx, func(p *jobspb.PGSubscriptionProgress) {
+					p.ConfirmedLSN = uint64(confirmed)
+				}); err != nil {
+					return err
+				}
+				checkpointed = confirmed
+			}
+			nextStatus = now.Add(pgSubscriptionStatusInterval.Get(&execCfg.Settings.SV))
+		}
+
+		recvCtx, cancel := context.WithDeadline(ctx, nextStatus)
+		msg, err := conn.ReceiveMessage(recvCtx)
+		cancel()
+		if err != nil {
+			if pgconn.Timeout(err) && ctx.Err() == nil {
+				continue
+			}
+			return errors.Wrap(err, "receiving from publisher")
+		}
+
+		switch msg := msg.(type) {
+		case *pgproto3.CopyData:
+			if len(msg.Data) == 0 {
+				continue
+			}
+			switch msg.Data[0] {
+			case pgoutput.PrimaryKeepaliveMessage:
+				keepalive, err := pgoutput.ParsePrimaryKeepalive(msg.Data)
+				if err != nil {
+					return err
+				}
+				if keepalive.ReplyRequested {
+					nextStatus = time.Time{}
+				}
+			case pgoutput.XLogDataMessage:
+				xld, err := pgoutput.ParseXLogData(msg.Data)
+				if err != nil {
+					return err
+				}
+				m, err := pgoutput.ParseMessage(xld.Data)
+				if err != nil {
+					return err
+				}
+				committed, err := a.handle(ctx, m)
+				if err != nil {
+					return err
+				}
+				if committed != 0 {
+					confirmed = committed
+				}
+			}
+		case *pgproto3.ErrorResponse:
+			return pgconn.ErrorResponseToPgError(msg)
+		case *pgproto3.CopyDone:
+			return errors.New("publisher ended the replication stream")
+		}
+	}
+}
+
+func (r *pgSubscriptionResumer) updateProgress(
+	ctx context.Context, fn func(*jobspb.PGSubscriptionProgress),
+) error {
+	return r.job.NoTxn().Update(ctx, func(txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+		fn(md.Progress.GetPgSubscription())
+		ju.UpdateProgress(md.Progress)
+		return nil
+	})
+}
+
+func (r *pgSubscriptionResumer) updateStatusMessage(
+	ctx context.Context, status redact.RedactableString,
+) {
+	log.Infof(ctx, "%s", status)
+	err := r.job.NoTxn().Update(ctx, func(txn isql.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater) error {
+		md.Progress.StatusMessage = string(status.Redact())
+		ju.UpdateProgress(md.Progress)
+		return nil
+	})
+	if err != nil {
+		log.Warningf(ctx, "error when updating job running status: %s", err)
+	}
+}
+
+// OnFailOrCancel implements the jobs.Resumer interface. If the subscription
+// created its replication slot, it attempts to drop it so that the publisher
+// does not retain WAL for it indefinitely.
+func (r *pgSubscriptionResumer) OnFailOrCancel(
+	ctx context.Context, execCtx interface{}, _ error,
+) error {
+	var (
+		execCfg  = execCtx.(sql.JobExecContext).ExecCfg()
+		details  = r.job.Details().(jobspb.PGSubscriptionDetails)
+		progress = r.job.Progress().Details.(*jobspb.Progress_PgSubscription).PgSubscription
+	)
+	if !progress.SlotCreated {
+		return nil
+	}
+	if err := timeutil.RunWithTimeout(ctx, "drop replication slot", 30*time.Second,
+		func(ctx context.Context) error {
+			conn, err := connectToPublisher(ctx, execCfg.InternalDB, details.ConnectionUri)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = conn.Close(ctx) }()
+			query := "DROP_REPLICATION_SLOT " + lexbase.EscapeSQLIdent(details.SlotName)
+			if _, err := conn.Exec(ctx, query).ReadAll(); err != nil &&
+				!isPublisherError(err, pgcode.UndefinedObject) {
+				return err
+			}
+			return nil
+		}); err != nil {
+		log.Warningf(ctx, "failed to drop replication slot %s: %v", details.SlotName, err)
+	}
+	return nil
+}
+
+// CollectProfile implements the jobs.Resumer interface.
+func (r *pgSubscriptionResumer) CollectProfile(context.Context, interface{}) error {
+	return nil
+}
+
+// publisherConnConfig resolves the external connection named by the
+// subscription's connection URI into the configuration of a replication
+// connection to the publisher.
+func publisherConnConfig(ctx context.Context, db isql.DB, configUri string) (*pgconn.Config, error) {
+	clusterUri, err := streamclient.LookupClusterUri(ctx, configUri, db)
+	if err != nil {
+		return nil, err
+	}
+	u := clusterUri.URL()
+	q := u.Query()
+	// The publisher is connected to directly, so the routing mode of
+	// CockroachDB stream clients doesn't apply, and the certificates must be
+	// stored in files.
+	if q.Has("sslinline") {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"sslinline is not supported by subscriptions")
+	}
+	q.Del(streamclient.RoutingModeKey)
+	u.RawQuery = q.Encode()
+	cfg, err := pgconn.ParseConfig(u.String())
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid connection URI")
+	}
+	cfg.RuntimeParams["replication"] = "database"
+	return cfg, nil
+}
+
+// connectToPublisher opens a replication connection to the publisher.
+func connectToPublisher(ctx context.Context, db isql.DB, configUri string) (*pgconn.PgConn, error) {
+	cfg, err := publisherConnConfig(ctx, db, configUri)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := pgconn.ConnectConfig(ctx, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to publisher")
+	}
+	return conn, nil
+}
+
+// startReplication starts streaming the changes of the subscription's
+// publications from the replication slot, starting after the given position.
+func startReplication(
+	ctx context.Context, conn *pgconn.PgConn, details jobspb.PGSubscriptionDetails, start lsn.LSN,
+) error {
+	pubs := make([]string, len(details.Publications))
+	for i, pub := range details.Publications {
+		pubs[i] = lexbase.EscapeSQLIdent(pub)
+	}
+	query := fmt.Sprintf(
+		"START_REPLICATION SLOT %s LOGICAL %s (proto_version '1', publication_names %s)",
+		lexbase.EscapeSQLIdent(details.SlotName), start, lexbase.EscapeSQLString(strings.Join(pubs, ",")),
+	)
+	fe := conn.Frontend()
+	fe.Send(&pgproto3.Query{String: query})
+	if err := fe.Flush(); err != nil {
+		return errors.Wrap(err, "starting replication")
+	}
+	for {
+		msg, err := conn.ReceiveMessage(ctx)
+		if err != nil {
+			return errors.Wrap(err, "starting replication")
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.CopyBothResponse:
+			return nil
+		case *pgproto3.ErrorResponse:
+			return pgconn.ErrorResponseToPgError(msg)
+		}
+	}
+}
+
+// sendStandbyStatus reports to the publisher that all changes up to pos were
+// applied, allowing it to advance the replication slot.
+func sendStandbyStatus(conn *pgconn.PgConn, pos lsn.LSN) error {
+	fe := conn.Frontend()
+	fe.Send(&pgproto3.CopyData{Data: pgoutput.AppendStandbyStatusUpdate(nil, pgoutput.StandbyStatusUpdate{
+		Written:    pos,
+		Flushed:    pos,
+		Applied:    pos,
+		ClientTime: timeutil.Now(),
+	})})
+	return errors.Wrap(fe.Flush(), "sending standby status update")
+}
+
+func isPublisherError(err error, code pgcode.Code) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code.String()
+}
+
+// subscribedRelation maps an upstream relation to a local table.
+type subscribedRelation struct {
+	table catalog.TableDescriptor
+	// numUpstreamCols is the number of columns of the upstream relation.
+	numUpstreamCols int
+	// The names and types of the writable columns of the table.
+	names []string
+	types []*types.T
+	// upstreamOrds maps each column of the table to the ordinal of the upstream
+	// column of the same name, or -1 if there is none.
+	upstreamOrds []int
+	// keyOrds are the ordinals of the primary key columns of the table.
+	keyOrds []int
+}
+
+// pendingChange is a change of the transaction being received.
+type pendingChange struct {
+	rel *subscribedRelation
+	msg pgoutput.Message
+}
+
+// pgSubscriptionApplier applies the transactions received from the publisher.
+type pgSubscriptionApplier struct {
+	db      *sql.InternalDB
+	evalCtx *eval.Context
+	dbID    descpb.ID
+	sd      *sessiondata.SessionData
+	ie      isql.Executor
+	querier *lwwQuerier
+	alloc   tree.DatumAlloc
+
+	relations map[oid.Oid]*subscribedRelation
+	// versions holds the version of each table added to the querier.
+	versions map[descpb.ID]descpb.DescriptorVersion
+
+	inTxn   bool
+	pending []pendingChange
+	// ts is the origin timestamp of the next change. Changes of the same
+	// upstream transaction share its commit time, so the logical component is
+	// incremented for each change to let later changes to a row win over
+	// earlier ones.
+	ts hlc.Timestamp
+}
+
+func newPGSubscriptionApplier(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	evalCtx *eval.Context,
+	dbID descpb.ID,
+	jobID jobspb.JobID,
+) *pgSubscriptionApplier {
+	sd := sql.NewInternalSessionData(ctx, execCfg.Settings, "" /* opName */)
+	return &pgSubscriptionApplier{
+		db:      execCfg.InternalDB,
+		evalCtx: evalCtx,
+		dbID:    dbID,
+		sd:      sd,
+		ie:      execCfg.InternalDB.Executor(isql.WithSessionData(sd)),
+		querier: &lwwQuerier{
+			settings: execCfg.Settings,
+			codec:    execCfg.Codec,
+			db:       execCfg.InternalDB,
+			leaseMgr: execCfg.LeaseManager,
+			queryBuffer: queryBuffer{
+				deleteQueries: make(map[descpb.ID]queryBuilder),
+				insertQueries: make(map[descpb.ID]map[descpb.FamilyID]queryBuilder),
+			},
+			tombstoneUpdaters:          make(map[descpb.ID]*tombstoneUpdater),
+			ieOverrideOptimisticInsert: getIEOverride(replicatedOptimisticInsertOpName, jobID),
+			ieOverrideInsert:           getIEOverride(replicatedInsertOpName, jobID),
+			ieOverrideDelete:           getIEOverride(replicatedDeleteOpName, jobID),
+		},
+		relations: make(map[oid.Oid]*subscribedRelation),
+		versions:  make(map[descpb.ID]descpb.DescriptorVersion),
+	}
+}
+
+// handle processes a message of the replication stream. When the message
+// commits a transaction, the transaction is applied and the end position of
+// the commit is returned.
+func (a *pgSubscriptionApplier) handle(ctx context.Context, msg pgoutput.Message) (lsn.LSN, error) {
+	switch m := msg.(type) {
+	case *pgoutput.Begin:
+		a.inTxn = true
+		a.pending = a.pending[:0]
+	case *pgoutput.Commit:
+		if !a.inTxn {
+			return 0, pgerror.New(pgcode.ProtocolViolation, "commit received outside of a transaction")
+		}
+		if err := a.apply(ctx, m.CommitTime); err != nil {
+			return 0, err
+		}
+		a.inTxn = false
+		return m.EndLSN, nil
+	case *pgoutput.Relation:
+		rel, err := a.resolveRelation(ctx, m)
+		if err != nil {
+			return 0, err
+		}
+		a.relations[m.ID] = rel
+	case *pgoutput.Insert:
+		return 0, a.addChange(m.RelationID, m)
+	case *pgoutput.Update:
+		return 0, a.addChange(m.RelationID, m)
+	case *pgoutput.Delete:
+		return 0, a.addChange(m.RelationID, m)
+	case *pgoutput.Truncate:
+		return 0, jobs.MarkAsPermanentJobError(
+			unimplemented.New("subscription truncate", "replicating TRUNCATE is not supported"))
+	case *pgoutput.Origin, *pgoutput.CustomType:
+		// The origin of the upstream transaction is not tracked, and the types
+		// of columns are those of the local tables.
+	}
+	return 0, nil
+}
+
+func (a *pgSubscriptionApplier) addChange(relID oid.Oid, msg pgoutput.Message) error {
+	if !a.inTxn {
+		return pgerror.New(pgcode.ProtocolViolation, "change received outside of a transaction")
+	}
+	rel, ok := a.relations[relID]
+	if !ok {
+		return pgerror.Newf(pgcode.ProtocolViolation, "change received for unknown relation %d", relID)
+	}
+	a.pending = append(a.pending, pendingChange{rel: rel, msg: msg})
+	return nil
+}
+
+// resolveRelation finds the local table with the schema and name of an
+// upstream relation. Every upstream column must exist in the table; local
+// columns which are not replicated are set to NULL.
+func (a *pgSubscriptionApplier) resolveRelation(
+	ctx context.Context, rel *pgoutput.Relation,
+) (*subscribedRelation, error) {
+	var table catalog.TableDescriptor
+	if err := a.db.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		db, err := txn.Descriptors().ByIDWithLeased(txn.KV()).Get().Database(ctx, a.dbID)
+		if err != nil {
+			return err
+		}
+		sc, err := txn.Descriptors().ByNameWithLeased(txn.KV()).Get().Schema(ctx, db, rel.Namespace)
+		if err != nil {
+			return err
+		}
+		table, err = txn.Descriptors().ByNameWithLeased(txn.KV()).Get().Table(ctx, db, sc, rel.Name)
+		return err
+	}); err != nil {
+		return nil, errors.Wrapf(err, "resolving logical replication target relation %s.%s",
+			rel.Namespace, rel.Name)
+	}
+
+	cols, err := writeableColunms(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+	upstream := make(map[string]int, len(rel.Columns))
+	for i, col := range rel.Columns {
+		upstream[col.Name] = i
+	}
+	sr := &subscribedRelation{
+		table:           table,
+		numUpstreamCols: len(rel.Columns),
+		upstreamOrds:    make([]int, len(cols)),
+	}
+	local := make(map[string]struct{}, len(cols))
+	for i, col := range cols {
+		sr.names = append(sr.names, col.GetName())
+		sr.types = append(sr.types, col.GetType())
+		local[col.GetName()] = struct{}{}
+		ord, ok := upstream[col.GetName()]
+		if !ok {
+			ord = -1
+		}
+		sr.upstreamOrds[i] = ord
+	}
+	for _, col := range rel.Columns {
+		if _, ok := local[col.Name]; !ok {
+			return nil, pgerror.Newf(pgcode.UndefinedColumn,
+				"logical replication target relation %q.%q is missing replicated column %q",
+				rel.Namespace, rel.Name, col.Name)
+		}
+	}
+	keyCols := table.GetPrimaryIndex().CollectKeyColumnIDs()
+	for i, col := range cols {
+		if !keyCols.Contains(col.GetID()) {
+			continue
+		}
+		if sr.upstreamOrds[i] < 0 {
+			return nil, pgerror.Newf(pgcode.UndefinedColumn,
+				"primary key column %q of logical replication target relation %q.%q is not replicated",
+				col.GetName(), rel.Namespace, rel.Name)
+		}
+		sr.keyOrds = append(sr.keyOrds, i)
+	}
+
+	if v, ok := a.versions[table.GetID()]; !ok || v != table.GetVersion() {
+		if tu, ok := a.querier.tombstoneUpdaters[table.GetID()]; ok {
+			tu.ReleaseLeases(ctx)
+		}
+		if err := a.querier.AddTable(int32(table.GetID()), sqlProcessorTableConfig{srcDesc: table}); err != nil {
+			return nil, err
+		}
+		a.versions[table.GetID()] = table.GetVersion()
+	}
+	return sr, nil
+}
+
+// apply applies the changes of the received transaction in a single local
+// transaction.
+func (a *pgSubscriptionApplier) apply(ctx context.Context, commitTime time.Time) error {
+	if len(a.pending) == 0 {
+		return nil
+	}
+	err := a.db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		a.ts = hlc.Timestamp{WallTime: commitTime.UnixNano()}
+		for _, c := range a.pending {
+			if err := a.applyChange(ctx, txn, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, isql.WithSessionData(a.sd))
+	a.pending = a.pending[:0]
+	return err
+}
+
+func (a *pgSubscriptionApplier) applyChange(ctx context.Context, txn isql.Txn, c pendingChange) error {
+	switch m := c.msg.(type) {
+	case *pgoutput.Insert:
+		row, err := a.makeRow(ctx, txn, c.rel, m.New)
+		if err != nil {
+			return err
+		}
+		return a.insertRow(ctx, txn, c.rel, row, true /* likelyInsert */)
+	case *pgoutput.Update:
+		if m.Old != nil && keyChanged(c.rel, m.Old, m.New) {
+			old, err := a.makeRow(ctx, txn, c.rel, m.Old)
+			if err != nil {
+				return err
+			}
+			if err := a.deleteRow(ctx, txn, old); err != nil {
+				return err
+			}
+		}
+		row, err := a.makeRow(ctx, txn, c.rel, m.New)
+		if err != nil {
+			return err
+		}
+		return a.insertRow(ctx, txn, c.rel, row, false /* likelyInsert */)
+	case *pgoutput.Delete:
+		row, err := a.makeRow(ctx, txn, c.rel, m.Old)
+		if err != nil {
+			return err
+		}
+		return a.deleteRow(ctx, txn, row)
+	default:
+		return errors.AssertionFailedf("unexpected change %T", c.msg)
+	}
+}
+
+func (a *pgSubscriptionApplier) nextTimestamp() hlc.Timestamp {
+	a.ts.Logical++
+	return a.ts
+}
+
+func (a *pgSubscriptionApplier) insertRow(
+	ctx context.Context, txn isql.Txn, rel *subscribedRelation, row cdcevent.Row, likelyInsert bool,
+) error {
+	return rel.table.ForeachFamily(func(family *descpb.ColumnFamilyDescriptor) error {
+		row.FamilyID = family.ID
+		row.MvccTimestamp = a.nextTimestamp()
+		_, err := a.querier.InsertRow(ctx, txn, a.ie, row, nil /* prevRow */, likelyInsert)
+		return err
+	})
+}
+
+func (a *pgSubscriptionApplier) deleteRow(ctx context.Context, txn isql.Txn, row cdcevent.Row) error {
+	row.MvccTimestamp = a.nextTimestamp()
+	_, err := a.querier.DeleteRow(ctx, txn, a.ie, row, nil /* prevRow */)
+	return err
+}
+
+// makeRow decodes an upstream tuple into a row of the local table.
+func (a *pgSubscriptionApplier) makeRow(
+	ctx context.Context, txn isql.Txn, rel *subscribedRelation, tuple pgoutput.Tuple,
+) (cdcevent.Row, error) {
+	if len(tuple) != rel.numUpstreamCols {
+		return cdcevent.Row{}, pgerror.Newf(pgcode.ProtocolViolation,
+			"expected %d columns for relation %s, found %d",
+			rel.numUpstreamCols, rel.table.GetName(), len(tuple))
+	}
+	datums := make(tree.Datums, len(rel.upstreamOrds))
+	var unchanged []int
+	for i, ord := range rel.upstreamOrds {
+		datums[i] = tree.DNull
+		if ord < 0 {
+			continue
+		}
+		v := tuple[ord]
+		format := pgwirebase.FormatText
+		switch v.Kind {
+		case pgoutput.ValueNull:
+			continue
+		case pgoutput.ValueUnchanged:
+			unchanged = append(unchanged, i)
+			continue
+		case pgoutput.ValueBinary:
+			format = pgwirebase.FormatBinary
+		}
+		d, err := pgwirebase.DecodeDatum(ctx, a.evalCtx, rel.types[i], format, v.Data, &a.alloc)
+		if err != nil {
+			return cdcevent.Row{}, errors.Wrapf(err, "decoding column %q of %s",
+				rel.names[i], rel.table.GetName())
+		}
+		datums[i] = d
+	}
+	if len(unchanged) > 0 {
+		if err := a.readUnchanged(ctx, txn, rel, datums, unchanged); err != nil {
+			return cdcevent.Row{}, err
+		}
+	}
+	row := cdcevent.MakeRowFromTuple(ctx, a.evalCtx,
+		tree.NewDTuple(types.MakeLabeledTuple(rel.types, rel.names), datums...))
+	row.TableID = rel.table.GetID()
+	return row, nil
+}
+
+// readUnchanged fills in the values of columns the publisher did not send
+// because they are unchanged (TOASTed) from the local row.
+func (a *pgSubscriptionApplier) readUnchanged(
+	ctx context.Context, txn isql.Txn, rel *subscribedRelation, datums tree.Datums, unchanged []int,
+) error {
+	cols := make([]string, len(unchanged))
+	for i, ord := range unchanged {
+		cols[i] = rel.names[ord]
+	}
+	preds := make([]string, len(rel.keyOrds))
+	args := make([]interface{}, len(rel.keyOrds))
+	for i, ord := range rel.keyOrds {
+		preds[i] = fmt.Sprintf("%s = $%d", lexbase.EscapeSQLIdent(rel.names[ord]), i+1)
+		args[i] = datums[ord]
+	}
+	query := fmt.Sprintf("SELECT %s FROM [%d AS t] WHERE %s",
+		sqlEscapedJoin(cols, ", "), rel.table.GetID(), strings.Join(preds, " AND "))
+	row, err := a.ie.QueryRowEx(ctx, "pg-subscription-read-unchanged", txn.KV(),
+		sessiondata.NoSessionDataOverride, query, args...)
+	if err != nil || row == nil {
+		return err
+	}
+	for i, ord := range unchanged {
+		datums[ord] = row[i]
+	}
+	return nil
+}
+
+// keyChanged returns whether an update changed the primary key of a row.
+func keyChanged(rel *subscribedRelation, old, new pgoutput.Tuple) bool {
+	if len(old) != len(new) {
+		return true
+	}
+	for _, ord := range rel.keyOrds {
+		i := rel.upstreamOrds[ord]
+		if old[i].Kind != new[i].Kind || string(old[i].Data) != string(new[i].Data) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	jobs.RegisterConstructor(
+		jobspb.TypePGSubscription,
+		func(job *jobs.Job, _ *cluster.Settings) jobs.Resumer {
+			return &pgSubscriptionResumer{job: job}
+		},
+		jobs.UsesTenantCostControl,
+	)
+}
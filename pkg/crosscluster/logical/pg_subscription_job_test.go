@@ -0,0 +1,121 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package logical
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/jobutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/skip"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// TestPGSubscription subscribes to a publication of another database of the
+// same cluster through the walsender, and checks that its changes are applied,
+// that the subscription resumes from its confirmed position, and that
+// dropping it cleans up its job and replication slot.
+func TestPGSubscription(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	skip.UnderDeadlock(t)
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	sysDB := sqlutils.MakeSQLRunner(srv.SystemLayer().SQLConn(t))
+	sysDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sysDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '100ms'`)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `SET CLUSTER SETTING logical_replication.pg_subscription.status_interval = '100ms'`)
+	sqlDB.Exec(t, `CREATE DATABASE pubdb`)
+	sqlDB.Exec(t, `CREATE DATABASE subdb`)
+	sqlDB.Exec(t, `CREATE TABLE pubdb.t (k INT PRIMARY KEY, v STRING)`)
+	sqlDB.Exec(t, `CREATE TABLE subdb.t (k INT PRIMARY KEY, v STRING)`)
+	pubDB := sqlutils.MakeSQLRunner(s.SQLConn(t, serverutils.DBName("pubdb")))
+	pubDB.Exec(t, `CREATE PUBLICATION p FOR TABLE t`)
+
+	pubURL, cleanup := s.PGUrl(t, serverutils.DBName("pubdb"))
+	defer cleanup()
+	sqlDB.Exec(t, fmt.Sprintf(`CREATE EXTERNAL CONNECTION pub AS '%s'`, pubURL.String()))
+
+	subDB := sqlutils.MakeSQLRunner(s.SQLConn(t, serverutils.DBName("subdb")))
+	subDB.ExpectErr(t, "connection URI must be an external connection",
+		fmt.Sprintf(`CREATE SUBSCRIPTION s CONNECTION '%s' PUBLICATION p`, pubURL.String()))
+	subDB.ExpectErr(t, "subscription copy_data",
+		`CREATE SUBSCRIPTION s CONNECTION 'external://pub' PUBLICATION p WITH (copy_data = true)`)
+	subDB.Exec(t, `CREATE SUBSCRIPTION s CONNECTION 'external://pub' PUBLICATION p`)
+	subDB.ExpectErr(t, `subscription "s" already exists`,
+		`CREATE SUBSCRIPTION s CONNECTION 'external://pub' PUBLICATION p`)
+
+	var jobID jobspb.JobID
+	subDB.QueryRow(t,
+		`SELECT job_id FROM [SHOW JOBS] WHERE job_type = $1`, jobspb.TypePGSubscription.String(),
+	).Scan(&jobID)
+	// Changes are only streamed once the subscription has created its
+	// replication slot.
+	testutils.SucceedsSoon(t, func() error {
+		if !jobutils.GetJobProgress(t, subDB, jobID).GetPgSubscription().SlotCreated {
+			return errors.New("waiting for the replication slot to be created")
+		}
+		return nil
+	})
+
+	sqlDB.Exec(t, `INSERT INTO pubdb.t VALUES (1, 'a'), (2, 'b'), (3, 'c')`)
+	sqlDB.Exec(t, `UPDATE pubdb.t SET v = 'bb' WHERE k = 2`)
+	sqlDB.Exec(t, `DELETE FROM pubdb.t WHERE k = 3`)
+	subDB.CheckQueryResultsRetry(t, `SELECT k, v FROM t`, [][]string{{"1", "a"}, {"2", "bb"}})
+
+	// Wait for the applied changes to be checkpointed, then pause the
+	// subscription and make more changes. Once resumed, the subscription
+	// continues from its confirmed position.
+	testutils.SucceedsSoon(t, func() error {
+		progress := jobutils.GetJobProgress(t, subDB, jobID).GetPgSubscription()
+		if progress.ConfirmedLSN == 0 {
+			return errors.New("waiting for the subscription to confirm its position")
+		}
+		return nil
+	})
+	subDB.Exec(t, `PAUSE JOB $1`, jobID)
+	jobutils.WaitForJobToPause(t, subDB, jobID)
+	confirmed := jobutils.GetJobProgress(t, subDB, jobID).GetPgSubscription().ConfirmedLSN
+
+	sqlDB.Exec(t, `INSERT INTO pubdb.t VALUES (4, 'd')`)
+	sqlDB.Exec(t, `DELETE FROM pubdb.t WHERE k = 1`)
+	subDB.CheckQueryResults(t, `SELECT k, v FROM t`, [][]string{{"1", "a"}, {"2", "bb"}})
+
+	subDB.Exec(t, `RESUME JOB $1`, jobID)
+	jobutils.WaitForJobToRun(t, subDB, jobID)
+	subDB.CheckQueryResultsRetry(t, `SELECT k, v FROM t`, [][]string{{"2", "bb"}, {"4", "d"}})
+	testutils.SucceedsSoon(t, func() error {
+		progress := jobutils.GetJobProgress(t, subDB, jobID).GetPgSubscription()
+		if progress.ConfirmedLSN <= confirmed {
+			return errors.Newf("confirmed LSN %d has not advanced past %d", progress.ConfirmedLSN, confirmed)
+		}
+		return nil
+	})
+
+	// Dropping the subscription cancels its job, which drops the replication
+	// slot it created on the publisher.
+	subDB.Exec(t, `DROP SUBSCRIPTION s`)
+	jobutils.WaitForJobToCancel(t, subDB, jobID)
+	sqlDB.CheckQueryResultsRetry(t,
+		`SELECT count(*) FROM system.protected_ts_records WHERE meta_type = 'pg_replication_slot'`,
+		[][]string{{"0"}})
+	subDB.ExpectErr(t, `subscription "s" does not exist`, `DROP SUBSCRIPTION s`)
+	subDB.Exec(t, `DROP SUBSCRIPTION IF EXISTS s`)
+}
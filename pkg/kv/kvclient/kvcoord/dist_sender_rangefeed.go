@@ -66,6 +66,7 @@ type rangeFeedConfig struct {
 	withFiltering         bool
 	withMetadata          bool
 	withMatchingOriginIDs []uint32
+	rowFilter             *kvpb.RangeFeedFilter
 	rangeObserver         RangeObserver
 	consumerID            int64
 
@@ -125,6 +126,15 @@ func WithMatchingOriginIDs(originIDs ...uint32) RangeFeedOption {
 	})
 }
 
+// WithRowFilter opts the rangefeed into server-side filtering and projection
+// of the rows of a table. See kvpb.RangeFeedFilter for the guarantees that it
+// provides.
+func WithRowFilter(filter *kvpb.RangeFeedFilter) RangeFeedOption {
+	return optionFunc(func(c *rangeFeedConfig) {
+		c.rowFilter = filter
+	})
+}
+
 // WithRangeObserver is called when the rangefeed starts with a function that
 // can be used to iterate over all the ranges.
 func WithRangeObserver(observer RangeObserver) RangeFeedOption {
@@ -631,6 +641,7 @@ func makeRangeFeedRequest(
 	withDiff bool,
 	withFiltering bool,
 	withMatchingOriginIDs []uint32,
+	rowFilter *kvpb.RangeFeedFilter,
 	consumerID int64,
 ) kvpb.RangeFeedRequest {
 	admissionPri := admissionpb.BulkNormalPri
@@ -647,6 +658,7 @@ func makeRangeFeedRequest(
 		WithDiff:              withDiff,
 		WithFiltering:         withFiltering,
 		WithMatchingOriginIDs: withMatchingOriginIDs,
+		RowFilter:             rowFilter,
 		AdmissionHeader: kvpb.AdmissionHeader{
 			// NB: AdmissionHeader is used only at the start of the range feed
 			// stream since the initial catch-up scan is expensive.
@@ -39,6 +39,7 @@ type config struct {
 	withDiff              bool
 	withFiltering         bool
 	withMatchingOriginIDs []uint32
+	rowFilter             *kvpb.RangeFeedFilter
 	consumerID            int64
 	onUnrecoverableError  OnUnrecoverableError
 	onCheckpoint          OnCheckpoint
@@ -160,6 +161,17 @@ func WithOriginIDsMatching(originIDs ...uint32) Option {
 	})
 }
 
+// WithRowFilter makes an option to have the rangefeed server filter and
+// project the rows of a table before sending their values. The filter is
+// best-effort, as described on kvpb.RangeFeedFilter, so the OnValue callback
+// must still be prepared to see rows that don't satisfy it. The filter does
+// not apply to the initial scan.
+func WithRowFilter(filter *kvpb.RangeFeedFilter) Option {
+	return optionFunc(func(c *config) {
+		c.rowFilter = filter
+	})
+}
+
 func WithConsumerID(cid int64) Option {
 	return optionFunc(func(c *config) {
 		c.consumerID = cid
@@ -344,6 +344,9 @@ func (f *RangeFeed) run(ctx context.Context, frontier span.Frontier, resumeWithF
 	if len(f.withMatchingOriginIDs) != 0 {
 		rangefeedOpts = append(rangefeedOpts, kvcoord.WithMatchingOriginIDs(f.withMatchingOriginIDs...))
 	}
+	if f.rowFilter != nil {
+		rangefeedOpts = append(rangefeedOpts, kvcoord.WithRowFilter(f.rowFilter))
+	}
 	if f.onMetadata != nil {
 		rangefeedOpts = append(rangefeedOpts, kvcoord.WithMetadata())
 	}
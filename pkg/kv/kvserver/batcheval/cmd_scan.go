@@ -7,14 +7,19 @@ package batcheval
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/storage/fs"
 	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
 
 func init() {
@@ -74,6 +79,10 @@ func Scan(
 			return result.Result{}, err
 		}
 		reply.BatchResponses = scanRes.KVData
+		if args.RowFilter != nil && !args.ReturnRawMVCCValues {
+			reply.BatchResponses = filterBatchResponses(
+				ctx, cArgs.EvalCtx.ClusterSettings(), args.RowFilter, scanRes.KVData)
+		}
 	case kvpb.COL_BATCH_RESPONSE:
 		scanRes, err = storage.MVCCScanToCols(
 			ctx, readWriter, cArgs.Header.IndexFetchSpec, args.Key, args.EndKey,
@@ -132,6 +141,64 @@ func Scan(
 	return res, nil
 }
 
+// filterBatchResponses applies the given row filter to the key-value pairs of
+// a BATCH_RESPONSE: the values that don't match it are omitted, and the others
+// are projected. Like the filter of rangefeeds, it is best-effort, so the
+// key-value pairs on which it fails to be evaluated are returned unchanged.
+//
+// The filtered key-value pairs are copied into a new buffer, which is not
+// accounted for since it's no larger than the buffers of the scan.
+func filterBatchResponses(
+	ctx context.Context, st *cluster.Settings, filter *kvpb.RangeFeedFilter, batches [][]byte,
+) [][]byte {
+	if rangefeed.NewRowFilter == nil {
+		return batches
+	}
+	f, err := rangefeed.NewRowFilter(ctx, st, filter)
+	if err != nil {
+		log.VEventf(ctx, 2, "failed to create scan row filter: %v", err)
+		return batches
+	}
+	var buf []byte
+	for _, repr := range batches {
+		for len(repr) > 0 {
+			key, rawBytes, rest, err := storage.MVCCScanDecodeKeyValue(repr)
+			if err != nil {
+				log.VEventf(ctx, 2, "failed to decode scan response: %v", err)
+				return batches
+			}
+			kv := repr[:len(repr)-len(rest)]
+			repr = rest
+
+			value := roachpb.Value{RawBytes: rawBytes, Timestamp: key.Timestamp}
+			matches, err := f.Matches(ctx, key.Key, value)
+			if err != nil {
+				log.VEventf(ctx, 2, "failed to evaluate scan row filter on key %s: %v", key.Key, err)
+				buf = append(buf, kv...)
+				continue
+			}
+			if !matches {
+				continue
+			}
+			projected, err := f.Project(ctx, key.Key, value)
+			if err != nil {
+				log.VEventf(ctx, 2, "failed to project value of key %s: %v", key.Key, err)
+				buf = append(buf, kv...)
+				continue
+			}
+			encKey := storage.EncodeMVCCKey(key)
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(projected.RawBytes)))
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(encKey)))
+			buf = append(buf, encKey...)
+			buf = append(buf, projected.RawBytes...)
+		}
+	}
+	if len(buf) == 0 {
+		return nil
+	}
+	return [][]byte{buf}
+}
+
 func ScanReadCategory(ah kvpb.AdmissionHeader) fs.ReadCategory {
 	readCategory := fs.ScanRegularBatchEvalReadCategory
 	if admissionpb.WorkPriority(ah.Priority) < admissionpb.NormalPri {
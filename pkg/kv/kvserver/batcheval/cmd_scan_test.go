@@ -13,6 +13,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rangefeed"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -394,6 +395,90 @@ func TestScanReturnRawMVCC(t *testing.T) {
 	})
 }
 
+// testRowFilter is a rangefeed.RowFilter that omits the values of the key
+// "b" and projects the other values to "projected".
+type testRowFilter struct{}
+
+func (testRowFilter) Matches(_ context.Context, key roachpb.Key, _ roachpb.Value) (bool, error) {
+	return !key.Equal(roachpb.Key("b")), nil
+}
+
+func (testRowFilter) Project(
+	_ context.Context, _ roachpb.Key, value roachpb.Value,
+) (roachpb.Value, error) {
+	ret := roachpb.MakeValueFromString("projected")
+	ret.Timestamp = value.Timestamp
+	return ret, nil
+}
+
+func TestScanRowFilter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	defer func(prev func(
+		context.Context, *cluster.Settings, *kvpb.RangeFeedFilter,
+	) (rangefeed.RowFilter, error)) {
+		rangefeed.NewRowFilter = prev
+	}(rangefeed.NewRowFilter)
+	rangefeed.NewRowFilter = func(
+		context.Context, *cluster.Settings, *kvpb.RangeFeedFilter,
+	) (rangefeed.RowFilter, error) {
+		return testRowFilter{}, nil
+	}
+
+	clock := hlc.NewClockForTesting(nil)
+	settings := cluster.MakeTestingClusterSettings()
+	evalCtx := (&MockEvalCtx{Clock: clock, ClusterSettings: settings}).EvalContext()
+	db := storage.NewDefaultInMemForTesting()
+	defer db.Close()
+
+	ts := clock.Now()
+	for _, k := range []string{"a", "b", "c"} {
+		_, err := storage.MVCCPut(
+			ctx, db, roachpb.Key(k), ts, roachpb.MakeValueFromString(k), storage.MVCCWriteOptions{},
+		)
+		require.NoError(t, err)
+	}
+
+	scan := func(format kvpb.ScanFormat, filter *kvpb.RangeFeedFilter) kvpb.ScanResponse {
+		resp := kvpb.ScanResponse{}
+		_, err := Scan(ctx, db, CommandArgs{
+			EvalCtx: evalCtx,
+			Header:  kvpb.Header{Timestamp: clock.Now()},
+			Args: &kvpb.ScanRequest{
+				RequestHeader: kvpb.RequestHeader{Key: roachpb.Key("a"), EndKey: roachpb.Key("d")},
+				ScanFormat:    format,
+				RowFilter:     filter,
+			},
+		}, &resp)
+		require.NoError(t, err)
+		return resp
+	}
+	decode := func(batches [][]byte) map[string]string {
+		kvs := make(map[string]string)
+		require.NoError(t, storage.MVCCScanDecodeKeyValues(batches,
+			func(key storage.MVCCKey, rawBytes []byte) error {
+				require.Equal(t, ts, key.Timestamp)
+				b, err := roachpb.Value{RawBytes: rawBytes}.GetBytes()
+				kvs[string(key.Key)] = string(b)
+				return err
+			}))
+		return kvs
+	}
+
+	resp := scan(kvpb.BATCH_RESPONSE, &kvpb.RangeFeedFilter{})
+	require.Equal(t, map[string]string{"a": "projected", "c": "projected"}, decode(resp.BatchResponses))
+	// The scanned keys are still counted.
+	require.Equal(t, int64(3), resp.NumKeys)
+
+	resp = scan(kvpb.BATCH_RESPONSE, nil /* filter */)
+	require.Equal(t, map[string]string{"a": "a", "b": "b", "c": "c"}, decode(resp.BatchResponses))
+
+	// The filter is ignored with the other formats.
+	resp = scan(kvpb.KEY_VALUES, &kvpb.RangeFeedFilter{})
+	require.Len(t, resp.Rows, 3)
+}
+
 // makeRowKey makes a key for a SQL row for use in tests, using the system
 // tenant with table 1 index 1, and a single column.
 func makeRowKey(t *testing.T, id int, columnFamily uint32) roachpb.Key {
@@ -195,6 +195,7 @@ type Processor interface {
 		withDiff bool,
 		withFiltering bool,
 		withOmitRemote bool,
+		rowFilter RowFilter,
 		stream Stream,
 	) (bool, Disconnector, *Filter)
 
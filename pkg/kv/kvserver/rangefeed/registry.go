@@ -63,6 +63,8 @@ type registration interface {
 	getWithFiltering() bool
 	// getWithOmitRemote returns the withOmitRemote field of the registration.
 	getWithOmitRemote() bool
+	// getRowFilter returns the rowFilter field of the registration.
+	getRowFilter() RowFilter
 	// Range returns the keys field of the registration.
 	Range() interval.Range
 	// ID returns the id field of the registration as a uintptr.
@@ -86,6 +88,9 @@ type baseRegistration struct {
 	withDiff       bool
 	withFiltering  bool
 	withOmitRemote bool
+	// rowFilter, if set, filters and projects the values emitted to the
+	// registration.
+	rowFilter RowFilter
 	// removeRegFromProcessor is called to remove the registration from its
 	// processor. This is provided by the creator of the registration and called
 	// during disconnect(). Since it is called during disconnect it must be
@@ -136,6 +141,10 @@ func (r *baseRegistration) getWithOmitRemote() bool {
 	return r.withOmitRemote
 }
 
+func (r *baseRegistration) getRowFilter() RowFilter {
+	return r.rowFilter
+}
+
 func (r *baseRegistration) shouldUnregister() bool {
 	return r.shouldUnreg.Load()
 }
@@ -370,9 +379,12 @@ func (reg *registry) PublishToOverlapping(
 		// Don't publish events if they:
 		// 1. are equal to or less than the registration's starting timestamp, or
 		// 2. have OmitInRangefeeds = true and this registration has opted into filtering, or
-		// 3. have OmitRemote = true and this value is from a remote cluster.
+		// 3. have OmitRemote = true and this value is from a remote cluster, or
+		// 4. are values filtered out by the row filter of the registration.
 		if r.getCatchUpTimestamp().Less(minTS) && !(r.getWithFiltering() && valueMetadata.omitInRangefeeds) && (!r.getWithOmitRemote() || valueMetadata.originID == 0) {
-			r.publish(ctx, event, alloc)
+			if event, ok := applyRowFilter(ctx, r.getRowFilter(), event); ok {
+				r.publish(ctx, event, alloc)
+			}
 		}
 		return false, nil
 	})
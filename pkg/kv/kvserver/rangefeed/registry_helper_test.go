@@ -7,6 +7,7 @@ package rangefeed
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -294,6 +295,12 @@ func withOmitRemote(opt bool) registrationOption {
 	}
 }
 
+func withRowFilter(f RowFilter) registrationOption {
+	return func(cfg *testRegistrationConfig) {
+		cfg.rowFilter = f
+	}
+}
+
 func withRegistrationType(regType registrationType) registrationOption {
 	return func(cfg *testRegistrationConfig) {
 		cfg.withRegistrationTestTypes = regType
@@ -312,6 +319,39 @@ func withStartTs(ts hlc.Timestamp) registrationOption {
 	}
 }
 
+// testRowFilter is a RowFilter over string values. It omits the values that
+// start with "skip", fails to evaluate on the value "error", and projects
+// values by upper-casing them.
+type testRowFilter struct{}
+
+var _ RowFilter = testRowFilter{}
+
+func (testRowFilter) Matches(_ context.Context, _ roachpb.Key, value roachpb.Value) (bool, error) {
+	if !value.IsPresent() {
+		return true, nil
+	}
+	b, err := value.GetBytes()
+	if err != nil {
+		return false, err
+	}
+	if string(b) == "error" {
+		return false, errors.New("cannot evaluate filter")
+	}
+	return !strings.HasPrefix(string(b), "skip"), nil
+}
+
+func (testRowFilter) Project(
+	_ context.Context, _ roachpb.Key, value roachpb.Value,
+) (roachpb.Value, error) {
+	b, err := value.GetBytes()
+	if err != nil {
+		return roachpb.Value{}, err
+	}
+	projected := roachpb.MakeValueFromString(strings.ToUpper(string(b)))
+	projected.Timestamp = value.Timestamp
+	return projected, nil
+}
+
 type registrationType bool
 
 const (
@@ -338,6 +378,7 @@ type testRegistrationConfig struct {
 	withDiff                  bool
 	withFiltering             bool
 	withOmitRemote            bool
+	rowFilter                 RowFilter
 	withRegistrationTestTypes registrationType
 	metrics                   *Metrics
 }
@@ -361,6 +402,7 @@ func newTestRegistration(s *testStream, opts ...registrationOption) testRegistra
 			cfg.withDiff,
 			cfg.withFiltering,
 			cfg.withOmitRemote,
+			cfg.rowFilter,
 			5,
 			false, /* blockWhenFull */
 			cfg.metrics,
@@ -376,6 +418,7 @@ func newTestRegistration(s *testStream, opts ...registrationOption) testRegistra
 			cfg.withDiff,
 			cfg.withFiltering,
 			cfg.withOmitRemote,
+			cfg.rowFilter,
 			5,
 			cfg.metrics,
 			&testBufferedStream{Stream: s},
@@ -231,6 +231,52 @@ func TestRegistryWithOmitOrigin(t *testing.T) {
 	})
 }
 
+// TestRegistrationWithRowFilter verifies that the row filter of a registration
+// is applied to the values of both its catch-up scan and its live events.
+func TestRegistrationWithRowFilter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	testutils.RunValues(t, "registration type=", registrationTestTypes, func(t *testing.T, rt registrationType) {
+		reg := makeRegistry(NewMetrics())
+		s := newTestStream()
+		r := newTestRegistration(s, withRSpan(spBC),
+			withStartTs(hlc.Timestamp{WallTime: 1}),
+			withCatchUpIter(newTestIterator([]storage.MVCCKeyValue{
+				makeKV("b", "val1", 10),
+				makeKV("bc", "skip2", 11),
+				makeKV("bd", "val3", 12),
+			}, nil)), withDiff(true), withRowFilter(testRowFilter{}), withRegistrationType(rt))
+		reg.Register(ctx, r)
+		go r.runOutputLoop(ctx, 0)
+		defer r.Disconnect(nil)
+
+		publish := func(ev *kvpb.RangeFeedEvent) {
+			reg.PublishToOverlapping(ctx, spBC, ev, logicalOpMetadata{}, nil /* alloc */)
+		}
+		deletion := roachpb.Value{RawBytes: []byte{}, Timestamp: hlc.Timestamp{WallTime: 22}}
+		checkpoint := rangeFeedCheckpoint(spBC, hlc.Timestamp{WallTime: 23})
+		publish(rangeFeedValueWithPrev(roachpb.Key("b"), makeValWithTs("val4", 20), makeVal("val1")))
+		publish(rangeFeedValueWithPrev(roachpb.Key("bc"), makeValWithTs("skip5", 21), makeVal("skip2")))
+		// Deletions are emitted regardless of the filter.
+		publish(rangeFeedValueWithPrev(roachpb.Key("bd"), deletion, makeVal("val3")))
+		// Values on which the filter cannot be evaluated are emitted unchanged.
+		publish(rangeFeedValue(roachpb.Key("be"), makeValWithTs("error", 23)))
+		publish(checkpoint)
+
+		require.NoError(t, reg.waitForCaughtUp(ctx, all))
+		require.Equal(t, []*kvpb.RangeFeedEvent{
+			rangeFeedValue(roachpb.Key("b"), makeValWithTs("VAL1", 10)),
+			rangeFeedValue(roachpb.Key("bd"), makeValWithTs("VAL3", 12)),
+			rangeFeedValueWithPrev(roachpb.Key("b"), makeValWithTs("VAL4", 20), makeVal("VAL1")),
+			rangeFeedValueWithPrev(roachpb.Key("bd"), deletion, makeVal("VAL3")),
+			rangeFeedValue(roachpb.Key("be"), makeValWithTs("error", 23)),
+			checkpoint,
+		}, s.GetAndClearEvents())
+		require.Nil(t, s.Error())
+	})
+}
+
 func TestRegistryBasic(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	ctx := context.Background()
@@ -0,0 +1,99 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package rangefeed
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// RowFilter evaluates the kvpb.RangeFeedFilter of a registration on the
+// values that are emitted to it. Unlike Filter, which determines the
+// operations that the Processor needs, a RowFilter is specific to a single
+// registration.
+//
+// A RowFilter is used both by the catch-up scan and by the Processor, so it
+// must be safe for concurrent use.
+type RowFilter interface {
+	// Matches returns whether the value of the given key must be emitted. It
+	// must return true for deletions, i.e. values that are not present, on
+	// the selected column families.
+	Matches(ctx context.Context, key roachpb.Key, value roachpb.Value) (bool, error)
+	// Project returns the given value, which must be present, with only the
+	// projected columns. The timestamp of the value is preserved.
+	Project(ctx context.Context, key roachpb.Key, value roachpb.Value) (roachpb.Value, error)
+}
+
+// NewRowFilter returns a RowFilter for the given kvpb.RangeFeedFilter. It's
+// injected from pkg/sql/rangefeedfilter to avoid circular dependencies since
+// kvserver can't depend on the SQL layer. If it is nil, the filters of
+// rangefeed requests are ignored.
+var NewRowFilter func(
+	ctx context.Context, st *cluster.Settings, filter *kvpb.RangeFeedFilter,
+) (RowFilter, error)
+
+// applyRowFilter applies the row filter of a registration to an event. It
+// returns false if the event must not be emitted and otherwise returns the
+// event to emit, with projected values. Events other than RangeFeedValue are
+// returned unchanged.
+//
+// The filter is best-effort: if it cannot be evaluated on a value, the event
+// is emitted unchanged. The consumer is expected to evaluate it again.
+func applyRowFilter(
+	ctx context.Context, f RowFilter, event *kvpb.RangeFeedEvent,
+) (*kvpb.RangeFeedEvent, bool) {
+	if f == nil {
+		return event, true
+	}
+	t, ok := event.GetValue().(*kvpb.RangeFeedValue)
+	if !ok {
+		return event, true
+	}
+	matches, err := f.Matches(ctx, t.Key, t.Value)
+	if err != nil {
+		log.VEventf(ctx, 2, "failed to evaluate rangefeed row filter on key %s: %v", t.Key, err)
+		return event, true
+	}
+	if !matches {
+		return nil, false
+	}
+
+	value, prevValue := t.Value, t.PrevValue
+	if value.IsPresent() {
+		if value, err = f.Project(ctx, t.Key, value); err != nil {
+			log.VEventf(ctx, 2, "failed to project value of key %s: %v", t.Key, err)
+			return event, true
+		}
+	}
+	if prevValue.IsPresent() {
+		if prevValue, err = f.Project(ctx, t.Key, prevValue); err != nil {
+			log.VEventf(ctx, 2, "failed to project previous value of key %s: %v", t.Key, err)
+			return event, true
+		}
+	}
+	ret := event.ShallowCopy()
+	v := ret.GetValue().(*kvpb.RangeFeedValue)
+	v.Value, v.PrevValue = value, prevValue
+	return ret, true
+}
+
+// outputWithRowFilter wraps an outputEventFn so that the events that it
+// outputs are filtered and projected by the given row filter, if any.
+func outputWithRowFilter(ctx context.Context, f RowFilter, outputFn outputEventFn) outputEventFn {
+	if f == nil {
+		return outputFn
+	}
+	return func(event *kvpb.RangeFeedEvent) error {
+		if event, ok := applyRowFilter(ctx, f, event); ok {
+			return outputFn(event)
+		}
+		return nil
+	}
+}
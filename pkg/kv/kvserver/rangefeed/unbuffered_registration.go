@@ -98,6 +98,7 @@ func newUnbufferedRegistration(
 	withDiff bool,
 	withFiltering bool,
 	withOmitRemote bool,
+	rowFilter RowFilter,
 	bufferSz int,
 	metrics *Metrics,
 	stream BufferedStream,
@@ -111,6 +112,7 @@ func newUnbufferedRegistration(
 			withDiff:               withDiff,
 			withFiltering:          withFiltering,
 			withOmitRemote:         withOmitRemote,
+			rowFilter:              rowFilter,
 			removeRegFromProcessor: removeRegFromProcessor,
 		},
 		metrics: metrics,
@@ -363,8 +365,8 @@ func (ubr *unbufferedRegistration) maybeRunCatchUpScan(ctx context.Context) erro
 		ubr.metrics.RangeFeedCatchUpScanNanos.Inc(timeutil.Since(start).Nanoseconds())
 	}()
 
-	return catchUpIter.CatchUpScan(ctx, ubr.stream.SendUnbuffered, ubr.withDiff, ubr.withFiltering,
-		ubr.withOmitRemote)
+	return catchUpIter.CatchUpScan(ctx, outputWithRowFilter(ctx, ubr.rowFilter, ubr.stream.SendUnbuffered),
+		ubr.withDiff, ubr.withFiltering, ubr.withOmitRemote)
 }
 
 // Used for testing only.
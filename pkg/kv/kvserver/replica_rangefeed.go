@@ -252,6 +252,16 @@ func (r *Replica) RangeFeed(
 		return nil, errors.Errorf("multiple origin IDs and OriginID != 0 not supported yet")
 	}
 
+	// The row filter is an optimization that the client does not rely on, so
+	// it is ignored if the SQL layer did not provide an implementation.
+	var rowFilter rangefeed.RowFilter
+	if args.RowFilter != nil && rangefeed.NewRowFilter != nil {
+		rowFilter, err = rangefeed.NewRowFilter(streamCtx, r.ClusterSettings(), args.RowFilter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// If the RangeFeed is performing a catch-up scan then it will observe all
 	// values above args.Timestamp. If the RangeFeed is requesting previous
 	// values for every update then it will also need to look for the version
@@ -336,7 +346,8 @@ func (r *Replica) RangeFeed(
 	}
 
 	p, disconnector, err := r.registerWithRangefeedRaftMuLocked(
-		streamCtx, rSpan, args.Timestamp, catchUpIter, args.WithDiff, args.WithFiltering, omitRemote, stream,
+		streamCtx, rSpan, args.Timestamp, catchUpIter, args.WithDiff, args.WithFiltering, omitRemote,
+		rowFilter, stream,
 	)
 	r.raftMu.Unlock()
 
@@ -433,6 +444,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 	withDiff bool,
 	withFiltering bool,
 	withOmitRemote bool,
+	rowFilter rangefeed.RowFilter,
 	stream rangefeed.Stream,
 ) (rangefeed.Processor, rangefeed.Disconnector, error) {
 	defer logSlowRangefeedRegistration(streamCtx)()
@@ -454,7 +466,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 
 	if p != nil {
 		reg, disconnector, filter := p.Register(streamCtx, span, startTS, catchUpIter, withDiff, withFiltering, withOmitRemote,
-			stream)
+			rowFilter, stream)
 		if reg {
 			// Registered successfully with an existing processor.
 			// Update the rangefeed filter to avoid filtering ops
@@ -536,7 +548,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 	// this ensures that the only time the registration fails is during
 	// server shutdown.
 	reg, disconnector, filter := p.Register(streamCtx, span, startTS, catchUpIter, withDiff,
-		withFiltering, withOmitRemote, stream)
+		withFiltering, withOmitRemote, rowFilter, stream)
 	if !reg {
 		select {
 		case <-r.store.Stopper().ShouldQuiesce():
@@ -0,0 +1,215 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/server/status"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/logtags"
+)
+
+const (
+	otlpMetricsIntervalKey          = "external.otlp_metrics.interval"
+	remoteWriteIntervalKey          = "external.prometheus_remote_write.interval"
+	maxMetricsPushInterval          = 15 * time.Minute
+	metricsPushRequestTimeout       = 10 * time.Second
+	defaultMetricsPushInterval      = 10 * time.Second
+	defaultOTLPMetricsBatchSize     = 1000
+	defaultRemoteWriteBatchSize     = 2000
+	otlpMetricsExporterName         = "otlp"
+	remoteWriteMetricsExporterName  = "prometheus_remote_write"
+	otlpMetricsExporterDesc         = "the OpenTelemetry collector"
+	remoteWriteMetricsExporterDesc  = "the Prometheus remote-write endpoint"
+	metricsPushExporterInstanceAttr = "instance"
+)
+
+var (
+	// otlpMetricsEndpoint is the URL, if any, of the OTLP/HTTP metrics
+	// endpoint of an OpenTelemetry collector.
+	otlpMetricsEndpoint = settings.RegisterStringSetting(
+		settings.ApplicationLevel,
+		"external.otlp_metrics.endpoint",
+		"if nonempty, push server metrics to the OpenTelemetry collector at the specified URL "+
+			"using OTLP/HTTP (e.g. http://localhost:4318/v1/metrics)",
+		"",
+		settings.WithPublic)
+
+	// otlpMetricsInterval is how often metrics are pushed to the
+	// OpenTelemetry collector, if enabled.
+	otlpMetricsInterval = settings.RegisterDurationSetting(
+		settings.ApplicationLevel,
+		otlpMetricsIntervalKey,
+		"the interval at which metrics are pushed to the OpenTelemetry collector (if enabled)",
+		defaultMetricsPushInterval,
+		settings.NonNegativeDurationWithMaximum(maxMetricsPushInterval),
+		settings.WithPublic)
+
+	// otlpMetricsBatchSize is the maximum number of data points sent to the
+	// OpenTelemetry collector in a single request.
+	otlpMetricsBatchSize = settings.RegisterIntSetting(
+		settings.ApplicationLevel,
+		"external.otlp_metrics.batch_size",
+		"the maximum number of data points sent to the OpenTelemetry collector in a single request",
+		defaultOTLPMetricsBatchSize,
+		settings.PositiveInt,
+		settings.WithPublic)
+
+	// remoteWriteEndpoint is the URL, if any, of a Prometheus remote-write
+	// endpoint.
+	remoteWriteEndpoint = settings.RegisterStringSetting(
+		settings.ApplicationLevel,
+		"external.prometheus_remote_write.endpoint",
+		"if nonempty, push server metrics to the Prometheus remote-write endpoint at the specified URL",
+		"",
+		settings.WithPublic)
+
+	// remoteWriteInterval is how often metrics are pushed to the Prometheus
+	// remote-write endpoint, if enabled.
+	remoteWriteInterval = settings.RegisterDurationSetting(
+		settings.ApplicationLevel,
+		remoteWriteIntervalKey,
+		"the interval at which metrics are pushed to the Prometheus remote-write endpoint (if enabled)",
+		defaultMetricsPushInterval,
+		settings.NonNegativeDurationWithMaximum(maxMetricsPushInterval),
+		settings.WithPublic)
+
+	// remoteWriteBatchSize is the maximum number of series sent to the
+	// Prometheus remote-write endpoint in a single request.
+	remoteWriteBatchSize = settings.RegisterIntSetting(
+		settings.ApplicationLevel,
+		"external.prometheus_remote_write.batch_size",
+		"the maximum number of time series sent to the Prometheus remote-write endpoint in a single request",
+		defaultRemoteWriteBatchSize,
+		settings.PositiveInt,
+		settings.WithPublic)
+)
+
+// metricsPushExporters holds the self-metrics of the push-based metrics
+// exporters. They are registered when the server starts, even if the
+// exporters are never enabled, so that they always appear in the metric
+// catalog.
+type metricsPushExporters struct {
+	otlp        metric.PushExporterMetrics
+	remoteWrite metric.PushExporterMetrics
+}
+
+func makeMetricsPushExporters(registry *metric.Registry) *metricsPushExporters {
+	e := &metricsPushExporters{
+		otlp:        metric.MakePushExporterMetrics(otlpMetricsExporterName, otlpMetricsExporterDesc),
+		remoteWrite: metric.MakePushExporterMetrics(remoteWriteMetricsExporterName, remoteWriteMetricsExporterDesc),
+	}
+	registry.AddMetricStruct(&e.otlp)
+	registry.AddMetricStruct(&e.remoteWrite)
+	return e
+}
+
+// start begins pushing metrics to the OTLP and Prometheus remote-write
+// endpoints once they are configured. Each exporter runs in its own
+// task, so that a slow endpoint does not delay the other one. The
+// instance label identifies the process in the exported series.
+func (e *metricsPushExporters) start(
+	ctx context.Context,
+	stopper *stop.Stopper,
+	recorder *status.MetricsRecorder,
+	st *cluster.Settings,
+	instance string,
+) {
+	labels := map[string]string{metricsPushExporterInstanceAttr: instance}
+
+	var otlpOnce sync.Once
+	startOTLP := func(context.Context) {
+		if otlpMetricsEndpoint.Get(&st.SV) == "" {
+			return
+		}
+		otlpOnce.Do(func() {
+			exporter := metric.MakeOTLPExporter(&e.otlp)
+			startMetricsPushExporter(ctx, stopper, "otlp-metrics-exporter", otlpMetricsInterval, st,
+				func(ctx context.Context, pm *metric.PrometheusExporter) error {
+					endpoint := otlpMetricsEndpoint.Get(&st.SV)
+					if endpoint == "" {
+						return nil
+					}
+					return recorder.ExportToOTLP(ctx, &exporter, pm, metric.PushConfig{
+						Endpoint:  endpoint,
+						BatchSize: int(otlpMetricsBatchSize.Get(&st.SV)),
+						Labels:    labels,
+						Timeout:   metricsPushRequestTimeout,
+						Retry:     metric.DefaultPushRetryOptions,
+					})
+				})
+		})
+	}
+	otlpMetricsEndpoint.SetOnChange(&st.SV, startOTLP)
+	startOTLP(ctx)
+
+	var remoteWriteOnce sync.Once
+	startRemoteWrite := func(context.Context) {
+		if remoteWriteEndpoint.Get(&st.SV) == "" {
+			return
+		}
+		remoteWriteOnce.Do(func() {
+			exporter := metric.MakeRemoteWriteExporter(&e.remoteWrite)
+			startMetricsPushExporter(ctx, stopper, "prometheus-remote-write-exporter", remoteWriteInterval, st,
+				func(ctx context.Context, pm *metric.PrometheusExporter) error {
+					endpoint := remoteWriteEndpoint.Get(&st.SV)
+					if endpoint == "" {
+						return nil
+					}
+					return recorder.ExportToPrometheusRemoteWrite(ctx, &exporter, pm, metric.PushConfig{
+						Endpoint:  endpoint,
+						BatchSize: int(remoteWriteBatchSize.Get(&st.SV)),
+						Labels:    labels,
+						Timeout:   metricsPushRequestTimeout,
+						Retry:     metric.DefaultPushRetryOptions,
+					})
+				})
+		})
+	}
+	remoteWriteEndpoint.SetOnChange(&st.SV, startRemoteWrite)
+	startRemoteWrite(ctx)
+}
+
+// startMetricsPushExporter runs push at the configured interval until
+// the stopper quiesces.
+func startMetricsPushExporter(
+	ctx context.Context,
+	stopper *stop.Stopper,
+	taskName string,
+	interval *settings.DurationSetting,
+	st *cluster.Settings,
+	push func(ctx context.Context, pm *metric.PrometheusExporter) error,
+) {
+	ctx = logtags.AddTag(ctx, taskName, nil)
+	pm := metric.MakePrometheusExporter()
+
+	_ = stopper.RunAsyncTask(ctx, taskName, func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(interval.Get(&st.SV))
+			select {
+			case <-stopper.ShouldQuiesce():
+				return
+			case <-timer.C:
+				timer.Read = true
+				if err := push(ctx, &pm); err != nil {
+					log.Warningf(ctx, "error pushing metrics: %v", err)
+				}
+			}
+		}
+	})
+}
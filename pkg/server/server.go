@@ -96,6 +96,7 @@ import (
 	_ "github.com/cockroachdb/cockroach/pkg/sql/importer" // register jobs/planHooks declared outside of pkg/sql
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
+	_ "github.com/cockroachdb/cockroach/pkg/sql/rangefeedfilter"     // register the rangefeed row filters
 	_ "github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scjob" // register jobs declared outside of pkg/sql
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessionprotectedts"
@@ -103,6 +104,7 @@ import (
 	_ "github.com/cockroachdb/cockroach/pkg/sql/ttl/ttlschedule" // register schedules declared outside of pkg/sql
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/ts"
+	"github.com/cockroachdb/cockroach/pkg/ts/tsprom"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/goschedstats"
@@ -1919,6 +1921,12 @@ func (s *topLevelServer) PreStart(ctx context.Context) error {
 		}
 	})
 
+	// Push metrics to an OpenTelemetry collector and/or a Prometheus
+	// remote-write endpoint, if enabled by configuration.
+	makeMetricsPushExporters(s.sysRegistry).start(
+		workersCtx, s.stopper, s.recorder, s.st, s.cfg.AdvertiseAddr,
+	)
+
 	// Start the protected timestamp subsystem. Note that this needs to happen
 	// before the modeOperational switch below, as the protected timestamps
 	// subsystem will crash if accessed before being Started (and serving general
@@ -2054,6 +2062,7 @@ func (s *topLevelServer) PreStart(ctx context.Context) error {
 		gwMux,             /* handleRequestsUnauthenticated */
 		s.debug,           /* handleDebugUnauthenticated */
 		s.inspectzServer,  /* handleInspectzUnauthenticated */
+		tsprom.NewHandler(s.tsServer, recordedMetricNames(s.recorder)), /* handlePromQueryUnauthenticated */
 		newAPIV2Server(ctx, &apiV2ServerOpts{
 			admin:            s.admin,
 			status:           s.status,
@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/status"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/ts"
+	"github.com/cockroachdb/cockroach/pkg/ts/tsprom"
 	"github.com/cockroachdb/cockroach/pkg/ui"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
@@ -148,6 +149,7 @@ func (s *httpServer) setupRoutes(
 	handleRequestsUnauthenticated http.Handler,
 	handleDebugUnauthenticated http.Handler,
 	handleInspectzUnauthenticated http.Handler,
+	handlePromQueryUnauthenticated http.Handler,
 	apiServer http.Handler,
 	flags serverpb.FeatureFlags,
 ) error {
@@ -210,6 +212,16 @@ func (s *httpServer) setupRoutes(
 	// The timeseries endpoint, used to produce graphs.
 	s.mux.Handle(ts.URLPrefix, authenticatedHandler)
 
+	// The Prometheus-compatible query API over the timeseries, used by
+	// external dashboards such as Grafana. It requires the same
+	// authentication as the timeseries endpoint above.
+	var authenticatedPromQueryHandler = handlePromQueryUnauthenticated
+	if !s.cfg.InsecureWebAccess() {
+		authenticatedPromQueryHandler = authserver.NewMux(
+			authnServer, authenticatedPromQueryHandler, false /* allowAnonymous */)
+	}
+	s.mux.Handle(tsprom.URLPrefix, authenticatedPromQueryHandler)
+
 	// Exempt the 2nd health check endpoint from authentication.
 	// (This simply mirrors /health and exists for backward compatibility.)
 	s.mux.Handle(apiconstants.AdminHealth, handleRequestsUnauthenticated)
@@ -246,6 +258,16 @@ func (s *httpServer) setupRoutes(
 	return nil
 }
 
+// recordedMetricNames returns a function listing the time series names
+// recorded by the given metrics recorder, keyed by metric name. It is
+// used by the Prometheus-compatible query API to resolve metric names.
+func recordedMetricNames(recorder *status.MetricsRecorder) tsprom.MetricNamesFn {
+	return func() map[string]string {
+		md, _, _ := recorder.GetMetricsMetadata(true /* combined */)
+		return recorder.GetRecordedMetricNames(md)
+	}
+}
+
 func makeAdminAuthzCheckHandler(
 	adminAuthzCheck privchecker.CheckerForRPCHandlers, handler http.Handler,
 ) http.Handler {
@@ -86,6 +86,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rangeprober"
@@ -950,6 +951,11 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		&contentionMetrics,
 	)
 
+	notificationRegistry := pgnotify.NewRegistry(
+		cfg.AmbientCtx, cfg.Settings, codec, cfg.clock, cfg.stopper, cfg.internalDB, cfg.rangeFeedFactory,
+	)
+	cfg.registry.AddMetricStruct(notificationRegistry.Metrics())
+
 	if !cfg.Insecure {
 		certMgr, err := cfg.rpcContext.SecurityContext.GetCertificateManager()
 		if err != nil {
@@ -1053,6 +1059,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		ExternalIODirConfig:        cfg.ExternalIODirConfig,
 		GCJobNotifier:              gcJobNotifier,
 		RangeFeedFactory:           cfg.rangeFeedFactory,
+		NotificationRegistry:       notificationRegistry,
 		CollectionFactory:          collectionFactory,
 		SystemTableIDResolver:      descs.MakeSystemTableIDResolver(collectionFactory, cfg.internalDB),
 		ConsistencyChecker:         consistencychecker.NewConsistencyChecker(cfg.db),
@@ -1763,6 +1770,7 @@ func (s *SQLServer) preStart(
 	if err := s.execCfg.TableStatsCache.Start(ctx, s.execCfg.Codec, s.execCfg.RangeFeedFactory); err != nil {
 		return err
 	}
+	s.execCfg.NotificationRegistry.Start(ctx, s.execCfg.SystemTableIDResolver)
 
 	scheduledlogging.Start(
 		ctx, stopper, s.execCfg.InternalDB, s.execCfg.Settings,
@@ -382,6 +382,31 @@ func (mr *MetricsRecorder) ExportToGraphite(
 	return graphiteExporter.Push(ctx, endpoint)
 }
 
+// ExportToOTLP sends the current metric values to an OpenTelemetry
+// collector using the provided exporter. As with ExportToGraphite, the
+// caller provides the PrometheusExporter used to scrape the registries.
+func (mr *MetricsRecorder) ExportToOTLP(
+	ctx context.Context,
+	exporter *metric.OTLPExporter,
+	pm *metric.PrometheusExporter,
+	cfg metric.PushConfig,
+) error {
+	mr.ScrapeIntoPrometheus(pm)
+	return exporter.Push(ctx, pm, cfg)
+}
+
+// ExportToPrometheusRemoteWrite sends the current metric values to a
+// Prometheus remote-write endpoint using the provided exporter.
+func (mr *MetricsRecorder) ExportToPrometheusRemoteWrite(
+	ctx context.Context,
+	exporter *metric.RemoteWriteExporter,
+	pm *metric.PrometheusExporter,
+	cfg metric.PushConfig,
+) error {
+	mr.ScrapeIntoPrometheus(pm)
+	return exporter.Push(ctx, pm, cfg)
+}
+
 // GetTimeSeriesData serializes registered metrics for consumption by
 // CockroachDB's time series system. GetTimeSeriesData implements the DataSource
 // interface of the ts package.
@@ -64,6 +64,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness"
 	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
 	"github.com/cockroachdb/cockroach/pkg/ts"
+	"github.com/cockroachdb/cockroach/pkg/ts/tsprom"
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -753,6 +754,11 @@ func (s *SQLServerWrapper) PreStart(ctx context.Context) error {
 				})
 			}
 		})
+
+		// Likewise for the push-based metrics exporters.
+		makeMetricsPushExporters(s.sysRegistry).start(
+			workersCtx, s.stopper, s.recorder, s.ClusterSettings(), s.sqlServer.cfg.AdvertiseAddr,
+		)
 	}
 
 	if !s.sqlServer.cfg.DisableRuntimeStatsMonitor {
@@ -816,6 +822,7 @@ func (s *SQLServerWrapper) PreStart(ctx context.Context) error {
 		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			apiutil.WriteJSONResponse(r.Context(), w, http.StatusNotImplemented, nil)
 		}),
+		tsprom.NewHandler(s.tenantTimeSeries, recordedMetricNames(s.recorder)), /* handlePromQueryUnauthenticated */
 		newAPIV2Server(workersCtx, &apiV2ServerOpts{
 			admin:            s.tenantAdmin,
 			status:           s.tenantStatus,
@@ -78,6 +78,9 @@ func (n *alterFunctionOptionsNode) startExec(params runParams) error {
 	// referenced by other objects. This is needed when want to allow function
 	// references. Need to think about in what condition a function can be altered
 	// or not.
+	if err := checkRoutineAggregateKind(fnDesc, false /* aggregate */, "ALTER"); err != nil {
+		return err
+	}
 	if err := tree.ValidateRoutineOptions(n.n.Options, fnDesc.IsProcedure()); err != nil {
 		return err
 	}
@@ -173,6 +176,9 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkRoutineAggregateKind(fnDesc, n.n.Aggregate, "ALTER"); err != nil {
+		return err
+	}
 	oldFnName, err := params.p.getQualifiedFunctionName(params.ctx, fnDesc)
 	if err != nil {
 		return err
@@ -295,6 +301,9 @@ func (n *alterFunctionSetOwnerNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkRoutineAggregateKind(fnDesc, n.n.Aggregate, "ALTER"); err != nil {
+		return err
+	}
 	newOwner, err := decodeusername.FromRoleSpec(
 		params.p.SessionData(), username.PurposeValidation, n.n.NewOwner,
 	)
@@ -369,6 +378,9 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 			pgcode.UndefinedFunction, "could not find a procedure named %q", &n.n.Function.FuncName,
 		)
 	}
+	if err := checkRoutineAggregateKind(fnDesc, n.n.Aggregate, "ALTER"); err != nil {
+		return err
+	}
 	oldFnName, err := params.p.getQualifiedFunctionName(params.ctx, fnDesc)
 	if err != nil {
 		return err
@@ -525,6 +537,26 @@ func (p *planner) mustGetMutableFunctionForAlter(
 	return mut, nil
 }
 
+// checkRoutineAggregateKind returns an error if the given function is an
+// aggregate and the statement does not target aggregates, or vice versa. verb
+// is the statement used to suggest the correct syntax, e.g. "DROP".
+func checkRoutineAggregateKind(
+	fnDesc catalog.FunctionDescriptor, aggregate bool, verb string,
+) error {
+	if aggregate && !fnDesc.IsAggregate() {
+		return pgerror.Newf(
+			pgcode.WrongObjectType, "function %s is not an aggregate", fnDesc.GetName(),
+		)
+	}
+	if !aggregate && fnDesc.IsAggregate() {
+		return errors.WithHintf(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is an aggregate function", fnDesc.GetName()),
+			"Use %s AGGREGATE to %s aggregate functions.", verb, strings.ToLower(verb),
+		)
+	}
+	return nil
+}
+
 func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor_FunctionSignature {
 	ret := descpb.SchemaDescriptor_FunctionSignature{
 		ID:          fnDesc.GetID(),
@@ -532,6 +564,7 @@ func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor
 		ReturnType:  fnDesc.ReturnType.Type,
 		ReturnSet:   fnDesc.ReturnType.ReturnSet,
 		IsProcedure: fnDesc.IsProcedure(),
+		IsAggregate: fnDesc.IsAggregate(),
 	}
 	for paramIdx, param := range fnDesc.Params {
 		class := funcdesc.ToTreeRoutineParamClass(param.Class)
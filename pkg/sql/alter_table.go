@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -210,7 +211,31 @@ func (n *alterTableNode) startExec(params runParams) error {
 			} else if skip {
 				continue
 			}
+			if tree.IsDeferrableConstraintDef(t.ConstraintDef) &&
+				!params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.V25_2_DeferrableConstraints) {
+				return sqlerrors.NewDeferrableConstraintsNotSupportedError()
+			}
 			switch d := t.ConstraintDef.(type) {
+			case *tree.ExclusionConstraintTableDef:
+				if !params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.V25_2_ExclusionConstraints) {
+					return sqlerrors.NewExclusionConstraintsNotSupportedError()
+				}
+				if err := addExclusionConstraintTableDef(
+					params.ctx,
+					params.EvalContext(),
+					d,
+					n.tableDesc,
+					*tn,
+					NonEmptyTable,
+					t.ValidationBehavior,
+					params.p.SemaCtx(),
+				); err != nil {
+					return err
+				}
+				if err := params.p.addExclusionConstraintIndex(params, n.tableDesc, *tn, d); err != nil {
+					return err
+				}
+
 			case *tree.UniqueConstraintTableDef:
 				if d.WithoutIndex {
 					if err := addUniqueWithoutIndexTableDef(
@@ -228,6 +253,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 					}
 					continue
 				}
+				if d.Deferrability.IsDeferrable() {
+					return sqlerrors.NewDeferrableUniqueIndexError()
+				}
 
 				if d.PrimaryKey {
 					if t.ValidationBehavior == tree.ValidationSkip {
@@ -1705,6 +1733,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExclusionConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -2365,3 +2396,44 @@ func checkSchemaChangeIsAllowed(desc catalog.TableDescriptor, n tree.Statement)
 	}
 	return nil
 }
+
+// addExclusionConstraintIndex adds a mutation for the index backing the given
+// exclusion constraint, unless the table already has a suitable one.
+func (p *planner) addExclusionConstraintIndex(
+	params runParams,
+	tableDesc *tabledesc.Mutable,
+	tn tree.TableName,
+	d *tree.ExclusionConstraintTableDef,
+) error {
+	columns, typ, err := schemaexpr.ExclusionConstraintIndexColumns(d.Elems, columnTypeByName(tableDesc))
+	if err != nil || len(columns) == 0 {
+		return err
+	}
+	for _, idx := range tableDesc.AllIndexes() {
+		if !idx.Dropped() && indexBacksExclusionConstraint(idx, columns, typ) {
+			return nil
+		}
+	}
+	idx, err := makeIndexDescriptor(
+		params, tree.CreateIndex{Table: tn, Type: typ, Columns: columns}, tableDesc,
+	)
+	if err != nil {
+		return err
+	}
+	idx.Version = descpb.StrictIndexColumnIDGuaranteesVersion
+	*idx, err = p.configureIndexDescForNewIndexPartitioning(
+		params.ctx, tableDesc, *idx, nil, /* partitionByIndex */
+	)
+	if err != nil {
+		return err
+	}
+	if err := tableDesc.AddIndexMutationMaybeWithTempIndex(idx, descpb.DescriptorMutation_ADD); err != nil {
+		return err
+	}
+	// The IDs are allocated upfront like for UNIQUE constraints, so that the
+	// zone config can be updated in the same transaction.
+	if err := tableDesc.AllocateIDs(params.ctx, params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)); err != nil {
+		return err
+	}
+	return p.configureZoneConfigForNewIndexPartitioning(params.ctx, tableDesc, *idx)
+}
@@ -27,6 +27,7 @@ var (
 		catconstants.StatementActivityTableName,
 		catconstants.TransactionActivityTableName,
 		catconstants.PreparedTransactionsTableName,
+		catconstants.NotificationsTableName,
 	}
 
 	readWriteSystemTables = []catconstants.SystemTableName{
@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/docs"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -109,6 +110,11 @@ func ValidateColumnDefType(ctx context.Context, st *cluster.Settings, t *types.T
 		types.TSQueryFamily, types.TSVectorFamily, types.PGLSNFamily, types.PGVectorFamily, types.RefCursorFamily:
 	// These types are OK.
 
+	case types.RangeFamily, types.MultirangeFamily:
+		if !st.Version.IsActive(ctx, clusterversion.V25_2_RangeTypes) {
+			return sqlerrors.NewRangeTypesNotSupportedError()
+		}
+
 	case types.TupleFamily:
 		if !t.UserDefined() {
 			return pgerror.New(pgcode.InvalidTableDefinition, "cannot use anonymous record type as table column")
@@ -164,6 +170,10 @@ func ColumnTypeIsInvertedIndexable(t *types.T) bool {
 		}
 	case types.JsonFamily, types.StringFamily:
 		return true
+	case types.RangeFamily, types.MultirangeFamily:
+		// Ranges have a forward index encoding ordered by range, and an inverted
+		// index encoding that supports overlap queries.
+		return true
 	}
 	return ColumnTypeIsOnlyInvertedIndexable(t)
 }
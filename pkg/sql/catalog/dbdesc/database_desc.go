@@ -9,6 +9,7 @@ package dbdesc
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
@@ -200,6 +201,76 @@ func (desc *immutable) GetSchemaID(name string) descpb.ID {
 	return info.ID
 }
 
+// ForEachPublication implements the DatabaseDescriptor interface.
+func (desc *immutable) ForEachPublication(
+	f func(name string, pub descpb.DatabaseDescriptor_PublicationInfo) error,
+) error {
+	names := make([]string, 0, len(desc.Publications))
+	for name := range desc.Publications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := f(name, desc.Publications[name]); err != nil {
+			return iterutil.Map(err)
+		}
+	}
+	return nil
+}
+
+// GetPublication implements the DatabaseDescriptor interface.
+func (desc *immutable) GetPublication(
+	name string,
+) (descpb.DatabaseDescriptor_PublicationInfo, bool) {
+	pub, ok := desc.Publications[name]
+	return pub, ok
+}
+
+// IsTableInPublications implements the DatabaseDescriptor interface.
+func (desc *immutable) IsTableInPublications(id descpb.ID) bool {
+	for _, pub := range desc.Publications {
+		for _, tableID := range pub.TableIDs {
+			if tableID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetSubscription implements the DatabaseDescriptor interface.
+func (desc *immutable) GetSubscription(
+	name string,
+) (descpb.DatabaseDescriptor_SubscriptionInfo, bool) {
+	sub, ok := desc.Subscriptions[name]
+	return sub, ok
+}
+
+// ForEachEventTrigger implements the DatabaseDescriptor interface.
+func (desc *immutable) ForEachEventTrigger(
+	f func(name string, trig descpb.DatabaseDescriptor_EventTriggerInfo) error,
+) error {
+	names := make([]string, 0, len(desc.EventTriggers))
+	for name := range desc.EventTriggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := f(name, desc.EventTriggers[name]); err != nil {
+			return iterutil.Map(err)
+		}
+	}
+	return nil
+}
+
+// GetEventTrigger implements the DatabaseDescriptor interface.
+func (desc *immutable) GetEventTrigger(
+	name string,
+) (descpb.DatabaseDescriptor_EventTriggerInfo, bool) {
+	trig, ok := desc.EventTriggers[name]
+	return trig, ok
+}
+
 // HasPublicSchemaWithDescriptor returns if the database has a public schema
 // with a descriptor.
 // If descs.Schemas has an explicit entry for "public", then it has a descriptor
@@ -252,6 +323,32 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	}
 
 	desc.maybeValidateSystemDatabaseSchemaVersion(vea)
+
+	for name, pub := range desc.Publications {
+		if name == "" {
+			vea.Report(errors.AssertionFailedf("publication has an empty name"))
+		}
+		if pub.AllTables && len(pub.TableIDs) > 0 {
+			vea.Report(errors.AssertionFailedf(
+				"publication %q includes all tables but also lists table IDs", name))
+		}
+	}
+	for name, sub := range desc.Subscriptions {
+		if name == "" {
+			vea.Report(errors.AssertionFailedf("subscription has an empty name"))
+		}
+		if sub.JobID == catpb.InvalidJobID {
+			vea.Report(errors.AssertionFailedf("subscription %q has no job", name))
+		}
+	}
+	for name, trig := range desc.EventTriggers {
+		if name == "" {
+			vea.Report(errors.AssertionFailedf("event trigger has an empty name"))
+		}
+		if trig.FuncID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("event trigger %q has no function", name))
+		}
+	}
 }
 
 // validateMultiRegion performs checks specific to multi-region DBs.
@@ -332,6 +429,14 @@ func (desc *immutable) GetReferencedDescIDs(
 	for _, schema := range desc.Schemas {
 		ids.Add(schema.ID)
 	}
+	for _, pub := range desc.Publications {
+		for _, id := range pub.TableIDs {
+			ids.Add(id)
+		}
+	}
+	for _, trig := range desc.EventTriggers {
+		ids.Add(trig.FuncID)
+	}
 	return ids, nil
 }
 
@@ -339,6 +444,9 @@ func (desc *immutable) GetReferencedDescIDs(
 func (desc *immutable) ValidateForwardReferences(
 	vea catalog.ValidationErrorAccumulator, vdg catalog.ValidationDescGetter,
 ) {
+	desc.validatePublications(vea, vdg)
+	desc.validateEventTriggers(vea, vdg)
+
 	// Check multi-region enum type.
 	if !desc.IsMultiRegion() {
 		return
@@ -362,6 +470,48 @@ func (desc *immutable) ValidateForwardReferences(
 	}
 }
 
+// validatePublications checks that the tables included in publications exist
+// and belong to the database.
+func (desc *immutable) validatePublications(
+	vea catalog.ValidationErrorAccumulator, vdg catalog.ValidationDescGetter,
+) {
+	for name, pub := range desc.Publications {
+		for _, id := range pub.TableIDs {
+			report := func(err error) {
+				vea.Report(errors.Wrapf(err, "publication %q table %d", errors.Safe(name), id))
+			}
+			table, err := vdg.GetTableDescriptor(id)
+			if err != nil {
+				report(err)
+				continue
+			}
+			if table.Dropped() {
+				report(errors.Errorf("table descriptor is dropped"))
+			}
+			if table.GetParentID() != desc.GetID() {
+				report(errors.Errorf("parentID is actually %d", table.GetParentID()))
+			}
+		}
+	}
+}
+
+// validateEventTriggers checks that the functions invoked by event triggers
+// exist.
+func (desc *immutable) validateEventTriggers(
+	vea catalog.ValidationErrorAccumulator, vdg catalog.ValidationDescGetter,
+) {
+	for name, trig := range desc.EventTriggers {
+		fn, err := vdg.GetFunctionDescriptor(trig.FuncID)
+		if err != nil {
+			vea.Report(errors.Wrapf(err, "event trigger %q function %d", errors.Safe(name), trig.FuncID))
+			continue
+		}
+		if fn.Dropped() {
+			vea.Report(errors.Errorf("event trigger %q function %d is dropped", errors.Safe(name), trig.FuncID))
+		}
+	}
+}
+
 // ValidateBackReferences implements the catalog.Descriptor interface.
 func (desc *immutable) ValidateBackReferences(
 	vea catalog.ValidationErrorAccumulator, vdg catalog.ValidationDescGetter,
@@ -566,6 +716,62 @@ func (desc *Mutable) AddSchemaToDatabase(
 	desc.Schemas[schemaName] = schemaInfo
 }
 
+// AddPublication adds a publication to the database. If there is an existing
+// publication with the same name, it is overridden.
+func (desc *Mutable) AddPublication(name string, pub descpb.DatabaseDescriptor_PublicationInfo) {
+	if desc.Publications == nil {
+		desc.Publications = make(map[string]descpb.DatabaseDescriptor_PublicationInfo)
+	}
+	desc.Publications[name] = pub
+}
+
+// RemovePublication removes a publication from the database.
+func (desc *Mutable) RemovePublication(name string) {
+	delete(desc.Publications, name)
+}
+
+// RemoveTableFromPublications removes the table from the publications which
+// list it.
+func (desc *Mutable) RemoveTableFromPublications(id descpb.ID) {
+	for name, pub := range desc.Publications {
+		for i, tableID := range pub.TableIDs {
+			if tableID == id {
+				pub.TableIDs = append(pub.TableIDs[:i:i], pub.TableIDs[i+1:]...)
+				desc.Publications[name] = pub
+				break
+			}
+		}
+	}
+}
+
+// AddSubscription adds a subscription to the database. If there is an existing
+// subscription with the same name, it is overridden.
+func (desc *Mutable) AddSubscription(name string, sub descpb.DatabaseDescriptor_SubscriptionInfo) {
+	if desc.Subscriptions == nil {
+		desc.Subscriptions = make(map[string]descpb.DatabaseDescriptor_SubscriptionInfo)
+	}
+	desc.Subscriptions[name] = sub
+}
+
+// RemoveSubscription removes a subscription from the database.
+func (desc *Mutable) RemoveSubscription(name string) {
+	delete(desc.Subscriptions, name)
+}
+
+// AddEventTrigger adds an event trigger to the database. If there is an
+// existing event trigger with the same name, it is overridden.
+func (desc *Mutable) AddEventTrigger(name string, trig descpb.DatabaseDescriptor_EventTriggerInfo) {
+	if desc.EventTriggers == nil {
+		desc.EventTriggers = make(map[string]descpb.DatabaseDescriptor_EventTriggerInfo)
+	}
+	desc.EventTriggers[name] = trig
+}
+
+// RemoveEventTrigger removes an event trigger from the database.
+func (desc *Mutable) RemoveEventTrigger(name string) {
+	delete(desc.EventTriggers, name)
+}
+
 // GetDeclarativeSchemaChangerState is part of the catalog.MutableDescriptor
 // interface.
 func (desc *immutable) GetDeclarativeSchemaChangerState() *scpb.DescriptorState {
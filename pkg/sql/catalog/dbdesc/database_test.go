@@ -256,6 +256,26 @@ func TestValidateCrossDatabaseReferences(t *testing.T) {
 				},
 			},
 		},
+		{ // 9
+			err: `publication "p" table 500: referenced table ID 500: referenced descriptor not found`,
+			desc: descpb.DatabaseDescriptor{
+				ID:   51,
+				Name: "db1",
+				Publications: map[string]descpb.DatabaseDescriptor_PublicationInfo{
+					"p": {TableIDs: []descpb.ID{500}},
+				},
+			},
+		},
+		{ // 10
+			err: `event trigger "et" function 500: referenced function ID 500: referenced descriptor not found`,
+			desc: descpb.DatabaseDescriptor{
+				ID:   51,
+				Name: "db1",
+				EventTriggers: map[string]descpb.DatabaseDescriptor_EventTriggerInfo{
+					"et": {Event: "ddl_command_start", FuncID: 500},
+				},
+			},
+		},
 	}
 
 	for i, test := range tests {
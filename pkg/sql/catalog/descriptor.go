@@ -329,6 +329,23 @@ type DatabaseDescriptor interface {
 	// HasPublicSchemaWithDescriptor returns true iff the database has a public
 	// schema which itself has a descriptor.
 	HasPublicSchemaWithDescriptor() bool
+	// ForEachPublication iterates f over each publication in the database, in
+	// name order. iterutil.StopIteration is supported.
+	ForEachPublication(func(name string, pub descpb.DatabaseDescriptor_PublicationInfo) error) error
+	// GetPublication returns the publication with the given name, if it exists.
+	GetPublication(name string) (descpb.DatabaseDescriptor_PublicationInfo, bool)
+	// IsTableInPublications returns true if the table is listed by one of the
+	// publications of the database.
+	IsTableInPublications(id descpb.ID) bool
+	// GetSubscription returns the subscription with the given name, if it
+	// exists.
+	GetSubscription(name string) (descpb.DatabaseDescriptor_SubscriptionInfo, bool)
+	// ForEachEventTrigger iterates f over each event trigger in the database,
+	// in name order.
+	ForEachEventTrigger(func(name string, trig descpb.DatabaseDescriptor_EventTriggerInfo) error) error
+	// GetEventTrigger returns the event trigger with the given name, if it
+	// exists.
+	GetEventTrigger(name string) (descpb.DatabaseDescriptor_EventTriggerInfo, bool)
 }
 
 // TableDescriptor is an interface around the table descriptor types.
@@ -915,6 +932,10 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain, nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -1002,6 +1023,33 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domains, which are
+// base types with constraints.
+type DomainTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// BaseType returns the type that the domain is based on.
+	BaseType() *types.T
+
+	// IsNotNull returns true if the domain does not allow NULL values.
+	IsNotNull() bool
+
+	// GetDefaultExpr returns the serialized default expression of the domain,
+	// if it has one.
+	GetDefaultExpr() (string, bool)
+
+	// NumChecks returns the number of CHECK constraints of the domain.
+	NumChecks() int
+
+	// GetCheckName returns the name of the CHECK constraint at the given
+	// ordinal.
+	GetCheckName(ordinal int) string
+
+	// GetCheckExpr returns the serialized expression of the CHECK constraint at
+	// the given ordinal. It refers to the value being checked as VALUE.
+	GetCheckExpr(ordinal int) string
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
@@ -1087,6 +1135,10 @@ type FunctionDescriptor interface {
 	// returns false if the descriptor represents a user-defined function.
 	IsProcedure() bool
 
+	// IsAggregate returns true if the descriptor represents a user-defined
+	// aggregate.
+	IsAggregate() bool
+
 	// GetSecurity returns the security specification of this function.
 	GetSecurity() catpb.Function_Security
 }
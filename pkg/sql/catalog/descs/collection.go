@@ -756,6 +756,24 @@ func (tc *Collection) GetUncommittedDatabases() (databases []catalog.DatabaseDes
 	return databases
 }
 
+// GetUncommittedDescriptors returns all the descriptors updated or created in
+// the transaction, in ascending order of IDs.
+func (tc *Collection) GetUncommittedDescriptors() (descs []catalog.Descriptor) {
+	_ = tc.uncommitted.iterateUncommittedByID(func(desc catalog.Descriptor) error {
+		descs = append(descs, desc)
+		return nil
+	})
+	return descs
+}
+
+// GetOriginalDescriptor returns the version of the descriptor with the given ID
+// that was read from storage before it was modified in the transaction. It
+// returns nil if the descriptor was created in the transaction, or if it has
+// not been modified.
+func (tc *Collection) GetOriginalDescriptor(id descpb.ID) catalog.Descriptor {
+	return tc.uncommitted.getOriginalByID(id)
+}
+
 func newMutableSyntheticDescriptorAssertionError(id descpb.ID) error {
 	return errors.AssertionFailedf("attempted mutable access of synthetic descriptor %d", id)
 }
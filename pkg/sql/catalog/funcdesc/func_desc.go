@@ -497,6 +497,11 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 			return iterutil.Map(err)
 		}
 	}
+	if agg := desc.Aggregate; agg != nil && catid.IsOIDUserDefined(agg.StateType.Oid()) {
+		if err := fn(agg.StateType); err != nil {
+			return iterutil.Map(err)
+		}
+	}
 	if !catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
 		return nil
 	}
@@ -508,6 +513,9 @@ func (desc *immutable) MaybeRequiresTypeHydration() bool {
 	if catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
 		return true
 	}
+	if agg := desc.Aggregate; agg != nil && catid.IsOIDUserDefined(agg.StateType.Oid()) {
+		return true
+	}
 	for i := range desc.Params {
 		if catid.IsOIDUserDefined(desc.Params[i].Type.Oid()) {
 			return true
@@ -633,6 +641,12 @@ func (desc *Mutable) SetSecurity(v catpb.Function_Security) {
 	desc.Security = v
 }
 
+// SetAggregate marks the function as a user-defined aggregate with the given
+// support functions and state.
+func (desc *Mutable) SetAggregate(agg *descpb.FunctionDescriptor_AggregateInfo) {
+	desc.Aggregate = agg
+}
+
 // SetName sets the function name.
 func (desc *Mutable) SetName(n string) {
 	desc.Name = n
@@ -975,6 +989,16 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if desc.ReturnType.ReturnSet {
 		ret.Class = tree.GeneratorClass
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		ret.Aggregate = &tree.RoutineAggregate{
+			InitCond:       agg.InitialCondition,
+			SFuncOID:       agg.TransitionFunctionOID,
+			FinalFuncOID:   agg.FinalFunctionOID,
+			CombineFuncOID: agg.CombineFunctionOID,
+			StateType:      agg.StateType,
+		}
+	}
 	ret.SecurityMode = desc.getCreateExprSecurity()
 
 	return ret, nil
@@ -1056,6 +1080,11 @@ func (desc *immutable) IsProcedure() bool {
 	return desc.FunctionDescriptor.IsProcedure
 }
 
+// IsAggregate implements the FunctionDescriptor interface.
+func (desc *immutable) IsAggregate() bool {
+	return desc.FunctionDescriptor.Aggregate != nil
+}
+
 func (desc *immutable) getCreateExprLang() tree.RoutineLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
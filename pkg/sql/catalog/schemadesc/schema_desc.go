@@ -606,6 +606,9 @@ func (desc *immutable) GetResolvedFuncDefinition(
 		if funcDescPb.Signatures[i].ReturnSet {
 			overload.Class = tree.GeneratorClass
 		}
+		if funcDescPb.Signatures[i].IsAggregate {
+			overload.Class = tree.AggregateClass
+		}
 		// There is no need to look at the parameter classes since ArgTypes
 		// already contains only parameters that are included into the
 		// signature of the overload.
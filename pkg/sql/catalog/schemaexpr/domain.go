@@ -0,0 +1,89 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/seqexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// DomainValueName is the name by which the CHECK constraints of a domain refer
+// to the value being checked.
+const DomainValueName = tree.Name("value")
+
+// ValidateDomainCheckExpr validates that a CHECK constraint expression of a
+// domain with the given base type is a boolean expression which only refers to
+// VALUE, and returns the serialized expression.
+func ValidateDomainCheckExpr(
+	ctx context.Context,
+	expr tree.Expr,
+	baseType *types.T,
+	semaCtx *tree.SemaContext,
+	version clusterversion.ClusterVersion,
+) (string, error) {
+	replacedExpr, _, err := ReplaceColumnVars(expr, func(
+		columnName tree.Name,
+	) (exists bool, accessible bool, id catid.ColumnID, typ *types.T) {
+		if columnName != DomainValueName {
+			return false, false, 0, nil
+		}
+		return true, true, 0, baseType
+	})
+	if err != nil {
+		return "", err
+	}
+	typedExpr, err := SanitizeVarFreeExpr(
+		ctx, replacedExpr, types.Bool, tree.DomainCheckExpr, semaCtx, volatility.Immutable,
+		false, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	if err := funcdesc.MaybeFailOnUDFUsage(typedExpr, tree.DomainCheckExpr, version); err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// ValidateDomainDefaultExpr validates that a DEFAULT expression of a domain is
+// assignable to the domain's base type, and returns the serialized expression.
+// Domain defaults may not refer to sequences or user-defined functions, since
+// types do not track references to other descriptors.
+func ValidateDomainDefaultExpr(
+	ctx context.Context,
+	expr tree.Expr,
+	baseType *types.T,
+	semaCtx *tree.SemaContext,
+	version clusterversion.ClusterVersion,
+) (string, error) {
+	typedExpr, err := SanitizeVarFreeExpr(
+		ctx, expr, baseType, tree.DomainDefaultExpr, semaCtx, volatility.Volatile,
+		true, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	if err := funcdesc.MaybeFailOnUDFUsage(typedExpr, tree.DomainDefaultExpr, version); err != nil {
+		return "", err
+	}
+	seqIdentifiers, err := seqexpr.GetUsedSequences(typedExpr)
+	if err != nil {
+		return "", err
+	}
+	if len(seqIdentifiers) > 0 {
+		return "", unimplemented.Newf("domain default sequence",
+			"usage of sequences in domain default expressions is not supported")
+	}
+	return tree.Serialize(typedExpr), nil
+}
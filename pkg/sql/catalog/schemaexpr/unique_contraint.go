@@ -10,9 +10,16 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
 )
 
 // ValidateUniqueWithoutIndexPredicate verifies that an expression is a valid
@@ -50,3 +57,91 @@ func ValidateUniqueWithoutIndexPredicate(
 	}
 	return expr, nil
 }
+
+// ValidateExclusionConstraintElems verifies that the elements of an EXCLUDE
+// constraint using the given access method are valid, and returns the
+// operators to store in the UniqueWithoutIndexConstraint descriptor. colType
+// returns the type of the column with the given name.
+//
+// Exclusion constraints are stored like UNIQUE WITHOUT INDEX constraints and
+// backed by a separate index (see ExclusionConstraintIndexColumns), so the
+// access method only determines which operators are allowed: all of them must
+// be = with btree, which is the default, while gist also allows the && and -|-
+// operators of arrays, ranges and geometries. Like in Postgres, the operators
+// must be commutative.
+func ValidateExclusionConstraintElems(
+	method tree.Name, elems tree.ExclusionElemList, colType func(tree.Name) (*types.T, error),
+) ([]string, error) {
+	switch method {
+	case "", "btree", "gist":
+	default:
+		return nil, unimplemented.NewWithIssueDetailf(46657, "exclusion constraint using "+string(method),
+			"exclusion constraints using %s are not supported", method)
+	}
+	ops := make([]string, len(elems))
+	for i, elem := range elems {
+		switch elem.Operator.Symbol {
+		case treecmp.EQ:
+		case treecmp.Overlaps, treecmp.Adjacent:
+			if method != "gist" {
+				return nil, pgerror.Newf(pgcode.WrongObjectType,
+					"operator %s is not supported by access method btree", elem.Operator)
+			}
+		case treecmp.LT, treecmp.LE, treecmp.GT, treecmp.GE, treecmp.Contains, treecmp.ContainedBy,
+			treecmp.NotExtendRight, treecmp.NotExtendLeft:
+			return nil, errors.WithDetail(
+				pgerror.Newf(pgcode.WrongObjectType, "operator %s is not commutative", elem.Operator),
+				"Only commutative operators can be used in exclusion constraints.",
+			)
+		default:
+			return nil, unimplemented.NewWithIssueDetailf(46657, "exclusion constraint operator",
+				"exclusion constraints with the %s operator are not supported", elem.Operator)
+		}
+		typ, err := colType(elem.Column)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := tree.CmpOps[elem.Operator.Symbol].LookupImpl(typ, typ); !ok {
+			return nil, pgerror.Newf(pgcode.UndefinedFunction,
+				"operator does not exist: %s %s %s", typ.SQLString(), elem.Operator, typ.SQLString())
+		}
+		ops[i] = elem.Operator.String()
+	}
+	return ops, nil
+}
+
+// ExclusionConstraintIndexColumns returns the key columns and the type of the
+// index that backs an exclusion constraint, i.e. the index used to look up the
+// existing rows that may conflict with a new row. The columns compared with =
+// form a prefix of the key. If a column compared with && can be indexed by an
+// inverted index, it's the last key column of an inverted index. Otherwise,
+// the index is a forward index on the prefix. The returned columns are empty
+// if no column of the constraint can be indexed, e.g. if all of them are
+// compared with -|-, in which case conflicts are found with a full table
+// scan.
+func ExclusionConstraintIndexColumns(
+	elems tree.ExclusionElemList, colType func(tree.Name) (*types.T, error),
+) (tree.IndexElemList, idxtype.T, error) {
+	var columns tree.IndexElemList
+	var inverted *tree.IndexElem
+	for i := range elems {
+		typ, err := colType(elems[i].Column)
+		if err != nil {
+			return nil, 0, err
+		}
+		switch elems[i].Operator.Symbol {
+		case treecmp.EQ:
+			if colinfo.ColumnTypeIsIndexable(typ) {
+				columns = append(columns, tree.IndexElem{Column: elems[i].Column, Direction: tree.Ascending})
+			}
+		case treecmp.Overlaps:
+			if inverted == nil && colinfo.ColumnTypeIsInvertedIndexable(typ) {
+				inverted = &tree.IndexElem{Column: elems[i].Column}
+			}
+		}
+	}
+	if inverted != nil {
+		return append(columns, *inverted), idxtype.INVERTED, nil
+	}
+	return columns, idxtype.FORWARD, nil
+}
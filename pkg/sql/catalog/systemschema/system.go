@@ -1323,6 +1323,26 @@ CREATE TABLE system.prepared_transactions (
   CONSTRAINT "primary" PRIMARY KEY (global_id),
   FAMILY "primary" (global_id, transaction_id, transaction_key, prepared, owner, database, heuristic)
 );`
+
+	// NotificationsTableSchema stores the notifications sent by NOTIFY and
+	// pg_notify. Nodes watch the table with a rangefeed to deliver the
+	// notifications to their listening sessions, and rows are deleted once they
+	// are older than sql.notifications.retention.
+	NotificationsTableSchema = `
+CREATE TABLE system.notifications (
+  id       UUID         NOT NULL DEFAULT gen_random_uuid(),
+  channel  STRING       NOT NULL,
+  payload  STRING       NOT NULL,
+  -- The backend PID of the session which sent the notification.
+  pid      INT4         NOT NULL,
+  created  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+  -- The sequence number of the notification in the transaction which sent
+  -- it. The notifications of a transaction are delivered in this order.
+  seq      INT8         NOT NULL,
+  CONSTRAINT "primary" PRIMARY KEY (id),
+  INDEX notifications_created_idx (created ASC),
+  FAMILY "primary" (id, channel, payload, pid, created, seq)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1375,7 +1395,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // release version).
 //
 // NB: Don't set this to clusterversion.Latest; use a specific version instead.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.V25_2_AddSqlActivityFlushJob.Version()
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.V25_2_AddNotificationsTable.Version()
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1572,6 +1592,7 @@ func MakeSystemTables() []SystemTable {
 		SystemJobStatusTable,
 		SystemJobMessageTable,
 		PreparedTransactionsTable,
+		NotificationsTable,
 	}
 }
 
@@ -5199,6 +5220,39 @@ var (
 			pk("global_id"),
 		),
 	)
+
+	NotificationsTable = makeSystemTable(
+		NotificationsTableSchema,
+		systemTable(
+			catconstants.NotificationsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "id", ID: 1, Type: types.Uuid, DefaultExpr: &genRandomUUIDString},
+				{Name: "channel", ID: 2, Type: types.String},
+				{Name: "payload", ID: 3, Type: types.String},
+				{Name: "pid", ID: 4, Type: types.Int4},
+				{Name: "created", ID: 5, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+				{Name: "seq", ID: 6, Type: types.Int},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ColumnNames: []string{"id", "channel", "payload", "pid", "created", "seq"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6},
+				},
+			},
+			pk("id"),
+			descpb.IndexDescriptor{
+				Name:                "notifications_created_idx",
+				ID:                  2,
+				KeyColumnNames:      []string{"created"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        []descpb.ColumnID{5},
+				KeySuffixColumnIDs:  []descpb.ColumnID{1},
+				Version:             descpb.StrictIndexColumnIDGuaranteesVersion,
+			},
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
@@ -616,6 +616,14 @@ type ForeignKeyConstraint interface {
 
 	// Match returns the type of algorithm used to match composite keys.
 	Match() semenumpb.Match
+
+	// IsDeferrable returns true iff the check of the foreign key can be
+	// deferred until the end of the transaction.
+	IsDeferrable() bool
+
+	// IsInitiallyDeferred returns true iff the check of the foreign key is
+	// deferred until the end of the transaction by default.
+	IsInitiallyDeferred() bool
 }
 
 // UniqueWithoutIndexConstraint is an interface around a unique constraint
@@ -629,6 +637,21 @@ type UniqueWithoutIndexConstraint interface {
 
 	// ParentTableID returns the ID of the table this constraint applies to.
 	ParentTableID() descpb.ID
+
+	// IsDeferrable returns true iff the check of the constraint can be
+	// deferred until the end of the transaction.
+	IsDeferrable() bool
+
+	// IsInitiallyDeferred returns true iff the check of the constraint is
+	// deferred until the end of the transaction by default.
+	IsInitiallyDeferred() bool
+
+	// IsExclusion returns true iff the constraint is an exclusion constraint.
+	IsExclusion() bool
+
+	// GetExclusionOperator returns the operator of the key column at the given
+	// ordinal of an exclusion constraint.
+	GetExclusionOperator(columnOrdinal int) string
 }
 
 // PrimaryKeySwap is an interface around a primary key swap mutation.
@@ -194,7 +194,8 @@ func (c uniqueWithoutIndexConstraint) ParentTableID() descpb.ID {
 func (c uniqueWithoutIndexConstraint) IsValidReferencedUniqueConstraint(
 	fk catalog.ForeignKeyConstraint,
 ) bool {
-	return !c.IsPartial() && descpb.ColumnIDs(c.desc.ColumnIDs).PermutationOf(fk.ForeignKeyDesc().ReferencedColumnIDs)
+	return !c.IsPartial() && !c.IsExclusion() &&
+		descpb.ColumnIDs(c.desc.ColumnIDs).PermutationOf(fk.ForeignKeyDesc().ReferencedColumnIDs)
 }
 
 // NumKeyColumns implements the catalog.UniqueConstraint interface.
@@ -223,6 +224,28 @@ func (c uniqueWithoutIndexConstraint) GetPredicate() string {
 	return c.desc.Predicate
 }
 
+// IsDeferrable implements the catalog.UniqueWithoutIndexConstraint interface.
+func (c uniqueWithoutIndexConstraint) IsDeferrable() bool {
+	return c.desc.Deferrable
+}
+
+// IsInitiallyDeferred implements the catalog.UniqueWithoutIndexConstraint
+// interface.
+func (c uniqueWithoutIndexConstraint) IsInitiallyDeferred() bool {
+	return c.desc.InitiallyDeferred
+}
+
+// IsExclusion implements the catalog.UniqueWithoutIndexConstraint interface.
+func (c uniqueWithoutIndexConstraint) IsExclusion() bool {
+	return len(c.desc.ExclusionOperators) > 0
+}
+
+// GetExclusionOperator implements the catalog.UniqueWithoutIndexConstraint
+// interface.
+func (c uniqueWithoutIndexConstraint) GetExclusionOperator(columnOrdinal int) string {
+	return c.desc.ExclusionOperators[columnOrdinal]
+}
+
 // GetConstraintID implements the catalog.Constraint interface.
 func (c uniqueWithoutIndexConstraint) GetConstraintID() descpb.ConstraintID {
 	return c.desc.ConstraintID
@@ -335,6 +358,16 @@ func (c foreignKeyConstraint) Match() semenumpb.Match {
 	return c.desc.Match
 }
 
+// IsDeferrable implements the catalog.ForeignKeyConstraint interface.
+func (c foreignKeyConstraint) IsDeferrable() bool {
+	return c.desc.Deferrable
+}
+
+// IsInitiallyDeferred implements the catalog.ForeignKeyConstraint interface.
+func (c foreignKeyConstraint) IsInitiallyDeferred() bool {
+	return c.desc.InitiallyDeferred
+}
+
 // GetConstraintID implements the catalog.Constraint interface.
 func (c foreignKeyConstraint) GetConstraintID() descpb.ConstraintID {
 	return c.desc.ConstraintID
@@ -1699,6 +1699,11 @@ func (desc *Mutable) MakeMutationComplete(m descpb.DescriptorMutation) error {
 			// indexes with the new indexes that have been backfilled already.
 			desc.SetPrimaryIndex(t.MaterializedViewRefresh.NewPrimaryIndex)
 			desc.SetPublicNonPrimaryIndexes(t.MaterializedViewRefresh.NewIndexes)
+			desc.MaterializedViewRefreshedAt = nil
+			if t.MaterializedViewRefresh.ShouldBackfill {
+				refreshedAt := t.MaterializedViewRefresh.AsOf
+				desc.MaterializedViewRefreshedAt = &refreshedAt
+			}
 		}
 
 	case descpb.DescriptorMutation_DROP:
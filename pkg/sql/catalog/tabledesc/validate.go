@@ -642,6 +642,10 @@ func (desc *wrapper) validateOutboundFK(
 		return errors.AssertionFailedf("referenced table %q (%d) is dropped",
 			referencedTable.GetName(), referencedTable.GetID())
 	}
+	if fk.InitiallyDeferred && !fk.Deferrable {
+		return errors.AssertionFailedf(
+			"foreign key %q is initially deferred but not deferrable", fk.Name)
+	}
 
 	return nil
 }
@@ -1559,6 +1563,24 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		if c.IsInitiallyDeferred() && !c.IsDeferrable() {
+			return errors.AssertionFailedf(
+				"unique without index constraint %q is initially deferred but not deferrable", c.GetName())
+		}
+
+		if ops := c.UniqueWithoutIndexDesc().ExclusionOperators; len(ops) > 0 {
+			if len(ops) != c.NumKeyColumns() {
+				return errors.AssertionFailedf(
+					"exclusion constraint %q has %d operators for %d columns", c.GetName(), len(ops), c.NumKeyColumns())
+			}
+			for _, op := range ops {
+				if op != "=" && op != "&&" && op != "-|-" {
+					return errors.AssertionFailedf(
+						"exclusion constraint %q has unsupported operator %q", c.GetName(), op)
+				}
+			}
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.GetPredicate())
 			if err != nil {
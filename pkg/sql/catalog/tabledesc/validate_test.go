@@ -286,6 +286,8 @@ var validationMap = []struct {
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
 			"SystemDatabaseSchemaVersion":   {status: iSolemnlySwearThisFieldIsValidated},
 			"ReplicatedPCRVersion":          {status: thisFieldReferencesNoObjects},
+			"Publications":                  {status: iSolemnlySwearThisFieldIsValidated},
+			"Subscriptions":                 {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -154,4 +154,19 @@ func ensureTypeMetadataIsHydrated(
 			}
 		}
 	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		n := d.NumChecks()
+		tm.DomainData = &types.DomainMetadata{
+			NotNull:    d.IsNotNull(),
+			CheckNames: make([]string, n),
+			CheckExprs: make([]string, n),
+		}
+		if expr, ok := d.GetDefaultExpr(); ok {
+			tm.DomainData.DefaultExpr = &expr
+		}
+		for i := 0; i < n; i++ {
+			tm.DomainData.CheckNames[i] = d.GetCheckName(i)
+			tm.DomainData.CheckExprs[i] = d.GetCheckExpr(i)
+		}
+	}
 }
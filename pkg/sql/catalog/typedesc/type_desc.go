@@ -38,6 +38,7 @@ var _ catalog.EnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.RegionEnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.AliasTypeDescriptor = (*immutable)(nil)
 var _ catalog.CompositeTypeDescriptor = (*immutable)(nil)
+var _ catalog.DomainTypeDescriptor = (*immutable)(nil)
 var _ catalog.TypeDescriptor = (*Mutable)(nil)
 var _ catalog.MutableDescriptor = (*Mutable)(nil)
 
@@ -498,6 +499,8 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		desc.validateDomain(vea)
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -505,6 +508,32 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	}
 }
 
+// validateDomain performs domain checks.
+func (desc *immutable) validateDomain(vea catalog.ValidationErrorAccumulator) {
+	if desc.Domain == nil {
+		vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil domain"))
+		return
+	}
+	if base := desc.Domain.BaseType; base == nil {
+		vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+	} else if base.UserDefined() || base.Family() == types.ArrayFamily || base.Family() == types.TupleFamily {
+		vea.Report(errors.AssertionFailedf("DOMAIN type desc has invalid base type %s", base.SQLString()))
+	}
+	if desc.ArrayTypeID == descpb.InvalidID {
+		vea.Report(errors.AssertionFailedf("DOMAIN type desc has no array type ID"))
+	}
+	names := make(map[string]struct{}, len(desc.Domain.Checks))
+	for _, c := range desc.Domain.Checks {
+		if c.Name == "" {
+			vea.Report(errors.AssertionFailedf("domain check constraint %q has no name", c.Expr))
+		}
+		if _, ok := names[c.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate domain check constraint %q", c.Name))
+		}
+		names[c.Name] = struct{}{}
+	}
+}
+
 // validateEnumMembers performs enum member checks.
 // Returns true iff the enums are sorted.
 func (desc *immutable) validateEnumMembers(vea catalog.ValidationErrorAccumulator) (isSorted bool) {
@@ -767,6 +796,12 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(
+			catid.TypeIDToOID(desc.GetID()),
+			catid.TypeIDToOID(desc.ArrayTypeID),
+			desc.Domain.BaseType,
+		)
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
@@ -1021,6 +1056,14 @@ func (desc *immutable) AsCompositeTypeDescriptor() catalog.CompositeTypeDescript
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_DOMAIN {
+		return desc
+	}
+	return nil
+}
+
 // AsTableImplicitRecordTypeDescriptor implements the catalog.TypeDescriptor
 // interface.
 func (desc *immutable) AsTableImplicitRecordTypeDescriptor() catalog.TableImplicitRecordTypeDescriptor {
@@ -1047,6 +1090,39 @@ func (desc *immutable) GetElementType(ordinal int) *types.T {
 	return desc.Composite.Elements[ordinal].ElementType
 }
 
+// BaseType implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) BaseType() *types.T {
+	return desc.Domain.BaseType
+}
+
+// IsNotNull implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) IsNotNull() bool {
+	return desc.Domain.NotNull
+}
+
+// GetDefaultExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetDefaultExpr() (string, bool) {
+	if desc.Domain.DefaultExpr == nil {
+		return "", false
+	}
+	return *desc.Domain.DefaultExpr, true
+}
+
+// NumChecks implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) NumChecks() int {
+	return len(desc.Domain.Checks)
+}
+
+// GetCheckName implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheckName(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Name
+}
+
+// GetCheckExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheckExpr(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Expr
+}
+
 // ForEachRegionInSuperRegion implements the catalog.RegionEnumTypeDescriptor
 // interface.
 func (desc *immutable) ForEachRegionInSuperRegion(
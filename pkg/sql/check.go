@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/retry"
 	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/redact"
 	pbtypes "github.com/gogo/protobuf/types"
 )
 
@@ -334,13 +335,21 @@ func validateForeignKey(
 		return err
 	}
 	if values.Len() > 0 {
-		return pgerror.WithConstraintName(pgerror.Newf(pgcode.ForeignKeyViolation,
-			"foreign key violation: %q row %s has no match in %q",
-			srcTable.Name, formatValues(colNames, values), targetTable.GetName()), fk.Name)
+		return nonMatchingRowError(srcTable.Name, colNames, values, targetTable.GetName(), fk.Name)
 	}
 	return nil
 }
 
+// nonMatchingRowError returns the error reported when the validation of a
+// foreign key finds an origin row without a match in the referenced table.
+func nonMatchingRowError(
+	srcName string, colNames []string, values tree.Datums, targetName string, fkName string,
+) error {
+	return pgerror.WithConstraintName(pgerror.Newf(pgcode.ForeignKeyViolation,
+		"foreign key violation: %q row %s has no match in %q",
+		srcName, formatValues(colNames, values), targetName), fkName)
+}
+
 // duplicateRowQuery generates and returns a query for column values that
 // violate the specified unique constraint. Rows in the table with any null
 // values in the key are excluded from matching.
@@ -414,6 +423,79 @@ func duplicateRowQuery(
 	return query, colNames, nil
 }
 
+// exclusionConflictQuery generates and returns a query for two distinct rows
+// which conflict according to the specified exclusion constraint, i.e. for
+// which the operators of all the constraint's columns return true. Rows in the
+// table with any null values in the key are excluded from matching.
+//
+// For example, an exclusion constraint EXCLUDE USING gist (a WITH =, b WITH
+// &&) on the table "tbl" with primary key k would require the following query:
+//
+// WITH rows AS (SELECT k, a, b FROM tbl WHERE a IS NOT NULL AND b IS NOT NULL)
+// SELECT r1.a, r1.b, r2.a, r2.b
+// FROM rows AS r1 JOIN rows AS r2 ON r1.a = r2.a AND r1.b && r2.b
+// WHERE (r1.k) < (r2.k)
+// LIMIT 1
+//
+// The pred argument is a partial constraint predicate, as in duplicateRowQuery.
+func exclusionConflictQuery(
+	srcTbl catalog.TableDescriptor, columnIDs []descpb.ColumnID, ops []string, pred string,
+) (sql string, colNames []string, _ error) {
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, columnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColNames, err := catalog.ColumnNamesForIDs(
+		srcTbl, srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs,
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var selected []string
+	var srcWhere, joinOn []string
+	seen := make(map[string]struct{})
+	addSelected := func(n string) {
+		if _, ok := seen[n]; !ok {
+			seen[n] = struct{}{}
+			selected = append(selected, tree.NameString(n))
+		}
+	}
+	r1Cols := make([]string, len(colNames))
+	r2Cols := make([]string, len(colNames))
+	for i, n := range colNames {
+		addSelected(n)
+		srcWhere = append(srcWhere, fmt.Sprintf("%s IS NOT NULL", tree.NameString(n)))
+		r1Cols[i] = "r1." + tree.NameString(n)
+		r2Cols[i] = "r2." + tree.NameString(n)
+		joinOn = append(joinOn, fmt.Sprintf("%s %s %s", r1Cols[i], ops[i], r2Cols[i]))
+	}
+	r1PK := make([]string, len(pkColNames))
+	r2PK := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		addSelected(n)
+		r1PK[i] = "r1." + tree.NameString(n)
+		r2PK[i] = "r2." + tree.NameString(n)
+	}
+	if pred != "" {
+		srcWhere = append(srcWhere, fmt.Sprintf("(%s)", pred))
+	}
+
+	query := fmt.Sprintf(
+		`WITH rows AS (SELECT %[1]s FROM [%[2]d AS tbl] WHERE %[3]s) `+
+			`SELECT %[4]s, %[5]s FROM rows AS r1 JOIN rows AS r2 ON %[6]s WHERE (%[7]s) < (%[8]s) LIMIT 1`,
+		strings.Join(selected, ", "),    // 1
+		srcTbl.GetID(),                  // 2
+		strings.Join(srcWhere, " AND "), // 3
+		strings.Join(r1Cols, ", "),      // 4
+		strings.Join(r2Cols, ", "),      // 5
+		strings.Join(joinOn, " AND "),   // 6
+		strings.Join(r1PK, ", "),        // 7
+		strings.Join(r2PK, ", "),        // 8
+	)
+	return query, colNames, nil
+}
+
 // RevalidateUniqueConstraintsInCurrentDB verifies that all unique constraints
 // defined on tables in the current database are valid. In other words, it
 // verifies that for every table in the database with one or more unique
@@ -614,6 +696,9 @@ func RevalidateUniqueConstraintsInTable(
 //
 // preExisting indicates whether this constraint already exists, and therefore
 // informs the error message that gets produced.
+//
+// If the constraint is an exclusion constraint, its own columns and operators
+// are validated by validateExclusionConstraint instead.
 func validateUniqueConstraint(
 	ctx context.Context,
 	srcTable catalog.TableDescriptor,
@@ -625,6 +710,14 @@ func validateUniqueConstraint(
 	user username.SQLUsername,
 	preExisting bool,
 ) error {
+	for _, uwi := range srcTable.UniqueConstraintsWithoutIndex() {
+		if uwi.GetName() == constraintName && uwi.IsExclusion() {
+			uc := uwi.UniqueWithoutIndexDesc()
+			return validateExclusionConstraint(
+				ctx, srcTable, constraintName, uc.ColumnIDs, uc.ExclusionOperators, pred, txn, user, preExisting,
+			)
+		}
+	}
 	query, colNames, err := duplicateRowQuery(
 		srcTable, columnIDs, pred, indexIDForValidation, true, /* limitResults */
 	)
@@ -639,6 +732,104 @@ func validateUniqueConstraint(
 		query,
 	)
 
+	values, err := queryUniqueValidationRow(ctx, txn, user, "validate unique constraint", query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		return uniqueViolationError(constraintName, colNames, values, preExisting)
+	}
+	return nil
+}
+
+// uniqueViolationError returns the error reported when the validation of a
+// unique constraint finds duplicated values.
+func uniqueViolationError(
+	constraintName string, colNames []string, values tree.Datums, preExisting bool,
+) error {
+	valuesStr := make([]string, len(values))
+	for i := range values {
+		valuesStr[i] = values[i].String()
+	}
+	// Note: this error message mirrors the message produced by Postgres
+	// when it fails to add a unique index due to duplicated keys.
+	errMsg := "could not create unique constraint"
+	if preExisting {
+		errMsg = "failed to validate unique constraint"
+	}
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(
+				pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
+			),
+			constraintName,
+		),
+		fmt.Sprintf(
+			"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
+		),
+	)
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict according to the given exclusion constraint. It is called by
+// validateUniqueConstraint, since exclusion constraints are stored as unique
+// without index constraints.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	constraintName string,
+	columnIDs []descpb.ColumnID,
+	ops []string,
+	pred string,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	query, colNames, err := exclusionConflictQuery(srcTable, columnIDs, ops, pred)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		constraintName,
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	values, err := queryUniqueValidationRow(ctx, txn, user, "validate exclusion constraint", query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		n := len(colNames)
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint.
+		errMsg := "could not create exclusion constraint"
+		if preExisting {
+			errMsg = "failed to validate exclusion constraint"
+		}
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "%s %q", errMsg, constraintName,
+				),
+				constraintName,
+			),
+			fmt.Sprintf(
+				"Key %s conflicts with key %s.",
+				formatValues(colNames, values[:n]), formatValues(colNames, values[n:]),
+			),
+		)
+	}
+	return nil
+}
+
+// queryUniqueValidationRow runs the given query, which validates a unique or
+// exclusion constraint, and returns the first row it finds.
+func queryUniqueValidationRow(
+	ctx context.Context, txn isql.Txn, user username.SQLUsername, opName redact.RedactableString, query string,
+) (values tree.Datums, err error) {
 	sessionDataOverride := sessiondata.NoSessionDataOverride
 	sessionDataOverride.User = user
 	// We are likely to have performed a lot of work before getting here (e.g.
@@ -651,14 +842,13 @@ func validateUniqueConstraint(
 	// error as "job retryable" and relying on the jobs framework to do the
 	// retries in order to not waste (a lot of) work that was performed before
 	// we got here.
-	var values tree.Datums
 	retryOptions := retry.Options{
 		InitialBackoff: 20 * time.Millisecond,
 		Multiplier:     1.5,
 		MaxRetries:     5,
 	}
 	for r := retry.StartWithCtx(ctx, retryOptions); r.Next(); {
-		values, err = txn.QueryRowEx(ctx, "validate unique constraint", txn.KV(), sessionDataOverride, query)
+		values, err = txn.QueryRowEx(ctx, opName, txn.KV(), sessionDataOverride, query)
 		if err == nil {
 			break
 		}
@@ -669,32 +859,9 @@ func validateUniqueConstraint(
 			log.Infof(ctx, "retrying the validation query because of %v", err)
 			continue
 		}
-		return err
+		return nil, err
 	}
-	if values.Len() > 0 {
-		valuesStr := make([]string, len(values))
-		for i := range values {
-			valuesStr[i] = values[i].String()
-		}
-		// Note: this error message mirrors the message produced by Postgres
-		// when it fails to add a unique index due to duplicated keys.
-		errMsg := "could not create unique constraint"
-		if preExisting {
-			errMsg = "failed to validate unique constraint"
-		}
-		return errors.WithDetail(
-			pgerror.WithConstraintName(
-				pgerror.Newf(
-					pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
-				),
-				constraintName,
-			),
-			fmt.Sprintf(
-				"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
-			),
-		)
-	}
-	return nil
+	return values, err
 }
 
 // ValidateTTLScheduledJobsInCurrentDB is part of the EvalPlanner interface.
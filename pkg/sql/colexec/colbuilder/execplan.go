@@ -151,6 +151,9 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 		return nil
 
 	case core.TableReader != nil:
+		if core.TableReader.BernoulliSample != nil {
+			return errTableSampleUnsupported
+		}
 		return nil
 
 	case core.JoinReader != nil:
@@ -235,6 +238,7 @@ var (
 	errExperimentalWrappingProhibited = errors.Newf("wrapping for non-JoinReader and non-LocalPlanNode cores is prohibited in vectorize=%s", sessiondatapb.VectorizeExperimentalAlways)
 	errWrappedCast                    = errors.New("mismatched types in NewColOperator and unsupported casts")
 	errLookupJoinUnsupported          = errors.New("lookup join reader is unsupported in vectorized")
+	errTableSampleUnsupported         = errors.New("table reader with row sampling is unsupported in vectorized")
 	errFilteringAggregation           = errors.New("filtering aggregation not supported")
 	errNonInnerHashJoinWithOnExpr     = errors.New("can't plan vectorized non-inner hash joins with ON expressions")
 	errNonInnerMergeJoinWithOnExpr    = errors.New("can't plan vectorized non-inner merge joins with ON expressions")
@@ -49,9 +49,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/sql/regions"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
@@ -1382,6 +1384,10 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 		ex.state.finishExternalTxn()
 	}
 
+	if ex.notificationListener != nil {
+		ex.notificationListener.Close()
+		ex.notificationListener = nil
+	}
 	ex.resetExtraTxnState(ctx, txnEvent{eventType: txnEvType}, payloadErr)
 	if ex.hasCreatedTemporarySchema && !ex.server.cfg.TestingKnobs.DisableTempObjectsCleanupOnSessionExit {
 		err := cleanupSessionTempObjects(
@@ -1719,6 +1725,19 @@ type connExecutor struct {
 		// The map key is the sequence descpb.ID.
 		createdSequences map[descpb.ID]struct{}
 
+		// pendingListens are the LISTEN and UNLISTEN statements executed in the
+		// current transaction. They take effect when the transaction commits.
+		pendingListens []pendingListen
+
+		// notifications are the notifications sent in the current
+		// transaction, mapped to their sequence number in the transaction.
+		notifications map[sentNotification]int
+
+		// deferredConstraints contains the constraint check modes set with SET
+		// CONSTRAINTS and the deferred constraints which must be validated
+		// before the transaction commits.
+		deferredConstraints deferredConstraintsState
+
 		// shouldLogToTelemetry indicates if the current transaction should be
 		// logged to telemetry. It is used in telemetry transaction sampling
 		// mode to emit all statement events for a particular transaction.
@@ -1846,6 +1865,10 @@ type connExecutor struct {
 	// temporary schema, which requires special cleanup on close.
 	hasCreatedTemporarySchema bool
 
+	// notificationListener queues the notifications on the channels the
+	// session listens on. It is created by the first LISTEN of the session.
+	notificationListener *pgnotify.Listener
+
 	// stmtDiagnosticsRecorder is used to track which queries need to have
 	// information collected.
 	stmtDiagnosticsRecorder *stmtdiagnostics.Registry
@@ -2204,6 +2227,24 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent, pay
 		log.Warningf(ctx, "error closing cursors: %v", err)
 	}
 
+	// LISTEN and UNLISTEN take effect when the transaction commits. The
+	// notifications which arrived during the transaction can be delivered once
+	// it ends.
+	if ev.eventType == txnCommit {
+		ex.applyPendingListens()
+	}
+	ex.extraTxnState.pendingListens = nil
+	ex.extraTxnState.notifications = nil
+	// The constraint check modes are kept when the transaction restarts, but
+	// the violations found by the deferred checks are not.
+	ex.extraTxnState.deferredConstraints.pending = nil
+	if ev.eventType != txnRestart {
+		ex.extraTxnState.deferredConstraints.modes = constraintModes{}
+	}
+	if ex.notificationListener != nil && ev.eventType != txnRestart {
+		ex.notificationListener.MaybeWake()
+	}
+
 	switch ev.eventType {
 	case txnCommit, txnRollback, txnPrepare:
 		ex.extraTxnState.prepStmtsNamespaceAtTxnRewindPos.closeAllPortals(
@@ -2632,6 +2673,13 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		//   was created when the statement started executing (via the
 		//   reset() method).
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, crtime.NowMono())
+	case StartReplication:
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionQueryReceived, tcmd.TimeReceived)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionStartParse, tcmd.ParseStart)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionEndParse, tcmd.ParseEnd)
+		replRes := ex.clientComm.CreateStartReplicationResult(tcmd, pos)
+		res = replRes
+		ev, payload = ex.execStartReplication(ctx, tcmd, replRes)
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -2642,6 +2690,14 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		if ex.idleConn() {
 			return errDrainingComplete
 		}
+	case DeliverNotifications:
+		// Notifications are only sent between transactions. If the session is
+		// in a transaction, they are delivered once it ends.
+		notificationRes := ex.clientComm.CreateNotificationResult(pos)
+		res = notificationRes
+		if ex.idleConn() {
+			ex.deliverNotifications(notificationRes)
+		}
 	case Flush:
 		// Closing the res will flush the connection's buffer.
 		res = ex.clientComm.CreateFlushResult(pos)
@@ -2910,8 +2966,12 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				// Can't advance.
 			case CopyOut:
 				// Can't advance.
+			case StartReplication:
+				canAdvance = true
 			case DrainRequest:
 				canAdvance = true
+			case DeliverNotifications:
+				canAdvance = true
 			case Flush:
 				canAdvance = true
 			default:
@@ -3923,6 +3983,8 @@ func (ex *connExecutor) initPlanner(ctx context.Context, p *planner) {
 	p.noticeSender = nil
 	p.preparedStatements = ex.getPrepStmtsAccessor()
 	p.sqlCursors = ex.getCursorAccessor()
+	p.sessionListener = ex.getListenAccessor()
+	p.deferredConstraints = ex.getDeferredConstraintsAccessor()
 	p.storedProcTxnState = ex.getStoredProcTxnStateAccessor()
 	p.createdSequences = ex.getCreatedSequencesAccessor()
 
@@ -4274,6 +4336,14 @@ func (ex *connExecutor) maybeSetSQLLivenessSessionAndGeneration() error {
 func (ex *connExecutor) initStatementResult(
 	ctx context.Context, res RestrictedCommandResult, ast tree.Statement, cols colinfo.ResultColumns,
 ) error {
+	if fetch, ok := ast.(*tree.FetchCursor); ok {
+		// Rows fetched from a BINARY cursor are returned in the binary format,
+		// unless the client specified the result formats when binding the FETCH
+		// statement through the extended protocol.
+		if cursor := ex.getCursorAccessor().getCursor(fetch.Name); cursor != nil && cursor.binary {
+			res.SetDefaultFormatCode(pgwirebase.FormatBinary)
+		}
+	}
 	for i, c := range cols {
 		fmtCode, err := res.GetFormatCode(i)
 		if err != nil {
@@ -4512,6 +4582,18 @@ func (ex *connExecutor) getCursorAccessor() sqlCursors {
 	}
 }
 
+func (ex *connExecutor) getListenAccessor() sessionListener {
+	return connExListenAccessor{
+		ex: ex,
+	}
+}
+
+func (ex *connExecutor) getDeferredConstraintsAccessor() deferredConstraints {
+	return connExDeferredConstraintsAccessor{
+		ex: ex,
+	}
+}
+
 func (ex *connExecutor) getStoredProcTxnStateAccessor() storedProcTxnStateAccessor {
 	return storedProcTxnStateAccessor{
 		ex: ex,
@@ -690,7 +690,15 @@ func (ex *connExecutor) execDescribe(
 			}
 			// Sending a nil formatCodes is equivalent to sending all text format
 			// codes.
-			res.SetPortalOutput(ctx, cursor.Rows.Types(), nil /* formatCodes */)
+			var formatCodes []pgwirebase.FormatCode
+			cols := cursor.Rows.Types()
+			if cursor.binary {
+				formatCodes = make([]pgwirebase.FormatCode, len(cols))
+				for i := range formatCodes {
+					formatCodes[i] = pgwirebase.FormatBinary
+				}
+			}
+			res.SetPortalOutput(ctx, cols, formatCodes)
 			return nil, nil
 		}
 
@@ -97,10 +97,13 @@ func (ex *connExecutor) execSavepointInOpenState(
 	}
 
 	sp := savepoint{
-		name:            s.Name,
-		commitOnRelease: commitOnRelease,
-		kvToken:         token,
-		numDDL:          ex.extraTxnState.numDDL,
+		name:              s.Name,
+		commitOnRelease:   commitOnRelease,
+		kvToken:           token,
+		numDDL:            ex.extraTxnState.numDDL,
+		numPendingListens: len(ex.extraTxnState.pendingListens),
+		numNotifications:  len(ex.extraTxnState.notifications),
+		constraintModes:   ex.extraTxnState.deferredConstraints.modes.clone(),
 	}
 	savepoints.push(sp)
 	ex.sessionDataStack.PushTopClone()
@@ -215,6 +218,8 @@ func (ex *connExecutor) execRollbackToSavepointInOpenState(
 	if err := ex.popSavepointsToIdx(s, idx); err != nil {
 		return ex.makeErrEvent(err, s)
 	}
+	ex.rollbackListenAndNotify(entry)
+	ex.extraTxnState.deferredConstraints.modes = entry.constraintModes.clone()
 
 	if entry.kvToken.Initial() {
 		return eventTxnRestart{}, nil
@@ -292,6 +297,8 @@ func (ex *connExecutor) execRollbackToSavepointInAbortedState(
 	if err := ex.popSavepointsToIdx(s, idx); err != nil {
 		return ex.makeErrEvent(err, s)
 	}
+	ex.rollbackListenAndNotify(entry)
+	ex.extraTxnState.deferredConstraints.modes = entry.constraintModes.clone()
 
 	if err := ex.state.mu.txn.RollbackToSavepoint(ctx, entry.kvToken); err != nil {
 		return ex.makeErrEvent(err, s)
@@ -360,6 +367,35 @@ type savepoint struct {
 	// more DDL statements were executed since the savepoint's creation.
 	// TODO(knz): support partial DDL cancellation in pending txns.
 	numDDL int
+
+	// The number of LISTEN and UNLISTEN statements that had been executed in
+	// the transaction at the time the savepoint was created. The ones executed
+	// since are discarded when rolling back to the savepoint.
+	numPendingListens int
+
+	// The constraint check modes set with SET CONSTRAINTS at the time the
+	// savepoint was created. They are restored when rolling back to the
+	// savepoint. The deferred constraints which found a violation since are
+	// still validated at commit time, which is harmless.
+	constraintModes constraintModes
+
+	// The number of notifications that had been sent in the transaction at
+	// the time the savepoint was created. The ones sent since are forgotten
+	// when rolling back to the savepoint, so they can be sent again.
+	numNotifications int
+}
+
+// rollbackListenAndNotify discards the LISTEN, UNLISTEN and NOTIFY statements
+// executed since the given savepoint was created.
+func (ex *connExecutor) rollbackListenAndNotify(sp *savepoint) {
+	if len(ex.extraTxnState.pendingListens) > sp.numPendingListens {
+		ex.extraTxnState.pendingListens = ex.extraTxnState.pendingListens[:sp.numPendingListens]
+	}
+	for n, seq := range ex.extraTxnState.notifications {
+		if seq >= sp.numNotifications {
+			delete(ex.extraTxnState.notifications, n)
+		}
+	}
 }
 
 type savepointStack []savepoint
@@ -15,6 +15,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -401,6 +404,40 @@ func (c CopyIn) String() string {
 
 var _ Command = CopyIn{}
 
+// StartReplication is the command for streaming changes from a logical
+// replication slot using the CopyBoth pgwire subprotocol. While the stream is
+// running, the network routine keeps reading from the connection and forwards
+// the client's messages through the channels below.
+type StartReplication struct {
+	ParsedStmt statements.Statement[tree.Statement]
+	Stmt       *pgrepltree.StartReplication
+	// Feedback receives the flush positions reported by the client's standby
+	// status updates.
+	Feedback <-chan lsn.LSN
+	// ClientDone is closed once the client ends the stream, either with a
+	// CopyDone message or by closing the connection.
+	ClientDone <-chan struct{}
+	// TimeReceived is the time at which the message was received
+	// from the client. Used to compute the service latency.
+	TimeReceived crtime.Mono
+	// ParseStart/ParseEnd are the timing info for parsing of the query. Used for
+	// stats reporting.
+	ParseStart crtime.Mono
+	ParseEnd   crtime.Mono
+}
+
+// command implements the Command interface.
+func (StartReplication) command() string { return "start replication" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (StartReplication) isExtendedProtocolCmd() bool { return false }
+
+func (c StartReplication) String() string {
+	return fmt.Sprintf("StartReplication: %s", c.Stmt)
+}
+
+var _ Command = StartReplication{}
+
 // CopyOut is the command for execution of the Copy-out pgwire subprotocol.
 type CopyOut struct {
 	ParsedStmt statements.Statement[tree.Statement]
@@ -448,6 +485,26 @@ func (DrainRequest) String() string {
 
 var _ Command = DrainRequest{}
 
+// DeliverNotifications is pushed by the notification listener of a session
+// when notifications are queued for the session. The notifications are sent
+// to the client if the session is not in a transaction; otherwise they are
+// sent once the transaction ends.
+//
+// DeliverNotifications commands produce a NotificationResult.
+type DeliverNotifications struct{}
+
+// command implements the Command interface.
+func (DeliverNotifications) command() string { return "deliver notifications" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (DeliverNotifications) isExtendedProtocolCmd() bool { return false }
+
+func (DeliverNotifications) String() string {
+	return "DeliverNotifications"
+}
+
+var _ Command = DeliverNotifications{}
+
 // SendError is a command that, upon execution, send a specific error to the
 // client. This is used by pgwire to schedule errors to be sent at an
 // appropriate time.
@@ -756,8 +813,14 @@ type ClientComm interface {
 	CreateCopyInResult(cmd CopyIn, pos CmdPos) CopyInResult
 	// CreateCopyOutResult creates a result for a Copy-out command.
 	CreateCopyOutResult(cmd CopyOut, pos CmdPos) CopyOutResult
+	// CreateStartReplicationResult creates a result for a StartReplication
+	// command.
+	CreateStartReplicationResult(cmd StartReplication, pos CmdPos) StartReplicationResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
+	// CreateNotificationResult creates a result for a DeliverNotifications
+	// command.
+	CreateNotificationResult(pos CmdPos) NotificationResult
 
 	// LockCommunication ensures that no further results are delivered to the
 	// client. The returned ClientLock can be queried to see what results have
@@ -857,6 +920,14 @@ type RestrictedCommandResult interface {
 	// data in the provided column when sending messages to the client.
 	GetFormatCode(colIdx int) (pgwirebase.FormatCode, error)
 
+	// SetDefaultFormatCode sets the format code that is used for columns for
+	// which the client did not request a specific format (i.e. for all columns
+	// of statements executed through the simple protocol). It is used to return
+	// rows from BINARY cursors in the binary format.
+	//
+	// This needs to be called before SetColumns.
+	SetDefaultFormatCode(pgwirebase.FormatCode)
+
 	// AddRow accumulates a result row.
 	//
 	// The implementation cannot hold on to the row slice; it needs to make a
@@ -969,6 +1040,16 @@ type DrainResult interface {
 	ResultBase
 }
 
+// NotificationResult represents the result of a DeliverNotifications
+// command. Closing this result sends the buffered notifications to the
+// client and flushes them.
+type NotificationResult interface {
+	ResultBase
+
+	// BufferNotification buffers a notification to be sent to the client.
+	BufferNotification(n pgnotify.Notification)
+}
+
 // EmptyQueryResult represents the result of an empty query (a query
 // representing a blank string).
 type EmptyQueryResult interface {
@@ -1001,6 +1082,28 @@ type CopyOutResult interface {
 	SendCopyDone(ctx context.Context) error
 }
 
+// StartReplicationResult represents the result of a StartReplication command.
+// Closing this result sends a CommandComplete message to the client.
+type StartReplicationResult interface {
+	ResultBase
+
+	// SendCopyBoth sends the response starting the replication stream to the
+	// client.
+	SendCopyBoth(ctx context.Context) error
+
+	// SendReplicationData adds a CopyData message carrying a replication
+	// message to the result. The message may be buffered until
+	// FlushReplicationData is called.
+	SendReplicationData(ctx context.Context, data []byte) error
+
+	// FlushReplicationData flushes the buffered replication messages to the
+	// client.
+	FlushReplicationData(ctx context.Context) error
+
+	// SendCopyDone sends the copy done response to the client.
+	SendCopyDone(ctx context.Context) error
+}
+
 // ClientLock is an interface returned by ClientComm.lockCommunication(). It
 // represents a lock on the delivery of results to a SQL client. While such a
 // lock is used, no more results are delivered. The lock itself can be used to
@@ -1135,6 +1238,12 @@ func (r *streamingCommandResult) GetFormatCode(colIdx int) (pgwirebase.FormatCod
 	return pgwirebase.FormatText, nil
 }
 
+// SetDefaultFormatCode is part of the sql.RestrictedCommandResult interface.
+func (r *streamingCommandResult) SetDefaultFormatCode(pgwirebase.FormatCode) {
+	// Rows aren't serialized in the streamingCommandResult, so the format code
+	// is ignored.
+}
+
 // AddRow is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) AddRow(ctx context.Context, row tree.Datums) error {
 	// AddRow() and SetRowsAffected() are never called on the same command
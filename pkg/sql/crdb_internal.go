@@ -3658,7 +3658,7 @@ func writeCreateTypeDescRow(
 			typeList[i].Label = tree.Name(c.GetElementLabel(i))
 		}
 		typeVariety = tree.Composite
-	} else {
+	} else if typeDesc.AsDomainTypeDescriptor() == nil {
 		return false, errors.AssertionFailedf("unknown type descriptor kind %s", typeDesc.GetKind())
 	}
 
@@ -3666,11 +3666,18 @@ func writeCreateTypeDescRow(
 	if err != nil {
 		return false, err
 	}
-	node := &tree.CreateType{
-		Variety:           typeVariety,
-		TypeName:          name,
-		CompositeTypeList: typeList,
-		EnumLabels:        enumLabels,
+	var node tree.NodeFormatter
+	if d := typeDesc.AsDomainTypeDescriptor(); d != nil {
+		if node, err = makeCreateDomainNode(name, d); err != nil {
+			return false, err
+		}
+	} else {
+		node = &tree.CreateType{
+			Variety:           typeVariety,
+			TypeName:          name,
+			CompositeTypeList: typeList,
+			EnumLabels:        enumLabels,
+		}
 	}
 
 	createStatement := tree.AsString(node)
@@ -3692,6 +3699,35 @@ func writeCreateTypeDescRow(
 	)
 }
 
+// makeCreateDomainNode reconstructs the CREATE DOMAIN statement of a domain.
+func makeCreateDomainNode(
+	name *tree.UnresolvedObjectName, d catalog.DomainTypeDescriptor,
+) (*tree.CreateDomain, error) {
+	node := &tree.CreateDomain{
+		Name:    name,
+		Type:    d.BaseType(),
+		NotNull: d.IsNotNull(),
+	}
+	if exprStr, ok := d.GetDefaultExpr(); ok {
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			return nil, err
+		}
+		node.Default = expr
+	}
+	for i := 0; i < d.NumChecks(); i++ {
+		expr, err := parser.ParseExpr(d.GetCheckExpr(i))
+		if err != nil {
+			return nil, err
+		}
+		node.Checks = append(node.Checks, tree.DomainCheck{
+			Name: tree.Name(d.GetCheckName(i)),
+			Expr: expr,
+		})
+	}
+	return node, nil
+}
+
 var crdbInternalCreateTypeStmtsTable = virtualSchemaTable{
 	comment: "CREATE statements for all user defined types accessible by the current user in current database (KV scan)",
 	schema: `
@@ -0,0 +1,175 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+type createDomainNode struct {
+	zeroInputPlanNode
+	n        *tree.CreateDomain
+	typeName *tree.TypeName
+	dbDesc   catalog.DatabaseDescriptor
+}
+
+// CreateDomain creates a domain, which is a type descriptor wrapping a base
+// type with NOT NULL, DEFAULT and CHECK constraints.
+func (p *planner) CreateDomain(ctx context.Context, n *tree.CreateDomain) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V25_2_AddDomains) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE DOMAIN unsupported in mixed-version cluster")
+	}
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), "CREATE DOMAIN"); err != nil {
+		return nil, err
+	}
+	typeName, db, err := resolveNewTypeName(ctx, p, n.Name)
+	if err != nil {
+		return nil, err
+	}
+	n.Name.SetAnnotation(&p.semaCtx.Annotations, typeName)
+	return &createDomainNode{
+		n:        n,
+		typeName: typeName,
+		dbDesc:   db,
+	}, nil
+}
+
+func (n *createDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("domain"))
+	p := params.p
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	schema, err := getCreateTypeParams(params.ctx, p, n.typeName, n.dbDesc)
+	if err != nil {
+		return err
+	}
+	domain, err := makeDomain(params.ctx, p, n.n)
+	if err != nil {
+		return err
+	}
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return err
+	}
+	typeDesc := typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           n.typeName.Type(),
+		ID:             id,
+		ParentID:       n.dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType()
+	return p.finishCreateType(params.ctx, params.EvalContext(), n.typeName, typeDesc, n.dbDesc, schema)
+}
+
+// makeDomain validates the base type and the constraints of a domain.
+func makeDomain(
+	ctx context.Context, p *planner, n *tree.CreateDomain,
+) (*descpb.TypeDescriptor_Domain, error) {
+	baseType, err := tree.ResolveType(ctx, n.Type, p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.CheckTriggerType(baseType); err != nil {
+		return nil, err
+	}
+	if err := tree.CheckUnsupportedType(ctx, &p.semaCtx, baseType); err != nil {
+		return nil, err
+	}
+	switch {
+	case baseType.UserDefined():
+		return nil, unimplemented.Newf("domain over user-defined type",
+			"domains over user-defined types are not supported")
+	case baseType.Family() == types.ArrayFamily, baseType.Family() == types.TupleFamily:
+		return nil, unimplemented.Newf("domain over "+baseType.Family().Name(),
+			"domains over %s types are not supported", baseType.Family().Name())
+	}
+
+	version := p.ExecCfg().Settings.Version.ActiveVersion(ctx)
+	domain := &descpb.TypeDescriptor_Domain{
+		BaseType: baseType,
+		NotNull:  n.NotNull,
+	}
+	if n.Default != nil && n.Default != tree.DNull {
+		expr, err := schemaexpr.ValidateDomainDefaultExpr(ctx, n.Default, baseType, &p.semaCtx, version)
+		if err != nil {
+			return nil, err
+		}
+		domain.DefaultExpr = &expr
+	}
+	seenNames := make(map[string]struct{}, len(n.Checks))
+	for _, c := range n.Checks {
+		if c.Name != "" {
+			seenNames[string(c.Name)] = struct{}{}
+		}
+	}
+	for _, c := range n.Checks {
+		name := string(c.Name)
+		if name == "" {
+			name = n.Name.Object() + "_check"
+			for i := 1; ; i++ {
+				if _, ok := seenNames[name]; !ok {
+					break
+				}
+				name = fmt.Sprintf("%s_check%d", n.Name.Object(), i)
+			}
+		} else if domainHasCheck(domain, name) {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"constraint %q for domain %q already exists", name, n.Name.Object())
+		}
+		seenNames[name] = struct{}{}
+		expr, err := schemaexpr.ValidateDomainCheckExpr(ctx, c.Expr, baseType, &p.semaCtx, version)
+		if err != nil {
+			return nil, err
+		}
+		domain.Checks = append(domain.Checks, descpb.TypeDescriptor_Domain_DomainCheck{
+			Name: name,
+			Expr: expr,
+		})
+	}
+	return domain, nil
+}
+
+func domainHasCheck(domain *descpb.TypeDescriptor_Domain, name string) bool {
+	for i := range domain.Checks {
+		if domain.Checks[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *createDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createDomainNode) Close(ctx context.Context)           {}
+func (n *createDomainNode) ReadingOwnWrites()                   {}
@@ -176,6 +176,7 @@ func (n *createFunctionNode) createNewFunction(
 			ReturnType:       returnType,
 			ReturnSet:        udfDesc.ReturnType.ReturnSet,
 			IsProcedure:      udfDesc.IsProcedure(),
+			IsAggregate:      udfDesc.IsAggregate(),
 			OutParamOrdinals: outParamOrdinals,
 			OutParamTypes:    outParamTypes,
 			DefaultExprs:     defaultExprs,
@@ -206,6 +207,13 @@ func (n *createFunctionNode) replaceFunction(
 			udfDesc.Name,
 		)
 	}
+	if udfDesc.IsAggregate() {
+		return errors.WithDetailf(
+			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+			"%q is an aggregate function",
+			udfDesc.Name,
+		)
+	}
 
 	// Make sure return type is the same. The signature of user-defined types
 	// may change, as long as the same type is referenced. If this is the case,
@@ -335,6 +343,7 @@ func (n *createFunctionNode) replaceFunction(
 				ReturnType:       retType,
 				ReturnSet:        udfDesc.ReturnType.ReturnSet,
 				IsProcedure:      n.cf.IsProcedure,
+				IsAggregate:      udfDesc.IsAggregate(),
 				OutParamOrdinals: outParamOrdinals,
 				OutParamTypes:    outParamTypes,
 				DefaultExprs:     defaultExprs,
@@ -424,6 +433,15 @@ func (n *createFunctionNode) getMutableFuncDesc(
 		n.cf.IsProcedure,
 		privileges,
 	)
+	if agg := n.cf.Aggregate; agg != nil {
+		newUdfDesc.SetAggregate(&descpb.FunctionDescriptor_AggregateInfo{
+			TransitionFunctionOID: agg.SFuncOID,
+			StateType:             agg.StateType,
+			FinalFunctionOID:      agg.FinalFuncOID,
+			InitialCondition:      agg.InitCond,
+			CombineFunctionOID:    agg.CombineFuncOID,
+		})
+	}
 
 	return &newUdfDesc, nil, nil
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/scheduledjobs"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -715,7 +716,9 @@ func addUniqueWithoutIndexColumnTableDef(
 		desc,
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
-		"", /* predicate */
+		"",  /* predicate */
+		nil, /* exclusionOperators */
+		tree.NotDeferrable,
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -779,17 +782,148 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, nil /* exclusionOperators */, d.Deferrability,
+		ts, validationBehavior,
 	); err != nil {
 		return err
 	}
 	return nil
 }
 
+// addExclusionConstraintTableDef runs various checks on the given
+// ExclusionConstraintTableDef before adding it as a UNIQUE WITHOUT INDEX
+// constraint with exclusion operators to the given table descriptor. The index
+// backing the constraint is added separately, see
+// exclusionConstraintIndexDefs.
+func addExclusionConstraintTableDef(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	d *tree.ExclusionConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	semaCtx *tree.SemaContext,
+) error {
+	// The checks of exclusion constraints don't lock the conflicting rows, so
+	// they could be violated by concurrent transactions under weaker isolation
+	// levels. Writes are rejected in that case (see buildUniqueChecksForInsert),
+	// so the constraint can't be added either. Note that schema changes are
+	// usually upgraded to serializable isolation before getting here.
+	if evalCtx.TxnIsoLevel != isolation.Serializable {
+		return sqlerrors.NewExclusionConstraintIsolationError()
+	}
+	ops, err := schemaexpr.ValidateExclusionConstraintElems(d.Method, d.Elems, columnTypeByName(desc))
+	if err != nil {
+		return err
+	}
+
+	// If there is a predicate, validate it.
+	var predicate string
+	if d.Predicate != nil {
+		predicate, err = schemaexpr.ValidateUniqueWithoutIndexPredicate(
+			ctx, tn, desc, d.Predicate, semaCtx, evalCtx.Settings.Version.ActiveVersionOrEmpty(ctx),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	colNames := make([]string, len(d.Elems))
+	for i := range colNames {
+		colNames[i] = string(d.Elems[i].Column)
+	}
+	return ResolveUniqueWithoutIndexConstraint(
+		ctx, desc, string(d.Name), colNames, predicate, ops, d.Deferrability, ts, validationBehavior,
+	)
+}
+
+// columnTypeByName returns a function that returns the type of the active or
+// new column of the given table with the given name.
+func columnTypeByName(desc *tabledesc.Mutable) func(tree.Name) (*types.T, error) {
+	return func(name tree.Name) (*types.T, error) {
+		col, err := desc.FindActiveOrNewColumnByName(name)
+		if err != nil {
+			return nil, err
+		}
+		return col.GetType(), nil
+	}
+}
+
+// exclusionConstraintIndexDefs returns the definitions of the indexes backing
+// the exclusion constraints among the given definitions of a new table. The
+// constraints backed by one of the indexes defined with the table, such as
+// the ones in the output of SHOW CREATE TABLE, don't need another one.
+func exclusionConstraintIndexDefs(
+	desc *tabledesc.Mutable, defs tree.TableDefs,
+) (tree.TableDefs, error) {
+	var ret tree.TableDefs
+	for _, def := range defs {
+		d, ok := def.(*tree.ExclusionConstraintTableDef)
+		if !ok {
+			continue
+		}
+		columns, typ, err := schemaexpr.ExclusionConstraintIndexColumns(d.Elems, columnTypeByName(desc))
+		if err != nil {
+			return nil, err
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		backed := false
+		for _, other := range append(defs[:len(defs):len(defs)], ret...) {
+			if idx, ok := other.(*tree.IndexTableDef); ok && indexDefBacksExclusionConstraint(idx, columns, typ) {
+				backed = true
+				break
+			}
+		}
+		if !backed {
+			ret = append(ret, &tree.IndexTableDef{Columns: columns, Type: typ})
+		}
+	}
+	return ret, nil
+}
+
+// indexDefBacksExclusionConstraint returns whether the given index definition
+// can back an exclusion constraint, i.e. whether it's a non-partial index of
+// the given type on the given columns.
+func indexDefBacksExclusionConstraint(
+	d *tree.IndexTableDef, columns tree.IndexElemList, typ idxtype.T,
+) bool {
+	if d.Type != typ || d.Predicate != nil || len(d.Columns) != len(columns) {
+		return false
+	}
+	for i := range columns {
+		if d.Columns[i].Expr != nil || d.Columns[i].Column != columns[i].Column {
+			return false
+		}
+	}
+	return true
+}
+
+// indexBacksExclusionConstraint is like indexDefBacksExclusionConstraint for
+// an existing index.
+func indexBacksExclusionConstraint(
+	idx catalog.Index, columns tree.IndexElemList, typ idxtype.T,
+) bool {
+	if idx.GetType() != typ || idx.IsPartial() || idx.NumKeyColumns() != len(columns) {
+		return false
+	}
+	for i := range columns {
+		if idx.GetKeyColumnName(i) != string(columns[i].Column) {
+			return false
+		}
+	}
+	return true
+}
+
 // ResolveUniqueWithoutIndexConstraint looks up the columns mentioned in a
 // UNIQUE WITHOUT INDEX constraint and adds metadata representing that
 // constraint to the descriptor.
 //
+// exclusionOperators is non-empty for an EXCLUDE constraint, in which case it
+// contains the operator of each column.
+//
 // The passed validationBehavior is used to determine whether or not preexisting
 // entries in the table need to be validated against the unique constraint being
 // added. This only applies for existing tables, not new tables.
@@ -799,6 +933,8 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	exclusionOperators []string,
+	deferrability tree.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -811,8 +947,12 @@ func ResolveUniqueWithoutIndexConstraint(
 		}
 		// Ensure that the columns don't have duplicates.
 		if colSet.Contains(col.GetID()) {
+			kind := "unique"
+			if len(exclusionOperators) > 0 {
+				kind = "exclusion"
+			}
 			return pgerror.Newf(pgcode.DuplicateColumn,
-				"column %q appears twice in unique constraint", col.GetName())
+				"column %q appears twice in %s constraint", col.GetName(), kind)
 		}
 		colSet.Add(col.GetID())
 		cols[i] = col
@@ -820,8 +960,12 @@ func ResolveUniqueWithoutIndexConstraint(
 
 	// Verify we are not writing a constraint over the same name.
 	if constraintName == "" {
+		prefix := fmt.Sprintf("unique_%s", strings.Join(colNames, "_"))
+		if len(exclusionOperators) > 0 {
+			prefix = fmt.Sprintf("%s_%s_excl", tbl.Name, strings.Join(colNames, "_"))
+		}
 		constraintName = tabledesc.GenerateUniqueName(
-			fmt.Sprintf("unique_%s", strings.Join(colNames, "_")),
+			prefix,
 			func(p string) bool {
 				return catalog.FindConstraintByName(tbl, p) != nil
 			},
@@ -847,12 +991,15 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:               constraintName,
+		TableID:            tbl.ID,
+		ColumnIDs:          columnIDs,
+		Predicate:          predicate,
+		Validity:           validity,
+		ConstraintID:       tbl.NextConstraintID,
+		Deferrable:         deferrability.IsDeferrable(),
+		InitiallyDeferred:  deferrability.IsInitiallyDeferred(),
+		ExclusionOperators: exclusionOperators,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1126,6 +1273,8 @@ func ResolveFK(
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrable:          d.Deferrability.IsDeferrable(),
+		InitiallyDeferred:   d.Deferrability.IsInitiallyDeferred(),
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1407,6 +1556,20 @@ func NewTableDesc(
 ) (*tabledesc.Mutable, error) {
 
 	version := st.Version.ActiveVersionOrEmpty(ctx)
+	if !version.IsActive(clusterversion.V25_2_DeferrableConstraints) {
+		for _, def := range n.Defs {
+			if d, ok := def.(tree.ConstraintTableDef); ok && tree.IsDeferrableConstraintDef(d) {
+				return nil, sqlerrors.NewDeferrableConstraintsNotSupportedError()
+			}
+		}
+	}
+	if !version.IsActive(clusterversion.V25_2_ExclusionConstraints) {
+		for _, def := range n.Defs {
+			if _, ok := def.(*tree.ExclusionConstraintTableDef); ok {
+				return nil, sqlerrors.NewExclusionConstraintsNotSupportedError()
+			}
+		}
+	}
 	// Used to delay establishing Column/Sequence dependency until ColumnIDs have
 	// been populated.
 	cdd := make([]*tabledesc.ColumnDefDescs, len(n.Defs))
@@ -1900,7 +2063,13 @@ func NewTableDesc(
 		}
 	}
 
-	for _, def := range n.Defs {
+	// The indexes backing exclusion constraints are added like the other
+	// indexes.
+	exclusionIndexDefs, err := exclusionConstraintIndexDefs(&desc, n.Defs)
+	if err != nil {
+		return nil, err
+	}
+	for _, def := range append(n.Defs[:len(n.Defs):len(n.Defs)], exclusionIndexDefs...) {
 		switch d := def.(type) {
 		case *tree.ColumnTableDef, *tree.LikeTableDef:
 			// pass, handled above.
@@ -2033,6 +2202,9 @@ func NewTableDesc(
 				// We will add the unique constraint below.
 				break
 			}
+			if d.Deferrability.IsDeferrable() {
+				return nil, sqlerrors.NewDeferrableUniqueIndexError()
+			}
 			// If the index is named, ensure that the name is unique. Unnamed
 			// indexes will be given a unique auto-generated name later on when
 			// AllocateIDs is called.
@@ -2165,7 +2337,8 @@ func NewTableDesc(
 			); err != nil {
 				return nil, err
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExclusionConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2336,6 +2509,13 @@ func NewTableDesc(
 				}
 			}
 
+		case *tree.ExclusionConstraintTableDef:
+			if err := addExclusionConstraintTableDef(
+				ctx, evalCtx, d, &desc, n.Table, NewTable, tree.ValidationDefault, semaCtx,
+			); err != nil {
+				return nil, err
+			}
+
 		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
 			// Pass, handled above.
 
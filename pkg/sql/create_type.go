@@ -233,6 +233,8 @@ func CreateUserDefinedArrayTypeDesc(
 			labels[i] = e.ElementLabel
 		}
 		elemTyp = types.NewCompositeType(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), contents, labels)
+	case descpb.TypeDescriptor_DOMAIN:
+		elemTyp = types.MakeDomain(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), typDesc.Domain.BaseType)
 	default:
 		return nil, errors.AssertionFailedf("cannot make array type for kind %s", t.String())
 	}
@@ -414,8 +416,8 @@ func createCompositeTypeDesc(
 		if err != nil {
 			return nil, err
 		}
-		if typ.Identical(types.Trigger) {
-			return nil, tree.CannotAcceptTriggerErr
+		if err := tree.CheckTriggerType(typ); err != nil {
+			return nil, err
 		}
 		if err = tree.CheckUnsupportedType(params.ctx, &params.p.semaCtx, typ); err != nil {
 			return nil, err
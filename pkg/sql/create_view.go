@@ -255,6 +255,8 @@ func (n *createViewNode) startExec(params runParams) error {
 					return err
 				}
 
+				desc.ViewCheckOption = viewCheckOptionToProto(createView.CheckOption)
+
 				if createView.Materialized {
 					// If the view is materialized, set up some more state on the view descriptor.
 					// In particular,
@@ -392,6 +394,20 @@ func (n *createViewNode) Close(ctx context.Context)  {}
 // dependencies in the same transaction that the view is created and it
 // doesn't matter if reads/writes use a cached descriptor that doesn't
 // include the back-references.
+// viewCheckOptionToProto converts the WITH CHECK OPTION clause of a CREATE
+// VIEW statement to its descriptor representation.
+func viewCheckOptionToProto(
+	checkOption tree.ViewCheckOption,
+) descpb.TableDescriptor_ViewCheckOption {
+	switch checkOption {
+	case tree.ViewCheckOptionLocal:
+		return descpb.TableDescriptor_LOCAL
+	case tree.ViewCheckOptionCascaded:
+		return descpb.TableDescriptor_CASCADED
+	}
+	return descpb.TableDescriptor_NONE
+}
+
 func makeViewTableDesc(
 	ctx context.Context,
 	viewName string,
@@ -704,6 +720,7 @@ func (p *planner) replaceViewDesc(
 ) (*tabledesc.Mutable, error) {
 	// Set the query to the new query.
 	toReplace.ViewQuery = n.viewQuery
+	toReplace.ViewCheckOption = viewCheckOptionToProto(n.createView.CheckOption)
 
 	if sc != nil {
 		updatedQuery, err := replaceSeqNamesWithIDs(ctx, sc, n.viewQuery, false /* multiStmt */)
@@ -57,6 +57,11 @@ func (n *discardNode) startExec(params runParams) error {
 			m.initSequenceCache()
 		})
 
+		// UNLISTEN *
+		if err := params.p.sessionListener.addPendingListen(pendingListen{unlisten: true}); err != nil {
+			return err
+		}
+
 		// DISCARD TEMP
 		err := deleteTempTables(params.ctx, params.p)
 		if err != nil {
@@ -8,7 +8,10 @@ package sql
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
+	"hash/fnv"
+	"math"
 	"reflect"
 	"sort"
 
@@ -38,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/execstats"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -580,6 +584,13 @@ func checkSupportForPlanNode(
 			if agg.distsqlBlocklist {
 				return cannotDistribute, newQueryNotSupportedErrorf("aggregate %q cannot be executed with distsql", agg.funcName)
 			}
+			if agg.userDefined != nil {
+				for _, expr := range userDefinedAggExprs(agg.userDefined) {
+					if err := checkExprForDistSQL(expr, distSQLVisitor); err != nil {
+						return cannotDistribute, err
+					}
+				}
+			}
 		}
 		// Don't force distribution if we expect to process small number of
 		// rows.
@@ -808,6 +819,15 @@ func checkSupportForPlanNode(
 		if err != nil {
 			return cannotDistribute, err
 		}
+		for _, f := range n.funcs {
+			if f.userDefined != nil {
+				for _, expr := range userDefinedAggExprs(f.userDefined) {
+					if err := checkExprForDistSQL(expr, distSQLVisitor); err != nil {
+						return cannotDistribute, err
+					}
+				}
+			}
+		}
 		if len(n.partitionIdxs) > 0 {
 			// If the window has a PARTITION BY clause, then we should distribute the
 			// execution.
@@ -2182,6 +2202,7 @@ func initTableReaderSpecTemplate(
 		LockingStrength:                 n.lockingStrength,
 		LockingWaitPolicy:               n.lockingWaitPolicy,
 		LockingDurability:               n.lockingDurability,
+		BernoulliSample:                 makeBernoulliSampleSpec(n.tableSample),
 	}
 	if err := rowenc.InitIndexFetchSpec(&s.FetchSpec, codec, n.desc, n.index, colIDs); err != nil {
 		return nil, execinfrapb.PostProcessSpec{}, err
@@ -2196,6 +2217,21 @@ func initTableReaderSpecTemplate(
 	return s, post, nil
 }
 
+// makeBernoulliSampleSpec returns the spec for the row-level sampling that a
+// TableReader must perform for the given TABLESAMPLE clause, or nil if none is
+// needed. Sampling with the SYSTEM method is performed when planning the
+// TableReaders instead (see systemSampleSpans).
+func makeBernoulliSampleSpec(sample opt.TableSample) *execinfrapb.BernoulliSampleSpec {
+	if sample.Method != tree.TableSampleBernoulli {
+		return nil
+	}
+	return &execinfrapb.BernoulliSampleSpec{
+		Fraction:   sample.Fraction,
+		Repeatable: sample.Repeatable,
+		Seed:       sample.Seed,
+	}
+}
+
 // createTableReaders generates a plan consisting of table reader processors,
 // one for each node that has spans that we are reading.
 func (dsp *DistSQLPlanner) createTableReaders(
@@ -2220,6 +2256,7 @@ func (dsp *DistSQLPlanner) createTableReaders(
 			parallelize:         n.parallelize,
 			estimatedRowCount:   n.estimatedRowCount,
 			reqOrdering:         n.reqOrdering,
+			tableSample:         n.tableSample,
 			finalizeLastStageCb: planCtx.associateWithPlanNode(n),
 		},
 	)
@@ -2238,6 +2275,7 @@ type tableReaderPlanningInfo struct {
 	parallelize         bool
 	estimatedRowCount   uint64
 	reqOrdering         ReqOrdering
+	tableSample         opt.TableSample
 	finalizeLastStageCb func(*physicalplan.PhysicalPlan) // will be nil in the spec factory
 }
 
@@ -2395,6 +2433,31 @@ func (dsp *DistSQLPlanner) planTableReaders(
 		ignoreMisplannedRanges bool
 		err                    error
 	)
+	typs := make([]*types.T, len(info.spec.FetchSpec.FetchedColumns))
+	for i := range typs {
+		typs[i] = info.spec.FetchSpec.FetchedColumns[i].Type
+	}
+
+	if info.tableSample.Method == tree.TableSampleSystem {
+		info.spans, err = dsp.systemSampleSpans(ctx, planCtx, info.spans, info.tableSample)
+		if err != nil {
+			return err
+		}
+		if len(info.spans) == 0 {
+			// None of the ranges were included in the sample, so there is
+			// nothing to scan.
+			corePlacement := []physicalplan.ProcessorCorePlacement{{
+				SQLInstanceID: dsp.gatewaySQLInstanceID,
+				Core: execinfrapb.ProcessorCoreUnion{
+					Values: dsp.createValuesSpec(planCtx, typs, 0 /* numRows */, nil /* rawBytes */),
+				},
+			}}
+			p.AddNoInputStage(corePlacement, info.post, typs, execinfrapb.Ordering{}, info.finalizeLastStageCb)
+			p.PlanToStreamColMap = identityMap(make([]int, len(typs)), len(typs))
+			return nil
+		}
+	}
+
 	if planCtx.isLocal {
 		spanPartitions, parallelizeLocal = dsp.maybeParallelizeLocalScans(ctx, planCtx, info)
 	} else if info.post.Limit == 0 {
@@ -2478,11 +2541,6 @@ func (dsp *DistSQLPlanner) planTableReaders(
 		corePlacement[i].Core.TableReader = tr
 	}
 
-	typs := make([]*types.T, len(info.spec.FetchSpec.FetchedColumns))
-	for i := range typs {
-		typs[i] = info.spec.FetchSpec.FetchedColumns[i].Type
-	}
-
 	// Note: we will set a merge ordering below.
 	p.AddNoInputStage(corePlacement, info.post, typs, execinfrapb.Ordering{}, info.finalizeLastStageCb)
 
@@ -2501,6 +2559,62 @@ func (dsp *DistSQLPlanner) planTableReaders(
 	return nil
 }
 
+// systemSampleSpans returns the parts of the given spans that fall within a
+// sample of the ranges they touch, as requested by TABLESAMPLE SYSTEM. Each
+// range is included with probability sample.Fraction, based on a hash of the
+// seed and the start key of the range, so that a REPEATABLE sample of a table
+// whose ranges have not changed always includes the same ranges.
+func (dsp *DistSQLPlanner) systemSampleSpans(
+	ctx context.Context, planCtx *PlanningCtx, spans roachpb.Spans, sample opt.TableSample,
+) (roachpb.Spans, error) {
+	it := planCtx.spanIter
+	if it == nil {
+		// This can only happen in tests.
+		return spans, nil
+	}
+	seed := sample.Seed
+	if !sample.Repeatable {
+		seed = randutil.FastInt63()
+	}
+	var sampled roachpb.Spans
+	for _, sp := range spans {
+		for it.Seek(ctx, sp, kvcoord.Ascending); ; it.Next(ctx) {
+			if !it.Valid() {
+				return nil, it.Error()
+			}
+			desc := it.Desc()
+			if includeRangeInSample(seed, desc.StartKey, sample.Fraction) {
+				part := sp
+				if start := desc.StartKey.AsRawKey(); part.Key.Compare(start) < 0 {
+					part.Key = start
+				}
+				if end := desc.EndKey.AsRawKey(); part.EndKey != nil && end.Compare(part.EndKey) < 0 {
+					part.EndKey = end
+				}
+				sampled = append(sampled, part)
+			}
+			if sp.EndKey == nil || !it.NeedAnother() {
+				break
+			}
+		}
+	}
+	return sampled, nil
+}
+
+// includeRangeInSample returns whether the range with the given start key is
+// part of a TABLESAMPLE SYSTEM sample with the given seed and fraction.
+func includeRangeInSample(seed int64, startKey roachpb.RKey, fraction float64) bool {
+	if fraction >= 1 {
+		return true
+	}
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(seed))
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write(startKey)
+	return float64(h.Sum64())/math.Exp2(64) < fraction
+}
+
 // createPlanForRender takes a PhysicalPlan and updates it to produce results
 // corresponding to the render node. An evaluator stage is added if the render
 // node has any expressions which are not just simple column references.
@@ -2607,11 +2721,22 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			aggregations[i].Func = execinfrapb.UserDefined
+			var err error
+			aggregations[i].UserDefined, err = makeUserDefinedAggSpec(
+				ctx, planCtx, fholder.funcName, fholder.userDefined,
+			)
+			if err != nil {
+				return err
+			}
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -2647,6 +2772,68 @@ func (dsp *DistSQLPlanner) addAggregators(
 	})
 }
 
+// userDefinedDistAggregationInfo describes how a user-defined aggregate with a
+// combine function is evaluated in two stages: the local stage accumulates
+// partial states, which are combined by the final stage. The specification of
+// the aggregate is adjusted for each stage by localUserDefinedAgg and
+// finalUserDefinedAgg.
+var userDefinedDistAggregationInfo = physicalplan.DistAggregationInfo{
+	LocalStage: []execinfrapb.AggregatorSpec_Func{execinfrapb.UserDefined},
+	FinalStage: []physicalplan.FinalStageInfo{
+		{Fn: execinfrapb.UserDefined, LocalIdxs: []uint32{0}},
+	},
+}
+
+// distAggregationInfo returns the information needed to evaluate the given
+// aggregation in multiple stages. It returns false if the aggregation cannot
+// be evaluated in multiple stages.
+func distAggregationInfo(
+	agg *execinfrapb.AggregatorSpec_Aggregation,
+) (physicalplan.DistAggregationInfo, bool) {
+	if agg.UserDefined != nil {
+		return userDefinedDistAggregationInfo, !agg.UserDefined.Combine.Empty()
+	}
+	info, ok := physicalplan.DistAggregationTable[agg.Func]
+	return info, ok
+}
+
+// localUserDefinedAgg returns the specification of the local stage of the
+// given user-defined aggregate, which outputs the state of the aggregate.
+func localUserDefinedAgg(
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+) *execinfrapb.AggregatorSpec_UserDefinedAggregate {
+	local := *spec
+	local.ResultType = spec.StateType
+	local.Final = execinfrapb.Expression{}
+	local.Combine = execinfrapb.Expression{}
+	return &local
+}
+
+// finalUserDefinedAgg returns the specification of the final stage of the
+// given user-defined aggregate, which combines the states output by the local
+// stage and computes the result from the combined state.
+func finalUserDefinedAgg(
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+) *execinfrapb.AggregatorSpec_UserDefinedAggregate {
+	final := *spec
+	final.Transition = spec.Combine
+	final.TransitionStrict = spec.CombineStrict
+	final.TupleArgs = false
+	final.Combine = execinfrapb.Expression{}
+	return &final
+}
+
+// aggregateOutputType returns the output type of the given aggregation when
+// applied on the given types.
+func aggregateOutputType(
+	agg *execinfrapb.AggregatorSpec_Aggregation, argTypes []*types.T,
+) (*types.T, error) {
+	if agg.UserDefined != nil {
+		return agg.UserDefined.ResultType, nil
+	}
+	return execagg.GetAggregateOutputType(agg.Func, argTypes)
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2847,7 +3034,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 				break
 			}
 			// Check that the function supports a local stage.
-			if _, ok := physicalplan.DistAggregationTable[e.Func]; !ok {
+			if _, ok := distAggregationInfo(&e); !ok {
 				multiStage = false
 				break
 			}
@@ -2887,7 +3074,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 		nFinalAgg := 0
 		needRender := false
 		for _, e := range info.aggregations {
-			info := physicalplan.DistAggregationTable[e.Func]
+			info, _ := distAggregationInfo(&e)
 			nLocalAgg += len(info.LocalStage)
 			nFinalAgg += len(info.FinalStage)
 			if info.FinalRendering != nil {
@@ -2922,7 +3109,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 		// to all final aggregations.
 		finalIdx := 0
 		for _, e := range info.aggregations {
-			info := physicalplan.DistAggregationTable[e.Func]
+			info, _ := distAggregationInfo(&e)
 
 			// relToAbsLocalIdx maps each local stage for the given
 			// aggregation e to its final index in localAggs.  This
@@ -2944,6 +3131,9 @@ func (dsp *DistSQLPlanner) planAggregators(
 					ColIdx:       e.ColIdx,
 					FilterColIdx: e.FilterColIdx,
 				}
+				if e.UserDefined != nil {
+					localAgg.UserDefined = localUserDefinedAgg(e.UserDefined)
+				}
 
 				isNewAgg := true
 				for j, prevLocalAgg := range localAggs {
@@ -2970,7 +3160,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 					for _, c := range e.ColIdx {
 						argTypes = append(argTypes, inputTypes[c])
 					}
-					outputType, err := execagg.GetAggregateOutputType(localFunc, argTypes)
+					outputType, err := aggregateOutputType(&localAgg, argTypes)
 					if err != nil {
 						return err
 					}
@@ -2994,6 +3184,9 @@ func (dsp *DistSQLPlanner) planAggregators(
 					Func:   finalInfo.Fn,
 					ColIdx: argIdxs,
 				}
+				if e.UserDefined != nil {
+					finalAgg.UserDefined = finalUserDefinedAgg(e.UserDefined)
+				}
 
 				isNewAgg := true
 				for i, prevFinalAgg := range finalAggs {
@@ -3021,7 +3214,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 							// types for the current aggregation e.
 							argTypes = append(argTypes, intermediateTypes[argIdxs[i]])
 						}
-						outputType, err := execagg.GetAggregateOutputType(finalInfo.Fn, argTypes)
+						outputType, err := aggregateOutputType(&finalAgg, argTypes)
 						if err != nil {
 							return err
 						}
@@ -3141,7 +3334,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 			var ef physicalplan.ExprFactory
 			ef.Init(ctx, planCtx, nil /* indexVarMap */)
 			for i, e := range info.aggregations {
-				info := physicalplan.DistAggregationTable[e.Func]
+				info, _ := distAggregationInfo(&e)
 				if info.FinalRendering == nil {
 					// mappedIdx corresponds to the index
 					// location of the result for this
@@ -3194,7 +3387,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 			argTypes = append(argTypes, inputTypes[c])
 		}
 		argTypes = append(argTypes, info.argumentsColumnTypes[i]...)
-		returnTyp, err := execagg.GetAggregateOutputType(agg.Func, argTypes)
+		returnTyp, err := aggregateOutputType(&agg, argTypes)
 		if err != nil {
 			return err
 		}
@@ -2321,7 +2321,7 @@ func (dsp *DistSQLPlanner) PlanAndRunPostQueries(
 		for ; triggersIdx < numTriggers; triggersIdx++ {
 			trigger := &plan.triggers[triggersIdx]
 			hasBuffer, numBufferedRows := checkPostQueryBuffer(plan.triggers[triggersIdx])
-			if hasBuffer && numBufferedRows == 0 {
+			if hasBuffer && numBufferedRows == 0 && !trigger.RunOnEmptyBuffer {
 				// No rows were actually modified.
 				continue
 			}
@@ -311,6 +311,7 @@ func (e *distSQLSpecExecFactory) ConstructScan(
 	*trSpec = execinfrapb.TableReaderSpec{
 		Reverse:                         params.Reverse,
 		TableDescriptorModificationTime: tabDesc.GetModificationTime(),
+		BernoulliSample:                 makeBernoulliSampleSpec(params.TableSample),
 	}
 	if err := rowenc.InitIndexFetchSpec(&trSpec.FetchSpec, e.planner.ExecCfg().Codec, tabDesc, idx, columnIDs); err != nil {
 		return nil, err
@@ -349,6 +350,7 @@ func (e *distSQLSpecExecFactory) ConstructScan(
 			parallelize:       params.Parallelize,
 			estimatedRowCount: params.EstimatedRowCount,
 			reqOrdering:       ReqOrdering(reqOrdering),
+			tableSample:       params.TableSample,
 		},
 	)
 
@@ -549,6 +551,7 @@ func populateAggFuncSpec(
 	ctx context.Context,
 	spec *execinfrapb.AggregatorSpec_Aggregation,
 	funcName string,
+	userDefined *exec.UserDefinedAggInfo,
 	distinct bool,
 	argCols []exec.NodeColumnOrdinal,
 	constArgs []tree.Datum,
@@ -556,11 +559,19 @@ func populateAggFuncSpec(
 	planCtx *PlanningCtx,
 	physPlan *PhysicalPlan,
 ) (argumentsColumnTypes []*types.T, err error) {
-	funcIdx, err := execinfrapb.GetAggregateFuncIdx(funcName)
-	if err != nil {
-		return nil, err
+	if userDefined != nil {
+		spec.Func = execinfrapb.UserDefined
+		spec.UserDefined, err = makeUserDefinedAggSpec(ctx, planCtx, funcName, userDefined)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		funcIdx, err := execinfrapb.GetAggregateFuncIdx(funcName)
+		if err != nil {
+			return nil, err
+		}
+		spec.Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 	}
-	spec.Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 	spec.Distinct = distinct
 	spec.ColIdx = make([]uint32, len(argCols))
 	for i, col := range argCols {
@@ -605,6 +616,11 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 		// rows.
 		aggRec = canDistribute
 	}
+	for j := range aggregations {
+		if info := aggregations[j].UserDefined; info != nil {
+			aggRec = aggRec.compose(e.checkExprsAndMaybeMergeLastStage(userDefinedAggExprs(info), physPlan))
+		}
+	}
 	planCtx := e.getPlanCtx(aggRec)
 	aggregationSpecs := make([]execinfrapb.AggregatorSpec_Aggregation, len(groupCols)+len(aggregations))
 	argumentsColumnTypes := make([][]*types.T, len(groupCols)+len(aggregations))
@@ -616,7 +632,7 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 			spec := &aggregationSpecs[i]
 			argColsScratch[0] = col
 			_, err = populateAggFuncSpec(
-				e.ctx, spec, builtins.AnyNotNull, false /* distinct*/, argColsScratch,
+				e.ctx, spec, builtins.AnyNotNull, nil /* userDefined */, false /* distinct*/, argColsScratch,
 				nil /* constArgs */, noFilter, planCtx, physPlan,
 			)
 			if err != nil {
@@ -629,7 +645,7 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 		spec := &aggregationSpecs[i]
 		agg := &aggregations[j]
 		argumentsColumnTypes[i], err = populateAggFuncSpec(
-			e.ctx, spec, agg.FuncName, agg.Distinct, agg.ArgCols,
+			e.ctx, spec, agg.FuncName, agg.UserDefined, agg.Distinct, agg.ArgCols,
 			agg.ConstArgs, agg.Filter, planCtx, physPlan,
 		)
 		if err != nil {
@@ -1246,16 +1262,24 @@ func (e *distSQLSpecExecFactory) ConstructWindow(
 			outputColIdx: window.OutputIdxs[windowFnSpecIdx],
 			frame:        window.Exprs[windowFnSpecIdx].WindowDef.Frame,
 		}
+		if window.UserDefined != nil {
+			planInfo.funcs[windowFnSpecIdx].userDefined = window.UserDefined[windowFnSpecIdx]
+		}
 	}
 
 	recommendation := canDistribute
+	for _, info := range window.UserDefined {
+		if info != nil && e.checkExprsAndMaybeMergeLastStage(userDefinedAggExprs(info), physPlan) == cannotDistribute {
+			recommendation = cannotDistribute
+		}
+	}
 	if len(partitionIdxs) > 0 {
 		// If the window has a PARTITION BY clause, then we should distribute the
 		// execution.
 		// TODO(yuzefovich): we might want to be smarter about this and don't force
 		// distribution with small inputs.
 		log.VEventf(e.ctx, 2, "window with PARTITION BY recommends plan distribution")
-		recommendation = shouldDistribute
+		recommendation = recommendation.compose(shouldDistribute)
 	}
 	planCtx := e.getPlanCtx(recommendation)
 	if err := e.dsp.planWindow(e.ctx, planCtx, &planInfo, physPlan); err != nil {
@@ -1427,6 +1451,23 @@ func (e *distSQLSpecExecFactory) ConstructUpsert(
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: upsert")
 }
 
+func (e *distSQLSpecExecFactory) ConstructMerge(
+	input exec.Node,
+	table cat.Table,
+	canaryCol exec.NodeColumnOrdinal,
+	deleteCol exec.NodeColumnOrdinal,
+	insertCols exec.TableColumnOrdinalSet,
+	fetchCols exec.TableColumnOrdinalSet,
+	updateCols exec.TableColumnOrdinalSet,
+	returnCols exec.TableColumnOrdinalSet,
+	checks exec.CheckOrdinalSet,
+	uniqueWithTombstoneIndexes cat.IndexOrdinals,
+	lockedIndexes cat.IndexOrdinals,
+	autoCommit bool,
+) (exec.Node, error) {
+	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: merge")
+}
+
 func (e *distSQLSpecExecFactory) ConstructDelete(
 	input exec.Node,
 	table cat.Table,
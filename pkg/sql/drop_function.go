@@ -73,6 +73,20 @@ func (p *planner) DropFunction(ctx context.Context, n *tree.DropRoutine) (ret pl
 		if err != nil {
 			return nil, err
 		}
+		if err := checkRoutineAggregateKind(mut, n.Aggregate, "DROP"); err != nil {
+			return nil, err
+		}
+		db, err := p.Descriptors().ByIDWithLeased(p.txn).Get().Database(ctx, mut.GetParentID())
+		if err != nil {
+			return nil, err
+		}
+		if trigName, ok := eventTriggerUsingFunction(db, fnID); ok {
+			return nil, pgerror.Newf(
+				pgcode.DependentObjectsStillExist,
+				"cannot drop function %q because event trigger %q depends on it",
+				mut.Name, trigName,
+			)
+		}
 		if n.DropBehavior != tree.DropCascade && len(mut.DependedOnBy) > 0 {
 			dependedOnByIDs := make([]descpb.ID, 0, len(mut.DependedOnBy))
 			for _, ref := range mut.DependedOnBy {
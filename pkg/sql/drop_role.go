@@ -14,6 +14,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -147,6 +148,18 @@ func (n *DropRoleNode) startExec(params runParams) error {
 						ObjectName: db.GetName(),
 					})
 			}
+			if err := db.ForEachPublication(func(name string, pub descpb.DatabaseDescriptor_PublicationInfo) error {
+				owner := pub.OwnerProto.Decode()
+				if _, ok := userNames[owner]; ok {
+					userNames[owner] = append(userNames[owner], objectAndType{
+						ObjectType: "publication",
+						ObjectName: tree.NameString(db.GetName()) + "." + tree.NameString(name),
+					})
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
 			for _, u := range db.GetPrivileges().Users {
 				if _, ok := userNames[u.User()]; ok {
 					if privilegeObjectFormatter.Len() > 0 {
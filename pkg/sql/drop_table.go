@@ -356,6 +356,10 @@ func (p *planner) dropTableImpl(
 		return droppedViews, err
 	}
 
+	if err := p.removeTableFromPublications(ctx, tableDesc); err != nil {
+		return droppedViews, err
+	}
+
 	err = p.initiateDropTable(ctx, tableDesc, !droppingParent, jobDesc)
 	return droppedViews, err
 }
@@ -17,8 +17,7 @@ import (
 type errorIfRowsNode struct {
 	singleInputPlanNode
 
-	// mkErr creates the error message, given the values of the first row
-	// produced.
+	// mkErr creates the error message, given the values of a row produced.
 	mkErr exec.MkErrFn
 
 	nexted bool
@@ -34,14 +33,17 @@ func (n *errorIfRowsNode) Next(params runParams) (bool, error) {
 	}
 	n.nexted = true
 
-	ok, err := n.input.Next(params)
-	if err != nil {
-		return false, err
+	// If the violations of the constraint are deferred, each of the rows is
+	// recorded so that only their keys are validated later.
+	for {
+		ok, err := n.input.Next(params)
+		if err != nil || !ok {
+			return false, err
+		}
+		if err := params.p.maybeDeferConstraintCheck(n.mkErr(n.input.Values())); err != nil {
+			return false, err
+		}
 	}
-	if ok {
-		return false, n.mkErr(n.input.Values())
-	}
-	return false, nil
 }
 
 func (n *errorIfRowsNode) Values() tree.Datums {
@@ -0,0 +1,585 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+type createEventTriggerNode struct {
+	zeroInputPlanNode
+	n    *tree.CreateEventTrigger
+	desc *dbdesc.Mutable
+}
+
+// CreateEventTrigger creates an event trigger in the current database. Event
+// triggers are stored in the database descriptor.
+func (p *planner) CreateEventTrigger(
+	ctx context.Context, n *tree.CreateEventTrigger,
+) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V25_2_EventTriggers) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE EVENT TRIGGER unsupported in mixed-version cluster")
+	}
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), "CREATE EVENT TRIGGER"); err != nil {
+		return nil, err
+	}
+	desc, err := p.mutableCurrentDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &createEventTriggerNode{n: n, desc: desc}, nil
+}
+
+func (n *createEventTriggerNode) startExec(params runParams) error {
+	p := params.p
+	ctx := params.ctx
+	name := string(n.n.Name)
+
+	if err := p.checkCanManageEventTriggers(ctx, "create"); err != nil {
+		return err
+	}
+	event := string(n.n.Event)
+	if !tree.IsValidEventTriggerEvent(event) {
+		return pgerror.Newf(pgcode.Syntax, "unrecognized event name %q", event)
+	}
+	tags := make([]string, 0, len(n.n.Tags))
+	for _, tag := range n.n.Tags {
+		if err := tree.CheckEventTriggerTag(tag); err != nil {
+			return err
+		}
+		tags = append(tags, strings.ToUpper(tag))
+	}
+	if _, ok := n.desc.GetEventTrigger(name); ok {
+		return pgerror.Newf(pgcode.DuplicateObject, "event trigger %q already exists", name)
+	}
+
+	fnName, err := n.n.FuncName.ToRoutineName()
+	if err != nil {
+		return err
+	}
+	ol, err := p.matchRoutine(ctx, &tree.RoutineObj{
+		FuncName: fnName,
+		Params:   tree.RoutineParams{},
+	}, true /* required */, tree.UDFRoutine, false /* inDropContext */)
+	if err != nil {
+		return err
+	}
+	if ol.Type == tree.BuiltinRoutine || ol.FixedReturnType().Oid() != oid.T_event_trigger {
+		return pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type event_trigger", n.n.FuncName)
+	}
+
+	n.desc.AddEventTrigger(name, descpb.DatabaseDescriptor_EventTriggerInfo{
+		Event:      event,
+		Tags:       tags,
+		FuncID:     funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+		OwnerProto: p.User().EncodeProto(),
+	})
+	if err := p.writeNonDropDatabaseChange(
+		ctx, n.desc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("event trigger"))
+	return nil
+}
+
+func (n *createEventTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createEventTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createEventTriggerNode) Close(context.Context)        {}
+
+type dropEventTriggerNode struct {
+	zeroInputPlanNode
+	n    *tree.DropEventTrigger
+	desc *dbdesc.Mutable
+}
+
+// DropEventTrigger drops an event trigger from the current database.
+func (p *planner) DropEventTrigger(
+	ctx context.Context, n *tree.DropEventTrigger,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), "DROP EVENT TRIGGER"); err != nil {
+		return nil, err
+	}
+	desc, err := p.mutableCurrentDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dropEventTriggerNode{n: n, desc: desc}, nil
+}
+
+func (n *dropEventTriggerNode) startExec(params runParams) error {
+	p := params.p
+	ctx := params.ctx
+	name := string(n.n.Name)
+
+	if _, ok := n.desc.GetEventTrigger(name); !ok {
+		if n.n.IfExists {
+			return nil
+		}
+		return pgerror.Newf(pgcode.UndefinedObject, "event trigger %q does not exist", name)
+	}
+	if err := p.checkCanManageEventTriggers(ctx, "drop"); err != nil {
+		return err
+	}
+	n.desc.RemoveEventTrigger(name)
+	if err := p.writeNonDropDatabaseChange(
+		ctx, n.desc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("event trigger"))
+	return nil
+}
+
+func (n *dropEventTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropEventTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropEventTriggerNode) Close(context.Context)        {}
+
+type alterEventTriggerNode struct {
+	zeroInputPlanNode
+	n    *tree.AlterEventTrigger
+	desc *dbdesc.Mutable
+}
+
+// AlterEventTrigger enables, disables, renames or changes the owner of an
+// event trigger in the current database.
+func (p *planner) AlterEventTrigger(
+	ctx context.Context, n *tree.AlterEventTrigger,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), "ALTER EVENT TRIGGER"); err != nil {
+		return nil, err
+	}
+	desc, err := p.mutableCurrentDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &alterEventTriggerNode{n: n, desc: desc}, nil
+}
+
+func (n *alterEventTriggerNode) startExec(params runParams) error {
+	p := params.p
+	ctx := params.ctx
+	name := string(n.n.Name)
+
+	trig, ok := n.desc.GetEventTrigger(name)
+	if !ok {
+		return pgerror.Newf(pgcode.UndefinedObject, "event trigger %q does not exist", name)
+	}
+	if err := p.checkCanManageEventTriggers(ctx, "alter"); err != nil {
+		return err
+	}
+	switch n.n.Cmd {
+	case tree.AlterEventTriggerEnable:
+		trig.Disabled = false
+	case tree.AlterEventTriggerDisable:
+		trig.Disabled = true
+	case tree.AlterEventTriggerRename:
+		newName := string(n.n.NewName)
+		if _, ok := n.desc.GetEventTrigger(newName); ok {
+			return pgerror.Newf(pgcode.DuplicateObject, "event trigger %q already exists", newName)
+		}
+		n.desc.RemoveEventTrigger(name)
+		name = newName
+	case tree.AlterEventTriggerOwner:
+		newOwner, err := decodeusername.FromRoleSpec(
+			p.SessionData(), username.PurposeValidation, n.n.Owner,
+		)
+		if err != nil {
+			return err
+		}
+		if err := p.CheckRoleExists(ctx, newOwner); err != nil {
+			return err
+		}
+		// As in PostgreSQL, the owner of an event trigger must be a superuser.
+		isAdmin, err := p.UserHasAdminRole(ctx, newOwner)
+		if err != nil {
+			return err
+		}
+		if !isAdmin {
+			return pgerror.Newf(pgcode.InsufficientPrivilege,
+				"permission denied to change owner of event trigger %q", name)
+		}
+		trig.OwnerProto = newOwner.EncodeProto()
+	}
+	n.desc.AddEventTrigger(name, trig)
+	if err := p.writeNonDropDatabaseChange(
+		ctx, n.desc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounter("event trigger"))
+	return nil
+}
+
+func (n *alterEventTriggerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *alterEventTriggerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterEventTriggerNode) Close(context.Context)        {}
+
+// checkCanManageEventTriggers returns an error if the current user cannot
+// create, alter or drop event triggers. As in PostgreSQL, where only
+// superusers can manage event triggers, this requires the admin role.
+func (p *planner) checkCanManageEventTriggers(ctx context.Context, action string) error {
+	isAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"permission denied to %s event trigger", action)
+	}
+	return nil
+}
+
+// eventTriggerUsingFunction returns the name of an event trigger of the
+// database which invokes the given function, if there is one.
+func eventTriggerUsingFunction(db catalog.DatabaseDescriptor, fnID descpb.ID) (string, bool) {
+	var found string
+	_ = db.ForEachEventTrigger(func(name string, trig descpb.DatabaseDescriptor_EventTriggerInfo) error {
+		if trig.FuncID == fnID && found == "" {
+			found = name
+		}
+		return nil
+	})
+	return found, found != ""
+}
+
+// eventTriggerState is the state of a DDL statement which fires event
+// triggers. It is used by pg_event_trigger_ddl_commands() and
+// pg_event_trigger_dropped_objects() to determine the objects that the
+// statement created, altered and dropped.
+type eventTriggerState struct {
+	// tag is the command tag of the statement.
+	tag string
+	// before maps the IDs of the descriptors modified in the transaction
+	// before the statement to their versions at that point.
+	before map[descpb.ID]catalog.Descriptor
+}
+
+// newEventTriggerState captures the descriptors modified in the transaction
+// before the execution of the statement with the given tag.
+func newEventTriggerState(tc *descs.Collection, tag string) *eventTriggerState {
+	s := &eventTriggerState{tag: tag, before: make(map[descpb.ID]catalog.Descriptor)}
+	for _, desc := range tc.GetUncommittedDescriptors() {
+		s.before[desc.GetID()] = desc
+	}
+	return s
+}
+
+// forEachChange calls fn for each descriptor modified by the statement, in
+// ascending order of IDs. before is the version of the descriptor before the
+// statement, which is nil if the statement created the descriptor.
+//
+// Each write of a descriptor to the collection creates a new uncommitted
+// version, so a descriptor has been modified by the statement if its current
+// uncommitted version is not the one captured by newEventTriggerState.
+func (s *eventTriggerState) forEachChange(
+	tc *descs.Collection, fn func(before, after catalog.Descriptor) error,
+) error {
+	for _, desc := range tc.GetUncommittedDescriptors() {
+		before, ok := s.before[desc.GetID()]
+		if ok && before == desc {
+			continue
+		}
+		if !ok {
+			before = tc.GetOriginalDescriptor(desc.GetID())
+		}
+		if err := fn(before, desc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkEventTriggerState returns the event trigger state of the current
+// statement, or an error if the statement does not fire event triggers.
+func (p *planner) checkEventTriggerState(fnName string) (*eventTriggerState, error) {
+	if p.eventTriggers == nil {
+		return nil, pgerror.Newf(pgcode.ExternalRoutineTriggerProtocolViolated,
+			"%s can only be called in an event trigger function", fnName)
+	}
+	return p.eventTriggers, nil
+}
+
+// EventTriggerDDLCommands is part of the eval.Planner interface.
+//
+// The commands are derived from the descriptors modified by the statement:
+// there is one row for each object of the kind targeted by the command which
+// was created or, if the command creates no such object, altered. Before the
+// command is executed, that is in ddl_command_start triggers, there are no
+// rows. As in Postgres, commands which drop objects are not reported.
+func (p *planner) EventTriggerDDLCommands(ctx context.Context) ([]tree.Datums, error) {
+	s, err := p.checkEventTriggerState("pg_event_trigger_ddl_commands()")
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(s.tag, "DROP ") {
+		return nil, nil
+	}
+	targetType := eventTriggerTargetDescriptorType(s.tag)
+	var created, altered []catalog.Descriptor
+	if err := s.forEachChange(p.Descriptors(), func(before, desc catalog.Descriptor) error {
+		if desc.Dropped() || desc.DescriptorType() != targetType || isImplicitType(desc) {
+			return nil
+		}
+		if before == nil {
+			created = append(created, desc)
+		} else {
+			altered = append(altered, desc)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	// Objects which are altered as a side effect of creating another object,
+	// for example a table referenced by a new view, are not reported.
+	changed := altered
+	if strings.HasPrefix(s.tag, "CREATE ") && len(created) > 0 {
+		changed = created
+	}
+	var rows []tree.Datums
+	for _, desc := range changed {
+		obj, err := p.describeEventTriggerObject(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, tree.Datums{
+			obj.classID,                     // classid
+			obj.objID,                       // objid
+			tree.DZero,                      // objsubid
+			tree.NewDString(s.tag),          // command_tag
+			tree.NewDString(obj.objType),    // object_type
+			obj.schemaName,                  // schema_name
+			tree.NewDString(obj.identity()), // object_identity
+			tree.DBoolFalse,                 // in_extension
+		})
+	}
+	return rows, nil
+}
+
+// EventTriggerDroppedObjects is part of the eval.Planner interface.
+//
+// The dropped objects are the descriptors dropped by the statement, and the
+// indexes removed from tables that the statement altered.
+func (p *planner) EventTriggerDroppedObjects(ctx context.Context) ([]tree.Datums, error) {
+	s, err := p.checkEventTriggerState("pg_event_trigger_dropped_objects()")
+	if err != nil {
+		return nil, err
+	}
+	var rows []tree.Datums
+	addRow := func(obj eventTriggerObject, isTemp bool) error {
+		addressNames := tree.NewDArray(types.String)
+		if obj.schemaName != tree.DNull {
+			if err := addressNames.Append(obj.schemaName); err != nil {
+				return err
+			}
+		}
+		if err := addressNames.Append(tree.NewDString(obj.name)); err != nil {
+			return err
+		}
+		addressArgs := tree.NewDArray(types.String)
+		for _, arg := range obj.args {
+			if err := addressArgs.Append(tree.NewDString(arg)); err != nil {
+				return err
+			}
+		}
+		objName := tree.DNull
+		if obj.args == nil {
+			// As in Postgres, object_name is NULL for routines, since their names
+			// do not identify them.
+			objName = tree.NewDString(obj.name)
+		}
+		rows = append(rows, tree.Datums{
+			obj.classID,                        // classid
+			obj.objID,                          // objid
+			tree.DZero,                         // objsubid
+			tree.DBoolTrue,                     // original
+			tree.DBoolFalse,                    // normal
+			tree.MakeDBool(tree.DBool(isTemp)), // is_temporary
+			tree.NewDString(obj.objType),       // object_type
+			obj.schemaName,                     // schema_name
+			objName,                            // object_name
+			tree.NewDString(obj.identity()),    // object_identity
+			addressNames,                       // address_names
+			addressArgs,                        // address_args
+		})
+		return nil
+	}
+	if err := s.forEachChange(p.Descriptors(), func(before, desc catalog.Descriptor) error {
+		if desc.Dropped() {
+			if (before != nil && before.Dropped()) || isImplicitType(desc) {
+				return nil
+			}
+			obj, err := p.describeEventTriggerObject(ctx, desc)
+			if err != nil {
+				return err
+			}
+			isTemp := false
+			if tbl, ok := desc.(catalog.TableDescriptor); ok {
+				isTemp = tbl.IsTemporary()
+			}
+			return addRow(obj, isTemp)
+		}
+		// Report the indexes removed from altered tables.
+		tbl, ok := desc.(catalog.TableDescriptor)
+		if !ok || before == nil {
+			return nil
+		}
+		for _, idx := range before.(catalog.TableDescriptor).PublicNonPrimaryIndexes() {
+			stillPublic := catalog.FindPublicNonPrimaryIndex(tbl, func(i catalog.Index) bool {
+				return i.GetID() == idx.GetID()
+			}) != nil
+			if stillPublic {
+				continue
+			}
+			obj, err := p.describeEventTriggerObject(ctx, desc)
+			if err != nil {
+				return err
+			}
+			h := makeOidHasher()
+			obj.objID = h.IndexOid(tbl.GetID(), idx.GetID())
+			obj.objType = "index"
+			obj.name = idx.GetName()
+			if err := addRow(obj, tbl.IsTemporary()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// eventTriggerObject describes an object reported by
+// pg_event_trigger_ddl_commands() or pg_event_trigger_dropped_objects().
+type eventTriggerObject struct {
+	classID    *tree.DOid
+	objID      *tree.DOid
+	objType    string
+	schemaName tree.Datum
+	name       string
+	// args are the argument types of a routine, and nil for other objects.
+	args []string
+}
+
+// identity returns the object_identity of the object, as in Postgres.
+func (o eventTriggerObject) identity() string {
+	var buf strings.Builder
+	if o.schemaName != tree.DNull {
+		buf.WriteString(tree.NameString(string(tree.MustBeDString(o.schemaName))))
+		buf.WriteByte('.')
+	}
+	buf.WriteString(tree.NameString(o.name))
+	if o.args != nil {
+		buf.WriteByte('(')
+		buf.WriteString(strings.Join(o.args, ","))
+		buf.WriteByte(')')
+	}
+	return buf.String()
+}
+
+// describeEventTriggerObject returns the description of the object of the
+// given descriptor.
+func (p *planner) describeEventTriggerObject(
+	ctx context.Context, desc catalog.Descriptor,
+) (eventTriggerObject, error) {
+	obj := eventTriggerObject{schemaName: tree.DNull, name: desc.GetName()}
+	switch d := desc.(type) {
+	case catalog.TableDescriptor:
+		obj.classID = tree.NewDOid(catconstants.PgCatalogClassTableID)
+		obj.objID = tableOid(d.GetID())
+		switch {
+		case d.IsSequence():
+			obj.objType = "sequence"
+		case d.MaterializedView():
+			obj.objType = "materialized view"
+		case d.IsView():
+			obj.objType = "view"
+		default:
+			obj.objType = "table"
+		}
+	case catalog.SchemaDescriptor:
+		obj.classID = tree.NewDOid(catconstants.PgCatalogNamespaceTableID)
+		obj.objID = schemaOid(d.GetID())
+		obj.objType = "schema"
+		return obj, nil
+	case catalog.TypeDescriptor:
+		obj.classID = tree.NewDOid(catconstants.PgCatalogTypeTableID)
+		obj.objID = tree.NewDOid(catid.TypeIDToOID(d.GetID()))
+		obj.objType = "type"
+	case catalog.FunctionDescriptor:
+		obj.classID = tree.NewDOid(catconstants.PgCatalogProcTableID)
+		obj.objID = tree.NewDOid(catid.FuncIDToOID(d.GetID()))
+		obj.objType = "function"
+		if d.IsProcedure() {
+			obj.objType = "procedure"
+		}
+		obj.args = []string{}
+		for _, param := range d.GetParams() {
+			if param.Class != catpb.Function_Param_OUT {
+				obj.args = append(obj.args, param.Type.SQLStandardName())
+			}
+		}
+	default:
+		return eventTriggerObject{}, errors.AssertionFailedf(
+			"unexpected %s descriptor %q", desc.DescriptorType(), desc.GetName())
+	}
+	sc, err := p.Descriptors().ByIDWithoutLeased(p.txn).Get().Schema(ctx, desc.GetParentSchemaID())
+	if err != nil {
+		return eventTriggerObject{}, err
+	}
+	obj.schemaName = tree.NewDString(sc.GetName())
+	return obj, nil
+}
+
+// eventTriggerTargetDescriptorType returns the type of the descriptors of the
+// objects targeted by DDL commands with the given tag. Commands on indexes,
+// triggers, policies and other objects which are part of a table target the
+// table.
+func eventTriggerTargetDescriptorType(tag string) catalog.DescriptorType {
+	switch {
+	case strings.HasSuffix(tag, " SCHEMA"):
+		return catalog.Schema
+	case strings.HasSuffix(tag, " TYPE"), strings.HasSuffix(tag, " DOMAIN"):
+		return catalog.Type
+	case strings.HasSuffix(tag, " FUNCTION"), strings.HasSuffix(tag, " PROCEDURE"),
+		strings.HasSuffix(tag, " ROUTINE"), strings.HasSuffix(tag, " AGGREGATE"):
+		return catalog.Function
+	default:
+		return catalog.Table
+	}
+}
+
+// isImplicitType returns true if the given descriptor is a type descriptor
+// which is created and dropped along with another object, such as the array
+// type of a user-defined type.
+func isImplicitType(desc catalog.Descriptor) bool {
+	typ, ok := desc.(catalog.TypeDescriptor)
+	return ok && typ.GetKind() == descpb.TypeDescriptor_ARRAY
+}
@@ -75,6 +75,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -1443,6 +1444,10 @@ type ExecutorConfig struct {
 
 	RangeFeedFactory *rangefeed.Factory
 
+	// NotificationRegistry delivers the notifications sent by NOTIFY to the
+	// sessions which LISTEN on this node.
+	NotificationRegistry *pgnotify.Registry
+
 	// VersionUpgradeHook is called after validating a `SET CLUSTER SETTING
 	// version` but before executing it. It can carry out arbitrary upgrades
 	// that allow us to eventually remove legacy code.
@@ -2198,9 +2203,9 @@ func checkResultType(typ *types.T, fmtCode pgwirebase.FormatCode) error {
 		// Placeholder case.
 		return errors.Errorf("could not determine data type of %s", typ)
 	case types.TriggerFamily:
-		// The TRIGGER datatype is only allowed as the return type of a trigger
-		// function.
-		return tree.CannotAcceptTriggerErr
+		// The TRIGGER and EVENT_TRIGGER datatypes are only allowed as the return
+		// type of a trigger function.
+		return tree.CheckTriggerType(typ)
 	default:
 		return errors.Errorf("unsupported result type: %s", typ)
 	}
@@ -108,6 +108,12 @@ func GetAggregateConstructor(
 		paramTypes[len(aggInfo.ColIdx)+j] = d.ResolvedType()
 		arguments[j] = d
 	}
+	if aggInfo.UserDefined != nil {
+		constructor, outputType, err = getUserDefinedAggregateInfo(
+			ctx, evalCtx, semaCtx, aggInfo.UserDefined, paramTypes,
+		)
+		return
+	}
 	constructor, outputType, err = getAggregateInfo(aggInfo.Func, paramTypes)
 	return
 }
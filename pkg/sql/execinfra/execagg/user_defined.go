@@ -0,0 +1,300 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package execagg
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/errors"
+)
+
+// The indexes of the expressions of a user-defined aggregate in
+// userDefinedAggregateExprs.
+const (
+	udaInitialStateIdx = iota
+	udaTransitionIdx
+	udaFinalIdx
+	udaNumExprs
+)
+
+// userDefinedAggregateExprs holds the prepared expressions of a user-defined
+// aggregate, which are shared by all instances of the aggregate created by the
+// same constructor.
+type userDefinedAggregateExprs struct {
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate
+	// types are the types of the state and arguments that the expressions
+	// refer to.
+	types []*types.T
+	exprs [udaNumExprs]tree.TypedExpr
+}
+
+// getUserDefinedAggregateInfo returns the aggregate constructor and the return
+// type for the given user-defined aggregate when applied on the given types.
+func getUserDefinedAggregateInfo(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+	paramTypes []*types.T,
+) (AggregateConstructor, *types.T, error) {
+	if len(paramTypes) != 1 {
+		return nil, nil, errors.AssertionFailedf(
+			"user-defined aggregate %s needs 1 input, found %d", spec.Name, len(paramTypes),
+		)
+	}
+	argTypes := paramTypes
+	if spec.TupleArgs {
+		if paramTypes[0].Family() != types.TupleFamily {
+			return nil, nil, errors.AssertionFailedf(
+				"expected tuple input for user-defined aggregate %s, found %s", spec.Name, paramTypes[0],
+			)
+		}
+		argTypes = paramTypes[0].TupleContents()
+	}
+	e := &userDefinedAggregateExprs{
+		spec:  spec,
+		types: make([]*types.T, 0, len(argTypes)+1),
+	}
+	e.types = append(e.types, spec.StateType)
+	e.types = append(e.types, argTypes...)
+
+	var h execinfrapb.MultiExprHelper
+	if err := h.Init(ctx, udaNumExprs, e.types, semaCtx, evalCtx); err != nil {
+		return nil, nil, err
+	}
+	for i, expr := range [udaNumExprs]execinfrapb.Expression{
+		udaInitialStateIdx: spec.InitialState,
+		udaTransitionIdx:   spec.Transition,
+		udaFinalIdx:        spec.Final,
+	} {
+		if err := h.AddExpr(ctx, expr, i); err != nil {
+			return nil, nil, errors.Wrapf(err, "%s", expr)
+		}
+		e.exprs[i] = h.Expr(i)
+	}
+	if e.exprs[udaInitialStateIdx] == nil || e.exprs[udaTransitionIdx] == nil {
+		return nil, nil, errors.AssertionFailedf(
+			"missing initial state or transition of user-defined aggregate %s", spec.Name,
+		)
+	}
+	constructAgg := func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return newUserDefinedAggregate(evalCtx, e)
+	}
+	return constructAgg, spec.ResultType, nil
+}
+
+// GetUserDefinedWindowFunctionInfo returns the windowFunc constructor and the
+// return type for the given user-defined aggregate when used as a window
+// function on the given input types. The aggregate is computed over the window
+// frame by calling its transition for every row in the frame.
+func GetUserDefinedWindowFunctionInfo(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+	inputTypes ...*types.T,
+) (windowConstructor func(*eval.Context) eval.WindowFunc, returnType *types.T, err error) {
+	constructAgg, returnType, err := getUserDefinedAggregateInfo(ctx, evalCtx, semaCtx, spec, inputTypes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return builtins.NewFramableAggregateWindowFunc(constructAgg), returnType, nil
+}
+
+// userDefinedAggregate computes a user-defined aggregate by evaluating its
+// transition for every input row, and its final expression on the resulting
+// state.
+type userDefinedAggregate struct {
+	evalCtx *eval.Context
+	exprs   *userDefinedAggregateExprs
+
+	// row holds the state and arguments while evaluating the expressions of
+	// the aggregate.
+	row tree.Datums
+	// state is the current state of the aggregate. It is only valid once
+	// initialized is true.
+	state       tree.Datum
+	initialized bool
+	// started is false while the state is NULL because the initial state of
+	// an aggregate with a strict transition is NULL. The first argument of the
+	// first row with no NULL arguments then becomes the state.
+	started bool
+
+	acc *mon.BoundAccount
+	// ownAcc is true if acc was created by this aggregate, which is then
+	// responsible for closing it. Otherwise, acc is shared with other
+	// aggregates.
+	ownAcc bool
+	// accountedFor indicates how much memory (in bytes) have been registered
+	// with acc.
+	accountedFor int64
+}
+
+var _ eval.AggregateFunc = &userDefinedAggregate{}
+var _ eval.IndexedVarContainer = &userDefinedAggregate{}
+
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
+
+func newUserDefinedAggregate(
+	evalCtx *eval.Context, exprs *userDefinedAggregateExprs,
+) *userDefinedAggregate {
+	a := &userDefinedAggregate{
+		evalCtx: evalCtx,
+		exprs:   exprs,
+		row:     make(tree.Datums, len(exprs.types)),
+		acc:     evalCtx.SingleDatumAggMemAccount,
+	}
+	if a.acc == nil {
+		acc := evalCtx.Planner.Mon().MakeBoundAccount()
+		a.acc, a.ownAcc = &acc, true
+	}
+	return a
+}
+
+// IndexedVarResolvedType is part of the tree.IndexedVarContainer interface.
+func (a *userDefinedAggregate) IndexedVarResolvedType(idx int) *types.T {
+	return a.exprs.types[idx]
+}
+
+// IndexedVarEval is part of the eval.IndexedVarContainer interface.
+func (a *userDefinedAggregate) IndexedVarEval(idx int) (tree.Datum, error) {
+	return a.row[idx], nil
+}
+
+// eval evaluates the i-th expression of the aggregate with the current
+// contents of row.
+func (a *userDefinedAggregate) eval(ctx context.Context, i int) (tree.Datum, error) {
+	a.evalCtx.PushIVarContainer(a)
+	defer a.evalCtx.PopIVarContainer()
+	return eval.Expr(ctx, a.evalCtx, a.exprs.exprs[i])
+}
+
+// init evaluates the initial state of the aggregate if that has not happened
+// yet.
+func (a *userDefinedAggregate) init(ctx context.Context) error {
+	if a.initialized {
+		return nil
+	}
+	state, err := a.eval(ctx, udaInitialStateIdx)
+	if err != nil {
+		return err
+	}
+	a.initialized = true
+	a.started = state != tree.DNull || !a.exprs.spec.TransitionStrict
+	return a.setState(ctx, state)
+}
+
+// setState sets the state of the aggregate and updates the memory account to
+// reflect its size.
+func (a *userDefinedAggregate) setState(ctx context.Context, state tree.Datum) error {
+	newUsage := int64(state.Size())
+	if err := a.acc.Grow(ctx, newUsage-a.accountedFor); err != nil {
+		return err
+	}
+	a.accountedFor = newUsage
+	a.state = state
+	return nil
+}
+
+// Add is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Add(ctx context.Context, datum tree.Datum, _ ...tree.Datum) error {
+	if err := a.init(ctx); err != nil {
+		return err
+	}
+	args := a.row[1:]
+	if a.exprs.spec.TupleArgs {
+		t, ok := tree.AsDTuple(datum)
+		if !ok || len(t.D) != len(args) {
+			return errors.AssertionFailedf(
+				"expected %d arguments for user-defined aggregate %s", len(args), a.exprs.spec.Name,
+			)
+		}
+		copy(args, t.D)
+	} else {
+		args[0] = datum
+	}
+	if a.exprs.spec.TransitionStrict {
+		for _, arg := range args {
+			if arg == tree.DNull {
+				return nil
+			}
+		}
+		if !a.started {
+			// The first argument of the first row becomes the state. The
+			// aggregate was created with a check that this is allowed.
+			if len(args) == 0 {
+				return errors.AssertionFailedf(
+					"user-defined aggregate %s has no argument to use as its state", a.exprs.spec.Name,
+				)
+			}
+			a.started = true
+			return a.setState(ctx, args[0])
+		}
+		if a.state == tree.DNull {
+			// A strict transition is not called with a NULL state, which
+			// remains NULL.
+			return nil
+		}
+	}
+	a.row[0] = a.state
+	state, err := a.eval(ctx, udaTransitionIdx)
+	if err != nil {
+		return err
+	}
+	return a.setState(ctx, state)
+}
+
+// Result is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	// Result is not passed a context, so use the background context like
+	// other aggregates that need one here.
+	ctx := context.Background()
+	if err := a.init(ctx); err != nil {
+		return nil, err
+	}
+	if a.exprs.exprs[udaFinalIdx] == nil {
+		return a.state, nil
+	}
+	// The final expression does not modify the state, so the aggregate can
+	// continue to accumulate rows afterwards.
+	a.row[0] = a.state
+	return a.eval(ctx, udaFinalIdx)
+}
+
+// Reset is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.state = nil
+	a.initialized = false
+	a.started = false
+	if a.ownAcc {
+		a.acc.Clear(ctx)
+	} else {
+		a.acc.Shrink(ctx, a.accountedFor)
+	}
+	a.accountedFor = 0
+}
+
+// Close is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(ctx context.Context) {
+	if a.ownAcc {
+		a.acc.Close(ctx)
+	} else {
+		a.acc.Shrink(ctx, a.accountedFor)
+	}
+	a.accountedFor = 0
+}
+
+// Size is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
@@ -142,7 +142,11 @@ func (a *AggregatorSpec) summary() (string, []string) {
 	}
 	for _, agg := range a.Aggregations {
 		var buf bytes.Buffer
-		buf.WriteString(agg.Func.String())
+		if agg.UserDefined != nil {
+			buf.WriteString(agg.UserDefined.Name)
+		} else {
+			buf.WriteString(agg.Func.String())
+		}
 		buf.WriteByte('(')
 
 		if agg.Distinct {
@@ -678,6 +682,8 @@ func (w *WindowerSpec) summary() (string, []string) {
 		var buf bytes.Buffer
 		if windowFn.Func.WindowFunc != nil {
 			buf.WriteString(windowFn.Func.WindowFunc.String())
+		} else if windowFn.UserDefined != nil {
+			buf.WriteString(windowFn.UserDefined.Name)
 		} else {
 			buf.WriteString(windowFn.Func.AggregateFunc.String())
 		}
@@ -316,6 +316,26 @@ func (*DummyEvalPlanner) RepairTTLScheduledJobForTable(ctx context.Context, tabl
 	return errors.WithStack(errEvalPlanner)
 }
 
+// SendNotification is part of the Planner interface.
+func (*DummyEvalPlanner) SendNotification(ctx context.Context, channel, payload string) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
+// ListeningChannels is part of the Planner interface.
+func (*DummyEvalPlanner) ListeningChannels() []string {
+	return nil
+}
+
+// EventTriggerDDLCommands is part of the Planner interface.
+func (*DummyEvalPlanner) EventTriggerDDLCommands(ctx context.Context) ([]tree.Datums, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
+// EventTriggerDroppedObjects is part of the Planner interface.
+func (*DummyEvalPlanner) EventTriggerDroppedObjects(ctx context.Context) ([]tree.Datums, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
 // Mon is part of the eval.Planner interface.
 func (ep *DummyEvalPlanner) Mon() *mon.BytesMonitor {
 	return ep.Monitor
@@ -348,6 +368,20 @@ func (*DummyEvalPlanner) PLpgSQLFetchCursor(
 	return nil, errors.WithStack(errEvalPlanner)
 }
 
+// PLpgSQLFetchCursorRecord is part of the Planner interface.
+func (*DummyEvalPlanner) PLpgSQLFetchCursorRecord(
+	context.Context, *tree.CursorStmt,
+) (tree.Datum, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
+// PLpgSQLOpenQueryCursor is part of the Planner interface.
+func (*DummyEvalPlanner) PLpgSQLOpenQueryCursor(
+	context.Context, tree.Name, string, tree.Datums,
+) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
 func (p *DummyEvalPlanner) StartHistoryRetentionJob(
 	ctx context.Context, desc string, protectTS hlc.Timestamp, expiration time.Duration,
 ) (jobspb.JobID, error) {
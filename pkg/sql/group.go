@@ -9,7 +9,9 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 )
 
@@ -80,6 +82,9 @@ type aggregateFuncHolder struct {
 	// distsqlBlocklist is set when this function cannot be evaluated in
 	// distributed fashion.
 	distsqlBlocklist bool
+	// userDefined is set if this is a user-defined aggregate, in which case
+	// funcName is only used for display.
+	userDefined *exec.UserDefinedAggInfo
 }
 
 // newAggregateFuncHolder creates an aggregateFuncHolder.
@@ -110,3 +115,51 @@ func newAggregateFuncHolder(
 func (a *aggregateFuncHolder) hasFilter() bool {
 	return a.filterRenderIdx != tree.NoColumnIdx
 }
+
+// userDefinedAggExprs returns the expressions that are evaluated to compute the
+// given user-defined aggregate.
+func userDefinedAggExprs(info *exec.UserDefinedAggInfo) tree.TypedExprs {
+	exprs := make(tree.TypedExprs, 0, 4)
+	for _, expr := range []tree.TypedExpr{info.InitialState, info.Transition, info.Combine, info.Final} {
+		if expr != nil {
+			exprs = append(exprs, expr)
+		}
+	}
+	return exprs
+}
+
+// makeUserDefinedAggSpec creates the specification of the given user-defined
+// aggregate, which has a single argument column holding a tuple of its
+// arguments.
+func makeUserDefinedAggSpec(
+	ctx context.Context, planCtx *PlanningCtx, name string, info *exec.UserDefinedAggInfo,
+) (*execinfrapb.AggregatorSpec_UserDefinedAggregate, error) {
+	spec := &execinfrapb.AggregatorSpec_UserDefinedAggregate{
+		Name:             name,
+		StateType:        info.StateType,
+		ResultType:       info.ResultType,
+		TransitionStrict: info.TransitionStrict,
+		TupleArgs:        true,
+		CombineStrict:    info.CombineStrict,
+	}
+	var ef physicalplan.ExprFactory
+	ef.Init(ctx, planCtx, nil /* indexVarMap */)
+	for _, e := range []struct {
+		expr tree.TypedExpr
+		dst  *execinfrapb.Expression
+	}{
+		{info.InitialState, &spec.InitialState},
+		{info.Transition, &spec.Transition},
+		{info.Combine, &spec.Combine},
+		{info.Final, &spec.Final},
+	} {
+		if e.expr == nil {
+			continue
+		}
+		var err error
+		if *e.dst, err = ef.Make(e.expr); err != nil {
+			return nil, err
+		}
+	}
+	return spec, nil
+}
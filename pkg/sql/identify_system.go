@@ -8,6 +8,7 @@ package sql
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
@@ -53,9 +54,12 @@ func (s *identifySystemNode) Close(ctx context.Context) {}
 func (p *planner) IdentifySystem(
 	ctx context.Context, n *pgrepltree.IdentifySystem,
 ) (planNode, error) {
+	xlogPos := lsnutil.HLCToLSN(p.Txn().ReadTimestamp())
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V25_2_PGReplicationSlots) {
+		xlogPos = lsnutil.LegacyHLCToLSN(p.Txn().ReadTimestamp())
+	}
 	return &identifySystemNode{
-		// TODO(#105130): correctly populate this field.
-		lsn:       lsnutil.HLCToLSN(p.Txn().ReadTimestamp()),
+		lsn:       xlogPos,
 		clusterID: p.ExecCfg().NodeInfo.LogicalClusterID().String(),
 		database:  p.SessionData().Database,
 	}, nil
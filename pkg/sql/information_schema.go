@@ -862,7 +862,7 @@ https://www.postgresql.org/docs/9.5/infoschema-key-column-usage.html`,
 					cols = table.ForeignKeyOriginColumns(fk)
 				} else if uwi := c.AsUniqueWithIndex(); uwi != nil {
 					cols = table.IndexKeyColumns(uwi)
-				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil && !uwoi.IsExclusion() {
 					cols = table.UniqueWithoutIndexColumns(uwoi)
 				}
 				for pos, col := range cols {
@@ -1459,23 +1459,31 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 
 				for _, c := range table.AllConstraints() {
 					kind := catconstants.ConstraintTypeUnique
+					var deferrable, initiallyDeferred bool
 					if c.AsCheck() != nil {
 						kind = catconstants.ConstraintTypeCheck
-					} else if c.AsForeignKey() != nil {
+					} else if fk := c.AsForeignKey(); fk != nil {
 						kind = catconstants.ConstraintTypeFK
+						deferrable, initiallyDeferred = fk.IsDeferrable(), fk.IsInitiallyDeferred()
 					} else if u := c.AsUniqueWithIndex(); u != nil && u.Primary() {
 						kind = catconstants.ConstraintTypePK
+					} else if u := c.AsUniqueWithoutIndex(); u != nil {
+						if u.IsExclusion() {
+							// Like in Postgres, exclusion constraints are not shown.
+							continue
+						}
+						deferrable, initiallyDeferred = u.IsDeferrable(), u.IsInitiallyDeferred()
 					}
 					if err := addRow(
-						dbNameStr,                     // constraint_catalog
-						scNameStr,                     // constraint_schema
-						tree.NewDString(c.GetName()),  // constraint_name
-						dbNameStr,                     // table_catalog
-						scNameStr,                     // table_schema
-						tbNameStr,                     // table_name
-						tree.NewDString(string(kind)), // constraint_type
-						yesOrNoDatum(false),           // is_deferrable
-						yesOrNoDatum(false),           // initially_deferred
+						dbNameStr,                       // constraint_catalog
+						scNameStr,                       // constraint_schema
+						tree.NewDString(c.GetName()),    // constraint_name
+						dbNameStr,                       // table_catalog
+						scNameStr,                       // table_schema
+						tbNameStr,                       // table_name
+						tree.NewDString(string(kind)),   // constraint_type
+						yesOrNoDatum(deferrable),        // is_deferrable
+						yesOrNoDatum(initiallyDeferred), // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -0,0 +1,179 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// maxChannelNameLength is the maximum length of a notification channel name,
+// which is an identifier in Postgres (NAMEDATALEN - 1).
+const maxChannelNameLength = 63
+
+// pendingListen is a LISTEN or UNLISTEN executed in the current transaction.
+// It takes effect when the transaction commits.
+type pendingListen struct {
+	// channel is empty for UNLISTEN *.
+	channel  string
+	unlisten bool
+}
+
+// sessionListener gives a planner access to the notification channels of its
+// session.
+type sessionListener interface {
+	// addPendingListen records a LISTEN or UNLISTEN of the current transaction.
+	addPendingListen(l pendingListen) error
+	// listeningChannels returns the channels the session listens on.
+	listeningChannels() []string
+	// notificationSeq returns the sequence number of the next notification
+	// of the current transaction, and whether the transaction has already
+	// sent the given notification.
+	notificationSeq(n sentNotification) (seq int, sent bool)
+	// addNotification records a notification sent in the current
+	// transaction.
+	addNotification(n sentNotification)
+}
+
+// emptySessionListener is the sessionListener of planners which are not
+// associated with a client session.
+type emptySessionListener struct{}
+
+func (emptySessionListener) addPendingListen(l pendingListen) error {
+	if l.unlisten {
+		return nil
+	}
+	return pgerror.New(pgcode.FeatureNotSupported, "LISTEN is only supported in client sessions")
+}
+
+func (emptySessionListener) listeningChannels() []string { return nil }
+
+func (emptySessionListener) notificationSeq(sentNotification) (int, bool) { return 0, false }
+
+func (emptySessionListener) addNotification(sentNotification) {}
+
+// connExListenAccessor is a sessionListener that delegates to a connExecutor.
+type connExListenAccessor struct {
+	ex *connExecutor
+}
+
+func (c connExListenAccessor) addPendingListen(l pendingListen) error {
+	if c.ex.executorType == executorTypeInternal {
+		return emptySessionListener{}.addPendingListen(l)
+	}
+	if !l.unlisten && c.ex.server.cfg.NotificationRegistry == nil {
+		return pgerror.New(pgcode.FeatureNotSupported, "LISTEN is not supported by this server")
+	}
+	c.ex.extraTxnState.pendingListens = append(c.ex.extraTxnState.pendingListens, l)
+	return nil
+}
+
+func (c connExListenAccessor) listeningChannels() []string {
+	if c.ex.notificationListener == nil {
+		return nil
+	}
+	return c.ex.notificationListener.Channels()
+}
+
+func (c connExListenAccessor) notificationSeq(n sentNotification) (seq int, sent bool) {
+	_, sent = c.ex.extraTxnState.notifications[n]
+	return len(c.ex.extraTxnState.notifications), sent
+}
+
+func (c connExListenAccessor) addNotification(n sentNotification) {
+	if c.ex.extraTxnState.notifications == nil {
+		c.ex.extraTxnState.notifications = make(map[sentNotification]int)
+	}
+	c.ex.extraTxnState.notifications[n] = len(c.ex.extraTxnState.notifications)
+}
+
+// applyPendingListens applies the LISTEN and UNLISTEN statements of the
+// transaction which just committed.
+func (ex *connExecutor) applyPendingListens() {
+	for _, l := range ex.extraTxnState.pendingListens {
+		if ex.notificationListener == nil {
+			if l.unlisten {
+				continue
+			}
+			ex.notificationListener = ex.server.cfg.NotificationRegistry.NewListener(func() {
+				// The error is only returned if the buffer is closed, in which
+				// case the session is ending anyway.
+				_ = ex.stmtBuf.Push(ex.ctxHolder.connCtx, DeliverNotifications{})
+			})
+		}
+		switch {
+		case !l.unlisten:
+			ex.notificationListener.Listen(l.channel)
+		case l.channel == "":
+			ex.notificationListener.UnlistenAll()
+		default:
+			ex.notificationListener.Unlisten(l.channel)
+		}
+	}
+}
+
+// deliverNotifications sends the queued notifications to the client.
+func (ex *connExecutor) deliverNotifications(res NotificationResult) {
+	if ex.notificationListener == nil {
+		return
+	}
+	for _, n := range ex.notificationListener.Drain() {
+		res.BufferNotification(n)
+	}
+}
+
+// checkNotificationsSupported returns an error if the cluster does not
+// support LISTEN and NOTIFY yet.
+func checkNotificationsSupported(ctx context.Context, p *planner, stmt string) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V25_2_AddNotificationsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s unsupported in mixed-version cluster", stmt)
+	}
+	return nil
+}
+
+// checkChannelName returns an error if the name is not a valid notification
+// channel name.
+func checkChannelName(channel string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > maxChannelNameLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	return nil
+}
+
+// listenNode implements LISTEN and UNLISTEN.
+type listenNode struct {
+	zeroInputPlanNode
+	listen pendingListen
+}
+
+// Listen implements the LISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-listen.html for details.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if err := checkNotificationsSupported(ctx, p, "LISTEN"); err != nil {
+		return nil, err
+	}
+	channel := string(n.ChannelName)
+	if err := checkChannelName(channel); err != nil {
+		return nil, err
+	}
+	return &listenNode{listen: pendingListen{channel: channel}}, nil
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	return params.p.sessionListener.addPendingListen(n.listen)
+}
+
+func (n *listenNode) Next(runParams) (bool, error) { return false, nil }
+func (n *listenNode) Values() tree.Datums          { return nil }
+func (n *listenNode) Close(context.Context)        {}
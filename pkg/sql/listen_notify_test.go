@@ -0,0 +1,179 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/testutils/pgurlutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListenNotifyAcrossNodes checks that notifications sent on one node are
+// delivered to the sessions listening on other nodes, and only once the
+// transaction which sent them commits.
+func TestListenNotifyAcrossNodes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := serverutils.StartCluster(t, 3 /* numNodes */, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	connect := func(idx int) *pgx.Conn {
+		pgURL, cleanup := pgurlutils.PGUrl(
+			t, tc.Server(idx).ApplicationLayer().AdvSQLAddr(), t.Name(), url.User(username.RootUser),
+		)
+		defer cleanup()
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		require.NoError(t, err)
+		return conn
+	}
+	listener0 := connect(0)
+	defer func() { _ = listener0.Close(ctx) }()
+	listener2 := connect(2)
+	defer func() { _ = listener2.Close(ctx) }()
+	sender := connect(1)
+	defer func() { _ = sender.Close(ctx) }()
+
+	for _, conn := range []*pgx.Conn{listener0, listener2} {
+		_, err := conn.Exec(ctx, "LISTEN foo")
+		require.NoError(t, err)
+	}
+	_, err := listener0.Exec(ctx, "LISTEN bar")
+	require.NoError(t, err)
+
+	var senderPID uint32
+	require.NoError(t, sender.QueryRow(ctx, "SELECT pg_backend_pid()").Scan(&senderPID))
+
+	// expectNotification waits for the next notification of the session.
+	expectNotification := func(conn *pgx.Conn, channel, payload string) {
+		t.Helper()
+		waitCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
+		defer cancel()
+		n, err := conn.WaitForNotification(waitCtx)
+		require.NoError(t, err)
+		require.Equal(t, channel, n.Channel)
+		require.Equal(t, payload, n.Payload)
+		require.Equal(t, senderPID, n.PID)
+	}
+
+	// Notifications of transactions which roll back are never delivered, so
+	// the first notification each listener gets is the committed one.
+	_, err = sender.Exec(ctx, "BEGIN; NOTIFY foo, 'rolled back'; ROLLBACK")
+	require.NoError(t, err)
+	_, err = sender.Exec(ctx, "BEGIN; NOTIFY foo, 'committed'; COMMIT")
+	require.NoError(t, err)
+	expectNotification(listener0, "foo", "committed")
+	expectNotification(listener2, "foo", "committed")
+
+	// Only the sessions listening on the channel receive its notifications.
+	_, err = sender.Exec(ctx, "SELECT pg_notify('bar', 'via pg_notify')")
+	require.NoError(t, err)
+	expectNotification(listener0, "bar", "via pg_notify")
+
+	_, err = listener0.Exec(ctx, "UNLISTEN *")
+	require.NoError(t, err)
+	_, err = sender.Exec(ctx, "NOTIFY bar, 'ignored'")
+	require.NoError(t, err)
+	_, err = sender.Exec(ctx, "NOTIFY foo, 'after unlisten'")
+	require.NoError(t, err)
+	expectNotification(listener2, "foo", "after unlisten")
+
+	// Notifications which arrive during a transaction are delivered once it
+	// ends.
+	tx, err := listener2.Begin(ctx)
+	require.NoError(t, err)
+	_, err = sender.Exec(ctx, "NOTIFY foo, 'during txn'")
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, "SELECT 1")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+	expectNotification(listener2, "foo", "during txn")
+
+	// The listener which unlistened got nothing more.
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = listener0.WaitForNotification(waitCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// TestNotifyTransactionOrder checks that the notifications of a transaction
+// are delivered in the order they were sent, and that a transaction sends a
+// notification only once.
+func TestNotifyTransactionOrder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv := serverutils.StartServerOnly(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+
+	connect := func() *pgx.Conn {
+		pgURL, cleanup := pgurlutils.PGUrl(
+			t, srv.ApplicationLayer().AdvSQLAddr(), t.Name(), url.User(username.RootUser),
+		)
+		defer cleanup()
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		require.NoError(t, err)
+		return conn
+	}
+	listener := connect()
+	defer func() { _ = listener.Close(ctx) }()
+	sender := connect()
+	defer func() { _ = sender.Close(ctx) }()
+
+	_, err := listener.Exec(ctx, "LISTEN foo")
+	require.NoError(t, err)
+
+	expectPayloads := func(payloads ...string) {
+		t.Helper()
+		for _, payload := range payloads {
+			waitCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
+			n, err := listener.WaitForNotification(waitCtx)
+			cancel()
+			require.NoError(t, err)
+			require.Equal(t, payload, n.Payload)
+		}
+	}
+
+	// Duplicates are folded, unless the first notification was rolled back
+	// with a savepoint.
+	_, err = sender.Exec(ctx, `BEGIN;
+NOTIFY foo, 'b';
+NOTIFY foo, 'a';
+NOTIFY foo, 'b';
+SAVEPOINT s;
+NOTIFY foo, 'd';
+ROLLBACK TO SAVEPOINT s;
+SELECT pg_notify('foo', 'a');
+NOTIFY foo, 'd';
+SELECT pg_notify('foo', x::STRING) FROM generate_series(1, 4) AS x;
+COMMIT`)
+	require.NoError(t, err)
+	expectPayloads("b", "a", "d", "1", "2", "3", "4")
+
+	// Duplicates are only folded within a transaction.
+	_, err = sender.Exec(ctx, "NOTIFY foo, 'b'")
+	require.NoError(t, err)
+	_, err = sender.Exec(ctx, "NOTIFY foo, 'b'")
+	require.NoError(t, err)
+	expectPayloads("b", "b")
+
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = listener.WaitForNotification(waitCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
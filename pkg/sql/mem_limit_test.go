@@ -146,6 +146,40 @@ func TestMemoryLimit(t *testing.T) {
 	}
 }
 
+// TestUserDefinedAggregateMemoryLimit verifies that the state of a
+// user-defined aggregate is accounted for, and that the input rows of the
+// aggregate are not buffered.
+func TestUserDefinedAggregateMemoryLimit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{
+		SQLMemoryPoolSize: 64 << 20, /* 64MiB */
+	})
+	defer s.Stopper().Stop(context.Background())
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE FUNCTION last_step(state STRING, x STRING) RETURNS STRING LANGUAGE SQL AS 'SELECT x'`)
+	sqlDB.Exec(t, `CREATE AGGREGATE my_last(STRING) (SFUNC = last_step, STYPE = STRING)`)
+	sqlDB.Exec(t, `CREATE FUNCTION concat_step(state STRING, x STRING) RETURNS STRING LANGUAGE SQL AS 'SELECT state || x'`)
+	sqlDB.Exec(t, `CREATE AGGREGATE my_concat(STRING) (SFUNC = concat_step, STYPE = STRING, INITCOND = '')`)
+
+	testutils.RunTrueAndFalse(t, "vectorize", func(t *testing.T, vectorize bool) {
+		vectorizeMode := "off"
+		if vectorize {
+			vectorizeMode = "on"
+		}
+		sqlDB.Exec(t, "SET vectorize = "+vectorizeMode)
+		// Only the state is kept while the rows are aggregated, so the 200MiB
+		// of input do not need to fit in memory.
+		sqlDB.CheckQueryResults(t,
+			`SELECT length(my_last(repeat('a', 1<<20))) FROM generate_series(1, 200)`,
+			[][]string{{"1048576"}})
+		// The state grows to 100MiB.
+		sqlDB.ExpectErr(t, "memory budget exceeded",
+			`SELECT length(my_concat(repeat('a', 1<<20))) FROM generate_series(1, 100)`)
+	})
+}
+
 // TestStreamerTightBudget verifies that the Streamer utilizes its available
 // budget as tightly as possible, without incurring unnecessary debt. It gives
 // the Streamer such a budget that a single result puts it in debt, so there
@@ -0,0 +1,109 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+)
+
+// maxNotificationPayloadLength is the length in bytes a notification payload
+// must be shorter than, as in Postgres.
+const maxNotificationPayloadLength = 8000
+
+// sentNotification is a notification sent in the current transaction.
+type sentNotification struct {
+	channel string
+	payload string
+}
+
+type notifyNode struct {
+	zeroInputPlanNode
+	channel string
+	payload string
+}
+
+// Notify implements the NOTIFY statement.
+// See https://www.postgresql.org/docs/current/sql-notify.html for details.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	if err := checkNotificationsSupported(ctx, p, "NOTIFY"); err != nil {
+		return nil, err
+	}
+	channel := string(n.ChannelName)
+	if err := checkNotification(channel, n.Payload); err != nil {
+		return nil, err
+	}
+	return &notifyNode{channel: channel, payload: n.Payload}, nil
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	return params.p.sendNotification(params.ctx, n.channel, n.payload)
+}
+
+func (n *notifyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *notifyNode) Values() tree.Datums          { return nil }
+func (n *notifyNode) Close(context.Context)        {}
+
+// SendNotification is part of the eval.Planner interface.
+func (p *planner) SendNotification(ctx context.Context, channel, payload string) error {
+	if err := checkNotificationsSupported(ctx, p, "pg_notify()"); err != nil {
+		return err
+	}
+	if err := checkNotification(channel, payload); err != nil {
+		return err
+	}
+	return p.sendNotification(ctx, channel, payload)
+}
+
+// ListeningChannels is part of the eval.Planner interface.
+func (p *planner) ListeningChannels() []string {
+	return p.sessionListener.listeningChannels()
+}
+
+// sendNotification writes a notification to system.notifications in the
+// current transaction. The nodes with sessions listening on the channel pick
+// it up with a rangefeed once the transaction commits.
+//
+// As in Postgres, a notification is only sent once per transaction: sending
+// the same payload on the same channel again is a no-op. The notifications of
+// a transaction are delivered in the order they were sent, so each
+// notification records its sequence number in the transaction, and the
+// registry delivers the notifications of a transaction in sequence order. The
+// id of the notification stays random, so that the writes of concurrent
+// transactions are spread over the table.
+func (p *planner) sendNotification(ctx context.Context, channel, payload string) error {
+	n := sentNotification{channel: channel, payload: payload}
+	seq, sent := p.sessionListener.notificationSeq(n)
+	if sent {
+		return nil
+	}
+	pid := int32(p.ExtendedEvalContext().QueryCancelKey.GetPGBackendPID())
+	if _, err := p.InternalSQLTxn().ExecEx(
+		ctx, "notify", p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.notifications (channel, payload, pid, seq) VALUES ($1, $2, $3, $4)`,
+		channel, payload, pid, seq,
+	); err != nil {
+		return err
+	}
+	p.sessionListener.addNotification(n)
+	return nil
+}
+
+// checkNotification returns an error if the notification is not valid.
+func checkNotification(channel, payload string) error {
+	if err := checkChannelName(channel); err != nil {
+		return err
+	}
+	if len(payload) >= maxNotificationPayloadLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	return nil
+}
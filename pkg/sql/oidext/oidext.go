@@ -38,6 +38,19 @@ const (
 const (
 	T_jsonpath  = oid.Oid(4072)
 	T__jsonpath = oid.Oid(4073)
+
+	T_int4multirange  = oid.Oid(4451)
+	T__int4multirange = oid.Oid(6150)
+	T_nummultirange   = oid.Oid(4532)
+	T__nummultirange  = oid.Oid(6151)
+	T_tsmultirange    = oid.Oid(4533)
+	T__tsmultirange   = oid.Oid(6152)
+	T_tstzmultirange  = oid.Oid(4534)
+	T__tstzmultirange = oid.Oid(6153)
+	T_datemultirange  = oid.Oid(4535)
+	T__datemultirange = oid.Oid(6155)
+	T_int8multirange  = oid.Oid(4536)
+	T__int8multirange = oid.Oid(6157)
 )
 
 // ExtensionTypeName returns a mapping from extension oids
@@ -53,6 +66,19 @@ var ExtensionTypeName = map[oid.Oid]string{
 	T__pgvector:  "_VECTOR",
 	T_jsonpath:   "JSONPATH",
 	T__jsonpath:  "_JSONPATH",
+
+	T_int4multirange:  "INT4MULTIRANGE",
+	T__int4multirange: "_INT4MULTIRANGE",
+	T_nummultirange:   "NUMMULTIRANGE",
+	T__nummultirange:  "_NUMMULTIRANGE",
+	T_tsmultirange:    "TSMULTIRANGE",
+	T__tsmultirange:   "_TSMULTIRANGE",
+	T_tstzmultirange:  "TSTZMULTIRANGE",
+	T__tstzmultirange: "_TSTZMULTIRANGE",
+	T_datemultirange:  "DATEMULTIRANGE",
+	T__datemultirange: "_DATEMULTIRANGE",
+	T_int8multirange:  "INT8MULTIRANGE",
+	T__int8multirange: "_INT8MULTIRANGE",
 }
 
 // TypeName checks the name for a given type by first looking up oid.TypeName
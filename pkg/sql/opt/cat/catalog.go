@@ -274,4 +274,8 @@ type Catalog interface {
 
 	// IsOwner returns true if user is the owner of the object o
 	IsOwner(ctx context.Context, o Object, user username.SQLUsername) (bool, error)
+
+	// EventTriggers returns the event triggers of the current database, ordered
+	// by name.
+	EventTriggers(ctx context.Context) ([]EventTrigger, error)
 }
@@ -0,0 +1,47 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package cat
+
+import "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+
+// EventTrigger is an interface to an event trigger of a database, which
+// executes a function in response to DDL commands.
+type EventTrigger interface {
+	// Name is the name of the event trigger. It is unique within a database.
+	Name() tree.Name
+
+	// Event is the event on which the trigger fires, for example
+	// ddl_command_start (see tree.EventTriggerDDLCommandStart).
+	Event() string
+
+	// Tags is the list of command tags for which the trigger fires. If it is
+	// empty, the trigger fires for all commands that support event triggers.
+	Tags() []string
+
+	// FuncID is the ID of the function that is called when the trigger fires.
+	FuncID() StableID
+
+	// Enabled is false if the trigger has been disabled with ALTER EVENT
+	// TRIGGER ... DISABLE.
+	Enabled() bool
+}
+
+// EventTriggerMatches returns true if the given event trigger should fire on
+// the given event for a command with the given tag.
+func EventTriggerMatches(trigger EventTrigger, event, tag string) bool {
+	if !trigger.Enabled() || trigger.Event() != event {
+		return false
+	}
+	if len(trigger.Tags()) == 0 {
+		return true
+	}
+	for _, t := range trigger.Tags() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
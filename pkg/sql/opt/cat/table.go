@@ -10,6 +10,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -333,9 +334,18 @@ type ForeignKeyConstraint interface {
 	// existing data satisfies the constraint). It is possible to set up a foreign
 	// key constraint on existing tables without validating it, in which case we
 	// cannot make any assumptions about the data. An unvalidated constraint still
-	// needs to be enforced on new mutations.
+	// needs to be enforced on new mutations. Deferrable constraints are never
+	// validated, since the data can violate them until the end of a transaction.
 	Validated() bool
 
+	// Deferrable is true if the check of the constraint can be deferred until
+	// the end of the transaction with SET CONSTRAINTS.
+	Deferrable() bool
+
+	// InitiallyDeferred is true if the check of the constraint is deferred
+	// until the end of the transaction unless SET CONSTRAINTS says otherwise.
+	InitiallyDeferred() bool
+
 	// MatchMethod returns the method used for comparing composite foreign keys.
 	MatchMethod() tree.CompositeKeyMatchMethod
 
@@ -390,15 +400,38 @@ type UniqueConstraint interface {
 	// existing data satisfies the constraint). It is possible to set up a unique
 	// constraint on existing tables without validating it, in which case we
 	// cannot make any assumptions about the data. An unvalidated constraint still
-	// needs to be enforced on new mutations.
+	// needs to be enforced on new mutations. Deferrable constraints are never
+	// validated, since the data can violate them until the end of a transaction.
 	Validated() bool
 
+	// Deferrable is true if the check of the constraint can be deferred until
+	// the end of the transaction with SET CONSTRAINTS. Only unique constraints
+	// without an index can be deferrable.
+	Deferrable() bool
+
+	// InitiallyDeferred is true if the check of the constraint is deferred
+	// until the end of the transaction unless SET CONSTRAINTS says otherwise.
+	InitiallyDeferred() bool
+
 	// UniquenessGuaranteedByAnotherIndex returns true when WithoutIndex() returns
 	// true and the uniqueness of the constraint is guaranteed by another index.
 	// When true, the optimizer will always consider the constraint to be
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// IsExclusion is true if this is an exclusion constraint rather than a
+	// unique constraint. Two rows conflict under an exclusion constraint if the
+	// comparison returned by ExclusionOperator is true for every column. Since
+	// the comparisons need not be equalities, exclusion constraints do not make
+	// their columns a key, and the optimizer never treats them as validated or
+	// uses them as arbiters.
+	IsExclusion() bool
+
+	// ExclusionOperator returns the comparison operator used for the ith column
+	// of an exclusion constraint. It must only be called if IsExclusion returns
+	// true.
+	ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
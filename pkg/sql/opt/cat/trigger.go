@@ -212,10 +212,22 @@ func ValidateCreateTrigger(
 	return nil
 }
 
-// HasRowLevelTriggers returns true if the given table has any row-level
+// TriggerTarget is a data source on which triggers can be defined; it is
+// implemented by both Table and View.
+type TriggerTarget interface {
+	DataSource
+
+	// TriggerCount returns the number of triggers present on the data source.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger, where i < TriggerCount.
+	Trigger(i int) Trigger
+}
+
+// HasRowLevelTriggers returns true if the given table or view has any row-level
 // triggers that match the given action time and event type.
 func HasRowLevelTriggers(
-	tab Table, actionTime tree.TriggerActionTime, eventToMatch tree.TriggerEventType,
+	tab TriggerTarget, actionTime tree.TriggerActionTime, eventToMatch tree.TriggerEventType,
 ) bool {
 	for i := 0; i < tab.TriggerCount(); i++ {
 		trigger := tab.Trigger(i)
@@ -233,15 +245,33 @@ func HasRowLevelTriggers(
 }
 
 // GetRowLevelTriggers returns the set of row-level triggers for the given
-// table and given trigger event type and timing. The triggers are returned in
+// table or view and given trigger event type and timing. The triggers are returned in
 // the order in which they should be executed.
 func GetRowLevelTriggers(
-	tab Table, actionTime tree.TriggerActionTime, eventsToMatch tree.TriggerEventTypeSet,
+	tab TriggerTarget, actionTime tree.TriggerActionTime, eventsToMatch tree.TriggerEventTypeSet,
+) []Trigger {
+	return getTriggers(tab, true /* forEachRow */, actionTime, eventsToMatch)
+}
+
+// GetStatementLevelTriggers returns the set of statement-level triggers for the
+// given table and given trigger event type and timing. The triggers are
+// returned in the order in which they should be executed.
+func GetStatementLevelTriggers(
+	tab TriggerTarget, actionTime tree.TriggerActionTime, eventsToMatch tree.TriggerEventTypeSet,
+) []Trigger {
+	return getTriggers(tab, false /* forEachRow */, actionTime, eventsToMatch)
+}
+
+func getTriggers(
+	tab TriggerTarget,
+	forEachRow bool,
+	actionTime tree.TriggerActionTime,
+	eventsToMatch tree.TriggerEventTypeSet,
 ) []Trigger {
 	var neededTriggers intsets.Fast
 	for i := 0; i < tab.TriggerCount(); i++ {
 		trigger := tab.Trigger(i)
-		if !trigger.Enabled() || !trigger.ForEachRow() ||
+		if !trigger.Enabled() || trigger.ForEachRow() != forEachRow ||
 			trigger.ActionTime() != actionTime {
 			continue
 		}
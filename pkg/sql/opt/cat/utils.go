@@ -104,6 +104,21 @@ func FormatTable(
 	for i := 0; i < tab.UniqueCount(); i++ {
 		var withoutIndexStr string
 		uniq := tab.Unique(i)
+		if uniq.IsExclusion() {
+			var buf bytes.Buffer
+			for j := 0; j < uniq.ColumnCount(); j++ {
+				if j > 0 {
+					buf.WriteString(", ")
+				}
+				col := tab.Column(uniq.ColumnOrdinal(tab, j))
+				fmt.Fprintf(&buf, "%s WITH %s", col.ColName(), uniq.ExclusionOperator(j))
+			}
+			c := child.Childf("EXCLUDE (%s)", buf.String())
+			if pred, isPartial := uniq.Predicate(); isPartial {
+				c.Childf("WHERE %s", MaybeMarkRedactable(pred, redactableValues))
+			}
+			continue
+		}
 		if uniq.WithoutIndex() {
 			withoutIndexStr = "WITHOUT INDEX "
 		}
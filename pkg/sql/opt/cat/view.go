@@ -34,6 +34,11 @@ type View interface {
 	// crdb_internal.ranges).
 	IsSystemView() bool
 
+	// CheckOption returns the WITH CHECK OPTION setting of the view, which
+	// requires rows written through an automatically updatable view to satisfy
+	// the view's WHERE clause.
+	CheckOption() tree.ViewCheckOption
+
 	// TriggerCount returns the number of triggers present on the view.
 	TriggerCount() int
 
@@ -56,6 +61,11 @@ func FormatView(view View, tp treeprinter.Node) {
 		buf.WriteString(")")
 	}
 
+	if checkOption := view.CheckOption(); checkOption != tree.ViewCheckOptionNone {
+		buf.WriteByte(' ')
+		buf.WriteString(tree.AsString(&checkOption))
+	}
+
 	child := tp.Childf("VIEW %s%s", view.Name(), buf.String())
 
 	child.Child(view.Query())
@@ -195,6 +195,12 @@ func (b *Builder) tryBuildFastPathInsert(
 	if ins.VectorInsert {
 		return execPlan{}, colOrdMap{}, false, nil
 	}
+	// Do not attempt the fast path if the checks of deferrable constraints
+	// are needed, since their violations may have to be ignored rather than
+	// stopping the insert.
+	if hasDeferrableChecks(b.mem.Metadata(), ins) {
+		return execPlan{}, colOrdMap{}, false, nil
+	}
 
 	insInput := ins.Input
 	values, ok := insInput.(*memo.ValuesExpr)
@@ -394,6 +400,23 @@ func (b *Builder) tryBuildFastPathInsert(
 	return ep, outputCols, true, nil
 }
 
+// hasDeferrableChecks returns true if any of the uniqueness or foreign key
+// checks of the insert is for a deferrable constraint.
+func hasDeferrableChecks(md *opt.Metadata, ins *memo.InsertExpr) bool {
+	tab := md.Table(ins.Table)
+	for i := range ins.UniqueChecks {
+		if tab.Unique(ins.UniqueChecks[i].CheckOrdinal).Deferrable() {
+			return true
+		}
+	}
+	for i := range ins.FKChecks {
+		if tab.OutboundForeignKey(ins.FKChecks[i].FKOrdinal).Deferrable() {
+			return true
+		}
+	}
+	return false
+}
+
 // rearrangeColumns rearranges the columns in a matrix of TypedExpr values.
 //
 // Each column in inRows corresponds to a column in inCols. The values in the
@@ -508,8 +531,12 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (_ execPlan, outputCols colO
 	// "Put" to insert new rows or blindly overwrite existing rows. Existing rows
 	// do not need to be fetched or separately updated (i.e. ups.FetchCols and
 	// ups.UpdateCols are both empty).
+	//
+	// If MergeDeleteCol != 0, then this Upsert was built for a MERGE statement,
+	// which can also delete existing rows.
 	colList := appendColsWhenPresent(
 		ups.InsertCols, ups.FetchCols, ups.UpdateCols, opt.OptionalColList{ups.CanaryCol},
+		opt.OptionalColList{ups.MergeDeleteCol},
 		ups.CheckCols, ups.PartialIndexPutCols, ups.PartialIndexDelCols,
 		ups.VectorIndexPutPartitionCols, ups.VectorIndexPutQuantizedVecCols,
 		ups.VectorIndexDelPartitionCols,
@@ -539,22 +566,47 @@ func (b *Builder) buildUpsert(ups *memo.UpsertExpr) (_ execPlan, outputCols colO
 	updateColOrds := ordinalSetFromColList(ups.UpdateCols)
 	returnColOrds := ordinalSetFromColList(ups.ReturnCols)
 	checkOrds := ordinalSetFromColList(ups.CheckCols)
-	node, err := b.factory.ConstructUpsert(
-		input.root,
-		tab,
-		ups.ArbiterIndexes,
-		ups.ArbiterConstraints,
-		canaryCol,
-		insertColOrds,
-		fetchColOrds,
-		updateColOrds,
-		returnColOrds,
-		checkOrds,
-		ups.UniqueWithTombstoneIndexes,
-		lockedIndexes,
-		b.allowAutoCommit && len(ups.UniqueChecks) == 0 &&
-			len(ups.FKChecks) == 0 && len(ups.FKCascades) == 0 && ups.AfterTriggers == nil,
-	)
+	autoCommit := b.allowAutoCommit && len(ups.UniqueChecks) == 0 &&
+		len(ups.FKChecks) == 0 && len(ups.FKCascades) == 0 && ups.AfterTriggers == nil
+	var node exec.Node
+	if ups.MergeDeleteCol != 0 {
+		// The delete column comes right after the canary column.
+		deleteCol := canaryCol + 1
+		if canaryCol == -1 || colList[deleteCol] != ups.MergeDeleteCol {
+			return execPlan{}, colOrdMap{},
+				errors.AssertionFailedf("merge delete column not found")
+		}
+		node, err = b.factory.ConstructMerge(
+			input.root,
+			tab,
+			canaryCol,
+			deleteCol,
+			insertColOrds,
+			fetchColOrds,
+			updateColOrds,
+			returnColOrds,
+			checkOrds,
+			ups.UniqueWithTombstoneIndexes,
+			lockedIndexes,
+			autoCommit,
+		)
+	} else {
+		node, err = b.factory.ConstructUpsert(
+			input.root,
+			tab,
+			ups.ArbiterIndexes,
+			ups.ArbiterConstraints,
+			canaryCol,
+			insertColOrds,
+			fetchColOrds,
+			updateColOrds,
+			returnColOrds,
+			checkOrds,
+			ups.UniqueWithTombstoneIndexes,
+			lockedIndexes,
+			autoCommit,
+		)
+	}
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
 	}
@@ -914,6 +966,9 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	tabMeta := md.TableMeta(c.Table)
 	uc := tabMeta.Table.Unique(c.CheckOrdinal)
 	constraintName := uc.Name()
+	if uc.IsExclusion() {
+		return mkExclusionCheckErr(tabMeta.Table, uc, keyVals)
+	}
 	var msg, details bytes.Buffer
 
 	// Generate an error of the form:
@@ -940,13 +995,56 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 
 	details.WriteString(") already exists.")
 
-	return errors.WithDetail(
+	return maybeDeferrableUniqueCheckErr(tabMeta.Table, uc, keyVals, errors.WithDetail(
 		pgerror.WithConstraintName(
 			pgerror.Newf(pgcode.UniqueViolation, "%s", msg.String()),
 			constraintName,
 		),
 		details.String(),
-	)
+	))
+}
+
+// mkExclusionCheckErr is the version of mkUniqueCheckErr for exclusion
+// constraints. The key values are ordered by column ordinal, which may differ
+// from the order of the columns in the constraint.
+func mkExclusionCheckErr(tab cat.Table, uc cat.UniqueConstraint, keyVals tree.Datums) error {
+	var msg, details bytes.Buffer
+
+	// Generate an error of the form:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (a, b)=(1, {2}) conflicts with an existing key.
+	msg.WriteString("conflicting key value violates exclusion constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, uc.Name())
+
+	var ords intsets.Fast
+	for i := 0; i < uc.ColumnCount(); i++ {
+		ords.Add(uc.ColumnOrdinal(tab, i))
+	}
+	details.WriteString("Key (")
+	first := true
+	ords.ForEach(func(ord int) {
+		if !first {
+			details.WriteString(", ")
+		}
+		first = false
+		details.WriteString(string(tab.Column(ord).ColName()))
+	})
+	details.WriteString(")=(")
+	for i, d := range keyVals {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(d.String())
+	}
+	details.WriteString(") conflicts with an existing key.")
+
+	return maybeDeferrableUniqueCheckErr(tab, uc, nil /* keyVals */, errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ExclusionViolation, "%s", msg.String()),
+			uc.Name(),
+		),
+		details.String(),
+	))
 }
 
 // mkUniqueCheckErrWithoutColNames is a simpler version of mkUniqueCheckErr that
@@ -975,13 +1073,33 @@ func mkUniqueCheckErrWithoutColNames(
 
 	details.WriteString(") already exists.")
 
-	return errors.WithDetail(
+	// The key values are not in the order of the constraint columns.
+	return maybeDeferrableUniqueCheckErr(tabMeta.Table, uc, nil /* keyVals */, errors.WithDetail(
 		pgerror.WithConstraintName(
 			pgerror.Newf(pgcode.UniqueViolation, "%s", msg.String()),
 			constraintName,
 		),
 		details.String(),
-	)
+	))
+}
+
+// maybeDeferrableUniqueCheckErr wraps the violation error of a deferrable
+// unique constraint in an exec.DeferrableCheckError. keyVals are the values of
+// the constraint columns, in the order of the constraint, or nil if they are
+// not known.
+func maybeDeferrableUniqueCheckErr(
+	tab cat.Table, uc cat.UniqueConstraint, keyVals tree.Datums, err error,
+) error {
+	if !uc.Deferrable() {
+		return err
+	}
+	return &exec.DeferrableCheckError{
+		TableID:           tab.ID(),
+		Constraint:        uc.Name(),
+		InitiallyDeferred: uc.InitiallyDeferred(),
+		KeyVals:           keyVals,
+		Err:               err,
+	}
 }
 
 // mkFastPathUniqueCheckErr is a wrapper for mkUniqueCheckErr in the insert fast
@@ -1030,11 +1148,12 @@ func mkFKCheckErr(md *opt.Metadata, c *memo.FKChecksItem, keyVals tree.Datums) e
 
 	var msg, details bytes.Buffer
 	var constraintName string
+	var fk cat.ForeignKeyConstraint
 	if c.FKOutbound {
 		// Generate an error of the form:
 		//   ERROR:  insert on table "child" violates foreign key constraint "foo"
 		//   DETAIL: Key (child_p)=(2) is not present in table "parent".
-		fk := origin.Table.OutboundForeignKey(c.FKOrdinal)
+		fk = origin.Table.OutboundForeignKey(c.FKOrdinal)
 		constraintName = fk.Name()
 		fmt.Fprintf(&msg, "%s on table ", c.OpName)
 		lexbase.EncodeEscapedSQLIdent(&msg, string(origin.Alias.ObjectName))
@@ -1076,7 +1195,7 @@ func mkFKCheckErr(md *opt.Metadata, c *memo.FKChecksItem, keyVals tree.Datums) e
 		//   ERROR:  delete on table "parent" violates foreign key constraint
 		//           "child_child_p_fkey" on table "child"
 		//   DETAIL: Key (p)=(1) is still referenced from table "child".
-		fk := referenced.Table.InboundForeignKey(c.FKOrdinal)
+		fk = referenced.Table.InboundForeignKey(c.FKOrdinal)
 		constraintName = fk.Name()
 		fmt.Fprintf(&msg, "%s on table ", c.OpName)
 		lexbase.EncodeEscapedSQLIdent(&msg, string(referenced.Alias.ObjectName))
@@ -1105,13 +1224,23 @@ func mkFKCheckErr(md *opt.Metadata, c *memo.FKChecksItem, keyVals tree.Datums) e
 		details.WriteByte('.')
 	}
 
-	return errors.WithDetail(
+	err := errors.WithDetail(
 		pgerror.WithConstraintName(
 			pgerror.Newf(pgcode.ForeignKeyViolation, "%s", msg.String()),
 			constraintName,
 		),
 		details.String(),
 	)
+	if fk.Deferrable() {
+		return &exec.DeferrableCheckError{
+			TableID:           fk.OriginTableID(),
+			Constraint:        constraintName,
+			InitiallyDeferred: fk.InitiallyDeferred(),
+			KeyVals:           keyVals,
+			Err:               err,
+		}
+	}
+	return err
 }
 
 func (b *Builder) buildFKCascades(withID opt.WithID, cascades memo.FKCascades) error {
@@ -1136,7 +1265,18 @@ func (b *Builder) buildAfterTriggers(withID opt.WithID, triggers *memo.AfterTrig
 	if err != nil {
 		return err
 	}
-	b.triggers = append(b.triggers, tb.setupTriggers(triggers))
+	if len(triggers.Triggers) > 0 {
+		b.triggers = append(b.triggers, tb.setupTriggers(
+			triggers.Triggers, triggers.Builder, false, /* runOnEmptyBuffer */
+		))
+	}
+	if len(triggers.StatementTriggers) > 0 {
+		// Statement-level triggers fire after the row-level triggers, even if no
+		// rows were modified.
+		b.triggers = append(b.triggers, tb.setupTriggers(
+			triggers.StatementTriggers, triggers.StatementBuilder, true, /* runOnEmptyBuffer */
+		))
+	}
 	return nil
 }
 
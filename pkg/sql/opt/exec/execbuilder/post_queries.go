@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
@@ -171,11 +172,16 @@ func (cb *postQueryBuilder) setupCascade(cascade *memo.FKCascade) exec.PostQuery
 	}
 }
 
-// setupTriggers fills in an exec.PostQuery struct for the given triggers.
-func (cb *postQueryBuilder) setupTriggers(triggers *memo.AfterTriggers) exec.PostQuery {
+// setupTriggers fills in an exec.PostQuery struct for the given triggers, which
+// are built by the given builder. If runOnEmptyBuffer is true, the triggers are
+// executed even if the mutation did not modify any rows.
+func (cb *postQueryBuilder) setupTriggers(
+	triggers []cat.Trigger, builder memo.PostQueryBuilder, runOnEmptyBuffer bool,
+) exec.PostQuery {
 	return exec.PostQuery{
-		Triggers: triggers.Triggers,
-		Buffer:   cb.mutationBuffer,
+		Triggers:         triggers,
+		Buffer:           cb.mutationBuffer,
+		RunOnEmptyBuffer: runOnEmptyBuffer,
 		PlanFn: func(
 			ctx context.Context,
 			semaCtx *tree.SemaContext,
@@ -188,7 +194,7 @@ func (cb *postQueryBuilder) setupTriggers(triggers *memo.AfterTriggers) exec.Pos
 			const actionName = "trigger"
 			return cb.planPostQuery(
 				ctx, semaCtx, evalCtx, execFactory, bufferRef, numBufferedRows, allowAutoCommit,
-				triggers.Builder, actionName,
+				builder, actionName,
 			)
 		},
 	}
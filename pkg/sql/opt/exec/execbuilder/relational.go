@@ -805,6 +805,7 @@ func (b *Builder) scanParams(
 		Reverse:            reverse,
 		Parallelize:        parallelize,
 		Locking:            locking,
+		TableSample:        scan.TableSample,
 		EstimatedRowCount:  rowCount,
 		LocalityOptimized:  scan.LocalityOptimized,
 	}, outputMap, nil
@@ -1777,7 +1778,20 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			agg = aggDistinct.Input
 		}
 
-		name, overload := memo.FindAggregateOverload(agg)
+		var name string
+		var distsqlBlocklist bool
+		var userDefined *exec.UserDefinedAggInfo
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			name = uda.Def.Name
+			userDefined, err = b.buildUserDefinedAggInfo(uda.Def)
+			if err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+		} else {
+			var overload *tree.Overload
+			name, overload = memo.FindAggregateOverload(agg)
+			distsqlBlocklist = overload.DistsqlBlocklist
+		}
 
 		// Accumulate variable arguments in argCols and constant arguments in
 		// constArgs. Constant arguments must follow variable arguments.
@@ -1811,7 +1825,8 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			ArgCols:          argCols[:len(argCols):len(argCols)],
 			ConstArgs:        constArgs[:len(constArgs):len(constArgs)],
 			Filter:           filterOrd,
-			DistsqlBlocklist: overload.DistsqlBlocklist,
+			DistsqlBlocklist: distsqlBlocklist,
+			UserDefined:      userDefined,
 		}
 		outputCols.Set(item.Col, len(groupingColIdx)+i)
 		// Slice argCols and constArgs so the rest of their capacity can be
@@ -1860,6 +1875,48 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 	return ep, outputCols, nil
 }
 
+// buildUserDefinedAggInfo builds the expressions that are used to evaluate the
+// given user-defined aggregate. The state of the aggregate is @1, followed by
+// its arguments. In the combine expression, the partial state that is merged
+// into the state is @2.
+func (b *Builder) buildUserDefinedAggInfo(
+	def *memo.UserDefinedAggDefinition,
+) (_ *exec.UserDefinedAggInfo, err error) {
+	cols := b.colOrdsAlloc.Alloc()
+	cols.Set(def.StateCol, 0)
+	for i, col := range def.ArgCols {
+		cols.Set(col, i+1)
+	}
+	ctx := makeBuildScalarCtx(cols)
+	info := &exec.UserDefinedAggInfo{
+		StateType:        def.StateType,
+		ResultType:       def.Typ,
+		TransitionStrict: def.TransitionStrict,
+		CombineStrict:    def.CombineStrict,
+	}
+	if info.InitialState, err = b.buildScalar(&ctx, def.InitialState); err != nil {
+		return nil, err
+	}
+	if info.Transition, err = b.buildScalar(&ctx, def.Transition); err != nil {
+		return nil, err
+	}
+	if def.Final != nil {
+		if info.Final, err = b.buildScalar(&ctx, def.Final); err != nil {
+			return nil, err
+		}
+	}
+	if def.Combine != nil {
+		combineCols := b.colOrdsAlloc.Alloc()
+		combineCols.Set(def.StateCol, 0)
+		combineCols.Set(def.OtherStateCol, 1)
+		combineCtx := makeBuildScalarCtx(combineCols)
+		if info.Combine, err = b.buildScalar(&combineCtx, def.Combine); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
 func (b *Builder) buildDistinct(
 	distinct memo.RelExpr,
 ) (_ execPlan, outputCols colOrdMap, err error) {
@@ -3751,15 +3808,36 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 	filterIdxs := make([]int, len(w.Windows))
 	exprs := make([]*tree.FuncExpr, len(w.Windows))
 	windowVals := make([]tree.WindowDef, len(w.Windows))
+	var userDefined []*exec.UserDefinedAggInfo
 
 	for i := range w.Windows {
 		item := &w.Windows[i]
 		fn := b.extractWindowFunction(item.Function)
-		name, overload := memo.FindWindowOverload(fn)
-		if !b.disableTelemetry {
-			telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+		var name string
+		var overload *tree.Overload
+		var props *tree.FunctionProperties
+		var typ *types.T
+		var err error
+		if uda, ok := fn.(*memo.UserDefinedAggExpr); ok {
+			// A user-defined aggregate is evaluated over the window frame by
+			// running its transition function, so it has no builtin properties.
+			if userDefined == nil {
+				userDefined = make([]*exec.UserDefinedAggInfo, len(w.Windows))
+			}
+			userDefined[i], err = b.buildUserDefinedAggInfo(uda.Def)
+			if err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+			name, overload = uda.Def.Name, uda.Def.Overload
+			props, typ = &tree.FunctionProperties{}, uda.Def.Typ
+		} else {
+			name, overload = memo.FindWindowOverload(fn)
+			if !b.disableTelemetry {
+				telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+			}
+			props, _ = builtinsregistry.GetBuiltinProperties(name)
+			typ = overload.FixedReturnType()
 		}
-		props, _ := builtinsregistry.GetBuiltinProperties(name)
 
 		args := make([]tree.TypedExpr, fn.ChildCount())
 		argIdxs[i] = make([]exec.NodeColumnOrdinal, fn.ChildCount())
@@ -3802,7 +3880,12 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 			OrderBy:    orderingExprs,
 			Frame:      frame,
 		}
-		wrappedFn, err := b.wrapBuiltinFunction(name)
+		var wrappedFn tree.ResolvableFunctionReference
+		if userDefined != nil && userDefined[i] != nil {
+			wrappedFn, err = b.wrapFunction(name)
+		} else {
+			wrappedFn, err = b.wrapBuiltinFunction(name)
+		}
 		if err != nil {
 			return execPlan{}, colOrdMap{}, err
 		}
@@ -3812,7 +3895,7 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 			args,
 			builtFilter,
 			&windowVals[i],
-			overload.FixedReturnType(),
+			typ,
 			props,
 			overload,
 		)
@@ -3851,13 +3934,14 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 	}
 	var ep execPlan
 	ep.root, err = b.factory.ConstructWindow(input.root, exec.WindowInfo{
-		Cols:       resultCols,
-		Exprs:      exprs,
-		OutputIdxs: outputIdxs,
-		ArgIdxs:    argIdxs,
-		FilterIdxs: filterIdxs,
-		Partition:  partitionIdxs,
-		Ordering:   sqlOrdering,
+		Cols:        resultCols,
+		Exprs:       exprs,
+		OutputIdxs:  outputIdxs,
+		ArgIdxs:     argIdxs,
+		FilterIdxs:  filterIdxs,
+		UserDefined: userDefined,
+		Partition:   partitionIdxs,
+		Ordering:    sqlOrdering,
 	})
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
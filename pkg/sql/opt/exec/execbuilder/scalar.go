@@ -985,14 +985,16 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	}
 
 	// Create a tree.RoutinePlanFn that can plan the statements in the UDF body.
+	// The body can reference outer With bindings, e.g. the transition relations
+	// of a trigger function.
 	// TODO(mgartner): Add support for WITH expressions inside UDF bodies.
 	planGen := b.buildRoutinePlanGenerator(
 		udf.Def.Params,
 		udf.Def.Body,
 		udf.Def.BodyProps,
 		udf.Def.BodyStmts,
-		false, /* allowOuterWithRefs */
-		nil,   /* wrapRootExpr */
+		true, /* allowOuterWithRefs */
+		nil,  /* wrapRootExpr */
 	)
 
 	// Enable stepping for volatile functions so that statements within the UDF
@@ -1056,8 +1058,8 @@ func (b *Builder) initRoutineExceptionHandler(
 			action.Body,
 			action.BodyProps,
 			action.BodyStmts,
-			false, /* allowOuterWithRefs */
-			nil,   /* wrapRootExpr */
+			true, /* allowOuterWithRefs */
+			nil,  /* wrapRootExpr */
 		)
 		// Build a routine with no arguments for the exception handler. The actual
 		// arguments will be supplied when (if) the handler is invoked.
@@ -1172,11 +1174,13 @@ func (b *Builder) buildRoutinePlanGenerator(
 						return f.ConstructConstVal(args[ord], t.Typ)
 					}
 
-				case *memo.WithScanExpr:
+				case *memo.WithScanExpr, *memo.UDFCallExpr:
 					// Allow referring to "outer" With expressions, if
 					// allowOuterWithRefs is true. The bound expressions are not
 					// part of this Memo, but they are used only for their
-					// relational properties, which should be valid.
+					// relational properties, which should be valid. Nested routines
+					// may also refer to the outer With expressions, so they must be
+					// available when the nested routines are planned.
 					//
 					// We must add all With expressions to the metadata even if they
 					// aren't referred to directly because they might be referred to
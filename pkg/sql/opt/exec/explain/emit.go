@@ -411,6 +411,7 @@ var nodeNames = [...]string{
 	limitOp:                "limit",
 	lookupJoinOp:           "", // This node does not have a fixed name.
 	max1RowOp:              "max1row",
+	mergeOp:                "merge",
 	mergeJoinOp:            "", // This node does not have a fixed name.
 	opaqueOp:               "", // This node does not have a fixed name.
 	ordinalityOp:           "ordinality",
@@ -709,6 +710,9 @@ func (e *emitter) emitNodeAttributes(ctx context.Context, evalCtx *eval.Context,
 			ob.Attr("limit", "")
 		}
 
+		if a.Params.TableSample.IsSet() {
+			ob.Attr("table sample", a.Params.TableSample.String())
+		}
 		if a.Params.Parallelize {
 			ob.VAttr("parallel", "")
 		}
@@ -1083,6 +1087,21 @@ func (e *emitter) emitNodeAttributes(ctx context.Context, evalCtx *eval.Context,
 		}
 		e.emitPolicies(ob, a.Table, n)
 
+	case mergeOp:
+		a := n.args.(*mergeArgs)
+		ob.Attrf(
+			"into", "%s(%s)",
+			a.Table.Name(),
+			printColumns(tableColumns(a.Table, a.InsertCols)),
+		)
+		ob.Attr("set", printColumns(tableColumns(a.Table, a.UpdateCols)))
+		if a.AutoCommit {
+			ob.Attr("auto commit", "")
+		}
+		if uniqWithTombstoneIndexes := joinIndexNames(a.Table, a.UniqueWithTombstonesIndexes, ", "); uniqWithTombstoneIndexes != "" {
+			ob.Attr("uniqueness checks (tombstones)", uniqWithTombstoneIndexes)
+		}
+
 	case updateOp:
 		a := n.args.(*updateArgs)
 		ob.Attrf("table", "%s", a.Table.Name())
@@ -193,6 +193,10 @@ func getResultColumns(
 		a := args.(*upsertArgs)
 		return tableColumns(a.Table, a.ReturnCols), nil
 
+	case mergeOp:
+		a := args.(*mergeArgs)
+		return tableColumns(a.Table, a.ReturnCols), nil
+
 	case deleteOp:
 		a := args.(*deleteArgs)
 		return appendColumns(
@@ -136,6 +136,10 @@ type ScanParams struct {
 	// Row-level locking properties.
 	Locking opt.Locking
 
+	// TableSample, if set, indicates that the scan only returns a sample of the
+	// rows of the table, as requested by a TABLESAMPLE clause.
+	TableSample opt.TableSample
+
 	// EstimatedRowCount, if set, is the estimated number of rows that will be
 	// scanned, rounded up.
 	EstimatedRowCount uint64
@@ -234,6 +238,48 @@ type AggInfo struct {
 	// DistsqlBlocklist is set to true when this aggregate function cannot be
 	// evaluated in distributed fashion.
 	DistsqlBlocklist bool
+
+	// UserDefined is set if this is a user-defined aggregate, in which case
+	// FuncName is only used for display.
+	UserDefined *UserDefinedAggInfo
+}
+
+// UserDefinedAggInfo describes how to evaluate a user-defined aggregate. The
+// aggregate has a single argument column holding a tuple of the arguments to
+// the aggregate.
+//
+// InitialState, Transition and Final refer to the aggregate state as @1 and to
+// the (unpacked) arguments as @2, @3, etc. Combine refers to the state of the
+// aggregate as @1 and to a partial state that should be merged into it as @2.
+type UserDefinedAggInfo struct {
+	// StateType is the type of the aggregate state.
+	StateType *types.T
+
+	// ResultType is the type of the result of the aggregate.
+	ResultType *types.T
+
+	// InitialState is the initial value of the state. It is evaluated once for
+	// each group.
+	InitialState tree.TypedExpr
+
+	// Transition computes the next state from the current state and a row.
+	Transition tree.TypedExpr
+
+	// TransitionStrict is true if Transition is not called when any of its
+	// inputs are NULL.
+	TransitionStrict bool
+
+	// Combine merges two partial states, if the aggregate has a COMBINEFUNC.
+	// It is nil otherwise, and the aggregate cannot be distributed.
+	Combine tree.TypedExpr
+
+	// CombineStrict is true if Combine is not called when either state is
+	// NULL.
+	CombineStrict bool
+
+	// Final computes the result of the aggregate from its state. It is nil if
+	// the state is the result.
+	Final tree.TypedExpr
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -258,6 +304,10 @@ type WindowInfo struct {
 	// FilterIdxs is the list of column indices to use as filters.
 	FilterIdxs []int
 
+	// UserDefined is the list of user-defined aggregates, in the same order as
+	// Exprs. Entries for other window functions are nil.
+	UserDefined []*UserDefinedAggInfo
+
 	// Partition is the set of input columns to partition on.
 	Partition []NodeColumnOrdinal
 
@@ -308,6 +358,11 @@ type PostQuery struct {
 	// the mutation. It is nil if the cascade does not require a buffer.
 	Buffer Node
 
+	// RunOnEmptyBuffer is true if the post-query must run even if the buffer is
+	// empty. It is set for statement-level AFTER triggers, which fire even if
+	// the mutation did not modify any rows.
+	RunOnEmptyBuffer bool
+
 	// PlanFn builds the cascade/trigger query and creates the plan for it.
 	// Note that the generated Plan can in turn contain more cascades, triggers,
 	// and checks.
@@ -382,6 +437,35 @@ type InsertFastPathCheck struct {
 // relevant row.
 type MkErrFn func(tree.Datums) error
 
+// DeferrableCheckError is the error generated by the MkErrFn of the check of a
+// deferrable constraint. The execution engine does not return it as is: if the
+// check of the constraint is currently deferred, the violation is ignored and
+// the constraint is validated again at the end of the transaction. Otherwise,
+// Err is returned.
+type DeferrableCheckError struct {
+	// TableID is the ID of the table the constraint belongs to. For foreign
+	// keys, it is the origin (referencing) table.
+	TableID cat.StableID
+	// Constraint is the name of the constraint.
+	Constraint string
+	// InitiallyDeferred is true if the check of the constraint is deferred
+	// unless SET CONSTRAINTS says otherwise.
+	InitiallyDeferred bool
+	// KeyVals are the values of the constraint columns of the violating row,
+	// in the order of the columns of the constraint (for foreign keys, of the
+	// origin columns). They are nil if they are not known, in which case the
+	// whole constraint is validated at the end of the transaction.
+	KeyVals tree.Datums
+	// Err is the violation error returned when the check is not deferred.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *DeferrableCheckError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the violation error.
+func (e *DeferrableCheckError) Unwrap() error { return e.Err }
+
 // ExplainFactory is an extension of Factory used when constructing a plan that
 // can be explained. It allows annotation of nodes with extra information.
 type ExplainFactory interface {
@@ -142,6 +142,13 @@ func TryFilterInvertedIndex(
 				index:           index,
 				computedColumns: computedColumns,
 			}
+		case types.RangeFamily, types.MultirangeFamily:
+			filterPlanner = &rangeFilterPlanner{
+				tabID:           tabID,
+				index:           index,
+				computedColumns: computedColumns,
+				typ:             typ,
+			}
 		default:
 			return nil, nil, nil, nil, false
 		}
@@ -40,7 +40,7 @@ func (j *jsonOrArrayJoinPlanner) extractInvertedJoinConditionFromLeaf(
 	ctx context.Context, expr opt.ScalarExpr,
 ) opt.ScalarExpr {
 	switch t := expr.(type) {
-	case *memo.ContainsExpr, *memo.ContainedByExpr:
+	case *memo.ContainsExpr, *memo.ContainedByExpr, *memo.OverlapsExpr:
 		return j.extractJSONOrArrayJoinCondition(t)
 	default:
 		return nil
@@ -55,7 +55,7 @@ func (j *jsonOrArrayJoinPlanner) extractJSONOrArrayJoinCondition(
 	expr opt.ScalarExpr,
 ) opt.ScalarExpr {
 	var left, right, indexCol, val opt.ScalarExpr
-	commuteArgs, containedBy := false, false
+	commuteArgs, containedBy, overlaps := false, false, false
 	switch t := expr.(type) {
 	case *memo.ContainsExpr:
 		left = t.Left
@@ -64,6 +64,10 @@ func (j *jsonOrArrayJoinPlanner) extractJSONOrArrayJoinCondition(
 		left = t.Left
 		right = t.Right
 		containedBy = true
+	case *memo.OverlapsExpr:
+		left = t.Left
+		right = t.Right
+		overlaps = true
 	default:
 		return nil
 	}
@@ -107,6 +111,10 @@ func (j *jsonOrArrayJoinPlanner) extractJSONOrArrayJoinCondition(
 	// If commuteArgs is true, we construct a new equivalent expression so that
 	// the left argument is the indexed column.
 	if commuteArgs {
+		if overlaps {
+			// && is commutative.
+			return j.factory.ConstructOverlaps(right, left)
+		}
 		if containedBy {
 			return j.factory.ConstructContains(right, left)
 		}
@@ -311,6 +319,9 @@ func (g *jsonOrArrayDatumsToInvertedExpr) Convert(
 			case treecmp.ContainedBy:
 				return getInvertedExprForJSONOrArrayIndexForContainedBy(ctx, g.evalCtx, d), nil
 
+			case treecmp.Overlaps:
+				return getInvertedExprForArrayIndexForOverlaps(ctx, g.evalCtx, d), nil
+
 			default:
 				return nil, fmt.Errorf("unsupported expression %v", t)
 			}
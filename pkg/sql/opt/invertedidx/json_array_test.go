@@ -90,6 +90,17 @@ func TestTryJoinJsonOrArrayIndex(t *testing.T) {
 			indexOrd:     arrayOrd,
 			invertedExpr: "array2 @> array1",
 		},
+		{
+			// Indexed column can be on either side of &&.
+			filters:      "array1 && array2",
+			indexOrd:     arrayOrd,
+			invertedExpr: "array2 && array1",
+		},
+		{
+			filters:      "array2 && array1",
+			indexOrd:     arrayOrd,
+			invertedExpr: "array2 && array1",
+		},
 		{
 			// Wrong index ordinal.
 			filters:      "json2 @> json1",
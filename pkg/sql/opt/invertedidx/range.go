@@ -0,0 +1,119 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package invertedidx
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+type rangeFilterPlanner struct {
+	tabID           opt.TableID
+	index           cat.Index
+	computedColumns map[opt.ColumnID]opt.ScalarExpr
+	// typ is the range or multirange type of the indexed column.
+	typ *types.T
+}
+
+var _ invertedFilterPlanner = &rangeFilterPlanner{}
+
+// extractInvertedFilterConditionFromLeaf implements the invertedFilterPlanner
+// interface.
+//
+// An inverted index on a range or multirange column can accelerate overlaps
+// (&&) predicates, as well as contains (@>) predicates where the indexed
+// column is the container, since a range can only contain a non-empty range
+// or value that it overlaps.
+func (r *rangeFilterPlanner) extractInvertedFilterConditionFromLeaf(
+	ctx context.Context, evalCtx *eval.Context, expr opt.ScalarExpr,
+) (
+	invertedExpr inverted.Expression,
+	remainingFilters opt.ScalarExpr,
+	_ *invertedexpr.PreFiltererStateForInvertedFilterer,
+) {
+	var constantVal opt.ScalarExpr
+	switch e := expr.(type) {
+	case *memo.OverlapsExpr:
+		if isIndexColumn(r.tabID, r.index, e.Left, r.computedColumns) && memo.CanExtractConstDatum(e.Right) {
+			constantVal = e.Right
+		} else if isIndexColumn(r.tabID, r.index, e.Right, r.computedColumns) && memo.CanExtractConstDatum(e.Left) {
+			constantVal = e.Left
+		}
+	case *memo.ContainsExpr:
+		if isIndexColumn(r.tabID, r.index, e.Left, r.computedColumns) && memo.CanExtractConstDatum(e.Right) {
+			constantVal = e.Right
+		}
+	case *memo.ContainedByExpr:
+		if isIndexColumn(r.tabID, r.index, e.Right, r.computedColumns) && memo.CanExtractConstDatum(e.Left) {
+			constantVal = e.Left
+		}
+	}
+	if constantVal == nil {
+		// Can only accelerate with a single constant value.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	d := memo.ExtractConstDatum(constantVal)
+	switch t := d.(type) {
+	case *tree.DRange, *tree.DMultirange:
+		if _, ok := expr.(*memo.OverlapsExpr); !ok && rangeConstIsEmpty(t) {
+			// Every range contains the empty range, so the index cannot be
+			// used to constrain the scan.
+			return inverted.NonInvertedColExpression{}, expr, nil
+		}
+	default:
+		if d == tree.DNull {
+			return inverted.NonInvertedColExpression{}, expr, nil
+		}
+		// A range contains a value if it overlaps the range that contains only
+		// that value.
+		typ := r.typ
+		if typ.Family() == types.MultirangeFamily {
+			typ = typ.MultirangeContents()
+		}
+		bound := tree.RangeBound{Val: d, Inclusive: true}
+		rng, err := tree.NewDRange(typ, bound, bound)
+		if err != nil {
+			return inverted.NonInvertedColExpression{}, expr, nil
+		}
+		d = rng
+	}
+
+	invertedExpr, err := rowenc.EncodeOverlapsInvertedIndexSpans(ctx, evalCtx, d)
+	if err != nil {
+		panic(err)
+	}
+
+	// The extracted inverted expression is never tight, so the original filter
+	// must be applied after the inverted index scan.
+	if !invertedExpr.IsTight() {
+		remainingFilters = expr
+	}
+
+	// We do not currently support pre-filtering for range indexes, so the
+	// returned pre-filter state is nil.
+	return invertedExpr, remainingFilters, nil
+}
+
+// rangeConstIsEmpty returns true if the given range or multirange is empty.
+func rangeConstIsEmpty(d tree.Datum) bool {
+	switch t := d.(type) {
+	case *tree.DRange:
+		return t.Empty
+	case *tree.DMultirange:
+		return t.IsEmpty()
+	}
+	return false
+}
@@ -763,6 +763,69 @@ type UDFDefinition struct {
 	CursorDeclaration *tree.RoutineOpenCursor
 }
 
+// UserDefinedAggDefinition stores the details of a user-defined aggregate
+// needed to evaluate it. The state and result of the aggregate are computed
+// by scalar expressions over synthesized columns that stand in for the state
+// and the arguments of the aggregate. These columns are only bound during
+// execution of the aggregate, and do not appear anywhere else in the query.
+type UserDefinedAggDefinition struct {
+	// Name is the name of the aggregate.
+	Name string
+
+	// Typ is the result type of the aggregate.
+	Typ *types.T
+
+	// Overload is the overload of the aggregate being called.
+	Overload *tree.Overload
+
+	// StateType is the type of the state of the aggregate.
+	StateType *types.T
+
+	// Volatility is the maximum volatility of the support functions of the
+	// aggregate.
+	Volatility volatility.V
+
+	// StateCol is the column that holds the current state in Transition,
+	// Combine and Final.
+	StateCol opt.ColumnID
+
+	// ArgCols are the columns that hold the arguments of the aggregate in
+	// Transition.
+	ArgCols opt.ColList
+
+	// OtherStateCol is the column that holds the partial state that is
+	// combined with the current state in Combine.
+	OtherStateCol opt.ColumnID
+
+	// InitialState is the constant initial state of the aggregate.
+	InitialState opt.ScalarExpr
+
+	// Transition computes the next state from StateCol and ArgCols.
+	Transition opt.ScalarExpr
+
+	// TransitionStrict is true if the transition function is strict. Rows with
+	// a NULL argument are then skipped, and the first argument of the first
+	// remaining row becomes the state if the initial state is NULL.
+	TransitionStrict bool
+
+	// Combine, if set, computes the state that results from combining the
+	// partial states StateCol and OtherStateCol. The aggregate can only be
+	// evaluated in multiple stages if it is set.
+	Combine opt.ScalarExpr
+
+	// CombineStrict is true if the combine function is strict. It has the same
+	// meaning for Combine as TransitionStrict has for Transition.
+	CombineStrict bool
+
+	// Final, if set, computes the result of the aggregate from StateCol.
+	// Otherwise, the result is the final state.
+	Final opt.ScalarExpr
+
+	// Empty is the result of the aggregate if there are no input rows. It does
+	// not reference any of the synthesized columns.
+	Empty opt.ScalarExpr
+}
+
 // ExceptionBlock contains the information needed to match and handle errors in
 // the EXCEPTION block of a routine defined with PLpgSQL.
 type ExceptionBlock struct {
@@ -829,14 +892,16 @@ func (f *WindowFrame) String() string {
 }
 
 // IsCanonical returns true if the ScanPrivate indicates an original unaltered
-// primary index Scan operator (i.e. unconstrained and not limited).
+// primary index Scan operator (i.e. unconstrained, not limited and not
+// sampled).
 // s.InvertedConstraint is implicitly nil because a primary index cannot
 // be inverted.
 func (s *ScanPrivate) IsCanonical() bool {
 	return s.Index == cat.PrimaryIndex &&
 		s.Constraint == nil &&
 		s.HardLimit == 0 &&
-		!s.LocalityOptimized
+		!s.LocalityOptimized &&
+		!s.TableSample.IsSet()
 }
 
 // IsUnfiltered returns true if the ScanPrivate will produce all rows in the
@@ -846,7 +911,8 @@ func (s *ScanPrivate) IsUnfiltered(md *opt.Metadata) bool {
 		s.InvertedConstraint == nil &&
 		s.HardLimit == 0 &&
 		s.PartialIndexPredicate(md) == nil &&
-		s.Locking.WaitPolicy != tree.LockWaitSkipLocked
+		s.Locking.WaitPolicy != tree.LockWaitSkipLocked &&
+		!s.TableSample.IsSet()
 }
 
 // IsFullIndexScan returns true if the ScanPrivate will produce all rows in the
@@ -1357,14 +1423,26 @@ type FKCascade struct {
 // AfterTriggers stores metadata necessary for building a set of AFTER triggers.
 // AFTER triggers are built as needed, after the original query is executed.
 type AfterTriggers struct {
+	// Triggers are the row-level AFTER triggers, which fire once for each
+	// modified row.
 	Triggers []cat.Trigger
 
-	// Builder is an object that can be used as the "optbuilder" for the cascading
-	// query.
+	// Builder is an object that can be used as the "optbuilder" for the
+	// row-level triggers. It is nil if there are no row-level triggers.
 	Builder PostQueryBuilder
 
+	// StatementTriggers are the statement-level AFTER triggers. They fire once
+	// after the row-level triggers, even if no rows were modified.
+	StatementTriggers []cat.Trigger
+
+	// StatementBuilder is an object that can be used as the "optbuilder" for the
+	// statement-level triggers. It is nil if there are no statement-level
+	// triggers.
+	StatementBuilder PostQueryBuilder
+
 	// WithID identifies the buffer for the mutation input in the original
-	// expression tree. It is always nonzero.
+	// expression tree. It is 0 if none of the triggers require the buffered
+	// input.
 	WithID opt.WithID
 }
 
@@ -545,6 +545,9 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			tp.Child(b.String())
 		}
 		f.formatLocking(tp, private.Locking)
+		if private.TableSample.IsSet() {
+			tp.Childf("table sample: %s", private.TableSample)
+		}
 
 	case *InvertedFilterExpr:
 		var b strings.Builder
@@ -688,6 +691,9 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			}
 			if t.CanaryCol != 0 {
 				f.formatRelColList(e, tp, "canary column:", opt.ColList{t.CanaryCol})
+				if t.MergeDeleteCol != 0 {
+					f.formatRelColList(e, tp, "merge delete column:", opt.ColList{t.MergeDeleteCol})
+				}
 				f.formatOptionalColList(e, tp, "fetch columns:", t.FetchCols)
 				f.formatMutationCols(e, tp, "insert-mapping:", t.InsertCols, t.Table)
 				f.formatMutationCols(e, tp, "update-mapping:", t.UpdateCols, t.Table)
@@ -1463,6 +1469,9 @@ func (f *ExprFmtCtx) formatScalarPrivate(scalar opt.ScalarExpr) {
 	case *UDFCallExpr:
 		private = nil
 
+	case *UserDefinedAggExpr:
+		fmt.Fprintf(f.Buffer, " %s", t.Def.Name)
+
 	default:
 		private = scalar.Private()
 	}
@@ -1667,6 +1676,9 @@ func (f *ExprFmtCtx) formatMutationCommon(tp treeprinter.Node, p *MutationPrivat
 		for i := range p.AfterTriggers.Triggers {
 			c.Child(p.AfterTriggers.Triggers[i].Name().Normalize())
 		}
+		for i := range p.AfterTriggers.StatementTriggers {
+			c.Child(p.AfterTriggers.StatementTriggers[i].Name().Normalize())
+		}
 	}
 }
 
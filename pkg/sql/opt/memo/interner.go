@@ -537,7 +537,12 @@ func (h *hasher) HashFKCascades(val FKCascades) {
 
 func (h *hasher) HashAfterTriggers(val *AfterTriggers) {
 	if val != nil {
-		h.HashUint64(uint64(reflect.ValueOf(val.Builder).Pointer()))
+		if val.Builder != nil {
+			h.HashUint64(uint64(reflect.ValueOf(val.Builder).Pointer()))
+		}
+		if val.StatementBuilder != nil {
+			h.HashUint64(uint64(reflect.ValueOf(val.StatementBuilder).Pointer()))
+		}
 	}
 }
 
@@ -658,6 +663,13 @@ func (h *hasher) HashLocking(val opt.Locking) {
 	h.HashByte(byte(val.WaitPolicy))
 }
 
+func (h *hasher) HashTableSample(val opt.TableSample) {
+	h.HashByte(byte(val.Method))
+	h.HashFloat64(val.Fraction)
+	h.HashBool(val.Repeatable)
+	h.HashInt64(val.Seed)
+}
+
 func (h *hasher) HashInvertedSpans(val inverted.Spans) {
 	for i := range val {
 		span := &val[i]
@@ -790,6 +802,10 @@ func (h *hasher) HashUDFDefinition(val *UDFDefinition) {
 	h.HashUint64(uint64(reflect.ValueOf(val).Pointer()))
 }
 
+func (h *hasher) HashUserDefinedAggDefinition(val *UserDefinedAggDefinition) {
+	h.HashUint64(uint64(reflect.ValueOf(val).Pointer()))
+}
+
 func (h *hasher) HashStoredProcTxnOp(val tree.StoredProcTxnOp) {
 	h.HashUint64(uint64(val))
 }
@@ -1030,7 +1046,7 @@ func (h *hasher) IsAfterTriggersEqual(l, r *AfterTriggers) bool {
 		return false
 	}
 	// It's sufficient to compare the TriggerBuilder instances.
-	return l.Builder == r.Builder
+	return l.Builder == r.Builder && l.StatementBuilder == r.StatementBuilder
 }
 
 func (h *hasher) IsExplainOptionsEqual(l, r tree.ExplainOptions) bool {
@@ -1123,6 +1139,10 @@ func (h *hasher) IsLockingEqual(l, r opt.Locking) bool {
 	return l == r
 }
 
+func (h *hasher) IsTableSampleEqual(l, r opt.TableSample) bool {
+	return l == r
+}
+
 func (h *hasher) IsInvertedSpansEqual(l, r inverted.Spans) bool {
 	return l.Equals(r)
 }
@@ -1316,6 +1336,10 @@ func (h *hasher) IsLiteralRowsEqual(l, r *opt.LiteralRows) bool {
 	return l == r
 }
 
+func (h *hasher) IsUserDefinedAggDefinitionEqual(l, r *UserDefinedAggDefinition) bool {
+	return l == r
+}
+
 func (h *hasher) IsUDFDefinitionEqual(l, r *UDFDefinition) bool {
 	if len(l.Body) != len(r.Body) {
 		return false
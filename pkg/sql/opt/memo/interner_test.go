@@ -599,6 +599,30 @@ func TestInterner(t *testing.T) {
 			},
 		}},
 
+		{hashFn: in.hasher.HashTableSample, eqFn: in.hasher.IsTableSampleEqual, variations: []testVariation{
+			{val1: opt.TableSample{}, val2: opt.TableSample{}, equal: true},
+			{
+				val1:  opt.TableSample{},
+				val2:  opt.TableSample{Method: tree.TableSampleBernoulli, Fraction: 0.1},
+				equal: false,
+			},
+			{
+				val1:  opt.TableSample{Method: tree.TableSampleBernoulli, Fraction: 0.1},
+				val2:  opt.TableSample{Method: tree.TableSampleSystem, Fraction: 0.1},
+				equal: false,
+			},
+			{
+				val1:  opt.TableSample{Method: tree.TableSampleSystem, Fraction: 0.1, Seed: 1, Repeatable: true},
+				val2:  opt.TableSample{Method: tree.TableSampleSystem, Fraction: 0.1, Seed: 2, Repeatable: true},
+				equal: false,
+			},
+			{
+				val1:  opt.TableSample{Method: tree.TableSampleSystem, Fraction: 0.1, Seed: 1, Repeatable: true},
+				val2:  opt.TableSample{Method: tree.TableSampleSystem, Fraction: 0.1, Seed: 1, Repeatable: true},
+				equal: true,
+			},
+		}},
+
 		{hashFn: in.hasher.HashFastPathUniqueChecksExpr, eqFn: in.hasher.IsFastPathUniqueChecksExprEqual, variations: []testVariation{
 			{
 				val1:  FastPathUniqueChecksExpr{FastPathUniqueChecksItem{Check: scanNode}},
@@ -857,6 +857,11 @@ func (sb *statisticsBuilder) buildScan(scan *ScanExpr, relProps *props.Relationa
 	// scan on a non-partial index. The stats of the scan are the same as the
 	// underlying table stats.
 	if scan.Constraint == nil && scan.InvertedConstraint == nil && pred == nil {
+		if scan.TableSample.IsSet() {
+			// A sampled scan returns the sampled fraction of the rows of the
+			// table, on average.
+			s.ApplySelectivity(props.MakeSelectivity(scan.TableSample.Fraction))
+		}
 		sb.finalizeFromCardinality(relProps)
 		return
 	}
@@ -76,6 +76,9 @@ func (c *CustomFuncs) neededMutationCols(
 	if private.CanaryCol != 0 {
 		cols.Add(private.CanaryCol)
 	}
+	if private.MergeDeleteCol != 0 {
+		cols.Add(private.MergeDeleteCol)
+	}
 	cols.UnionWith(private.TriggerCols)
 
 	if private.WithID != 0 {
@@ -137,9 +140,12 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 	//      no corresponding UPDATE column. In that case, either the INSERT or
 	//      FETCH column becomes the RETURN column, so both must be available
 	//      for the CASE expression.
+	//   4. For an Upsert built for a MERGE, the FETCH column is always needed,
+	//      since it is returned for deleted rows.
 	for ord, col := range private.ReturnCols {
 		if col != 0 {
-			if op == opt.DeleteOp || len(private.UpdateCols) == 0 || private.UpdateCols[ord] == 0 {
+			if op == opt.DeleteOp || private.MergeDeleteCol != 0 ||
+				len(private.UpdateCols) == 0 || private.UpdateCols[ord] == 0 {
 				cols.Add(tabMeta.MetaID.ColumnID(ord))
 			}
 		}
@@ -221,8 +227,11 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 				cols.UnionWith(fkCols)
 			}
 		}
+	}
 
-	case opt.DeleteOp:
+	// An Upsert built for a MERGE can also delete rows, so it needs the same
+	// columns as a Delete.
+	if op == opt.DeleteOp || private.MergeDeleteCol != 0 {
 		// Add in all strict key columns from all indexes, since these are needed
 		// to compose the keys of rows to delete. Include mutation indexes, since
 		// it is necessary to delete rows even from indexes that are being added
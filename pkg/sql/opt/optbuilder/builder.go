@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/delegate"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/optgen/exprgen"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -87,6 +88,10 @@ type Builder struct {
 	// statements.
 	DisableMemoReuse bool
 
+	// HasEventTriggers is set to true if the statement is a DDL command that
+	// fires event triggers.
+	HasEventTriggers bool
+
 	factory *norm.Factory
 	stmt    tree.Statement
 
@@ -157,6 +162,11 @@ type Builder struct {
 	// CALL statement.
 	insideNestedPLpgSQLCall bool
 
+	// plpgsqlRecordField, if set, is used to resolve a reference to a field of a
+	// PL/pgSQL RECORD variable while building the body of a PL/pgSQL routine. It
+	// returns nil if the given expression does not reference a RECORD variable.
+	plpgsqlRecordField func(s *scope, col *scopeColumn, field tree.Name) tree.Expr
+
 	// If set, we are collecting view dependencies in schemaDeps. This can only
 	// happen inside view/function definitions.
 	//
@@ -201,6 +211,12 @@ type Builder struct {
 	// built, and can be safely reused across different call-sites within the same
 	// memo.
 	builtTriggerFuncs map[cat.StableID][]cachedTriggerFunc
+
+	// builtUserDefinedAggs caches the definitions of the user-defined aggregates
+	// referenced by the query, keyed by the OID of the aggregate. Like
+	// UDFDefinitions, the definitions are independent of the context in which
+	// they are built.
+	builtUserDefinedAggs map[oid.Oid]*memo.UserDefinedAggDefinition
 }
 
 // New creates a new Builder structure initialized with the given
@@ -279,6 +295,7 @@ func (b *Builder) Build() (err error) {
 	// Build the memo, and call SetRoot on the memo to indicate the root group
 	// and physical properties.
 	outScope := b.buildStmtAtRoot(b.stmt, nil /* desiredTypes */)
+	b.buildEventTriggers(b.stmt, outScope)
 
 	physical := outScope.makePhysicalProps()
 	b.factory.Memo().SetRoot(outScope.expr, physical)
@@ -348,7 +365,7 @@ func (b *Builder) buildStmt(
 	if b.insideViewDef {
 		// A blocklist of statements that can't be used from inside a view.
 		switch stmt := stmt.(type) {
-		case *tree.Delete, *tree.Insert, *tree.Update, *tree.CreateTable, *tree.CreateView,
+		case *tree.Delete, *tree.Insert, *tree.Update, *tree.Merge, *tree.CreateTable, *tree.CreateView,
 			*tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
 			*tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions,
 			*tree.CreateRoutine:
@@ -400,6 +417,11 @@ func (b *Builder) buildStmt(
 			return b.buildUpdate(stmt, inScope)
 		})
 
+	case *tree.Merge:
+		return b.processWiths(stmt.With, inScope, func(inScope *scope) *scope {
+			return b.buildMerge(stmt, inScope)
+		})
+
 	case *tree.CreateTable:
 		return b.buildCreateTable(stmt, inScope)
 
@@ -0,0 +1,198 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// buildCreateAggregate validates the attributes of a CREATE AGGREGATE
+// statement and resolves its support functions. It then fills in the return
+// type and the options of cf, so that the aggregate can be created like any
+// other routine. The aggregate has an empty body, since it is evaluated by
+// calling its support functions directly (see buildUserDefinedAggDefinition).
+func (b *Builder) buildCreateAggregate(cf *tree.CreateRoutine) {
+	if !b.evalCtx.Settings.Version.ActiveVersion(b.ctx).IsActive(clusterversion.V25_2_UserDefinedAggregates) {
+		panic(sqlerrors.NewUserDefinedAggregatesNotSupportedError())
+	}
+	agg := cf.Aggregate
+	if agg.SType == nil {
+		panic(pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified"))
+	}
+	if agg.SFunc == nil {
+		panic(pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified"))
+	}
+	argTypes := make([]*types.T, len(cf.Params))
+	for i := range cf.Params {
+		param := &cf.Params[i]
+		if param.IsOutParam() {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"aggregates cannot have output arguments"))
+		}
+		if param.DefaultVal != nil {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"aggregates cannot have default arguments"))
+		}
+		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
+		if err != nil {
+			panic(err)
+		}
+		if typ.IsPolymorphicType() {
+			panic(unimplemented.New("CREATE AGGREGATE polymorphic",
+				"aggregates with polymorphic arguments are not yet supported"))
+		}
+		argTypes[i] = typ
+	}
+	stateType, err := tree.ResolveType(b.ctx, agg.SType, b.semaCtx.TypeResolver)
+	if err != nil {
+		panic(err)
+	}
+	if stateType.IsPolymorphicType() {
+		panic(unimplemented.New("CREATE AGGREGATE polymorphic",
+			"aggregates with a polymorphic state type are not yet supported"))
+	}
+	agg.StateType = stateType
+
+	// Resolve the transition function, which is called with the current state
+	// and the arguments of each row.
+	sfunc := b.resolveAggregateSupportFunction(agg.SFunc, append([]*types.T{stateType}, argTypes...))
+	if typ := overloadReturnType(sfunc); !typ.Equivalent(stateType) {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of transition function %s is not %s", agg.SFunc, stateType.SQLStringForError()))
+	}
+	// A strict transition function starts from the first argument of the first
+	// row if there is no initial condition.
+	if !sfunc.CalledOnNullInput && agg.InitCond == nil &&
+		(len(argTypes) == 0 || !argTypes[0].Equivalent(stateType)) {
+		panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict and "+
+				"transition type is not compatible with input type"))
+	}
+	agg.SFuncOID = sfunc.Oid
+	vol := sfunc.Volatility
+
+	b.addAggregateSupportFunctionDep(sfunc)
+
+	// The result of the aggregate is the final state, unless there is a final
+	// function.
+	returnType := stateType
+	if agg.FinalFunc != nil {
+		ffunc := b.resolveAggregateSupportFunction(agg.FinalFunc, []*types.T{stateType})
+		returnType = overloadReturnType(ffunc)
+		agg.FinalFuncOID = ffunc.Oid
+		vol = max(vol, ffunc.Volatility)
+		b.addAggregateSupportFunctionDep(ffunc)
+	}
+
+	// The combine function merges two partial states, which allows the
+	// aggregate to be evaluated in multiple stages.
+	if agg.CombineFunc != nil {
+		combinefunc := b.resolveAggregateSupportFunction(agg.CombineFunc, []*types.T{stateType, stateType})
+		if typ := overloadReturnType(combinefunc); !typ.Equivalent(stateType) {
+			panic(pgerror.Newf(pgcode.DatatypeMismatch,
+				"return type of combine function %s is not %s", agg.CombineFunc, stateType.SQLStringForError()))
+		}
+		agg.CombineFuncOID = combinefunc.Oid
+		vol = max(vol, combinefunc.Volatility)
+		b.addAggregateSupportFunctionDep(combinefunc)
+	}
+
+	// The initial condition is cast to the state type each time the aggregate
+	// is evaluated, so validate it up front.
+	if agg.InitCond != nil {
+		if _, err := eval.PerformCast(b.ctx, b.evalCtx, tree.NewDString(*agg.InitCond), stateType); err != nil {
+			panic(err)
+		}
+		if v, ok := cast.LookupCastVolatility(types.String, stateType); ok {
+			vol = max(vol, v)
+		}
+	}
+
+	cf.ReturnType = &tree.RoutineReturnType{Type: returnType}
+	cf.Options = tree.RoutineOptions{
+		routineVolatilityFromOverload(vol),
+		tree.RoutineLangSQL,
+		tree.RoutineBodyStr(""),
+	}
+}
+
+// addAggregateSupportFunctionDep records that the aggregate being created
+// depends on the given support function, if it is a user-defined function.
+func (b *Builder) addAggregateSupportFunctionDep(o *tree.Overload) {
+	if o.Type == tree.UDFRoutine {
+		b.schemaFunctionDeps.Add(int(o.Oid))
+	}
+}
+
+// resolveAggregateSupportFunction resolves the support function of a
+// user-defined aggregate with the given name and argument types.
+func (b *Builder) resolveAggregateSupportFunction(
+	name *tree.RoutineName, argTypes []*types.T,
+) *tree.Overload {
+	path := &b.evalCtx.SessionData().SearchPath
+	un := name.ToUnresolvedObjectName().ToUnresolvedName()
+	def, err := b.catalog.ResolveFunction(b.ctx, tree.MakeUnresolvedFunctionName(un), path)
+	if err != nil {
+		panic(err)
+	}
+	obj := tree.RoutineObj{FuncName: *name, Params: make(tree.RoutineParams, len(argTypes))}
+	for i, typ := range argTypes {
+		obj.Params[i] = tree.RoutineParam{Type: typ, Class: tree.RoutineParamIn}
+	}
+	ol, err := def.MatchOverload(
+		b.ctx, b.catalog, &obj, path, tree.UDFRoutine|tree.BuiltinRoutine,
+		false /* inDropContext */, false, /* tryDefaultExprs */
+	)
+	if err != nil {
+		panic(err)
+	}
+	o := ol.Overload
+	if o.UDFContainsOnlySignature {
+		if _, o, err = b.catalog.ResolveFunctionByOID(b.ctx, o.Oid); err != nil {
+			panic(err)
+		}
+	}
+	if o.Class != tree.NormalClass {
+		panic(pgerror.Newf(pgcode.WrongObjectType,
+			"function %s is not a plain function", &obj))
+	}
+	return o
+}
+
+// overloadReturnType returns the return type of a support function of a
+// user-defined aggregate.
+func overloadReturnType(o *tree.Overload) *types.T {
+	args := make([]tree.TypedExpr, 0, len(o.Types.Types()))
+	for _, typ := range o.Types.Types() {
+		args = append(args, tree.NewTypedCastExpr(tree.DNull, typ))
+	}
+	return o.ReturnType(args)
+}
+
+// routineVolatilityFromOverload converts the volatility of an overload to a
+// routine option.
+func routineVolatilityFromOverload(v volatility.V) tree.RoutineVolatility {
+	switch v {
+	case volatility.Leakproof, volatility.Immutable:
+		return tree.RoutineImmutable
+	case volatility.Stable:
+		return tree.RoutineStable
+	case volatility.Volatile:
+		return tree.RoutineVolatile
+	default:
+		panic(errors.AssertionFailedf("unexpected volatility %s", v))
+	}
+}
@@ -65,6 +65,10 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		}
 	}()
 
+	if cf.Aggregate != nil {
+		b.buildCreateAggregate(cf)
+	}
+
 	if cf.RoutineBody != nil {
 		panic(unimplemented.New("CREATE FUNCTION sql_body", "CREATE FUNCTION...sql_body unimplemented"))
 	}
@@ -161,20 +165,20 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		if err != nil {
 			panic(err)
 		}
-		if typ.Identical(types.Trigger) {
-			// TRIGGER is not allowed in this context.
+		if typ.Family() == types.TriggerFamily {
+			// TRIGGER and EVENT_TRIGGER are not allowed in this context.
 			if language == tree.RoutineLangPLpgSQL {
-				panic(pgerror.New(pgcode.FeatureNotSupported,
-					"PL/pgSQL functions cannot accept type trigger",
+				panic(pgerror.Newf(pgcode.FeatureNotSupported,
+					"PL/pgSQL functions cannot accept type %s", typ.Name(),
 				))
 			}
 			if param.IsOutParam() {
-				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
-					"SQL functions cannot return type trigger",
+				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"SQL functions cannot return type %s", typ.Name(),
 				))
 			} else {
-				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
-					"SQL functions cannot have arguments of type trigger",
+				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"SQL functions cannot have arguments of type %s", typ.Name(),
 				))
 			}
 		}
@@ -275,6 +279,16 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		}
 	}
 
+	// An aggregate depends on its state type and on its support functions,
+	// which were resolved by buildCreateAggregate.
+	if cf.Aggregate != nil {
+		typedesc.GetTypeDescriptorClosure(cf.Aggregate.StateType).ForEach(func(id descpb.ID) {
+			typeDeps.Add(int(id))
+		})
+		functionDeps.UnionWith(b.schemaFunctionDeps)
+		b.schemaFunctionDeps = intsets.Fast{}
+	}
+
 	// Determine OUT parameter based return type.
 	var outParamType *types.T
 	if (cf.IsProcedure && len(outParamTypes) > 0) || len(outParamTypes) > 1 {
@@ -354,6 +368,13 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			// Trigger functions cannot have parameters.
 			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "trigger functions cannot have declared arguments"))
 		}
+	} else if funcReturnType.Identical(types.EventTrigger) {
+		if language == tree.RoutineLangSQL {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "SQL functions cannot return type event_trigger"))
+		}
+		if len(cf.Params) > 0 {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "event trigger functions cannot have declared arguments"))
+		}
 	}
 	// Collect the user defined type dependency of the return type.
 	typedesc.GetTypeDescriptorClosure(funcReturnType).ForEach(func(id descpb.ID) {
@@ -449,6 +470,19 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			// Analysis of SQL expressions for trigger functions must be deferred
 			// until the function is bound to a trigger.
 			buildSQL = false
+		} else if funcReturnType.Identical(types.EventTrigger) {
+			// Event trigger functions have the implicitly defined TG_EVENT and
+			// TG_TAG parameters, and return no value.
+			for i := range eventTriggerFuncParams {
+				param := &eventTriggerFuncParams[i]
+				paramColName := funcParamColName(param.name, i)
+				col := b.synthesizeColumn(
+					bodyScope, paramColName, param.typ, nil /* expr */, nil, /* scalar */
+				)
+				col.setParamOrd(i)
+			}
+			routineParams = eventTriggerFuncParams
+			funcReturnType = types.Void
 		}
 
 		// We need to disable stable function folding because we want to catch the
@@ -334,39 +334,20 @@ const triggerColNew = "new"
 const triggerColOld = "old"
 
 func checkUnsupportedCreateTrigger(ct *tree.CreateTrigger, ds cat.DataSource) {
-	if ct.ForEach == tree.TriggerForEachStatement {
-		panic(unimplementedStatementLevelErr)
-	}
-	if ct.ActionTime == tree.TriggerActionTimeInsteadOf {
-		panic(unimplementedInsteadOfErr)
-	}
-	if len(ct.Transitions) > 0 {
-		panic(unimplementedReferencingErr)
-	}
 	for _, event := range ct.Events {
-		if event.EventType == tree.TriggerEventTruncate {
-			panic(unimplementedTruncateErr)
-		}
 		if len(event.Columns) > 0 {
 			panic(unimplementedColumnListErr)
 		}
 	}
-	if _, ok := ds.(cat.View); ok {
+	// Views only support row-level INSTEAD OF triggers.
+	if _, ok := ds.(cat.View); ok && ct.ForEach == tree.TriggerForEachStatement {
 		panic(unimplementedViewTriggerErr)
 	}
 }
 
 var (
-	unimplementedStatementLevelErr = unimplemented.NewWithIssue(126362,
-		"statement-level triggers are not yet supported")
-	unimplementedInsteadOfErr = unimplemented.NewWithIssue(126363,
-		"INSTEAD OF triggers are not yet supported")
-	unimplementedReferencingErr = unimplemented.NewWithIssue(135655,
-		"REFERENCING clause is not yet supported for triggers")
-	unimplementedTruncateErr = unimplemented.NewWithIssue(135657,
-		"TRUNCATE triggers are not yet supported")
 	unimplementedColumnListErr = unimplemented.NewWithIssue(135656,
 		"column lists are not yet supported for triggers")
 	unimplementedViewTriggerErr = unimplemented.NewWithIssue(135658,
-		"triggers on views are not yet supported")
+		"statement-level triggers on views are not yet supported")
 )
@@ -7,6 +7,8 @@ package optbuilder
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/util"
@@ -101,6 +103,15 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 		}
 	}
 
+	// WITH CHECK OPTION is only allowed on views that are automatically
+	// updatable, since it is enforced by checking rows written through the view.
+	if cv.CheckOption != tree.ViewCheckOptionNone {
+		if _, detail := b.analyzeUpdatableView(cv.AsSource, cv.ColumnNames); detail != "" {
+			panic(errors.WithDetail(pgerror.New(pgcode.FeatureNotSupported,
+				"WITH CHECK OPTION is supported only on automatically updatable views"), detail))
+		}
+	}
+
 	// We need the view query to include user-defined types as a 3-part name to
 	// properly detect cross-database type access.
 	fmtFlags := tree.FmtParsable | tree.FmtAlwaysQualifyUserDefinedTypeNames
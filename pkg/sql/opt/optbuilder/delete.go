@@ -7,6 +7,7 @@ package optbuilder
 
 import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -56,14 +57,8 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 			"DELETE BATCH not implemented"))
 	}
 
-	// Find which table we're working on, check the permissions.
-	tab, depName, alias, refColumns := b.resolveTableForMutation(del.Table, privilege.DELETE)
-
-	if tab.IsVirtualTable() {
-		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
-			"cannot delete from view \"%s\"", tab.Name(),
-		))
-	}
+	// Find which table or view we're working on, check the permissions.
+	ds, depName, alias, refColumns := b.resolveDataSourceForMutation(del.Table, privilege.DELETE)
 
 	if refColumns != nil {
 		panic(pgerror.Newf(pgcode.Syntax,
@@ -71,13 +66,37 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 	}
 
 	// Check Select permission as well, since existing values must be read.
-	b.checkPrivilege(depName, tab, privilege.SELECT)
+	b.checkPrivilege(depName, ds, privilege.SELECT)
+
+	// Views are deleted from by their INSTEAD OF triggers if they have any, or
+	// else by deleting from the table of an automatically updatable view.
+	var uv *updatableView
+	if view, ok := ds.(cat.View); ok {
+		if cat.HasRowLevelTriggers(view, tree.TriggerActionTimeInsteadOf, tree.TriggerEventDelete) {
+			return b.buildDeleteInsteadOfTriggers(del, view, alias, inScope)
+		}
+		uv = b.resolveUpdatableView(view, tree.TriggerEventDelete)
+	}
+
+	var tab cat.Table
+	if uv != nil {
+		tab = uv.tab
+	} else {
+		tab = ds.(cat.Table)
+		if tab.IsVirtualTable() {
+			panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot delete from view \"%s\"", tab.Name(),
+			))
+		}
+	}
 
 	// Check if this table has already been mutated in another subquery.
 	b.checkMultipleMutations(tab, generalMutation)
 
 	var mb mutationBuilder
 	mb.init(b, "delete", tab, alias)
+	mb.view = uv
+	mb.fireStatementTriggers = true
 
 	// Build the input expression that selects the rows that will be deleted:
 	//
@@ -98,6 +117,9 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 		mb.buildDelete(nil /* returning */)
 	}
 
+	// Build statement-level BEFORE triggers, which fire before the mutation.
+	mb.buildStatementLevelBeforeTriggers(opt.DeleteOp)
+
 	return mb.outScope
 }
 
@@ -106,7 +128,7 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 func (mb *mutationBuilder) buildDelete(returning *tree.ReturningExprs) {
 	mb.buildFKChecksAndCascadesForDelete()
 
-	mb.buildRowLevelAfterTriggers(opt.DeleteOp)
+	mb.buildAfterTriggers(opt.DeleteOp)
 
 	// Project partial index DEL boolean columns.
 	mb.projectPartialIndexDelCols()
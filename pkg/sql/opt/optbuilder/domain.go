@@ -0,0 +1,117 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+const domainCheckFnName = "crdb_internal.domain_check"
+
+// buildDomainCast builds a cast of the given expression to a domain type,
+// followed by the checks of the domain's NOT NULL and CHECK constraints.
+func (b *Builder) buildDomainCast(
+	texpr tree.TypedExpr, typ *types.T, inScope *scope, colRefs *opt.ColSet,
+) opt.ScalarExpr {
+	arg := b.buildScalar(texpr, inScope, nil, nil, colRefs)
+	// The input is referenced once by the cast and once by each constraint
+	// check, so it must not be volatile.
+	var sharedProps props.Shared
+	memo.BuildSharedProps(arg, &sharedProps, b.evalCtx)
+	if sharedProps.VolatilitySet.HasVolatile() {
+		panic(unimplemented.Newf("domain cast volatile",
+			"casting a volatile expression to domain %s is not supported", typ.SQLString()))
+	}
+	return b.buildDomainChecks(b.factory.ConstructCast(arg, typ), typ)
+}
+
+// buildDomainChecks wraps val, which must be of the given domain type, in calls
+// to crdb_internal.domain_check that raise an error if val violates the NOT
+// NULL or CHECK constraints of the domain. If typ is not a domain, val is
+// returned unchanged.
+func (b *Builder) buildDomainChecks(val opt.ScalarExpr, typ *types.T) opt.ScalarExpr {
+	if !typ.IsDomain() || typ.TypeMeta.DomainData == nil {
+		return val
+	}
+	domain := typ.TypeMeta.DomainData
+	domainName := typ.TypeMeta.Name.Basename()
+	input := val
+	if domain.NotNull {
+		ok := b.factory.ConstructIsNot(input, memo.NullSingleton)
+		val = b.constructDomainCheck(val, ok, domainName, "" /* constraint */)
+	}
+	if len(domain.CheckExprs) == 0 {
+		return val
+	}
+
+	// The CHECK expressions reference the value being checked as VALUE. Build
+	// them in a scope with a single column of the base type named VALUE, and
+	// then replace references to that column with the value.
+	baseType := typ.DomainBaseType()
+	checkScope := b.allocScope()
+	valueCol := b.synthesizeColumn(
+		checkScope, scopeColName(schemaexpr.DomainValueName), baseType, nil /* expr */, nil, /* scalar */
+	)
+	var baseVal opt.ScalarExpr = b.factory.ConstructCast(input, baseType)
+	var replace norm.ReplaceFunc
+	replace = func(e opt.Expr) opt.Expr {
+		if v, ok := e.(*memo.VariableExpr); ok && v.Col == valueCol.id {
+			return baseVal
+		}
+		return b.factory.Replace(e, replace)
+	}
+	for i, exprStr := range domain.CheckExprs {
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			panic(err)
+		}
+		texpr := checkScope.resolveAndRequireType(expr, types.Bool)
+		check := b.buildScalar(texpr, checkScope, nil, nil, nil)
+		check = replace(check).(opt.ScalarExpr)
+		// Like table CHECK constraints, domain CHECK constraints are satisfied
+		// if they evaluate to NULL.
+		ok := b.factory.ConstructCoalesce(memo.ScalarListExpr{check, memo.TrueSingleton})
+		val = b.constructDomainCheck(val, ok, domainName, domain.CheckNames[i])
+	}
+	return val
+}
+
+// constructDomainCheck builds a call to crdb_internal.domain_check, which
+// returns val if ok is true, and otherwise raises an error that the value
+// violates the given constraint of the domain. An empty constraint name
+// indicates the NOT NULL constraint.
+func (b *Builder) constructDomainCheck(
+	val, ok opt.ScalarExpr, domainName, constraint string,
+) opt.ScalarExpr {
+	fnProps, overloads := builtinsregistry.GetBuiltinProperties(domainCheckFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", domainCheckFnName))
+	}
+	return b.factory.ConstructFunction(
+		memo.ScalarListExpr{
+			val,
+			ok,
+			b.factory.ConstructConstVal(tree.NewDString(domainName), types.String),
+			b.factory.ConstructConstVal(tree.NewDString(constraint), types.String),
+		},
+		&memo.FunctionPrivate{
+			Name:       domainCheckFnName,
+			Typ:        val.DataType(),
+			Properties: fnProps,
+			Overload:   &overloads[0],
+		},
+	)
+}
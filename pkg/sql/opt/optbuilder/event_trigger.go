@@ -0,0 +1,175 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// eventTriggerFuncParams is the set of implicitly-defined parameters for a
+// PL/pgSQL event trigger function.
+var eventTriggerFuncParams = []routineParam{
+	{name: "tg_event", typ: types.String, class: tree.RoutineParamIn},
+	{name: "tg_tag", typ: types.String, class: tree.RoutineParamIn},
+}
+
+// buildEventTriggers wraps the expression of the given top-level DDL statement
+// with the event triggers of the current database that fire for it:
+//
+//   - the ddl_command_start triggers are invoked before the command,
+//   - the sql_drop triggers are invoked after commands that drop objects,
+//   - the ddl_command_end triggers are invoked after the command.
+//
+// Triggers for the same event are invoked in order of their names. As for
+// TRUNCATE triggers (see buildTruncateTriggers), the invocations and the
+// command itself are the bindings of materialized With operators, which are
+// executed in order before the (empty) main query. Event triggers do not fire
+// for internal statements.
+func (b *Builder) buildEventTriggers(stmt tree.Statement, outScope *scope) {
+	if stmt.StatementType() != tree.TypeDDL || len(outScope.cols) > 0 ||
+		b.evalCtx.SessionData().Internal {
+		return
+	}
+	tag := stmt.StatementTag()
+	if !tree.EventTriggerSupportsTag(tag) {
+		return
+	}
+	triggers, err := b.catalog.EventTriggers(b.ctx)
+	if err != nil {
+		panic(err)
+	}
+	if len(triggers) == 0 {
+		return
+	}
+	var before, after []memo.RelExpr
+	buildEvent := func(event string) memo.RelExpr {
+		return b.buildEventTriggerInvocations(triggers, event, tag)
+	}
+	if expr := buildEvent(tree.EventTriggerDDLCommandStart); expr != nil {
+		before = append(before, expr)
+	}
+	if strings.HasPrefix(tag, "DROP ") {
+		if expr := buildEvent(tree.EventTriggerSQLDrop); expr != nil {
+			after = append(after, expr)
+		}
+	}
+	if expr := buildEvent(tree.EventTriggerDDLCommandEnd); expr != nil {
+		after = append(after, expr)
+	}
+	if len(before) == 0 && len(after) == 0 {
+		return
+	}
+	b.HasEventTriggers = true
+
+	// Build the With operators from the bottom up.
+	ddlExpr := outScope.expr
+	expr := ddlExpr
+	if len(after) > 0 {
+		expr = b.factory.ConstructZeroValues()
+		for i := len(after) - 1; i >= 0; i-- {
+			expr = b.constructMaterializedWith(after[i], expr, "event triggers")
+		}
+		expr = b.constructMaterializedWith(ddlExpr, expr, "ddl")
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		expr = b.constructMaterializedWith(before[i], expr, "event triggers")
+	}
+	outScope.expr = expr
+}
+
+// buildEventTriggerInvocations builds an expression that invokes the function
+// of each of the given event triggers that fires on the given event for a
+// command with the given tag. It returns nil if no trigger fires.
+func (b *Builder) buildEventTriggerInvocations(
+	triggers []cat.EventTrigger, event, tag string,
+) memo.RelExpr {
+	f := b.factory
+	triggerScope := b.allocScope()
+	triggerScope.expr = f.ConstructNoColsRow()
+	var found bool
+	for _, trigger := range triggers {
+		if !cat.EventTriggerMatches(trigger, event, tag) {
+			continue
+		}
+		if found {
+			// No need to place a barrier below the first trigger.
+			triggerScope.expr = f.ConstructBarrier(triggerScope.expr)
+		}
+		found = true
+		args := memo.ScalarListExpr{
+			f.ConstructConstVal(tree.NewDString(event), types.String), // TG_EVENT
+			f.ConstructConstVal(tree.NewDString(tag), types.String),   // TG_TAG
+		}
+		fn, name := b.buildEventTriggerFunction(trigger, args)
+		b.projectColWithMetadataName(triggerScope, name, types.Void, fn)
+	}
+	if !found {
+		return nil
+	}
+	// Always wrap the expression in a barrier, or else the projections will be
+	// pruned and the triggers will not be executed.
+	return f.ConstructBarrier(triggerScope.expr)
+}
+
+// buildEventTriggerFunction resolves and builds an invocation of the function
+// of the given event trigger, using the given arguments. It also returns the
+// name of the function.
+func (b *Builder) buildEventTriggerFunction(
+	trigger cat.EventTrigger, args memo.ScalarListExpr,
+) (opt.ScalarExpr, string) {
+	funcScope := b.allocScope()
+	funcRef := &tree.FunctionOID{OID: catid.FuncIDToOID(catid.DescID(trigger.FuncID()))}
+	funcExpr := tree.FuncExpr{Func: tree.ResolvableFunctionReference{FunctionReference: funcRef}}
+	funcScope.resolveType(&funcExpr, types.AnyElement)
+	resolvedDef := funcExpr.Func.FunctionReference.(*tree.ResolvedFunctionDefinition)
+	o := funcExpr.ResolvedOverload()
+
+	params := eventTriggerFuncParams
+	paramCols := make(opt.ColList, len(params))
+	for colOrd, param := range params {
+		paramColName := funcParamColName(param.name, colOrd)
+		col := b.synthesizeColumn(funcScope, paramColName, param.typ, nil /* expr */, nil /* scalar */)
+		col.setParamOrd(colOrd)
+		paramCols[colOrd] = col.id
+	}
+
+	// Event trigger functions are always called on NULL input, and do not
+	// return a value.
+	const calledOnNullInput = true
+	udfDef := &memo.UDFDefinition{
+		Name:              resolvedDef.Name,
+		Typ:               types.Void,
+		Volatility:        o.Volatility,
+		CalledOnNullInput: calledOnNullInput,
+		RoutineType:       o.Type,
+		RoutineLang:       o.Language,
+		Params:            paramCols,
+	}
+
+	// Parse and build the function body.
+	stmt, err := plpgsql.Parse(o.Body)
+	if err != nil {
+		panic(err)
+	}
+	plBuilder := newPLpgSQLBuilder(
+		b, resolvedDef.Name, stmt.AST.Label, nil /* colRefs */, params, types.Void,
+		false /* isProc */, false /* isDoBlock */, true /* buildSQL */, nil, /* outScope */
+	)
+	stmtScope := plBuilder.buildRootBlock(stmt.AST, funcScope, params)
+	udfDef.Body = []memo.RelExpr{stmtScope.expr}
+	udfDef.BodyProps = []*physical.Required{stmtScope.makePhysicalProps()}
+
+	return b.factory.ConstructUDFCall(args, &memo.UDFCallPrivate{Def: udfDef}), resolvedDef.Name
+}
@@ -327,6 +327,7 @@ func (cb *onDeleteFastCascadeBuilder) Build(
 				b.allocScope(),
 				true, /* disableNotVisibleIndex */
 				cat.PolicyScopeExempt,
+				opt.TableSample{}, /* tableSample */
 			)
 			mb.outScope = mb.fetchScope
 
@@ -583,6 +584,7 @@ func (b *Builder) buildDeleteCascadeMutationInput(
 		b.allocScope(),
 		true, /* disableNotVisibleIndex */
 		cat.PolicyScopeExempt,
+		opt.TableSample{}, /* tableSample */
 	)
 
 	numFKCols := fk.ColumnCount()
@@ -858,6 +860,7 @@ func (b *Builder) buildUpdateCascadeMutationInput(
 		b.allocScope(),
 		true, /* disableNotVisibleIndex */
 		cat.PolicyScopeExempt,
+		opt.TableSample{}, /* tableSample */
 	)
 
 	numFKCols := fk.ColumnCount()
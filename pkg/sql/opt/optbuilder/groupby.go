@@ -40,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -95,8 +96,69 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// groupingSets is non-nil if the GROUP BY clause has more than one grouping
+	// set, e.g. GROUP BY ROLLUP (a, b).
+	groupingSets *groupingSets
+}
+
+// groupingSets contains information about the grouping sets of a GROUP BY
+// clause with ROLLUP, CUBE or GROUPING SETS items.
+//
+// The aggregation is computed over a cross join of the pre-projection with the
+// IDs of the grouping sets, so that each input row is aggregated once for each
+// grouping set. The grouping columns which are not in every grouping set are
+// projected to NULL in the rows of the grouping sets that don't contain them.
+// For example:
+//
+//	SELECT a, b, count(*) FROM t GROUP BY ROLLUP (a, b)
+//
+//	grouping sets:   (a, b), (a), ()
+//	pre-projection:  a (as col1), b (as col2)
+//	cross join:      VALUES (0), (1), (2) (as set)
+//	masking:         CASE WHEN set IN (0, 1) THEN col1 END (as col3),
+//	                 CASE WHEN set IN (0) THEN col2 END (as col4)
+//	aggregation:     group by col3, col4, set, calculate count_rows()
+//
+// Postgres computes all the grouping sets in a single pass over sorted or
+// hashed input instead. Building the grouping sets out of existing operators
+// means that they work in both execution engines and are distributed like any
+// other GroupBy, since the set ID is a grouping column that hash partitions the
+// rows of the different sets. It also means that the expansion is visible to
+// the optimizer: the statistics of the cross join multiply the row count of
+// the input by the number of grouping sets, so the GroupBy is costed for the
+// rows that it actually aggregates. The price is that each input row is
+// aggregated once per grouping set rather than once in total.
+type groupingSets struct {
+	// sets contains the grouping columns of each grouping set. The ID of each
+	// grouping set is its ordinal in sets.
+	sets []opt.ColSet
+
+	// inCols contains the grouping columns in the aggInScope, and outCols the
+	// corresponding columns in the aggOutScope. The two differ for grouping
+	// columns which are not in every grouping set.
+	inCols  opt.ColList
+	outCols opt.ColList
+
+	// setCol is the column of the GroupBy input containing the grouping set ID
+	// of each row.
+	setCol opt.ColumnID
+
+	// emptySets contains the IDs of the empty grouping sets, if any. Empty
+	// grouping sets produce a row even if the input has no rows, which requires
+	// a join of the GroupBy with the IDs in emptySetCol.
+	emptySets   []int
+	emptySetCol opt.ColumnID
+
+	// setOutCol is the column in the aggOutScope containing the grouping set ID
+	// of each row. It is used to build GROUPING expressions.
+	setOutCol opt.ColumnID
 }
 
+// maxGroupingSets is the maximum number of grouping sets that a GROUP BY
+// clause can expand to. It matches the limit of Postgres.
+const maxGroupingSets = 4096
+
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
 // grouping column in an aggOutScope scope that projects that expression. It
 // is used to enforce scoping rules, since any non-aggregate, variable
@@ -242,7 +304,9 @@ func (a aggregateInfo) isOrderedSetAggregate() bool {
 // ordering sensitive. That is, it can give different results based on the order
 // values are fed to it.
 func (a aggregateInfo) isOrderingSensitive() bool {
-	if a.isOrderedSetAggregate() {
+	if a.isOrderedSetAggregate() || isUserDefinedAggregate(a.def) {
+		// The transition function of a user-defined aggregate may depend on the
+		// order of its inputs.
 		return true
 	}
 	switch a.def.Name {
@@ -327,12 +391,74 @@ func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope,
 	g := fromScope.groupby
 
 	// The "from" columns are visible to any grouping expressions.
-	b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
+	sets := b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
+
+	// A GROUP BY with a single grouping set is an ordinary GROUP BY of the
+	// columns of that set.
+	if len(sets) > 1 {
+		b.buildGroupingSetColumns(g, sets)
+		return
+	}
 
 	// Copy the grouping columns to the aggOutScope.
 	g.aggOutScope.appendColumns(g.groupingCols())
 }
 
+// buildGroupingSetColumns adds the grouping columns of a GROUP BY clause with
+// the given grouping sets to the aggOutScope, along with a column containing
+// the ID of the grouping set of each row. See groupingSets.
+func (b *Builder) buildGroupingSetColumns(g *groupby, sets []opt.ColSet) {
+	gs := &groupingSets{sets: sets}
+	always := sets[0].Copy()
+	for i := range sets {
+		always.IntersectionWith(sets[i])
+		if sets[i].Empty() {
+			gs.emptySets = append(gs.emptySets, i)
+		}
+	}
+
+	groupingCols := g.groupingCols()
+	gs.inCols = make(opt.ColList, len(groupingCols))
+	gs.outCols = make(opt.ColList, len(groupingCols))
+	for i := range groupingCols {
+		col := &groupingCols[i]
+		gs.inCols[i] = col.id
+		if always.Contains(col.id) {
+			g.aggOutScope.appendColumn(col)
+		} else {
+			// The column is NULL in the rows of some grouping sets, so the
+			// aggregation produces it as a new column.
+			b.synthesizeColumn(g.aggOutScope, col.name, col.typ, col.expr, nil /* scalar */)
+		}
+		gs.outCols[i] = g.aggOutScope.cols[len(g.aggOutScope.cols)-1].id
+	}
+
+	setOutCol := b.synthesizeColumn(
+		g.aggOutScope, scopeColName("grouping_set"), types.Int, nil /* expr */, nil, /* scalar */
+	)
+	setOutCol.visibility = inaccessible
+	gs.setOutCol = setOutCol.id
+	gs.setCol = gs.setOutCol
+	if len(gs.emptySets) > 0 {
+		md := b.factory.Metadata()
+		gs.setCol = md.AddColumn("grouping_set", types.Int)
+		gs.emptySetCol = md.AddColumn("empty_grouping_set", types.Int)
+	}
+	g.groupingSets = gs
+
+	// References to the grouping expressions must resolve to the columns of the
+	// aggOutScope.
+	outCols := g.aggOutScope.cols[len(g.aggOutScope.cols)-len(groupingCols)-1:]
+	for exprStr, col := range g.groupStrs {
+		for i := range groupingCols {
+			if gs.inCols[i] == col.id {
+				g.groupStrs[exprStr] = &outCols[i]
+				break
+			}
+		}
+	}
+}
+
 // buildAggregation builds the aggregation operators and constructs the
 // GroupBy expression. Returns the output scope for the aggregation operation.
 func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (outScope *scope) {
@@ -349,6 +475,10 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// If there are any aggregates that are ordering sensitive, build the
 	// aggregations as window functions over each group.
 	if g.hasNonCommutativeAggregates() {
+		if g.groupingSets != nil {
+			panic(unimplemented.NewWithIssue(46280,
+				"ordered aggregates are not supported with grouping sets"))
+		}
 		return b.buildAggregationAsWindow(groupingColSet, having, fromScope)
 	}
 
@@ -380,7 +510,7 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		aggCols[i].scalar = b.constructAggregate(&agg.def, args)
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -425,12 +555,16 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// aggregate arguments, as well as any additional order by columns.
 	b.constructProjectForScope(fromScope, g.aggInScope)
 
-	g.aggOutScope.expr = b.constructGroupBy(
-		g.aggInScope.expr,
-		groupingColSet,
-		aggCols,
-		g.aggInScope.ordering,
-	)
+	if g.groupingSets != nil {
+		g.aggOutScope.expr = b.constructGroupingSetsGroupBy(g, aggCols)
+	} else {
+		g.aggOutScope.expr = b.constructGroupBy(
+			g.aggInScope.expr,
+			groupingColSet,
+			aggCols,
+			g.aggInScope.ordering,
+		)
+	}
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -442,6 +576,137 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	return g.aggOutScope
 }
 
+// constructGroupingSetsGroupBy constructs the aggregation of a GROUP BY clause
+// with multiple grouping sets. See groupingSets.
+func (b *Builder) constructGroupingSetsGroupBy(
+	g *groupby, aggCols []scopeColumn,
+) memo.RelExpr {
+	gs := g.groupingSets
+	f := b.factory
+	md := f.Metadata()
+
+	// Produce each input row once for each grouping set.
+	ids := make([]int, len(gs.sets))
+	for i := range ids {
+		ids[i] = i
+	}
+	input := f.ConstructInnerJoin(
+		g.aggInScope.expr, b.constructGroupingSetIDs(gs.setCol, ids), memo.TrueFilter, memo.EmptyJoinPrivate,
+	)
+
+	// Project the grouping columns which are not in every grouping set to NULL
+	// in the rows of the grouping sets that don't contain them.
+	groupingColSet := opt.MakeColSet(gs.setCol)
+	var projections memo.ProjectionsExpr
+	for i, inCol := range gs.inCols {
+		outCol := gs.outCols[i]
+		groupingColSet.Add(outCol)
+		if outCol == inCol {
+			continue
+		}
+		var inSets memo.ScalarListExpr
+		var inSetsTypes []*types.T
+		for j := range gs.sets {
+			if gs.sets[j].Contains(inCol) {
+				inSets = append(inSets, f.ConstructConstVal(tree.NewDInt(tree.DInt(j)), types.Int))
+				inSetsTypes = append(inSetsTypes, types.Int)
+			}
+		}
+		masked := f.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{f.ConstructWhen(
+				f.ConstructIn(
+					f.ConstructVariable(gs.setCol), f.ConstructTuple(inSets, types.MakeTuple(inSetsTypes)),
+				),
+				f.ConstructVariable(inCol),
+			)},
+			f.ConstructNull(md.ColumnMeta(inCol).Type),
+		)
+		projections = append(projections, f.ConstructProjectionsItem(masked, outCol))
+	}
+	input = f.ConstructProject(input, projections, input.Relational().OutputCols)
+
+	if len(gs.emptySets) == 0 {
+		return b.constructGroupBy(input, groupingColSet, aggCols, g.aggInScope.ordering)
+	}
+
+	// An empty grouping set produces a row even if the input has no rows, like
+	// a scalar aggregation, but the GroupBy produces no rows at all in that
+	// case. Full join the GroupBy with the IDs of the empty grouping sets to
+	// produce the missing rows, and replace the NULL results of aggregates
+	// like count with the results of those aggregates over no rows.
+	var outProjections memo.ProjectionsExpr
+	var remapped opt.ColSet
+	aggCols = append([]scopeColumn(nil), aggCols...)
+	for i := range aggCols {
+		var empty opt.ScalarExpr
+		agg := memo.ExtractAggFunc(aggCols[i].scalar)
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			if uda.Def.Empty.Op() == opt.NullOp {
+				continue
+			}
+			empty = uda.Def.Empty
+		} else if opt.AggregateIsNullOnEmpty(agg.Op()) {
+			continue
+		}
+		id := md.AddColumn(md.ColumnMeta(aggCols[i].id).Alias, aggCols[i].typ)
+		var proj opt.ScalarExpr
+		if empty != nil {
+			// The result of a user-defined aggregate over no rows is not
+			// necessarily non-NULL, so only replace the result in the rows that
+			// were produced by the join.
+			proj = f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(
+					f.ConstructIs(f.ConstructVariable(gs.setCol), memo.NullSingleton),
+					empty,
+				)},
+				f.ConstructVariable(id),
+			)
+		} else {
+			proj = f.ConstructCoalesce(memo.ScalarListExpr{
+				f.ConstructVariable(id), f.ConstructConstVal(tree.DZero, types.Int),
+			})
+		}
+		outProjections = append(outProjections, f.ConstructProjectionsItem(proj, aggCols[i].id))
+		remapped.Add(id)
+		aggCols[i].id = id
+	}
+	groupBy := b.constructGroupBy(input, groupingColSet, aggCols, g.aggInScope.ordering)
+	on := memo.FiltersExpr{f.ConstructFiltersItem(
+		f.ConstructEq(f.ConstructVariable(gs.setCol), f.ConstructVariable(gs.emptySetCol)),
+	)}
+	join := f.ConstructFullJoin(
+		groupBy, b.constructGroupingSetIDs(gs.emptySetCol, gs.emptySets), on, memo.EmptyJoinPrivate,
+	)
+	outProjections = append(outProjections, f.ConstructProjectionsItem(
+		f.ConstructCoalesce(memo.ScalarListExpr{
+			f.ConstructVariable(gs.setCol), f.ConstructVariable(gs.emptySetCol),
+		}),
+		gs.setOutCol,
+	))
+	passthrough := groupBy.Relational().OutputCols.Difference(remapped)
+	passthrough.Remove(gs.setCol)
+	return f.ConstructProject(join, outProjections, passthrough)
+}
+
+// constructGroupingSetIDs constructs a Values expression with a row for each
+// of the given grouping set IDs.
+func (b *Builder) constructGroupingSetIDs(col opt.ColumnID, ids []int) memo.RelExpr {
+	rows := make(memo.ScalarListExpr, len(ids))
+	rowTyp := types.MakeTuple([]*types.T{types.Int})
+	for i, id := range ids {
+		rows[i] = b.factory.ConstructTuple(
+			memo.ScalarListExpr{b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(id)), types.Int)},
+			rowTyp,
+		)
+	}
+	return b.factory.ConstructValues(rows, &memo.ValuesPrivate{
+		Cols: opt.ColList{col},
+		ID:   b.factory.Metadata().NextUniqueID(),
+	})
+}
+
 // analyzeHaving analyzes the having clause and returns it as a typed
 // expression. fromScope contains the name bindings that are visible for this
 // HAVING clause (e.g., passed in from an enclosing statement).
@@ -477,7 +742,8 @@ func (b *Builder) buildHaving(having tree.TypedExpr, fromScope *scope) opt.Scala
 
 // buildGroupingList builds a set of memo groups that represent a list of
 // GROUP BY expressions, adding the group-by expressions as columns to
-// aggInScope and populating groupStrs.
+// aggInScope and populating groupStrs. If the list has ROLLUP, CUBE or
+// GROUPING SETS items, the grouping sets it expands to are returned.
 //
 // groupBy   The given GROUP BY expressions.
 // selects   The select expressions are needed in case one of the GROUP BY
@@ -489,7 +755,7 @@ func (b *Builder) buildHaving(having tree.TypedExpr, fromScope *scope) opt.Scala
 // fromScope The scope for the input to the aggregation (the FROM clause).
 func (b *Builder) buildGroupingList(
 	groupBy tree.GroupBy, selects tree.SelectExprs, projectionsScope *scope, fromScope *scope,
-) {
+) (sets []opt.ColSet) {
 	g := fromScope.groupby
 	g.groupStrs = make(groupByStrSet, len(groupBy))
 	if g.aggInScope.cols == nil {
@@ -505,15 +771,172 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
+	defer func() { g.buildingGroupingCols = false }()
+	if !groupBy.HasGroupingSets() {
+		for _, e := range groupBy {
+			b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		}
+		return nil
+	}
+
+	// The grouping sets of the GROUP BY clause are the cross product of the
+	// grouping sets of its items. For example:
+	//   GROUP BY a, ROLLUP (b, c)
+	// is equivalent to:
+	//   GROUP BY GROUPING SETS ((a, b, c), (a, b), (a))
+	sets = []opt.ColSet{{}}
 	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		itemSets := b.buildGroupingSets(e, selects, projectionsScope, fromScope)
+		if len(sets)*len(itemSets) > maxGroupingSets {
+			panic(tooManyGroupingSetsError())
+		}
+		product := make([]opt.ColSet, 0, len(sets)*len(itemSets))
+		for i := range sets {
+			for j := range itemSets {
+				product = append(product, sets[i].Union(itemSets[j]))
+			}
+		}
+		sets = product
+	}
+	return sets
+}
+
+// buildGroupingSets builds the GROUP BY expressions of a single GROUP BY item
+// and returns the grouping sets that the item expands to. An ordinary
+// expression expands to a single grouping set, and ROLLUP, CUBE and GROUPING
+// SETS items expand as in Postgres:
+//
+//	ROLLUP (a, b, c)          => (a, b, c), (a, b), (a), ()
+//	CUBE (a, b)               => (a, b), (a), (b), ()
+//	GROUPING SETS (a, (b, c)) => (a), (b, c)
+//
+// Parenthesized lists like (b, c) are treated as a single element of ROLLUP
+// and CUBE.
+func (b *Builder) buildGroupingSets(
+	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) []opt.ColSet {
+	aggInScope := fromScope.groupby.aggInScope
+	gs, ok := groupBy.(*tree.GroupingSet)
+	if !ok {
+		return []opt.ColSet{b.buildGrouping(groupBy, selects, projectionsScope, fromScope, aggInScope)}
+	}
+
+	var sets []opt.ColSet
+	switch gs.Type {
+	case tree.RollupGroupingSet:
+		elems := make([]opt.ColSet, len(gs.Exprs))
+		for i, e := range gs.Exprs {
+			elems[i] = b.buildGrouping(e, selects, projectionsScope, fromScope, aggInScope)
+		}
+		// Produce the prefixes of the elements, from the full set to ().
+		sets = make([]opt.ColSet, 0, len(elems)+1)
+		for i := len(elems); i >= 0; i-- {
+			var set opt.ColSet
+			for j := 0; j < i; j++ {
+				set.UnionWith(elems[j])
+			}
+			sets = append(sets, set)
+		}
+
+	case tree.CubeGroupingSet:
+		if len(gs.Exprs) > maxCubeElements {
+			panic(pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"CUBE is limited to %d elements", maxCubeElements))
+		}
+		elems := make([]opt.ColSet, len(gs.Exprs))
+		for i, e := range gs.Exprs {
+			elems[i] = b.buildGrouping(e, selects, projectionsScope, fromScope, aggInScope)
+		}
+		// Produce the subsets in the order of Postgres, from the full set to ().
+		// The first element corresponds to the most significant bit of the mask.
+		n := len(elems)
+		sets = make([]opt.ColSet, 0, 1<<n)
+		for mask := (1 << n) - 1; mask >= 0; mask-- {
+			var set opt.ColSet
+			for i := range elems {
+				if mask&(1<<(n-1-i)) != 0 {
+					set.UnionWith(elems[i])
+				}
+			}
+			sets = append(sets, set)
+		}
+
+	case tree.GroupingSetsGroupingSet:
+		for _, e := range gs.Exprs {
+			sets = append(sets, b.buildGroupingSets(e, selects, projectionsScope, fromScope)...)
+			if len(sets) > maxGroupingSets {
+				panic(tooManyGroupingSetsError())
+			}
+		}
+
+	default:
+		panic(errors.AssertionFailedf("unknown grouping set type %v", gs.Type))
 	}
-	g.buildingGroupingCols = false
+	return sets
+}
+
+// buildGroupingExpr builds a GROUPING expression, which returns a bitmask of
+// its arguments in which the bits of the arguments that are not in the
+// grouping set of the current row are set. The last argument corresponds to
+// the least significant bit. For example:
+//
+//	SELECT GROUPING(a, b) FROM t GROUP BY ROLLUP (a, b)
+//	=>
+//	CASE grouping_set WHEN 0 THEN 0 WHEN 1 THEN 1 WHEN 2 THEN 3 ELSE 0 END
+func (b *Builder) buildGroupingExpr(t *tree.GroupingExpr, g *groupby) opt.ScalarExpr {
+	if len(t.Exprs) > maxGroupingArgs {
+		panic(pgerror.Newf(pgcode.TooManyArguments,
+			"GROUPING must have fewer than %d arguments", maxGroupingArgs+1))
+	}
+	args := make([]opt.ColumnID, len(t.Exprs))
+	for i, e := range t.Exprs {
+		col, ok := g.groupStrs[symbolicExprStr(e.(tree.TypedExpr))]
+		if !ok {
+			panic(newGroupingExprArgError())
+		}
+		args[i] = col.id
+	}
+
+	zero := b.factory.ConstructConstVal(tree.DZero, types.Int)
+	gs := g.groupingSets
+	if gs == nil {
+		// All the arguments are in the only grouping set.
+		return zero
+	}
+	whens := make(memo.ScalarListExpr, len(gs.sets))
+	for i := range gs.sets {
+		var mask tree.DInt
+		for _, arg := range args {
+			mask <<= 1
+			if j, ok := gs.outCols.Find(arg); ok && !gs.sets[i].Contains(gs.inCols[j]) {
+				mask |= 1
+			}
+		}
+		whens[i] = b.factory.ConstructWhen(
+			b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int),
+			b.factory.ConstructConstVal(tree.NewDInt(mask), types.Int),
+		)
+	}
+	return b.factory.ConstructCase(b.factory.ConstructVariable(gs.setOutCol), whens, zero)
+}
+
+// maxGroupingArgs is the maximum number of arguments of GROUPING, which must
+// fit in the bits of the result.
+const maxGroupingArgs = 31
+
+// maxCubeElements is the maximum number of elements of a CUBE, which expands
+// to 2^n grouping sets.
+const maxCubeElements = 12
+
+func tooManyGroupingSetsError() error {
+	return pgerror.Newf(pgcode.StatementTooComplex,
+		"too many grouping sets present (maximum %d)", maxGroupingSets)
 }
 
 // buildGrouping builds a set of memo groups that represent a GROUP BY
 // expression. The expression (or expressions, if we have a star) is added to
-// groupStrs and to the aggInScope.
+// groupStrs and to the aggInScope. Returns the set of grouping columns for the
+// expression.
 //
 // groupBy          The given GROUP BY expression.
 // selects          The select expressions are needed in case the GROUP BY
@@ -533,7 +956,7 @@ func (b *Builder) buildGroupingList(
 //	as the aggregate function arguments.
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
-) {
+) (cols opt.ColSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 	alias := ""
@@ -631,7 +1054,8 @@ func (b *Builder) buildGrouping(
 		// If a grouping column has already been added, don't add it again.
 		// GROUP BY a, a is semantically equivalent to GROUP BY a.
 		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+		if col, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			cols.Add(col.id)
 			continue
 		}
 
@@ -641,7 +1065,9 @@ func (b *Builder) buildGrouping(
 		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
 		b.buildScalar(e, fromScope, aggInScope, col, nil)
 		fromScope.groupby.groupStrs[exprStr] = col
+		cols.Add(col.id)
 	}
+	return cols
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -698,7 +1124,6 @@ func (b *Builder) buildAggregateFunction(
 		FuncExpr: f,
 		def:      *def,
 		distinct: (f.Type == tree.DistinctFuncType),
-		args:     make(memo.ScalarListExpr, len(f.Exprs)),
 	}
 
 	// Temporarily set b.subquery to nil so we don't add outer columns to the
@@ -707,8 +1132,10 @@ func (b *Builder) buildAggregateFunction(
 	b.subquery = nil
 	defer func() { b.subquery = subq }()
 
-	for i, pexpr := range f.Exprs {
-		info.args[i] = b.buildAggArg(pexpr.(tree.TypedExpr), &info, tempScope, fromScope)
+	args := aggregateArgs(f, def)
+	info.args = make(memo.ScalarListExpr, len(args))
+	for i, pexpr := range args {
+		info.args[i] = b.buildAggArg(pexpr, &info, tempScope, fromScope)
 	}
 
 	// If we have a filter, add it to tempScope after all the arguments. We'll
@@ -770,8 +1197,13 @@ func (b *Builder) buildAggregateFunction(
 	return &info
 }
 
-func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.ScalarExpr {
-	switch name {
+func (b *Builder) constructWindowFn(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	if isUserDefinedAggregate(def) {
+		return b.constructAggregate(def, args)
+	}
+	switch def.Name {
 	case "rank":
 		return b.factory.ConstructRank()
 	case "row_number":
@@ -795,12 +1227,17 @@ func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.Scal
 	case "nth_value":
 		return b.factory.ConstructNthValue(args[0], args[1])
 	default:
-		return b.constructAggregate(name, args)
+		return b.constructAggregate(def, args)
 	}
 }
 
-func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.ScalarExpr {
-	switch name {
+func (b *Builder) constructAggregate(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	if isUserDefinedAggregate(def) {
+		return b.constructUserDefinedAgg(def, args[0])
+	}
+	switch def.Name {
 	case "array_agg":
 		return b.factory.ConstructArrayAgg(args[0])
 	case "array_cat_agg":
@@ -897,7 +1334,7 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 		return b.factory.ConstructMergeAggregatedStmtMetadata(args[0])
 	}
 
-	panic(errors.AssertionFailedf("unhandled aggregate: %s", name))
+	panic(errors.AssertionFailedf("unhandled aggregate: %s", def.Name))
 }
 
 func isAggregate(def *tree.ResolvedFunctionDefinition) bool {
@@ -927,6 +1364,12 @@ func newGroupingError(name tree.Name) error {
 	)
 }
 
+func newGroupingExprArgError() error {
+	return pgerror.New(pgcode.Grouping,
+		"arguments to GROUPING must be grouping expressions of the associated query level",
+	)
+}
+
 // allowImplicitGroupingColumn returns true if col is part of a table and the
 // groupby metadata indicates that we are grouping on the entire PK, an entire
 // unique index key, or an entire unique without index key of that table. In
@@ -935,6 +1378,11 @@ func newGroupingError(name tree.Name) error {
 // In the unique index or unique without index cases, all key columns must be
 // marked as NOT NULL to allow the implicit grouping.
 func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bool {
+	if g.groupingSets != nil {
+		// The column would not be NULL in the rows of the grouping sets which
+		// don't contain the PK.
+		return false
+	}
 	md := b.factory.Metadata()
 	colMeta := md.ColumnMeta(colID)
 	if colMeta.Table == 0 {
@@ -963,6 +1411,10 @@ func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bo
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for i := 0; i < tab.UniqueCount(); i++ {
 		uniqueConstraint := tab.Unique(i)
+		if uniqueConstraint.IsExclusion() {
+			// Exclusion constraints do not make their columns unique.
+			continue
+		}
 		var uniqueCols opt.ColSet
 		nullable := false
 		for j := 0; j < uniqueConstraint.ColumnCount(); j++ {
@@ -176,13 +176,43 @@ func init() {
 // ON CONFLICT clause is present, since it joins a new set of rows to the input
 // and thereby scrambles the input ordering.
 func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope) {
-	// Find which table we're working on, check the permissions.
-	tab, depName, alias, refColumns := b.resolveTableForMutation(ins.Table, privilege.INSERT)
+	// Find which table or view we're working on, check the permissions.
+	ds, depName, alias, refColumns := b.resolveDataSourceForMutation(ins.Table, privilege.INSERT)
+
+	// Views are inserted into by their INSTEAD OF triggers if they have any, or
+	// else by inserting into the table of an automatically updatable view.
+	var uv *updatableView
+	if view, ok := ds.(cat.View); ok {
+		if refColumns != nil {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"cannot specify a list of column IDs when inserting into view \"%s\"", view.Name()))
+		}
+		if cat.HasRowLevelTriggers(view, tree.TriggerActionTimeInsteadOf, tree.TriggerEventInsert) {
+			return b.buildInsertInsteadOfTriggers(ins, view, alias, inScope)
+		}
+		uv = b.resolveUpdatableView(view, tree.TriggerEventInsert)
+		if ins.OnConflict != nil {
+			// The ON CONFLICT clause refers to the view's columns. Copy the
+			// statement rather than modifying the AST, which may be cached.
+			insCopy := *ins
+			insCopy.OnConflict = uv.translateOnConflict(ins.OnConflict)
+			ins = &insCopy
+			if !ins.OnConflict.DoNothing {
+				b.checkPrivilege(opt.DepByID(uv.tab.ID()), uv.tab, privilege.UPDATE)
+			}
+		}
+	}
 
-	if tab.IsVirtualTable() {
-		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
-			"cannot insert into view \"%s\"", tab.Name(),
-		))
+	var tab cat.Table
+	if uv != nil {
+		tab = uv.tab
+	} else {
+		tab = ds.(cat.Table)
+		if tab.IsVirtualTable() {
+			panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot insert into view \"%s\"", tab.Name(),
+			))
+		}
 	}
 
 	// It is possible to insert into specific columns using table reference
@@ -205,12 +235,12 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	if ins.OnConflict != nil {
 		// UPSERT and INDEX ON CONFLICT will read from the table to check for
 		// duplicates.
-		b.checkPrivilege(depName, tab, privilege.SELECT)
+		b.checkPrivilege(depName, ds, privilege.SELECT)
 
 		if !ins.OnConflict.DoNothing {
 			// UPSERT and INDEX ON CONFLICT DO UPDATE may modify rows if the
 			// DO NOTHING clause is not present.
-			b.checkPrivilege(depName, tab, privilege.UPDATE)
+			b.checkPrivilege(depName, ds, privilege.UPDATE)
 		}
 	}
 
@@ -227,6 +257,8 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	} else {
 		mb.init(b, "insert", tab, alias)
 	}
+	mb.view = uv
+	mb.fireStatementTriggers = true
 
 	// Compute target columns in two cases:
 	//
@@ -277,6 +309,13 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		mb.buildInputForInsert(inScope, nil /* rows */)
 	}
 
+	// If the target is an updatable view, an UPSERT only updates the table
+	// columns that are targeted through the view.
+	var viewTargetCols tree.NameList
+	if mb.view != nil {
+		viewTargetCols = mb.viewTargetColNames()
+	}
+
 	// Add default columns that were not explicitly specified by name or
 	// implicitly targeted by input columns. Also add any computed columns. In
 	// both cases, include columns undergoing mutations in the write-only state.
@@ -331,7 +370,11 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	case ins.OnConflict.IsUpsertAlias():
 		// Add columns which will be updated by the Upsert when a conflict occurs.
 		// These are derived from the insert columns.
-		mb.setUpsertCols(ins.Columns)
+		if mb.view != nil {
+			mb.setUpsertCols(viewTargetCols)
+		} else {
+			mb.setUpsertCols(ins.Columns)
+		}
 
 		// Check whether the existing rows need to be fetched in order to detect
 		// conflicts.
@@ -348,7 +391,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 			if mb.buildRowLevelBeforeTriggers(tree.TriggerEventInsert, false /* cascade */) {
 				// INSERT triggers are able to modify the row being inserted, so we need
 				// to recompute the upsert columns.
-				mb.setUpsertCols(nil /* insertCols */)
+				mb.setUpsertCols(viewTargetCols)
 			}
 
 			// Add additional columns for computed expressions that may depend on any
@@ -362,7 +405,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 			if mb.buildRowLevelBeforeTriggers(tree.TriggerEventInsert, false /* cascade */) {
 				// INSERT triggers are able to modify the row being inserted, so we need
 				// to recompute the upsert columns.
-				mb.setUpsertCols(nil /* insertCols */)
+				mb.setUpsertCols(viewTargetCols)
 			}
 		}
 
@@ -378,6 +421,12 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		// Project row-level BEFORE triggers for INSERT.
 		mb.buildRowLevelBeforeTriggers(tree.TriggerEventInsert, false /* cascade */)
 
+		// The columns of the view are accessible to the WHERE clause and SET
+		// expressions, for both the existing and the excluded rows.
+		if mb.view != nil {
+			mb.addViewColsForUpsert()
+		}
+
 		// Add a filter from the WHERE clause if one exists. This must happen after
 		// the INSERT triggers are added, since BEFORE INSERT triggers are called
 		// for every input row, even if it doesn't end up being inserted.
@@ -409,6 +458,9 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		b.schemaDeps = append(b.schemaDeps, dep)
 	}
 
+	// Build statement-level BEFORE triggers, which fire before the mutation.
+	mb.buildStatementLevelBeforeTriggers(opt.InsertOp)
+
 	return mb.outScope
 }
 
@@ -501,7 +553,11 @@ func (mb *mutationBuilder) addTargetNamedColsForInsert(names tree.NameList) {
 	}
 
 	// Add target table columns by the names specified in the Insert statement.
-	mb.addTargetColsByName(names)
+	if mb.view != nil {
+		mb.addTargetColsByViewName(names, "insert into")
+	} else {
+		mb.addTargetColsByName(names)
+	}
 
 	// Ensure that primary key columns are in the target column list, or that
 	// they have default values.
@@ -618,6 +674,16 @@ func (mb *mutationBuilder) addTargetTableColsForInsert(maxCols int) {
 		panic(errors.AssertionFailedf("addTargetTableColsForInsert cannot be called more than once"))
 	}
 
+	// The columns of an updatable view are targeted in the order they appear in
+	// the view.
+	if mb.view != nil {
+		for i := 0; i < len(mb.view.cols) && i < maxCols; i++ {
+			mb.addTargetViewCol(&mb.view.cols[i], "insert into")
+		}
+		mb.checkNumCols(len(mb.targetColList), maxCols)
+		return
+	}
+
 	// Only consider non-mutation columns, since mutation columns are hidden from
 	// the SQL user.
 	numCols := 0
@@ -666,6 +732,16 @@ func (mb *mutationBuilder) buildInputForInsert(inScope *scope, inputRows *tree.S
 		for i, colID := range mb.targetColList {
 			desiredTypes[i] = mb.md.ColumnMeta(colID).Type
 		}
+	} else if mb.view != nil {
+		// Input columns are mapped to the columns of the updatable view. Stop at
+		// the first column that is not updatable, which cannot be the target of
+		// an INSERT.
+		for i := range mb.view.cols {
+			if mb.view.cols[i].ord < 0 {
+				break
+			}
+			desiredTypes = append(desiredTypes, mb.tab.Column(mb.view.cols[i].ord).DatumType())
+		}
 	} else {
 		desiredTypes = make([]*types.T, 0, mb.tab.ColumnCount())
 		for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
@@ -757,6 +833,9 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs, vectorIns
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
+	// Add the WITH CHECK OPTION check of the view, if any.
+	mb.buildViewCheckOption()
+
 	// Project partial index PUT boolean columns.
 	mb.projectPartialIndexPutCols()
 
@@ -767,7 +846,7 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs, vectorIns
 
 	mb.buildFKChecksForInsert()
 
-	mb.buildRowLevelAfterTriggers(opt.InsertOp)
+	mb.buildAfterTriggers(opt.InsertOp)
 
 	private := mb.makeMutationPrivate(returning != nil, vectorInsert)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
@@ -962,6 +1041,9 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
+	// Add the WITH CHECK OPTION check of the view, if any.
+	mb.buildViewCheckOption()
+
 	// Add the partial index predicate expressions to the table metadata.
 	// These expressions are used to prune fetch columns during
 	// normalization.
@@ -987,7 +1069,7 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpsert()
 
-	mb.buildRowLevelAfterTriggers(opt.InsertOp)
+	mb.buildAfterTriggers(opt.InsertOp)
 
 	private := mb.makeMutationPrivate(returning != nil, false /* vectorInsert */)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
@@ -1097,5 +1179,7 @@ func (mb *mutationBuilder) buildOnConflictWhereClause(
 			Right: whereClause.Expr,
 		},
 	}
-	mb.b.buildWhere(where, mb.outScope)
+	exprScope := mb.exprScope()
+	mb.b.buildWhere(where, exprScope)
+	mb.setOutScopeFromExprScope(exprScope)
 }
@@ -0,0 +1,553 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// duplicateMergeErrText is the error text used when a target row is joined
+// with more than one source row by a MERGE statement.
+const duplicateMergeErrText = "MERGE command cannot affect row a second time"
+
+// buildMerge builds a memo group for a MERGE statement. MERGE is built as an
+// Upsert operator whose input joins the source relation with the target table,
+// similar to INSERT ... ON CONFLICT DO UPDATE. For example:
+//
+//	CREATE TABLE abc (a INT PRIMARY KEY, b INT, c INT)
+//	MERGE INTO abc USING xyz ON a = x
+//	WHEN MATCHED AND y = 0 THEN DELETE
+//	WHEN MATCHED THEN UPDATE SET b = y
+//	WHEN NOT MATCHED THEN INSERT VALUES (x, y, z)
+//
+// This would create an input expression similar to this SQL:
+//
+//	SELECT
+//	  *,
+//	  CASE action WHEN 3 THEN x ELSE NULL END AS a_new,
+//	  CASE action WHEN 3 THEN y ELSE NULL END AS b_new,
+//	  CASE action WHEN 3 THEN z ELSE NULL END AS c_new,
+//	  CASE action WHEN 2 THEN y ELSE fetch_b END AS b_upd,
+//	  action = 1 AS merge_delete
+//	FROM (
+//	  SELECT DISTINCT ON (fetch_a) *
+//	  FROM (
+//	    SELECT
+//	      *,
+//	      CASE
+//	        WHEN fetch_a IS NOT NULL AND y = 0 THEN 1
+//	        WHEN fetch_a IS NOT NULL THEN 2
+//	        WHEN fetch_a IS NULL THEN 3
+//	        ELSE 0
+//	      END AS action
+//	    FROM xyz LEFT JOIN abc AS fetch ON a = x
+//	  )
+//	  WHERE action != 0
+//	)
+//
+// The action column identifies the first WHEN clause that applies to each
+// joined row, and rows to which no WHEN clause applies are discarded. A target
+// row that is joined with more than one source row results in an error. The
+// Upsert operator inserts a new row if the canary column (fetch_a above) is
+// NULL, deletes the existing row if the merge_delete column is true, and
+// updates it otherwise.
+func (b *Builder) buildMerge(merge *tree.Merge, inScope *scope) (outScope *scope) {
+	var hasInsert, hasUpdate, hasDelete bool
+	var notMatchedByTarget, notMatchedBySource bool
+	for _, when := range merge.Whens {
+		switch when.Match {
+		case tree.MergeNotMatchedByTarget:
+			notMatchedByTarget = true
+		case tree.MergeNotMatchedBySource:
+			notMatchedBySource = true
+		}
+		switch when.Action {
+		case tree.MergeInsert:
+			hasInsert = true
+		case tree.MergeUpdate:
+			hasUpdate = true
+		case tree.MergeDelete:
+			hasDelete = true
+		}
+	}
+
+	// Find which table we're working on, check the permissions. Select
+	// permission is always required, since existing rows must be read.
+	tab, depName, alias, refColumns := b.resolveTableForMutation(merge.Table, privilege.SELECT)
+
+	if tab.IsVirtualTable() {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot merge into view \"%s\"", tab.Name(),
+		))
+	}
+
+	if refColumns != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"cannot specify a list of column IDs with MERGE"))
+	}
+
+	if hasInsert {
+		b.checkPrivilege(depName, tab, privilege.INSERT)
+	}
+	if hasUpdate {
+		b.checkPrivilege(depName, tab, privilege.UPDATE)
+	}
+	if hasDelete {
+		b.checkPrivilege(depName, tab, privilege.DELETE)
+	}
+
+	if vectorIndexCount(tab) > 0 {
+		panic(unimplemented.Newf("merge vector index",
+			"MERGE is not supported for tables with vector indexes"))
+	}
+	if tab.TriggerCount() > 0 {
+		panic(unimplemented.Newf("merge triggers",
+			"MERGE is not supported for tables with triggers"))
+	}
+	if tab.IsRowLevelSecurityEnabled() {
+		panic(unimplemented.Newf("merge row-level security",
+			"MERGE is not supported for tables with row-level security enabled"))
+	}
+
+	// Check if this table has already been mutated in another subquery.
+	b.checkMultipleMutations(tab, generalMutation)
+
+	var mb mutationBuilder
+	mb.init(b, "merge", tab, alias)
+
+	// The source is on the left side of the join, and the target table on the
+	// right side. Rows of either side that are not joined with any row of the
+	// other side are only needed if a WHEN clause applies to them.
+	joinType := descpb.InnerJoin
+	switch {
+	case notMatchedByTarget && notMatchedBySource:
+		joinType = descpb.FullOuterJoin
+	case notMatchedByTarget:
+		joinType = descpb.LeftOuterJoin
+	case notMatchedBySource:
+		joinType = descpb.RightOuterJoin
+	}
+
+	// Build the input expression that joins the source with the target table,
+	// and determines the action of each joined row.
+	sourceCol := mb.buildInputForMerge(inScope, merge.Table, merge.Source, merge.On, joinType)
+	actionCol := mb.addMergeActionCol(merge.Whens, sourceCol)
+
+	// Project the insert, update, and delete values of each joined row.
+	mb.addMergeDeleteCol(merge.Whens, actionCol)
+	if hasInsert {
+		mb.addMergeInsertCols(merge.Whens, actionCol)
+	}
+	if hasUpdate {
+		mb.addMergeUpdateCols(merge.Whens, actionCol)
+	}
+
+	// Build the final upsert statement, including any returned expressions.
+	if resultsNeeded(merge.Returning) {
+		mb.buildUpsert(merge.Returning.(*tree.ReturningExprs))
+	} else {
+		mb.buildUpsert(nil /* returning */)
+	}
+
+	return mb.outScope
+}
+
+// buildInputForMerge constructs the join between the source relation and the
+// target table of a MERGE statement, using the given join type. If the join
+// null-extends the source, a not-null column is projected from the source and
+// returned, so that joined rows can be told apart from target rows which are
+// not matched by any source row. Otherwise, the returned column is nil.
+func (mb *mutationBuilder) buildInputForMerge(
+	inScope *scope, texpr, source tree.TableExpr, on tree.Expr, joinType descpb.JoinType,
+) (sourceCol *scopeColumn) {
+	var indexFlags *tree.IndexFlags
+	if ate, ok := texpr.(*tree.AliasedTableExpr); ok && ate.IndexFlags != nil {
+		indexFlags = ate.IndexFlags
+	}
+
+	if mb.b.evalCtx.SessionData().AvoidFullTableScansInMutations {
+		if indexFlags == nil {
+			indexFlags = &tree.IndexFlags{}
+		}
+		indexFlags.AvoidFullScan = true
+	}
+
+	// Fetch columns from different instance of the table metadata, so that it's
+	// possible to remap columns, as in buildInputForUpdate.
+	//
+	// NOTE: Include mutation columns, but be careful to never use them for any
+	//       reason other than as "fetch columns". See buildScan comment.
+	mb.fetchScope = mb.b.buildScan(
+		mb.b.addTable(mb.tab, &mb.alias),
+		tableOrdinals(mb.tab, columnKinds{
+			includeMutations: true,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags,
+		noRowLocking,
+		inScope,
+		false, /* disableNotVisibleIndex */
+		cat.PolicyScopeUpdate,
+		opt.TableSample{}, /* tableSample */
+	)
+
+	// Set list of columns that will be fetched by the input expression.
+	mb.setFetchColIDs(mb.fetchScope.cols)
+
+	sourceScope := mb.b.buildFromTables(tree.TableExprs{source}, noLocking, inScope)
+
+	// Check that the same table name is not used by the source and the target.
+	mb.b.validateJoinTableNames(sourceScope, mb.fetchScope)
+
+	if joinType == descpb.RightOuterJoin || joinType == descpb.FullOuterJoin {
+		projectionsScope := sourceScope.replace()
+		projectionsScope.appendColumnsFromScope(sourceScope)
+		sourceCol = mb.b.synthesizeColumn(
+			projectionsScope,
+			scopeColName("").WithMetadataName("merge_source"),
+			types.Bool,
+			nil, /* expr */
+			memo.TrueSingleton,
+		)
+		mb.b.constructProjectForScope(sourceScope, projectionsScope)
+		sourceScope = projectionsScope
+	}
+
+	// Add the columns of both sides. We create a new scope so that fetchScope
+	// is not modified. It will be used later to build partial index predicate
+	// expressions, and we do not want ambiguities with column names in the
+	// source.
+	mb.outScope = mb.fetchScope.replace()
+	mb.outScope.appendColumnsFromScope(sourceScope)
+	mb.outScope.appendColumnsFromScope(mb.fetchScope)
+
+	// Do not allow special functions in the ON clause.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require(
+		exprKindOn.String(),
+		tree.RejectGenerators|tree.RejectWindowApplications|tree.RejectProcedures,
+	)
+	mb.outScope.context = exprKindOn
+	filter := mb.b.buildScalar(
+		mb.outScope.resolveAndRequireType(on, types.Bool), mb.outScope, nil, nil, nil,
+	)
+	mb.outScope.context = exprKindNone
+
+	mb.outScope.expr = mb.b.constructJoin(
+		joinType,
+		sourceScope.expr,
+		mb.fetchScope.expr,
+		memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(filter)},
+		&memo.JoinPrivate{},
+		false, /* isLateral */
+	)
+
+	// Record a not-null "canary" column. After the join, this will be null if
+	// the source row is not matched by any target row, or not null otherwise.
+	// At least one not-null column must exist, since primary key columns are
+	// not-null.
+	mb.canaryColID = mb.fetchColIDs[findNotNullIndexCol(mb.tab.Index(cat.PrimaryIndex))]
+
+	return sourceCol
+}
+
+// addMergeActionCol projects an INT column that holds the 1-based ordinal of
+// the first WHEN clause that applies to each joined row, or 0 if no WHEN clause
+// applies or the applicable clause is DO NOTHING. Rows with no action are
+// discarded, and the remaining rows are checked to ensure that every target row
+// is affected at most once.
+func (mb *mutationBuilder) addMergeActionCol(
+	whens tree.MergeWhens, sourceCol *scopeColumn,
+) (actionCol *scopeColumn) {
+	// WHEN conditions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("MERGE WHEN", tree.RejectSpecial)
+
+	canaryCol := mb.outScope.getColumn(mb.canaryColID)
+	caseExpr := &tree.CaseExpr{
+		Whens: make([]*tree.When, len(whens)),
+		Else:  tree.NewDInt(0),
+	}
+	for i, when := range whens {
+		var cond tree.Expr
+		switch when.Match {
+		case tree.MergeMatched:
+			cond = &tree.IsNotNullExpr{Expr: canaryCol}
+			if sourceCol != nil {
+				cond = &tree.AndExpr{Left: cond, Right: &tree.IsNotNullExpr{Expr: sourceCol}}
+			}
+		case tree.MergeNotMatchedByTarget:
+			cond = &tree.IsNullExpr{Expr: canaryCol}
+		case tree.MergeNotMatchedBySource:
+			cond = &tree.IsNullExpr{Expr: sourceCol}
+		}
+		if when.Cond != nil {
+			cond = &tree.AndExpr{Left: cond, Right: when.Cond}
+		}
+		action := tree.NewDInt(tree.DInt(i + 1))
+		if when.Action == tree.MergeDoNothing {
+			action = tree.NewDInt(0)
+		}
+		caseExpr.Whens[i] = &tree.When{Cond: cond, Val: action}
+	}
+
+	pb := makeProjectionBuilder(mb.b, mb.outScope)
+	actionColID, _ := pb.Add(
+		scopeColName("").WithMetadataName("merge_action"), caseExpr, types.Int,
+	)
+	mb.outScope = pb.Finish()
+	actionCol = mb.outScope.getColumn(actionColID)
+
+	// Discard the rows that no WHEN clause applies to.
+	f := mb.b.factory
+	mb.outScope.expr = f.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{f.ConstructFiltersItem(f.ConstructNe(
+			f.ConstructVariable(actionColID), f.ConstructConstVal(tree.NewDInt(0), types.Int),
+		))},
+	)
+
+	// Ensure that every target row is joined with at most one source row.
+	// Source rows that are not matched by a target row have NULL primary key
+	// values, and are never considered duplicates of one another.
+	var pkCols opt.ColSet
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	for i := 0; i < primaryIndex.KeyColumnCount(); i++ {
+		pkCols.Add(mb.fetchColIDs[primaryIndex.Column(i).Ordinal()])
+	}
+	mb.outScope = mb.b.buildDistinctOn(
+		pkCols, mb.outScope, true /* nullsAreDistinct */, duplicateMergeErrText,
+	)
+	return mb.outScope.getColumn(actionColID)
+}
+
+// addMergeDeleteCol projects the boolean column that is true for the rows
+// deleted by a DELETE action. The column is always false if there are no
+// DELETE actions.
+func (mb *mutationBuilder) addMergeDeleteCol(whens tree.MergeWhens, actionCol *scopeColumn) {
+	f := mb.b.factory
+	var deleteExpr opt.ScalarExpr
+	for i, when := range whens {
+		if when.Action != tree.MergeDelete {
+			continue
+		}
+		eq := f.ConstructEq(
+			f.ConstructVariable(actionCol.id),
+			f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int),
+		)
+		if deleteExpr == nil {
+			deleteExpr = eq
+		} else {
+			deleteExpr = f.ConstructOr(deleteExpr, eq)
+		}
+	}
+	if deleteExpr == nil {
+		deleteExpr = memo.FalseSingleton
+	} else {
+		mb.mergeCanDelete = true
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	deleteCol := mb.b.synthesizeColumn(
+		projectionsScope,
+		scopeColName("").WithMetadataName("merge_delete"),
+		types.Bool,
+		nil, /* expr */
+		deleteExpr,
+	)
+	mb.mergeDeleteColID = deleteCol.id
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
+
+// addMergeInsertCols projects the values inserted by the INSERT actions. For
+// each insertable table column, a CASE expression selects the value of the
+// INSERT action that applies to the row, or the column default if the action
+// does not specify the column.
+func (mb *mutationBuilder) addMergeInsertCols(whens tree.MergeWhens, actionCol *scopeColumn) {
+	// INSERT values should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("MERGE INSERT", tree.RejectSpecial)
+
+	// Determine the value of each target column for each INSERT action. A nil
+	// value indicates that the column takes its default value.
+	values := make([][]tree.Expr, len(whens))
+	for i, when := range whens {
+		if when.Action != tree.MergeInsert {
+			continue
+		}
+		mb.targetColList = mb.targetColList[:0]
+		mb.targetColSet = opt.ColSet{}
+		if when.Columns != nil {
+			mb.addTargetNamedColsForInsert(when.Columns)
+			if when.Values != nil {
+				mb.checkNumCols(len(mb.targetColList), len(when.Values))
+			}
+		} else if when.Values != nil {
+			mb.addTargetTableColsForInsert(len(when.Values))
+		}
+
+		values[i] = make([]tree.Expr, mb.tab.ColumnCount())
+		for j, val := range when.Values {
+			ord := mb.tabID.ColumnOrdinal(mb.targetColList[j])
+			if _, ok := val.(tree.DefaultVal); ok {
+				continue
+			}
+
+			// Raise an error if the target column is a `GENERATED ALWAYS AS
+			// IDENTITY` column. Such a column is not allowed to be explicitly
+			// written to.
+			if col := mb.tab.Column(ord); col.IsGeneratedAlwaysAsIdentity() {
+				panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnOverrideError(string(col.ColName())))
+			}
+			values[i][ord] = val
+		}
+	}
+	mb.targetColList = mb.targetColList[:0]
+	mb.targetColSet = opt.ColSet{}
+
+	pb := makeProjectionBuilder(mb.b, mb.outScope)
+	for ord, n := 0, mb.tab.ColumnCount(); ord < n; ord++ {
+		tabCol := mb.tab.Column(ord)
+		if tabCol.Kind() != cat.Ordinary || tabCol.IsComputed() {
+			continue
+		}
+		tabColID := mb.tabID.ColumnID(ord)
+		caseExpr := &tree.CaseExpr{Expr: actionCol, Else: tree.DNull}
+		for i, when := range whens {
+			if when.Action != tree.MergeInsert {
+				continue
+			}
+			val := values[i][ord]
+			if val == nil {
+				val = mb.parseDefaultExpr(tabColID)
+			}
+			caseExpr.Whens = append(caseExpr.Whens, &tree.When{
+				Cond: tree.NewDInt(tree.DInt(i + 1)), Val: val,
+			})
+		}
+
+		// The column is given an empty reference name, so that it does not
+		// make references to the source or target columns of the same name
+		// ambiguous.
+		colName := scopeColName("").WithMetadataName(string(tabCol.ColName()) + "_new")
+		mb.insertColIDs[ord], _ = pb.Add(colName, caseExpr, tabCol.DatumType())
+	}
+	mb.outScope = pb.Finish()
+
+	// Add assignment casts for insert columns.
+	mb.addAssignmentCasts(mb.insertColIDs)
+
+	// Add write-only and computed columns. Computed insert values must be
+	// derived from the other insert values rather than from the values of the
+	// existing row, so the fetch columns are hidden while they are built.
+	fetchColIDs := mb.fetchColIDs
+	mb.fetchColIDs = make(opt.OptionalColList, len(fetchColIDs))
+	mb.addSynthesizedColsForInsert()
+	mb.fetchColIDs = fetchColIDs
+}
+
+// addMergeUpdateCols projects the values set by the UPDATE actions. For each
+// table column set by any UPDATE action, a CASE expression selects the value
+// set by the UPDATE action that applies to the row, or the existing value if
+// the action does not set the column.
+func (mb *mutationBuilder) addMergeUpdateCols(whens tree.MergeWhens, actionCol *scopeColumn) {
+	// SET expressions should reject aggregates, generators, etc.
+	scalarProps := &mb.b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+	mb.b.semaCtx.Properties.Require("MERGE UPDATE SET", tree.RejectSpecial)
+
+	// Determine the value set for each target column by each UPDATE action.
+	values := make([][]tree.Expr, len(whens))
+	for i, when := range whens {
+		if when.Action != tree.MergeUpdate {
+			continue
+		}
+		mb.targetColList = mb.targetColList[:0]
+		mb.targetColSet = opt.ColSet{}
+		values[i] = make([]tree.Expr, mb.tab.ColumnCount())
+		for _, set := range when.Exprs {
+			mb.addTargetColsByName(set.Names)
+
+			exprs := tree.Exprs{set.Expr}
+			if set.Tuple {
+				t, ok := set.Expr.(*tree.Tuple)
+				if !ok {
+					panic(unimplemented.Newf("merge update tuple",
+						"source for a multiple-column MERGE UPDATE item must be a ROW() expression"))
+				}
+				if len(set.Names) != len(t.Exprs) {
+					panic(pgerror.Newf(pgcode.Syntax,
+						"number of columns (%d) does not match number of values (%d)",
+						len(set.Names), len(t.Exprs)))
+				}
+				exprs = t.Exprs
+			}
+
+			targetIdx := len(mb.targetColList) - len(exprs)
+			for j, expr := range exprs {
+				ord := mb.tabID.ColumnOrdinal(mb.targetColList[targetIdx+j])
+				targetCol := mb.tab.Column(ord)
+
+				// Allow right side of SET to be DEFAULT.
+				if _, ok := expr.(tree.DefaultVal); ok {
+					expr = mb.parseDefaultExpr(mb.tabID.ColumnID(ord))
+				} else if targetCol.IsGeneratedAlwaysAsIdentity() {
+					// GENERATED ALWAYS AS IDENTITY columns are not allowed to be
+					// explicitly written to.
+					panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnUpdateError(string(targetCol.ColName())))
+				}
+				values[i][ord] = expr
+			}
+		}
+	}
+	mb.targetColList = mb.targetColList[:0]
+	mb.targetColSet = opt.ColSet{}
+
+	pb := makeProjectionBuilder(mb.b, mb.outScope)
+	for ord, n := 0, mb.tab.ColumnCount(); ord < n; ord++ {
+		caseExpr := &tree.CaseExpr{Expr: actionCol}
+		for i := range whens {
+			if values[i] == nil || values[i][ord] == nil {
+				continue
+			}
+			caseExpr.Whens = append(caseExpr.Whens, &tree.When{
+				Cond: tree.NewDInt(tree.DInt(i + 1)), Val: values[i][ord],
+			})
+		}
+		if len(caseExpr.Whens) == 0 {
+			continue
+		}
+		caseExpr.Else = mb.outScope.getColumn(mb.fetchColIDs[ord])
+
+		tabCol := mb.tab.Column(ord)
+		colName := scopeColName(tabCol.ColName()).WithMetadataName(string(tabCol.ColName()) + "_new")
+		mb.updateColIDs[ord], _ = pb.Add(colName, caseExpr, tabCol.DatumType())
+	}
+	mb.outScope = pb.Finish()
+
+	// Add assignment casts for update columns.
+	mb.addAssignmentCasts(mb.updateColIDs)
+
+	// Add additional columns for computed expressions that may depend on the
+	// updated columns.
+	mb.addSynthesizedColsForUpdate()
+}
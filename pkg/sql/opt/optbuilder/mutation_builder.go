@@ -169,6 +169,15 @@ type mutationBuilder struct {
 	// an insert; otherwise it's an update.
 	canaryColID opt.ColumnID
 
+	// mergeDeleteColID is the ID of the boolean column that is true for input
+	// rows whose existing row is deleted by a MERGE statement. It is only set
+	// for MERGE statements.
+	mergeDeleteColID opt.ColumnID
+
+	// mergeCanDelete is true if the MERGE statement has DELETE actions, in which
+	// case mergeDeleteColID can be true for some rows.
+	mergeCanDelete bool
+
 	// arbiters is the set of indexes and unique constraints that are used to
 	// detect conflicts for UPSERT and INSERT ON CONFLICT statements.
 	arbiters arbiterSet
@@ -200,6 +209,10 @@ type mutationBuilder struct {
 	// once and cached for reuse.
 	parsedUniqueConstraintExprs []tree.Expr
 
+	// domainCheckedCols contains the columns which were built with checks of
+	// the constraints of their domain type by addAssignmentCasts.
+	domainCheckedCols opt.ColSet
+
 	// uniqueChecks contains unique check queries; see buildUnique* methods.
 	uniqueChecks memo.UniqueChecksExpr
 
@@ -213,9 +226,14 @@ type mutationBuilder struct {
 	// cascades contains foreign key check cascades; see buildFK* methods.
 	cascades memo.FKCascades
 
-	// afterTriggers contains AFTER triggers; see buildRowLevelAfterTriggers.
+	// afterTriggers contains AFTER triggers; see buildAfterTriggers.
 	afterTriggers *memo.AfterTriggers
 
+	// fireStatementTriggers is true if statement-level triggers should be built
+	// for the mutation. It is false for mutations built for FK cascades, which do
+	// not fire statement-level triggers.
+	fireStatementTriggers bool
+
 	// withID is nonzero if we need to buffer the input for FK or uniqueness
 	// checks.
 	withID opt.WithID
@@ -227,6 +245,15 @@ type mutationBuilder struct {
 	// RETURNING clause, respectively.
 	extraAccessibleCols []scopeColumn
 
+	// view is set if the target of the mutation is an automatically updatable
+	// view, in which case tab is the view's table.
+	view *updatableView
+
+	// viewCols contains the columns of the updatable view, if view is set. They
+	// are accessible to the expressions of the mutation statement instead of
+	// the table's columns.
+	viewCols []scopeColumn
+
 	// fkCheckHelper is used to prevent allocating the helper separately.
 	fkCheckHelper fkCheckHelper
 
@@ -363,6 +390,7 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		inScope,
 		false, /* disableNotVisibleIndex */
 		cat.PolicyScopeUpdate,
+		opt.TableSample{}, /* tableSample */
 	)
 
 	// Set list of columns that will be fetched by the input expression.
@@ -396,23 +424,29 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		mb.outScope = mb.fetchScope
 	}
 
+	// Filter the rows to those visible through the updatable view.
+	if mb.view != nil {
+		mb.buildViewFilter()
+	}
+
 	// WHERE
-	mb.b.buildWhere(where, mb.outScope)
+	exprScope := mb.exprScope()
+	mb.b.buildWhere(where, exprScope)
 
 	// SELECT + ORDER BY (which may add projected expressions)
-	projectionsScope := mb.outScope.replace()
-	projectionsScope.appendColumnsFromScope(mb.outScope)
-	orderByScope := mb.b.analyzeOrderBy(orderBy, mb.outScope, projectionsScope,
+	projectionsScope := exprScope.replace()
+	projectionsScope.appendColumnsFromScope(exprScope)
+	orderByScope := mb.b.analyzeOrderBy(orderBy, exprScope, projectionsScope,
 		exprKindOrderByUpdate, tree.RejectGenerators|tree.RejectAggregates)
-	mb.b.buildOrderBy(mb.outScope, projectionsScope, orderByScope)
-	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.b.buildOrderBy(exprScope, projectionsScope, orderByScope)
+	mb.b.constructProjectForScope(exprScope, projectionsScope)
 
 	// LIMIT
 	if limit != nil {
 		mb.b.buildLimit(limit, inScope, projectionsScope)
 	}
 
-	mb.outScope = projectionsScope
+	mb.setOutScopeFromExprScope(projectionsScope)
 
 	// Build a distinct-on operator on the primary key columns to ensure there
 	// is at most one row in the joined output for every row in the target
@@ -482,6 +516,7 @@ func (mb *mutationBuilder) buildInputForDelete(
 		inScope,
 		false, /* disableNotVisibleIndex */
 		cat.PolicyScopeDelete,
+		opt.TableSample{}, /* tableSample */
 	)
 
 	// Set list of columns that will be fetched by the input expression.
@@ -517,23 +552,29 @@ func (mb *mutationBuilder) buildInputForDelete(
 		mb.outScope = mb.fetchScope
 	}
 
+	// Filter the rows to those visible through the updatable view.
+	if mb.view != nil {
+		mb.buildViewFilter()
+	}
+
 	// WHERE
-	mb.b.buildWhere(where, mb.outScope)
+	exprScope := mb.exprScope()
+	mb.b.buildWhere(where, exprScope)
 
 	// SELECT + ORDER BY (which may add projected expressions)
-	projectionsScope := mb.outScope.replace()
-	projectionsScope.appendColumnsFromScope(mb.outScope)
-	orderByScope := mb.b.analyzeOrderBy(orderBy, mb.outScope, projectionsScope,
+	projectionsScope := exprScope.replace()
+	projectionsScope.appendColumnsFromScope(exprScope)
+	orderByScope := mb.b.analyzeOrderBy(orderBy, exprScope, projectionsScope,
 		exprKindOrderByDelete, tree.RejectGenerators|tree.RejectAggregates)
-	mb.b.buildOrderBy(mb.outScope, projectionsScope, orderByScope)
-	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.b.buildOrderBy(exprScope, projectionsScope, orderByScope)
+	mb.b.constructProjectForScope(exprScope, projectionsScope)
 
 	// LIMIT
 	if limit != nil {
 		mb.b.buildLimit(limit, inScope, projectionsScope)
 	}
 
-	mb.outScope = projectionsScope
+	mb.setOutScopeFromExprScope(projectionsScope)
 
 	// Build a distinct on to ensure there is at most one row in the joined output
 	// for every row in the table.
@@ -1342,6 +1383,7 @@ func (mb *mutationBuilder) makeMutationPrivate(
 		FetchCols:                      checkEmptyList(mb.fetchColIDs),
 		UpdateCols:                     checkEmptyList(mb.updateColIDs),
 		CanaryCol:                      mb.canaryColID,
+		MergeDeleteCol:                 mb.mergeDeleteColID,
 		ArbiterIndexes:                 mb.arbiters.IndexOrdinals(),
 		ArbiterConstraints:             mb.arbiters.UniqueConstraintOrdinals(),
 		CheckCols:                      checkEmptyList(mb.checkColIDs),
@@ -1442,6 +1484,12 @@ func (mb *mutationBuilder) buildReturning(returning *tree.ReturningExprs) {
 	inScope.expr = mb.outScope.expr
 	inScope.appendOrdinaryColumnsFromTable(mb.md.TableMeta(mb.tabID), &mb.alias)
 
+	// If the target is an updatable view, the RETURNING clause refers to the
+	// view's columns instead of the table's columns.
+	if mb.view != nil {
+		mb.appendViewColsForReturning(inScope)
+	}
+
 	// extraAccessibleCols contains all the columns that the RETURNING
 	// clause can refer to in addition to the table columns. This is useful for
 	// UPDATE ... FROM and DELETE ... USING statements, where all columns from
@@ -1460,6 +1508,12 @@ func (mb *mutationBuilder) buildReturning(returning *tree.ReturningExprs) {
 // checkNumCols raises an error if the expected number of columns does not match
 // the actual number of columns.
 func (mb *mutationBuilder) checkNumCols(expected, actual int) {
+	checkNumCols(mb.opName, expected, actual)
+}
+
+// checkNumCols raises an error if the expected number of columns does not match
+// the actual number of columns for the given mutation operator.
+func checkNumCols(opName string, expected, actual int) {
 	if actual != expected {
 		more, less := "expressions", "target columns"
 		if actual < expected {
@@ -1468,7 +1522,7 @@ func (mb *mutationBuilder) checkNumCols(expected, actual int) {
 
 		panic(pgerror.Newf(pgcode.Syntax,
 			"%s has more %s than %s, %d expressions for %d targets",
-			strings.ToUpper(mb.opName), more, less, actual, expected))
+			strings.ToUpper(opName), more, less, actual, expected))
 	}
 }
 
@@ -1498,6 +1552,13 @@ func (mb *mutationBuilder) parseDefaultExpr(colID opt.ColumnID) tree.Expr {
 	col := mb.tab.Column(ord)
 	exprStr := col.DefaultExprStr()
 
+	// A column of a domain type without a default expression uses the default
+	// expression of the domain, if any.
+	if typ := col.DatumType(); exprStr == "" && typ.IsDomain() &&
+		typ.TypeMeta.DomainData != nil && typ.TypeMeta.DomainData.DefaultExpr != nil {
+		exprStr = *typ.TypeMeta.DomainData.DefaultExpr
+	}
+
 	// If no default expression, return NULL or a default value.
 	if exprStr == "" {
 		if col.IsMutation() && !col.IsNullable() {
@@ -1696,21 +1757,33 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 		targetCol := mb.tab.Column(ord)
 		targetType := mb.tab.Column(ord).DatumType()
 
+		// Values of a domain type must satisfy the constraints of the domain.
+		// Mutation columns are not checked, since they may be filled with a
+		// synthesized value. Columns that were already checked are skipped,
+		// since this function is called more than once for insert columns.
+		checkDomain := targetType.IsDomain() && !targetCol.IsMutation() &&
+			!mb.domainCheckedCols.Contains(colID)
+
 		// An assignment cast is not necessary if the source and target types
 		// are identical.
-		if srcType.Identical(targetType) {
+		if srcType.Identical(targetType) && !checkDomain {
 			continue
 		}
 
-		// Check if an assignment cast is available from the inScope column
-		// type to the out type.
-		if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
-			panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(targetCol.ColName())))
-		}
+		var scalar opt.ScalarExpr = mb.b.factory.ConstructVariable(colID)
+		if !srcType.Identical(targetType) {
+			// Check if an assignment cast is available from the inScope column
+			// type to the out type.
+			if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
+				panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(targetCol.ColName())))
+			}
 
-		// Create the cast expression.
-		variable := mb.b.factory.ConstructVariable(colID)
-		cast := mb.b.factory.ConstructAssignmentCast(variable, targetType)
+			// Create the cast expression.
+			scalar = mb.b.factory.ConstructAssignmentCast(scalar, targetType)
+		}
+		if checkDomain {
+			scalar = mb.b.buildDomainChecks(scalar, targetType)
+		}
 
 		// Lazily create the new scope.
 		if projectionScope == nil {
@@ -1725,7 +1798,10 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 		// column, we perform a lookup with the ID and the name. See #61520.
 		scopeCol := projectionScope.getColumnWithIDAndReferenceName(colID, targetCol.ColName())
 		scopeCol.name = scopeCol.name.WithMetadataName(fmt.Sprintf("%s_cast", targetCol.ColName()))
-		mb.b.populateSynthesizedColumn(scopeCol, cast)
+		mb.b.populateSynthesizedColumn(scopeCol, scalar)
+		if checkDomain {
+			mb.domainCheckedCols.Add(scopeCol.id)
+		}
 
 		// Replace old source column with the new one.
 		srcCols[ord] = scopeCol.id
@@ -1862,6 +1938,29 @@ func (mb *mutationBuilder) buildCheckInputScan(
 	}
 
 	mb.ensureWithID()
+
+	// Rows deleted by a MERGE statement have no new values. Scan the delete
+	// column as well so that they can be filtered out.
+	if typ == checkInputScanNewVals && mb.mergeCanDelete {
+		deleteCol := mb.md.AddColumn("merge_delete", types.Bool)
+		withScan := mb.b.factory.ConstructWithScan(&memo.WithScanPrivate{
+			With:       mb.withID,
+			InCols:     append(inputCols, mb.mergeDeleteColID),
+			OutCols:    append(outScope.colList(), deleteCol),
+			ID:         mb.b.factory.Metadata().NextUniqueID(),
+			CheckInput: true,
+		})
+		notDeleted := mb.b.factory.ConstructNot(mb.b.factory.ConstructVariable(deleteCol))
+		outScope.expr = mb.b.factory.ConstructProject(
+			mb.b.factory.ConstructSelect(
+				withScan, memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(notDeleted)},
+			),
+			memo.EmptyProjectionsExpr,
+			outScope.colSet(),
+		)
+		return outScope, notNullOutCols
+	}
+
 	outScope.expr = mb.b.factory.ConstructWithScan(&memo.WithScanPrivate{
 		With:       mb.withID,
 		InCols:     inputCols,
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
 )
@@ -74,6 +75,10 @@ func (mb *mutationBuilder) findArbiters(onConflict *tree.OnConflict) arbiterSet
 		for i, uc := 0, mb.tab.UniqueCount(); i < uc; i++ {
 			constraint := mb.tab.Unique(i)
 			if constraint.Name() == string(onConflict.Constraint) {
+				if constraint.IsExclusion() {
+					panic(unimplemented.NewWithIssue(46657,
+						"ON CONFLICT with an exclusion constraint as the arbiter"))
+				}
 				if _, partial := constraint.Predicate(); partial {
 					panic(partialIndexArbiterError(onConflict, mb.tab.Name()))
 				}
@@ -161,7 +166,9 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 			}
 		}
 		for uc, ucCount := 0, mb.tab.UniqueCount(); uc < ucCount; uc++ {
-			if mb.tab.Unique(uc).WithoutIndex() {
+			// Exclusion constraints cannot be arbiters, so conflicting rows are
+			// reported as errors by the check built for the mutation.
+			if u := mb.tab.Unique(uc); u.WithoutIndex() && !u.IsExclusion() {
 				arbiters.AddUniqueConstraint(uc)
 			}
 		}
@@ -215,8 +222,9 @@ func (mb *mutationBuilder) inferArbitersFromConflictOrds(
 	// unique constraint if it exists before returning any partial indexes.
 	for uc, ucCount := 0, mb.tab.UniqueCount(); uc < ucCount; uc++ {
 		uniqueConstraint := mb.tab.Unique(uc)
-		if !uniqueConstraint.WithoutIndex() {
-			// Unique constraints with an index were handled above.
+		if !uniqueConstraint.WithoutIndex() || uniqueConstraint.IsExclusion() {
+			// Unique constraints with an index were handled above, and exclusion
+			// constraints cannot be arbiters.
 			continue
 		}
 
@@ -338,6 +346,7 @@ func (mb *mutationBuilder) buildAntiJoinForDoNothingArbiter(
 		// TODO(136704): Review and adjust the scope used here after implementing
 		// WITH CHECK to ensure correct filtering behavior for UPSERT operations.
 		cat.PolicyScopeExempt,
+		opt.TableSample{}, /* tableSample */
 	)
 
 	// If the index is a unique partial index, then rows that are not in the
@@ -480,6 +489,7 @@ func (mb *mutationBuilder) buildLeftJoinForUpsertArbiter(
 		// TODO(136704): Review and adjust the scope used here after implementing
 		// WITH CHECK to ensure correct filtering behavior for UPSERT operations.
 		cat.PolicyScopeExempt,
+		opt.TableSample{}, /* tableSample */
 	)
 	// Set fetchColIDs to reference the columns created for the fetch values.
 	mb.setFetchColIDs(mb.fetchScope.cols)
@@ -700,6 +710,7 @@ func (h *arbiterPredicateHelper) tableScope() *scope {
 			// TODO(136704): Review and adjust the scope used here after implementing
 			// WITH CHECK to ensure correct filtering behavior for UPSERT operations.
 			cat.PolicyScopeExempt,
+			opt.TableSample{}, /* tableSample */
 		)
 	}
 	return h.tableScopeLazy
@@ -16,6 +16,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -404,8 +405,10 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 	for i := 0; i < numInbound; i++ {
 		// Verify that at least one FK column is updated by the Upsert; columns that
 		// are not updated can get new values (through the insert path) but existing
-		// values are never removed.
-		if !mb.inboundFKColsUpdated(i) {
+		// values are never removed. Existing values are also removed when a MERGE
+		// statement deletes rows.
+		colsUpdated := mb.inboundFKColsUpdated(i)
+		if !colsUpdated && !mb.mergeCanDelete {
 			continue
 		}
 
@@ -413,6 +416,20 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 			continue
 		}
 
+		if mb.mergeCanDelete {
+			// Cascades are built for all the rows of the mutation input, so they
+			// cannot be limited to the rows that a MERGE deletes. The deletion
+			// check below handles both deleted and updated rows.
+			if a := h.fk.DeleteReferenceAction(); a != tree.Restrict && a != tree.NoAction {
+				panic(unimplemented.Newf("merge delete cascade",
+					"MERGE with DELETE actions on a table referenced by a foreign key with ON DELETE %s", a))
+			}
+			if a := h.fk.UpdateReferenceAction(); colsUpdated && a != tree.Restrict && a != tree.NoAction {
+				panic(unimplemented.Newf("merge delete cascade",
+					"MERGE with DELETE actions on a table referenced by a foreign key with ON UPDATE %s", a))
+			}
+		}
+
 		if a := h.fk.UpdateReferenceAction(); a != tree.Restrict && a != tree.NoAction {
 			telemetry.Inc(sqltelemetry.ForeignKeyCascadesUseCounter)
 			mb.ensureWithID()
@@ -670,6 +687,7 @@ func (h *fkCheckHelper) buildOtherTableScan(parent bool) (outScope *scope, tabMe
 		h.mb.b.allocScope(),
 		true, /* disableNotVisibleIndex */
 		cat.PolicyScopeExempt,
+		opt.TableSample{}, /* tableSample */
 	), otherTabMeta
 }
 
@@ -13,6 +13,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -52,6 +54,9 @@ func (mb *mutationBuilder) buildUniqueChecksForInsert() {
 		// For non-serializable transactions, we guarantee uniqueness by writing tombstones in all
 		// partitions of a unique index with implicit partitioning columns.
 		if mb.b.evalCtx.TxnIsoLevel != isolation.Serializable {
+			if u.IsExclusion() {
+				panic(sqlerrors.NewExclusionConstraintIsolationError())
+			}
 			if indexOrdinal, ok := u.TombstoneIndexOrdinal(); ok {
 				mb.uniqueWithTombstoneIndexes.Add(indexOrdinal)
 				continue
@@ -69,7 +74,11 @@ func (mb *mutationBuilder) buildUniqueChecksForInsert() {
 		}
 
 		if h.init(mb, i) {
-			uniqueChecksItem, fastPathUniqueChecksItem := h.buildInsertionCheck(buildFastPathCheck)
+			// Fast path checks look up rows equal to the inserted row, which does
+			// not work for the non-equality operators of exclusion constraints.
+			uniqueChecksItem, fastPathUniqueChecksItem := h.buildInsertionCheck(
+				buildFastPathCheck && !u.IsExclusion(),
+			)
 			if fastPathUniqueChecksItem == nil {
 				// If we can't build one fast path check, don't build any of them into
 				// the expression tree.
@@ -111,6 +120,9 @@ func (mb *mutationBuilder) buildUniqueChecksForUpdate() {
 		// For non-serializable transactions, we guarantee uniqueness by writing tombstones in all
 		// partitions of a unique index with implicit partitioning columns.
 		if mb.b.evalCtx.TxnIsoLevel != isolation.Serializable {
+			if u.IsExclusion() {
+				panic(sqlerrors.NewExclusionConstraintIsolationError())
+			}
 			if indexOrdinal, ok := u.TombstoneIndexOrdinal(); ok {
 				mb.uniqueWithTombstoneIndexes.Add(indexOrdinal)
 				continue
@@ -153,6 +165,9 @@ func (mb *mutationBuilder) buildUniqueChecksForUpsert() {
 		// For non-serializable transactions, we guarantee uniqueness by writing tombstones in all
 		// partitions of a unique index with implicit partitioning columns.
 		if mb.b.evalCtx.TxnIsoLevel != isolation.Serializable {
+			if u.IsExclusion() {
+				panic(sqlerrors.NewExclusionConstraintIsolationError())
+			}
 			if indexOrdinal, ok := u.TombstoneIndexOrdinal(); ok {
 				mb.uniqueWithTombstoneIndexes.Add(indexOrdinal)
 				continue
@@ -250,9 +265,14 @@ type uniqueCheckHelper struct {
 	uniqueOrdinals intsets.Fast
 
 	// primaryKeyOrdinals includes the ordinals from any primary key columns
-	// that are not included in uniqueOrdinals.
+	// that are not included in uniqueOrdinals. For exclusion constraints it
+	// includes all primary key columns.
 	primaryKeyOrdinals intsets.Fast
 
+	// exclusionOperators maps the ordinals of the columns of an exclusion
+	// constraint that are not compared with equality to their operator.
+	exclusionOperators map[int]treecmp.ComparisonOperatorSymbol
+
 	// The scope and column ordinals of the scan that will serve as the right
 	// side of the semi join for the uniqueness checks.
 	scanScope    *scope
@@ -276,6 +296,17 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
 		uniqueOrds.Add(h.unique.ColumnOrdinal(mb.tab, i))
 	}
+	isExclusion := h.unique.IsExclusion()
+	if isExclusion {
+		for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
+			if op := h.unique.ExclusionOperator(i); op != treecmp.EQ {
+				if h.exclusionOperators == nil {
+					h.exclusionOperators = make(map[int]treecmp.ComparisonOperatorSymbol)
+				}
+				h.exclusionOperators[h.unique.ColumnOrdinal(mb.tab, i)] = op
+			}
+		}
+	}
 
 	// Find the primary key columns that are not part of the unique constraint.
 	// If there aren't any, we don't need a check.
@@ -284,8 +315,15 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// Similarly, we don't need a check for a partial unique constraint if there
 	// exists a non-partial unique constraint with columns that are a subset of
 	// the partial unique constraint columns.
+	//
+	// Rows that are distinct on the primary key can still conflict under an
+	// exclusion constraint with non-equality operators, so the check is always
+	// needed for those, and all primary key columns are used to tell rows
+	// apart.
 	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-	primaryOrds.DifferenceWith(uniqueOrds)
+	if !isExclusion {
+		primaryOrds.DifferenceWith(uniqueOrds)
+	}
 	if primaryOrds.Empty() {
 		// The primary key columns are a subset of the unique columns; unique check
 		// not needed.
@@ -345,6 +383,12 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// However, because the region column is computed and depends only on k, the
 	// presence of the unique index on (region, k) (i.e., the primary index) is
 	// sufficient to guarantee the uniqueness of k.
+	//
+	// This does not apply to exclusion constraints, since rows with distinct
+	// values can still conflict.
+	if isExclusion {
+		return true
+	}
 	var uniqueCols opt.ColSet
 	h.uniqueOrdinals.ForEach(func(ord int) {
 		colID := h.scanScope.cols[ord].id
@@ -484,6 +528,10 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	// Build the join filters:
 	//   (new_a = existing_a) AND (new_b = existing_b) AND ...
 	//
+	// For exclusion constraints, the columns compared with && or -|- use that
+	// operator instead of an equality. The scan of the table can then use the
+	// index backing the constraint, e.g. in an inverted join.
+	//
 	// Set the capacity to h.uniqueOrdinals.Len()+1 since we'll have an equality
 	// condition for each column in the unique constraint, plus one additional
 	// condition to prevent rows from matching themselves (see below). If the
@@ -496,12 +544,24 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	}
 	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
 	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
-		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
-			f.ConstructEq(
-				f.ConstructVariable(uniqueCheckScope.cols[i].id),
-				f.ConstructVariable(h.scanScope.cols[i].id),
-			),
-		))
+		newVal := f.ConstructVariable(uniqueCheckScope.cols[i].id)
+		existingVal := f.ConstructVariable(h.scanScope.cols[i].id)
+		var cond opt.ScalarExpr
+		switch h.exclusionOperators[i] {
+		case treecmp.Overlaps:
+			if h.scanScope.cols[i].typ.Family() == types.GeometryFamily {
+				// The && operator means "intersects" with geometry operands, like
+				// in the optbuilder.
+				cond = f.ConstructBBoxIntersects(newVal, existingVal)
+			} else {
+				cond = f.ConstructOverlaps(newVal, existingVal)
+			}
+		case treecmp.Adjacent:
+			cond = f.ConstructAdjacent(newVal, existingVal)
+		default:
+			cond = f.ConstructEq(newVal, existingVal)
+		}
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(cond))
 	}
 	// Find the ScanExpr which reads from the table this unique check applies to.
 	var uniqueFastPathCheck memo.RelExpr
@@ -668,6 +728,7 @@ func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
 		h.mb.b.allocScope(),
 		true, /* disableNotVisibleIndex */
 		cat.PolicyScopeExempt,
+		opt.TableSample{}, /* tableSample */
 	), ordinals
 }
 
@@ -82,5 +82,9 @@ func (b *Builder) tryBuildOpaque(stmt tree.Statement, inScope *scope) (outScope
 	default:
 		panic(errors.AssertionFailedf("invalid opaque statement type %d", info.typ))
 	}
+	if truncate, ok := stmt.(*tree.Truncate); ok {
+		// TRUNCATE fires the TRUNCATE triggers of the truncated tables.
+		outScope.expr = b.buildTruncateTriggers(truncate, outScope.expr)
+	}
 	return outScope
 }
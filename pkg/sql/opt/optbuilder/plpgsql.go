@@ -25,8 +25,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
+	"golang.org/x/text/language"
 )
 
 // plpgsqlBuilder translates a PLpgSQL AST into a series of SQL routines that
@@ -174,6 +176,11 @@ type plpgsqlBuilder struct {
 	// context; see also continuationType.
 	continuations []continuation
 
+	// loopCursors is a stack with the internal cursors of the query FOR loops
+	// whose body is being built. Statements that leave the body of such a loop
+	// other than through its end must close the cursor.
+	loopCursors []loopCursor
+
 	// blocks is a stack containing every block in the path from the root block to
 	// the current block. It is necessary to track the entire stack because
 	// variables from a parent block can be referenced in a child block.
@@ -264,11 +271,21 @@ type plBlock struct {
 	// constants tracks the variables that were declared as constant.
 	constants map[ast.Variable]struct{}
 
+	// notNulls tracks the variables that were declared NOT NULL. Assigning NULL
+	// to one of these variables results in an error.
+	notNulls map[ast.Variable]struct{}
+
 	// cursors is the set of cursor declarations for a PL/pgSQL block. It is set
 	// for bound cursor declarations, which allow a query to be associated with a
 	// cursor before it is opened.
 	cursors map[ast.Variable]ast.CursorDeclaration
 
+	// records maps from each RECORD variable declared in the block to the types
+	// of the rows that can be assigned to it. A RECORD variable that can be
+	// assigned rows of different types, or rows with a structure that is only
+	// known at execution time, has type AnyTuple; see inferRecordVarType.
+	records map[ast.Variable][]*types.T
+
 	// hiddenVars is an ordered list of *hidden* variables that were not declared
 	// by the user, but are used internally by the builder. Hidden variables are
 	// not visible to the user, and are identified by their metadata name. They
@@ -308,6 +325,15 @@ func (b *plpgsqlBuilder) buildRootBlock(
 	s = s.push()
 	b.ensureScopeHasExpr(s)
 
+	// References to the fields of RECORD variables are resolved by the PL/pgSQL
+	// builder; see buildRecordField. Restore the previous hook afterward, since
+	// PL/pgSQL routines can be built while building another PL/pgSQL routine.
+	prevRecordField := b.ob.plpgsqlRecordField
+	b.ob.plpgsqlRecordField = b.buildRecordField
+	defer func() {
+		b.ob.plpgsqlRecordField = prevRecordField
+	}()
+
 	// Initialize OUT parameters to NULL. Note that the initial block for
 	// parameters was already created in newPLpgSQLBuilder().
 	for _, param := range routineParams {
@@ -327,14 +353,12 @@ func (b *plpgsqlBuilder) buildRootBlock(
 				// TODO(#122266): once we support this, make sure to validate that
 				// transaction control statements are only allowed in a nested procedure
 				// when all ancestors are procedures or DO blocks.
-				panic(unimplemented.NewWithIssue(122266,
-					"transaction control statements in nested routines",
-				))
-			}
-			if b.isDoBlock {
-				// Disallow transaction control statements in DO blocks for now.
-				panic(unimplemented.NewWithIssue(138704,
-					"transaction control statements in DO blocks",
+				panic(errors.WithHint(
+					unimplemented.NewWithIssue(122266,
+						"transaction control statements in nested routines",
+					),
+					"COMMIT and ROLLBACK are only supported in a procedure called directly "+
+						"with CALL and at the top level of a DO block",
 				))
 			}
 			// Disable stable folding, since different parts of the routine can be run
@@ -361,7 +385,9 @@ func (b *plpgsqlBuilder) pushNewBlock(astBlock *ast.Block) *plBlock {
 		vars:      make([]ast.Variable, 0, len(astBlock.Decls)),
 		varTypes:  make(map[ast.Variable]*types.T),
 		constants: make(map[ast.Variable]struct{}),
+		notNulls:  make(map[ast.Variable]struct{}),
 		cursors:   make(map[ast.Variable]ast.CursorDeclaration),
+		records:   make(map[ast.Variable][]*types.T),
 	})
 	if len(astBlock.Exceptions) > 0 || b.hasExceptionHandler() {
 		// If the current block or some ancestor block has an exception handler, it
@@ -375,33 +401,43 @@ func (b *plpgsqlBuilder) pushNewBlock(astBlock *ast.Block) *plBlock {
 	return block
 }
 
-// addDeclarations adds the given variable declarations to the given block.
-func (b *plpgsqlBuilder) addDeclarations(decls []ast.Statement, block *plBlock, s *scope) *scope {
-	for i := range decls {
-		switch dec := decls[i].(type) {
+// addDeclarations adds the variable declarations of the given PL/pgSQL block
+// to the given block.
+func (b *plpgsqlBuilder) addDeclarations(astBlock *ast.Block, block *plBlock, s *scope) *scope {
+	for i := range astBlock.Decls {
+		switch dec := astBlock.Decls[i].(type) {
 		case *ast.Declaration:
-			if dec.NotNull {
-				panic(notNullVarErr)
-			}
-			if dec.Collate != "" {
-				panic(collatedVarErr)
+			if dec.NotNull && dec.Expr == nil {
+				panic(pgerror.Newf(pgcode.NullValueNotAllowed,
+					"variable \"%s\" must have a default value, since it's declared NOT NULL", dec.Var,
+				))
 			}
 			typ, err := tree.ResolveType(b.ob.ctx, dec.Typ, b.ob.semaCtx.TypeResolver)
 			if err != nil {
 				panic(err)
 			}
 			if typ.Identical(types.AnyTuple) {
-				panic(recordVarErr)
+				// The structure of a RECORD variable is determined by the rows that are
+				// assigned to it.
+				typ, block.records[dec.Var] = b.inferRecordVarType(astBlock, i, s)
 			} else if typ.IsPolymorphicType() {
 				// NOTE: Postgres also returns an "unsupported" error.
 				panic(pgerror.Newf(pgcode.FeatureNotSupported,
 					"variable \"%s\" has pseudo-type %s", dec.Var, typ.Name(),
 				))
 			}
+			if dec.Collate != "" {
+				typ = resolveVariableCollation(typ, dec.Collate)
+			}
+			s = b.shadowVariable(s, dec.Var)
 			b.addVariable(dec.Var, typ)
 			if dec.Expr != nil {
 				// Some variable declarations initialize the variable.
 				s = b.addPLpgSQLAssign(s, dec.Var, dec.Expr, noIndirection)
+			} else if typ.Identical(types.AnyTuple) {
+				// An uninitialized RECORD variable is null, and has no structure until
+				// a row is assigned to it.
+				s = b.addPLpgSQLAssign(s, dec.Var, tree.DNull, noIndirection)
 			} else {
 				// Uninitialized variables are null.
 				s = b.addPLpgSQLAssign(
@@ -413,8 +449,17 @@ func (b *plpgsqlBuilder) addDeclarations(decls []ast.Statement, block *plBlock,
 				// constant variables only prevent assignment, not initialization.
 				block.constants[dec.Var] = struct{}{}
 			}
+			if dec.NotNull {
+				// Like Postgres, use a different error message when the default value
+				// of a NOT NULL variable is NULL.
+				b.addNotNullCheck(s, dec.Var, fmt.Sprintf(
+					"variable \"%s\" declared NOT NULL cannot default to NULL", dec.Var,
+				))
+				block.notNulls[dec.Var] = struct{}{}
+			}
 		case *ast.CursorDeclaration:
 			// Declaration of a bound cursor declares a variable of type refcursor.
+			s = b.shadowVariable(s, dec.Name)
 			b.addVariable(dec.Name, types.RefCursor)
 			s = b.addPLpgSQLAssign(
 				s, dec.Name, &tree.CastExpr{Expr: tree.DNull, Type: types.RefCursor}, noIndirection,
@@ -425,6 +470,25 @@ func (b *plpgsqlBuilder) addDeclarations(decls []ast.Statement, block *plBlock,
 	return s
 }
 
+// resolveVariableCollation applies the collation from a variable declaration to
+// the declared type of the variable.
+func resolveVariableCollation(typ *types.T, locale string) *types.T {
+	switch typ.Family() {
+	case types.StringFamily, types.CollatedStringFamily:
+	default:
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"collations are not supported by type %s", typ.SQLStandardName(),
+		))
+	}
+	if collatedstring.IsDefaultEquivalentCollation(locale) {
+		return typ
+	}
+	if _, err := language.Parse(locale); err != nil {
+		panic(pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid locale %s", locale))
+	}
+	return types.MakeCollatedString(typ, locale)
+}
+
 // buildBlock constructs an expression that returns the result of executing a
 // PL/pgSQL block, including variable declarations and exception handlers.
 //
@@ -434,7 +498,7 @@ func (b *plpgsqlBuilder) buildBlock(astBlock *ast.Block, s *scope) *scope {
 	b.ensureScopeHasExpr(s)
 	block := b.pushNewBlock(astBlock)
 	defer b.popBlock()
-	s = b.addDeclarations(astBlock.Decls, block, s)
+	s = b.addDeclarations(astBlock, block, s)
 
 	// For a RECORD-returning routine, infer the concrete type by examining the
 	// RETURN statements. This has to happen after building the declaration
@@ -508,37 +572,11 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			return scope
 
 		case *ast.Return:
-			// If the routine has OUT-parameters or a VOID return type, the RETURN
-			// statement must have no expression. Otherwise, the RETURN statement must
-			// have a non-empty expression.
-			expr := t.Expr
-			if b.hasOutParam() {
-				if expr != nil {
-					panic(returnWithOUTParameterErr)
-				}
-				expr = b.makeReturnForOutParams()
-			} else if b.returnType.Family() == types.VoidFamily {
-				if expr != nil {
-					if b.isProcedure {
-						panic(returnWithVoidParameterProcedureErr)
-					} else {
-						panic(returnWithVoidParameterErr)
-					}
-				}
-				expr = tree.DNull
-			}
-			if expr == nil {
-				panic(emptyReturnErr)
-			}
-			// RETURN is handled by projecting a single column with the expression
-			// that is being returned.
-			returnScalar := b.buildSQLExpr(expr, b.returnType, s)
-			b.addBarrierIfVolatile(s, returnScalar)
-			returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return"))
-			returnScope := s.push()
-			b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, returnScalar)
-			b.ob.constructProjectForScope(s, returnScope)
-			return returnScope
+			// The internal cursors of the enclosing query FOR loops are closed
+			// before returning.
+			return b.closeLoopCursors(s, -1 /* conIdx */, func(s *scope) *scope {
+				return b.buildReturn(t, s)
+			})
 
 		case *ast.Assignment:
 			// Assignment (:=) is handled by projecting a new column with the same
@@ -679,10 +717,13 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 				scope := b.handleIntForLoop(s, t, c)
 				b.popContinuation()
 				return scope
+			case *ast.QueryForLoopControl, *ast.CursorForLoopControl, *ast.DynamicQueryForLoopControl:
+				// FOR target IN query LOOP ...
+				// FOR target IN cursor LOOP ...
+				// FOR target IN EXECUTE query [ USING expr [, ...] ] LOOP ...
+				return b.handleQueryForLoop(s, t, &exitCon)
 			default:
-				panic(errors.WithDetail(unsupportedPLStmtErr,
-					"query and cursor FOR loops are not yet supported",
-				))
+				panic(errors.AssertionFailedf("unexpected FOR loop control %T", c))
 			}
 
 		case *ast.Exit:
@@ -702,7 +743,9 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 				conTypes |= continuationBlockExit
 			}
 			if con := b.getContinuation(conTypes, t.Label); con != nil {
-				return b.callContinuation(con, s)
+				return b.closeLoopCursors(s, b.continuationIdx(con), func(s *scope) *scope {
+					return b.callContinuation(con, s)
+				})
 			}
 			if t.Label == unspecifiedLabel {
 				panic(exitOutsideLoopErr)
@@ -710,7 +753,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			if t.Label == b.rootBlock().label {
 				// An EXIT from the root block has the same handling as when the routine
 				// ends with no RETURN statement.
-				return b.handleEndOfFunction(s)
+				return b.closeLoopCursors(s, -1 /* conIdx */, b.handleEndOfFunction)
 			}
 			if t.Label == b.routineName {
 				// An EXIT from the routine name itself results in an end-of-function
@@ -745,7 +788,9 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 						"block label \"%s\" cannot be used in CONTINUE", t.Label,
 					))
 				}
-				return b.callContinuation(con, s)
+				return b.closeLoopCursors(s, b.continuationIdx(con), func(s *scope) *scope {
+					return b.callContinuation(con, s)
+				})
 			}
 			if t.Label == unspecifiedLabel {
 				panic(continueOutsideLoopErr)
@@ -852,9 +897,6 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// This is handled by calling the plpgsql_open_cursor internal builtin
 			// function in a separate body statement that returns no results, similar
 			// to the RAISE implementation.
-			if t.Scroll == tree.Scroll {
-				panic(scrollableCursorErr)
-			}
 			openCon := b.makeContinuation("_stmt_open")
 			openCon.def.Volatility = volatility.Volatile
 			_, source, _, err := openCon.s.FindSourceProvidingColumn(b.ob.ctx, t.CurVar)
@@ -871,12 +913,12 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			}
 			// Initialize the routine with the information needed to pipe the first
 			// body statement into a cursor.
-			query := b.resolveOpenQuery(t)
+			query, scroll := b.resolveOpenQuery(t)
 			fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
 			fmtCtx.FormatNode(query)
 			openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
 				NameArgIdx: source.(*scopeColumn).getParamOrd(),
-				Scroll:     t.Scroll,
+				Scroll:     scroll,
 				CursorSQL:  fmtCtx.CloseAndGetString(),
 			}
 			openScope := b.buildSQLStatement(query, openCon.s)
@@ -904,11 +946,6 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// that calls the builtin function.
 			closeCon := b.makeContinuation("_stmt_close")
 			closeCon.def.Volatility = volatility.Volatile
-			const closeFnName = "crdb_internal.plpgsql_close"
-			props, overloads := builtinsregistry.GetBuiltinProperties(closeFnName)
-			if len(overloads) != 1 {
-				panic(errors.AssertionFailedf("expected one overload for %s", closeFnName))
-			}
 			_, source, _, err := closeCon.s.FindSourceProvidingColumn(b.ob.ctx, t.CurVar)
 			if err != nil {
 				if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
@@ -921,19 +958,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 					"variable \"%s\" must be of type cursor or refcursor", t.CurVar,
 				))
 			}
-			closeCall := b.ob.factory.ConstructFunction(
-				memo.ScalarListExpr{b.ob.factory.ConstructVariable(source.(*scopeColumn).id)},
-				&memo.FunctionPrivate{
-					Name:       closeFnName,
-					Typ:        types.Int,
-					Properties: props,
-					Overload:   &overloads[0],
-				},
-			)
-			closeColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_close"))
-			closeScope := closeCon.s.push()
-			b.ob.synthesizeColumn(closeScope, closeColName, types.Int, nil /* expr */, closeCall)
-			b.ob.constructProjectForScope(closeCon.s, closeScope)
+			closeScope := b.buildClose(closeCon.s, source.(*scopeColumn).id)
 			b.appendBodyStmtFromScope(&closeCon, closeScope)
 			b.appendPlpgSQLStmts(&closeCon, stmts[i+1:])
 			return b.callContinuation(&closeCon, s)
@@ -1111,9 +1136,16 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			//
 			// Build a continuation that will execute the routine in the first body
 			// statement, and then the following PL/pgSQL statements in the second.
+			//
+			// A nested DO block is built in the same way as a nested CALL statement,
+			// so that transaction control statements within it are rejected.
 			doCon := b.makeContinuation("_stmt_do")
 			doCon.def.Volatility = volatility.Volatile
-			body, bodyProps := b.ob.buildPLpgSQLDoBody(t)
+			var body memo.RelExpr
+			var bodyProps *physical.Required
+			b.ob.withinNestedPLpgSQLCall(func() {
+				body, bodyProps = b.ob.buildPLpgSQLDoBody(t)
+			})
 			b.appendBodyStmt(&doCon, body, bodyProps)
 			b.appendPlpgSQLStmts(&doCon, stmts[i+1:])
 			return b.callContinuation(&doCon, s)
@@ -1173,6 +1205,7 @@ func (b *plpgsqlBuilder) handleIntForLoop(
 	b.addHiddenVariable(upperName, types.Int)
 	b.addHiddenVariable(stepName, types.Int)
 	b.addHiddenVariable(counterName, types.Int)
+	s = b.shadowVariable(s, forLoop.Target[0])
 	b.addVariable(forLoop.Target[0], types.Int)
 
 	// Initialize the constant bounds and step size.
@@ -1277,17 +1310,363 @@ func (b *plpgsqlBuilder) handleIntForLoop(
 	return b.callContinuation(&loopCon, s)
 }
 
+// handleQueryForLoop builds a FOR loop that iterates over the rows of a query.
+// The query is opened as a cursor with an internal name, and each iteration
+// fetches the next row from the cursor and assigns it to the loop target. The
+// loop ends when the cursor returns no more rows, at which point the cursor is
+// closed. Example:
+//
+//	DECLARE
+//	  r RECORD;
+//	BEGIN
+//	  FOR r IN SELECT * FROM xy LOOP
+//	    RAISE NOTICE 'x: %, y: %', (r).x, (r).y;
+//	  END LOOP;
+//	END
+//
+// The same approach is used for a FOR loop over a bound cursor, which opens the
+// cursor with its bound query, and for a FOR loop over a dynamic query, which
+// opens the cursor with a query string that is only known at execution time.
+// The target of a cursor FOR loop is implicitly declared as a RECORD variable
+// that is local to the loop. The rows of a dynamic query are fetched as labeled
+// tuples, since their structure is not known when the routine is built.
+//
+// Like Postgres, the target of a query FOR loop retains the value of the last
+// row after the loop ends. The cursor is also closed when the loop is left through RETURN, or
+// through EXIT or CONTINUE with the label of an enclosing loop or block; see
+// closeLoopCursors. If the loop is left through an exception, the cursor is
+// closed when the exception handler rolls back the block, or when the
+// transaction is rolled back.
+func (b *plpgsqlBuilder) handleQueryForLoop(
+	s *scope, forLoop *ast.ForLoop, exitCon *continuation,
+) *scope {
+	// Resolve the query that the loop iterates over. The query of a FOR loop
+	// over a dynamic query is only known at execution time.
+	var query tree.Statement
+	var cursorVar *scopeColumn
+	var dynamic *ast.DynamicQueryForLoopControl
+	scroll := tree.UnspecifiedScroll
+	switch c := forLoop.Control.(type) {
+	case *ast.QueryForLoopControl:
+		if _, ok := c.Query.(*tree.Select); !ok {
+			panic(unimplemented.New("for loop over mutation",
+				"FOR loops over data-modifying statements are not yet supported",
+			))
+		}
+		query = c.Query
+	case *ast.CursorForLoopControl:
+		cursorVar, query, scroll = b.resolveLoopCursor(s, forLoop, c)
+	case *ast.DynamicQueryForLoopControl:
+		dynamic = c
+	default:
+		panic(errors.AssertionFailedf("unexpected FOR loop control %T", c))
+	}
+	b.checkDuplicateTargets(forLoop.Target, "FOR")
+
+	// Build an implicit block declaring hidden variables for the internal cursor
+	// and for tracking whether the last FETCH found a row. The target variables
+	// are declared by the user, so they are not part of the implicit block,
+	// except for the target of a cursor FOR loop.
+	b.pushNewBlock(&ast.Block{Label: forLoop.Label})
+	defer b.popBlock()
+	cursorName := b.makeIdentifier("_loop_cursor")
+	foundName := b.makeIdentifier("_loop_found")
+	b.addHiddenVariable(cursorName, types.RefCursor)
+	b.addHiddenVariable(foundName, types.Bool)
+	var cursorVal tree.Expr = &tree.CastExpr{Expr: tree.DNull, Type: types.RefCursor}
+	if cursorVar != nil {
+		// A cursor FOR loop opens the cursor with the name that is stored in the
+		// cursor variable, if it is set.
+		cursorVal = cursorVar
+	}
+	s = b.assignToHiddenVariable(s, cursorName, cursorVal)
+	s = b.assignToHiddenVariable(s, foundName, tree.DBoolFalse)
+	if cursorVar != nil {
+		target := forLoop.Target[0]
+		typ := types.EmptyTuple
+		if b.buildSQL {
+			typ = b.queryRowType(query, s)
+		}
+		s = b.shadowVariable(s, target)
+		b.addVariable(target, typ)
+		b.block().records[target] = []*types.T{typ}
+		s = b.addPLpgSQLAssign(s, target, &tree.CastExpr{Expr: tree.DNull, Type: typ}, noIndirection)
+	}
+
+	// Resolve the types of the values that are fetched from the cursor. If the
+	// target is a single record-type variable, the columns of the query are
+	// assigned as its elements, rather than directly to the variable. A RECORD
+	// variable with type AnyTuple is instead assigned the fetched row as a whole.
+	var targetTypes []*types.T
+	isRecordTarget := b.targetIsRecordVar(forLoop.Target)
+	if isRecordTarget {
+		targetTypes = b.resolveVariableForAssign(forLoop.Target[0]).TupleContents()
+	} else {
+		targetTypes = make([]*types.T, len(forLoop.Target))
+		for i := range forLoop.Target {
+			targetTypes[i] = b.resolveVariableForAssign(forLoop.Target[i])
+		}
+	}
+
+	// The looping is implemented by the following continuations:
+	//   1. openCon opens the cursor for the query and calls loopCon.
+	//   2. loopCon fetches the next row from the cursor and assigns it to the
+	//      target variables. It then executes the loop body if a row was found,
+	//      and exits the loop otherwise. The loop body calls loopCon recursively.
+	//   3. closeCon closes the cursor and resumes execution after the loop. It is
+	//      called when the loop exits, including through an EXIT statement.
+	openCon := b.makeContinuation("_stmt_for_query_open")
+	openCon.def.Volatility = volatility.Volatile
+	loopCon := b.makeContinuationWithTyp("stmt_for_query_loop", forLoop.Label, continuationLoopContinue)
+	loopCon.def.IsRecursive = true
+	loopCon.def.Volatility = volatility.Volatile
+	closeCon := b.makeContinuationWithTyp("stmt_for_query_close", forLoop.Label, continuationLoopExit)
+	closeCon.def.Volatility = volatility.Volatile
+
+	var queryCols int
+	var fetchTypes []*types.T
+	var queryLabels []string
+	if dynamic == nil {
+		// Build the query into the first body statement of the OPEN continuation,
+		// which will be piped into the cursor. Project an additional non-null
+		// column so that the loop can distinguish between a row of NULL values and
+		// the result of fetching from an exhausted cursor.
+		fmtCtx := b.ob.evalCtx.FmtCtx(tree.FmtSimple)
+		fmtCtx.FormatNode(query)
+		openCon.def.CursorDeclaration = &tree.RoutineOpenCursor{
+			NameArgIdx: openCon.s.findAnonymousColumnWithMetadataName(cursorName).getParamOrd(),
+			Scroll:     scroll,
+			CursorSQL:  fmtCtx.CloseAndGetString(),
+		}
+		openScope := b.buildSQLStatement(query, openCon.s)
+		if openScope.expr.Relational().CanMutate {
+			panic(unimplemented.New("for loop over mutation",
+				"FOR loops over data-modifying statements are not yet supported",
+			))
+		}
+		queryCols = len(openScope.cols)
+		fetchTypes = make([]*types.T, 0, queryCols+1)
+		queryLabels = make([]string, 0, queryCols)
+		for i := range openScope.cols {
+			fetchTypes = append(fetchTypes, openScope.cols[i].typ)
+			queryLabels = append(queryLabels, string(openScope.cols[i].name.ReferenceName()))
+		}
+		fetchTypes = append(fetchTypes, types.Bool)
+		cursorScope := openScope.push()
+		cursorScope.appendColumnsFromScope(openScope)
+		b.ob.synthesizeColumn(
+			cursorScope, scopeColName("").WithMetadataName(b.makeIdentifier("found")),
+			types.Bool, nil /* expr */, memo.TrueSingleton,
+		)
+		cursorScope.copyOrdering(openScope)
+		b.ob.constructProjectForScope(openScope, cursorScope)
+		b.appendBodyStmtFromScope(&openCon, cursorScope)
+	} else {
+		// The query string is parsed and planned when the cursor is opened by the
+		// crdb_internal.plpgsql_open_query builtin function.
+		openCall := &tree.FuncExpr{
+			Func: tree.WrapFunction("crdb_internal.plpgsql_open_query"),
+			Exprs: tree.Exprs{
+				openCon.s.findAnonymousColumnWithMetadataName(cursorName),
+				dynamic.Query,
+				&tree.Tuple{Exprs: dynamic.Params},
+			},
+		}
+		openScope := openCon.s.push()
+		openColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_open"))
+		scalar := b.buildSQLExpr(openCall, types.Int, openCon.s)
+		b.ob.synthesizeColumn(openScope, openColName, types.Int, nil /* expr */, scalar)
+		b.ob.constructProjectForScope(openCon.s, openScope)
+		b.appendBodyStmtFromScope(&openCon, openScope)
+	}
+	startScope := openCon.s.push()
+	b.ensureScopeHasExpr(startScope)
+	b.appendBodyStmtFromScope(&openCon, b.callContinuation(&loopCon, startScope))
+
+	// Build the CLOSE continuation, which closes the cursor and then calls the
+	// continuation for the statements following the loop.
+	closeScope := b.buildClose(
+		closeCon.s, closeCon.s.findAnonymousColumnWithMetadataName(cursorName).id,
+	)
+	b.appendBodyStmtFromScope(&closeCon, closeScope)
+	exitScope := closeCon.s.push()
+	b.ensureScopeHasExpr(exitScope)
+	b.appendBodyStmtFromScope(&closeCon, b.callContinuation(exitCon, exitScope))
+
+	// Build a continuation for the loop body, which is executed if the FETCH
+	// found a row. Push the loop continuations so that the body can call into
+	// them.
+	bodyCon := b.makeContinuation("stmt_for_query_body")
+	b.loopCursors = append(b.loopCursors, loopCursor{name: cursorName, conIdx: len(b.continuations)})
+	b.pushContinuation(closeCon)
+	b.pushContinuation(loopCon)
+	ifStmt := &ast.If{
+		Condition: bodyCon.s.findAnonymousColumnWithMetadataName(foundName),
+		ThenBody:  forLoop.Body,
+		ElseBody:  []ast.Statement{&ast.Exit{}},
+	}
+	b.appendPlpgSQLStmts(&bodyCon, []ast.Statement{ifStmt})
+	b.popContinuation()
+	b.popContinuation()
+	b.loopCursors = b.loopCursors[:len(b.loopCursors)-1]
+
+	// Build the loop continuation. Fetch the next row from the cursor, and
+	// assign its values to the targets. If no row was found, the targets retain
+	// their previous values.
+	loopCursorCol := loopCon.s.findAnonymousColumnWithMetadataName(cursorName).id
+	var fetchScope *scope
+	var found, fetchedRecord opt.ScalarExpr
+	var fetchedValue func(i int, typ *types.T) opt.ScalarExpr
+	if dynamic == nil {
+		fetchScope = b.buildFetchCall(loopCon.s, loopCursorCol, tree.FetchNormal, 1 /* count */, fetchTypes)
+		tupleVar := b.ob.factory.ConstructVariable(fetchScope.cols[0].id)
+		found = b.ob.factory.ConstructIsNot(
+			b.ob.factory.ConstructColumnAccess(tupleVar, memo.TupleOrdinal(queryCols)),
+			memo.NullSingleton,
+		)
+		fetchedValue = func(i int, typ *types.T) opt.ScalarExpr {
+			if i >= queryCols {
+				// If there are less query columns than targets, NULL is assigned to
+				// any remaining targets.
+				return b.ob.factory.ConstructConstVal(tree.DNull, typ)
+			}
+			elem := b.ob.factory.ConstructColumnAccess(tupleVar, memo.TupleOrdinal(i))
+			return b.coerceType(elem, typ)
+		}
+		if isRecordTarget {
+			elems := make(memo.ScalarListExpr, queryCols)
+			for i := range elems {
+				elems[i] = b.ob.factory.ConstructColumnAccess(tupleVar, memo.TupleOrdinal(i))
+			}
+			fetchedRecord = b.ob.factory.ConstructTuple(
+				elems, types.MakeLabeledTuple(fetchTypes[:queryCols], queryLabels),
+			)
+		}
+	} else {
+		// The fetched row is a labeled tuple, or NULL if no row was found. Its
+		// columns are assigned to the targets by position.
+		fetchScope = b.buildFetchRecordCall(loopCon.s, loopCursorCol, tree.FetchNormal, 1 /* count */)
+		fetchedRecord = b.ob.factory.ConstructVariable(fetchScope.cols[0].id)
+		found = b.ob.factory.ConstructIsNot(fetchedRecord, memo.NullSingleton)
+		fetchedValue = func(i int, typ *types.T) opt.ScalarExpr {
+			elem := &tree.FuncExpr{
+				Func: tree.WrapFunction("crdb_internal.plpgsql_record_field"),
+				Exprs: tree.Exprs{
+					&fetchScope.cols[0],
+					tree.NewDString(""),
+					tree.NewDInt(tree.DInt(i)),
+					&tree.CastExpr{Expr: tree.DNull, Type: typ},
+				},
+			}
+			return b.buildSQLExpr(elem, typ, fetchScope)
+		}
+	}
+	intoScope := fetchScope.push()
+	for i := range forLoop.Target {
+		name := forLoop.Target[i]
+		_, source, _, err := fetchScope.FindSourceProvidingColumn(b.ob.ctx, name)
+		if err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "failed to find variable %s", name))
+		}
+		typ := source.(*scopeColumn).typ
+		var newVal opt.ScalarExpr
+		switch {
+		case isRecordTarget && typ.Identical(types.AnyTuple):
+			newVal = fetchedRecord
+		case isRecordTarget:
+			elems := make(memo.ScalarListExpr, len(targetTypes))
+			for j := range elems {
+				elems[j] = fetchedValue(j, targetTypes[j])
+			}
+			newVal = b.ob.factory.ConstructTuple(elems, typ)
+		default:
+			newVal = fetchedValue(i, targetTypes[i])
+		}
+		scalar := b.ob.factory.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{b.ob.factory.ConstructWhen(found, newVal)},
+			b.ob.factory.ConstructVariable(source.(*scopeColumn).id),
+		)
+		b.ob.synthesizeColumn(intoScope, scopeColName(name), typ, nil /* expr */, scalar)
+	}
+	b.ob.synthesizeColumn(
+		intoScope, scopeColName("").WithMetadataName(foundName), types.Bool, nil /* expr */, found,
+	)
+	b.ob.constructProjectForScope(fetchScope, intoScope)
+
+	// Add a barrier in case the projected variables are never referenced again,
+	// to prevent column-pruning rules from removing the FETCH.
+	b.ob.addBarrier(intoScope)
+	b.addNotNullChecks(intoScope, forLoop.Target)
+	b.appendBodyStmtFromScope(&loopCon, b.callContinuation(&bodyCon, intoScope))
+
+	// Generate a unique name for the cursor in its own volatile routine, and then
+	// open the cursor.
+	nameCon := b.makeContinuation("_gen_cursor_name")
+	nameCon.def.Volatility = volatility.Volatile
+	nameScope := b.buildCursorNameGenForCol(
+		nameCon.s,
+		nameCon.s.findAnonymousColumnWithMetadataName(cursorName),
+		scopeColName("").WithMetadataName(cursorName),
+	)
+	b.appendBodyStmtFromScope(&nameCon, b.callContinuation(&openCon, nameScope))
+	return b.callContinuation(&nameCon, s)
+}
+
+// resolveLoopCursor returns the column for the cursor variable of a cursor FOR
+// loop, along with the query and scroll option of the cursor. Like Postgres,
+// the cursor must have been bound to a query when it was declared.
+func (b *plpgsqlBuilder) resolveLoopCursor(
+	s *scope, forLoop *ast.ForLoop, control *ast.CursorForLoopControl,
+) (*scopeColumn, tree.Statement, tree.CursorScrollOption) {
+	if len(forLoop.Target) != 1 {
+		panic(pgerror.New(pgcode.Syntax, "cursor FOR loop must have only one target variable"))
+	}
+	_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, control.CursorVar)
+	if err != nil {
+		if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
+			panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", control.CursorVar))
+		}
+		panic(err)
+	}
+	col := source.(*scopeColumn)
+	// Search the blocks in reverse order to ensure that more recent declarations
+	// are encountered first.
+	for i := len(b.blocks) - 1; i >= 0 && col.typ.Identical(types.RefCursor); i-- {
+		block := &b.blocks[i]
+		if _, ok := block.varTypes[control.CursorVar]; !ok {
+			continue
+		}
+		dec, ok := block.cursors[control.CursorVar]
+		if !ok {
+			break
+		}
+		if _, ok := dec.Query.(*tree.Select); !ok {
+			panic(pgerror.Newf(
+				pgcode.InvalidCursorDefinition, "cannot open %s query as cursor", dec.Query.StatementTag(),
+			))
+		}
+		return col, dec.Query, dec.Scroll
+	}
+	panic(pgerror.New(pgcode.Syntax, "cursor FOR loop must use a bound cursor variable"))
+}
+
 // resolveOpenQuery finds and validates the query that is bound to cursor for
-// the given OPEN statement.
-func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
+// the given OPEN statement. It also returns the scroll option of the cursor,
+// which is taken from the cursor declaration for bound cursors.
+func (b *plpgsqlBuilder) resolveOpenQuery(
+	open *ast.Open,
+) (tree.Statement, tree.CursorScrollOption) {
 	// Search the blocks in reverse order to ensure that more recent declarations
 	// are encountered first.
 	var boundStmt tree.Statement
+	scroll := open.Scroll
 	for i := len(b.blocks) - 1; i >= 0; i-- {
 		block := &b.blocks[i]
 		for name := range block.cursors {
 			if open.CurVar == name {
 				boundStmt = block.cursors[name].Query
+				scroll = block.cursors[name].Scroll
 				break
 			}
 		}
@@ -1317,7 +1696,7 @@ func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
 			pgcode.InvalidCursorDefinition, "cannot open %s query as cursor", stmt.StatementTag(),
 		))
 	}
-	return stmt
+	return stmt, scroll
 }
 
 // buildCursorNameGen builds a statement that generates a unique name for the
@@ -1326,13 +1705,22 @@ func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
 // builtin function.
 func (b *plpgsqlBuilder) buildCursorNameGen(nameCon *continuation, nameVar ast.Variable) *scope {
 	_, source, _, _ := nameCon.s.FindSourceProvidingColumn(b.ob.ctx, nameVar)
+	return b.buildCursorNameGenForCol(nameCon.s, source.(*scopeColumn), scopeColName(nameVar))
+}
+
+// buildCursorNameGenForCol is similar to buildCursorNameGen, but reads the
+// current cursor name from the given column, and projects the result as a
+// column with the given name.
+func (b *plpgsqlBuilder) buildCursorNameGenForCol(
+	s *scope, nameCol *scopeColumn, colName scopeColumnName,
+) *scope {
 	const nameFnName = "crdb_internal.plpgsql_gen_cursor_name"
 	props, overloads := builtinsregistry.GetBuiltinProperties(nameFnName)
 	if len(overloads) != 1 {
 		panic(errors.AssertionFailedf("expected one overload for %s", nameFnName))
 	}
 	nameCall := b.ob.factory.ConstructFunction(
-		memo.ScalarListExpr{b.ob.factory.ConstructVariable(source.(*scopeColumn).id)},
+		memo.ScalarListExpr{b.ob.factory.ConstructVariable(nameCol.id)},
 		&memo.FunctionPrivate{
 			Name:       nameFnName,
 			Typ:        types.RefCursor,
@@ -1340,9 +1728,9 @@ func (b *plpgsqlBuilder) buildCursorNameGen(nameCon *continuation, nameVar ast.V
 			Overload:   &overloads[0],
 		},
 	)
-	nameScope := nameCon.s.push()
-	b.ob.synthesizeColumn(nameScope, scopeColName(nameVar), types.RefCursor, nil /* expr */, nameCall)
-	b.ob.constructProjectForScope(nameCon.s, nameScope)
+	nameScope := s.push()
+	b.ob.synthesizeColumn(nameScope, colName, types.RefCursor, nil /* expr */, nameCall)
+	b.ob.constructProjectForScope(s, nameScope)
 	return nameScope
 }
 
@@ -1383,6 +1771,7 @@ func (b *plpgsqlBuilder) addPLpgSQLAssign(
 	b.ob.synthesizeColumn(assignScope, colName, typ, nil, scalar)
 	b.ob.constructProjectForScope(inScope, assignScope)
 	b.addBarrierIfVolatile(assignScope, scalar)
+	b.addNotNullChecks(assignScope, []ast.Variable{ident})
 	return assignScope
 }
 
@@ -1432,6 +1821,25 @@ func (b *plpgsqlBuilder) handleIndirectionForAssign(
 		// block.variable pair.
 		panic(pgerror.Newf(pgcode.Syntax, "\"%s.%s\" is not a known variable", ident, indirection))
 	}
+	_, source, _, err := inScope.FindSourceProvidingColumn(b.ob.ctx, ident)
+	if err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "failed to find variable %s", ident))
+	}
+	if !source.(*scopeColumn).typ.Identical(typ) {
+		panic(errors.AssertionFailedf("unexpected type for variable %s", ident))
+	}
+	if typ.Identical(types.AnyTuple) {
+		// The structure of the record is only known at execution time, so the
+		// field is resolved by the crdb_internal.plpgsql_record_set_field builtin
+		// function.
+		setField := &tree.FuncExpr{
+			Func: tree.WrapFunction("crdb_internal.plpgsql_record_set_field"),
+			Exprs: tree.Exprs{
+				source.(*scopeColumn), tree.NewDString(string(ident)), tree.NewDString(elemName), val,
+			},
+		}
+		return b.buildSQLExpr(setField, typ, inScope)
+	}
 	var found bool
 	var elemIdx int
 	for i := range typ.TupleLabels() {
@@ -1446,13 +1854,6 @@ func (b *plpgsqlBuilder) handleIndirectionForAssign(
 			"record \"%s\" has no field \"%s\"", ident, elemName,
 		))
 	}
-	_, source, _, err := inScope.FindSourceProvidingColumn(b.ob.ctx, ident)
-	if err != nil {
-		panic(errors.NewAssertionErrorWithWrappedErrf(err, "failed to find variable %s", ident))
-	}
-	if !source.(*scopeColumn).typ.Identical(typ) {
-		panic(errors.AssertionFailedf("unexpected type for variable %s", ident))
-	}
 	varCol := b.ob.factory.ConstructVariable(source.(*scopeColumn).id)
 	scalar := b.buildSQLExpr(val, typ.TupleContents()[elemIdx], inScope)
 	newElems := make([]opt.ScalarExpr, len(typ.TupleContents()))
@@ -1466,15 +1867,97 @@ func (b *plpgsqlBuilder) handleIndirectionForAssign(
 	return b.ob.factory.ConstructTuple(newElems, typ)
 }
 
+// buildRecordField resolves a reference like "(r).x" to the field of a RECORD
+// variable. Since the structure of a RECORD variable is determined by the row
+// that was last assigned to it, the reference is replaced with a call to the
+// crdb_internal.plpgsql_record_field builtin function, which resolves the field
+// at execution time. Like Postgres, it is an error to reference a field of a
+// RECORD variable that is not assigned yet, or that has no such field.
+//
+// The type of the field is inferred from the types of the rows that can be
+// assigned to the variable. If they disagree on the type of the field, the
+// field is resolved as a string.
+//
+// buildRecordField returns nil if the given column does not correspond to a
+// RECORD variable.
+func (b *plpgsqlBuilder) buildRecordField(s *scope, col *scopeColumn, field tree.Name) tree.Expr {
+	name := ast.Variable(col.name.ReferenceName())
+	candidates, ok := b.resolveRecordVariable(name, col.typ)
+	if !ok {
+		return nil
+	}
+	var fieldTyp *types.T
+	for _, typ := range candidates {
+		for i, label := range typ.TupleLabels() {
+			if label != string(field) {
+				continue
+			}
+			if fieldTyp == nil {
+				fieldTyp = typ.TupleContents()[i]
+			} else if !fieldTyp.Identical(typ.TupleContents()[i]) {
+				fieldTyp = types.String
+			}
+			break
+		}
+	}
+	if fieldTyp == nil {
+		fieldTyp = types.String
+	}
+	return &tree.FuncExpr{
+		Func: tree.WrapFunction("crdb_internal.plpgsql_record_field"),
+		Exprs: tree.Exprs{
+			col,
+			tree.NewDString(string(name)),
+			tree.NewDString(string(field)),
+			&tree.CastExpr{Expr: tree.DNull, Type: fieldTyp},
+		},
+	}
+}
+
+// resolveRecordVariable returns the types of the rows that can be assigned to
+// the RECORD variable with the given name and type. It returns false if there
+// is no such RECORD variable in scope.
+func (b *plpgsqlBuilder) resolveRecordVariable(
+	name ast.Variable, typ *types.T,
+) (candidates []*types.T, ok bool) {
+	// Search the blocks in reverse order to ensure that more recent declarations
+	// are encountered first.
+	for i := len(b.blocks) - 1; i >= 0; i-- {
+		block := &b.blocks[i]
+		varTyp, found := block.varTypes[name]
+		if !found {
+			continue
+		}
+		if candidates, isRecord := block.records[name]; isRecord && varTyp.Identical(typ) {
+			return candidates, true
+		}
+		break
+	}
+	// RECORD variables that are referenced while inferring the type of another
+	// RECORD variable have type AnyTuple; see recordVarTyper.
+	return nil, typ.Identical(types.AnyTuple)
+}
+
 // buildInto handles the mapping from the columns of a SQL statement to the
 // variables in an INTO target.
 func (b *plpgsqlBuilder) buildInto(stmtScope *scope, target []ast.Variable) *scope {
 	var targetTypes []*types.T
 	var targetNames []ast.Variable
+	var labels []string
 	if b.targetIsRecordVar(target) {
 		// For a single record-type variable, the SQL statement columns are assigned
 		// as elements of the variable, rather than the variable itself.
 		targetTypes = b.resolveVariableForAssign(target[0]).TupleContents()
+		if b.resolveVariableForAssign(target[0]).Identical(types.AnyTuple) {
+			// The structure of a RECORD variable with type AnyTuple is determined by
+			// the columns of the SQL statement.
+			targetTypes = make([]*types.T, len(stmtScope.cols))
+			labels = make([]string, len(stmtScope.cols))
+			for j := range stmtScope.cols {
+				targetTypes[j] = stmtScope.cols[j].typ
+				labels[j] = string(stmtScope.cols[j].name.ReferenceName())
+			}
+		}
 	} else {
 		targetNames = target
 		targetTypes = make([]*types.T, len(target))
@@ -1508,8 +1991,9 @@ func (b *plpgsqlBuilder) buildInto(stmtScope *scope, target []ast.Variable) *sco
 	b.ob.constructProjectForScope(stmtScope, intoScope)
 	if b.targetIsRecordVar(target) {
 		// Handle a single record-type variable (see projectRecordVar for details).
-		intoScope = b.projectRecordVar(intoScope, target[0])
+		intoScope = b.projectRecordVar(intoScope, target[0], labels)
 	}
+	b.addNotNullChecks(intoScope, target)
 	return intoScope
 }
 
@@ -1785,6 +2269,42 @@ func (b *plpgsqlBuilder) buildExceptions(block *ast.Block) *memo.ExceptionBlock
 	}
 }
 
+// buildReturn builds a RETURN statement by projecting a single column with the
+// expression that is being returned.
+func (b *plpgsqlBuilder) buildReturn(t *ast.Return, s *scope) *scope {
+	// If the routine has OUT-parameters or a VOID return type, the RETURN
+	// statement must have no expression. Otherwise, the RETURN statement must
+	// have a non-empty expression.
+	expr := t.Expr
+	if b.hasOutParam() {
+		if expr != nil {
+			panic(returnWithOUTParameterErr)
+		}
+		expr = b.makeReturnForOutParams(s)
+	} else if b.returnType.Family() == types.VoidFamily {
+		if expr != nil {
+			if b.isProcedure {
+				panic(returnWithVoidParameterProcedureErr)
+			} else {
+				panic(returnWithVoidParameterErr)
+			}
+		}
+		expr = tree.DNull
+	}
+	if expr == nil {
+		panic(emptyReturnErr)
+	}
+	// RETURN is handled by projecting a single column with the expression
+	// that is being returned.
+	returnScalar := b.buildSQLExpr(expr, b.returnType, s)
+	b.addBarrierIfVolatile(s, returnScalar)
+	returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return"))
+	returnScope := s.push()
+	b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, returnScalar)
+	b.ob.constructProjectForScope(s, returnScope)
+	return returnScope
+}
+
 // handleEndOfFunction handles the case when control flow reaches the end of a
 // PL/pgSQL routine without reaching a RETURN statement.
 func (b *plpgsqlBuilder) handleEndOfFunction(inScope *scope) *scope {
@@ -1793,7 +2313,7 @@ func (b *plpgsqlBuilder) handleEndOfFunction(inScope *scope) *scope {
 		// specify a RETURN statement.
 		var returnExpr tree.Expr = tree.DNull
 		if b.hasOutParam() {
-			returnExpr = b.makeReturnForOutParams()
+			returnExpr = b.makeReturnForOutParams(inScope)
 		}
 		returnScope := inScope.push()
 		colName := scopeColName("_implicit_return")
@@ -1906,14 +2426,71 @@ func (b *plpgsqlBuilder) addRuntimeCheck(
 	s.expr = b.ob.factory.ConstructProject(s.expr, memo.ProjectionsExpr{}, originalCols)
 }
 
+// addNotNullChecks adds a runtime check for each of the given variables that
+// was declared NOT NULL. The check raises an error if the variable was assigned
+// a NULL value. It should be called after projecting the new values for the
+// variables.
+func (b *plpgsqlBuilder) addNotNullChecks(s *scope, target []ast.Variable) {
+	for _, name := range target {
+		if b.isNotNullVariable(name) {
+			b.addNotNullCheck(s, name, fmt.Sprintf(
+				"null value cannot be assigned to variable \"%s\" declared NOT NULL", name,
+			))
+		}
+	}
+}
+
+// addNotNullCheck adds a runtime check that raises an error with the given
+// message if the given variable is NULL.
+func (b *plpgsqlBuilder) addNotNullCheck(s *scope, name ast.Variable, message string) {
+	if !b.buildSQL {
+		// For lazy SQL evaluation, all variables are NULL.
+		return
+	}
+	_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, name)
+	if err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "failed to find variable %s", name))
+	}
+	args := b.ob.makeConstRaiseArgs(
+		"ERROR", /* severity */
+		message,
+		"",                                  /* detail */
+		"",                                  /* hint */
+		pgcode.NullValueNotAllowed.String(), /* code */
+	)
+	isNull := b.ob.factory.ConstructIs(
+		b.ob.factory.ConstructVariable(source.(*scopeColumn).id), memo.NullSingleton,
+	)
+	b.addRuntimeCheck(s, memo.ScalarListExpr{isNull}, []memo.ScalarListExpr{args})
+}
+
+// buildClose projects a call to the crdb_internal.plpgsql_close builtin
+// function, which closes the cursor with the name stored in the given column.
+func (b *plpgsqlBuilder) buildClose(s *scope, cursorCol opt.ColumnID) *scope {
+	const closeFnName = "crdb_internal.plpgsql_close"
+	props, overloads := builtinsregistry.GetBuiltinProperties(closeFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", closeFnName))
+	}
+	closeCall := b.ob.factory.ConstructFunction(
+		memo.ScalarListExpr{b.ob.factory.ConstructVariable(cursorCol)},
+		&memo.FunctionPrivate{
+			Name:       closeFnName,
+			Typ:        types.Int,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+	closeColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_close"))
+	closeScope := s.push()
+	b.ob.synthesizeColumn(closeScope, closeColName, types.Int, nil /* expr */, closeCall)
+	b.ob.constructProjectForScope(s, closeScope)
+	return closeScope
+}
+
 // buildFetch projects a call to the crdb_internal.plpgsql_fetch builtin
 // function, which handles cursors for the PLpgSQL FETCH and MOVE statements.
 func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
-	const fetchFnName = "crdb_internal.plpgsql_fetch"
-	props, overloads := builtinsregistry.GetBuiltinProperties(fetchFnName)
-	if len(overloads) != 1 {
-		panic(errors.AssertionFailedf("expected one overload for %s", fetchFnName))
-	}
 	_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, fetch.Cursor.Name)
 	if err != nil {
 		if pgerror.GetPGCode(err) == pgcode.UndefinedColumn {
@@ -1926,8 +2503,24 @@ func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
 			"variable \"%s\" must be of type cursor or refcursor", fetch.Cursor.Name,
 		))
 	}
-	makeConst := func(val tree.Datum, typ *types.T) opt.ScalarExpr {
-		return b.ob.factory.ConstructConstVal(val, typ)
+	if !fetch.IsMove && b.targetIsRecordVar(fetch.Target) &&
+		b.resolveVariableForAssign(fetch.Target[0]).Identical(types.AnyTuple) {
+		// The structure of a RECORD variable with type AnyTuple is determined by
+		// the fetched row, so the row is fetched as a labeled tuple. Wrap it into
+		// a tuple with a single element, which corresponds to the single target
+		// variable.
+		fetchScope := b.buildFetchRecordCall(
+			s, source.(*scopeColumn).id, fetch.Cursor.FetchType, fetch.Cursor.Count,
+		)
+		wrappedTyp := types.MakeTuple([]*types.T{types.AnyTuple})
+		wrapped := b.ob.factory.ConstructTuple(
+			memo.ScalarListExpr{b.ob.factory.ConstructVariable(fetchScope.cols[0].id)}, wrappedTyp,
+		)
+		recordScope := fetchScope.push()
+		recordColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_fetch_record"))
+		b.ob.synthesizeColumn(recordScope, recordColName, wrappedTyp, nil /* expr */, wrapped)
+		b.ob.constructProjectForScope(fetchScope, recordScope)
+		return recordScope
 	}
 	// For a FETCH statement, we have to pass the expected result types.
 	var typs []*types.T
@@ -1945,6 +2538,46 @@ func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
 			}
 		}
 	}
+	fetchScope := b.buildFetchCall(
+		s, source.(*scopeColumn).id, fetch.Cursor.FetchType, fetch.Cursor.Count, typs,
+	)
+	if !fetch.IsMove && b.targetIsRecordVar(fetch.Target) {
+		// Handle a single record-type variable by wrapping the fetched columns into
+		// a tuple with the type of the variable. The result is a tuple with a
+		// single element, which corresponds to the single target variable.
+		recordTyp := b.resolveVariableForAssign(fetch.Target[0])
+		tupleVar := b.ob.factory.ConstructVariable(fetchScope.cols[0].id)
+		elems := make(memo.ScalarListExpr, len(typs))
+		for i := range elems {
+			elems[i] = b.ob.factory.ConstructColumnAccess(tupleVar, memo.TupleOrdinal(i))
+		}
+		wrappedTyp := types.MakeTuple([]*types.T{recordTyp})
+		wrapped := b.ob.factory.ConstructTuple(
+			memo.ScalarListExpr{b.ob.factory.ConstructTuple(elems, recordTyp)}, wrappedTyp,
+		)
+		recordScope := fetchScope.push()
+		recordColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_fetch_record"))
+		b.ob.synthesizeColumn(recordScope, recordColName, wrappedTyp, nil /* expr */, wrapped)
+		b.ob.constructProjectForScope(fetchScope, recordScope)
+		fetchScope = recordScope
+	}
+	return fetchScope
+}
+
+// buildFetchCall projects a call to the crdb_internal.plpgsql_fetch builtin
+// function for the cursor with the name stored in the given column. The
+// resulting scope has a single tuple column with the given element types.
+func (b *plpgsqlBuilder) buildFetchCall(
+	s *scope, cursorCol opt.ColumnID, fetchType tree.FetchType, count int64, typs []*types.T,
+) *scope {
+	const fetchFnName = "crdb_internal.plpgsql_fetch"
+	props, overloads := builtinsregistry.GetBuiltinProperties(fetchFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", fetchFnName))
+	}
+	makeConst := func(val tree.Datum, typ *types.T) opt.ScalarExpr {
+		return b.ob.factory.ConstructConstVal(val, typ)
+	}
 	returnType := types.MakeTuple(typs)
 	elems := make(memo.ScalarListExpr, len(typs))
 	for i := range elems {
@@ -1959,9 +2592,9 @@ func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
 	// The result of the fetch will be cast to strings and returned as an array.
 	fetchCall := b.ob.factory.ConstructFunction(
 		memo.ScalarListExpr{
-			b.ob.factory.ConstructVariable(source.(*scopeColumn).id),
-			makeConst(tree.NewDInt(tree.DInt(fetch.Cursor.FetchType)), types.Int),
-			makeConst(tree.NewDInt(tree.DInt(fetch.Cursor.Count)), types.Int),
+			b.ob.factory.ConstructVariable(cursorCol),
+			makeConst(tree.NewDInt(tree.DInt(fetchType)), types.Int),
+			makeConst(tree.NewDInt(tree.DInt(count)), types.Int),
 			b.ob.factory.ConstructTuple(elems, returnType),
 		},
 		&memo.FunctionPrivate{
@@ -1976,10 +2609,39 @@ func (b *plpgsqlBuilder) buildFetch(s *scope, fetch *ast.Fetch) *scope {
 	fetchScope := s.push()
 	b.ob.synthesizeColumn(fetchScope, fetchColName, returnType, nil /* expr */, fetchCall)
 	b.ob.constructProjectForScope(s, fetchScope)
-	if !fetch.IsMove && b.targetIsRecordVar(fetch.Target) {
-		// Handle a single record-type variable (see projectRecordVar for details).
-		fetchScope = b.projectRecordVar(fetchScope, fetch.Target[0])
+	return fetchScope
+}
+
+// buildFetchRecordCall projects a call to the crdb_internal.plpgsql_fetch_record
+// builtin function for the cursor with the name stored in the given column. The
+// resulting scope has a single column with the fetched row as a labeled tuple,
+// or NULL if no row was found.
+func (b *plpgsqlBuilder) buildFetchRecordCall(
+	s *scope, cursorCol opt.ColumnID, fetchType tree.FetchType, count int64,
+) *scope {
+	const fetchFnName = "crdb_internal.plpgsql_fetch_record"
+	props, overloads := builtinsregistry.GetBuiltinProperties(fetchFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", fetchFnName))
 	}
+	fetchCall := b.ob.factory.ConstructFunction(
+		memo.ScalarListExpr{
+			b.ob.factory.ConstructVariable(cursorCol),
+			b.ob.factory.ConstructConstVal(tree.NewDInt(tree.DInt(fetchType)), types.Int),
+			b.ob.factory.ConstructConstVal(tree.NewDInt(tree.DInt(count)), types.Int),
+		},
+		&memo.FunctionPrivate{
+			Name:       fetchFnName,
+			Typ:        types.AnyTuple,
+			Properties: props,
+			Overload:   &overloads[0],
+		},
+	)
+	b.addBarrierIfVolatile(s, fetchCall)
+	fetchColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_fetch"))
+	fetchScope := s.push()
+	b.ob.synthesizeColumn(fetchScope, fetchColName, types.AnyTuple, nil /* expr */, fetchCall)
+	b.ob.constructProjectForScope(s, fetchScope)
 	return fetchScope
 }
 
@@ -1990,17 +2652,26 @@ func (b *plpgsqlBuilder) targetIsRecordVar(target []ast.Variable) bool {
 }
 
 // projectRecordVar handles the special case when a single RECORD-type variable
-// is the target of an INTO clause or FETCH statement. In this case, the columns
-// from the SQL statement (or FETCH) should be wrapped into a tuple, which is
-// assigned to the RECORD-type variable.
-func (b *plpgsqlBuilder) projectRecordVar(s *scope, name ast.Variable) *scope {
+// is the target of an INTO clause. In this case, the columns from the SQL
+// statement should be wrapped into a tuple, which is assigned to the
+// RECORD-type variable. If the variable has type AnyTuple, the tuple is labeled
+// with the given labels.
+func (b *plpgsqlBuilder) projectRecordVar(s *scope, name ast.Variable, labels []string) *scope {
 	typ := b.resolveVariableForAssign(name)
 	recordScope := s.push()
 	elems := make(memo.ScalarListExpr, len(s.cols))
 	for j := range elems {
 		elems[j] = b.ob.factory.ConstructVariable(s.cols[j].id)
 	}
-	tuple := b.ob.factory.ConstructTuple(elems, typ)
+	tupleTyp := typ
+	if typ.Identical(types.AnyTuple) {
+		contents := make([]*types.T, len(s.cols))
+		for j := range s.cols {
+			contents[j] = s.cols[j].typ
+		}
+		tupleTyp = types.MakeLabeledTuple(contents, labels)
+	}
+	tuple := b.ob.factory.ConstructTuple(elems, tupleTyp)
 	col := b.ob.synthesizeColumn(recordScope, scopeColName(name), typ, nil /* expr */, tuple)
 	recordScope.expr = b.ob.constructProject(s.expr, []scopeColumn{*col})
 	return recordScope
@@ -2027,6 +2698,12 @@ func (b *plpgsqlBuilder) makeContinuation(conName string) continuation {
 	for i := range b.blocks {
 		block := &b.blocks[i]
 		for _, name := range block.vars {
+			if b.isShadowed(i, name) {
+				// Shadowed variables cannot be referenced by the user, so they are
+				// passed as anonymous columns; see shadowVariable.
+				addParam(scopeColName("").WithMetadataName(shadowedVarName(i, name)), block.varTypes[name])
+				continue
+			}
 			addParam(scopeColName(name), block.varTypes[name])
 		}
 		for _, name := range block.hiddenVars {
@@ -2165,6 +2842,14 @@ func (b *plpgsqlBuilder) makeContinuationArgs(con *continuation, s *scope) memo.
 		}
 		block := &b.blocks[i]
 		for _, name := range block.vars {
+			if b.isShadowed(i, name) {
+				col := s.findAnonymousColumnWithMetadataName(shadowedVarName(i, name))
+				if col == nil {
+					panic(errors.AssertionFailedf("shadowed variable %s not found", name))
+				}
+				args = append(args, b.ob.factory.ConstructVariable(col.id))
+				continue
+			}
 			_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, name)
 			if err != nil {
 				panic(err)
@@ -2252,6 +2937,19 @@ func (b *plpgsqlBuilder) buildSQLStatement(stmt tree.Statement, inScope *scope)
 // coerceType implements PLpgSQL type-coercion behavior.
 func (b *plpgsqlBuilder) coerceType(scalar opt.ScalarExpr, typ *types.T) opt.ScalarExpr {
 	resolved := scalar.DataType()
+	if typ.Identical(types.AnyTuple) {
+		// The structure of a RECORD variable with type AnyTuple is determined by
+		// the row that is assigned to it at execution time.
+		switch resolved.Family() {
+		case types.TupleFamily:
+			return scalar
+		case types.UnknownFamily:
+			return b.ob.factory.ConstructNull(types.AnyTuple)
+		}
+		panic(pgerror.New(pgcode.DatatypeMismatch,
+			"cannot assign non-composite value to a record variable",
+		))
+	}
 	if !resolved.Identical(typ) {
 		// Postgres will attempt to coerce the expression's type with an assignment
 		// cast. If that fails, it will convert to a string and attempt to parse the
@@ -2296,6 +2994,22 @@ func (b *plpgsqlBuilder) resolveVariableForAssign(name ast.Variable) *types.T {
 	panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", name))
 }
 
+// isNotNullVariable returns true if the variable with the given name was
+// declared NOT NULL.
+func (b *plpgsqlBuilder) isNotNullVariable(name ast.Variable) bool {
+	// Search the blocks in reverse order to ensure that more recent declarations
+	// are encountered first.
+	for i := len(b.blocks) - 1; i >= 0; i-- {
+		block := &b.blocks[i]
+		if _, ok := block.varTypes[name]; !ok {
+			continue
+		}
+		_, ok := block.notNulls[name]
+		return ok
+	}
+	return false
+}
+
 // resolveHiddenVariableForAssign is similar to resolveVariableForAssign, but
 // applies to hidden variables, which are identified only by their name in the
 // query's metadata. It panics if the hidden variable is not found.
@@ -2333,6 +3047,7 @@ func (b *plpgsqlBuilder) projectTupleAsIntoTarget(inScope *scope, target []ast.V
 		b.ob.synthesizeColumn(intoScope, colName, typ, nil /* expr */, scalar)
 	}
 	b.ob.constructProjectForScope(inScope, intoScope)
+	b.addNotNullChecks(intoScope, target)
 	return intoScope
 }
 
@@ -2396,14 +3111,21 @@ func (b *plpgsqlBuilder) hasOutParam() bool {
 }
 
 // makeReturnForOutParams builds the implicit RETURN expression for a routine
-// with OUT-parameters.
-func (b *plpgsqlBuilder) makeReturnForOutParams() tree.Expr {
+// with OUT-parameters. The given scope is used to resolve OUT-parameters that
+// are shadowed by a variable in the current block.
+func (b *plpgsqlBuilder) makeReturnForOutParams(s *scope) tree.Expr {
 	if len(b.outParams) == 0 {
 		panic(errors.AssertionFailedf("expected at least one out param"))
 	}
 	exprs := make(tree.Exprs, len(b.outParams))
 	for i, param := range b.outParams {
-		if param != "" {
+		if param != "" && b.isShadowed(0 /* blockIdx */, param) {
+			col := s.findAnonymousColumnWithMetadataName(shadowedVarName(0, param))
+			if col == nil {
+				panic(errors.AssertionFailedf("shadowed parameter %s not found", param))
+			}
+			exprs[i] = col
+		} else if param != "" {
 			exprs[i] = tree.NewUnresolvedName(string(param))
 		} else {
 			// TODO(121251): if the unnamed parameter of INOUT type, then we
@@ -2497,6 +3219,57 @@ func (b *plpgsqlBuilder) getContinuation(
 	return nil
 }
 
+// continuationIdx returns the position of the given continuation in the
+// continuation stack.
+func (b *plpgsqlBuilder) continuationIdx(con *continuation) int {
+	for i := range b.continuations {
+		if &b.continuations[i] == con {
+			return i
+		}
+	}
+	panic(errors.AssertionFailedf("continuation %s is not on the stack", con.def.Name))
+}
+
+// loopCursor is the internal cursor of a query FOR loop.
+type loopCursor struct {
+	// name is the name of the hidden variable that holds the cursor name.
+	name string
+	// conIdx is the position of the continuation that closes the cursor and
+	// exits the loop in the continuation stack.
+	conIdx int
+}
+
+// closeLoopCursors closes the internal cursors of the enclosing query FOR loops
+// whose closing continuation is above conIdx in the continuation stack, and
+// then resumes execution with the statement built by fn. It is used by the
+// statements that jump to the continuation at position conIdx, or return from
+// the routine if conIdx is -1, skipping the normal exit of the loops.
+func (b *plpgsqlBuilder) closeLoopCursors(
+	s *scope, conIdx int, fn func(s *scope) *scope,
+) *scope {
+	var cursors []string
+	for i := len(b.loopCursors) - 1; i >= 0; i-- {
+		if b.loopCursors[i].conIdx > conIdx {
+			cursors = append(cursors, b.loopCursors[i].name)
+		}
+	}
+	if len(cursors) == 0 {
+		return fn(s)
+	}
+	// Close the cursors in a separate volatile routine, like the normal exit of
+	// the loop does.
+	con := b.makeContinuation("_stmt_for_query_close")
+	con.def.Volatility = volatility.Volatile
+	for _, name := range cursors {
+		closeScope := b.buildClose(con.s, con.s.findAnonymousColumnWithMetadataName(name).id)
+		b.appendBodyStmtFromScope(&con, closeScope)
+	}
+	resumeScope := con.s.push()
+	b.ensureScopeHasExpr(resumeScope)
+	b.appendBodyStmtFromScope(&con, fn(resumeScope))
+	return b.callContinuation(&con, s)
+}
+
 // addVariable adds a variable with the given name and type to the current
 // PL/pgSQL block scope.
 func (b *plpgsqlBuilder) addVariable(name ast.Variable, typ *types.T) {
@@ -2504,19 +3277,58 @@ func (b *plpgsqlBuilder) addVariable(name ast.Variable, typ *types.T) {
 	if _, ok := curBlock.varTypes[name]; ok {
 		panic(pgerror.Newf(pgcode.Syntax, "duplicate declaration at or near \"%s\"", name))
 	}
-	for i := range b.blocks {
-		block := &b.blocks[i]
-		if _, ok := block.varTypes[name]; ok {
-			panic(errors.WithHintf(
-				unimplemented.NewWithIssue(117508, "variable shadowing is not yet implemented"),
-				"variable \"%s\" shadows a previously defined variable", name,
-			))
-		}
-	}
 	curBlock.vars = append(curBlock.vars, name)
 	curBlock.varTypes[name] = typ
 }
 
+// shadowVariable must be called before a variable with the given name is added
+// to the current block. If the new variable shadows a variable from an ancestor
+// block, shadowVariable projects the current value of the ancestor's variable
+// as an anonymous column. The anonymous column is used to pass the value of the
+// shadowed variable to continuations until control returns to the ancestor
+// block, at which point the variable is visible again.
+func (b *plpgsqlBuilder) shadowVariable(s *scope, name ast.Variable) *scope {
+	for i := len(b.blocks) - 2; i >= 0; i-- {
+		if _, ok := b.blocks[i].varTypes[name]; !ok {
+			continue
+		}
+		// Only the closest ancestor variable needs to be handled, since any other
+		// variable with the same name is already shadowed.
+		_, source, _, err := s.FindSourceProvidingColumn(b.ob.ctx, name)
+		if err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "failed to find variable %s", name))
+		}
+		col := source.(*scopeColumn)
+		shadowScope := s.push()
+		shadowScope.appendColumnsFromScope(s)
+		colName := scopeColName("").WithMetadataName(shadowedVarName(i, name))
+		b.ob.synthesizeColumn(
+			shadowScope, colName, col.typ, nil /* expr */, b.ob.factory.ConstructVariable(col.id),
+		)
+		b.ob.constructProjectForScope(s, shadowScope)
+		return shadowScope
+	}
+	return s
+}
+
+// isShadowed returns true if the variable with the given name from the block
+// with the given index is shadowed by a variable declared in a descendant
+// block.
+func (b *plpgsqlBuilder) isShadowed(blockIdx int, name ast.Variable) bool {
+	for i := blockIdx + 1; i < len(b.blocks); i++ {
+		if _, ok := b.blocks[i].varTypes[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// shadowedVarName returns the metadata name of the anonymous column that holds
+// the value of a shadowed variable from the block with the given index.
+func shadowedVarName(blockIdx int, name ast.Variable) string {
+	return fmt.Sprintf("_shadowed_%s_%d", name, blockIdx)
+}
+
 // addHiddenVariable adds a hidden variable with the given (metadata) name and
 // type to the current PL/pgSQL block scope.
 func (b *plpgsqlBuilder) addHiddenVariable(metadataName string, typ *types.T) {
@@ -2649,6 +3461,295 @@ func (r *recordTypeVisitor) Visit(stmt ast.Statement) (newStmt ast.Statement, re
 	return stmt, true
 }
 
+// inferRecordVarType determines the type of the RECORD variable declared by
+// the declaration with the given index in the given block. In Postgres, the
+// structure of a RECORD variable is determined at runtime by the row that is
+// assigned to it. The types of the rows that can be assigned to the variable
+// are inferred from the statements in the variable's scope, and are returned
+// along with the type of the variable.
+//
+// If all such rows have the same type, the variable has that type. Otherwise,
+// the variable has type AnyTuple, and its structure is only known at execution
+// time. This is also the case if the variable is assigned the rows of an
+// unbound cursor or a dynamic query. References to the fields of the variable
+// are resolved at execution time in either case; see buildRecordField.
+func (b *plpgsqlBuilder) inferRecordVarType(
+	astBlock *ast.Block, declIdx int, s *scope,
+) (typ *types.T, candidates []*types.T) {
+	if !b.buildSQL {
+		// For lazy SQL evaluation, all expressions are replaced with NULL, so the
+		// structure of the record is irrelevant.
+		return types.EmptyTuple, nil
+	}
+	dec := astBlock.Decls[declIdx].(*ast.Declaration)
+	r := recordVarTyper{b: b, name: dec.Var}
+	scratch := s.push()
+	if dec.Expr != nil {
+		r.assignExpr(dec.Expr, scratch)
+	}
+	if !r.addDecls(astBlock.Decls[declIdx+1:], scratch) {
+		r.walkStmts(astBlock.Body, scratch)
+		for i := range astBlock.Exceptions {
+			r.walkStmts(astBlock.Exceptions[i].Action, scratch)
+		}
+	}
+	switch {
+	case r.dynamic || len(r.typs) > 1:
+		return types.AnyTuple, r.typs
+	case len(r.typs) == 1:
+		return r.typs[0], r.typs
+	}
+	// The variable is never assigned a row, so it remains NULL.
+	return types.EmptyTuple, nil
+}
+
+// recordVarTyper walks the statements within the scope of a RECORD variable to
+// infer its type; see inferRecordVarType. It maintains a scratch scope with a
+// column for each variable that is visible to the statements being walked, so
+// that the SQL statements and expressions can be type-checked. Walking stops at
+// any block or loop that shadows the RECORD variable.
+type recordVarTyper struct {
+	b    *plpgsqlBuilder
+	name ast.Variable
+
+	// typs contains the distinct types of the rows that are assigned to the
+	// RECORD variable.
+	typs []*types.T
+
+	// dynamic is true if the RECORD variable is assigned rows with a structure
+	// that is only known at execution time.
+	dynamic bool
+
+	// cursors maps from the name of each bound cursor declared within the scope
+	// of the RECORD variable to its query.
+	cursors map[ast.Variable]tree.Statement
+}
+
+// addDecls adds a scratch column for each of the given declarations to the
+// given scope. It returns true if one of the declarations shadows the RECORD
+// variable.
+func (r *recordVarTyper) addDecls(decls []ast.Statement, s *scope) (shadowed bool) {
+	for i := range decls {
+		switch dec := decls[i].(type) {
+		case *ast.Declaration:
+			if dec.Var == r.name {
+				return true
+			}
+			typ, err := tree.ResolveType(r.b.ob.ctx, dec.Typ, r.b.ob.semaCtx.TypeResolver)
+			if err != nil {
+				panic(err)
+			}
+			// The type of a different RECORD variable is irrelevant here, so it is
+			// left as AnyTuple; see resolveRecordVariable.
+			r.addVar(dec.Var, typ, s)
+		case *ast.CursorDeclaration:
+			if dec.Name == r.name {
+				return true
+			}
+			r.addVar(dec.Name, types.RefCursor, s)
+			if r.cursors == nil {
+				r.cursors = make(map[ast.Variable]tree.Statement)
+			}
+			r.cursors[dec.Name] = dec.Query
+		}
+	}
+	return false
+}
+
+func (r *recordVarTyper) addVar(name ast.Variable, typ *types.T, s *scope) {
+	r.b.ob.synthesizeColumn(s, scopeColName(name), typ, nil /* expr */, nil /* scalar */)
+}
+
+// walkStmts walks the given statements, recording each assignment to the
+// RECORD variable.
+func (r *recordVarTyper) walkStmts(stmts []ast.Statement, s *scope) {
+	for _, stmt := range stmts {
+		switch t := stmt.(type) {
+		case *ast.Block:
+			blockScope := s.push()
+			if !r.addDecls(t.Decls, blockScope) {
+				r.walkStmts(t.Body, blockScope)
+				for i := range t.Exceptions {
+					r.walkStmts(t.Exceptions[i].Action, blockScope)
+				}
+			}
+		case *ast.Assignment:
+			if t.Var == r.name && t.Indirection == noIndirection {
+				r.assignExpr(t.Value, s)
+			}
+		case *ast.Execute:
+			if r.isTarget(t.Target) {
+				r.assignQuery(t.SqlStmt, s)
+			}
+		case *ast.Fetch:
+			if !t.IsMove && r.isTarget(t.Target) {
+				if query := r.resolveCursorQuery(t.Cursor.Name); query != nil {
+					r.assignQuery(query, s)
+				} else {
+					// The rows of an unbound cursor are only known at execution time.
+					r.dynamic = true
+				}
+			}
+		case *ast.If:
+			r.walkStmts(t.ThenBody, s)
+			for i := range t.ElseIfList {
+				r.walkStmts(t.ElseIfList[i].Stmts, s)
+			}
+			r.walkStmts(t.ElseBody, s)
+		case *ast.Loop:
+			r.walkStmts(t.Body, s)
+		case *ast.While:
+			r.walkStmts(t.Body, s)
+		case *ast.ForLoop:
+			switch c := t.Control.(type) {
+			case *ast.IntForLoopControl:
+				if len(t.Target) == 1 && t.Target[0] == r.name {
+					// The loop declares a new variable that shadows the RECORD variable.
+					continue
+				}
+				loopScope := s.push()
+				for _, name := range t.Target {
+					r.addVar(name, types.Int, loopScope)
+				}
+				r.walkStmts(t.Body, loopScope)
+			case *ast.QueryForLoopControl:
+				if r.isTarget(t.Target) {
+					r.assignQuery(c.Query, s)
+				}
+				r.walkStmts(t.Body, s)
+			case *ast.CursorForLoopControl:
+				if r.isTarget(t.Target) {
+					// The loop declares a new RECORD variable that shadows this one.
+					continue
+				}
+				loopScope := s.push()
+				for _, name := range t.Target {
+					r.addVar(name, types.AnyTuple, loopScope)
+				}
+				r.walkStmts(t.Body, loopScope)
+			case *ast.DynamicQueryForLoopControl:
+				if r.isTarget(t.Target) {
+					// The rows of a dynamic query are only known at execution time.
+					r.dynamic = true
+				}
+				r.walkStmts(t.Body, s)
+			}
+		}
+	}
+}
+
+// isTarget returns true if the RECORD variable is the sole target of an INTO
+// clause, FETCH statement or FOR loop.
+func (r *recordVarTyper) isTarget(target []ast.Variable) bool {
+	return len(target) == 1 && target[0] == r.name
+}
+
+// assignExpr records the type of an expression that is assigned to the RECORD
+// variable.
+func (r *recordVarTyper) assignExpr(expr ast.Expr, s *scope) {
+	expr, _ = tree.WalkExpr(s, expr)
+	typedExpr, err := expr.TypeCheck(r.b.ob.ctx, r.b.ob.semaCtx, types.AnyTuple)
+	if err != nil {
+		panic(err)
+	}
+	typ := typedExpr.ResolvedType()
+	switch typ.Family() {
+	case types.UnknownFamily:
+		// Assigning NULL does not determine the structure of the record.
+		return
+	case types.TupleFamily:
+	default:
+		panic(pgerror.New(pgcode.DatatypeMismatch,
+			"cannot assign non-composite value to a record variable",
+		))
+	}
+	r.assignType(typ)
+}
+
+// assignQuery records the type of the rows returned by a SQL statement that
+// are assigned to the RECORD variable.
+func (r *recordVarTyper) assignQuery(stmt tree.Statement, s *scope) {
+	r.assignType(r.b.queryRowType(recordShapeQuery(stmt), s))
+}
+
+func (r *recordVarTyper) assignType(typ *types.T) {
+	if typ.Identical(types.AnyTuple) {
+		// The assigned row has a structure that is only known at execution time,
+		// e.g. because it is the value of another RECORD variable with type
+		// AnyTuple.
+		r.dynamic = true
+		return
+	}
+	for i := range r.typs {
+		if r.typs[i].Identical(typ) {
+			return
+		}
+	}
+	r.typs = append(r.typs, typ)
+}
+
+// resolveCursorQuery returns the query bound to the cursor with the given
+// name, which is used to infer the type of rows fetched from the cursor. It
+// returns nil if the cursor is not bound to a query.
+func (r *recordVarTyper) resolveCursorQuery(name tree.Name) tree.Statement {
+	cursorVar := ast.Variable(name)
+	if query, ok := r.cursors[cursorVar]; ok {
+		return query
+	}
+	for i := len(r.b.blocks) - 1; i >= 0; i-- {
+		if dec, ok := r.b.blocks[i].cursors[cursorVar]; ok {
+			return dec.Query
+		}
+	}
+	return nil
+}
+
+// queryRowType returns a labeled tuple type with the types and names of the
+// columns that are returned by the given query.
+func (b *plpgsqlBuilder) queryRowType(stmt tree.Statement, s *scope) *types.T {
+	stmtScope := b.ob.buildStmtAtRootWithScope(stmt, nil /* desiredTypes */, s)
+	contents := make([]*types.T, len(stmtScope.cols))
+	labels := make([]string, len(stmtScope.cols))
+	for i := range stmtScope.cols {
+		contents[i] = stmtScope.cols[i].typ
+		labels[i] = string(stmtScope.cols[i].name.ReferenceName())
+	}
+	return types.MakeLabeledTuple(contents, labels)
+}
+
+// recordShapeQuery returns a statement that produces rows with the same
+// structure as the given statement. Data-modifying statements are replaced with
+// a SELECT of their RETURNING expressions, so that inferring the type of a
+// RECORD variable does not require building the mutation.
+func recordShapeQuery(stmt tree.Statement) tree.Statement {
+	var with *tree.With
+	var tables tree.TableExprs
+	var returning tree.ReturningClause
+	switch t := stmt.(type) {
+	case *tree.Insert:
+		with, tables, returning = t.With, tree.TableExprs{t.Table}, t.Returning
+	case *tree.Update:
+		with, returning = t.With, t.Returning
+		tables = append(tree.TableExprs{t.Table}, t.From...)
+	case *tree.Delete:
+		with, returning = t.With, t.Returning
+		tables = append(tree.TableExprs{t.Table}, t.Using...)
+	default:
+		return stmt
+	}
+	exprs, ok := returning.(*tree.ReturningExprs)
+	if !ok {
+		return stmt
+	}
+	return &tree.Select{
+		With: with,
+		Select: &tree.SelectClause{
+			Exprs: tree.SelectExprs(*exprs),
+			From:  tree.From{Tables: tables},
+		},
+	}
+}
+
 // transactionControlVisitor is used to check for COMMIT or ROLLBACK statements
 // for a PL/pgSQL stored procedure, so that stable folding can be disabled.
 type transactionControlVisitor struct {
@@ -2671,18 +3772,6 @@ var (
 	unsupportedPLStmtErr = unimplemented.New("unimplemented PL/pgSQL statement",
 		"attempted to use a PL/pgSQL statement that is not yet supported",
 	)
-	notNullVarErr = unimplemented.NewWithIssueDetail(105243, "not null variable",
-		"not-null PL/pgSQL variables are not yet supported",
-	)
-	collatedVarErr = unimplemented.NewWithIssueDetail(105245, "variable collation",
-		"collation for PL/pgSQL variables is not yet supported",
-	)
-	recordVarErr = unimplemented.NewWithIssueDetail(114874, "RECORD variable",
-		"RECORD type for PL/pgSQL variables is not yet supported",
-	)
-	scrollableCursorErr = unimplemented.NewWithIssue(77102,
-		"DECLARE SCROLL CURSOR",
-	)
 	retryableErrErr = unimplemented.NewWithIssue(111446,
 		"catching a Transaction Retry error in a PLpgSQL EXCEPTION block is not yet implemented",
 	)
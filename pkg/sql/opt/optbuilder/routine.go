@@ -59,7 +59,7 @@ func (b *Builder) buildUDF(
 	}
 
 	// Trigger functions cannot be directly invoked.
-	if f.ResolvedType().Identical(types.Trigger) {
+	if f.ResolvedType().Family() == types.TriggerFamily {
 		// Note: Postgres also uses the "0A000" error code.
 		panic(pgerror.New(pgcode.FeatureNotSupported,
 			"trigger functions can only be called as triggers",
@@ -812,6 +812,11 @@ func (b *Builder) buildDo(do *tree.DoBlock, inScope *scope) *scope {
 	// TODO(drewk): Enable memo reuse with DO statements.
 	b.DisableMemoReuse = true
 
+	// A DO statement is only top-level if it is not part of a routine
+	// definition or body. Transaction control statements are only allowed in a
+	// top-level DO statement.
+	isNested := b.insideFuncDef || b.insideUDF
+
 	defer func(oldInsideFuncDep bool) { b.insideFuncDef = oldInsideFuncDep }(b.insideFuncDef)
 	b.insideFuncDef = true
 
@@ -826,7 +831,15 @@ func (b *Builder) buildDo(do *tree.DoBlock, inScope *scope) *scope {
 	if !ok {
 		panic(errors.AssertionFailedf("expected a plpgsql block"))
 	}
-	body, bodyProps := b.buildPLpgSQLDoBody(doBlockImpl)
+	var body memo.RelExpr
+	var bodyProps *physical.Required
+	if isNested {
+		b.withinNestedPLpgSQLCall(func() {
+			body, bodyProps = b.buildPLpgSQLDoBody(doBlockImpl)
+		})
+	} else {
+		body, bodyProps = b.buildPLpgSQLDoBody(doBlockImpl)
+	}
 
 	// Build a CALL expression that invokes the routine.
 	outScope := inScope.push()
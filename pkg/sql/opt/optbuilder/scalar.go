@@ -184,6 +184,12 @@ func (b *Builder) buildScalar(
 			)
 		}
 
+	case *tree.GroupingExpr:
+		if !inGroupingContext {
+			panic(newGroupingExprArgError())
+		}
+		out = b.buildGroupingExpr(t, inScope.groupby)
+
 	case *tree.IfErrExpr:
 		cond := b.buildScalar(t.Cond.(tree.TypedExpr), inScope, nil, nil, colRefs)
 
@@ -276,6 +282,10 @@ func (b *Builder) buildScalar(
 
 	case *tree.CastExpr:
 		texpr := t.Expr.(tree.TypedExpr)
+		if typ := t.ResolvedType(); typ.IsDomain() {
+			out = b.buildDomainCast(texpr, typ, inScope, colRefs)
+			break
+		}
 		arg := b.buildScalar(texpr, inScope, nil, nil, colRefs)
 		out = b.factory.ConstructCast(arg, t.ResolvedType())
 
@@ -808,6 +818,12 @@ func (b *Builder) constructComparison(
 		return b.factory.ConstructOverlaps(left, right)
 	case treecmp.TSMatches:
 		return b.factory.ConstructTSMatches(left, right)
+	case treecmp.NotExtendRight:
+		return b.factory.ConstructNotExtendRight(left, right)
+	case treecmp.NotExtendLeft:
+		return b.factory.ConstructNotExtendLeft(left, right)
+	case treecmp.Adjacent:
+		return b.factory.ConstructAdjacent(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled comparison operator: %s", redact.Safe(cmp.Operator)))
 }
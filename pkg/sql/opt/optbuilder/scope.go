@@ -134,6 +134,7 @@ const (
 	exprKindReturning
 	exprKindSelect
 	exprKindStoreID
+	exprKindTableSample
 	exprKindValues
 	exprKindWhere
 	exprKindWindowFrameStart
@@ -158,6 +159,7 @@ var exprKindName = [...]string{
 	exprKindReturning:         "RETURNING",
 	exprKindSelect:            "SELECT",
 	exprKindStoreID:           "RELOCATE STORE ID",
+	exprKindTableSample:       "TABLESAMPLE",
 	exprKindValues:            "VALUES",
 	exprKindWhere:             "WHERE",
 	exprKindWindowFrameStart:  "WINDOW FRAME START",
@@ -1097,6 +1099,27 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 		}
 		return false, colI.(*scopeColumn)
 
+	case *tree.ColumnAccessExpr:
+		// The structure of a PL/pgSQL RECORD variable may only be known at
+		// execution time, so accessing one of its fields is handled by the
+		// PL/pgSQL builder.
+		if s.builder.plpgsqlRecordField == nil || t.ByIndex {
+			break
+		}
+		name, ok := tree.StripParens(t.Expr).(*tree.UnresolvedName)
+		if !ok || name.NumParts != 1 || name.Star {
+			break
+		}
+		colI, err := colinfo.ResolveColumnItem(
+			s.builder.ctx, s, &tree.ColumnItem{ColumnName: tree.Name(name.Parts[0])},
+		)
+		if err != nil {
+			break
+		}
+		if newExpr := s.builder.plpgsqlRecordField(s, colI.(*scopeColumn), t.ColName); newExpr != nil {
+			return false, newExpr
+		}
+
 	case *tree.Placeholder:
 		// Replace placeholders that are references to function arguments with
 		// scope columns that represent those arguments.
@@ -1277,6 +1300,7 @@ func isOrderedSetAggregate(
 // used later by the Builder to build aggregations in the aggregation scope.
 func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.ResolvedFunctionDefinition) tree.Expr {
 	f, def = s.replaceCount(f, def)
+	f = replaceStarArg(f, def)
 
 	// We need to save and restore the previous value of the field in
 	// semaCtx in case we are recursively called within a subquery
@@ -1342,6 +1366,7 @@ func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.ResolvedFunctionDef
 	}
 
 	f = typedFunc.(*tree.FuncExpr)
+	s.builder.checkUserDefinedAggregateCall(f, def.Name)
 
 	private := memo.FunctionPrivate{
 		Name:       def.Name,
@@ -1385,6 +1410,7 @@ func (s *scope) constructWindowDef(def tree.WindowDef) tree.WindowDef {
 
 func (s *scope) replaceWindowFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefinition) tree.Expr {
 	f, def = s.replaceCount(f, def)
+	f = replaceStarArg(f, def)
 
 	if err := tree.CheckIsWindowOrAgg(def); err != nil {
 		panic(err)
@@ -1414,6 +1440,7 @@ func (s *scope) replaceWindowFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefi
 	}
 
 	f = typedFunc.(*tree.FuncExpr)
+	s.builder.checkUserDefinedAggregateCall(f, def.Name)
 
 	// We will be performing type checking on expressions from PARTITION BY and
 	// ORDER BY clauses below, and we need the semantic context to know that we
@@ -1595,9 +1622,9 @@ func (s *scope) replaceCount(
 	}
 	f.Exprs[0] = vn
 
-	// It is ok to use string equality here, even if there is a UDF named
-	// "count" because UDFs cannot be aggregate functions. This code path is
-	// only executed for aggregate functions.
+	// It is ok to use string equality here, even if there is a user-defined
+	// aggregate named "count", because count(*) always refers to the builtin.
+	// This code path is only executed for aggregate functions.
 	if strings.EqualFold(def.Name, "count") && f.Type == 0 {
 		if _, ok := vn.(tree.UnqualifiedStar); ok {
 			if f.Filter != nil {
@@ -86,7 +86,11 @@ func (b *Builder) buildDataSource(
 			lockCtx.withoutTargets()
 		}
 
-		outScope = b.buildDataSource(source.Expr, indexFlags, lockCtx, inScope)
+		if source.TableSample != nil {
+			outScope = b.buildTableSample(source.Expr, source.TableSample, indexFlags, lockCtx, inScope)
+		} else {
+			outScope = b.buildDataSource(source.Expr, indexFlags, lockCtx, inScope)
+		}
 
 		if source.Ordinality {
 			outScope = b.buildWithOrdinality(outScope)
@@ -119,6 +123,17 @@ func (b *Builder) buildDataSource(
 				outCols[i] = newCol.id
 			}
 
+			var canaryCol opt.ColumnID
+			if cte.canaryCol != 0 {
+				// The canary column is scanned in order to filter the rows, but it is
+				// not visible to the query.
+				md := b.factory.Metadata()
+				c := md.ColumnMeta(cte.canaryCol)
+				canaryCol = md.AddColumn(c.Alias, c.Type)
+				inCols = append(inCols, cte.canaryCol)
+				outCols = append(outCols, canaryCol)
+			}
+
 			outScope.expr = b.factory.ConstructWithScan(&memo.WithScanPrivate{
 				With:    cte.id,
 				Name:    string(cte.name.Alias),
@@ -128,6 +143,22 @@ func (b *Builder) buildDataSource(
 				Mtr:     cte.mtr,
 			})
 
+			if canaryCol != 0 {
+				var filter opt.ScalarExpr
+				if cte.canaryNotNull {
+					filter = b.factory.ConstructIsNot(b.factory.ConstructVariable(canaryCol), memo.NullSingleton)
+				} else {
+					filter = b.factory.ConstructIs(b.factory.ConstructVariable(canaryCol), memo.NullSingleton)
+				}
+				outScope.expr = b.factory.ConstructSelect(
+					outScope.expr,
+					memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+				)
+				outScope.expr = b.factory.ConstructProject(
+					outScope.expr, memo.ProjectionsExpr{}, outScope.colSet(),
+				)
+			}
+
 			return outScope
 		}
 
@@ -166,6 +197,7 @@ func (b *Builder) buildDataSource(
 				indexFlags, locking, inScope,
 				false, /* disableNotVisibleIndex */
 				policyCommandScope,
+				opt.TableSample{}, /* tableSample */
 			)
 
 		case cat.Sequence:
@@ -316,31 +348,7 @@ func (b *Builder) buildView(
 	defer func() {
 		delete(b.sourceViews, viewName.FQString())
 	}()
-	// Cache the AST so that multiple references won't need to reparse.
-	if b.views == nil {
-		b.views = make(map[cat.View]*tree.Select)
-	}
-
-	// Check whether view has already been parsed, and if not, parse now.
-	sel, ok := b.views[view]
-	if !ok {
-		stmt, err := parser.ParseOne(view.Query())
-		if err != nil {
-			wrapped := pgerror.Wrapf(err, pgcode.Syntax,
-				"failed to parse underlying query from view %q", view.Name())
-			panic(wrapped)
-		}
-
-		sel, ok = stmt.AST.(*tree.Select)
-		if !ok {
-			panic(errors.AssertionFailedf("expected SELECT statement"))
-		}
-
-		b.views[view] = sel
-
-		// Keep track of referenced views for EXPLAIN (opt, env).
-		b.factory.Metadata().AddView(view)
-	}
+	sel := b.parseViewQuery(view)
 
 	// When building the view, we don't want to check for the SELECT privilege
 	// on the underlying tables, just on the view itself. Checking on the
@@ -391,6 +399,36 @@ func (b *Builder) buildView(
 	return outScope
 }
 
+// parseViewQuery returns the AST of the given view's query. The AST is cached
+// so that multiple references to the view won't need to reparse it.
+func (b *Builder) parseViewQuery(view cat.View) *tree.Select {
+	if b.views == nil {
+		b.views = make(map[cat.View]*tree.Select)
+	}
+
+	// Check whether view has already been parsed, and if not, parse now.
+	sel, ok := b.views[view]
+	if !ok {
+		stmt, err := parser.ParseOne(view.Query())
+		if err != nil {
+			wrapped := pgerror.Wrapf(err, pgcode.Syntax,
+				"failed to parse underlying query from view %q", view.Name())
+			panic(wrapped)
+		}
+
+		sel, ok = stmt.AST.(*tree.Select)
+		if !ok {
+			panic(errors.AssertionFailedf("expected SELECT statement"))
+		}
+
+		b.views[view] = sel
+
+		// Keep track of referenced views for EXPLAIN (opt, env).
+		b.factory.Metadata().AddView(view)
+	}
+	return sel
+}
+
 // renameSource applies an AS clause to the columns in scope.
 func (b *Builder) renameSource(as tree.AliasClause, scope *scope) {
 	if as.Alias != "" {
@@ -484,6 +522,7 @@ func (b *Builder) buildScanFromTableRef(
 	return b.buildScan(
 		tabMeta, ordinals, indexFlags, locking, inScope, false, /* disableNotVisibleIndex */
 		policyCommandScope,
+		opt.TableSample{}, /* tableSample */
 	)
 }
 
@@ -526,6 +565,9 @@ func errorOnInvalidMultiregionDB(
 // be in the list (in practice, this coincides with all "ordinary" table columns
 // being in the list).
 //
+// If tableSample is set, the scan returns a random sample of the rows of the
+// table (see buildTableSample).
+//
 // See Builder.buildStmt for a description of the remaining input and return
 // values.
 func (b *Builder) buildScan(
@@ -536,6 +578,7 @@ func (b *Builder) buildScan(
 	inScope *scope,
 	disableNotVisibleIndex bool,
 	policyCommandScope cat.PolicyCommandScope,
+	tableSample opt.TableSample,
 ) (outScope *scope) {
 	if ordinals == nil {
 		panic(errors.AssertionFailedf("no ordinals"))
@@ -635,7 +678,7 @@ func (b *Builder) buildScan(
 		}
 	}
 
-	private := memo.ScanPrivate{Table: tabID, Cols: scanColIDs}
+	private := memo.ScanPrivate{Table: tabID, Cols: scanColIDs, TableSample: tableSample}
 	if indexFlags != nil {
 		private.Flags.NoIndexJoin = indexFlags.NoIndexJoin
 		private.Flags.NoZigzagJoin = indexFlags.NoZigzagJoin
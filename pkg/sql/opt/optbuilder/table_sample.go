@@ -0,0 +1,151 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// buildTableSample builds a scan of the table referenced by texpr that
+// returns only a sample of its rows, as requested by a TABLESAMPLE clause:
+//
+//	SELECT * FROM t TABLESAMPLE BERNOULLI (10) REPEATABLE (42)
+//
+// The sample is recorded in the ScanPrivate, which prevents the scan from
+// being replaced by any alternative plan (see ScanPrivate.IsCanonical).
+// Sampling is only supported on tables and materialized views.
+func (b *Builder) buildTableSample(
+	texpr tree.TableExpr,
+	sample *tree.TableSample,
+	indexFlags *tree.IndexFlags,
+	lockCtx lockingContext,
+	inScope *scope,
+) (outScope *scope) {
+	if !b.evalCtx.Settings.Version.ActiveVersion(b.ctx).IsActive(clusterversion.V25_2_TableSample) {
+		panic(sqlerrors.NewTableSampleNotSupportedError())
+	}
+	tn, ok := texpr.(*tree.TableName)
+	if !ok || inScope.resolveCTE(tn) != nil {
+		panic(errTableSampleWrongObjectType)
+	}
+	if indexFlags != nil {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"TABLESAMPLE cannot be combined with index hints"))
+	}
+
+	ds, _, resName := b.resolveDataSource(tn, privilege.SELECT)
+	t, ok := ds.(cat.Table)
+	if !ok {
+		panic(errTableSampleWrongObjectType)
+	}
+	if t.IsVirtualTable() {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"TABLESAMPLE is not supported on virtual table %s", tree.ErrString(tn)))
+	}
+	lockCtx.filter(tn.ObjectName)
+	if lockCtx.locking.isSet() {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s cannot be applied to a table with a TABLESAMPLE clause",
+			lockCtx.locking.get().Strength))
+	}
+
+	tableSample := b.buildTableSampleParams(sample)
+	tabMeta := b.addTable(t, &resName)
+	policyCommandScope, locking := b.prepForTableScan(lockCtx.locking, tabMeta)
+	return b.buildScan(
+		tabMeta,
+		tableOrdinals(t, columnKinds{
+			includeMutations: false,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags, locking, inScope,
+		false, /* disableNotVisibleIndex */
+		policyCommandScope,
+		tableSample,
+	)
+}
+
+var errTableSampleWrongObjectType = pgerror.New(pgcode.WrongObjectType,
+	"TABLESAMPLE clause can only be applied to tables and materialized views")
+
+// buildTableSampleParams evaluates the percentage and seed arguments of a
+// TABLESAMPLE clause, which must be constants. If the arguments contain
+// placeholders while preparing a statement, the memo is marked as not
+// reusable so that the statement is rebuilt with the placeholder values
+// during execution.
+func (b *Builder) buildTableSampleParams(sample *tree.TableSample) opt.TableSample {
+	res := opt.TableSample{Method: sample.Method, Fraction: 1}
+
+	percent, ok := b.buildTableSampleArg(sample.Percent)
+	if ok {
+		if percent == tree.DNull {
+			panic(pgerror.New(pgcode.InvalidParameterValue,
+				"TABLESAMPLE parameter cannot be null"))
+		}
+		p := float64(*percent.(*tree.DFloat))
+		if math.IsNaN(p) || p < 0 || p > 100 {
+			panic(pgerror.New(pgcode.InvalidParameterValue,
+				"sample percentage must be between 0 and 100"))
+		}
+		res.Fraction = p / 100
+	}
+
+	if sample.Repeatable != nil {
+		seed, ok := b.buildTableSampleArg(sample.Repeatable)
+		if ok {
+			if seed == tree.DNull {
+				panic(pgerror.New(pgcode.InvalidParameterValue,
+					"TABLESAMPLE REPEATABLE parameter cannot be null"))
+			}
+			res.Seed = tableSampleSeed(float64(*seed.(*tree.DFloat)))
+		}
+		res.Repeatable = true
+	}
+	return res
+}
+
+// buildTableSampleArg builds the given TABLESAMPLE argument as a FLOAT and
+// returns its value. It returns ok=false if the value is not yet known
+// because the argument references placeholders.
+func (b *Builder) buildTableSampleArg(expr tree.Expr) (_ tree.Datum, ok bool) {
+	scalar := b.resolveAndBuildScalar(
+		expr, types.Float, exprKindTableSample,
+		tree.RejectSpecial|tree.RejectSubqueries, b.allocScope(),
+	)
+	if memo.CanExtractConstDatum(scalar) {
+		return memo.ExtractConstDatum(scalar), true
+	}
+	if b.KeepPlaceholders {
+		// The argument will be known once the placeholders are assigned, at
+		// which point the statement must be rebuilt.
+		b.DisableMemoReuse = true
+		return nil, false
+	}
+	panic(pgerror.New(pgcode.InvalidParameterValue,
+		"TABLESAMPLE argument must be a constant expression"))
+}
+
+// tableSampleSeed converts the REPEATABLE argument of a TABLESAMPLE clause to
+// the seed used to choose the sample. Integral arguments are used as-is, so
+// that they are displayed unchanged by EXPLAIN.
+func tableSampleSeed(f float64) int64 {
+	if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return int64(f)
+	}
+	return int64(math.Float64bits(f))
+}
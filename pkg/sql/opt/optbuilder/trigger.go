@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -45,11 +46,7 @@ func (mb *mutationBuilder) buildRowLevelBeforeTriggers(
 		return false
 	}
 
-	typeID := typedesc.TableIDToImplicitTypeOID(descpb.ID(mb.tab.ID()))
-	tableTyp, err := mb.b.semaCtx.TypeResolver.ResolveTypeByOID(mb.b.ctx, typeID)
-	if err != nil {
-		panic(err)
-	}
+	tableTyp := mb.b.resolveTableType(mb.tab)
 
 	// Create a mapping from the set of visible columns to their ordinals in the
 	// table.
@@ -83,8 +80,12 @@ func (mb *mutationBuilder) buildRowLevelBeforeTriggers(
 		triggerScope.expr = f.ConstructBarrier(triggerScope.expr)
 
 		// Resolve the trigger function and build the invocation.
-		args := mb.buildTriggerFunctionArgs(trigger, eventType, oldColID, newColID)
-		triggerFn, def := mb.b.buildTriggerFunction(triggers[i], mb.tab.ID(), tableTyp, args)
+		args := mb.b.buildTriggerFunctionArgs(
+			mb.tab, trigger, tree.TriggerActionTimeBefore, eventType, oldColID, newColID,
+		)
+		triggerFn, def := mb.b.buildTriggerFunction(
+			triggers[i], mb.tab.ID(), tableTyp, args, nil, /* transitions */
+		)
 
 		// If there is a WHEN condition, wrap the trigger function invocation in a
 		// CASE WHEN statement that checks the WHEN condition.
@@ -201,11 +202,15 @@ func (mb *mutationBuilder) buildOldAndNewCols(
 }
 
 // buildTriggerFunctionArgs builds the set of arguments that should be passed to
-// the trigger function.
-func (mb *mutationBuilder) buildTriggerFunctionArgs(
-	trigger cat.Trigger, eventType tree.TriggerEventType, oldColID, newColID opt.ColumnID,
+// the function of a row-level trigger defined on the given table or view.
+func (b *Builder) buildTriggerFunctionArgs(
+	ds cat.DataSource,
+	trigger cat.Trigger,
+	actionTime tree.TriggerActionTime,
+	eventType tree.TriggerEventType,
+	oldColID, newColID opt.ColumnID,
 ) memo.ScalarListExpr {
-	f := mb.b.factory
+	f := b.factory
 	tgNew := opt.ScalarExpr(memo.NullSingleton)
 	if newColID != 0 {
 		tgNew = f.ConstructVariable(newColID)
@@ -215,12 +220,12 @@ func (mb *mutationBuilder) buildTriggerFunctionArgs(
 		tgOld = f.ConstructVariable(oldColID)
 	}
 	tgName := tree.NewDName(string(trigger.Name()))
-	tgWhen := tree.NewDString("BEFORE")
+	tgWhen := tree.NewDString(tree.AsString(&actionTime))
 	tgLevel := tree.NewDString("ROW")
 	tgOp := tree.NewDString(eventType.String())
-	tgRelID := tree.NewDOid(oid.Oid(mb.tab.ID()))
-	tgTableName := tree.NewDString(string(mb.tab.Name()))
-	fqName, err := mb.b.catalog.FullyQualifiedName(mb.b.ctx, mb.tab)
+	tgRelID := tree.NewDOid(oid.Oid(ds.ID()))
+	tgTableName := tree.NewDString(string(ds.Name()))
+	fqName, err := b.catalog.FullyQualifiedName(b.ctx, ds)
 	if err != nil {
 		panic(err)
 	}
@@ -378,20 +383,66 @@ func (mb *mutationBuilder) recomputeComputedColsForTrigger(eventType tree.Trigge
 // Row-level AFTER triggers
 // ============================================================================
 
-// buildRowLevelAfterTriggers builds any applicable row-level AFTER triggers
-// based on the mutation operator. Since AFTER triggers are a form of
+// buildAfterTriggers builds any applicable row-level and statement-level AFTER
+// triggers based on the mutation operator. Since AFTER triggers are a form of
 // post-query, they are stored on mutationBuilder instead of being projected as
 // part of the mutation input.
 //
-// NOTE: buildRowLevelAfterTriggers doesn't actually build the expression that
-// calls the trigger functions. Instead, it stores the information needed to do
-// so after the mutation executes.
-func (mb *mutationBuilder) buildRowLevelAfterTriggers(mutation opt.Operator) {
+// NOTE: buildAfterTriggers doesn't actually build the expression that calls
+// the trigger functions. Instead, it stores the information needed to do so
+// after the mutation executes.
+func (mb *mutationBuilder) buildAfterTriggers(mutation opt.Operator) {
 	eventsToMatch := mb.getEventsToMatchForMutation(mutation)
-	triggers := cat.GetRowLevelTriggers(mb.tab, tree.TriggerActionTimeAfter, eventsToMatch)
-	if len(triggers) == 0 {
+	rowTriggers := cat.GetRowLevelTriggers(mb.tab, tree.TriggerActionTimeAfter, eventsToMatch)
+	var stmtTriggers []statementLevelTrigger
+	if mb.fireStatementTriggers {
+		stmtTriggers = mb.getStatementLevelTriggers(mutation, tree.TriggerActionTimeAfter)
+	}
+	if len(rowTriggers) == 0 && len(stmtTriggers) == 0 {
 		return
 	}
+
+	// The mutation input must be buffered for row-level triggers, as well as for
+	// triggers that reference transition relations.
+	needBuffer := len(rowTriggers) > 0
+	for i := range stmtTriggers {
+		needBuffer = needBuffer || hasTransitionRelations(stmtTriggers[i].trigger)
+	}
+	var fetchCols, updateCols, insertCols opt.ColList
+	var canaryCol opt.ColumnID
+	if needBuffer {
+		fetchCols, updateCols, insertCols = mb.addTriggerCols(mutation)
+		canaryCol = mb.canaryColID
+	}
+	if mb.afterTriggers != nil {
+		panic(errors.AssertionFailedf("afterTriggers already set"))
+	}
+	mb.afterTriggers = &memo.AfterTriggers{WithID: mb.withID}
+	if len(rowTriggers) > 0 {
+		mb.afterTriggers.Triggers = rowTriggers
+		mb.afterTriggers.Builder = mb.newRowLevelAfterTriggerBuilder(
+			mutation, rowTriggers, fetchCols, updateCols, insertCols,
+		)
+	}
+	if len(stmtTriggers) > 0 {
+		mb.afterTriggers.StatementTriggers = make([]cat.Trigger, len(stmtTriggers))
+		for i := range stmtTriggers {
+			mb.afterTriggers.StatementTriggers[i] = stmtTriggers[i].trigger
+		}
+		mb.afterTriggers.StatementBuilder = mb.newStatementLevelAfterTriggerBuilder(
+			stmtTriggers, fetchCols, updateCols, insertCols, canaryCol,
+		)
+	}
+}
+
+// addTriggerCols adds the columns needed to build the OLD and NEW rows of
+// AFTER triggers to triggerColIDs, and ensures that the mutation input is
+// buffered. It returns the lists of columns that provide the old values, the
+// new values of updated rows, and the new values of inserted rows. Each list
+// is either empty or has one entry per visible column in the table.
+func (mb *mutationBuilder) addTriggerCols(
+	mutation opt.Operator,
+) (fetchCols, updateCols, insertCols opt.ColList) {
 	mb.ensureWithID()
 
 	var visibleColOrds intsets.Fast
@@ -401,7 +452,6 @@ func (mb *mutationBuilder) buildRowLevelAfterTriggers(mutation opt.Operator) {
 		}
 	}
 
-	var fetchCols opt.ColList
 	if mutation == opt.DeleteOp || mutation == opt.UpdateOp || mb.canaryColID != 0 {
 		// For DELETE, UPDATE, and UPSERT/ON CONFLICT, we need to provide the old
 		// values for each row.
@@ -432,7 +482,6 @@ func (mb *mutationBuilder) buildRowLevelAfterTriggers(mutation opt.Operator) {
 		}
 		return newCols
 	}
-	var updateCols, insertCols opt.ColList
 	if mb.canaryColID != 0 || mutation == opt.UpdateOp {
 		updateCols = makeNewCols(mb.updateColIDs)
 	}
@@ -442,16 +491,7 @@ func (mb *mutationBuilder) buildRowLevelAfterTriggers(mutation opt.Operator) {
 	if mb.canaryColID != 0 {
 		mb.triggerColIDs.Add(mb.canaryColID)
 	}
-	if mb.afterTriggers != nil {
-		panic(errors.AssertionFailedf("afterTriggers already set"))
-	}
-	mb.afterTriggers = &memo.AfterTriggers{
-		Triggers: triggers,
-		Builder: mb.newRowLevelAfterTriggerBuilder(
-			mutation, triggers, fetchCols, updateCols, insertCols,
-		),
-		WithID: mb.withID,
-	}
+	return fetchCols, updateCols, insertCols
 }
 
 // getEventsToMatchForMutation returns the set of trigger events that should be
@@ -546,11 +586,7 @@ func (tb *rowLevelAfterTriggerBuilder) Build(
 			f := b.factory
 			md := f.Metadata()
 
-			typeID := typedesc.TableIDToImplicitTypeOID(descpb.ID(tb.mutatedTable.ID()))
-			tableTyp, err := semaCtx.TypeResolver.ResolveTypeByOID(ctx, typeID)
-			if err != nil {
-				panic(err)
-			}
+			tableTyp := b.resolveTableType(tb.mutatedTable)
 
 			// Map the columns from the original memo to the new one using colMap.
 			inFetchCols := tb.fetchCols.RemapColumns(colMap)
@@ -711,8 +747,15 @@ func (tb *rowLevelAfterTriggerBuilder) Build(
 					f.ConstructConstVal(tgArgV, types.StringArray),   // TG_ARGV
 				}
 
-				// Resolve the trigger function and build the invocation.
-				triggerFn, def := b.buildTriggerFunction(trigger, tb.mutatedTable.ID(), tableTyp, args)
+				// Resolve the trigger function and build the invocation. The trigger
+				// function can reference the transition relations, which scan the
+				// buffered mutation input.
+				transitions := b.buildTransitionRelations(
+					trigger, tableTyp, binding, inFetchCols, inUpdateCols, inInsertCols, inCanaryCol,
+				)
+				triggerFn, def := b.buildTriggerFunction(
+					trigger, tb.mutatedTable.ID(), tableTyp, args, transitions,
+				)
 
 				// If there is a WHEN condition, wrap the trigger function invocation in a
 				// CASE WHEN statement that checks the WHEN condition.
@@ -759,10 +802,450 @@ func (tb *rowLevelAfterTriggerBuilder) Build(
 		})
 }
 
+// ============================================================================
+// Statement-level triggers
+// ============================================================================
+
+// statementLevelTrigger is a statement-level trigger, along with the event for
+// which it fires. A trigger that matches more than one event of a mutation
+// (e.g. INSERT and UPDATE for an UPSERT) fires once for each matching event.
+type statementLevelTrigger struct {
+	trigger   cat.Trigger
+	eventType tree.TriggerEventType
+
+	// transitions contains the transition relations that can be referenced by
+	// the trigger function, keyed by name. It is only set for AFTER triggers
+	// with a REFERENCING clause, and only while the triggers are being built.
+	transitions map[string]*cteSource
+}
+
+// getStatementLevelTriggers returns the statement-level triggers with the
+// given timing that fire for the mutation, in the order in which they should
+// be executed.
+//
+// UPSERT and INSERT ... ON CONFLICT DO UPDATE statements fire both INSERT and
+// UPDATE triggers. As in Postgres, BEFORE INSERT triggers fire before BEFORE
+// UPDATE triggers, and AFTER UPDATE triggers fire before AFTER INSERT triggers.
+func (mb *mutationBuilder) getStatementLevelTriggers(
+	mutation opt.Operator, actionTime tree.TriggerActionTime,
+) []statementLevelTrigger {
+	var events []tree.TriggerEventType
+	switch mutation {
+	case opt.InsertOp:
+		events = []tree.TriggerEventType{tree.TriggerEventInsert}
+		if mb.canaryColID != 0 {
+			// This is an UPSERT or INSERT with ON CONFLICT, so rows can be updated in
+			// addition to being inserted.
+			if actionTime == tree.TriggerActionTimeBefore {
+				events = append(events, tree.TriggerEventUpdate)
+			} else {
+				events = append([]tree.TriggerEventType{tree.TriggerEventUpdate}, events...)
+			}
+		}
+	case opt.UpdateOp:
+		events = []tree.TriggerEventType{tree.TriggerEventUpdate}
+	case opt.DeleteOp:
+		events = []tree.TriggerEventType{tree.TriggerEventDelete}
+	default:
+		panic(errors.AssertionFailedf("unexpected mutation operator: %v", mutation))
+	}
+	var triggers []statementLevelTrigger
+	for _, eventType := range events {
+		eventsToMatch := tree.MakeTriggerEventTypeSet(eventType)
+		for _, trigger := range cat.GetStatementLevelTriggers(mb.tab, actionTime, eventsToMatch) {
+			triggers = append(triggers, statementLevelTrigger{trigger: trigger, eventType: eventType})
+		}
+	}
+	return triggers
+}
+
+// buildStatementLevelBeforeTriggers builds any applicable statement-level
+// BEFORE triggers for the mutation, which must already have been built in
+// mb.outScope. The trigger functions are invoked by the binding of a With
+// operator that wraps the mutation. The binding is always materialized, and is
+// executed before the main query, so the triggers fire exactly once before the
+// mutation even if it does not modify any rows.
+//
+// Statement-level triggers do not fire for the mutations of FK cascades.
+func (mb *mutationBuilder) buildStatementLevelBeforeTriggers(mutation opt.Operator) {
+	if !mb.fireStatementTriggers {
+		return
+	}
+	triggers := mb.getStatementLevelTriggers(mutation, tree.TriggerActionTimeBefore)
+	if len(triggers) == 0 {
+		return
+	}
+	tableTyp := mb.b.resolveTableType(mb.tab)
+	triggersExpr := mb.b.buildStatementLevelTriggers(
+		mb.tab, tableTyp, triggers, tree.TriggerActionTimeBefore,
+	)
+	mb.outScope.expr = mb.b.constructMaterializedWith(triggersExpr, mb.outScope.expr, "triggers")
+}
+
+// statementLevelAfterTriggerBuilder is a memo.PostQueryBuilder implementation
+// for statement-level AFTER triggers.
+//
+// It provides a method to build the trigger-function invocations, which happen
+// exactly once after the mutation, regardless of the number of modified rows.
+// The buffered mutation input is only used to build the transition relations
+// referenced by the triggers, if any.
+type statementLevelAfterTriggerBuilder struct {
+	mutatedTable cat.Table
+	triggers     []statementLevelTrigger
+
+	// stmtTreeInitFn returns a statementTree that tracks the mutations in
+	// ancestor statements. It may be unset if there are no ancestor statements.
+	stmtTreeInitFn func() statementTree
+
+	// The following fields contain the columns from the mutation input needed to
+	// build the transition relations; see rowLevelAfterTriggerBuilder. They are
+	// unset if no trigger references a transition relation.
+	fetchCols  opt.ColList
+	updateCols opt.ColList
+	insertCols opt.ColList
+	canaryCol  opt.ColumnID
+}
+
+var _ memo.PostQueryBuilder = &statementLevelAfterTriggerBuilder{}
+
+func (mb *mutationBuilder) newStatementLevelAfterTriggerBuilder(
+	triggers []statementLevelTrigger,
+	fetchCols, updateCols, insertCols opt.ColList,
+	canaryCol opt.ColumnID,
+) *statementLevelAfterTriggerBuilder {
+	return &statementLevelAfterTriggerBuilder{
+		mutatedTable:   mb.tab,
+		triggers:       triggers,
+		stmtTreeInitFn: mb.b.stmtTree.GetInitFnForPostQuery(),
+		fetchCols:      fetchCols,
+		updateCols:     updateCols,
+		insertCols:     insertCols,
+		canaryCol:      canaryCol,
+	}
+}
+
+// Build is part of the memo.PostQueryBuilder interface.
+func (tb *statementLevelAfterTriggerBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	colMap opt.ColMap,
+) (_ memo.RelExpr, err error) {
+	return buildTriggerCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, tb.stmtTreeInitFn,
+		func(b *Builder) memo.RelExpr {
+			tableTyp := b.resolveTableType(tb.mutatedTable)
+
+			// Copy the triggers, since Build must not mutate captured state.
+			triggers := make([]statementLevelTrigger, len(tb.triggers))
+			copy(triggers, tb.triggers)
+			hasTransitions := len(tb.fetchCols) > 0 || len(tb.updateCols) > 0 ||
+				len(tb.insertCols) > 0
+			if hasTransitions {
+				if binding == 0 {
+					panic(errors.AssertionFailedf("missing buffer for transition relations"))
+				}
+				// Map the columns from the original memo to the new one using colMap.
+				inFetchCols := tb.fetchCols.RemapColumns(colMap)
+				inUpdateCols := tb.updateCols.RemapColumns(colMap)
+				inInsertCols := tb.insertCols.RemapColumns(colMap)
+				var inCanaryCol opt.ColumnID
+				if tb.canaryCol != 0 {
+					inCanaryColID, ok := colMap.Get(int(tb.canaryCol))
+					if !ok {
+						panic(errors.AssertionFailedf("column %d not in mapping %s\n",
+							tb.canaryCol, colMap.String()))
+					}
+					inCanaryCol = opt.ColumnID(inCanaryColID)
+				}
+				b.factory.Metadata().AddWithBinding(binding, b.factory.ConstructFakeRel(
+					&memo.FakeRelPrivate{Props: bindingProps},
+				))
+				for i := range triggers {
+					triggers[i].transitions = b.buildTransitionRelations(
+						triggers[i].trigger, tableTyp, binding,
+						inFetchCols, inUpdateCols, inInsertCols, inCanaryCol,
+					)
+				}
+			}
+			return b.buildStatementLevelTriggers(
+				tb.mutatedTable, tableTyp, triggers, tree.TriggerActionTimeAfter,
+			)
+		})
+}
+
+// buildStatementLevelTriggers builds an expression that invokes the trigger
+// function of each of the given statement-level triggers exactly once, in
+// order. The OLD and NEW arguments of the trigger functions are NULL, and
+// their results are ignored.
+func (b *Builder) buildStatementLevelTriggers(
+	tab cat.Table,
+	tableTyp *types.T,
+	triggers []statementLevelTrigger,
+	actionTime tree.TriggerActionTime,
+) memo.RelExpr {
+	f := b.factory
+	fqName, err := b.catalog.FullyQualifiedName(b.ctx, tab)
+	if err != nil {
+		panic(err)
+	}
+	tgWhen := tree.NewDString(tree.AsString(&actionTime))
+	tgLevel := tree.NewDString("STATEMENT")
+	tgRelID := tree.NewDOid(oid.Oid(tab.ID()))
+	tgTableName := tree.NewDString(string(tab.Name()))
+	tgTableSchema := tree.NewDString(fqName.Schema())
+
+	triggerScope := b.allocScope()
+	triggerScope.expr = f.ConstructNoColsRow()
+	for i := range triggers {
+		if i > 0 {
+			// No need to place a barrier below the first trigger.
+			triggerScope.expr = f.ConstructBarrier(triggerScope.expr)
+		}
+		trigger := triggers[i].trigger
+		tgName := tree.NewDName(string(trigger.Name()))
+		tgOp := tree.NewDString(triggers[i].eventType.String())
+		tgNumArgs := tree.NewDInt(tree.DInt(len(trigger.FuncArgs())))
+		tgArgV := tree.NewDArray(types.String)
+		for _, arg := range trigger.FuncArgs() {
+			if err = tgArgV.Append(arg); err != nil {
+				panic(err)
+			}
+		}
+		args := memo.ScalarListExpr{
+			memo.NullSingleton,                               // NEW
+			memo.NullSingleton,                               // OLD
+			f.ConstructConstVal(tgName, types.Name),          // TG_NAME
+			f.ConstructConstVal(tgWhen, types.String),        // TG_WHEN
+			f.ConstructConstVal(tgLevel, types.String),       // TG_LEVEL
+			f.ConstructConstVal(tgOp, types.String),          // TG_OP
+			f.ConstructConstVal(tgRelID, types.Oid),          // TG_RELIID
+			f.ConstructConstVal(tgTableName, types.String),   // TG_RELNAME
+			f.ConstructConstVal(tgTableName, types.String),   // TG_TABLE_NAME
+			f.ConstructConstVal(tgTableSchema, types.String), // TG_TABLE_SCHEMA
+			f.ConstructConstVal(tgNumArgs, types.Int),        // TG_NARGS
+			f.ConstructConstVal(tgArgV, types.StringArray),   // TG_ARGV
+		}
+
+		// Resolve the trigger function and build the invocation.
+		triggerFn, def := b.buildTriggerFunction(
+			trigger, tab.ID(), tableTyp, args, triggers[i].transitions,
+		)
+
+		// If there is a WHEN condition, wrap the trigger function invocation in a
+		// CASE WHEN statement that checks the WHEN condition. The condition of a
+		// statement-level trigger cannot reference the OLD and NEW rows.
+		if trigger.WhenExpr() != "" {
+			triggerFn = b.buildTriggerWhen(
+				trigger, triggerScope, 0 /* oldColID */, 0 /* newColID */, triggerFn, f.ConstructNull(tableTyp),
+			)
+		}
+
+		// Finally, project a column that invokes the trigger function.
+		b.projectColWithMetadataName(triggerScope, def.Name, tableTyp, triggerFn)
+	}
+	// Always wrap the expression in a barrier, or else the projections will be
+	// pruned and the triggers will not be executed.
+	return f.ConstructBarrier(triggerScope.expr)
+}
+
+// hasTransitionRelations returns true if the given trigger has a REFERENCING
+// clause that names the OLD or NEW transition relation.
+func hasTransitionRelations(trigger cat.Trigger) bool {
+	return trigger.OldTransitionAlias() != "" || trigger.NewTransitionAlias() != ""
+}
+
+// buildTransitionRelations builds the OLD and NEW transition relations for the
+// given AFTER trigger, keyed by the names given to them by the REFERENCING
+// clause. It returns nil if the trigger does not reference any transition
+// relations.
+//
+// The transition relations scan the buffered mutation input bound to the given
+// WithID. The OLD relation scans fetchCols, and the NEW relation scans either
+// updateCols or insertCols, depending on the trigger event. For UPSERT and
+// INSERT ... ON CONFLICT, canaryCol is used to restrict the relations to the
+// rows that were inserted (for INSERT triggers) or updated (for UPDATE
+// triggers).
+func (b *Builder) buildTransitionRelations(
+	trigger cat.Trigger,
+	tableTyp *types.T,
+	binding opt.WithID,
+	fetchCols, updateCols, insertCols opt.ColList,
+	canaryCol opt.ColumnID,
+) map[string]*cteSource {
+	if !hasTransitionRelations(trigger) {
+		return nil
+	}
+	if trigger.EventCount() != 1 {
+		panic(errors.AssertionFailedf("expected a single event for a trigger with transition relations"))
+	}
+	eventType := trigger.Event(0).EventType
+	newCols := insertCols
+	if eventType == tree.TriggerEventUpdate {
+		newCols = updateCols
+	}
+	bindingExpr := b.factory.Metadata().WithBinding(binding).(memo.RelExpr)
+	transitions := make(map[string]*cteSource, 2)
+	addTransition := func(name tree.Name, cols opt.ColList) {
+		if len(cols) != len(tableTyp.TupleContents()) {
+			panic(errors.AssertionFailedf("unexpected number of columns for transition relation"))
+		}
+		presentation := make(physical.Presentation, len(cols))
+		for i, col := range cols {
+			presentation[i] = opt.AliasedColumn{Alias: tableTyp.TupleLabels()[i], ID: col}
+		}
+		cte := &cteSource{
+			id:   binding,
+			name: tree.AliasClause{Alias: name},
+			cols: presentation,
+			expr: bindingExpr,
+			mtr:  tree.CTEMaterializeAlways,
+		}
+		if canaryCol != 0 {
+			cte.canaryCol = canaryCol
+			cte.canaryNotNull = eventType == tree.TriggerEventUpdate
+		}
+		transitions[name.String()] = cte
+	}
+	if name := trigger.OldTransitionAlias(); name != "" {
+		addTransition(name, fetchCols)
+	}
+	if name := trigger.NewTransitionAlias(); name != "" {
+		addTransition(name, newCols)
+	}
+	return transitions
+}
+
+// ============================================================================
+// TRUNCATE triggers
+// ============================================================================
+
+// buildTruncateTriggers wraps the given TRUNCATE expression with the BEFORE
+// and AFTER TRUNCATE triggers of the truncated tables, including the tables
+// that are truncated because of CASCADE. TRUNCATE triggers are always
+// statement-level triggers.
+//
+// The triggers and the TRUNCATE itself are the bindings of materialized With
+// operators, which are executed in order before the (empty) main query: first
+// the BEFORE triggers, then the TRUNCATE, and finally the AFTER triggers.
+func (b *Builder) buildTruncateTriggers(
+	truncate *tree.Truncate, truncateExpr memo.RelExpr,
+) memo.RelExpr {
+	var before, after []memo.RelExpr
+	eventsToMatch := tree.MakeTriggerEventTypeSet(tree.TriggerEventTruncate)
+	buildTriggers := func(tab cat.Table, actionTime tree.TriggerActionTime) memo.RelExpr {
+		triggers := cat.GetStatementLevelTriggers(tab, actionTime, eventsToMatch)
+		if len(triggers) == 0 {
+			return nil
+		}
+		stmtTriggers := make([]statementLevelTrigger, len(triggers))
+		for i := range triggers {
+			stmtTriggers[i] = statementLevelTrigger{
+				trigger:   triggers[i],
+				eventType: tree.TriggerEventTruncate,
+			}
+		}
+		return b.buildStatementLevelTriggers(tab, b.resolveTableType(tab), stmtTriggers, actionTime)
+	}
+	for _, tab := range b.resolveTablesForTruncate(truncate) {
+		if expr := buildTriggers(tab, tree.TriggerActionTimeBefore); expr != nil {
+			before = append(before, expr)
+		}
+		if expr := buildTriggers(tab, tree.TriggerActionTimeAfter); expr != nil {
+			after = append(after, expr)
+		}
+	}
+	if len(before) == 0 && len(after) == 0 {
+		return truncateExpr
+	}
+
+	// Build the With operators from the bottom up.
+	expr := truncateExpr
+	if len(after) > 0 {
+		expr = b.factory.ConstructZeroValues()
+		for i := len(after) - 1; i >= 0; i-- {
+			expr = b.constructMaterializedWith(after[i], expr, "triggers")
+		}
+		expr = b.constructMaterializedWith(truncateExpr, expr, "truncate")
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		expr = b.constructMaterializedWith(before[i], expr, "triggers")
+	}
+	return expr
+}
+
+// resolveTablesForTruncate resolves the tables that are truncated by the given
+// TRUNCATE statement. If CASCADE is specified, the tables that reference the
+// truncated tables are included, after the explicitly named tables.
+func (b *Builder) resolveTablesForTruncate(truncate *tree.Truncate) []cat.Table {
+	tables := make([]cat.Table, 0, len(truncate.Tables))
+	seen := make(map[cat.StableID]struct{}, len(truncate.Tables))
+	for i := range truncate.Tables {
+		// Copy the name so that the AST is not modified.
+		tn := truncate.Tables[i]
+		tab, _ := b.resolveTable(&tn, privilege.DROP)
+		if _, ok := seen[tab.ID()]; ok {
+			continue
+		}
+		seen[tab.ID()] = struct{}{}
+		tables = append(tables, tab)
+	}
+	if truncate.DropBehavior != tree.DropCascade {
+		// Without CASCADE, the TRUNCATE fails if there are referencing tables
+		// that are not truncated.
+		return tables
+	}
+	for i := 0; i < len(tables); i++ {
+		tab := tables[i]
+		for j := 0; j < tab.InboundForeignKeyCount(); j++ {
+			originID := tab.InboundForeignKey(j).OriginTableID()
+			if _, ok := seen[originID]; ok {
+				continue
+			}
+			seen[originID] = struct{}{}
+			ds, _, err := b.catalog.ResolveDataSourceByID(b.ctx, cat.Flags{}, originID)
+			if err != nil {
+				panic(err)
+			}
+			b.checkPrivilege(opt.DepByID(originID), ds, privilege.DROP)
+			tables = append(tables, ds.(cat.Table))
+		}
+	}
+	return tables
+}
+
 // ============================================================================
 // Shared logic
 // ============================================================================
 
+// resolveTableType returns the implicit record type of the given table or view.
+func (b *Builder) resolveTableType(ds cat.DataSource) *types.T {
+	typeID := typedesc.TableIDToImplicitTypeOID(descpb.ID(ds.ID()))
+	tableTyp, err := b.semaCtx.TypeResolver.ResolveTypeByOID(b.ctx, typeID)
+	if err != nil {
+		panic(err)
+	}
+	return tableTyp
+}
+
+// constructMaterializedWith wraps the given expression in a With operator with
+// the given binding. The binding is always materialized, so it is executed
+// exactly once before the wrapped expression, even if it is never referenced.
+// It is used to invoke trigger functions at a specific point in the execution
+// of a statement.
+func (b *Builder) constructMaterializedWith(binding, expr memo.RelExpr, name string) memo.RelExpr {
+	id := b.factory.Memo().NextWithID()
+	b.factory.Metadata().AddWithBinding(id, binding)
+	return b.factory.ConstructWith(binding, expr, &memo.WithPrivate{
+		ID:   id,
+		Name: name,
+		Mtr:  tree.CTEMaterializeAlways,
+	})
+}
+
 type cachedTriggerFunc struct {
 	triggerName tree.Name
 	funDef      *memo.UDFDefinition
@@ -770,9 +1253,15 @@ type cachedTriggerFunc struct {
 }
 
 // buildTriggerFunction resolves and builds a trigger function invocation for
-// the given trigger, using the given arguments.
+// the given trigger, using the given arguments. If the trigger references
+// transition relations, they must be supplied by the transitions map, which is
+// keyed by the transition relation names (see buildTransitionRelations).
 func (b *Builder) buildTriggerFunction(
-	trigger cat.Trigger, tableID cat.StableID, tableTyp *types.T, args memo.ScalarListExpr,
+	trigger cat.Trigger,
+	tableID cat.StableID,
+	tableTyp *types.T,
+	args memo.ScalarListExpr,
+	transitions map[string]*cteSource,
 ) (opt.ScalarExpr, *tree.ResolvedFunctionDefinition) {
 	cached := b.builtTriggerFuncs[tableID]
 	for _, cachedFunc := range cached {
@@ -784,6 +1273,8 @@ func (b *Builder) buildTriggerFunction(
 
 	f := b.factory
 	triggerFuncScope := b.allocScope()
+	// The trigger function body can reference the transition relations, if any.
+	triggerFuncScope.ctes = transitions
 	funcRef := &tree.FunctionOID{OID: catid.FuncIDToOID(catid.DescID(trigger.FuncID()))}
 	funcExpr := tree.FuncExpr{Func: tree.ResolvableFunctionReference{FunctionReference: funcRef}}
 	triggerFuncScope.resolveType(&funcExpr, types.AnyElement)
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -69,14 +70,8 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 		panic(pgerror.DangerousStatementf("UPDATE without WHERE or LIMIT clause"))
 	}
 
-	// Find which table we're working on, check the permissions.
-	tab, depName, alias, refColumns := b.resolveTableForMutation(upd.Table, privilege.UPDATE)
-
-	if tab.IsVirtualTable() {
-		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
-			"cannot update view \"%s\"", tab.Name(),
-		))
-	}
+	// Find which table or view we're working on, check the permissions.
+	ds, depName, alias, refColumns := b.resolveDataSourceForMutation(upd.Table, privilege.UPDATE)
 
 	if refColumns != nil {
 		panic(pgerror.Newf(pgcode.Syntax,
@@ -84,13 +79,37 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 	}
 
 	// Check Select permission as well, since existing values must be read.
-	b.checkPrivilege(depName, tab, privilege.SELECT)
+	b.checkPrivilege(depName, ds, privilege.SELECT)
+
+	// Views are updated by their INSTEAD OF triggers if they have any, or else
+	// by updating the table of an automatically updatable view.
+	var uv *updatableView
+	if view, ok := ds.(cat.View); ok {
+		if cat.HasRowLevelTriggers(view, tree.TriggerActionTimeInsteadOf, tree.TriggerEventUpdate) {
+			return b.buildUpdateInsteadOfTriggers(upd, view, alias, inScope)
+		}
+		uv = b.resolveUpdatableView(view, tree.TriggerEventUpdate)
+	}
+
+	var tab cat.Table
+	if uv != nil {
+		tab = uv.tab
+	} else {
+		tab = ds.(cat.Table)
+		if tab.IsVirtualTable() {
+			panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot update view \"%s\"", tab.Name(),
+			))
+		}
+	}
 
 	// Check if this table has already been mutated in another subquery.
 	b.checkMultipleMutations(tab, generalMutation)
 
 	var mb mutationBuilder
 	mb.init(b, "update", tab, alias)
+	mb.view = uv
+	mb.fireStatementTriggers = true
 
 	// Build the input expression that selects the rows that will be updated:
 	//
@@ -117,6 +136,9 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 		mb.buildUpdate(nil /* returning */)
 	}
 
+	// Build statement-level BEFORE triggers, which fire before the mutation.
+	mb.buildStatementLevelBeforeTriggers(opt.UpdateOp)
+
 	return mb.outScope
 }
 
@@ -130,7 +152,11 @@ func (mb *mutationBuilder) addTargetColsForUpdate(exprs tree.UpdateExprs) {
 	}
 
 	for _, expr := range exprs {
-		mb.addTargetColsByName(expr.Names)
+		if mb.view != nil {
+			mb.addTargetColsByViewName(expr.Names, "update")
+		} else {
+			mb.addTargetColsByName(expr.Names)
+		}
 
 		if expr.Tuple {
 			n := -1
@@ -146,7 +172,7 @@ func (mb *mutationBuilder) addTargetColsForUpdate(exprs tree.UpdateExprs) {
 				for i := range desiredTypes {
 					desiredTypes[i] = mb.md.ColumnMeta(mb.targetColList[targetIdx+i]).Type
 				}
-				outScope := mb.b.buildSelectStmt(t.Select, noLocking, desiredTypes, mb.outScope)
+				outScope := mb.b.buildSelectStmt(t.Select, noLocking, desiredTypes, mb.exprScope())
 				mb.subqueries = append(mb.subqueries, outScope)
 				n = len(outScope.cols)
 
@@ -190,8 +216,9 @@ func (mb *mutationBuilder) addUpdateCols(exprs tree.UpdateExprs) {
 	defer scalarProps.Restore(*scalarProps)
 	mb.b.semaCtx.Properties.Require("UPDATE SET", tree.RejectSpecial)
 
-	// UPDATE input columns are accessible to SET expressions.
-	inScope := mb.outScope
+	// UPDATE input columns are accessible to SET expressions. If the target is
+	// an updatable view, the view's columns are accessible instead.
+	inScope := mb.exprScope()
 
 	// Project additional column(s) for each update expression (can be multiple
 	// columns in case of tuple assignment).
@@ -251,10 +278,11 @@ func (mb *mutationBuilder) addUpdateCols(exprs tree.UpdateExprs) {
 				}
 
 				// Lazily create new scope to hold results of join.
-				if mb.outScope == inScope {
-					mb.outScope = inScope.replace()
-					mb.outScope.appendColumnsFromScope(inScope)
-					mb.outScope.expr = inScope.expr
+				if mb.outScope == inScope || mb.outScope.expr == inScope.expr {
+					outScope := mb.outScope.replace()
+					outScope.appendColumnsFromScope(mb.outScope)
+					outScope.expr = mb.outScope.expr
+					mb.outScope = outScope
 				}
 
 				// Wrap input with Max1Row + LOJ.
@@ -338,6 +366,9 @@ func (mb *mutationBuilder) buildUpdate(returning *tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(true /* isUpdate */)
 
+	// Add the WITH CHECK OPTION check of the view, if any.
+	mb.buildViewCheckOption()
+
 	// Add the partial index predicate expressions to the table metadata.
 	// These expressions are used to prune fetch columns during
 	// normalization.
@@ -353,7 +384,7 @@ func (mb *mutationBuilder) buildUpdate(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpdate()
 
-	mb.buildRowLevelAfterTriggers(opt.UpdateOp)
+	mb.buildAfterTriggers(opt.UpdateOp)
 
 	private := mb.makeMutationPrivate(returning != nil, false /* vectorInsert */)
 	for _, col := range mb.extraAccessibleCols {
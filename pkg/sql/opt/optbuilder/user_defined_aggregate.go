@@ -0,0 +1,250 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// isUserDefinedAggregate returns true if the given aggregate is a user-defined
+// aggregate.
+func isUserDefinedAggregate(def *memo.FunctionPrivate) bool {
+	return def.Overload != nil && def.Overload.Type == tree.UDFRoutine
+}
+
+// hasUserDefinedOverload returns true if the given function definition has at
+// least one user-defined overload.
+func hasUserDefinedOverload(def *tree.ResolvedFunctionDefinition) bool {
+	for i := range def.Overloads {
+		if def.Overloads[i].Type == tree.UDFRoutine {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceStarArg removes the argument of a call of the form agg(*), which is
+// how a user-defined aggregate without arguments is called. It must be called
+// after replaceCount, which normalizes the argument.
+func replaceStarArg(f *tree.FuncExpr, def *tree.ResolvedFunctionDefinition) *tree.FuncExpr {
+	if len(f.Exprs) != 1 || !hasUserDefinedOverload(def) {
+		return f
+	}
+	if _, ok := f.Exprs[0].(tree.UnqualifiedStar); !ok {
+		return f
+	}
+	fCopy := *f
+	fCopy.Exprs = nil
+	return &fCopy
+}
+
+// checkUserDefinedAggregateCall validates a type-checked call to an aggregate.
+// If the call is to a user-defined aggregate, it checks that the current user
+// can execute the aggregate, and tracks the aggregate as a dependency of the
+// query so that the query is re-planned if the aggregate is altered or
+// dropped.
+func (b *Builder) checkUserDefinedAggregateCall(f *tree.FuncExpr, name string) {
+	o := f.ResolvedOverload()
+	if o.Type != tree.UDFRoutine {
+		return
+	}
+	if f.AggType == tree.OrderedSetAgg {
+		panic(pgerror.Newf(pgcode.WrongObjectType,
+			"WITHIN GROUP specified, but %s is not an ordered-set aggregate", name))
+	}
+	if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid, b.checkPrivilegeUser); err != nil {
+		panic(err)
+	}
+	argTypes := make([]*types.T, len(f.Exprs))
+	for i := range f.Exprs {
+		argTypes[i] = f.Exprs[i].(tree.TypedExpr).ResolvedType()
+	}
+	b.factory.Metadata().AddUserDefinedRoutine(o, argTypes, f.Func.ReferenceByName)
+	if b.trackSchemaDeps {
+		b.schemaFunctionDeps.Add(int(o.Oid))
+	}
+}
+
+// aggregateArgs returns the arguments with which the given aggregate is built.
+// A user-defined aggregate has a single argument, which is a tuple of its
+// arguments cast to its parameter types. The tuple is empty if the aggregate
+// has no arguments.
+func aggregateArgs(f *tree.FuncExpr, def *memo.FunctionPrivate) []tree.TypedExpr {
+	args := getTypedExprs(f.Exprs)
+	if !isUserDefinedAggregate(def) {
+		return args
+	}
+	paramTypes := def.Overload.Types.Types()
+	exprs := make(tree.Exprs, len(args))
+	for i, arg := range args {
+		if !arg.ResolvedType().Identical(paramTypes[i]) {
+			arg = tree.NewTypedCastExpr(arg, paramTypes[i])
+		}
+		exprs[i] = arg
+	}
+	return []tree.TypedExpr{tree.NewTypedTuple(types.MakeTuple(paramTypes), exprs)}
+}
+
+// constructUserDefinedAgg constructs a call to the given user-defined
+// aggregate with the given argument tuple (see aggregateArgs).
+func (b *Builder) constructUserDefinedAgg(
+	def *memo.FunctionPrivate, arg opt.ScalarExpr,
+) opt.ScalarExpr {
+	return b.factory.ConstructUserDefinedAgg(arg, &memo.UserDefinedAggPrivate{
+		Def: b.buildUserDefinedAggDefinition(def),
+	})
+}
+
+// buildUserDefinedAggDefinition builds the definition of the given
+// user-defined aggregate. The support functions of the aggregate are built as
+// calls over synthesized columns that stand in for the state and arguments of
+// the aggregate. The definition is cached, so it is only built once for each
+// aggregate referenced by the query.
+func (b *Builder) buildUserDefinedAggDefinition(
+	def *memo.FunctionPrivate,
+) *memo.UserDefinedAggDefinition {
+	o := def.Overload
+	if d, ok := b.builtUserDefinedAggs[o.Oid]; ok {
+		return d
+	}
+	if o.UDFContainsOnlySignature {
+		var err error
+		if _, o, err = b.catalog.ResolveFunctionByOID(b.ctx, o.Oid); err != nil {
+			panic(err)
+		}
+	}
+	agg := o.Aggregate
+	if agg == nil {
+		panic(errors.AssertionFailedf("missing definition of aggregate %s", def.Name))
+	}
+	paramTypes := o.Types.Types()
+	d := &memo.UserDefinedAggDefinition{
+		Name:      def.Name,
+		Overload:  o,
+		StateType: agg.StateType,
+	}
+
+	// The support functions are checked against the owner of the aggregate,
+	// which was allowed to execute them when the aggregate was created.
+	defer func(checkPrivilegeUser username.SQLUsername, trackSchemaDeps bool, subq *subquery) {
+		b.checkPrivilegeUser = checkPrivilegeUser
+		b.trackSchemaDeps = trackSchemaDeps
+		b.subquery = subq
+	}(b.checkPrivilegeUser, b.trackSchemaDeps, b.subquery)
+	owner, err := b.catalog.GetRoutineOwner(b.ctx, o.Oid)
+	if err != nil {
+		panic(err)
+	}
+	b.checkPrivilegeUser = owner
+	// The query only depends on the aggregate itself, which depends on its
+	// support functions.
+	b.trackSchemaDeps = false
+	// The synthesized columns are not outer columns of any subquery.
+	b.subquery = nil
+
+	// Synthesize the columns for the state and arguments of the aggregate.
+	s := b.allocScope()
+	s.cols = make([]scopeColumn, 0, len(paramTypes)+2)
+	colName := func(suffix string) scopeColumnName {
+		return scopeColName("").WithMetadataName(fmt.Sprintf("%s_%s", def.Name, suffix))
+	}
+	b.synthesizeColumn(s, colName("state"), agg.StateType, nil /* expr */, nil /* scalar */)
+	for i, typ := range paramTypes {
+		b.synthesizeColumn(s, colName(fmt.Sprintf("arg%d", i+1)), typ, nil /* expr */, nil /* scalar */)
+	}
+	b.synthesizeColumn(s, colName("other_state"), agg.StateType, nil /* expr */, nil /* scalar */)
+	stateCol := &s.cols[0]
+	argCols := s.cols[1 : len(paramTypes)+1]
+	otherStateCol := &s.cols[len(paramTypes)+1]
+	d.StateCol = stateCol.id
+	d.ArgCols = make(opt.ColList, len(argCols))
+	for i := range argCols {
+		d.ArgCols[i] = argCols[i].id
+	}
+	d.OtherStateCol = otherStateCol.id
+
+	// vol is the maximum volatility of the support functions and of the cast
+	// of the initial condition to the state type.
+	vol := volatility.Leakproof
+	if agg.InitCond != nil {
+		if v, ok := cast.LookupCastVolatility(types.String, agg.StateType); ok {
+			vol = max(vol, v)
+		}
+	}
+
+	// call builds a call to the support function with the given OID.
+	call := func(funcOID oid.Oid, args ...tree.TypedExpr) (opt.ScalarExpr, *tree.Overload) {
+		f := &tree.FuncExpr{
+			Func:  tree.ResolvableFunctionReference{FunctionReference: &tree.FunctionOID{OID: funcOID}},
+			Exprs: make(tree.Exprs, len(args)),
+		}
+		for i := range args {
+			f.Exprs[i] = args[i]
+		}
+		typed := s.resolveType(f, types.AnyElement)
+		typedFunc, ok := typed.(*tree.FuncExpr)
+		if !ok {
+			panic(errors.AssertionFailedf("expected call to support function of %s", def.Name))
+		}
+		fo := typedFunc.ResolvedOverload()
+		vol = max(vol, fo.Volatility)
+		return b.buildScalar(typed, s, nil /* outScope */, nil /* outCol */, nil /* colRefs */), fo
+	}
+
+	// The support functions are built as opaque calls, since the definition is
+	// not optimized along with the rest of the query. Inlining a SQL function
+	// would turn it into a subquery.
+	b.factory.DisableOptimizationRulesTemporarily(intsets.MakeFast(int(opt.InlineUDF)), func() {
+		var initExpr tree.TypedExpr = tree.NewTypedCastExpr(tree.DNull, agg.StateType)
+		if agg.InitCond != nil {
+			initExpr = tree.NewTypedCastExpr(tree.NewDString(*agg.InitCond), agg.StateType)
+		}
+		d.InitialState = b.buildScalar(initExpr, s, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+
+		transitionArgs := make([]tree.TypedExpr, 0, len(argCols)+1)
+		transitionArgs = append(transitionArgs, stateCol)
+		for i := range argCols {
+			transitionArgs = append(transitionArgs, &argCols[i])
+		}
+		var sfunc *tree.Overload
+		d.Transition, sfunc = call(agg.SFuncOID, transitionArgs...)
+		d.TransitionStrict = !sfunc.CalledOnNullInput
+
+		if agg.CombineFuncOID != 0 {
+			var combinefunc *tree.Overload
+			d.Combine, combinefunc = call(agg.CombineFuncOID, stateCol, otherStateCol)
+			d.CombineStrict = !combinefunc.CalledOnNullInput
+		}
+
+		d.Typ = agg.StateType
+		d.Empty = d.InitialState
+		if agg.FinalFuncOID != 0 {
+			d.Final, _ = call(agg.FinalFuncOID, stateCol)
+			d.Empty, _ = call(agg.FinalFuncOID, initExpr)
+			d.Typ = d.Final.DataType()
+		}
+	})
+	d.Volatility = vol
+
+	if b.builtUserDefinedAggs == nil {
+		b.builtUserDefinedAggs = make(map[oid.Oid]*memo.UserDefinedAggDefinition)
+	}
+	b.builtUserDefinedAggs[o.Oid] = d
+	return d
+}
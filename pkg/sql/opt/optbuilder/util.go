@@ -573,6 +573,21 @@ func (b *Builder) checkMultipleMutationsCascade(
 func (b *Builder) resolveTableForMutation(
 	n tree.TableExpr, priv privilege.Kind,
 ) (tab cat.Table, depName opt.MDDepName, alias tree.TableName, columns []tree.ColumnID) {
+	ds, depName, alias, columns := b.resolveDataSourceForMutation(n, priv)
+	tab, ok := ds.(cat.Table)
+	if !ok {
+		panic(sqlerrors.NewWrongObjectTypeError(tree.NewUnqualifiedTableName(ds.Name()), "table"))
+	}
+	return tab, depName, alias, columns
+}
+
+// resolveDataSourceForMutation is like resolveTableForMutation, except that
+// the TableExpr may also resolve to a view. Views can be the target of INSERT,
+// UPDATE, DELETE and UPSERT statements if they are automatically updatable or
+// have INSTEAD OF triggers.
+func (b *Builder) resolveDataSourceForMutation(
+	n tree.TableExpr, priv privilege.Kind,
+) (ds cat.DataSource, depName opt.MDDepName, alias tree.TableName, columns []tree.ColumnID) {
 	// Strip off an outer AliasedTableExpr if there is one.
 	var outerAlias *tree.TableName
 	if ate, ok := n.(*tree.AliasedTableExpr); ok {
@@ -587,13 +602,19 @@ func (b *Builder) resolveTableForMutation(
 
 	switch t := n.(type) {
 	case *tree.TableName:
-		tab, alias = b.resolveTable(t, priv)
+		ds, _, alias = b.resolveDataSource(t, priv)
 		depName = opt.DepByName(t)
+		if _, ok := ds.(cat.Sequence); ok {
+			panic(sqlerrors.NewWrongObjectTypeError(t, "table"))
+		}
 
 	case *tree.TableRef:
-		tab = b.resolveTableRef(t, priv)
+		ds, _ = b.resolveDataSourceRef(t, priv)
 		alias = tree.MakeUnqualifiedTableName(t.As.Alias)
 		depName = opt.DepByID(cat.StableID(t.TableID))
+		if _, ok := ds.(cat.Sequence); ok {
+			panic(sqlerrors.NewWrongObjectTypeError(t, "table"))
+		}
 
 		// See tree.TableRef: "Note that a nil [Columns] array means 'unspecified'
 		// (all columns). whereas an array of length 0 means 'zero columns'.
@@ -613,12 +634,14 @@ func (b *Builder) resolveTableForMutation(
 		alias = *outerAlias
 	}
 
-	// We can't mutate materialized views.
-	if tab.IsMaterializedView() {
+	// We can't mutate materialized views, except when maintaining their
+	// contents internally.
+	if tab, ok := ds.(cat.Table); ok && tab.IsMaterializedView() &&
+		!b.evalCtx.SessionData().AllowMaterializedViewMutations {
 		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
 	}
 
-	return tab, depName, alias, columns
+	return ds, depName, alias, columns
 }
 
 // resolveTable returns the table in the catalog with the given name. If the
@@ -0,0 +1,967 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
+)
+
+// ============================================================================
+// Automatically updatable views
+// ============================================================================
+
+// updatableView describes a view that is automatically updatable, following
+// the rules used by Postgres: the view selects from a single table, and does
+// not contain WITH, DISTINCT, GROUP BY, HAVING, LIMIT, OFFSET, set operations,
+// aggregate functions, window functions, or set-returning functions. Each row
+// of such a view corresponds to exactly one row of the table, so INSERT,
+// UPDATE, DELETE and UPSERT statements targeting the view can be built as
+// mutations of the table.
+type updatableView struct {
+	view cat.View
+
+	// tab is the table that the view selects from.
+	tab cat.Table
+
+	// tabName is the name by which the view's query refers to the table: the
+	// alias from the FROM clause, or else the fully qualified table name.
+	tabName tree.TableName
+
+	// cols has one entry for each column of the view.
+	cols []updatableViewCol
+
+	// where is the expression in the WHERE clause of the view's query, or nil
+	// if there is no WHERE clause. It is resolved against the table's columns.
+	where tree.Expr
+}
+
+// updatableViewCol describes a column of an updatable view.
+type updatableViewCol struct {
+	name tree.Name
+
+	// ord is the ordinal of the table column that the view column selects, or
+	// -1 if the view column is not updatable.
+	ord int
+
+	// expr is the expression of the view column in the view's query. It is
+	// resolved against the table's columns.
+	expr tree.Expr
+
+	// detail explains why the view column is not updatable.
+	detail string
+}
+
+// findColumn returns the view column with the given name, or nil if there is
+// no such column.
+func (uv *updatableView) findColumn(name tree.Name) *updatableViewCol {
+	for i := range uv.cols {
+		if uv.cols[i].name == name {
+			return &uv.cols[i]
+		}
+	}
+	return nil
+}
+
+// analyzeUpdatableView determines whether the given view query is
+// automatically updatable. If so, it returns the updatableView that describes
+// it (without the view field set). Otherwise, it returns a detail message that
+// explains why the view is not updatable. If colNames is non-empty, it
+// overrides the names of the view's columns.
+func (b *Builder) analyzeUpdatableView(
+	sel *tree.Select, colNames tree.NameList,
+) (uv *updatableView, detail string) {
+	if sel.With != nil {
+		return nil, "Views containing WITH are not automatically updatable."
+	}
+	if sel.Limit != nil {
+		return nil, "Views containing LIMIT or OFFSET are not automatically updatable."
+	}
+	var sc *tree.SelectClause
+	switch t := sel.Select.(type) {
+	case *tree.ParenSelect:
+		return b.analyzeUpdatableView(t.Select, colNames)
+	case *tree.SelectClause:
+		sc = t
+	case *tree.UnionClause:
+		return nil, "Views containing UNION, INTERSECT, or EXCEPT are not automatically updatable."
+	default:
+		return nil, "Views that do not select from a single table or view are not automatically updatable."
+	}
+	switch {
+	case sc.Distinct || sc.DistinctOn != nil:
+		return nil, "Views containing DISTINCT are not automatically updatable."
+	case len(sc.GroupBy) > 0:
+		return nil, "Views containing GROUP BY are not automatically updatable."
+	case sc.Having != nil:
+		return nil, "Views containing HAVING are not automatically updatable."
+	case len(sc.Window) > 0:
+		return nil, "Views that return window functions are not automatically updatable."
+	}
+
+	// The view must select from exactly one table.
+	const notSingleTable = "Views that do not select from a single table or view are not automatically updatable."
+	if len(sc.From.Tables) != 1 || sc.From.AsOf.Expr != nil {
+		return nil, notSingleTable
+	}
+	texpr := sc.From.Tables[0]
+	var alias tree.Name
+	if ate, ok := texpr.(*tree.AliasedTableExpr); ok {
+		if ate.Ordinality || ate.Lateral || len(ate.As.Cols) > 0 {
+			return nil, notSingleTable
+		}
+		texpr, alias = ate.Expr, ate.As.Alias
+	}
+	tn, ok := texpr.(*tree.TableName)
+	if !ok {
+		return nil, notSingleTable
+	}
+	var flags cat.Flags
+	if b.insideViewDef || b.insideFuncDef || b.insideTriggerDef {
+		flags.AvoidDescriptorCaches = true
+	}
+	tnCopy := *tn
+	ds, resName, err := b.catalog.ResolveDataSource(b.ctx, flags, &tnCopy)
+	if err != nil {
+		panic(err)
+	}
+	if _, ok := ds.(cat.View); ok {
+		return nil, "Views that select from another view are not automatically updatable."
+	}
+	tab, ok := ds.(cat.Table)
+	if !ok || tab.IsVirtualTable() || tab.IsMaterializedView() {
+		return nil, notSingleTable
+	}
+
+	uv = &updatableView{tab: tab, tabName: resName}
+	if alias != "" {
+		uv.tabName = tree.MakeUnqualifiedTableName(alias)
+	}
+	if sc.Where != nil {
+		uv.where = sc.Where.Expr
+	}
+
+	// Analyze the columns of the view. Stars are expanded to the visible columns
+	// of the table, and view columns that select a table column by name are
+	// updatable.
+	v := updatableViewVisitor{b: b}
+	for _, e := range sc.Exprs {
+		isStar := false
+		switch t := e.Expr.(type) {
+		case tree.UnqualifiedStar, *tree.AllColumnsSelector:
+			isStar = true
+		case *tree.UnresolvedName:
+			isStar = t.Star
+		}
+		if isStar {
+			for ord, n := 0, tab.ColumnCount(); ord < n; ord++ {
+				col := tab.Column(ord)
+				if col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+					uv.cols = append(uv.cols, updatableViewCol{
+						name: col.ColName(), ord: ord, expr: &tree.ColumnItem{ColumnName: col.ColName()},
+					})
+				}
+			}
+			continue
+		}
+		tree.WalkExprConst(&v, e.Expr)
+		if v.detail != "" {
+			return nil, v.detail
+		}
+		col := updatableViewCol{
+			name:   e.As,
+			ord:    -1,
+			expr:   e.Expr,
+			detail: "View columns that are not columns of their base relation are not updatable.",
+		}
+		if un, ok := tree.StripParens(e.Expr).(*tree.UnresolvedName); ok {
+			if vn, err := un.NormalizeVarName(); err == nil {
+				if ci, ok := vn.(*tree.ColumnItem); ok {
+					if col.name == "" {
+						col.name = ci.ColumnName
+					}
+					if ord := findPublicTableColumnByName(tab, ci.ColumnName); ord != -1 {
+						if tab.Column(ord).Kind() == cat.System {
+							col.detail = "View columns that refer to system columns are not updatable."
+						} else {
+							col.ord, col.detail = ord, ""
+						}
+					}
+				}
+			}
+		}
+		if col.name == "" {
+			col.name = "?column?"
+		}
+		uv.cols = append(uv.cols, col)
+	}
+	for i := range colNames {
+		if i < len(uv.cols) {
+			uv.cols[i].name = colNames[i]
+		}
+	}
+	return uv, ""
+}
+
+// updatableViewVisitor searches the SELECT list of a view for aggregate,
+// window, and set-returning functions, which make the view not automatically
+// updatable. It does not descend into subqueries.
+type updatableViewVisitor struct {
+	b      *Builder
+	detail string
+}
+
+var _ tree.Visitor = &updatableViewVisitor{}
+
+// VisitPre is part of the tree.Visitor interface.
+func (v *updatableViewVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.detail != "" {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		return false, expr
+
+	case *tree.FuncExpr:
+		if t.WindowDef != nil {
+			v.detail = "Views that return window functions are not automatically updatable."
+			return false, expr
+		}
+		semaCtx := v.b.semaCtx
+		def, err := t.Func.Resolve(v.b.ctx, semaCtx.SearchPath, semaCtx.FunctionResolver)
+		if err != nil {
+			panic(err)
+		}
+		if isAggregate(def) {
+			v.detail = "Views that return aggregate functions are not automatically updatable."
+			return false, expr
+		}
+		if isGenerator(def) {
+			v.detail = "Views that return set-returning functions are not automatically updatable."
+			return false, expr
+		}
+	}
+	return true, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (*updatableViewVisitor) VisitPost(expr tree.Expr) tree.Expr {
+	return expr
+}
+
+// resolveUpdatableView returns the updatableView that describes the given
+// view. If the view is not automatically updatable, resolveUpdatableView raises
+// an error that suggests using an INSTEAD OF trigger for the given event.
+//
+// The privilege required by the event is checked on the view's table as well
+// as the view. SELECT privileges on the table are not required, since the rows
+// of the table are read through the view.
+func (b *Builder) resolveUpdatableView(
+	view cat.View, eventType tree.TriggerEventType,
+) *updatableView {
+	colNames := make(tree.NameList, view.ColumnNameCount())
+	for i := range colNames {
+		colNames[i] = view.ColumnName(i)
+	}
+	uv, detail := b.analyzeUpdatableView(b.parseViewQuery(view), colNames)
+	if uv == nil {
+		var err error
+		switch eventType {
+		case tree.TriggerEventInsert:
+			err = pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot insert into view \"%s\"", view.Name())
+			err = errors.WithHint(err,
+				"To enable inserting into the view, provide an INSTEAD OF INSERT trigger.")
+		case tree.TriggerEventUpdate:
+			err = pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot update view \"%s\"", view.Name())
+			err = errors.WithHint(err,
+				"To enable updating the view, provide an INSTEAD OF UPDATE trigger.")
+		default:
+			err = pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"cannot delete from view \"%s\"", view.Name())
+			err = errors.WithHint(err,
+				"To enable deleting from the view, provide an INSTEAD OF DELETE trigger.")
+		}
+		panic(errors.WithDetail(err, detail))
+	}
+	uv.view = view
+
+	priv := privilege.INSERT
+	switch eventType {
+	case tree.TriggerEventUpdate:
+		priv = privilege.UPDATE
+	case tree.TriggerEventDelete:
+		priv = privilege.DELETE
+	}
+	b.checkPrivilege(opt.DepByID(uv.tab.ID()), uv.tab, priv)
+
+	if b.trackSchemaDeps {
+		dep := opt.SchemaDep{DataSource: view}
+		for i := range uv.cols {
+			dep.ColumnOrdinals.Add(i)
+		}
+		b.schemaDeps = append(b.schemaDeps, dep)
+	}
+	return uv
+}
+
+// translateOnConflict returns a copy of the given ON CONFLICT clause in which
+// the arbiter columns refer to the columns of the view's table.
+func (uv *updatableView) translateOnConflict(onConflict *tree.OnConflict) *tree.OnConflict {
+	if onConflict.ArbiterPredicate != nil {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"ON CONFLICT with a WHERE clause is not supported for view \"%s\"", uv.view.Name()))
+	}
+	res := *onConflict
+	if onConflict.Columns != nil {
+		res.Columns = make(tree.NameList, len(onConflict.Columns))
+		for i, name := range onConflict.Columns {
+			col := uv.findColumn(name)
+			if col == nil {
+				panic(colinfo.NewUndefinedColumnError(string(name)))
+			}
+			if col.ord < 0 {
+				panic(errors.WithDetail(pgerror.Newf(pgcode.InvalidColumnReference,
+					"column \"%s\" of view \"%s\" cannot be used as an arbiter", name, uv.view.Name()),
+					col.detail))
+			}
+			res.Columns[i] = uv.tab.Column(col.ord).ColName()
+		}
+	}
+	return &res
+}
+
+// addTargetViewCol adds the table column selected by the given column of the
+// updatable view as a target column. verb describes the mutation in error
+// messages, e.g. "insert into" or "update".
+func (mb *mutationBuilder) addTargetViewCol(col *updatableViewCol, verb string) {
+	if col.ord < 0 {
+		panic(errors.WithDetail(pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot %s column \"%s\" of view \"%s\"", verb, col.name, mb.view.view.Name()),
+			col.detail))
+	}
+	mb.addTargetCol(col.ord)
+}
+
+// addTargetColsByViewName is like addTargetColsByName, except that the names
+// refer to columns of the updatable view that is the target of the mutation.
+func (mb *mutationBuilder) addTargetColsByViewName(names tree.NameList, verb string) {
+	for _, name := range names {
+		col := mb.view.findColumn(name)
+		if col == nil {
+			panic(colinfo.NewUndefinedColumnError(string(name)))
+		}
+		mb.addTargetViewCol(col, verb)
+	}
+}
+
+// viewSourceScope returns a scope containing the ordinary columns of the
+// view's table, with values given by colIDs. The scope can be used to resolve
+// the expressions of the view's query.
+func (mb *mutationBuilder) viewSourceScope(s *scope, colIDs opt.OptionalColList) *scope {
+	srcScope := s.replace()
+	srcScope.expr = s.expr
+	for ord, n := 0, mb.tab.ColumnCount(); ord < n; ord++ {
+		col := mb.tab.Column(ord)
+		if colIDs[ord] == 0 || col.Kind() != cat.Ordinary || col.IsMutation() {
+			continue
+		}
+		srcScope.cols = append(srcScope.cols, scopeColumn{
+			name:       scopeColName(col.ColName()),
+			table:      mb.view.tabName,
+			typ:        mb.md.ColumnMeta(colIDs[ord]).Type,
+			id:         colIDs[ord],
+			visibility: columnVisibility(col.Visibility()),
+		})
+	}
+	return srcScope
+}
+
+// projectViewCols returns one column for each column of the updatable view,
+// with values computed from the table column values in colIDs. The returned
+// columns are named after the view's columns and qualified by viewName. View
+// columns that are expressions over the table columns are projected on top of
+// the expression in s.
+func (mb *mutationBuilder) projectViewCols(
+	s *scope, colIDs opt.OptionalColList, viewName *tree.TableName,
+) []scopeColumn {
+	// The view's query has already been authorized by the SELECT privilege on
+	// the view, so don't check privileges of objects referenced by it.
+	if !mb.b.skipSelectPrivilegeChecks {
+		mb.b.skipSelectPrivilegeChecks = true
+		defer func() { mb.b.skipSelectPrivilegeChecks = false }()
+	}
+
+	uv := mb.view
+	var srcScope *scope
+	var projections memo.ProjectionsExpr
+	cols := make([]scopeColumn, len(uv.cols))
+	for i := range uv.cols {
+		vc := &uv.cols[i]
+		col := &cols[i]
+		col.name = scopeColName(vc.name)
+		col.table = *viewName
+		if vc.ord >= 0 {
+			col.id = colIDs[vc.ord]
+			col.typ = mb.md.ColumnMeta(col.id).Type
+			continue
+		}
+		if srcScope == nil {
+			srcScope = mb.viewSourceScope(s, colIDs)
+		}
+		scalar := mb.b.resolveAndBuildScalar(
+			vc.expr, types.AnyElement, exprKindSelect, tree.RejectSpecial, srcScope,
+		)
+		col.typ = scalar.DataType()
+		col.id = mb.md.AddColumn(string(vc.name), col.typ)
+		projections = append(projections, mb.b.factory.ConstructProjectionsItem(scalar, col.id))
+	}
+	if len(projections) > 0 {
+		s.expr = mb.b.factory.ConstructProject(s.expr, projections, s.expr.Relational().OutputCols)
+	}
+	return cols
+}
+
+// buildViewFilter filters the rows in mb.outScope to those that are visible
+// through the updatable view, and projects the view's columns so that they
+// can be referenced by the WHERE, ORDER BY and LIMIT clauses of an UPDATE or
+// DELETE statement.
+func (mb *mutationBuilder) buildViewFilter() {
+	// Create a new scope so that fetchScope is not modified.
+	s := mb.outScope.replace()
+	s.appendColumnsFromScope(mb.outScope)
+	s.expr = mb.outScope.expr
+
+	if mb.view.where != nil {
+		if !mb.b.skipSelectPrivilegeChecks {
+			mb.b.skipSelectPrivilegeChecks = true
+			defer func() { mb.b.skipSelectPrivilegeChecks = false }()
+		}
+		srcScope := mb.viewSourceScope(s, mb.fetchColIDs)
+		mb.b.buildWhere(&tree.Where{Type: tree.AstWhere, Expr: mb.view.where}, srcScope)
+		s.expr = srcScope.expr
+	}
+	mb.viewCols = mb.projectViewCols(s, mb.fetchColIDs, &mb.alias)
+
+	// Keep the view's columns in the output scope so that they are not pruned
+	// by a DISTINCT ON, but make them inaccessible so that they don't conflict
+	// with the table's columns.
+	for _, col := range mb.viewCols {
+		col.visibility = inaccessible
+		s.cols = append(s.cols, col)
+	}
+	mb.outScope = s
+}
+
+// addViewColsForUpsert projects the columns of the updatable view for both the
+// existing row and the row proposed for insertion by an INSERT .. ON CONFLICT
+// DO UPDATE statement. The proposed row is accessible via the special
+// "excluded" data source.
+func (mb *mutationBuilder) addViewColsForUpsert() {
+	mb.viewCols = append(
+		mb.projectViewCols(mb.outScope, mb.fetchColIDs, &mb.alias),
+		mb.projectViewCols(mb.outScope, mb.insertColIDs, &excludedTableName)...,
+	)
+}
+
+// exprScope returns the scope used to resolve the expressions of the mutation
+// statement, such as WHERE and SET expressions. For tables, this is
+// mb.outScope. For updatable views, the view's columns are accessible instead
+// of the table's columns.
+func (mb *mutationBuilder) exprScope() *scope {
+	if mb.view == nil {
+		return mb.outScope
+	}
+	s := mb.outScope.replace()
+	s.expr = mb.outScope.expr
+	s.appendColumns(mb.viewCols)
+	s.appendColumns(mb.extraAccessibleCols)
+	l := len(s.cols)
+	s.appendColumnsFromScope(mb.outScope)
+	for i := l; i < len(s.cols); i++ {
+		s.cols[i].visibility = inaccessible
+	}
+	return s
+}
+
+// setOutScopeFromExprScope updates mb.outScope to use the expression built in
+// the given scope, which was derived from exprScope.
+func (mb *mutationBuilder) setOutScopeFromExprScope(s *scope) {
+	if mb.view == nil {
+		mb.outScope = s
+		return
+	}
+	outScope := mb.outScope.replace()
+	outScope.appendColumnsFromScope(mb.outScope)
+	outScope.expr = s.expr
+	mb.outScope = outScope
+}
+
+// viewTargetColNames returns the names of the table columns in
+// mb.targetColList.
+func (mb *mutationBuilder) viewTargetColNames() tree.NameList {
+	names := make(tree.NameList, len(mb.targetColList))
+	for i, colID := range mb.targetColList {
+		names[i] = mb.tab.Column(mb.tabID.ColumnOrdinal(colID)).ColName()
+	}
+	return names
+}
+
+// appendViewColsForReturning adds the columns of the updatable view to the
+// RETURNING scope, which contains the table's columns. The table's columns are
+// made inaccessible, since RETURNING refers to the view's columns.
+func (mb *mutationBuilder) appendViewColsForReturning(inScope *scope) {
+	colIDs := make(opt.OptionalColList, mb.tab.ColumnCount())
+	for i := range colIDs {
+		colIDs[i] = mb.tabID.ColumnID(i)
+	}
+	viewCols := mb.projectViewCols(inScope, colIDs, &mb.alias)
+	for i := range inScope.cols {
+		inScope.cols[i].visibility = inaccessible
+	}
+	inScope.appendColumns(viewCols)
+}
+
+// buildViewCheckOption adds a runtime check for the WITH CHECK OPTION of the
+// updatable view, if it has one. The check raises an error if a row that is
+// inserted or updated through the view would not be visible through the view.
+//
+// A CASCADED check option, which is the default, also checks the conditions
+// of the views the view selects from. Views that select from other views are
+// not updatable yet, so the condition of the view itself is the only one to
+// check, and CASCADED is equivalent to LOCAL.
+func (mb *mutationBuilder) buildViewCheckOption() {
+	if mb.view == nil || mb.view.where == nil ||
+		mb.view.view.CheckOption() == tree.ViewCheckOptionNone {
+		return
+	}
+	if !mb.b.skipSelectPrivilegeChecks {
+		mb.b.skipSelectPrivilegeChecks = true
+		defer func() { mb.b.skipSelectPrivilegeChecks = false }()
+	}
+	colIDs := make(opt.OptionalColList, mb.tab.ColumnCount())
+	for i := range colIDs {
+		colIDs[i] = mb.mapToReturnColID(i)
+	}
+	pred := mb.b.resolveAndBuildScalar(
+		mb.view.where,
+		types.Bool,
+		exprKindWhere,
+		tree.RejectGenerators|tree.RejectWindowApplications|tree.RejectProcedures,
+		mb.viewSourceScope(mb.outScope, colIDs),
+	)
+
+	// Build a CASE statement to raise the error unless the predicate is true.
+	// Add a barrier to ensure the check isn't removed or re-ordered with a
+	// filter.
+	f := mb.b.factory
+	msg := fmt.Sprintf("new row violates check option for view \"%s\"", mb.view.view.Name())
+	raiseFn := mb.b.makePLpgSQLRaiseFn(mb.b.makeConstRaiseArgs(
+		"ERROR",                                  /* severity */
+		msg,                                      /* message */
+		"",                                       /* detail */
+		"",                                       /* hint */
+		pgcode.WithCheckOptionViolation.String(), /* code */
+	))
+	check := f.ConstructCase(memo.TrueSingleton,
+		memo.ScalarListExpr{f.ConstructWhen(pred, f.ConstructNull(types.Int))},
+		raiseFn,
+	)
+	mb.b.projectColWithMetadataName(mb.outScope, "check-option", types.Int, check)
+	mb.outScope.expr = f.ConstructBarrier(mb.outScope.expr)
+}
+
+// ============================================================================
+// Row-level INSTEAD OF triggers
+// ============================================================================
+
+// buildInsertInsteadOfTriggers builds an INSERT into a view that has INSTEAD OF
+// INSERT triggers. Instead of inserting into a table, the triggers are invoked
+// for each input row, with NEW set to the row proposed for insertion. Columns
+// of the view that are not given a value are set to NULL.
+func (b *Builder) buildInsertInsteadOfTriggers(
+	ins *tree.Insert, view cat.View, alias tree.TableName, inScope *scope,
+) (outScope *scope) {
+	if ins.OnConflict != nil {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"ON CONFLICT is not supported for view \"%s\" with INSTEAD OF triggers", view.Name()))
+	}
+	tableTyp := b.resolveTableType(view)
+	colTypes := tableTyp.TupleContents()
+
+	// Determine the view columns that are given values by the input.
+	var targetOrds []int
+	if len(ins.Columns) != 0 {
+		var targeted intsets.Fast
+		for _, name := range ins.Columns {
+			ord := findTupleLabel(tableTyp, name)
+			if targeted.Contains(ord) {
+				panic(pgerror.Newf(pgcode.Syntax,
+					"multiple assignments to the same column \"%s\"", name))
+			}
+			targeted.Add(ord)
+			targetOrds = append(targetOrds, ord)
+		}
+	}
+
+	var s *scope
+	if ins.DefaultValues() {
+		s = inScope.push()
+		s.expr = b.factory.ConstructNoColsRow()
+	} else {
+		desiredTypes := colTypes
+		if targetOrds != nil {
+			desiredTypes = make([]*types.T, len(targetOrds))
+			for i, ord := range targetOrds {
+				desiredTypes[i] = colTypes[ord]
+			}
+		}
+		s = b.buildStmt(replaceDefaultsWithNull(ins.Rows), desiredTypes, inScope)
+		if targetOrds == nil {
+			checkNumCols("insert", len(colTypes), max(len(colTypes), len(s.cols)))
+			for i := range s.cols {
+				targetOrds = append(targetOrds, i)
+			}
+		} else {
+			checkNumCols("insert", len(targetOrds), len(s.cols))
+		}
+	}
+
+	// Project the NEW row.
+	f := b.factory
+	elems := make(memo.ScalarListExpr, len(colTypes))
+	for i := range elems {
+		elems[i] = f.ConstructNull(colTypes[i])
+	}
+	for i, ord := range targetOrds {
+		elems[ord] = b.assignToViewCol(f.ConstructVariable(s.cols[i].id), s.cols[i].typ, colTypes[ord])
+	}
+	newColID := b.projectColWithMetadataName(s, triggerColNew, tableTyp, f.ConstructTuple(elems, tableTyp))
+
+	if b.trackSchemaDeps {
+		dep := opt.SchemaDep{DataSource: view}
+		for _, ord := range targetOrds {
+			dep.ColumnOrdinals.Add(ord)
+		}
+		b.schemaDeps = append(b.schemaDeps, dep)
+	}
+
+	return b.buildInsteadOfTriggers(
+		view, alias, tree.TriggerEventInsert, s, 0 /* oldColID */, newColID,
+		nil /* extraCols */, ins.Returning,
+	)
+}
+
+// buildUpdateInsteadOfTriggers builds an UPDATE of a view that has INSTEAD OF
+// UPDATE triggers. Instead of updating a table, the triggers are invoked for
+// each row of the view that matches the WHERE clause, with OLD set to the
+// existing row and NEW set to the row with the SET expressions applied.
+func (b *Builder) buildUpdateInsteadOfTriggers(
+	upd *tree.Update, view cat.View, alias tree.TableName, inScope *scope,
+) (outScope *scope) {
+	tableTyp := b.resolveTableType(view)
+	colTypes := tableTyp.TupleContents()
+	s, viewCols, extraCols := b.buildInputForInsteadOfTriggers(
+		upd.Table, upd.From, upd.Where, upd.OrderBy, upd.Limit, exprKindOrderByUpdate, inScope,
+	)
+
+	f := b.factory
+	oldElems := make(memo.ScalarListExpr, len(colTypes))
+	for i := range oldElems {
+		oldElems[i] = f.ConstructVariable(viewCols[i].id)
+	}
+	newElems := make(memo.ScalarListExpr, len(colTypes))
+	copy(newElems, oldElems)
+
+	// SET expressions should reject aggregates, generators, etc.
+	defer b.semaCtx.Properties.Restore(b.semaCtx.Properties)
+	b.semaCtx.Properties.Require("UPDATE SET", tree.RejectSpecial)
+
+	var targeted intsets.Fast
+	addCol := func(name tree.Name, expr tree.Expr) {
+		ord := findTupleLabel(tableTyp, name)
+		if targeted.Contains(ord) {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"multiple assignments to the same column \"%s\"", name))
+		}
+		targeted.Add(ord)
+
+		// Columns of a view don't have defaults, so DEFAULT is NULL.
+		if _, ok := expr.(tree.DefaultVal); ok {
+			expr = tree.DNull
+		}
+		texpr := s.resolveType(expr, colTypes[ord])
+		scalar := b.buildScalar(texpr, s, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+		newElems[ord] = b.assignToViewCol(scalar, texpr.ResolvedType(), colTypes[ord])
+	}
+	for _, set := range upd.Exprs {
+		if !set.Tuple {
+			addCol(set.Names[0], set.Expr)
+			continue
+		}
+		t, ok := set.Expr.(*tree.Tuple)
+		if !ok {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"source for a multiple-column UPDATE item of view \"%s\" with INSTEAD OF triggers "+
+					"must be a ROW() expression", view.Name()))
+		}
+		if len(set.Names) != len(t.Exprs) {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"number of columns (%d) does not match number of values (%d)",
+				len(set.Names), len(t.Exprs)))
+		}
+		for i := range t.Exprs {
+			addCol(set.Names[i], t.Exprs[i])
+		}
+	}
+
+	// Project the OLD and NEW rows.
+	oldColID := b.projectColWithMetadataName(s, triggerColOld, tableTyp, f.ConstructTuple(oldElems, tableTyp))
+	newColID := b.projectColWithMetadataName(s, triggerColNew, tableTyp, f.ConstructTuple(newElems, tableTyp))
+
+	return b.buildInsteadOfTriggers(
+		view, alias, tree.TriggerEventUpdate, s, oldColID, newColID, extraCols, upd.Returning,
+	)
+}
+
+// buildDeleteInsteadOfTriggers builds a DELETE from a view that has INSTEAD OF
+// DELETE triggers. Instead of deleting from a table, the triggers are invoked
+// for each row of the view that matches the WHERE clause, with OLD set to the
+// row.
+func (b *Builder) buildDeleteInsteadOfTriggers(
+	del *tree.Delete, view cat.View, alias tree.TableName, inScope *scope,
+) (outScope *scope) {
+	tableTyp := b.resolveTableType(view)
+	s, viewCols, extraCols := b.buildInputForInsteadOfTriggers(
+		del.Table, del.Using, del.Where, del.OrderBy, del.Limit, exprKindOrderByDelete, inScope,
+	)
+
+	// Project the OLD row.
+	f := b.factory
+	oldElems := make(memo.ScalarListExpr, len(viewCols))
+	for i := range oldElems {
+		oldElems[i] = f.ConstructVariable(viewCols[i].id)
+	}
+	oldColID := b.projectColWithMetadataName(s, triggerColOld, tableTyp, f.ConstructTuple(oldElems, tableTyp))
+
+	return b.buildInsteadOfTriggers(
+		view, alias, tree.TriggerEventDelete, s, oldColID, 0 /* newColID */, extraCols, del.Returning,
+	)
+}
+
+// buildInputForInsteadOfTriggers builds the rows of the view that are the
+// target of an UPDATE or DELETE statement with INSTEAD OF triggers, similar to
+// this:
+//
+//	SELECT <cols>
+//	FROM <view> [, <from-tables>]
+//	WHERE <where>
+//	ORDER BY <order-by>
+//	LIMIT <limit>
+//
+// It returns the scope of the rows, along with the view's columns and the
+// columns of the FROM or USING tables.
+func (b *Builder) buildInputForInsteadOfTriggers(
+	texpr tree.TableExpr,
+	from tree.TableExprs,
+	where *tree.Where,
+	orderBy tree.OrderBy,
+	limit *tree.Limit,
+	orderByKind exprKind,
+	inScope *scope,
+) (s *scope, viewCols, extraCols []scopeColumn) {
+	viewScope := b.buildDataSource(texpr, nil /* indexFlags */, noLocking, inScope)
+	viewCols = viewScope.cols
+
+	s = viewScope
+	if len(from) > 0 {
+		fromScope := b.buildFromTables(from, noLocking, inScope)
+
+		// Check that the same table name is not used multiple times.
+		b.validateJoinTableNames(viewScope, fromScope)
+
+		// The FROM table columns can be accessed by the RETURNING clause.
+		extraCols = fromScope.cols
+
+		s = viewScope.replace()
+		s.appendColumnsFromScope(viewScope)
+		s.appendColumnsFromScope(fromScope)
+		s.expr = b.factory.ConstructInnerJoin(
+			viewScope.expr, fromScope.expr, memo.TrueFilter, memo.EmptyJoinPrivate,
+		)
+	}
+
+	// WHERE
+	b.buildWhere(where, s)
+
+	// SELECT + ORDER BY (which may add projected expressions)
+	projectionsScope := s.replace()
+	projectionsScope.appendColumnsFromScope(s)
+	orderByScope := b.analyzeOrderBy(orderBy, s, projectionsScope,
+		orderByKind, tree.RejectGenerators|tree.RejectAggregates)
+	b.buildOrderBy(s, projectionsScope, orderByScope)
+	b.constructProjectForScope(s, projectionsScope)
+
+	// LIMIT
+	if limit != nil {
+		b.buildLimit(limit, inScope, projectionsScope)
+	}
+	return projectionsScope, viewCols, extraCols
+}
+
+// buildInsteadOfTriggers invokes the row-level INSTEAD OF triggers of the view
+// for the given event, once for each row in s. The triggers are invoked in
+// order, and each INSERT or UPDATE trigger receives the row returned by the
+// previous trigger as NEW. If a trigger returns NULL, the row is skipped by the
+// remaining triggers, and is not counted or returned.
+//
+// Without a RETURNING clause, the resulting expression returns the number of
+// rows that were processed by the triggers. Otherwise, RETURNING refers to the
+// rows returned by the triggers (or to OLD for DELETE), and to the columns of
+// any FROM or USING tables.
+func (b *Builder) buildInsteadOfTriggers(
+	view cat.View,
+	alias tree.TableName,
+	eventType tree.TriggerEventType,
+	s *scope,
+	oldColID, newColID opt.ColumnID,
+	extraCols []scopeColumn,
+	returning tree.ReturningClause,
+) (outScope *scope) {
+	var eventsToMatch tree.TriggerEventTypeSet
+	eventsToMatch.Add(eventType)
+	triggers := cat.GetRowLevelTriggers(view, tree.TriggerActionTimeInsteadOf, eventsToMatch)
+	tableTyp := b.resolveTableType(view)
+
+	// Build each trigger function invocation in order, applying optimization
+	// barriers to ensure correct evaluation order.
+	f := b.factory
+	resultColID := newColID
+	if eventType == tree.TriggerEventDelete {
+		resultColID = oldColID
+	}
+	for i := range triggers {
+		s.expr = f.ConstructBarrier(s.expr)
+		args := b.buildTriggerFunctionArgs(
+			view, triggers[i], tree.TriggerActionTimeInsteadOf, eventType, oldColID, newColID,
+		)
+		triggerFn, def := b.buildTriggerFunction(triggers[i], view.ID(), tableTyp, args, nil /* transitions */)
+		triggerFnColID := b.projectColWithMetadataName(s, def.Name, tableTyp, triggerFn)
+
+		// INSTEAD OF triggers can return NULL to indicate that the row was not
+		// processed.
+		filter := f.ConstructIsNot(f.ConstructVariable(triggerFnColID), memo.NullSingleton)
+		s.expr = f.ConstructSelect(s.expr, memo.FiltersExpr{f.ConstructFiltersItem(filter)})
+
+		if eventType != tree.TriggerEventDelete {
+			newColID = triggerFnColID
+			resultColID = triggerFnColID
+		}
+	}
+	s.expr = f.ConstructBarrier(s.expr)
+
+	if !resultsNeeded(returning) {
+		// Return the number of processed rows, which is reported as the number of
+		// rows affected by the statement.
+		countColID := b.factory.Metadata().AddColumn("count_rows", types.Int)
+		outScope = b.allocScope()
+		outScope.expr = f.ConstructScalarGroupBy(
+			s.expr,
+			memo.AggregationsExpr{f.ConstructAggregationsItem(f.ConstructCountRows(), countColID)},
+			&memo.GroupingPrivate{},
+		)
+		return outScope
+	}
+
+	// Construct a scope containing the columns of the result rows, in the same
+	// order and with the same names as the columns of the view.
+	inScope := s.replace()
+	labels := tableTyp.TupleLabels()
+	for i, typ := range tableTyp.TupleContents() {
+		elem := f.ConstructColumnAccess(f.ConstructVariable(resultColID), memo.TupleOrdinal(i))
+		col := b.synthesizeColumn(inScope, scopeColName(tree.Name(labels[i])), typ, nil /* expr */, elem)
+		col.table = alias
+	}
+	inScope.appendColumns(extraCols)
+	inScope.expr = b.constructProject(s.expr, inScope.cols)
+	for i := range inScope.cols {
+		inScope.cols[i].scalar = nil
+	}
+
+	// Construct the Project operator that projects the RETURNING expressions.
+	outScope = inScope.replace()
+	b.analyzeReturningList(returning.(*tree.ReturningExprs), nil /* desiredTypes */, inScope, outScope)
+	b.buildProjectionList(inScope, outScope)
+	b.constructProjectForScope(inScope, outScope)
+	return outScope
+}
+
+// assignToViewCol returns the given scalar expression, which has type typ,
+// with an assignment cast to the type of the view column if necessary.
+func (b *Builder) assignToViewCol(scalar opt.ScalarExpr, typ, colTyp *types.T) opt.ScalarExpr {
+	if typ.Identical(colTyp) {
+		return scalar
+	}
+	return b.factory.ConstructAssignmentCast(scalar, colTyp)
+}
+
+// findTupleLabel returns the ordinal of the element of the given tuple type
+// with the given label, which is the name of a view column. It raises an error
+// if there is no such element.
+func findTupleLabel(typ *types.T, name tree.Name) int {
+	for i, label := range typ.TupleLabels() {
+		if label == string(name) {
+			return i
+		}
+	}
+	panic(colinfo.NewUndefinedColumnError(string(name)))
+}
+
+// replaceDefaultsWithNull returns the given input rows, with any DEFAULT
+// specifiers in a VALUES clause replaced by NULL. It is used for INSERT
+// statements with INSTEAD OF triggers, since columns of a view don't have
+// default values.
+func replaceDefaultsWithNull(rows *tree.Select) *tree.Select {
+	values, ok := rows.Select.(*tree.ValuesClause)
+	if !ok || rows.With != nil || rows.OrderBy != nil || rows.Limit != nil {
+		return rows
+	}
+	var newRows []tree.Exprs
+	for i, tuple := range values.Rows {
+		var newTuple tree.Exprs
+		for j, val := range tuple {
+			if _, ok := val.(tree.DefaultVal); !ok {
+				continue
+			}
+			// Found DEFAULT, so lazily create new rows and copy the tuple.
+			if newRows == nil {
+				newRows = make([]tree.Exprs, len(values.Rows))
+				copy(newRows, values.Rows)
+			}
+			if newTuple == nil {
+				newTuple = append(tree.Exprs(nil), tuple...)
+				newRows[i] = newTuple
+			}
+			newTuple[j] = tree.DNull
+		}
+	}
+	if newRows == nil {
+		return rows
+	}
+	return &tree.Select{Select: &tree.ValuesClause{Rows: newRows}}
+}
@@ -138,7 +138,7 @@ func (b *Builder) buildWindow(outScope *scope, inScope *scope) {
 
 		frameIdx := b.findMatchingFrameIndex(&frames, partitions[i], orderings[i])
 
-		fn := b.constructWindowFn(w.def.Name, argLists[i])
+		fn := b.constructWindowFn(&w.def, argLists[i])
 
 		if windowFrames[i].Bounds.StartBound.OffsetExpr != nil {
 			fn = b.factory.ConstructWindowFromOffset(
@@ -256,7 +256,7 @@ func (b *Builder) buildAggregationAsWindow(
 
 	// Build the arguments, partitions and orderings for each aggregate.
 	for i, agg := range g.aggs {
-		argExprs := getTypedExprs(agg.Exprs)
+		argExprs := aggregateArgs(agg.FuncExpr, &agg.def)
 
 		// Build the appropriate arguments.
 		argLists[i] = b.buildWindowArgs(argExprs, i, agg.def.Name, fromScope, g.aggInScope)
@@ -283,7 +283,7 @@ func (b *Builder) buildAggregationAsWindow(
 	// so that we can group functions over the same partition and ordering.
 	frames := make([]memo.WindowExpr, 0, len(g.aggs))
 	for i, agg := range g.aggs {
-		fn := b.constructAggregate(agg.def.Name, argLists[i])
+		fn := b.constructAggregate(&agg.def, argLists[i])
 		if filterCols[i] != 0 {
 			fn = b.factory.ConstructAggFilter(
 				fn,
@@ -332,6 +332,9 @@ func (b *Builder) buildAggregationAsWindow(
 // projecting the default argument to some window functions when we could just
 // not do that projection.
 func (b *Builder) getTypedWindowArgs(w *windowInfo) []tree.TypedExpr {
+	if isUserDefinedAggregate(&w.def) {
+		return aggregateArgs(w.FuncExpr, &w.def)
+	}
 	argExprs := getTypedExprs(w.Exprs)
 
 	switch w.def.Name {
@@ -586,6 +589,11 @@ func (b *Builder) replaceDefaultReturn(
 // value for scalar group by when no rows are returned. The default null value
 // to be applied is also returned.
 func (b *Builder) overrideDefaultNullValue(agg aggregateInfo) (opt.ScalarExpr, bool) {
+	if isUserDefinedAggregate(&agg.def) {
+		// The result of a user-defined aggregate may be NULL even if there are
+		// rows, so it is handled by constructScalarWindowGroup.
+		return nil, false
+	}
 	switch agg.def.Name {
 	case "count", "count_rows":
 		return b.factory.ConstructConst(tree.NewDInt(0), types.Int), true
@@ -594,6 +602,20 @@ func (b *Builder) overrideDefaultNullValue(agg aggregateInfo) (opt.ScalarExpr, b
 	}
 }
 
+// userDefinedAggEmptyValue returns the result of the given aggregate over no
+// rows if it is a user-defined aggregate with a non-NULL result over no rows.
+// Otherwise, it returns nil.
+func (b *Builder) userDefinedAggEmptyValue(agg aggregateInfo) opt.ScalarExpr {
+	if !isUserDefinedAggregate(&agg.def) {
+		return nil
+	}
+	empty := b.buildUserDefinedAggDefinition(&agg.def).Empty
+	if empty.Op() == opt.NullOp {
+		return nil
+	}
+	return empty
+}
+
 // constructScalarWindowGroup wraps the input window expression with a scalar
 // grouping so the results of each window column are squashed down.
 // The expression may be wrapped with a projection so ensure the default NULL
@@ -619,9 +641,44 @@ func (b *Builder) constructScalarWindowGroup(
 
 	// Create an appropriate passthrough for the projection.
 	var passthrough opt.ColSet
+
+	// countRowsCol is the column which counts the rows of the input, if any
+	// user-defined aggregate needs it to tell whether there were no rows. The
+	// result of such an aggregate over no rows is projected as:
+	//
+	// CASE true WHEN count_rows = 0 THEN empty_val ELSE aggregate_result
+	var countRowsCol opt.ColumnID
 	for i := range aggInfos {
 		varExpr := b.factory.ConstructConstAgg(b.factory.ConstructVariable(aggInfos[i].col.id))
 
+		if empty := b.userDefinedAggEmptyValue(aggInfos[i]); empty != nil {
+			md := b.factory.Metadata()
+			if countRowsCol == 0 {
+				countRowsCol = md.AddColumn("count_rows", types.Int)
+				aggs = append(aggs, b.factory.ConstructAggregationsItem(
+					b.factory.ConstructCountRows(), countRowsCol,
+				))
+			}
+			aggregateCol := aggInfos[i].col
+			id := md.AddColumn(md.ColumnMeta(aggregateCol.id).Alias, aggregateCol.typ)
+			aggs = append(aggs, b.factory.ConstructAggregationsItem(varExpr, id))
+			projections = append(projections, b.factory.ConstructProjectionsItem(
+				b.factory.ConstructCase(
+					memo.TrueSingleton,
+					memo.ScalarListExpr{b.factory.ConstructWhen(
+						b.factory.ConstructEq(
+							b.factory.ConstructVariable(countRowsCol),
+							b.factory.ConstructConst(tree.NewDInt(0), types.Int),
+						),
+						empty,
+					)},
+					b.factory.ConstructVariable(id),
+				),
+				aggregateCol.id,
+			))
+			continue
+		}
+
 		// If the aggregate requires a projection to potentially set a default null value
 		// a new column will be needed to be synthesized.
 		defaultNullVal, requiresProjection := b.overrideDefaultNullValue(aggInfos[i])
@@ -35,6 +35,14 @@ type cteSource struct {
 
 	// built is true if we have constructed a With operator for this CTE.
 	built bool
+
+	// canaryCol, if set, is a column of the bound expression that is not part of
+	// cols. References to the CTE only return the rows for which canaryCol is
+	// NULL, or non-NULL if canaryNotNull is true. It is used by the transition
+	// relations of triggers for UPSERT and INSERT ... ON CONFLICT, which scan the
+	// mutation input and must only return the inserted or updated rows.
+	canaryCol     opt.ColumnID
+	canaryNotNull bool
 }
 
 type cteSources []*cteSource
@@ -176,82 +176,84 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 
 	// Add all types used in Optgen defines here.
 	md.types = map[string]*typeDef{
-		"RelExpr":              {fullName: "memo.RelExpr", isExpr: true, isInterface: true},
-		"Expr":                 {fullName: "opt.Expr", isExpr: true, isInterface: true},
-		"ScalarExpr":           {fullName: "opt.ScalarExpr", isExpr: true, isInterface: true},
-		"RelListExpr":          {fullName: "memo.RelListExpr"},
-		"Operator":             {fullName: "opt.Operator", passByVal: true},
-		"ColumnID":             {fullName: "opt.ColumnID", passByVal: true},
-		"ColSet":               {fullName: "opt.ColSet", passByVal: true},
-		"ColList":              {fullName: "opt.ColList", passByVal: true},
-		"OptionalColList":      {fullName: "opt.OptionalColList", passByVal: true},
-		"TableID":              {fullName: "opt.TableID", passByVal: true},
-		"SchemaID":             {fullName: "opt.SchemaID", passByVal: true},
-		"SequenceID":           {fullName: "opt.SequenceID", passByVal: true},
-		"UniqueID":             {fullName: "opt.UniqueID", passByVal: true},
-		"WithID":               {fullName: "opt.WithID", passByVal: true},
-		"UDFDefinition":        {fullName: "memo.UDFDefinition", isPointer: true},
-		"StoredProcTxnOp":      {fullName: "tree.StoredProcTxnOp", passByVal: true},
-		"TransactionModes":     {fullName: "tree.TransactionModes", passByVal: true},
-		"Ordering":             {fullName: "opt.Ordering", passByVal: true},
-		"OrderingChoice":       {fullName: "props.OrderingChoice", passByVal: true},
-		"GroupingOrder":        {fullName: "memo.GroupingOrder", passByVal: true},
-		"TupleOrdinal":         {fullName: "memo.TupleOrdinal", passByVal: true},
-		"ScanLimit":            {fullName: "memo.ScanLimit", passByVal: true},
-		"ScanFlags":            {fullName: "memo.ScanFlags", passByVal: true},
-		"JoinFlags":            {fullName: "memo.JoinFlags", passByVal: true},
-		"WindowFrame":          {fullName: "memo.WindowFrame", passByVal: true},
-		"FKCascades":           {fullName: "memo.FKCascades", passByVal: true},
-		"AfterTriggers":        {fullName: "memo.AfterTriggers", isPointer: true},
-		"ExplainOptions":       {fullName: "tree.ExplainOptions", passByVal: true},
-		"StatementReturnType":  {fullName: "tree.StatementReturnType", passByVal: true},
-		"StatementType":        {fullName: "tree.StatementType", passByVal: true},
-		"ShowTraceType":        {fullName: "tree.ShowTraceType", passByVal: true},
-		"ShowCompletions":      {fullName: "tree.ShowCompletions", isPointer: true, usePointerIntern: true},
-		"bool":                 {fullName: "bool", passByVal: true},
-		"int":                  {fullName: "int", passByVal: true},
-		"int64":                {fullName: "int64", passByVal: true},
-		"string":               {fullName: "string", passByVal: true},
-		"Type":                 {fullName: "types.T", isPointer: true},
-		"Datum":                {fullName: "tree.Datum", isInterface: true},
-		"TypedExpr":            {fullName: "tree.TypedExpr", isInterface: true},
-		"Statement":            {fullName: "tree.Statement", isInterface: true},
-		"Subquery":             {fullName: "tree.Subquery", isPointer: true, usePointerIntern: true},
-		"CreateTable":          {fullName: "tree.CreateTable", isPointer: true, usePointerIntern: true},
-		"CreateRoutine":        {fullName: "tree.CreateRoutine", isPointer: true, usePointerIntern: true},
-		"CreateTrigger":        {fullName: "tree.CreateTrigger", isPointer: true, usePointerIntern: true},
-		"CreateStats":          {fullName: "tree.CreateStats", isPointer: true, usePointerIntern: true},
-		"TableName":            {fullName: "tree.TableName", isPointer: true, usePointerIntern: true},
-		"Constraint":           {fullName: "constraint.Constraint", isPointer: true, usePointerIntern: true},
-		"FuncProps":            {fullName: "tree.FunctionProperties", isPointer: true, usePointerIntern: true},
-		"FuncOverload":         {fullName: "tree.Overload", isPointer: true, usePointerIntern: true},
-		"PhysProps":            {fullName: "physical.Required", isPointer: true},
-		"Presentation":         {fullName: "physical.Presentation", passByVal: true},
-		"RelProps":             {fullName: "props.Relational"},
-		"RelPropsPtr":          {fullName: "props.Relational", isPointer: true, usePointerIntern: true},
-		"ScalarProps":          {fullName: "props.Scalar"},
-		"FuncDepSet":           {fullName: "props.FuncDepSet"},
-		"JoinMultiplicity":     {fullName: "props.JoinMultiplicity"},
-		"OpaqueMetadata":       {fullName: "opt.OpaqueMetadata", isInterface: true},
-		"JobCommand":           {fullName: "tree.JobCommand", passByVal: true},
-		"ScheduleCommand":      {fullName: "tree.ScheduleCommand", passByVal: true},
-		"IndexOrdinal":         {fullName: "cat.IndexOrdinal", passByVal: true},
-		"IndexOrdinals":        {fullName: "cat.IndexOrdinals", passByVal: true},
-		"RelocateSubject":      {fullName: "tree.RelocateSubject", passByVal: true},
-		"UniqueOrdinals":       {fullName: "cat.UniqueOrdinals", passByVal: true},
-		"SchemaDeps":           {fullName: "opt.SchemaDeps", passByVal: true},
-		"SchemaTypeDeps":       {fullName: "opt.SchemaTypeDeps", passByVal: true},
-		"SchemaFunctionDeps":   {fullName: "opt.SchemaFunctionDeps", passByVal: true},
-		"Locking":              {fullName: "opt.Locking", passByVal: true},
-		"CTEMaterializeClause": {fullName: "tree.CTEMaterializeClause", passByVal: true},
-		"SpanExpression":       {fullName: "inverted.SpanExpression", isPointer: true, usePointerIntern: true},
-		"InvertedSpans":        {fullName: "inverted.Spans", passByVal: true},
-		"Persistence":          {fullName: "tree.Persistence", passByVal: true},
-		"PreFiltererState":     {fullName: "invertedexpr.PreFiltererStateForInvertedFilterer", isPointer: true, usePointerIntern: true},
-		"Volatility":           {fullName: "volatility.V", passByVal: true},
-		"LiteralRows":          {fullName: "opt.LiteralRows", isExpr: true, isPointer: true},
-		"Distribution":         {fullName: "physical.Distribution", passByVal: true},
-		"TreeCreateView":       {fullName: "tree.CreateView", isPointer: true, usePointerIntern: true},
+		"RelExpr":                  {fullName: "memo.RelExpr", isExpr: true, isInterface: true},
+		"Expr":                     {fullName: "opt.Expr", isExpr: true, isInterface: true},
+		"ScalarExpr":               {fullName: "opt.ScalarExpr", isExpr: true, isInterface: true},
+		"RelListExpr":              {fullName: "memo.RelListExpr"},
+		"Operator":                 {fullName: "opt.Operator", passByVal: true},
+		"ColumnID":                 {fullName: "opt.ColumnID", passByVal: true},
+		"ColSet":                   {fullName: "opt.ColSet", passByVal: true},
+		"ColList":                  {fullName: "opt.ColList", passByVal: true},
+		"OptionalColList":          {fullName: "opt.OptionalColList", passByVal: true},
+		"TableID":                  {fullName: "opt.TableID", passByVal: true},
+		"SchemaID":                 {fullName: "opt.SchemaID", passByVal: true},
+		"SequenceID":               {fullName: "opt.SequenceID", passByVal: true},
+		"UniqueID":                 {fullName: "opt.UniqueID", passByVal: true},
+		"WithID":                   {fullName: "opt.WithID", passByVal: true},
+		"UDFDefinition":            {fullName: "memo.UDFDefinition", isPointer: true},
+		"UserDefinedAggDefinition": {fullName: "memo.UserDefinedAggDefinition", isPointer: true},
+		"StoredProcTxnOp":          {fullName: "tree.StoredProcTxnOp", passByVal: true},
+		"TransactionModes":         {fullName: "tree.TransactionModes", passByVal: true},
+		"Ordering":                 {fullName: "opt.Ordering", passByVal: true},
+		"OrderingChoice":           {fullName: "props.OrderingChoice", passByVal: true},
+		"GroupingOrder":            {fullName: "memo.GroupingOrder", passByVal: true},
+		"TupleOrdinal":             {fullName: "memo.TupleOrdinal", passByVal: true},
+		"ScanLimit":                {fullName: "memo.ScanLimit", passByVal: true},
+		"ScanFlags":                {fullName: "memo.ScanFlags", passByVal: true},
+		"JoinFlags":                {fullName: "memo.JoinFlags", passByVal: true},
+		"WindowFrame":              {fullName: "memo.WindowFrame", passByVal: true},
+		"FKCascades":               {fullName: "memo.FKCascades", passByVal: true},
+		"AfterTriggers":            {fullName: "memo.AfterTriggers", isPointer: true},
+		"ExplainOptions":           {fullName: "tree.ExplainOptions", passByVal: true},
+		"StatementReturnType":      {fullName: "tree.StatementReturnType", passByVal: true},
+		"StatementType":            {fullName: "tree.StatementType", passByVal: true},
+		"ShowTraceType":            {fullName: "tree.ShowTraceType", passByVal: true},
+		"ShowCompletions":          {fullName: "tree.ShowCompletions", isPointer: true, usePointerIntern: true},
+		"bool":                     {fullName: "bool", passByVal: true},
+		"int":                      {fullName: "int", passByVal: true},
+		"int64":                    {fullName: "int64", passByVal: true},
+		"string":                   {fullName: "string", passByVal: true},
+		"Type":                     {fullName: "types.T", isPointer: true},
+		"Datum":                    {fullName: "tree.Datum", isInterface: true},
+		"TypedExpr":                {fullName: "tree.TypedExpr", isInterface: true},
+		"Statement":                {fullName: "tree.Statement", isInterface: true},
+		"Subquery":                 {fullName: "tree.Subquery", isPointer: true, usePointerIntern: true},
+		"CreateTable":              {fullName: "tree.CreateTable", isPointer: true, usePointerIntern: true},
+		"CreateRoutine":            {fullName: "tree.CreateRoutine", isPointer: true, usePointerIntern: true},
+		"CreateTrigger":            {fullName: "tree.CreateTrigger", isPointer: true, usePointerIntern: true},
+		"CreateStats":              {fullName: "tree.CreateStats", isPointer: true, usePointerIntern: true},
+		"TableName":                {fullName: "tree.TableName", isPointer: true, usePointerIntern: true},
+		"Constraint":               {fullName: "constraint.Constraint", isPointer: true, usePointerIntern: true},
+		"FuncProps":                {fullName: "tree.FunctionProperties", isPointer: true, usePointerIntern: true},
+		"FuncOverload":             {fullName: "tree.Overload", isPointer: true, usePointerIntern: true},
+		"PhysProps":                {fullName: "physical.Required", isPointer: true},
+		"Presentation":             {fullName: "physical.Presentation", passByVal: true},
+		"RelProps":                 {fullName: "props.Relational"},
+		"RelPropsPtr":              {fullName: "props.Relational", isPointer: true, usePointerIntern: true},
+		"ScalarProps":              {fullName: "props.Scalar"},
+		"FuncDepSet":               {fullName: "props.FuncDepSet"},
+		"JoinMultiplicity":         {fullName: "props.JoinMultiplicity"},
+		"OpaqueMetadata":           {fullName: "opt.OpaqueMetadata", isInterface: true},
+		"JobCommand":               {fullName: "tree.JobCommand", passByVal: true},
+		"ScheduleCommand":          {fullName: "tree.ScheduleCommand", passByVal: true},
+		"IndexOrdinal":             {fullName: "cat.IndexOrdinal", passByVal: true},
+		"IndexOrdinals":            {fullName: "cat.IndexOrdinals", passByVal: true},
+		"RelocateSubject":          {fullName: "tree.RelocateSubject", passByVal: true},
+		"UniqueOrdinals":           {fullName: "cat.UniqueOrdinals", passByVal: true},
+		"SchemaDeps":               {fullName: "opt.SchemaDeps", passByVal: true},
+		"SchemaTypeDeps":           {fullName: "opt.SchemaTypeDeps", passByVal: true},
+		"SchemaFunctionDeps":       {fullName: "opt.SchemaFunctionDeps", passByVal: true},
+		"Locking":                  {fullName: "opt.Locking", passByVal: true},
+		"TableSample":              {fullName: "opt.TableSample", passByVal: true},
+		"CTEMaterializeClause":     {fullName: "tree.CTEMaterializeClause", passByVal: true},
+		"SpanExpression":           {fullName: "inverted.SpanExpression", isPointer: true, usePointerIntern: true},
+		"InvertedSpans":            {fullName: "inverted.Spans", passByVal: true},
+		"Persistence":              {fullName: "tree.Persistence", passByVal: true},
+		"PreFiltererState":         {fullName: "invertedexpr.PreFiltererStateForInvertedFilterer", isPointer: true, usePointerIntern: true},
+		"Volatility":               {fullName: "volatility.V", passByVal: true},
+		"LiteralRows":              {fullName: "opt.LiteralRows", isExpr: true, isPointer: true},
+		"Distribution":             {fullName: "physical.Distribution", passByVal: true},
+		"TreeCreateView":           {fullName: "tree.CreateView", isPointer: true, usePointerIntern: true},
 	}
 
 	// Add types of generated op and private structs.
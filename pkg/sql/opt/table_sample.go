@@ -0,0 +1,45 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package opt
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// TableSample represents the TABLESAMPLE clause of a scan (see
+// tree.TableSample). The zero value indicates that the scan is not sampled.
+type TableSample struct {
+	// Method is the sampling method.
+	Method tree.TableSampleMethod
+
+	// Fraction is the probability in [0, 1] with which each row (BERNOULLI) or
+	// each range (SYSTEM) of the table is included in the sample.
+	Fraction float64
+
+	// Seed is the seed specified in the REPEATABLE clause. It is only valid if
+	// Repeatable is true. If Repeatable is false, the sample is chosen randomly
+	// on each execution.
+	Seed       int64
+	Repeatable bool
+}
+
+// IsSet returns true if the scan is sampled.
+func (s TableSample) IsSet() bool {
+	return s.Method != tree.TableSampleNone
+}
+
+func (s TableSample) String() string {
+	if !s.IsSet() {
+		return ""
+	}
+	res := fmt.Sprintf("%s (%g)", s.Method, s.Fraction*100)
+	if s.Repeatable {
+		res += fmt.Sprintf(" REPEATABLE (%d)", s.Seed)
+	}
+	return res
+}
@@ -356,11 +356,16 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		switch def := def.(type) {
 		case *tree.UniqueConstraintTableDef:
 			if def.WithoutIndex {
-				tab.addUniqueConstraint(def.Name, def.Columns, def.Predicate, def.WithoutIndex)
+				tab.addUniqueConstraint(
+					def.Name, def.Columns, def.Predicate, def.WithoutIndex, def.Deferrability,
+				)
 			} else if !def.PrimaryKey {
 				tab.addIndex(&def.IndexTableDef, uniqueIndex)
 			}
 
+		case *tree.ExclusionConstraintTableDef:
+			tab.addExclusionConstraint(def)
+
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
@@ -375,6 +380,7 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 						tree.IndexElemList{{Column: def.Name}},
 						nil, /* predicate */
 						def.Unique.WithoutIndex,
+						tree.NotDeferrable,
 					)
 				} else {
 					tab.addIndex(
@@ -677,6 +683,8 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrable:               d.Deferrability.IsDeferrable(),
+		initiallyDeferred:        d.Deferrability.IsInitiallyDeferred(),
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
@@ -712,7 +720,11 @@ func (tt *Table) addCheckConstraint(check *tree.CheckConstraintTableDef) {
 }
 
 func (tt *Table) addUniqueConstraint(
-	name tree.Name, columns tree.IndexElemList, predicate tree.Expr, withoutIndex bool,
+	name tree.Name,
+	columns tree.IndexElemList,
+	predicate tree.Expr,
+	withoutIndex bool,
+	deferrability tree.ConstraintDeferrability,
 ) {
 	// We don't currently use unique constraints with an index (those are already
 	// tracked with unique indexes), so don't bother adding them.
@@ -728,11 +740,13 @@ func (tt *Table) addUniqueConstraint(
 
 	// Create the constraint.
 	u := UniqueConstraint{
-		name:           tt.makeUniqueConstraintName(name, columns),
-		tabID:          tt.TabID,
-		columnOrdinals: cols,
-		withoutIndex:   withoutIndex,
-		validated:      true,
+		name:              tt.makeUniqueConstraintName(name, columns),
+		tabID:             tt.TabID,
+		columnOrdinals:    cols,
+		withoutIndex:      withoutIndex,
+		validated:         true,
+		deferrable:        deferrability.IsDeferrable(),
+		initiallyDeferred: deferrability.IsInitiallyDeferred(),
 	}
 	// Add partial unique constraint predicate.
 	if predicate != nil {
@@ -751,6 +765,38 @@ func (tt *Table) addUniqueConstraint(
 	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
 }
 
+// addExclusionConstraint adds an exclusion constraint, which is represented
+// as a unique constraint without an index that has an operator per column.
+// Unlike unique constraints, the columns keep their order in the definition.
+func (tt *Table) addExclusionConstraint(def *tree.ExclusionConstraintTableDef) {
+	u := UniqueConstraint{
+		name:               string(def.Name),
+		tabID:              tt.TabID,
+		columnOrdinals:     make([]int, len(def.Elems)),
+		exclusionOperators: make([]treecmp.ComparisonOperatorSymbol, len(def.Elems)),
+		withoutIndex:       true,
+		deferrable:         def.Deferrability.IsDeferrable(),
+		initiallyDeferred:  def.Deferrability.IsInitiallyDeferred(),
+	}
+	if u.name == "" {
+		var buf bytes.Buffer
+		buf.WriteString("excl")
+		for i := range def.Elems {
+			buf.WriteRune('_')
+			buf.WriteString(string(def.Elems[i].Column))
+		}
+		u.name = buf.String()
+	}
+	for i, e := range def.Elems {
+		u.columnOrdinals[i] = tt.FindOrdinal(string(e.Column))
+		u.exclusionOperators[i] = e.Operator.Symbol
+	}
+	if def.Predicate != nil {
+		u.predicate = tree.Serialize(def.Predicate)
+	}
+	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
+}
+
 func (tt *Table) addColumn(def *tree.ColumnTableDef) {
 	ordinal := len(tt.Columns)
 	nullable := !def.PrimaryKey.IsPrimaryKey && def.Nullable.Nullability != tree.NotNull
@@ -876,7 +922,9 @@ func (tt *Table) addIndexWithVersion(
 ) *Index {
 	// Add a unique constraint if this is a primary or unique index.
 	if typ != nonUniqueIndex {
-		tt.addUniqueConstraint(def.Name, def.Columns, def.Predicate, false /* withoutIndex */)
+		tt.addUniqueConstraint(
+			def.Name, def.Columns, def.Predicate, false /* withoutIndex */, tree.NotDeferrable,
+		)
 	}
 
 	// The test catalog does not support the hash-sharded index syntactic sugar.
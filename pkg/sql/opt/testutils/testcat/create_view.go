@@ -21,6 +21,7 @@ func (tc *Catalog) CreateView(stmt *tree.CreateView) *View {
 		ViewName:    stmt.Name,
 		QueryText:   fmtCtx.CloseAndGetString(),
 		ColumnNames: stmt.ColumnNames,
+		CheckOpt:    stmt.CheckOption,
 	}
 
 	// Add the new view to the catalog.
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
@@ -377,6 +378,12 @@ func (tc *Catalog) IsOwner(
 	}
 }
 
+// EventTriggers is part of the cat.Catalog interface. The test catalog does
+// not support event triggers.
+func (tc *Catalog) EventTriggers(ctx context.Context) ([]cat.EventTrigger, error) {
+	return nil, nil
+}
+
 func (tc *Catalog) resolveSchema(toResolve *cat.SchemaName) (cat.Schema, cat.SchemaName, error) {
 	if string(toResolve.CatalogName) != testDB {
 		return nil, cat.SchemaName{}, pgerror.Newf(pgcode.InvalidSchemaName,
@@ -750,6 +757,7 @@ type View struct {
 	ViewName    cat.DataSourceName
 	QueryText   string
 	ColumnNames tree.NameList
+	CheckOpt    tree.ViewCheckOption
 	Triggers    []Trigger
 
 	// If Revoked is true, then the user has had privileges on the view revoked.
@@ -803,6 +811,11 @@ func (tv *View) IsSystemView() bool {
 	return false
 }
 
+// CheckOption is part of the cat.View interface.
+func (tv *View) CheckOption() tree.ViewCheckOption {
+	return tv.CheckOpt
+}
+
 // Query is part of the cat.View interface.
 func (tv *View) Query() string {
 	return tv.QueryText
@@ -1663,10 +1676,12 @@ type ForeignKeyConstraint struct {
 	originColumnOrdinals     []int
 	referencedColumnOrdinals []int
 
-	validated    bool
-	matchMethod  tree.CompositeKeyMatchMethod
-	deleteAction tree.ReferenceAction
-	updateAction tree.ReferenceAction
+	validated         bool
+	matchMethod       tree.CompositeKeyMatchMethod
+	deleteAction      tree.ReferenceAction
+	updateAction      tree.ReferenceAction
+	deferrable        bool
+	initiallyDeferred bool
 }
 
 var _ cat.ForeignKeyConstraint = &ForeignKeyConstraint{}
@@ -1716,7 +1731,17 @@ func (fk *ForeignKeyConstraint) ReferencedColumnOrdinal(referencedTable cat.Tabl
 
 // Validated is part of the cat.ForeignKeyConstraint interface.
 func (fk *ForeignKeyConstraint) Validated() bool {
-	return fk.validated
+	return fk.validated && !fk.deferrable
+}
+
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) Deferrable() bool {
+	return fk.deferrable
+}
+
+// InitiallyDeferred is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) InitiallyDeferred() bool {
+	return fk.initiallyDeferred
 }
 
 // MatchMethod is part of the cat.ForeignKeyConstraint interface.
@@ -1745,6 +1770,9 @@ type UniqueConstraint struct {
 	canUseTombstones      bool
 	tombstoneIndexOrdinal cat.IndexOrdinal
 	validated             bool
+	deferrable            bool
+	initiallyDeferred     bool
+	exclusionOperators    []treecmp.ComparisonOperatorSymbol
 }
 
 var _ cat.UniqueConstraint = &UniqueConstraint{}
@@ -1798,7 +1826,17 @@ func (u *UniqueConstraint) TombstoneIndexOrdinal() (ordinal cat.IndexOrdinal, ok
 
 // Validated is part of the cat.UniqueConstraint interface.
 func (u *UniqueConstraint) Validated() bool {
-	return u.validated
+	return u.validated && !u.deferrable
+}
+
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) Deferrable() bool {
+	return u.deferrable
+}
+
+// InitiallyDeferred is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) InitiallyDeferred() bool {
+	return u.initiallyDeferred
 }
 
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
@@ -1807,6 +1845,16 @@ func (u *UniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return false
 }
 
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) IsExclusion() bool {
+	return u.exclusionOperators != nil
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *UniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol {
+	return u.exclusionOperators[i]
+}
+
 // Sequence implements the cat.Sequence interface for testing purposes.
 type Sequence struct {
 	SeqID      cat.StableID
@@ -571,6 +571,32 @@ func (oc *optCatalog) GetRoutineOwner(
 	return fnDesc.FuncDesc().Privileges.Owner(), nil
 }
 
+// EventTriggers is part of the cat.Catalog interface.
+func (oc *optCatalog) EventTriggers(ctx context.Context) ([]cat.EventTrigger, error) {
+	dbName := oc.planner.CurrentDatabase()
+	if dbName == "" {
+		return nil, nil
+	}
+	db, err := oc.planner.Descriptors().ByNameWithLeased(oc.planner.txn).MaybeGet().Database(ctx, dbName)
+	if err != nil || db == nil {
+		return nil, err
+	}
+	var triggers []cat.EventTrigger
+	if err := db.ForEachEventTrigger(func(name string, trig descpb.DatabaseDescriptor_EventTriggerInfo) error {
+		triggers = append(triggers, &optEventTrigger{
+			name:    tree.Name(name),
+			event:   trig.Event,
+			tags:    trig.Tags,
+			funcID:  cat.StableID(trig.FuncID),
+			enabled: !trig.Disabled,
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return triggers, nil
+}
+
 // dataSourceForDesc returns a data source wrapper for the given descriptor.
 // The wrapper might come from the cache, or it may be created now.
 func (oc *optCatalog) dataSourceForDesc(
@@ -733,6 +759,17 @@ func (ov *optView) IsSystemView() bool {
 	return ov.desc.IsVirtualTable()
 }
 
+// CheckOption is part of the cat.View interface.
+func (ov *optView) CheckOption() tree.ViewCheckOption {
+	switch ov.desc.TableDesc().ViewCheckOption {
+	case descpb.TableDescriptor_LOCAL:
+		return tree.ViewCheckOptionLocal
+	case descpb.TableDescriptor_CASCADED:
+		return tree.ViewCheckOptionCascaded
+	}
+	return tree.ViewCheckOptionNone
+}
+
 // Query is part of the cat.View interface.
 func (ov *optView) Query() string {
 	return ov.desc.GetViewQuery()
@@ -1005,12 +1042,35 @@ func newOptTable(
 	ot.uniqueConstraints = make([]optUniqueConstraint, len(ot.desc.EnforcedUniqueConstraintsWithoutIndex()))
 	for i, u := range ot.desc.EnforcedUniqueConstraintsWithoutIndex() {
 		ot.uniqueConstraints[i] = optUniqueConstraint{
-			name:         u.GetName(),
-			table:        ot.ID(),
-			columns:      u.CollectKeyColumnIDs().Ordered(),
-			predicate:    u.GetPredicate(),
-			withoutIndex: true,
-			validity:     u.GetConstraintValidity(),
+			name:              u.GetName(),
+			table:             ot.ID(),
+			columns:           u.CollectKeyColumnIDs().Ordered(),
+			predicate:         u.GetPredicate(),
+			withoutIndex:      true,
+			validity:          u.GetConstraintValidity(),
+			deferrable:        u.IsDeferrable(),
+			initiallyDeferred: u.IsInitiallyDeferred(),
+		}
+		if u.IsExclusion() {
+			// The operators of an exclusion constraint are positional, so the
+			// columns must keep the order of the descriptor.
+			uc := &ot.uniqueConstraints[i]
+			uc.columns = u.UniqueWithoutIndexDesc().ColumnIDs
+			uc.exclusionOperators = make([]treecmp.ComparisonOperatorSymbol, len(uc.columns))
+			for j := range uc.columns {
+				switch op := u.GetExclusionOperator(j); op {
+				case treecmp.EQ.String():
+					uc.exclusionOperators[j] = treecmp.EQ
+				case treecmp.Overlaps.String():
+					uc.exclusionOperators[j] = treecmp.Overlaps
+				case treecmp.Adjacent.String():
+					uc.exclusionOperators[j] = treecmp.Adjacent
+				default:
+					return nil, errors.AssertionFailedf(
+						"unsupported operator %q in exclusion constraint %q", op, u.GetName(),
+					)
+				}
+			}
 		}
 	}
 
@@ -1124,6 +1184,8 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.IsDeferrable(),
+			initiallyDeferred: fk.IsInitiallyDeferred(),
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -1137,6 +1199,8 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.IsDeferrable(),
+			initiallyDeferred: fk.IsInitiallyDeferred(),
 		})
 	}
 
@@ -2130,6 +2194,12 @@ type optUniqueConstraint struct {
 	canUseTombstones      bool
 	tombstoneIndexOrdinal cat.IndexOrdinal
 	validity              descpb.ConstraintValidity
+	deferrable            bool
+	initiallyDeferred     bool
+
+	// exclusionOperators is non-nil if this is an exclusion constraint, in
+	// which case it holds the operator for each of the columns.
+	exclusionOperators []treecmp.ComparisonOperatorSymbol
 
 	uniquenessGuaranteedByAnotherIndex bool
 }
@@ -2186,7 +2256,18 @@ func (u *optUniqueConstraint) TombstoneIndexOrdinal() (ordinal cat.IndexOrdinal,
 
 // Validated is part of the cat.UniqueConstraint interface.
 func (u *optUniqueConstraint) Validated() bool {
-	return u.validity == descpb.ConstraintValidity_Validated
+	return u.validity == descpb.ConstraintValidity_Validated && !u.deferrable &&
+		!u.IsExclusion()
+}
+
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrable() bool {
+	return u.deferrable
+}
+
+// InitiallyDeferred is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) InitiallyDeferred() bool {
+	return u.initiallyDeferred
 }
 
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
@@ -2197,6 +2278,16 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) IsExclusion() bool {
+	return u.exclusionOperators != nil
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol {
+	return u.exclusionOperators[i]
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -2210,10 +2301,12 @@ type optForeignKeyConstraint struct {
 	referencedTable   cat.StableID
 	referencedColumns []descpb.ColumnID
 
-	validity     descpb.ConstraintValidity
-	match        tree.CompositeKeyMatchMethod
-	deleteAction tree.ReferenceAction
-	updateAction tree.ReferenceAction
+	validity          descpb.ConstraintValidity
+	match             tree.CompositeKeyMatchMethod
+	deleteAction      tree.ReferenceAction
+	updateAction      tree.ReferenceAction
+	deferrable        bool
+	initiallyDeferred bool
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -2267,7 +2360,17 @@ func (fk *optForeignKeyConstraint) ReferencedColumnOrdinal(referencedTable cat.T
 
 // Validated is part of the cat.ForeignKeyConstraint interface.
 func (fk *optForeignKeyConstraint) Validated() bool {
-	return fk.validity == descpb.ConstraintValidity_Validated
+	return fk.validity == descpb.ConstraintValidity_Validated && !fk.deferrable
+}
+
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrable() bool {
+	return fk.deferrable
+}
+
+// InitiallyDeferred is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) InitiallyDeferred() bool {
+	return fk.initiallyDeferred
 }
 
 // MatchMethod is part of the cat.ForeignKeyConstraint interface.
@@ -3090,3 +3193,41 @@ func mapGeneratedAsIdentityType(inType catpb.GeneratedAsIdentityType) cat.Genera
 	}
 	return mapGeneratedAsIdentityType[inType]
 }
+
+// optEventTrigger is a wrapper around
+// descpb.DatabaseDescriptor_EventTriggerInfo that implements the
+// cat.EventTrigger interface.
+type optEventTrigger struct {
+	name    tree.Name
+	event   string
+	tags    []string
+	funcID  cat.StableID
+	enabled bool
+}
+
+var _ cat.EventTrigger = &optEventTrigger{}
+
+// Name is part of the cat.EventTrigger interface.
+func (o *optEventTrigger) Name() tree.Name {
+	return o.name
+}
+
+// Event is part of the cat.EventTrigger interface.
+func (o *optEventTrigger) Event() string {
+	return o.event
+}
+
+// Tags is part of the cat.EventTrigger interface.
+func (o *optEventTrigger) Tags() []string {
+	return o.tags
+}
+
+// FuncID is part of the cat.EventTrigger interface.
+func (o *optEventTrigger) FuncID() cat.StableID {
+	return o.funcID
+}
+
+// Enabled is part of the cat.EventTrigger interface.
+func (o *optEventTrigger) Enabled() bool {
+	return o.enabled
+}
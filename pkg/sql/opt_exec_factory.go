@@ -170,6 +170,7 @@ func (ef *execFactory) ConstructScan(
 	scan.lockingWaitPolicy = descpb.ToScanLockingWaitPolicy(params.Locking.WaitPolicy)
 	scan.lockingDurability = descpb.ToScanLockingDurability(params.Locking.Durability)
 	scan.localityOptimized = params.LocalityOptimized
+	scan.tableSample = params.TableSample
 	if !ef.isExplain && !ef.planner.SessionData().Internal {
 		idxUsageKey := roachpb.IndexUsageKey{
 			TableID: roachpb.TableID(tabDesc.GetID()),
@@ -568,6 +569,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.DistsqlBlocklist,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -1200,6 +1202,9 @@ func (ef *execFactory) ConstructWindow(root exec.Node, wi exec.WindowInfo) (exec
 			outputColIdx: wi.OutputIdxs[i],
 			frame:        wi.Exprs[i].WindowDef.Frame,
 		}
+		if wi.UserDefined != nil {
+			p.funcs[i].userDefined = wi.UserDefined[i]
+		}
 		if len(wi.Ordering) == 0 {
 			frame := p.funcs[i].frame
 			if frame.Mode == treewindow.RANGE && frame.Bounds.HasOffset() {
@@ -1688,6 +1693,51 @@ func (ef *execFactory) ConstructUpsert(
 	uniqueWithTombstoneIndexes cat.IndexOrdinals,
 	lockedIndexes cat.IndexOrdinals,
 	autoCommit bool,
+) (exec.Node, error) {
+	return ef.constructUpsertOrMerge(
+		input, table, canaryCol, -1 /* deleteCol */, insertColOrdSet, fetchColOrdSet,
+		updateColOrdSet, returnColOrdSet, checks, uniqueWithTombstoneIndexes, lockedIndexes,
+		autoCommit,
+	)
+}
+
+func (ef *execFactory) ConstructMerge(
+	input exec.Node,
+	table cat.Table,
+	canaryCol exec.NodeColumnOrdinal,
+	deleteCol exec.NodeColumnOrdinal,
+	insertColOrdSet exec.TableColumnOrdinalSet,
+	fetchColOrdSet exec.TableColumnOrdinalSet,
+	updateColOrdSet exec.TableColumnOrdinalSet,
+	returnColOrdSet exec.TableColumnOrdinalSet,
+	checks exec.CheckOrdinalSet,
+	uniqueWithTombstoneIndexes cat.IndexOrdinals,
+	lockedIndexes cat.IndexOrdinals,
+	autoCommit bool,
+) (exec.Node, error) {
+	return ef.constructUpsertOrMerge(
+		input, table, canaryCol, deleteCol, insertColOrdSet, fetchColOrdSet,
+		updateColOrdSet, returnColOrdSet, checks, uniqueWithTombstoneIndexes, lockedIndexes,
+		autoCommit,
+	)
+}
+
+// constructUpsertOrMerge constructs the upsertNode for an Upsert or a Merge
+// operator. deleteCol is -1 unless existing rows can be deleted by a MERGE
+// statement.
+func (ef *execFactory) constructUpsertOrMerge(
+	input exec.Node,
+	table cat.Table,
+	canaryCol exec.NodeColumnOrdinal,
+	deleteCol exec.NodeColumnOrdinal,
+	insertColOrdSet exec.TableColumnOrdinalSet,
+	fetchColOrdSet exec.TableColumnOrdinalSet,
+	updateColOrdSet exec.TableColumnOrdinalSet,
+	returnColOrdSet exec.TableColumnOrdinalSet,
+	checks exec.CheckOrdinalSet,
+	uniqueWithTombstoneIndexes cat.IndexOrdinals,
+	lockedIndexes cat.IndexOrdinals,
+	autoCommit bool,
 ) (exec.Node, error) {
 	// Derive table and column descriptors.
 	rowsNeeded := !returnColOrdSet.Empty()
@@ -1744,6 +1794,7 @@ func (ef *execFactory) ConstructUpsert(
 			tw: tableUpserter{
 				ri:            ri,
 				canaryOrdinal: int(canaryCol),
+				deleteOrdinal: int(deleteCol),
 				fetchCols:     fetchCols,
 				updateCols:    updateCols,
 				ru:            ru,
@@ -1751,6 +1802,19 @@ func (ef *execFactory) ConstructUpsert(
 		},
 	}
 
+	// Create the table deleter if existing rows can be deleted.
+	if deleteCol != -1 {
+		ups.run.tw.rd = row.MakeDeleter(
+			ef.planner.ExecCfg().Codec,
+			tabDesc,
+			ordinalsToIndexes(table, lockedIndexes),
+			fetchCols,
+			&ef.planner.ExecCfg().Settings.SV,
+			internal,
+			ef.planner.ExecCfg().GetRowMetrics(internal),
+		)
+	}
+
 	// If rows are not needed, no columns are returned.
 	if rowsNeeded {
 		returnCols := makeColList(table, returnColOrdSet)
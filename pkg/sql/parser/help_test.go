@@ -104,6 +104,10 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER TYPE t RENAME ??`, `ALTER TYPE`},
 		{`ALTER TYPE t DROP VALUE ??`, `ALTER TYPE`},
 
+		{`ALTER DOMAIN ??`, `ALTER DOMAIN`},
+		{`ALTER DOMAIN d ??`, `ALTER DOMAIN`},
+		{`ALTER DOMAIN d DROP ??`, `ALTER DOMAIN`},
+
 		{`ALTER INDEX foo@bar RENAME ??`, `ALTER INDEX`},
 		{`ALTER INDEX foo@bar RENAME TO blih ??`, `ALTER INDEX`},
 		{`ALTER INDEX foo@bar SPLIT ??`, `ALTER INDEX`},
@@ -179,6 +183,10 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE EXTENSION ??`, `CREATE EXTENSION`},
 
 		{`CREATE EXTERNAL CONNECTION ??`, `CREATE EXTERNAL CONNECTION`},
+		{`CREATE PUBLICATION ??`, `CREATE PUBLICATION`},
+		{`CREATE PUBLICATION p FOR ??`, `CREATE PUBLICATION`},
+		{`CREATE SUBSCRIPTION ??`, `CREATE SUBSCRIPTION`},
+		{`CREATE SUBSCRIPTION s CONNECTION 'uri' ??`, `CREATE SUBSCRIPTION`},
 
 		{`CREATE VIRTUAL CLUSTER ??`, `CREATE VIRTUAL CLUSTER`},
 		{`CREATE TENANT ??`, `CREATE VIRTUAL CLUSTER`},
@@ -210,6 +218,8 @@ func TestContextualHelp(t *testing.T) {
 
 		{`CREATE TYPE blah AS ENUM ??`, `CREATE TYPE`},
 		{`DROP TYPE ??`, `DROP TYPE`},
+		{`CREATE DOMAIN ??`, `CREATE DOMAIN`},
+		{`DROP DOMAIN ??`, `DROP DOMAIN`},
 
 		{`CREATE SCHEMA IF ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA IF NOT ??`, `CREATE SCHEMA`},
@@ -235,6 +245,8 @@ func TestContextualHelp(t *testing.T) {
 		{`DROP INDEX blah@blih ??`, `DROP INDEX`},
 
 		{`DROP EXTERNAL CONNECTION blah ??`, `DROP EXTERNAL CONNECTION`},
+		{`DROP PUBLICATION ??`, `DROP PUBLICATION`},
+		{`DROP SUBSCRIPTION ??`, `DROP SUBSCRIPTION`},
 
 		{`DROP USER ??`, `DROP ROLE`},
 		{`DROP USER IF ??`, `DROP ROLE`},
@@ -307,6 +319,15 @@ func TestContextualHelp(t *testing.T) {
 		{`MOVE ??`, `MOVE`},
 		{`MOVE 1 ??`, `MOVE`},
 
+		{`LISTEN ??`, `LISTEN`},
+		{`NOTIFY ??`, `NOTIFY`},
+		{`NOTIFY foo, ??`, `NOTIFY`},
+		{`UNLISTEN ??`, `UNLISTEN`},
+
+		{`MERGE ??`, `MERGE`},
+		{`MERGE INTO blah USING foo ON x WHEN ??`, `MERGE`},
+		{`EXPLAIN MERGE INTO blah USING foo ON x WHEN MATCHED THEN DELETE ??`, `MERGE`},
+
 		{`INSERT INTO ??`, `INSERT`},
 		{`INSERT INTO blah (??`, `<SELECTCLAUSE>`},
 		{`INSERT INTO blah VALUES (1) RETURNING ??`, `INSERT`},
@@ -546,6 +567,8 @@ func TestContextualHelp(t *testing.T) {
 		{`SET LOCAL TIME ??`, `SET LOCAL`},
 		{`SET LOCAL TIME ZONE 'UTC' ??`, `SET LOCAL`},
 
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
+		{`SET CONSTRAINTS ALL ??`, `SET CONSTRAINTS`},
 		{`SET TRANSACTION ??`, `SET TRANSACTION`},
 		{`SET TRANSACTION ISOLATION LEVEL SNAPSHOT ??`, `SET TRANSACTION`},
 		{`SET TIME ??`, `SET SESSION`},
@@ -617,6 +640,12 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE TRIGGER foo AFTER INSERT ON bar ??`, `CREATE TRIGGER`},
 		{`DROP TRIGGER ??`, `DROP TRIGGER`},
 
+		{`CREATE EVENT TRIGGER ??`, `CREATE EVENT TRIGGER`},
+		{`CREATE EVENT TRIGGER et ON ddl_command_start ??`, `CREATE EVENT TRIGGER`},
+		{`ALTER EVENT TRIGGER ??`, `ALTER EVENT TRIGGER`},
+		{`ALTER EVENT TRIGGER et ??`, `ALTER EVENT TRIGGER`},
+		{`DROP EVENT TRIGGER ??`, `DROP EVENT TRIGGER`},
+
 		{`CREATE POLICY ??`, `CREATE POLICY`},
 		{`CREATE POLICY p1 on ??`, `CREATE POLICY`},
 		{`ALTER POLICY ??`, `ALTER POLICY`},
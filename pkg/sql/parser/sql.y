@@ -331,6 +331,9 @@ func (u *sqlSymUnion) tableNames() tree.TableNames {
 func (u *sqlSymUnion) indexFlags() *tree.IndexFlags {
     return u.val.(*tree.IndexFlags)
 }
+func (u *sqlSymUnion) tableSample() *tree.TableSample {
+    return u.val.(*tree.TableSample)
+}
 func (u *sqlSymUnion) arraySubscript() *tree.ArraySubscript {
     return u.val.(*tree.ArraySubscript)
 }
@@ -427,6 +430,9 @@ func (u *sqlSymUnion) tenantCapabilities() []tree.TenantCapability {
 func (u *sqlSymUnion) persistence() tree.Persistence {
   return u.val.(tree.Persistence)
 }
+func (u *sqlSymUnion) viewCheckOption() tree.ViewCheckOption {
+  return u.val.(tree.ViewCheckOption)
+}
 func (u *sqlSymUnion) colType() *types.T {
     if colType, ok := u.val.(*types.T); ok && colType != nil {
         return colType
@@ -562,6 +568,12 @@ func (u *sqlSymUnion) privilegeList() privilege.List {
 func (u *sqlSymUnion) onConflict() *tree.OnConflict {
     return u.val.(*tree.OnConflict)
 }
+func (u *sqlSymUnion) mergeWhen() *tree.MergeWhen {
+    return u.val.(*tree.MergeWhen)
+}
+func (u *sqlSymUnion) mergeWhens() tree.MergeWhens {
+    return u.val.(tree.MergeWhens)
+}
 func (u *sqlSymUnion) orderBy() tree.OrderBy {
     return u.val.(tree.OrderBy)
 }
@@ -646,6 +658,12 @@ func (u *sqlSymUnion) idxElem() tree.IndexElem {
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) exclusionElem() tree.ExclusionElem {
+    return u.val.(tree.ExclusionElem)
+}
+func (u *sqlSymUnion) exclusionElems() tree.ExclusionElemList {
+    return u.val.(tree.ExclusionElemList)
+}
 func (u *sqlSymUnion) indexInvisibility() tree.IndexInvisibility {
     return u.val.(tree.IndexInvisibility)
 }
@@ -766,6 +784,9 @@ func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
 func (u *sqlSymUnion) referenceActions() tree.ReferenceActions {
     return u.val.(tree.ReferenceActions)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+    return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) createStatsOptions() *tree.CreateStatsOptions {
     return u.val.(*tree.CreateStatsOptions)
 }
@@ -884,6 +905,9 @@ func (u *sqlSymUnion) stmts() tree.Statements {
 func (u *sqlSymUnion) routineBody() *tree.RoutineBody {
     return u.val.(*tree.RoutineBody)
 }
+func (u *sqlSymUnion) routineAggregate() *tree.RoutineAggregate {
+    return u.val.(*tree.RoutineAggregate)
+}
 func (u *sqlSymUnion) functionObj() tree.RoutineObj {
     return u.val.(tree.RoutineObj)
 }
@@ -962,6 +986,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> TYPECAST TYPEANNOTATE DOT_DOT
 %token <str> LESS_EQUALS GREATER_EQUALS NOT_EQUALS
 %token <str> NOT_REGMATCH REGIMATCH NOT_REGIMATCH
+%token <str> NOT_EXTEND_RIGHT NOT_EXTEND_LEFT ADJACENT
 %token <str> ERROR
 
 // If you want to make any keyword changes, add the new keyword here as well as
@@ -977,7 +1002,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BOX2D BUNDLE BY BYPASSRLS
 
-%token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
+%token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASCADED CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
 %token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
@@ -991,7 +1016,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
 %token <str> DISABLE DISCARD DISTANCE DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> EACH ELSE ENABLE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENABLE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EVENT EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
@@ -1025,9 +1050,9 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGICAL LOGICALLY LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGICAL LOGICALLY LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MODE MONTH MOVE
+%token <str> MATCH MATCHED MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MODE MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
@@ -1035,7 +1060,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> NAN NAME NAMES NATURAL NEG_INNER_PRODUCT NEVER NEW NEW_DB_NAME NEW_KMS NEXT NO NOBYPASSRLS NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NODE NOLOGIN NOMODIFYCLUSTERSETTING NOREPLICATION
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
-%token <str> NOTHING NOTHING_AFTER_RETURNING
+%token <str> NOTHING NOTHING_AFTER_RETURNING NOTIFY
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
@@ -1064,7 +1089,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING SUBJECT SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
-%token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
+%token <str> TABLE TABLES TABLESAMPLE TABLESPACE TARGET TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
 %token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TO THROTTLING TRAILING TRACE
 %token <str> TRANSACTION TRANSACTIONS TRANSFER TRANSFORM TREAT TRIGGER TRIGGERS TRIM TRUE
 %token <str> TRUNCATE TRUSTED TYPE TYPES
@@ -1102,8 +1127,11 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 // references.
 // - TENANT_ALL is used to differentiate `ALTER TENANT <id>` from
 // `ALTER TENANT ALL`. Ditto `CLUSTER_ALL` and `CLUSTER ALL`.
+// - NOT_DEFERRABLE is used to differentiate NOT DEFERRABLE from NOT VALID and
+// NOT NULL after constraints, and from NOT LIKE and the like after the AS OF
+// SYSTEM TIME expression of BEGIN.
 %token NOT_LA NULLS_LA WITH_LA AS_LA GENERATED_ALWAYS GENERATED_BY_DEFAULT RESET_ALL ROLE_ALL
-%token USER_ALL ON_LA TENANT_ALL CLUSTER_ALL SET_TRACING
+%token USER_ALL ON_LA TENANT_ALL CLUSTER_ALL SET_TRACING NOT_DEFERRABLE
 
 %union {
   id    int32
@@ -1130,11 +1158,13 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Statement> alter_role_stmt
 %type <*tree.SetVar> set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
+%type <tree.Statement> alter_domain_stmt
 %type <tree.Statement> alter_schema_stmt
-%type <tree.Statement> alter_unsupported_stmt
+%type <tree.Statement> alter_aggregate_stmt
 %type <tree.Statement> alter_func_stmt
 %type <tree.Statement> alter_proc_stmt
 %type <tree.Statement> alter_policy_stmt
+%type <tree.Statement> alter_event_trigger_stmt
 
 // ALTER RANGE
 %type <tree.Statement> alter_zone_range_stmt
@@ -1266,11 +1296,15 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Statement> create_table_as_stmt
 %type <tree.Statement> create_virtual_cluster_stmt
 %type <tree.Statement> create_logical_replication_stream_stmt
+%type <tree.Statement> create_publication_stmt
+%type <tree.Statement> create_subscription_stmt
 %type <tree.Statement> create_view_stmt
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
+%type <tree.Statement> create_aggregate_stmt
 %type <tree.Statement> create_proc_stmt
 %type <tree.Statement> create_trigger_stmt
+%type <tree.Statement> create_event_trigger_stmt
 %type <tree.Statement> create_policy_stmt
 
 %type <tree.Statement> check_stmt
@@ -1286,6 +1320,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <*tree.CheckExternalConnectionOptions> opt_with_check_external_connection_options_list check_external_connection_options_list check_external_connection_options
 
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> create_domain_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -1293,17 +1328,22 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Statement> drop_ddl_stmt
 %type <tree.Statement> drop_database_stmt
 %type <tree.Statement> drop_external_connection_stmt
+%type <tree.Statement> drop_publication_stmt
+%type <tree.Statement> drop_subscription_stmt
 %type <tree.Statement> drop_index_stmt
 %type <tree.Statement> drop_role_stmt
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
+%type <tree.Statement> drop_aggregate_stmt
 %type <tree.Statement> drop_policy_stmt
 %type <tree.Statement> drop_proc_stmt
 %type <tree.Statement> drop_trigger_stmt
+%type <tree.Statement> drop_event_trigger_stmt
 %type <tree.Statement> drop_virtual_cluster_stmt
 %type <bool>           opt_immediate
 
@@ -1320,6 +1360,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Statement> deallocate_stmt
 %type <tree.Statement> grant_stmt
 %type <tree.Statement> insert_stmt
+%type <tree.Statement> merge_stmt
 %type <tree.Statement> import_stmt
 %type <tree.Statement> pause_stmt pause_jobs_stmt pause_schedules_stmt pause_all_jobs_stmt alter_job_stmt
 %type <*tree.Select>   for_schedules_clause
@@ -1346,6 +1387,9 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
+%type <tree.NameList> set_constraints_list
+%type <bool> set_constraints_mode
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -1413,6 +1457,8 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> unlisten_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
@@ -1433,6 +1479,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 
 %type <tree.KVOption> kv_option
 %type <[]tree.KVOption> kv_option_list opt_with_options var_set_list opt_with_schedule_options
+%type <[]tree.KVOption> opt_with_subscription_options
 %type <*tree.BackupOptions> opt_with_backup_options backup_options backup_options_list
 %type <*tree.RestoreOptions> opt_with_restore_options restore_options restore_options_list
 %type <*tree.TenantReplicationOptions> opt_with_replication_options replication_options replication_options_list source_replication_options source_replication_options_list
@@ -1546,6 +1593,9 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.OrderBy> sort_clause sort_clause_no_index single_sort_clause opt_sort_clause opt_sort_clause_no_index
 %type <[]*tree.Order> sortby_list sortby_no_index_list
 %type <tree.IndexElemList> index_params create_as_params
+%type <tree.ExclusionElem> exclusion_elem
+%type <tree.ExclusionElemList> exclusion_elems
+%type <str> opt_exclusion_method
 %type <tree.IndexInvisibility> opt_index_visible alter_index_visible
 %type <idxtype.T> opt_index_access_method
 %type <tree.NameList> name_list privilege_list
@@ -1609,6 +1659,9 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.ColumnDefList> opt_col_def_list col_def_list opt_col_def_list_no_types col_def_list_no_types
 %type <tree.ColumnDef> col_def
 %type <*tree.OnConflict> on_conflict
+%type <*tree.MergeWhen> merge_when_clause merge_when_matched_action merge_when_not_matched_action
+%type <tree.MergeWhens> merge_when_list
+%type <tree.Expr> opt_merge_when_condition
 
 %type <tree.Statement> begin_transaction
 %type <tree.TransactionModes> transaction_mode_list transaction_mode
@@ -1624,6 +1677,8 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <*tree.IndexFlags> opt_index_flags
 %type <*tree.IndexFlags> index_flags_param
 %type <*tree.IndexFlags> index_flags_param_list
+%type <*tree.TableSample> opt_tablesample_clause
+%type <tree.Expr> opt_repeatable_clause
 %type <tree.Expr> a_expr b_expr c_expr d_expr typed_literal
 %type <tree.Expr> substr_from substr_for
 %type <tree.Expr> in_expr
@@ -1708,6 +1763,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.ColumnQualification> col_qualification_elem create_as_col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
 %type <tree.ReferenceActions> reference_actions
+%type <tree.ConstraintDeferrability> opt_deferrable constraint_deferrability
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
 %type <tree.Expr> func_application func_expr_common_subexpr special_function
@@ -1753,6 +1809,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 
 %type <tree.Persistence> opt_temp
 %type <tree.Persistence> opt_persistence_temp_table
+%type <tree.ViewCheckOption> opt_view_check_option
 %type <bool> role_or_group_or_user
 
 %type <*tree.LabelSpec> schedule_label_spec
@@ -1777,7 +1834,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <bool> opt_or_replace opt_return_set opt_no
 %type <str> param_name routine_as
 %type <tree.RoutineParams> opt_routine_param_with_default_list routine_param_with_default_list
-%type <tree.RoutineParams> func_params func_params_list table_func_column_list
+%type <tree.RoutineParams> func_params func_params_list aggregate_params table_func_column_list
 %type <tree.RoutineParam> routine_param_with_default routine_param table_func_column
 %type <tree.ResolvableTypeReference> routine_return_type routine_param_type
 %type <tree.RoutineOptions> opt_create_routine_opt_list create_routine_opt_list alter_func_opt_list
@@ -1789,6 +1846,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Statement> routine_return_stmt routine_body_stmt
 %type <tree.Statements> routine_body_stmt_list
 %type <*tree.RoutineBody> opt_routine_body
+%type <*tree.RoutineAggregate> aggregate_attr_list aggregate_attr
 %type <tree.RoutineObj> function_with_paramtypes
 %type <tree.RoutineObjs> function_with_paramtypes_list
 %type <empty> opt_link_sym
@@ -1804,6 +1862,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %type <tree.Expr> trigger_when
 %type <str> trigger_func_arg opt_as function_or_procedure
 %type <[]string> trigger_func_args
+%type <[]string> opt_event_trigger_when event_trigger_filter_list event_trigger_tag_list
 
 %type <*tree.LabelSpec> label_spec
 
@@ -1853,7 +1912,7 @@ func (u *sqlSymUnion) doBlockOption() tree.DoBlockOption {
 %left      '|'
 %left      '#'
 %left      '&'
-%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT
+%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND NOT_EXTEND_RIGHT NOT_EXTEND_LEFT ADJACENT SQRT CBRT
 %left      OPERATOR // if changing the last token before OPERATOR, change all instances of %prec <last token>
 %left      '+' '-'
 %left      '*' '/' FLOORDIV '%'
@@ -1919,7 +1978,9 @@ stmt_without_legacy_transaction:
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
 | reindex_stmt
-| unlisten_stmt
+| listen_stmt                // EXTEND WITH HELP: LISTEN
+| notify_stmt                // EXTEND WITH HELP: NOTIFY
+| unlisten_stmt              // EXTEND WITH HELP: UNLISTEN
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
 
 // %Help: ALTER
@@ -1929,7 +1990,6 @@ alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
 | alter_virtual_cluster_stmt   /* SKIP DOC */
-| alter_unsupported_stmt
 | ALTER error         // SHOW HELP: ALTER
 
 alter_ddl_stmt:
@@ -1942,14 +2002,17 @@ alter_ddl_stmt:
 | alter_partition_stmt          // EXTEND WITH HELP: ALTER PARTITION
 | alter_schema_stmt             // EXTEND WITH HELP: ALTER SCHEMA
 | alter_type_stmt               // EXTEND WITH HELP: ALTER TYPE
+| alter_domain_stmt             // EXTEND WITH HELP: ALTER DOMAIN
 | alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
+| alter_aggregate_stmt          // EXTEND WITH HELP: ALTER AGGREGATE
 | alter_proc_stmt               // EXTEND WITH HELP: ALTER PROCEDURE
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
 | alter_policy_stmt             // EXTEND WITH HELP: ALTER POLICY
 | alter_job_stmt                // EXTEND WITH HELP: ALTER JOB
+| alter_event_trigger_stmt      // EXTEND WITH HELP: ALTER EVENT TRIGGER
 
 // %Help: ALTER TABLE - change the definition of a table
 // %Category: DDL
@@ -2149,6 +2212,43 @@ alter_func_stmt:
 | alter_func_dep_extension_stmt
 | ALTER FUNCTION error // SHOW HELP: ALTER FUNCTION
 
+// %Help: ALTER AGGREGATE - change the definition of an aggregate function
+// %Category: DDL
+// %Text:
+// ALTER AGGREGATE name ( [ argmode ] [ argname ] argtype [, ...] )
+//    RENAME TO new_name
+// ALTER AGGREGATE name ( [ argmode ] [ argname ] argtype [, ...] )
+//    OWNER TO { new_owner | CURRENT_USER | SESSION_USER }
+// ALTER AGGREGATE name ( [ argmode ] [ argname ] argtype [, ...] )
+//    SET SCHEMA new_schema
+// %SeeAlso: CREATE AGGREGATE, DROP AGGREGATE
+alter_aggregate_stmt:
+  ALTER AGGREGATE function_with_paramtypes RENAME TO name
+  {
+    $$.val = &tree.AlterRoutineRename{
+      Function: $3.functionObj(),
+      NewName: tree.Name($6),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes OWNER TO role_spec
+  {
+    $$.val = &tree.AlterRoutineSetOwner{
+      Function: $3.functionObj(),
+      NewOwner: $6.roleSpec(),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterRoutineSetSchema{
+      Function: $3.functionObj(),
+      NewSchemaName: tree.Name($6),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE error // SHOW HELP: ALTER AGGREGATE
+
 // %Help: ALTER PROCEDURE - change the definition of a procedure
 // %Category: DDL
 // %Text:
@@ -3282,6 +3382,140 @@ alter_type_stmt:
   }
 | ALTER TYPE error // SHOW HELP: ALTER TYPE
 
+// %Help: ALTER DOMAIN - change the definition of a domain.
+// %Category: DDL
+// %Text: ALTER DOMAIN <domainname> <command>
+//
+// Commands:
+//   ALTER DOMAIN ... { SET DEFAULT <expr> | DROP DEFAULT }
+//   ALTER DOMAIN ... { SET | DROP } NOT NULL
+//   ALTER DOMAIN ... ADD [CONSTRAINT <name>] CHECK (<expr>) [NOT VALID]
+//   ALTER DOMAIN ... DROP CONSTRAINT [IF EXISTS] <name> [CASCADE | RESTRICT]
+//   ALTER DOMAIN ... RENAME CONSTRAINT <name> TO <newname>
+//   ALTER DOMAIN ... VALIDATE CONSTRAINT <name>
+//   ALTER DOMAIN ... RENAME TO <newname>
+//   ALTER DOMAIN ... SET SCHEMA <newschemaname>
+//   ALTER DOMAIN ... OWNER TO {<newowner> | CURRENT_USER | SESSION_USER }
+//
+// %SeeAlso: CREATE DOMAIN, DROP DOMAIN
+alter_domain_stmt:
+  ALTER DOMAIN type_name SET DEFAULT a_expr
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{Default: $6.expr()},
+    }
+  }
+| ALTER DOMAIN type_name DROP DEFAULT
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetDefault{},
+    }
+  }
+| ALTER DOMAIN type_name SET NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: true},
+    }
+  }
+| ALTER DOMAIN type_name DROP NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{NotNull: false},
+    }
+  }
+| ALTER DOMAIN type_name ADD CHECK '(' a_expr ')' opt_validate_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Check: tree.DomainCheck{Expr: $7.expr()},
+        ValidationBehavior: $9.validationBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name ADD CONSTRAINT constraint_name CHECK '(' a_expr ')' opt_validate_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Check: tree.DomainCheck{Name: tree.Name($6), Expr: $9.expr()},
+        ValidationBehavior: $11.validationBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($6),
+        DropBehavior: $7.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT IF EXISTS constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($8),
+        IfExists: true,
+        DropBehavior: $9.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name RENAME CONSTRAINT constraint_name TO constraint_name
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainRenameConstraint{
+        Constraint: tree.Name($6),
+        NewName: tree.Name($8),
+      },
+    }
+  }
+| ALTER DOMAIN type_name VALIDATE CONSTRAINT constraint_name
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainValidateConstraint{
+        Constraint: tree.Name($6),
+      },
+    }
+  }
+| ALTER DOMAIN type_name RENAME TO name
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeRename{
+        NewName: tree.Name($6),
+      },
+    }
+  }
+| ALTER DOMAIN type_name SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeSetSchema{
+        Schema: tree.Name($6),
+      },
+    }
+  }
+| ALTER DOMAIN type_name OWNER TO role_spec
+  {
+    $$.val = &tree.AlterType{
+      Type: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeOwner{
+        Owner: $6.roleSpec(),
+      },
+    }
+  }
+| ALTER DOMAIN error // SHOW HELP: ALTER DOMAIN
+
 opt_add_val_placement:
   BEFORE SCONST
   {
@@ -3354,7 +3588,7 @@ alter_attribute_action:
 // %Help: REFRESH - recalculate a materialized view
 // %Category: Misc
 // %Text:
-// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [AS OF SYSTEM TIME <expr>>] [WITH [NO] DATA]
+// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [AS OF SYSTEM TIME <expr>>] [WITH [NO] DATA | INCREMENTAL]
 refresh_stmt:
   REFRESH MATERIALIZED VIEW opt_concurrently view_name opt_as_of_clause opt_clear_data
   {
@@ -3376,6 +3610,10 @@ opt_clear_data:
   {
     $$.val = tree.RefreshDataClear
   }
+| INCREMENTAL
+  {
+    $$.val = tree.RefreshDataIncremental
+  }
 | /* EMPTY */
   {
     $$.val = tree.RefreshDataDefault
@@ -3902,6 +4140,94 @@ drop_external_connection_stmt:
 	}
 	| DROP EXTERNAL CONNECTION error // SHOW HELP: DROP EXTERNAL CONNECTION
 
+// %Help: CREATE PUBLICATION - create a new publication
+// %Category: DDL
+// %Text:
+// CREATE PUBLICATION <name> [ FOR TABLE <tablename> [, ...] | FOR ALL TABLES ]
+//
+// A publication names a set of tables whose changes are streamed to logical
+// replication clients.
+// %SeeAlso: DROP PUBLICATION, CREATE SUBSCRIPTION
+create_publication_stmt:
+  CREATE PUBLICATION name
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3)}
+  }
+| CREATE PUBLICATION name FOR TABLE table_name_list
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3), Tables: $6.tableNames()}
+  }
+| CREATE PUBLICATION name FOR ALL TABLES
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3), AllTables: true}
+  }
+| CREATE PUBLICATION error // SHOW HELP: CREATE PUBLICATION
+
+// %Help: DROP PUBLICATION - remove a publication
+// %Category: DDL
+// %Text: DROP PUBLICATION [IF EXISTS] <name> [, ...]
+// %SeeAlso: CREATE PUBLICATION
+drop_publication_stmt:
+  DROP PUBLICATION name_list
+  {
+    $$.val = &tree.DropPublication{Names: $3.nameList()}
+  }
+| DROP PUBLICATION IF EXISTS name_list
+  {
+    $$.val = &tree.DropPublication{Names: $5.nameList(), IfExists: true}
+  }
+| DROP PUBLICATION error // SHOW HELP: DROP PUBLICATION
+
+// %Help: CREATE SUBSCRIPTION - replicate from a PostgreSQL publication
+// %Category: DDL
+// %Text:
+// CREATE SUBSCRIPTION <name> CONNECTION <conninfo> PUBLICATION <name> [, ...]
+//   [ WITH ( <option> [= <value>] [, ...] ) ]
+//
+// Conninfo:
+//   Connection URI of the upstream PostgreSQL server.
+//
+// Options:
+//   slot_name = '...'     name of the replication slot on the upstream server
+//   create_slot = 'bool'  whether to create the replication slot
+// %SeeAlso: DROP SUBSCRIPTION, CREATE PUBLICATION
+create_subscription_stmt:
+  CREATE SUBSCRIPTION name CONNECTION string_or_placeholder PUBLICATION name_list opt_with_subscription_options
+  {
+    $$.val = &tree.CreateSubscription{
+      Name: tree.Name($3),
+      Connection: $5.expr(),
+      Publications: $7.nameList(),
+      Options: $8.kvOptions(),
+    }
+  }
+| CREATE SUBSCRIPTION error // SHOW HELP: CREATE SUBSCRIPTION
+
+opt_with_subscription_options:
+  WITH '(' kv_option_list ')'
+  {
+    $$.val = $3.kvOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
+// %Help: DROP SUBSCRIPTION - remove a subscription
+// %Category: DDL
+// %Text: DROP SUBSCRIPTION [IF EXISTS] <name>
+// %SeeAlso: CREATE SUBSCRIPTION
+drop_subscription_stmt:
+  DROP SUBSCRIPTION name
+  {
+    $$.val = &tree.DropSubscription{Name: tree.Name($3)}
+  }
+| DROP SUBSCRIPTION IF EXISTS name
+  {
+    $$.val = &tree.DropSubscription{Name: tree.Name($5), IfExists: true}
+  }
+| DROP SUBSCRIPTION error // SHOW HELP: DROP SUBSCRIPTION
+
 // %Help: RESTORE - restore data from external storage
 // %Category: CCL
 // %Text:
@@ -4121,16 +4447,6 @@ import_format:
     $$ = strings.ToUpper($1)
   }
 
-alter_unsupported_stmt:
-  ALTER DOMAIN error
-  {
-    return unimplemented(sqllex, "alter domain")
-  }
-| ALTER AGGREGATE error
-  {
-    return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
-  }
-
 // %Help: IMPORT - load data from file in a distributed manner
 // %Category: CCL
 // %Text:
@@ -4707,6 +5023,8 @@ create_stmt:
 | create_external_connection_stmt // EXTEND WITH HELP: CREATE EXTERNAL CONNECTION
 | create_virtual_cluster_stmt     // EXTEND WITH HELP: CREATE VIRTUAL CLUSTER
 | create_logical_replication_stream_stmt     // EXTEND WITH HELP: CREATE LOGICAL REPLICATION STREAM
+| create_publication_stmt  // EXTEND WITH HELP: CREATE PUBLICATION
+| create_subscription_stmt // EXTEND WITH HELP: CREATE SUBSCRIPTION
 | create_schedule_stmt   // help texts in sub-rule
 | create_unsupported     {}
 | CREATE error           // SHOW HELP: CREATE
@@ -5241,6 +5559,83 @@ table_rls_mode:
     $$.val = tree.TableRLSNoForce
   }
 
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE AGGREGATE name ( { [ argmode ] [ argname ] argtype [, ...] | * } ) (
+//    SFUNC = sfunc,
+//    STYPE = state_data_type
+//    [ , FINALFUNC = ffunc ]
+//    [ , COMBINEFUNC = combinefunc ]
+//    [ , INITCOND = initial_condition ]
+// )
+// %SeeAlso: CREATE FUNCTION, DROP AGGREGATE
+create_aggregate_stmt:
+  CREATE AGGREGATE routine_create_name aggregate_params '(' aggregate_attr_list ')'
+  {
+    $$.val = &tree.CreateRoutine{
+      Name: $3.unresolvedObjectName().ToRoutineName(),
+      Params: $4.routineParams(),
+      Aggregate: $6.routineAggregate(),
+    }
+  }
+| CREATE AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+// An aggregate without arguments is declared with a '*' in place of the
+// argument list.
+aggregate_params:
+  '(' func_params_list ')'
+  {
+    $$.val = $2.routineParams()
+  }
+| '(' '*' ')'
+  {
+    $$.val = tree.RoutineParams{}
+  }
+
+aggregate_attr_list:
+  aggregate_attr
+| aggregate_attr_list ',' aggregate_attr
+  {
+    if err := $1.routineAggregate().CombineWith($3.routineAggregate()); err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = $1.routineAggregate()
+  }
+
+aggregate_attr:
+  name '=' typename
+  {
+    switch $1 {
+    case "stype":
+      $$.val = &tree.RoutineAggregate{SType: $3.typeReference()}
+    case "sfunc", "finalfunc", "combinefunc":
+      fn, ok := $3.typeReference().(*tree.UnresolvedObjectName)
+      if !ok {
+        return setErr(sqllex, pgerror.Newf(pgcode.Syntax, "invalid function name for aggregate attribute %q", $1))
+      }
+      name := fn.ToRoutineName()
+      switch $1 {
+      case "sfunc":
+        $$.val = &tree.RoutineAggregate{SFunc: &name}
+      case "finalfunc":
+        $$.val = &tree.RoutineAggregate{FinalFunc: &name}
+      default:
+        $$.val = &tree.RoutineAggregate{CombineFunc: &name}
+      }
+    default:
+      return setErr(sqllex, pgerror.Newf(pgcode.Syntax, "aggregate attribute %q not recognized", $1))
+    }
+  }
+| name '=' SCONST
+  {
+    if $1 != "initcond" {
+      return setErr(sqllex, pgerror.Newf(pgcode.Syntax, "aggregate attribute %q not recognized", $1))
+    }
+    initCond := $3
+    $$.val = &tree.RoutineAggregate{InitCond: &initCond}
+  }
+
 // %Help: CREATE FUNCTION - define a new function
 // %Category: DDL
 // %Text:
@@ -5695,6 +6090,32 @@ drop_proc_stmt:
   }
 | DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
 
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name ( [ argmode ] [ argname ] argtype [, ...] ) [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      Aggregate: true,
+      Routines: $3.routineObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Aggregate: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
 function_with_paramtypes_list:
   function_with_paramtypes
   {
@@ -6051,9 +6472,122 @@ drop_trigger_stmt:
   }
 | DROP TRIGGER error // SHOW HELP: DROP TRIGGER
 
+// %Help: CREATE EVENT TRIGGER - define a new event trigger
+// %Category: DDL
+// %Text:
+// CREATE EVENT TRIGGER name ON { ddl_command_start | ddl_command_end | sql_drop }
+//  [ WHEN TAG IN ( 'command_tag' [, ...] ) ]
+//  EXECUTE FUNCTION func_name ( )
+// %SeeAlso: DROP EVENT TRIGGER, ALTER EVENT TRIGGER
+create_event_trigger_stmt:
+  CREATE EVENT TRIGGER name ON name opt_event_trigger_when
+  EXECUTE function_or_procedure func_name '(' ')'
+  {
+    $$.val = &tree.CreateEventTrigger{
+      Name: tree.Name($4),
+      Event: tree.Name($6),
+      Tags: $7.strs(),
+      FuncName: $10.unresolvedName(),
+    }
+  }
+| CREATE EVENT TRIGGER error // SHOW HELP: CREATE EVENT TRIGGER
+
+opt_event_trigger_when:
+  WHEN event_trigger_filter_list
+  {
+    $$.val = $2.strs()
+  }
+| /* EMPTY */
+  {
+    $$.val = []string(nil)
+  }
+
+event_trigger_filter_list:
+  name IN '(' event_trigger_tag_list ')'
+  {
+    if $1 != "tag" {
+      return setErr(sqllex, pgerror.Newf(pgcode.Syntax, "unrecognized filter variable %q", $1))
+    }
+    $$.val = $4.strs()
+  }
+| event_trigger_filter_list AND name IN '(' event_trigger_tag_list ')'
+  {
+    if $3 != "tag" {
+      return setErr(sqllex, pgerror.Newf(pgcode.Syntax, "unrecognized filter variable %q", $3))
+    }
+    return setErr(sqllex, pgerror.Newf(pgcode.Syntax, "filter variable %q specified more than once", $3))
+  }
+
+event_trigger_tag_list:
+  SCONST
+  {
+    $$.val = []string{$1}
+  }
+| event_trigger_tag_list ',' SCONST
+  {
+    $$.val = append($1.strs(), $3)
+  }
+
+// %Help: DROP EVENT TRIGGER - remove an event trigger
+// %Category: DDL
+// %Text:
+// DROP EVENT TRIGGER [ IF EXISTS ] name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE EVENT TRIGGER
+drop_event_trigger_stmt:
+  DROP EVENT TRIGGER name opt_drop_behavior
+  {
+    $$.val = &tree.DropEventTrigger{
+      Name: tree.Name($4),
+      DropBehavior: $5.dropBehavior(),
+    }
+  }
+| DROP EVENT TRIGGER IF EXISTS name opt_drop_behavior
+  {
+    $$.val = &tree.DropEventTrigger{
+      Name: tree.Name($6),
+      IfExists: true,
+      DropBehavior: $7.dropBehavior(),
+    }
+  }
+| DROP EVENT TRIGGER error // SHOW HELP: DROP EVENT TRIGGER
+
+// %Help: ALTER EVENT TRIGGER - change the definition of an event trigger
+// %Category: DDL
+// %Text:
+// ALTER EVENT TRIGGER name ENABLE
+// ALTER EVENT TRIGGER name DISABLE
+// ALTER EVENT TRIGGER name RENAME TO new_name
+// ALTER EVENT TRIGGER name OWNER TO role_spec
+// %SeeAlso: CREATE EVENT TRIGGER
+alter_event_trigger_stmt:
+  ALTER EVENT TRIGGER name ENABLE
+  {
+    $$.val = &tree.AlterEventTrigger{Name: tree.Name($4), Cmd: tree.AlterEventTriggerEnable}
+  }
+| ALTER EVENT TRIGGER name DISABLE
+  {
+    $$.val = &tree.AlterEventTrigger{Name: tree.Name($4), Cmd: tree.AlterEventTriggerDisable}
+  }
+| ALTER EVENT TRIGGER name RENAME TO name
+  {
+    $$.val = &tree.AlterEventTrigger{
+      Name: tree.Name($4),
+      Cmd: tree.AlterEventTriggerRename,
+      NewName: tree.Name($7),
+    }
+  }
+| ALTER EVENT TRIGGER name OWNER TO role_spec
+  {
+    $$.val = &tree.AlterEventTrigger{
+      Name: tree.Name($4),
+      Cmd: tree.AlterEventTriggerOwner,
+      Owner: $7.roleSpec(),
+    }
+  }
+| ALTER EVENT TRIGGER error // SHOW HELP: ALTER EVENT TRIGGER
+
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
@@ -6062,10 +6596,8 @@ create_unsupported:
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
 | CREATE OPERATOR error { return unimplementedWithIssue(sqllex, 65017) }
-| CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
 | CREATE SERVER error { return unimplemented(sqllex, "create server") }
-| CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
 
@@ -6079,21 +6611,17 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
 | DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
-| DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
 | DROP SERVER error { return unimplemented(sqllex, "drop server") }
-| DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
 
 create_ddl_stmt:
@@ -6105,11 +6633,14 @@ create_ddl_stmt:
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_persistence_temp_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_domain_stmt   // EXTEND WITH HELP: CREATE DOMAIN
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
 | create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
 | create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
+| create_event_trigger_stmt // EXTEND WITH HELP: CREATE EVENT TRIGGER
 | create_policy_stmt   // EXTEND WITH HELP: CREATE POLICY
 
 // %Help: CREATE STATISTICS - create a new table statistic
@@ -6484,6 +7015,8 @@ drop_stmt:
 | drop_schedule_stmt            // EXTEND WITH HELP: DROP SCHEDULES
 | drop_external_connection_stmt // EXTEND WITH HELP: DROP EXTERNAL CONNECTION
 | drop_virtual_cluster_stmt     // EXTEND WITH HELP: DROP VIRTUAL CLUSTER
+| drop_publication_stmt         // EXTEND WITH HELP: DROP PUBLICATION
+| drop_subscription_stmt        // EXTEND WITH HELP: DROP SUBSCRIPTION
 | drop_unsupported   {}
 | DROP error                    // SHOW HELP: DROP
 
@@ -6495,9 +7028,12 @@ drop_ddl_stmt:
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_domain_stmt   // EXTEND WITH HELP: DROP DOMAIN
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
 | drop_proc_stmt     // EXTEND WITH HELP: DROP FUNCTION
 | drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
+| drop_event_trigger_stmt // EXTEND WITH HELP: DROP EVENT TRIGGER
 | drop_policy_stmt   // EXTEND WITH HELP: DROP POLICY
 
 // %Help: DROP VIEW - remove a view
@@ -6633,6 +7169,29 @@ drop_type_stmt:
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
 
+// %Help: DROP DOMAIN - remove a domain
+// %Category: DDL
+// %Text: DROP DOMAIN [IF EXISTS] <domain_name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE DOMAIN, ALTER DOMAIN
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP DOMAIN error // SHOW HELP: DROP DOMAIN
+
 // %Help: DROP VIRTUAL CLUSTER - remove a virtual cluster
 // %Category: Experimental
 // %Text: DROP VIRTUAL CLUSTER [IF EXISTS] <virtual_cluster_spec> [IMMEDIATE]
@@ -6856,6 +7415,7 @@ preparable_stmt:
 | explain_stmt   // EXTEND WITH HELP: EXPLAIN
 | import_stmt    // EXTEND WITH HELP: IMPORT
 | insert_stmt    // EXTEND WITH HELP: INSERT
+| merge_stmt     // EXTEND WITH HELP: MERGE
 | pause_stmt     // help texts in sub-rule
 | reset_stmt     // help texts in sub-rule
 | restore_stmt   // EXTEND WITH HELP: RESTORE
@@ -7633,7 +8193,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -8123,6 +8683,40 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - set when deferrable constraints are checked
+// %Category: Txn
+// %Text: SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// Only FOREIGN KEY and UNIQUE WITHOUT INDEX constraints declared DEFERRABLE
+// can be deferred. The setting lasts until the end of the transaction.
+// %SeeAlso: SET TRANSACTION, WEBDOCS/set-constraints.html
+set_constraints_stmt:
+  SET CONSTRAINTS set_constraints_list set_constraints_mode
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
+set_constraints_list:
+  ALL
+  {
+    $$.val = tree.NameList(nil)
+  }
+| name_list
+  {
+    $$.val = $1.nameList()
+  }
+
+set_constraints_mode:
+  DEFERRED
+  {
+    $$.val = true
+  }
+| IMMEDIATE
+  {
+    $$.val = false
+  }
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -11129,12 +11723,30 @@ col_qualification_elem:
       WithoutIndex: $2.bool(),
     }
   }
+| UNIQUE opt_without_index constraint_deferrability
+  {
+    if $3.constraintDeferrability().IsDeferrable() {
+      return unimplementedWithIssueDetail(sqllex, 31632, "deferrable unique column constraints")
+    }
+    $$.val = tree.UniqueConstraint{
+      WithoutIndex: $2.bool(),
+    }
+  }
 | PRIMARY KEY opt_with_storage_parameter_list
   {
     $$.val = tree.PrimaryKeyConstraint{
       StorageParams: $3.storageParams(),
     }
   }
+| PRIMARY KEY opt_with_storage_parameter_list constraint_deferrability
+  {
+    if $4.constraintDeferrability().IsDeferrable() {
+      return unimplementedWithIssueDetail(sqllex, 31632, "deferrable primary keys")
+    }
+    $$.val = tree.PrimaryKeyConstraint{
+      StorageParams: $3.storageParams(),
+    }
+  }
 | PRIMARY KEY USING HASH opt_hash_sharded_bucket_count opt_with_storage_parameter_list
 {
   $$.val = tree.ShardedPrimaryKeyConstraint{
@@ -11165,6 +11777,17 @@ col_qualification_elem:
       Match: $4.compositeKeyMatchMethod(),
     }
   }
+| REFERENCES table_name opt_name_parens key_match reference_actions constraint_deferrability
+  {
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.ColumnFKConstraint{
+      Table: name,
+      Col: tree.Name($3),
+      Actions: $5.referenceActions(),
+      Match: $4.compositeKeyMatchMethod(),
+      Deferrability: $6.constraintDeferrability(),
+    }
+  }
 | generated_as '(' a_expr ')' STORED
   {
     $$.val = &tree.ColumnComputedDef{Expr: $3.expr(), Virtual: false}
@@ -11343,6 +11966,9 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability().IsDeferrable() {
+      return unimplementedWithIssueDetail(sqllex, 31632, "deferrable check constraints")
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -11358,10 +11984,14 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrability: $8.constraintDeferrability(),
     }
   }
-| PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
+| PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list opt_deferrable
   {
+    if $8.constraintDeferrability().IsDeferrable() {
+      return unimplementedWithIssueDetail(sqllex, 31632, "deferrable primary keys")
+    }
     $$.val = &tree.UniqueConstraintTableDef{
       IndexTableDef: tree.IndexTableDef{
         Columns: $4.idxElems(),
@@ -11381,11 +12011,48 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
     }
   }
-| EXCLUDE USING error
+| EXCLUDE opt_exclusion_method '(' exclusion_elems ')' opt_deferrable opt_where_clause
+  {
+    $$.val = &tree.ExclusionConstraintTableDef{
+      Method: tree.Name($2),
+      Elems: $4.exclusionElems(),
+      Deferrability: $6.constraintDeferrability(),
+      Predicate: $7.expr(),
+    }
+  }
+
+opt_exclusion_method:
+  USING name
+  {
+    $$ = $2
+  }
+| /* EMPTY */
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    $$ = ""
+  }
+
+exclusion_elems:
+  exclusion_elem
+  {
+    $$.val = tree.ExclusionElemList{$1.exclusionElem()}
+  }
+| exclusion_elems ',' exclusion_elem
+  {
+    $$.val = append($1.exclusionElems(), $3.exclusionElem())
+  }
+
+exclusion_elem:
+  name WITH all_op
+  {
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok {
+      sqllex.Error(fmt.Sprintf("operator %s is not a comparison operator", $3.op()))
+      return 1
+    }
+    $$.val = tree.ExclusionElem{Column: tree.Name($1), Operator: op}
   }
 
 
@@ -11494,12 +12161,51 @@ create_as_col_qualification_elem:
   }
 
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.NotDeferrable
+  }
+| constraint_deferrability
+  {
+    $$.val = $1.constraintDeferrability()
+  }
+
+// NOT DEFERRABLE, the default, is accepted as a no-op. The lexer turns its NOT
+// into NOT_DEFERRABLE, so that it is not ambiguous with NOT VALID after a
+// table constraint and with NOT NULL after a column constraint.
+constraint_deferrability:
+  DEFERRABLE
+  {
+    $$.val = tree.DeferrableInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.DeferrableInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.DeferrableInitiallyDeferred
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.DeferrableInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.NotDeferrable
+  }
+| NOT_DEFERRABLE DEFERRABLE
+  {
+    $$.val = tree.NotDeferrable
+  }
+| NOT_DEFERRABLE DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.NotDeferrable
+  }
+| NOT_DEFERRABLE DEFERRABLE INITIALLY DEFERRED
+  {
+    return setErr(sqllex, pgerror.New(pgcode.Syntax, "constraint declared INITIALLY DEFERRED must be DEFERRABLE"))
+  }
 
 storing:
   COVERING
@@ -11877,11 +12583,11 @@ role_or_group_or_user:
 // %Help: CREATE VIEW - create a new view
 // %Category: DDL
 // %Text:
-// CREATE [TEMPORARY | TEMP] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source>
+// CREATE [TEMPORARY | TEMP] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source> [WITH [CASCADED | LOCAL] CHECK OPTION]
 // CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source> [WITH [NO] DATA]
 // %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
-  CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
+  CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt opt_view_check_option
   {
     name := $5.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
@@ -11891,11 +12597,12 @@ create_view_stmt:
       Persistence: $2.persistence(),
       IfNotExists: false,
       Replace: false,
+      CheckOption: $9.viewCheckOption(),
     }
   }
 // We cannot use a rule like opt_or_replace here as that would cause a conflict
 // with the opt_temp rule.
-| CREATE OR REPLACE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
+| CREATE OR REPLACE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt opt_view_check_option
   {
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
@@ -11905,9 +12612,10 @@ create_view_stmt:
       Persistence: $4.persistence(),
       IfNotExists: false,
       Replace: true,
+      CheckOption: $11.viewCheckOption(),
     }
   }
-| CREATE opt_temp opt_view_recursive VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt
+| CREATE opt_temp opt_view_recursive VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt opt_view_check_option
   {
     name := $8.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
@@ -11917,6 +12625,7 @@ create_view_stmt:
       Persistence: $2.persistence(),
       IfNotExists: true,
       Replace: false,
+      CheckOption: $12.viewCheckOption(),
     }
   }
 | CREATE MATERIALIZED VIEW view_name opt_column_list AS select_stmt opt_with_data
@@ -12119,6 +12828,24 @@ opt_view_recursive:
   /* EMPTY */ { /* no error */ }
 | RECURSIVE { return unimplemented(sqllex, "create recursive view") }
 
+opt_view_check_option:
+  WITH CHECK OPTION
+  {
+    $$.val = tree.ViewCheckOptionCascaded
+  }
+| WITH CASCADED CHECK OPTION
+  {
+    $$.val = tree.ViewCheckOptionCascaded
+  }
+| WITH LOCAL CHECK OPTION
+  {
+    $$.val = tree.ViewCheckOptionLocal
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.ViewCheckOptionNone
+  }
+
 
 // %Help: CREATE TYPE - create a type
 // %Category: DDL
@@ -12167,8 +12894,31 @@ create_type_stmt:
 | CREATE TYPE type_name '(' error         { return unimplementedWithIssueDetail(sqllex, 27793, "base") }
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
-  // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+
+// %Help: CREATE DOMAIN - create a domain
+// %Category: DDL
+// %Text:
+// CREATE DOMAIN <domain_name> [AS] <type> [<constraint> ...]
+//
+// Constraints:
+//   [CONSTRAINT <name>] { NOT NULL | NULL | CHECK (<expr>) }
+//   DEFAULT <expr>
+//   COLLATE <collation>
+//
+// %SeeAlso: ALTER DOMAIN, DROP DOMAIN
+create_domain_stmt:
+  CREATE DOMAIN type_name opt_as typename col_qual_list
+  {
+    n := &tree.CreateDomain{
+      Name: $3.unresolvedObjectName(),
+      Type: $5.typeReference(),
+    }
+    if err := n.AddQualifications($6.colQuals()); err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = n
+  }
+| CREATE DOMAIN error // SHOW HELP: CREATE DOMAIN
 
 opt_enum_val_list:
   enum_val_list
@@ -13240,7 +13990,7 @@ transaction_deferrable_mode:
   {
     $$.val = tree.Deferrable
   }
-| NOT DEFERRABLE
+| NOT_DEFERRABLE DEFERRABLE
   {
     $$.val = tree.NotDeferrable
   }
@@ -13530,6 +14280,114 @@ upsert_stmt:
   }
 | opt_with_clause UPSERT error // SHOW HELP: UPSERT
 
+// %Help: MERGE - insert, update or delete rows of a table based on a join
+// %Category: DML
+// %Text:
+// MERGE INTO <tablename> [[AS] <name>]
+//        USING <source> ON <expr>
+//        WHEN MATCHED [AND <expr>] THEN {UPDATE SET ... | DELETE | DO NOTHING}
+//        WHEN NOT MATCHED [BY TARGET] [AND <expr>] THEN
+//          {INSERT [( <colnames...> )] {VALUES ( <exprs...> ) | DEFAULT VALUES} | DO NOTHING}
+//        WHEN NOT MATCHED BY SOURCE [AND <expr>] THEN {UPDATE SET ... | DELETE | DO NOTHING}
+//        [...]
+//        [RETURNING <exprs...>]
+// %SeeAlso: INSERT, UPSERT, UPDATE, DELETE
+merge_stmt:
+  opt_with_clause MERGE INTO table_expr_opt_alias_idx USING table_ref ON a_expr merge_when_list returning_clause
+  {
+    $$.val = &tree.Merge{
+      With: $1.with(),
+      Table: $4.tblExpr(),
+      Source: $6.tblExpr(),
+      On: $8.expr(),
+      Whens: $9.mergeWhens(),
+      Returning: $10.retClause(),
+    }
+  }
+| opt_with_clause MERGE error // SHOW HELP: MERGE
+
+merge_when_list:
+  merge_when_clause
+  {
+    $$.val = tree.MergeWhens{$1.mergeWhen()}
+  }
+| merge_when_list merge_when_clause
+  {
+    $$.val = append($1.mergeWhens(), $2.mergeWhen())
+  }
+
+merge_when_clause:
+  WHEN MATCHED opt_merge_when_condition THEN merge_when_matched_action
+  {
+    when := $5.mergeWhen()
+    when.Match = tree.MergeMatched
+    when.Cond = $3.expr()
+    $$.val = when
+  }
+| WHEN NOT MATCHED BY SOURCE opt_merge_when_condition THEN merge_when_matched_action
+  {
+    when := $8.mergeWhen()
+    when.Match = tree.MergeNotMatchedBySource
+    when.Cond = $6.expr()
+    $$.val = when
+  }
+| WHEN NOT MATCHED opt_merge_when_condition THEN merge_when_not_matched_action
+  {
+    when := $6.mergeWhen()
+    when.Match = tree.MergeNotMatchedByTarget
+    when.Cond = $4.expr()
+    $$.val = when
+  }
+| WHEN NOT MATCHED BY TARGET opt_merge_when_condition THEN merge_when_not_matched_action
+  {
+    when := $8.mergeWhen()
+    when.Match = tree.MergeNotMatchedByTarget
+    when.Cond = $6.expr()
+    $$.val = when
+  }
+
+opt_merge_when_condition:
+  AND a_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+merge_when_matched_action:
+  UPDATE SET set_clause_list
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeUpdate, Exprs: $3.updateExprs()}
+  }
+| DELETE
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeDelete}
+  }
+| DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeDoNothing}
+  }
+
+merge_when_not_matched_action:
+  INSERT VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeInsert, Values: $4.exprs()}
+  }
+| INSERT '(' insert_column_list ')' VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeInsert, Columns: $3.nameList(), Values: $7.exprs()}
+  }
+| INSERT DEFAULT VALUES
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeInsert}
+  }
+| DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeDoNothing}
+  }
+
 insert_target:
   table_name_opt_idx
   {
@@ -13937,7 +14795,7 @@ simple_select:
 //        { <expr> [[AS] <name>] | [ [<dbname>.] <tablename>. ] * } [, ...]
 //        [ FROM <source> ]
 //        [ WHERE <expr> ]
-//        [ GROUP BY <expr> [ , ... ] ]
+//        [ GROUP BY <grouping_element> [ , ... ] ]
 //        [ HAVING <expr> ]
 //        [ WINDOW <name> AS ( <definition> ) ]
 //        [ { UNION | INTERSECT | EXCEPT } [ ALL | DISTINCT ] <selectclause> ]
@@ -14399,9 +15257,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.RollupGroupingSet, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.CubeGroupingSet, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.GroupingSetsGroupingSet, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -14582,6 +15449,7 @@ opt_index_flags:
 //   <source> NATURAL [ <jointype> ] JOIN <source>
 //   <source> CROSS JOIN <source>
 //   <source> WITH ORDINALITY
+//   <tablename> [ [AS] <alias> ] TABLESAMPLE { BERNOULLI | SYSTEM } ( <percent> ) [ REPEATABLE ( <seed> ) ]
 //   '[' EXPLAIN ... ']'
 //   '[' SHOW ... ']'
 //
@@ -14609,14 +15477,15 @@ table_ref:
         As:         $4.aliasClause(),
     }
   }
-| relation_expr opt_index_flags opt_ordinality opt_alias_clause
+| relation_expr opt_index_flags opt_ordinality opt_alias_clause opt_tablesample_clause
   {
     name := $1.unresolvedObjectName().ToTableName()
     $$.val = &tree.AliasedTableExpr{
-      Expr:       &name,
-      IndexFlags: $2.indexFlags(),
-      Ordinality: $3.bool(),
-      As:         $4.aliasClause(),
+      Expr:        &name,
+      IndexFlags:  $2.indexFlags(),
+      Ordinality:  $3.bool(),
+      As:          $4.aliasClause(),
+      TableSample: $5.tableSample(),
     }
   }
 | select_with_parens opt_ordinality opt_alias_clause
@@ -14783,6 +15652,35 @@ tableref_col_list:
     $$.val = append($1.tableRefCols(), tree.ColumnID($3.int64()))
   }
 
+opt_tablesample_clause:
+  TABLESAMPLE name '(' a_expr ')' opt_repeatable_clause
+  {
+    var method tree.TableSampleMethod
+    switch $2 {
+    case "bernoulli":
+      method = tree.TableSampleBernoulli
+    case "system":
+      method = tree.TableSampleSystem
+    default:
+      return setErr(sqllex, pgerror.Newf(pgcode.UndefinedObject, "tablesample method %s does not exist", $2))
+    }
+    $$.val = &tree.TableSample{Method: method, Percent: $4.expr(), Repeatable: $6.expr()}
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.TableSample)(nil)
+  }
+
+opt_repeatable_clause:
+  REPEATABLE '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
 opt_ordinality:
   WITH_LA ORDINALITY
   {
@@ -14985,7 +15883,36 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
-// UNLISTEN
+// %Help: LISTEN - listen for notifications on a channel
+// %Category: Misc
+// %Text: LISTEN <channel>
+// %SeeAlso: NOTIFY, UNLISTEN
+listen_stmt:
+  LISTEN name
+  {
+    $$.val = &tree.Listen{ChannelName: tree.Name($2)}
+  }
+| LISTEN error // SHOW HELP: LISTEN
+
+// %Help: NOTIFY - send a notification to the listeners of a channel
+// %Category: Misc
+// %Text: NOTIFY <channel> [, <payload>]
+// %SeeAlso: LISTEN, UNLISTEN
+notify_stmt:
+  NOTIFY name
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2)}
+  }
+| NOTIFY name ',' SCONST
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2), Payload: $4}
+  }
+| NOTIFY error // SHOW HELP: NOTIFY
+
+// %Help: UNLISTEN - stop listening for notifications
+// %Category: Misc
+// %Text: UNLISTEN { <channel> | * }
+// %SeeAlso: LISTEN, NOTIFY
 unlisten_stmt:
    UNLISTEN type_name
     {
@@ -14995,6 +15922,7 @@ unlisten_stmt:
       {
           $$.val = &tree.Unlisten{ ChannelName:nil, Star: true}
       }
+| UNLISTEN error // SHOW HELP: UNLISTEN
 
 
 // Given "UPDATE foo set set ...", we have to decide without looking any
@@ -15890,6 +16818,18 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Overlaps), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr NOT_EXTEND_RIGHT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.NotExtendRight), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr NOT_EXTEND_LEFT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.NotExtendLeft), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr ADJACENT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Adjacent), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr AT_AT a_expr
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
@@ -15956,6 +16896,11 @@ a_expr:
   {
     $$.val = &tree.NotExpr{Expr: $2.expr()}
   }
+| NOT_DEFERRABLE a_expr %prec NOT
+  {
+    /* SKIP DOC */
+    $$.val = &tree.NotExpr{Expr: $2.expr()}
+  }
 | a_expr LIKE a_expr
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Like), Left: $1.expr(), Right: $3.expr()}
@@ -16460,7 +17405,10 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingExpr{Exprs: $3.exprs()}
+  }
 
 func_application:
   func_application_name '(' ')'
@@ -17117,6 +18065,9 @@ all_op:
 | REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.RegIMatch) }
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
+| NOT_EXTEND_RIGHT { $$.val = treecmp.MakeComparisonOperator(treecmp.NotExtendRight) }
+| NOT_EXTEND_LEFT { $$.val = treecmp.MakeComparisonOperator(treecmp.NotExtendLeft) }
+| ADJACENT { $$.val = treecmp.MakeComparisonOperator(treecmp.Adjacent) }
 | AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
 | DISTANCE { $$.val = treebin.MakeBinaryOperator(treebin.Distance) }
 | COS_DISTANCE { $$.val = treebin.MakeBinaryOperator(treebin.CosDistance) }
@@ -18094,6 +19045,7 @@ unreserved_keyword:
 | CAPABILITIES
 | CAPABILITY
 | CASCADE
+| CASCADED
 | CHANGEFEED
 | CHECK_FILES
 | CLOSE
@@ -18159,6 +19111,7 @@ unreserved_keyword:
 | ENUM
 | ENUMS
 | ESCAPE
+| EVENT
 | EXCLUDE
 | EXCLUDING
 | EXECUTE
@@ -18256,6 +19209,7 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGICAL
@@ -18265,6 +19219,7 @@ unreserved_keyword:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -18298,6 +19253,7 @@ unreserved_keyword:
 | NO
 | NORMAL
 | NOTHING
+| NOTIFY
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
@@ -18485,6 +19441,7 @@ unreserved_keyword:
 | SYSTEM
 | TABLES
 | TABLESPACE
+| TARGET
 | TEMP
 | TEMPLATE
 | TEMPORARY
@@ -18604,6 +19561,7 @@ bare_label_keywords:
 | CAPABILITIES
 | CAPABILITY
 | CASCADE
+| CASCADED
 | CASE
 | CAST
 | CHANGEFEED
@@ -18694,6 +19652,7 @@ bare_label_keywords:
 | ENUM
 | ENUMS
 | ESCAPE
+| EVENT
 | EXCLUDE
 | EXCLUDING
 | EXECUTE
@@ -18825,6 +19784,7 @@ bare_label_keywords:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCALITY
 | LOCALTIME
@@ -18836,6 +19796,7 @@ bare_label_keywords:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -18883,6 +19844,7 @@ bare_label_keywords:
 | NOSQLLOGIN
 | NOT
 | NOTHING
+| NOTIFY
 | NOTHING_AFTER_RETURNING
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
@@ -19080,7 +20042,9 @@ bare_label_keywords:
 | SYSTEM
 | TABLE
 | TABLES
+| TABLESAMPLE
 | TABLESPACE
+| TARGET
 | TEMP
 | TEMPLATE
 | TEMPORARY
@@ -19266,6 +20230,7 @@ type_func_name_no_crdb_extra_keyword:
 | OVERLAPS
 | RIGHT
 | SIMILAR
+| TABLESAMPLE
 
 // CockroachDB-specific keywords that can be used in type/function
 // identifiers.
@@ -972,6 +972,8 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		condeferrable := tree.DBoolFalse
+		condeferred := tree.DBoolFalse
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -1043,6 +1045,8 @@ func populateTableConstraints(
 			if r, ok := fkMatchMap[fk.Match()]; ok {
 				confmatchtype = r
 			}
+			condeferrable = tree.MakeDBool(tree.DBool(fk.IsDeferrable()))
+			condeferred = tree.MakeDBool(tree.DBool(fk.IsInitiallyDeferred()))
 			if conkey, err = colIDArrayToDatum(fk.ForeignKeyDesc().OriginColumnIDs); err != nil {
 				return err
 			}
@@ -1065,13 +1069,26 @@ func populateTableConstraints(
 			conoid = h.UniqueWithoutIndexConstraintOid(
 				db.GetID(), sc.GetID(), table.GetID(), uwoi,
 			)
-			f.WriteString("UNIQUE WITHOUT INDEX (")
-			colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
-			if err != nil {
-				return err
+			if uwoi.IsExclusion() {
+				contype = conTypeExclusion
+				if err := showExclusionConstraintElems(&f.Buffer, table, uwoi); err != nil {
+					return err
+				}
+			} else {
+				f.WriteString("UNIQUE WITHOUT INDEX (")
+				colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
+				if err != nil {
+					return err
+				}
+				f.WriteString(strings.Join(colNames, ", "))
+				f.WriteByte(')')
 			}
-			f.WriteString(strings.Join(colNames, ", "))
-			f.WriteByte(')')
+			if uwoi.IsDeferrable() {
+				f.WriteByte(' ')
+				f.WriteString(tree.MakeConstraintDeferrability(uwoi.IsDeferrable(), uwoi.IsInitiallyDeferred()).String())
+			}
+			condeferrable = tree.MakeDBool(tree.DBool(uwoi.IsDeferrable()))
+			condeferred = tree.MakeDBool(tree.DBool(uwoi.IsInitiallyDeferred()))
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1107,8 +1124,8 @@ func populateTableConstraints(
 			dNameOrNull(c.GetName()), // conname
 			namespaceOid,             // connamespace
 			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			condeferrable,            // condeferrable
+			condeferred,              // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -1875,14 +1892,40 @@ https://www.postgresql.org/docs/9.5/catalog-pg-enum.html`,
 }
 
 var pgCatalogEventTriggerTable = virtualSchemaTable{
-	comment: `event triggers (empty - feature does not exist)
+	comment: `event triggers
 https://www.postgresql.org/docs/9.6/catalog-pg-event-trigger.html`,
 	schema: vtable.PGCatalogEventTrigger,
-	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Event triggers are not currently supported.
-		return nil
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(ctx context.Context, db catalog.DatabaseDescriptor) error {
+				return db.ForEachEventTrigger(func(name string, trig descpb.DatabaseDescriptor_EventTriggerInfo) error {
+					enabled := tree.NewDString("O")
+					if trig.Disabled {
+						enabled = tree.NewDString("D")
+					}
+					tags := tree.DNull
+					if len(trig.Tags) > 0 {
+						arr := tree.NewDArray(types.String)
+						for _, tag := range trig.Tags {
+							if err := arr.Append(tree.NewDString(tag)); err != nil {
+								return err
+							}
+						}
+						tags = arr
+					}
+					return addRow(
+						tree.NewDName(name),                          // evtname
+						tree.NewDName(trig.Event),                    // evtevent
+						h.UserOid(trig.OwnerProto.Decode()),          // evtowner
+						tree.NewDOid(catid.FuncIDToOID(trig.FuncID)), // evtfoid
+						enabled,                             // evtenabled
+						tags,                                // evttags
+						h.EventTriggerOid(db.GetID(), name), // oid
+					)
+				})
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogExtensionTable = virtualSchemaTable{
@@ -2676,6 +2719,8 @@ func addPgProcUDFRow(
 	kind := proKindFunction
 	if fnDesc.IsProcedure() {
 		kind = proKindProcedure
+	} else if fnDesc.IsAggregate() {
+		kind = proKindAggregate
 	}
 
 	lang := languageInternalOid
@@ -2892,16 +2937,27 @@ https://www.postgresql.org/docs/16/catalog-pg-proc.html`,
 }
 
 var pgCatalogRangeTable = virtualSchemaTable{
-	comment: `range types (empty - feature does not exist)
+	comment: `range types
 https://www.postgresql.org/docs/9.5/catalog-pg-range.html`,
 	schema: vtable.PGCatalogRange,
 	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// We currently do not support any range types, so this table is empty.
-		// This table should be populated when any range types are added to
-		// oidToDatum (and therefore pg_type).
+		// Only the built-in range types are supported, none of which have a
+		// collation, a custom operator class, or canonical and subtype
+		// difference functions.
+		for _, typ := range types.RangeTypes {
+			if err := addRow(
+				tree.NewDOid(typ.Oid()),                // rngtypid
+				tree.NewDOid(typ.RangeSubtype().Oid()), // rngsubtype
+				oidZero,                                // rngcollation
+				oidZero,                                // rngsubopc
+				oidZero,                                // rngcanonical
+				oidZero,                                // rngsubdiff
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogRewriteTable = virtualSchemaTable{
@@ -3298,17 +3354,16 @@ https://www.postgresql.org/docs/9.5/catalog-pg-trigger.html`,
 }
 
 var (
-	typTypeBase      = tree.NewDString("b")
-	typTypeComposite = tree.NewDString("c")
-	typTypeDomain    = tree.NewDString("d")
-	typTypeEnum      = tree.NewDString("e")
-	typTypePseudo    = tree.NewDString("p")
-	typTypeRange     = tree.NewDString("r")
+	typTypeBase       = tree.NewDString("b")
+	typTypeComposite  = tree.NewDString("c")
+	typTypeDomain     = tree.NewDString("d")
+	typTypeEnum       = tree.NewDString("e")
+	typTypePseudo     = tree.NewDString("p")
+	typTypeRange      = tree.NewDString("r")
+	typTypeMultirange = tree.NewDString("m")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -3330,7 +3385,6 @@ var (
 	// Avoid unused warning for constants.
 	_ = typCategoryEnum
 	_ = typCategoryGeometric
-	_ = typCategoryRange
 	_ = typCategoryBitString
 
 	commaTypDelim = tree.NewDString(",")
@@ -3436,6 +3490,12 @@ func addPGTypeRow(
 		if isUDT {
 			typrelid = tree.NewDOid(typ.Oid())
 		}
+	case types.RangeFamily:
+		typType = typTypeRange
+		typArray = tree.NewDOid(types.CalcArrayOid(typ))
+	case types.MultirangeFamily:
+		typType = typTypeMultirange
+		typArray = tree.NewDOid(types.CalcArrayOid(typ))
 	case types.VoidFamily:
 		// void does not have an array type.
 	case types.TriggerFamily:
@@ -3452,6 +3512,19 @@ func addPGTypeRow(
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typDefault := tree.DNull
+	if typ.IsDomain() {
+		typType = typTypeDomain
+		typBaseType = tree.NewDOid(typ.DomainBaseType().Oid())
+		if domain := typ.TypeMeta.DomainData; domain != nil {
+			typNotNull = tree.MakeDBool(tree.DBool(domain.NotNull))
+			if domain.DefaultExpr != nil {
+				typDefault = tree.NewDString(*domain.DefaultExpr)
+			}
+		}
+	}
 	typname := typ.PGName()
 	typDelim := tree.NewDString(typ.Delimiter())
 	return addRow(
@@ -3481,13 +3554,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -4358,12 +4431,28 @@ var pgCatalogStatUserFunctionsTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTable = virtualSchemaTable{
-	comment: "pg_publication was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublication,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `publications for logical replication
+https://www.postgresql.org/docs/16/catalog-pg-publication.html`,
+	schema: vtable.PgCatalogPublication,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(ctx context.Context, db catalog.DatabaseDescriptor) error {
+				return db.ForEachPublication(func(name string, pub descpb.DatabaseDescriptor_PublicationInfo) error {
+					return addRow(
+						h.PublicationOid(db.GetID(), name),        // oid
+						tree.NewDName(name),                       // pubname
+						h.UserOid(pub.OwnerProto.Decode()),        // pubowner
+						tree.MakeDBool(tree.DBool(pub.AllTables)), // puballtables
+						tree.DBoolTrue,                            // pubinsert
+						tree.DBoolTrue,                            // pubupdate
+						tree.DBoolTrue,                            // pubdelete
+						tree.DBoolFalse,                           // pubtruncate
+						tree.DBoolFalse,                           // pubviaroot
+					)
+				})
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAmprocTable = virtualSchemaTable{
@@ -4413,12 +4502,12 @@ https://www.postgresql.org/docs/14/view-pg-cursors.html`,
 				return err
 			}
 			if err := addRow(
-				tree.NewDString(string(name)),          /* name */
-				tree.NewDString(c.statement),           /* statement */
-				tree.MakeDBool(tree.DBool(c.withHold)), /* is_holdable */
-				tree.DBoolFalse,                        /* is_binary */
-				tree.DBoolFalse,                        /* is_scrollable */
-				tz,                                     /* creation_date */
+				tree.NewDString(string(name)),                     /* name */
+				tree.NewDString(c.statement),                      /* statement */
+				tree.MakeDBool(tree.DBool(c.withHold)),            /* is_holdable */
+				tree.MakeDBool(tree.DBool(c.binary)),              /* is_binary */
+				tree.MakeDBool(tree.DBool(c.scrollRows() != nil)), /* is_scrollable */
+				tz, /* creation_date */
 			); err != nil {
 				return err
 			}
@@ -4518,12 +4607,19 @@ var pgCatalogStatXactUserTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTablesTable = virtualSchemaTable{
-	comment: "pg_publication_tables was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationTables,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables published by each publication
+https://www.postgresql.org/docs/16/view-pg-publication-tables.html`,
+	schema: vtable.PgCatalogPublicationTables,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachPublicationTable(ctx, p, dbContext, false, /* explicitOnly */
+			func(db catalog.DatabaseDescriptor, name string, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				return addRow(
+					tree.NewDName(name),            // pubname
+					tree.NewDName(sc.GetName()),    // schemaname
+					tree.NewDName(table.GetName()), // tablename
+				)
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatProgressClusterTable = virtualSchemaTable{
@@ -4830,12 +4926,55 @@ var pgCatalogStatReplicationTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationRelTable = virtualSchemaTable{
-	comment: "pg_publication_rel was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationRel,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables explicitly added to publications
+https://www.postgresql.org/docs/16/catalog-pg-publication-rel.html`,
+	schema: vtable.PgCatalogPublicationRel,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachPublicationTable(ctx, p, dbContext, true, /* explicitOnly */
+			func(db catalog.DatabaseDescriptor, name string, _ catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				return addRow(
+					h.PublicationRelOid(db.GetID(), name, table.GetID()), // oid
+					h.PublicationOid(db.GetID(), name),                   // prpubid
+					tableOid(table.GetID()),                              // prrelid
+				)
+			})
 	},
-	unimplemented: true,
+}
+
+// forEachPublicationTable calls fn for each table published by each
+// publication in the databases visible to the user. If explicitOnly is set,
+// only the tables explicitly added to a publication are visited, as opposed to
+// the tables of FOR ALL TABLES publications.
+func forEachPublicationTable(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	explicitOnly bool,
+	fn func(db catalog.DatabaseDescriptor, name string, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error,
+) error {
+	return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+		func(ctx context.Context, db catalog.DatabaseDescriptor) error {
+			return db.ForEachPublication(func(name string, pub descpb.DatabaseDescriptor_PublicationInfo) error {
+				if explicitOnly && pub.AllTables {
+					return nil
+				}
+				tables, err := publicationTables(ctx, p.Descriptors(), p.txn, db, pub)
+				if err != nil {
+					return err
+				}
+				for _, table := range tables {
+					sc, err := p.Descriptors().ByIDWithLeased(p.txn).Get().Schema(ctx, table.GetParentSchemaID())
+					if err != nil {
+						return err
+					}
+					if err := fn(db, name, sc, table); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		})
 }
 
 var pgCatalogAvailableExtensionVersionsTable = virtualSchemaTable{
@@ -4859,7 +4998,7 @@ func typLen(typ *types.T) *tree.DInt {
 }
 
 func typByVal(typ *types.T) tree.Datum {
-	if typ.Identical(types.Trigger) {
+	if typ.Family() == types.TriggerFamily {
 		return tree.DBoolTrue
 	}
 	_, variable := tree.DatumTypeSize(typ)
@@ -4915,6 +5054,8 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.PGLSNFamily:       typCategoryUserDefined,
 	types.PGVectorFamily:    typCategoryUserDefined,
 	types.RefCursorFamily:   typCategoryUserDefined,
+	types.RangeFamily:       typCategoryRange,
+	types.MultirangeFamily:  typCategoryRange,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
 	types.UnknownFamily:     typCategoryUnknown,
@@ -5135,6 +5276,9 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	publicationTypeTag
+	publicationRelTypeTag
+	eventTriggerTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -5334,6 +5478,28 @@ func (h oidHasher) CastOid(srcID oid.Oid, tgtID oid.Oid) *tree.DOid {
 	return h.getOid()
 }
 
+func (h oidHasher) PublicationOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(publicationTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) EventTriggerOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(eventTriggerTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) PublicationRelOid(dbID descpb.ID, name string, tableID descpb.ID) *tree.DOid {
+	h.writeTypeTag(publicationRelTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	h.writeTable(tableID)
+	return h.getOid()
+}
+
 func funcVolatility(v catpb.Function_Volatility) string {
 	switch v {
 	case catpb.Function_IMMUTABLE:
@@ -0,0 +1,154 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgnotify
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// Listener holds the channels a session listens on and queues the
+// notifications sent on them until the session delivers them to its client.
+type Listener struct {
+	registry *Registry
+	// wake is called when a notification is queued, to ask the session to
+	// deliver the queue. It is not called again until the queue is drained,
+	// or MaybeWake is called.
+	wake func()
+
+	mu struct {
+		syncutil.Mutex
+		channels map[string]struct{}
+		queue    []Notification
+		// woken is set if wake has been called since the queue was last
+		// drained.
+		woken  bool
+		closed bool
+	}
+}
+
+// NewListener creates a Listener which does not listen on any channel.
+func (r *Registry) NewListener(wake func()) *Listener {
+	l := &Listener{registry: r, wake: wake}
+	l.mu.channels = make(map[string]struct{})
+	return l
+}
+
+// Listen starts listening on the channel.
+func (l *Listener) Listen(channel string) {
+	l.mu.Lock()
+	if l.mu.closed {
+		l.mu.Unlock()
+		return
+	}
+	l.mu.channels[channel] = struct{}{}
+	first := len(l.mu.channels) == 1
+	l.mu.Unlock()
+	// The registry locks listeners while it dispatches notifications, so it
+	// must not be called with l.mu held.
+	if first {
+		l.registry.register(l)
+	}
+}
+
+// Unlisten stops listening on the channel. Queued notifications for the
+// channel are discarded.
+func (l *Listener) Unlisten(channel string) {
+	l.mu.Lock()
+	if _, ok := l.mu.channels[channel]; !ok {
+		l.mu.Unlock()
+		return
+	}
+	delete(l.mu.channels, channel)
+	queue := l.mu.queue[:0]
+	for _, n := range l.mu.queue {
+		if n.Channel != channel {
+			queue = append(queue, n)
+		}
+	}
+	l.mu.queue = queue
+	last := len(l.mu.channels) == 0
+	l.mu.Unlock()
+	if last {
+		l.registry.unregister(l)
+	}
+}
+
+// UnlistenAll stops listening on all channels and discards the queue.
+func (l *Listener) UnlistenAll() {
+	l.mu.Lock()
+	l.mu.channels = make(map[string]struct{})
+	l.mu.queue = nil
+	l.mu.Unlock()
+	l.registry.unregister(l)
+}
+
+// Channels returns the channels the listener listens on, in sorted order.
+func (l *Listener) Channels() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	channels := make([]string, 0, len(l.mu.channels))
+	for c := range l.mu.channels {
+		channels = append(channels, c)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// Drain returns the queued notifications and empties the queue.
+func (l *Listener) Drain() []Notification {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	queue := l.mu.queue
+	l.mu.queue = nil
+	l.mu.woken = false
+	l.registry.metrics.Delivered.Inc(int64(len(queue)))
+	return queue
+}
+
+// MaybeWake calls wake if notifications are queued. Sessions call it when
+// they become able to deliver notifications which arrived while they could
+// not, such as at the end of a transaction.
+func (l *Listener) MaybeWake() {
+	l.mu.Lock()
+	wake := len(l.mu.queue) > 0 && !l.mu.closed
+	l.mu.woken = wake
+	l.mu.Unlock()
+	if wake {
+		l.wake()
+	}
+}
+
+// Close stops listening on all channels. The listener must not be used
+// afterwards.
+func (l *Listener) Close() {
+	l.mu.Lock()
+	l.mu.closed = true
+	l.mu.Unlock()
+	l.UnlistenAll()
+}
+
+// push queues a notification if the listener listens on its channel.
+func (l *Listener) push(n Notification) {
+	l.mu.Lock()
+	if _, ok := l.mu.channels[n.Channel]; !ok || l.mu.closed {
+		l.mu.Unlock()
+		return
+	}
+	if len(l.mu.queue) >= int(MaxQueueSize.Get(&l.registry.settings.SV)) {
+		l.mu.Unlock()
+		l.registry.metrics.Dropped.Inc(1)
+		return
+	}
+	l.mu.queue = append(l.mu.queue, n)
+	wake := !l.mu.woken
+	l.mu.woken = true
+	l.mu.Unlock()
+	if wake {
+		l.wake()
+	}
+}
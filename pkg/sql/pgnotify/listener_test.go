@@ -0,0 +1,147 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgnotify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListener(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	MaxQueueSize.Override(ctx, &st.SV, 2)
+	r := NewRegistry(
+		log.MakeTestingAmbientCtxWithNewTracer(), st, keys.SystemSQLCodec,
+		hlc.NewClockForTesting(nil), nil /* stopper */, nil /* db */, nil, /* rangeFeedFactory */
+	)
+	// Pretend the rangefeed is running; notifications are added directly.
+	r.mu.rangeFeedStarted = true
+
+	var wakes int
+	l := r.NewListener(func() { wakes++ })
+	ts := hlc.Timestamp{WallTime: 1}
+	dispatch := func(channel, payload string) {
+		ts = ts.Next()
+		r.addPending(ts, roachpb.Key(payload), 1, Notification{Channel: channel, Payload: payload, PID: 1})
+		r.advanceFrontier(ts)
+	}
+
+	// Notifications on channels the listener does not listen on are ignored.
+	dispatch("a", "1")
+	require.Empty(t, l.Drain())
+	require.Zero(t, wakes)
+
+	l.Listen("b")
+	l.Listen("a")
+	require.Equal(t, []string{"a", "b"}, l.Channels())
+
+	// Wakes are coalesced until the queue is drained, and notifications which
+	// do not fit in the queue are dropped.
+	dispatch("a", "1")
+	dispatch("b", "2")
+	dispatch("a", "3")
+	require.Equal(t, 1, wakes)
+	require.Equal(t, int64(1), r.Metrics().Dropped.Count())
+	require.Equal(t, []Notification{
+		{Channel: "a", Payload: "1", PID: 1},
+		{Channel: "b", Payload: "2", PID: 1},
+	}, l.Drain())
+	require.Equal(t, int64(2), r.Metrics().Delivered.Count())
+
+	// Values at or below the frontier are replays and are not dispatched again.
+	r.addPending(ts, roachpb.Key("replay"), 1, Notification{Channel: "a", Payload: "replay"})
+	r.advanceFrontier(ts.Next())
+	require.Empty(t, l.Drain())
+
+	// MaybeWake wakes the session again if notifications are still queued.
+	dispatch("a", "4")
+	require.Equal(t, 2, wakes)
+	l.MaybeWake()
+	require.Equal(t, 3, wakes)
+
+	// Unlisten discards the queued notifications of the channel.
+	dispatch("b", "5")
+	l.Unlisten("a")
+	require.Equal(t, []string{"b"}, l.Channels())
+	require.Equal(t, []Notification{{Channel: "b", Payload: "5", PID: 1}}, l.Drain())
+
+	l.Close()
+	require.Empty(t, l.Channels())
+	dispatch("b", "6")
+	require.Empty(t, l.Drain())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	require.Empty(t, r.mu.listeners)
+}
+
+func TestRegistryCommitOrder(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	st := cluster.MakeTestingClusterSettings()
+	r := NewRegistry(
+		log.MakeTestingAmbientCtxWithNewTracer(), st, keys.SystemSQLCodec,
+		hlc.NewClockForTesting(nil), nil /* stopper */, nil /* db */, nil, /* rangeFeedFactory */
+	)
+	r.mu.rangeFeedStarted = true
+	l := r.NewListener(func() {})
+	l.Listen("a")
+
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	add := func(wallTime int64, key string, seq int64, payload string) {
+		r.addPending(ts(wallTime), roachpb.Key(key), seq, Notification{Channel: "a", Payload: payload})
+	}
+	payloads := func() []string {
+		var res []string
+		for _, n := range l.Drain() {
+			res = append(res, n.Payload)
+		}
+		return res
+	}
+
+	// The rangefeed emits values out of timestamp order across ranges. The
+	// ids are random, so the values of a transaction are delivered in the
+	// order of their seq column, not in key order.
+	add(30, "a", 2, "txn3-2")
+	add(20, "a", 2, "txn2-2")
+	add(30, "b", 1, "txn3-1")
+	add(10, "a", 1, "txn1-1")
+	add(20, "b", 1, "txn2-1")
+
+	// Nothing is dispatched until the frontier passes the commit timestamp.
+	r.advanceFrontier(ts(5))
+	require.Empty(t, payloads())
+
+	r.advanceFrontier(ts(20))
+	require.Equal(t, []string{"txn1-1", "txn2-1", "txn2-2"}, payloads())
+
+	// Values emitted again after a rangefeed restart are only dispatched once,
+	// whether they are above or below the frontier.
+	add(30, "b", 1, "txn3-1")
+	add(20, "b", 1, "txn2-1")
+	add(40, "a", 1, "txn4-1")
+	r.advanceFrontier(ts(30))
+	require.Equal(t, []string{"txn3-1", "txn3-2"}, payloads())
+
+	// The frontier doesn't regress.
+	r.advanceFrontier(ts(25))
+	require.Empty(t, payloads())
+	r.advanceFrontier(ts(40))
+	require.Equal(t, []string{"txn4-1"}, payloads())
+	l.Close()
+}
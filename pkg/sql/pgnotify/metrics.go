@@ -0,0 +1,47 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgnotify
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+)
+
+var (
+	metaNotificationsDelivered = metric.Metadata{
+		Name:        "sql.notifications.delivered",
+		Help:        "Number of notifications delivered to listening sessions",
+		Measurement: "Notifications",
+		Unit:        metric.Unit_COUNT,
+		MetricType:  io_prometheus_client.MetricType_COUNTER,
+	}
+	metaNotificationsDropped = metric.Metadata{
+		Name: "sql.notifications.dropped",
+		Help: "Number of notifications dropped because the notification queue of " +
+			"a listening session was full",
+		Measurement: "Notifications",
+		Unit:        metric.Unit_COUNT,
+		MetricType:  io_prometheus_client.MetricType_COUNTER,
+	}
+)
+
+// Metrics is a metric.Struct which holds the metrics of LISTEN and NOTIFY.
+type Metrics struct {
+	Delivered *metric.Counter
+	Dropped   *metric.Counter
+}
+
+// MetricStruct makes Metrics a metric.Struct.
+func (m *Metrics) MetricStruct() {}
+
+var _ metric.Struct = (*Metrics)(nil)
+
+func makeMetrics() Metrics {
+	return Metrics{
+		Delivered: metric.NewCounter(metaNotificationsDelivered),
+		Dropped:   metric.NewCounter(metaNotificationsDropped),
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package pgnotify delivers the notifications sent by NOTIFY and pg_notify to
+// the sessions which LISTEN on their channels.
+//
+// NOTIFY writes the notification to system.notifications in the transaction
+// of the statement, so notifications are only sent if that transaction
+// commits. Each node on which a session listens watches system.notifications
+// with a rangefeed and queues the notifications for the channels its
+// sessions listen on. Notifications are held until the resolved timestamp of
+// the rangefeed passes their commit timestamp, so they are delivered in
+// commit order; the notifications of a transaction are delivered in the
+// order it sent them, which is recorded in their seq column. The ids of the
+// rows are random, so that notifications don't all go to the same range.
+// Rows are deleted once they are older than sql.notifications.retention.
+package pgnotify
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// MaxQueueSize is the maximum number of notifications queued for a session.
+var MaxQueueSize = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.notifications.max_queue_size",
+	"the maximum number of notifications queued for delivery to a listening session; "+
+		"notifications which arrive while the queue is full are dropped",
+	1000,
+	settings.PositiveInt,
+	settings.WithPublic,
+)
+
+// retention is how long notifications are kept in system.notifications.
+var retention = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.notifications.retention",
+	"the amount of time notifications are kept in system.notifications",
+	10*time.Minute,
+	settings.PositiveDuration,
+)
+
+const (
+	// gcInterval is how often old notifications are deleted.
+	gcInterval = time.Minute
+	// gcBatchSize is the number of notifications deleted per statement.
+	gcBatchSize = 1000
+)
+
+// Notification is a notification sent on a channel.
+type Notification struct {
+	Channel string
+	Payload string
+	// PID is the backend PID of the session which sent the notification.
+	PID int32
+}
+
+// pendingNotification is a notification which has been received from the
+// rangefeed, but not dispatched yet since the rangefeed may still emit
+// notifications which committed before it.
+type pendingNotification struct {
+	ts  hlc.Timestamp
+	seq int64
+	key roachpb.Key
+	n   Notification
+}
+
+// Registry dispatches the notifications written to system.notifications to
+// the listeners on this node.
+type Registry struct {
+	ambientCtx       log.AmbientContext
+	settings         *cluster.Settings
+	codec            keys.SQLCodec
+	clock            *hlc.Clock
+	stopper          *stop.Stopper
+	db               isql.DB
+	rangeFeedFactory *rangefeed.Factory
+	metrics          Metrics
+	decoder          valueside.Decoder
+
+	// tableIDResolver is set by Start.
+	tableIDResolver catalog.SystemTableIDResolver
+
+	mu struct {
+		syncutil.Mutex
+		// rangeFeedStarted is set once the rangefeed on system.notifications
+		// has been started. The rangefeed is started when a session first
+		// listens on this node and runs until the node stops.
+		rangeFeedStarted bool
+		// frontier is the resolved timestamp of the rangefeed. Values at or
+		// below it have already been dispatched.
+		frontier hlc.Timestamp
+		// pending are the notifications above the frontier, in the order
+		// they were received.
+		pending   []pendingNotification
+		listeners map[*Listener]struct{}
+	}
+}
+
+// NewRegistry creates a Registry.
+func NewRegistry(
+	ambientCtx log.AmbientContext,
+	st *cluster.Settings,
+	codec keys.SQLCodec,
+	clock *hlc.Clock,
+	stopper *stop.Stopper,
+	db isql.DB,
+	rangeFeedFactory *rangefeed.Factory,
+) *Registry {
+	r := &Registry{
+		ambientCtx:       ambientCtx,
+		settings:         st,
+		codec:            codec,
+		clock:            clock,
+		stopper:          stopper,
+		db:               db,
+		rangeFeedFactory: rangeFeedFactory,
+		metrics:          makeMetrics(),
+		decoder:          valueside.MakeDecoder(systemschema.NotificationsTable.PublicColumns()),
+	}
+	r.mu.listeners = make(map[*Listener]struct{})
+	return r
+}
+
+// Metrics returns the metrics of the registry.
+func (r *Registry) Metrics() *Metrics {
+	return &r.metrics
+}
+
+// Start starts the periodic deletion of old notifications.
+func (r *Registry) Start(ctx context.Context, tableIDResolver catalog.SystemTableIDResolver) {
+	r.tableIDResolver = tableIDResolver
+	_ = r.stopper.RunAsyncTask(ctx, "notifications-gc", r.gcLoop)
+}
+
+func (r *Registry) gcLoop(ctx context.Context) {
+	ctx, cancel := r.stopper.WithCancelOnQuiesce(ctx)
+	defer cancel()
+	var timer timeutil.Timer
+	defer timer.Stop()
+	for {
+		timer.Reset(gcInterval)
+		select {
+		case <-timer.C:
+			timer.Read = true
+		case <-ctx.Done():
+			return
+		}
+		if !r.settings.Version.IsActive(ctx, clusterversion.V25_2_AddNotificationsTable) {
+			continue
+		}
+		if err := r.deleteOldNotifications(ctx); err != nil && ctx.Err() == nil {
+			log.Warningf(ctx, "failed to delete old notifications: %v", err)
+		}
+	}
+}
+
+// deleteOldNotifications deletes the notifications which are older than
+// sql.notifications.retention.
+func (r *Registry) deleteOldNotifications(ctx context.Context) error {
+	cutoff := r.clock.PhysicalTime().Add(-retention.Get(&r.settings.SV))
+	for {
+		n, err := r.db.Executor().ExecEx(
+			ctx, "delete-old-notifications", nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.notifications WHERE created < $1 LIMIT $2`,
+			cutoff, gcBatchSize,
+		)
+		if err != nil || n < gcBatchSize {
+			return err
+		}
+	}
+}
+
+// register adds a listener which listens on at least one channel, starting
+// the rangefeed if needed.
+func (r *Registry) register(l *Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.listeners[l] = struct{}{}
+	if r.mu.rangeFeedStarted {
+		return
+	}
+	r.mu.rangeFeedStarted = true
+	// Notifications committed before the rangefeed starts are not delivered.
+	// This is fine, since the listener was registered after they committed.
+	startTS := r.clock.Now()
+	r.mu.frontier = startTS
+	ctx := r.ambientCtx.AnnotateCtx(context.Background())
+	if err := r.stopper.RunAsyncTask(ctx, "notifications-rangefeed", func(ctx context.Context) {
+		if err := r.startRangeFeed(ctx, startTS); err != nil {
+			log.Warningf(ctx, "failed to start notifications rangefeed: %v", err)
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.mu.rangeFeedStarted = false
+		}
+	}); err != nil {
+		r.mu.rangeFeedStarted = false
+	}
+}
+
+// unregister removes a listener which no longer listens on any channel.
+func (r *Registry) unregister(l *Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.mu.listeners, l)
+}
+
+func (r *Registry) startRangeFeed(ctx context.Context, startTS hlc.Timestamp) error {
+	if r.tableIDResolver == nil {
+		return errors.AssertionFailedf("notifications registry was not started")
+	}
+	tableID, err := r.tableIDResolver.LookupSystemTableID(
+		ctx, string(catconstants.NotificationsTableName),
+	)
+	if err != nil {
+		return err
+	}
+	// Only watch the primary index, so each notification is seen once.
+	prefix := r.codec.IndexPrefix(
+		uint32(tableID), uint32(systemschema.NotificationsTable.GetPrimaryIndexID()),
+	)
+	span := roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()}
+	var alloc tree.DatumAlloc
+	onValue := func(ctx context.Context, kv *kvpb.RangeFeedValue) {
+		// Deletions of old notifications are not of interest.
+		if !kv.Value.IsPresent() {
+			return
+		}
+		n, seq, err := r.decodeNotification(kv, &alloc)
+		if err != nil {
+			log.Warningf(ctx, "failed to decode notification %s: %v", kv.Key, err)
+			return
+		}
+		r.addPending(kv.Value.Timestamp, kv.Key, seq, n)
+	}
+	_, err = r.rangeFeedFactory.RangeFeed(
+		ctx, "notifications", []roachpb.Span{span}, startTS, onValue,
+		rangefeed.WithSystemTablePriority(),
+		rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+			r.advanceFrontier(ts)
+		}),
+	)
+	return err
+}
+
+// decodeNotification decodes a row of system.notifications, returning the
+// notification and its sequence number in its transaction.
+func (r *Registry) decodeNotification(
+	kv *kvpb.RangeFeedValue, alloc *tree.DatumAlloc,
+) (_ Notification, seq int64, _ error) {
+	tuple, err := kv.Value.GetTuple()
+	if err != nil {
+		return Notification{}, 0, err
+	}
+	datums, err := r.decoder.Decode(alloc, tuple)
+	if err != nil {
+		return Notification{}, 0, err
+	}
+	// The columns are (id, channel, payload, pid, created, seq).
+	channel, ok := tree.AsDString(datums[1])
+	if !ok {
+		return Notification{}, 0, errors.AssertionFailedf("unexpected channel %v", datums[1])
+	}
+	payload, ok := tree.AsDString(datums[2])
+	if !ok {
+		return Notification{}, 0, errors.AssertionFailedf("unexpected payload %v", datums[2])
+	}
+	pid, ok := tree.AsDInt(datums[3])
+	if !ok {
+		return Notification{}, 0, errors.AssertionFailedf("unexpected pid %v", datums[3])
+	}
+	s, ok := tree.AsDInt(datums[5])
+	if !ok {
+		return Notification{}, 0, errors.AssertionFailedf("unexpected seq %v", datums[5])
+	}
+	return Notification{Channel: string(channel), Payload: string(payload), PID: int32(pid)}, int64(s), nil
+}
+
+// addPending holds a notification written at the given timestamp until the
+// frontier passes it. seq is the sequence number of the notification in its
+// transaction.
+func (r *Registry) addPending(ts hlc.Timestamp, key roachpb.Key, seq int64, n Notification) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Values at or below the frontier are replays after a rangefeed restart.
+	if ts.LessEq(r.mu.frontier) {
+		return
+	}
+	r.mu.pending = append(r.mu.pending, pendingNotification{ts: ts, seq: seq, key: key, n: n})
+}
+
+// advanceFrontier forwards the frontier and queues the pending notifications
+// at or below it for the listeners on their channels, in timestamp order.
+// The notifications written by a transaction all have its commit timestamp,
+// and are ordered by their sequence number in the transaction. Ties between
+// transactions with the same commit timestamp are broken by key.
+func (r *Registry) advanceFrontier(frontier hlc.Timestamp) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.mu.frontier.Forward(frontier) {
+		return
+	}
+	var ready []pendingNotification
+	pending := r.mu.pending[:0]
+	for _, p := range r.mu.pending {
+		if p.ts.LessEq(r.mu.frontier) {
+			ready = append(ready, p)
+		} else {
+			pending = append(pending, p)
+		}
+	}
+	r.mu.pending = pending
+	sort.Slice(ready, func(i, j int) bool {
+		if c := ready[i].ts.Compare(ready[j].ts); c != 0 {
+			return c < 0
+		}
+		if ready[i].seq != ready[j].seq {
+			return ready[i].seq < ready[j].seq
+		}
+		return bytes.Compare(ready[i].key, ready[j].key) < 0
+	})
+	for i, p := range ready {
+		// The rangefeed may emit a value more than once if it restarts.
+		if i > 0 && p.ts == ready[i-1].ts && p.key.Equal(ready[i-1].key) {
+			continue
+		}
+		for l := range r.mu.listeners {
+			l.push(p.n)
+		}
+	}
+}
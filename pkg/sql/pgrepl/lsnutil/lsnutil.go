@@ -6,15 +6,33 @@
 package lsnutil
 
 import (
+	"math"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
-// HLCToLSN converts a HLC to a LSN.
+// HLCToLSN converts an HLC timestamp to an LSN. The LSN is the wall time of
+// the timestamp in nanoseconds; the logical component is dropped, so all
+// timestamps sharing a wall time map to the same LSN.
 // It is in a separate package to prevent the `lsn` package importing `log`.
 func HLCToLSN(h hlc.Timestamp) lsn.LSN {
-	// TODO(#105130): correctly populate this field.
+	return lsn.LSN(h.WallTime)
+}
+
+// LSNToHLC returns the highest HLC timestamp which maps to the given LSN. All
+// changes at or below the returned timestamp are at or before the LSN.
+func LSNToHLC(l lsn.LSN) hlc.Timestamp {
+	return hlc.Timestamp{WallTime: int64(l), Logical: math.MaxInt32}
+}
+
+// LegacyHLCToLSN converts an HLC timestamp to an LSN using the encoding which
+// predates V25_2_PGReplicationSlots. The shift overflows, so the LSN cannot
+// be converted back to a timestamp. It is only used until the cluster is
+// upgraded, so that all the nodes of a mixed-version cluster report LSNs in
+// the same encoding. It can be removed once mixed-version support with v25.1
+// is no longer necessary.
+func LegacyHLCToLSN(h hlc.Timestamp) lsn.LSN {
 	return lsn.LSN(h.WallTime/int64(time.Millisecond)) << 32
 }
@@ -0,0 +1,31 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package lsnutil
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLSNEncoding pins the encodings of LSNs, which are persisted by the
+// clients of replication slots and compared across nodes.
+func TestLSNEncoding(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ts := hlc.Timestamp{WallTime: 1700000000123456789, Logical: 7}
+
+	require.Equal(t, "17979CFE/3D85CD15", HLCToLSN(ts).String())
+	require.Equal(t, "CFE5687B/0", LegacyHLCToLSN(ts).String())
+
+	// LSNToHLC returns the highest timestamp mapping to the LSN.
+	l := HLCToLSN(ts)
+	require.Equal(t, l, HLCToLSN(LSNToHLC(l)))
+	require.True(t, ts.Less(LSNToHLC(l)))
+	require.True(t, LSNToHLC(l).Less(hlc.Timestamp{WallTime: ts.WallTime + 1}))
+}
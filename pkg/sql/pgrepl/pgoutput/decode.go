@@ -0,0 +1,329 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgoutput
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
+)
+
+// Message is a decoded pgoutput logical replication message.
+type Message interface {
+	MessageType() MessageType
+}
+
+// Begin marks the start of a transaction.
+type Begin struct {
+	FinalLSN   lsn.LSN
+	CommitTime time.Time
+	Xid        uint32
+}
+
+// Commit marks the end of a transaction.
+type Commit struct {
+	CommitLSN  lsn.LSN
+	EndLSN     lsn.LSN
+	CommitTime time.Time
+}
+
+// Origin identifies the origin of the changes of a transaction which was
+// itself replicated from another server.
+type Origin struct {
+	CommitLSN lsn.LSN
+	Name      string
+}
+
+// CustomType describes a user-defined type used by a replicated relation.
+type CustomType struct {
+	ID        oid.Oid
+	Namespace string
+	Name      string
+}
+
+// Insert is a row inserted into a relation.
+type Insert struct {
+	RelationID oid.Oid
+	New        Tuple
+}
+
+// Update is a row updated in a relation. Old is only set if the replica
+// identity of the row changed, or if the relation uses REPLICA IDENTITY FULL.
+type Update struct {
+	RelationID oid.Oid
+	Old        Tuple
+	New        Tuple
+}
+
+// Delete is a row deleted from a relation. Old holds either the replica
+// identity of the row or, with REPLICA IDENTITY FULL, the whole row.
+type Delete struct {
+	RelationID oid.Oid
+	Old        Tuple
+}
+
+// Truncate lists the relations truncated by a single TRUNCATE statement.
+type Truncate struct {
+	Cascade         bool
+	RestartIdentity bool
+	RelationIDs     []oid.Oid
+}
+
+// MessageType implements the Message interface.
+func (*Begin) MessageType() MessageType { return MessageBegin }
+
+// MessageType implements the Message interface.
+func (*Commit) MessageType() MessageType { return MessageCommit }
+
+// MessageType implements the Message interface.
+func (*Origin) MessageType() MessageType { return MessageOrigin }
+
+// MessageType implements the Message interface.
+func (*CustomType) MessageType() MessageType { return MessageCustomType }
+
+// MessageType implements the Message interface.
+func (*Relation) MessageType() MessageType { return MessageRelation }
+
+// MessageType implements the Message interface.
+func (*Insert) MessageType() MessageType { return MessageInsert }
+
+// MessageType implements the Message interface.
+func (*Update) MessageType() MessageType { return MessageUpdate }
+
+// MessageType implements the Message interface.
+func (*Delete) MessageType() MessageType { return MessageDelete }
+
+// MessageType implements the Message interface.
+func (*Truncate) MessageType() MessageType { return MessageTruncate }
+
+// ValueKind describes how a column value is represented in a tuple.
+type ValueKind byte
+
+// Kinds of tuple values.
+const (
+	// ValueNull is a NULL value.
+	ValueNull ValueKind = ValueKind(tupleColNull)
+	// ValueUnchanged is an unchanged TOASTed value whose contents were not
+	// sent.
+	ValueUnchanged ValueKind = ValueKind(tupleColUnchanged)
+	// ValueText is a value in the text format.
+	ValueText ValueKind = ValueKind(tupleColText)
+	// ValueBinary is a value in the binary format.
+	ValueBinary ValueKind = ValueKind(tupleColBinary)
+)
+
+// TupleValue is the value of a single column of a Tuple.
+type TupleValue struct {
+	Kind ValueKind
+	Data []byte
+}
+
+// Tuple holds the column values of a row, in the order of the columns of the
+// row's Relation.
+type Tuple []TupleValue
+
+// Flags of Truncate messages.
+const (
+	truncateCascade         = 1
+	truncateRestartIdentity = 2
+)
+
+// ParseMessage decodes a pgoutput message sent with protocol version 1.
+//
+// The returned message may reference data, which must not be modified while
+// the message is in use. The types of the columns of a Relation are resolved
+// from their OIDs; the columns of types which are not built in have the
+// Unknown type.
+func ParseMessage(data []byte) (Message, error) {
+	r := reader{data: data}
+	typ := MessageType(r.byte())
+	var msg Message
+	switch typ {
+	case MessageBegin:
+		msg = &Begin{
+			FinalLSN:   r.lsn(),
+			CommitTime: r.time(),
+			Xid:        r.uint32(),
+		}
+	case MessageCommit:
+		_ = r.byte() // flags
+		msg = &Commit{
+			CommitLSN:  r.lsn(),
+			EndLSN:     r.lsn(),
+			CommitTime: r.time(),
+		}
+	case MessageOrigin:
+		msg = &Origin{CommitLSN: r.lsn(), Name: r.string()}
+	case MessageCustomType:
+		msg = &CustomType{ID: oid.Oid(r.uint32()), Namespace: r.string(), Name: r.string()}
+	case MessageRelation:
+		rel := &Relation{
+			ID:        oid.Oid(r.uint32()),
+			Namespace: r.string(),
+			Name:      r.string(),
+		}
+		_ = r.byte() // replica identity
+		rel.Columns = make([]Column, r.uint16())
+		for i := range rel.Columns {
+			col := &rel.Columns[i]
+			col.Key = r.byte()&columnFlagKey != 0
+			col.Name = r.string()
+			col.Type = types.Unknown
+			if t, ok := types.OidToType[oid.Oid(r.uint32())]; ok {
+				col.Type = t
+			}
+			_ = r.uint32() // type modifier
+		}
+		msg = rel
+	case MessageInsert:
+		ins := &Insert{RelationID: oid.Oid(r.uint32())}
+		r.expect(tupleNew)
+		ins.New = r.tuple()
+		msg = ins
+	case MessageUpdate:
+		upd := &Update{RelationID: oid.Oid(r.uint32())}
+		if marker := r.peek(); marker == tupleKey || marker == tupleOld {
+			_ = r.byte()
+			upd.Old = r.tuple()
+		}
+		r.expect(tupleNew)
+		upd.New = r.tuple()
+		msg = upd
+	case MessageDelete:
+		del := &Delete{RelationID: oid.Oid(r.uint32())}
+		if marker := r.byte(); marker != tupleKey && marker != tupleOld && r.err == nil {
+			r.err = pgerror.Newf(pgcode.ProtocolViolation, "unexpected tuple marker %q", marker)
+		}
+		del.Old = r.tuple()
+		msg = del
+	case MessageTruncate:
+		n := r.uint32()
+		flags := r.byte()
+		trunc := &Truncate{
+			Cascade:         flags&truncateCascade != 0,
+			RestartIdentity: flags&truncateRestartIdentity != 0,
+		}
+		for i := uint32(0); i < n && r.err == nil; i++ {
+			trunc.RelationIDs = append(trunc.RelationIDs, oid.Oid(r.uint32()))
+		}
+		msg = trunc
+	default:
+		return nil, pgerror.Newf(pgcode.ProtocolViolation,
+			"unknown logical replication message type %q", byte(typ))
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return msg, nil
+}
+
+// reader decodes the fields of a message. Once an error is encountered, all
+// subsequent reads return zero values and the error is kept in err.
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.data) < n {
+		r.err = pgerror.New(pgcode.ProtocolViolation, "logical replication message is truncated")
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) peek() byte {
+	if r.err != nil || len(r.data) == 0 {
+		return 0
+	}
+	return r.data[0]
+}
+
+func (r *reader) byte() byte {
+	if b := r.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *reader) uint16() uint16 {
+	if b := r.next(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *reader) uint32() uint32 {
+	if b := r.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *reader) uint64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *reader) lsn() lsn.LSN {
+	return lsn.LSN(r.uint64())
+}
+
+func (r *reader) time() time.Time {
+	return pgEpoch.Add(time.Duration(int64(r.uint64())) * time.Microsecond)
+}
+
+func (r *reader) string() string {
+	if r.err != nil {
+		return ""
+	}
+	i := bytes.IndexByte(r.data, 0)
+	if i < 0 {
+		r.err = pgerror.New(pgcode.ProtocolViolation, "unterminated string in logical replication message")
+		return ""
+	}
+	s := string(r.data[:i])
+	r.data = r.data[i+1:]
+	return s
+}
+
+func (r *reader) expect(marker byte) {
+	if b := r.byte(); b != marker && r.err == nil {
+		r.err = pgerror.Newf(pgcode.ProtocolViolation, "expected tuple marker %q, found %q", marker, b)
+	}
+}
+
+func (r *reader) tuple() Tuple {
+	n := int(r.uint16())
+	t := make(Tuple, 0, n)
+	for i := 0; i < n && r.err == nil; i++ {
+		v := TupleValue{Kind: ValueKind(r.byte())}
+		switch v.Kind {
+		case ValueNull, ValueUnchanged:
+		case ValueText, ValueBinary:
+			v.Data = r.next(int(r.uint32()))
+		default:
+			if r.err == nil {
+				r.err = pgerror.Newf(pgcode.ProtocolViolation, "unknown tuple value kind %q", byte(v.Kind))
+			}
+		}
+		t = append(t, v)
+	}
+	return t
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package pgoutput encodes the messages of the PostgreSQL streaming
+// replication protocol, as well as the logical change messages produced by
+// the built-in "pgoutput" output plugin.
+//
+// See https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+// and https://www.postgresql.org/docs/current/protocol-replication.html.
+package pgoutput
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
+)
+
+// PluginName is the name of the output plugin implemented by this package.
+const PluginName = "pgoutput"
+
+// ProtoVersion is the version of the logical replication protocol implemented
+// by this package.
+const ProtoVersion = 1
+
+// MessageType identifies a pgoutput logical replication message.
+type MessageType byte
+
+// Logical replication message types.
+const (
+	MessageBegin    MessageType = 'B'
+	MessageCommit   MessageType = 'C'
+	MessageRelation MessageType = 'R'
+	MessageInsert   MessageType = 'I'
+	MessageUpdate   MessageType = 'U'
+	MessageDelete   MessageType = 'D'
+
+	// The following messages are never sent, but may be received from a
+	// PostgreSQL server.
+	MessageTruncate   MessageType = 'T'
+	MessageOrigin     MessageType = 'O'
+	MessageCustomType MessageType = 'Y'
+)
+
+// Tuple markers used inside Insert, Update and Delete messages.
+const (
+	tupleNew byte = 'N'
+	tupleKey byte = 'K'
+	tupleOld byte = 'O'
+
+	tupleColNull      byte = 'n'
+	tupleColUnchanged byte = 'u'
+	tupleColText      byte = 't'
+	tupleColBinary    byte = 'b'
+)
+
+// replicaIdentityDefault indicates that the old values of the primary key
+// columns are sent for updates and deletes.
+const replicaIdentityDefault byte = 'd'
+
+// columnFlagKey marks a column as part of the replica identity.
+const columnFlagKey byte = 1
+
+// pgEpoch is the epoch used for timestamps in the replication protocol.
+var pgEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Column describes a column of a replicated relation.
+type Column struct {
+	Name string
+	Type *types.T
+	// Key is set if the column is part of the relation's replica identity,
+	// i.e. its primary key.
+	Key bool
+}
+
+// Relation describes a replicated table. A Relation message must be sent
+// before the first change to the table, and again whenever its schema
+// changes.
+type Relation struct {
+	ID        oid.Oid
+	Namespace string
+	Name      string
+	Columns   []Column
+}
+
+// AppendBegin appends a Begin message for a transaction which commits at
+// finalLSN.
+func AppendBegin(buf []byte, finalLSN lsn.LSN, commitTime time.Time, xid uint32) []byte {
+	buf = append(buf, byte(MessageBegin))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(finalLSN))
+	buf = appendTime(buf, commitTime)
+	return binary.BigEndian.AppendUint32(buf, xid)
+}
+
+// AppendCommit appends a Commit message for a transaction which committed at
+// commitLSN. endLSN is the position following the transaction.
+func AppendCommit(buf []byte, commitLSN, endLSN lsn.LSN, commitTime time.Time) []byte {
+	buf = append(buf, byte(MessageCommit))
+	buf = append(buf, 0 /* flags */)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(commitLSN))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(endLSN))
+	return appendTime(buf, commitTime)
+}
+
+// AppendRelation appends a Relation message describing rel.
+func AppendRelation(buf []byte, rel *Relation) []byte {
+	buf = append(buf, byte(MessageRelation))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(rel.ID))
+	buf = appendString(buf, rel.Namespace)
+	buf = appendString(buf, rel.Name)
+	buf = append(buf, replicaIdentityDefault)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(rel.Columns)))
+	for _, col := range rel.Columns {
+		var flags byte
+		if col.Key {
+			flags |= columnFlagKey
+		}
+		buf = append(buf, flags)
+		buf = appendString(buf, col.Name)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(col.Type.Oid()))
+		buf = binary.BigEndian.AppendUint32(buf, uint32(col.Type.TypeModifier()))
+	}
+	return buf
+}
+
+// AppendInsert appends an Insert message for a new row of relation relID.
+func AppendInsert(buf []byte, relID oid.Oid, row tree.Datums) []byte {
+	buf = append(buf, byte(MessageInsert))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(relID))
+	buf = append(buf, tupleNew)
+	return appendTuple(buf, row)
+}
+
+// AppendUpdate appends an Update message for a row of relation relID. oldKey
+// holds the previous values of the key columns, with all other columns set to
+// NULL, and should only be set if the key of the row has changed.
+func AppendUpdate(buf []byte, relID oid.Oid, oldKey, row tree.Datums) []byte {
+	buf = append(buf, byte(MessageUpdate))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(relID))
+	if oldKey != nil {
+		buf = append(buf, tupleKey)
+		buf = appendTuple(buf, oldKey)
+	}
+	buf = append(buf, tupleNew)
+	return appendTuple(buf, row)
+}
+
+// AppendDelete appends a Delete message for a row of relation relID. oldKey
+// holds the values of the key columns of the deleted row, with all other
+// columns set to NULL.
+func AppendDelete(buf []byte, relID oid.Oid, oldKey tree.Datums) []byte {
+	buf = append(buf, byte(MessageDelete))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(relID))
+	buf = append(buf, tupleKey)
+	return appendTuple(buf, oldKey)
+}
+
+// appendTuple appends the TupleData encoding of row. Values are sent in the
+// text format.
+func appendTuple(buf []byte, row tree.Datums) []byte {
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(row)))
+	for _, d := range row {
+		if d == tree.DNull {
+			buf = append(buf, tupleColNull)
+			continue
+		}
+		s := tree.AsStringWithFlags(d, tree.FmtPgwireText)
+		buf = append(buf, tupleColText)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(s)))
+		buf = append(buf, s...)
+	}
+	return buf
+}
+
+// appendString appends a null-terminated string.
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, s...)
+	return append(buf, 0)
+}
+
+// appendTime appends t as the number of microseconds since the PostgreSQL
+// epoch.
+func appendTime(buf []byte, t time.Time) []byte {
+	return binary.BigEndian.AppendUint64(buf, uint64(t.Sub(pgEpoch).Microseconds()))
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgoutput
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeMessages(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ts := pgEpoch.Add(time.Second)
+	for _, tc := range []struct {
+		desc     string
+		buf      []byte
+		expected []byte
+	}{
+		{
+			desc: "begin",
+			buf:  AppendBegin(nil, lsn.LSN(0x0102), ts, 7),
+			expected: []byte{
+				'B',
+				0, 0, 0, 0, 0, 0, 0x01, 0x02,
+				0, 0, 0, 0, 0, 0x0f, 0x42, 0x40,
+				0, 0, 0, 7,
+			},
+		},
+		{
+			desc: "commit",
+			buf:  AppendCommit(nil, lsn.LSN(1), lsn.LSN(2), ts),
+			expected: []byte{
+				'C', 0,
+				0, 0, 0, 0, 0, 0, 0, 1,
+				0, 0, 0, 0, 0, 0, 0, 2,
+				0, 0, 0, 0, 0, 0x0f, 0x42, 0x40,
+			},
+		},
+		{
+			desc: "relation",
+			buf: AppendRelation(nil, &Relation{
+				ID:        104,
+				Namespace: "public",
+				Name:      "t",
+				Columns: []Column{
+					{Name: "k", Type: types.Int, Key: true},
+					{Name: "v", Type: types.String},
+				},
+			}),
+			expected: []byte{
+				'R',
+				0, 0, 0, 104,
+				'p', 'u', 'b', 'l', 'i', 'c', 0,
+				't', 0,
+				'd',
+				0, 2,
+				1, 'k', 0, 0, 0, 0, 20, 0xff, 0xff, 0xff, 0xff,
+				0, 'v', 0, 0, 0, 0, 25, 0xff, 0xff, 0xff, 0xff,
+			},
+		},
+		{
+			desc: "insert",
+			buf:  AppendInsert(nil, 104, tree.Datums{tree.NewDInt(1), tree.NewDString("a")}),
+			expected: []byte{
+				'I', 0, 0, 0, 104, 'N',
+				0, 2,
+				't', 0, 0, 0, 1, '1',
+				't', 0, 0, 0, 1, 'a',
+			},
+		},
+		{
+			desc: "update with key change",
+			buf: AppendUpdate(nil, 104,
+				tree.Datums{tree.NewDInt(1), tree.DNull},
+				tree.Datums{tree.NewDInt(2), tree.DBoolTrue}),
+			expected: []byte{
+				'U', 0, 0, 0, 104,
+				'K', 0, 2, 't', 0, 0, 0, 1, '1', 'n',
+				'N', 0, 2, 't', 0, 0, 0, 1, '2', 't', 0, 0, 0, 1, 't',
+			},
+		},
+		{
+			desc: "delete",
+			buf:  AppendDelete(nil, 104, tree.Datums{tree.NewDInt(1), tree.DNull}),
+			expected: []byte{
+				'D', 0, 0, 0, 104,
+				'K', 0, 2, 't', 0, 0, 0, 1, '1', 'n',
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.buf)
+		})
+	}
+}
+
+func TestStandbyStatusUpdate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	data := []byte{
+		'r',
+		0, 0, 0, 0, 0, 0, 0, 3,
+		0, 0, 0, 0, 0, 0, 0, 2,
+		0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, 0, 0x0f, 0x42, 0x40,
+		1,
+	}
+	u, err := ParseStandbyStatusUpdate(data)
+	require.NoError(t, err)
+	require.Equal(t, StandbyStatusUpdate{
+		Written:        3,
+		Flushed:        2,
+		Applied:        1,
+		ClientTime:     pgEpoch.Add(time.Second),
+		ReplyRequested: true,
+	}, u)
+
+	_, err = ParseStandbyStatusUpdate(data[:len(data)-1])
+	require.ErrorContains(t, err, "invalid standby status update message")
+
+	keepalive := AppendPrimaryKeepalive(nil, lsn.LSN(2), pgEpoch, false /* replyRequested */)
+	require.Equal(t, []byte{'k', 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0}, keepalive)
+}
+
+func TestParseMessages(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ts := pgEpoch.Add(time.Second)
+	rel := &Relation{
+		ID:        104,
+		Namespace: "public",
+		Name:      "t",
+		Columns: []Column{
+			{Name: "k", Type: types.Int, Key: true},
+			{Name: "v", Type: types.String},
+		},
+	}
+	text := func(s string) TupleValue { return TupleValue{Kind: ValueText, Data: []byte(s)} }
+	null := TupleValue{Kind: ValueNull}
+	for _, tc := range []struct {
+		desc     string
+		buf      []byte
+		expected Message
+	}{
+		{
+			desc:     "begin",
+			buf:      AppendBegin(nil, lsn.LSN(0x0102), ts, 7),
+			expected: &Begin{FinalLSN: 0x0102, CommitTime: ts, Xid: 7},
+		},
+		{
+			desc:     "commit",
+			buf:      AppendCommit(nil, lsn.LSN(1), lsn.LSN(2), ts),
+			expected: &Commit{CommitLSN: 1, EndLSN: 2, CommitTime: ts},
+		},
+		{
+			desc:     "relation",
+			buf:      AppendRelation(nil, rel),
+			expected: rel,
+		},
+		{
+			desc:     "insert",
+			buf:      AppendInsert(nil, 104, tree.Datums{tree.NewDInt(1), tree.NewDString("a")}),
+			expected: &Insert{RelationID: 104, New: Tuple{text("1"), text("a")}},
+		},
+		{
+			desc:     "update",
+			buf:      AppendUpdate(nil, 104, nil, tree.Datums{tree.NewDInt(1), tree.DNull}),
+			expected: &Update{RelationID: 104, New: Tuple{text("1"), null}},
+		},
+		{
+			desc: "update with key change",
+			buf: AppendUpdate(nil, 104,
+				tree.Datums{tree.NewDInt(1), tree.DNull},
+				tree.Datums{tree.NewDInt(2), tree.NewDString("")}),
+			expected: &Update{
+				RelationID: 104,
+				Old:        Tuple{text("1"), null},
+				New:        Tuple{text("2"), {Kind: ValueText, Data: []byte{}}},
+			},
+		},
+		{
+			desc:     "delete",
+			buf:      AppendDelete(nil, 104, tree.Datums{tree.NewDInt(1), tree.DNull}),
+			expected: &Delete{RelationID: 104, Old: Tuple{text("1"), null}},
+		},
+		{
+			desc: "update with unchanged value",
+			buf: []byte{
+				'U', 0, 0, 0, 104,
+				'N', 0, 2, 't', 0, 0, 0, 1, '1', 'u',
+			},
+			expected: &Update{RelationID: 104, New: Tuple{text("1"), {Kind: ValueUnchanged}}},
+		},
+		{
+			desc:     "truncate",
+			buf:      []byte{'T', 0, 0, 0, 2, 1, 0, 0, 0, 104, 0, 0, 0, 105},
+			expected: &Truncate{Cascade: true, RelationIDs: []oid.Oid{104, 105}},
+		},
+		{
+			desc:     "origin",
+			buf:      []byte{'O', 0, 0, 0, 0, 0, 0, 0, 9, 'o', 0},
+			expected: &Origin{CommitLSN: 9, Name: "o"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			msg, err := ParseMessage(tc.buf)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, msg)
+
+			// Truncated messages are rejected.
+			_, err = ParseMessage(tc.buf[:len(tc.buf)-1])
+			require.Error(t, err)
+		})
+	}
+
+	_, err := ParseMessage([]byte{'Z'})
+	require.ErrorContains(t, err, "unknown logical replication message type")
+}
+
+func TestParseReplicationMessages(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ts := pgEpoch.Add(time.Second)
+	data := AppendXLogDataHeader(nil, lsn.LSN(1), lsn.LSN(2), ts)
+	data = append(data, 'x')
+	x, err := ParseXLogData(data)
+	require.NoError(t, err)
+	require.Equal(t, XLogData{WALStart: 1, WALEnd: 2, ServerTime: ts, Data: []byte{'x'}}, x)
+
+	k, err := ParsePrimaryKeepalive(AppendPrimaryKeepalive(nil, lsn.LSN(3), ts, true /* replyRequested */))
+	require.NoError(t, err)
+	require.Equal(t, PrimaryKeepalive{WALEnd: 3, ServerTime: ts, ReplyRequested: true}, k)
+
+	u := StandbyStatusUpdate{Written: 3, Flushed: 2, Applied: 1, ClientTime: ts}
+	parsed, err := ParseStandbyStatusUpdate(AppendStandbyStatusUpdate(nil, u))
+	require.NoError(t, err)
+	require.Equal(t, u, parsed)
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgoutput
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// Message types sent inside CopyData messages once a connection has entered
+// the replication stream.
+const (
+	// XLogDataMessage carries a logical replication message.
+	XLogDataMessage byte = 'w'
+	// PrimaryKeepaliveMessage is sent periodically by the server.
+	PrimaryKeepaliveMessage byte = 'k'
+	// StandbyStatusUpdateMessage is sent by the client to report its progress.
+	StandbyStatusUpdateMessage byte = 'r'
+	// HotStandbyFeedbackMessage is sent by physical standbys.
+	HotStandbyFeedbackMessage byte = 'h'
+)
+
+// AppendXLogDataHeader appends the header of an XLogData message. The
+// message payload, e.g. a pgoutput message, should be appended after it.
+func AppendXLogDataHeader(buf []byte, walStart, walEnd lsn.LSN, sendTime time.Time) []byte {
+	buf = append(buf, XLogDataMessage)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(walStart))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(walEnd))
+	return appendTime(buf, sendTime)
+}
+
+// AppendPrimaryKeepalive appends a primary keepalive message.
+func AppendPrimaryKeepalive(
+	buf []byte, walEnd lsn.LSN, sendTime time.Time, replyRequested bool,
+) []byte {
+	buf = append(buf, PrimaryKeepaliveMessage)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(walEnd))
+	buf = appendTime(buf, sendTime)
+	var reply byte
+	if replyRequested {
+		reply = 1
+	}
+	return append(buf, reply)
+}
+
+// StandbyStatusUpdate is the progress report sent by a replication client.
+type StandbyStatusUpdate struct {
+	// Written is the position of the last change received by the client.
+	Written lsn.LSN
+	// Flushed is the position up to which the client has durably processed
+	// changes. The slot may be advanced to this position.
+	Flushed lsn.LSN
+	// Applied is the position of the last change applied by the client.
+	Applied        lsn.LSN
+	ClientTime     time.Time
+	ReplyRequested bool
+}
+
+const standbyStatusUpdateLen = 1 + 8 + 8 + 8 + 8 + 1
+
+// ParseStandbyStatusUpdate parses a standby status update message, including
+// its leading message type byte.
+func ParseStandbyStatusUpdate(data []byte) (StandbyStatusUpdate, error) {
+	if len(data) != standbyStatusUpdateLen || data[0] != StandbyStatusUpdateMessage {
+		return StandbyStatusUpdate{}, pgerror.Newf(pgcode.ProtocolViolation,
+			"invalid standby status update message")
+	}
+	data = data[1:]
+	readLSN := func() lsn.LSN {
+		v := lsn.LSN(binary.BigEndian.Uint64(data))
+		data = data[8:]
+		return v
+	}
+	var u StandbyStatusUpdate
+	u.Written = readLSN()
+	u.Flushed = readLSN()
+	u.Applied = readLSN()
+	u.ClientTime = pgEpoch.Add(time.Duration(int64(binary.BigEndian.Uint64(data))) * time.Microsecond)
+	u.ReplyRequested = data[8] != 0
+	return u, nil
+}
+
+// AppendStandbyStatusUpdate appends a standby status update message.
+func AppendStandbyStatusUpdate(buf []byte, u StandbyStatusUpdate) []byte {
+	buf = append(buf, StandbyStatusUpdateMessage)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(u.Written))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(u.Flushed))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(u.Applied))
+	buf = appendTime(buf, u.ClientTime)
+	var reply byte
+	if u.ReplyRequested {
+		reply = 1
+	}
+	return append(buf, reply)
+}
+
+// XLogData is a message of the replication stream carrying a logical
+// replication message.
+type XLogData struct {
+	WALStart   lsn.LSN
+	WALEnd     lsn.LSN
+	ServerTime time.Time
+	// Data is the logical replication message, e.g. a pgoutput message.
+	Data []byte
+}
+
+const xLogDataHeaderLen = 1 + 8 + 8 + 8
+
+// ParseXLogData parses an XLogData message, including its leading message
+// type byte. The returned Data references data.
+func ParseXLogData(data []byte) (XLogData, error) {
+	if len(data) < xLogDataHeaderLen || data[0] != XLogDataMessage {
+		return XLogData{}, pgerror.Newf(pgcode.ProtocolViolation, "invalid XLogData message")
+	}
+	r := reader{data: data[1:]}
+	return XLogData{
+		WALStart:   r.lsn(),
+		WALEnd:     r.lsn(),
+		ServerTime: r.time(),
+		Data:       r.data,
+	}, nil
+}
+
+// PrimaryKeepalive is the keepalive message sent periodically by the server.
+type PrimaryKeepalive struct {
+	WALEnd         lsn.LSN
+	ServerTime     time.Time
+	ReplyRequested bool
+}
+
+const primaryKeepaliveLen = 1 + 8 + 8 + 1
+
+// ParsePrimaryKeepalive parses a primary keepalive message, including its
+// leading message type byte.
+func ParsePrimaryKeepalive(data []byte) (PrimaryKeepalive, error) {
+	if len(data) != primaryKeepaliveLen || data[0] != PrimaryKeepaliveMessage {
+		return PrimaryKeepalive{}, pgerror.Newf(pgcode.ProtocolViolation,
+			"invalid primary keepalive message")
+	}
+	r := reader{data: data[1:]}
+	return PrimaryKeepalive{
+		WALEnd:         r.lsn(),
+		ServerTime:     r.time(),
+		ReplyRequested: r.byte() != 0,
+	}, nil
+}
@@ -0,0 +1,398 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgrepl
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplicationSlot creates a replication slot, streams changes from it
+// using pgoutput, and checks that standby status updates advance the slot.
+// Only the changes to the tables of the requested publication are streamed.
+func TestReplicationSlot(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	sysDB := sqlutils.MakeSQLRunner(srv.SystemLayer().SQLConn(t))
+	sysDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sysDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '100ms'`)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+	sqlDB.Exec(t, `CREATE TABLE u (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `CREATE PUBLICATION p FOR TABLE t`)
+
+	conn := replicationConn(ctx, t, s)
+	defer func() { _ = conn.Close(ctx) }()
+
+	results, err := conn.Exec(ctx, "CREATE_REPLICATION_SLOT s LOGICAL pgoutput").ReadAll()
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Len(t, results[0].Rows, 1)
+	require.Equal(t, "s", string(results[0].Rows[0][0]))
+	require.Equal(t, "pgoutput", string(results[0].Rows[0][3]))
+
+	_, err = conn.Exec(ctx, "CREATE_REPLICATION_SLOT s LOGICAL pgoutput").ReadAll()
+	requirePgError(t, err, pgcode.DuplicateObject)
+
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1, 'a')`)
+	sqlDB.Exec(t, `INSERT INTO u VALUES (1)`)
+	sqlDB.Exec(t, `UPDATE t SET v = 'b' WHERE k = 1`)
+	sqlDB.Exec(t, `DELETE FROM t WHERE k = 1`)
+
+	fe := conn.Frontend()
+	fe.Send(&pgproto3.Query{
+		String: `START_REPLICATION SLOT s LOGICAL 0/0 (proto_version '1', publication_names 'q')`,
+	})
+	require.NoError(t, fe.Flush())
+	requirePgError(t, receiveError(t, fe), pgcode.UndefinedObject)
+
+	fe = startReplication(t, conn, "s", "p")
+
+	// Collect the pgoutput messages until the transaction containing the
+	// delete commits. Changes which share a wall time are sent in the same
+	// transaction, so only the order of the changes is checked.
+	var changes []byte
+	var txns int
+	var commitLSN lsn.LSN
+	for done := false; !done; {
+		payload := nextPGOutputMessage(t, fe)
+		switch payload[0] {
+		case 'B':
+			txns++
+		case 'C':
+			commitLSN = lsn.LSN(binary.BigEndian.Uint64(payload[2:10]))
+			done = len(changes) > 0 && changes[len(changes)-1] == 'D'
+		default:
+			changes = append(changes, payload[0])
+		}
+	}
+	require.Equal(t, "RIUD", string(changes))
+	require.GreaterOrEqual(t, txns, 1)
+
+	// Confirm the last transaction, then end the stream.
+	endReplication(t, fe, commitLSN)
+
+	// The confirmed flush position is persisted in the slot's protected
+	// timestamp record.
+	sqlDB.CheckQueryResults(t, fmt.Sprintf(
+		`SELECT count(*) FROM system.protected_ts_records WHERE meta_type = 'pg_replication_slot' AND ts >= %d`,
+		uint64(commitLSN),
+	), [][]string{{"1"}})
+
+	_, err = conn.Exec(ctx, "DROP_REPLICATION_SLOT s").ReadAll()
+	require.NoError(t, err)
+	_, err = conn.Exec(ctx, "DROP_REPLICATION_SLOT s").ReadAll()
+	requirePgError(t, err, pgcode.UndefinedObject)
+}
+
+// TestReplicationSlotNewTable checks that tables created while a replication
+// stream is running are replicated from their creation.
+func TestReplicationSlotNewTable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	sysDB := sqlutils.MakeSQLRunner(srv.SystemLayer().SQLConn(t))
+	sysDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sysDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '100ms'`)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `CREATE PUBLICATION p FOR ALL TABLES`)
+
+	conn := replicationConn(ctx, t, s)
+	defer func() { _ = conn.Close(ctx) }()
+	_, err := conn.Exec(ctx, "CREATE_REPLICATION_SLOT s LOGICAL pgoutput").ReadAll()
+	require.NoError(t, err)
+
+	fe := startReplication(t, conn, "s", "p")
+	sqlDB.Exec(t, `CREATE TABLE u (k INT PRIMARY KEY)`)
+	sqlDB.Exec(t, `INSERT INTO u VALUES (1)`)
+	sqlDB.Exec(t, `INSERT INTO t VALUES (1)`)
+
+	relations := make(map[uint32]string)
+	var inserted []string
+	var commitLSN lsn.LSN
+	for len(inserted) < 2 {
+		payload := nextPGOutputMessage(t, fe)
+		switch payload[0] {
+		case 'R':
+			relations[binary.BigEndian.Uint32(payload[1:5])] = relationName(payload)
+		case 'I':
+			inserted = append(inserted, relations[binary.BigEndian.Uint32(payload[1:5])])
+		case 'C':
+			commitLSN = lsn.LSN(binary.BigEndian.Uint64(payload[2:10]))
+		}
+	}
+	require.Equal(t, []string{"u", "t"}, inserted)
+	endReplication(t, fe, commitLSN)
+}
+
+// TestReplicationSlotBufferLimit checks that a replication stream whose
+// pending changes exceed sql.pgrepl.max_buffer_size still delivers all of
+// them, in order.
+func TestReplicationSlotBufferLimit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	sysDB := sqlutils.MakeSQLRunner(srv.SystemLayer().SQLConn(t))
+	sysDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sysDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '100ms'`)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `SET CLUSTER SETTING sql.pgrepl.max_buffer_size = '64KiB'`)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+	sqlDB.Exec(t, `CREATE PUBLICATION p FOR ALL TABLES`)
+
+	conn := replicationConn(ctx, t, s)
+	defer func() { _ = conn.Close(ctx) }()
+	_, err := conn.Exec(ctx, "CREATE_REPLICATION_SLOT s LOGICAL pgoutput").ReadAll()
+	require.NoError(t, err)
+
+	// Each row is written by its own transaction, so the stream can shed all
+	// but the oldest pending transaction when it runs out of memory.
+	const numRows = 200
+	for i := 0; i < numRows; i++ {
+		sqlDB.Exec(t, `INSERT INTO t VALUES ($1, repeat('x', 1000))`, i)
+	}
+
+	fe := startReplication(t, conn, "s", "p")
+	var keys []int
+	var commitLSN lsn.LSN
+	for len(keys) < numRows {
+		payload := nextPGOutputMessage(t, fe)
+		switch payload[0] {
+		case 'I':
+			// Skip the relation ID, the tuple type, the number of columns and
+			// the type of the first column.
+			n := binary.BigEndian.Uint32(payload[9:13])
+			k, err := strconv.Atoi(string(payload[13 : 13+n]))
+			require.NoError(t, err)
+			keys = append(keys, k)
+		case 'C':
+			commitLSN = lsn.LSN(binary.BigEndian.Uint64(payload[2:10]))
+		}
+	}
+	for i, k := range keys {
+		require.Equal(t, i, k)
+	}
+	endReplication(t, fe, commitLSN)
+}
+
+// TestIdentifySystemLSN checks the encoding of the LSN reported by
+// IDENTIFY_SYSTEM, which is the nanosecond wall time of the current timestamp
+// once V25_2_PGReplicationSlots is active and the legacy encoding before.
+func TestIdentifySystemLSN(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	identifySystem := func(conn *pgconn.PgConn) lsn.LSN {
+		results, err := conn.Exec(ctx, "IDENTIFY_SYSTEM").ReadAll()
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Len(t, results[0].Rows, 1)
+		xlogPos, err := lsn.ParseLSN(string(results[0].Rows[0][2]))
+		require.NoError(t, err)
+		return xlogPos
+	}
+
+	t.Run("current version", func(t *testing.T) {
+		srv := serverutils.StartServerOnly(t, base.TestServerArgs{})
+		defer srv.Stopper().Stop(ctx)
+		s := srv.ApplicationLayer()
+
+		conn := replicationConn(ctx, t, s)
+		defer func() { _ = conn.Close(ctx) }()
+
+		before := s.Clock().Now()
+		xlogPos := identifySystem(conn)
+		after := s.Clock().Now()
+		require.LessOrEqual(t, lsn.LSN(before.WallTime), xlogPos)
+		require.LessOrEqual(t, xlogPos, lsn.LSN(after.WallTime))
+	})
+
+	t.Run("mixed version", func(t *testing.T) {
+		st := cluster.MakeTestingClusterSettingsWithVersions(
+			clusterversion.Latest.Version(), clusterversion.MinSupported.Version(), false, /* initializeVersion */
+		)
+		srv := serverutils.StartServerOnly(t, base.TestServerArgs{
+			Settings:          st,
+			DefaultTestTenant: base.TestControlsTenantsExplicitly,
+			Knobs: base.TestingKnobs{
+				Server: &server.TestingKnobs{
+					DisableAutomaticVersionUpgrade: make(chan struct{}),
+					ClusterVersionOverride:         (clusterversion.V25_2_PGReplicationSlots - 1).Version(),
+				},
+			},
+		})
+		defer srv.Stopper().Stop(ctx)
+		s := srv.ApplicationLayer()
+
+		conn := replicationConn(ctx, t, s)
+		defer func() { _ = conn.Close(ctx) }()
+
+		// The legacy encoding only uses the upper 32 bits.
+		require.Zero(t, uint32(identifySystem(conn)))
+
+		_, err := conn.Exec(ctx, "CREATE_REPLICATION_SLOT s LOGICAL pgoutput").ReadAll()
+		requirePgError(t, err, pgcode.FeatureNotSupported)
+	})
+}
+
+// replicationConn opens a replication connection to the server as root.
+func replicationConn(
+	ctx context.Context, t *testing.T, s serverutils.ApplicationLayerInterface,
+) *pgconn.PgConn {
+	t.Helper()
+	pgURL, cleanup := s.PGUrl(
+		t, serverutils.CertsDirPrefix("pgrepl_replication_slot_test"), serverutils.User(username.RootUser),
+	)
+	defer cleanup()
+	cfg, err := pgconn.ParseConfig(pgURL.String())
+	require.NoError(t, err)
+	cfg.RuntimeParams["replication"] = "database"
+
+	conn, err := pgconn.ConnectConfig(ctx, cfg)
+	require.NoError(t, err)
+	return conn
+}
+
+// startReplication starts streaming the changes to the tables of the
+// publications from the slot and waits for the server to switch to the copy
+// protocol.
+func startReplication(
+	t *testing.T, conn *pgconn.PgConn, slot, publications string,
+) *pgproto3.Frontend {
+	t.Helper()
+	fe := conn.Frontend()
+	fe.Send(&pgproto3.Query{
+		String: fmt.Sprintf(
+			`START_REPLICATION SLOT %s LOGICAL 0/0 (proto_version '1', publication_names '%s')`,
+			slot, publications,
+		),
+	})
+	require.NoError(t, fe.Flush())
+	msg, err := fe.Receive()
+	require.NoError(t, err)
+	require.IsType(t, &pgproto3.CopyBothResponse{}, msg)
+	return fe
+}
+
+// nextPGOutputMessage returns the pgoutput message carried by the next
+// XLogData message of the stream, skipping keepalives.
+func nextPGOutputMessage(t *testing.T, fe *pgproto3.Frontend) []byte {
+	t.Helper()
+	for {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		data, ok := msg.(*pgproto3.CopyData)
+		require.True(t, ok, "unexpected message %#v", msg)
+		switch data.Data[0] {
+		case 'k':
+			continue
+		case 'w':
+			// Skip the XLogData header: type, walStart, walEnd and sendTime.
+			return data.Data[25:]
+		default:
+			t.Fatalf("unexpected replication message %q", data.Data[0])
+		}
+	}
+}
+
+// endReplication confirms the changes up to flushed, then ends the stream and
+// waits for the connection to be ready for queries again.
+func endReplication(t *testing.T, fe *pgproto3.Frontend, flushed lsn.LSN) {
+	t.Helper()
+	update := []byte{'r'}
+	for i := 0; i < 3; i++ {
+		update = binary.BigEndian.AppendUint64(update, uint64(flushed))
+	}
+	update = binary.BigEndian.AppendUint64(update, 0)
+	update = append(update, 0)
+	fe.Send(&pgproto3.CopyData{Data: update})
+	fe.Send(&pgproto3.CopyDone{})
+	require.NoError(t, fe.Flush())
+	for done := false; !done; {
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		switch msg := msg.(type) {
+		case *pgproto3.CopyData, *pgproto3.CopyDone, *pgproto3.CommandComplete:
+		case *pgproto3.ReadyForQuery:
+			done = true
+		default:
+			t.Fatalf("unexpected message %#v", msg)
+		}
+	}
+}
+
+// relationName returns the name of the relation described by a pgoutput
+// Relation message.
+func relationName(payload []byte) string {
+	// Skip the message type and the relation ID, then the namespace.
+	parts := bytes.SplitN(payload[5:], []byte{0}, 3)
+	return string(parts[1])
+}
+
+// receiveError reads messages until ReadyForQuery and returns the error sent
+// by the server.
+func receiveError(t *testing.T, fe *pgproto3.Frontend) error {
+	t.Helper()
+	var err error
+	for {
+		msg, recvErr := fe.Receive()
+		require.NoError(t, recvErr)
+		switch msg := msg.(type) {
+		case *pgproto3.ErrorResponse:
+			err = pgconn.ErrorResponseToPgError(msg)
+		case *pgproto3.ReadyForQuery:
+			return err
+		}
+	}
+}
+
+func requirePgError(t *testing.T, err error, code pgcode.Code) {
+	t.Helper()
+	var pgErr *pgconn.PgError
+	require.True(t, errors.As(err, &pgErr), "expected a pg error, got %v", err)
+	require.Equal(t, code.String(), pgErr.Code)
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package replslot implements persistent logical replication slots.
+//
+// A replication slot is stored as a protected timestamp record targeting the
+// slot's database. The protected timestamp is the slot's confirmed flush
+// position: it prevents the MVCC history which has not yet been consumed by
+// the slot's client from being garbage collected, and it survives node
+// restarts. The remaining slot metadata is stored in the record's Meta field.
+package replslot
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// MetaType is the meta type of protected timestamp records backing
+// replication slots.
+const MetaType = "pg_replication_slot"
+
+// slotNamespace is used to derive the protected timestamp record ID of a slot
+// from its name, which ensures that slot names are unique.
+var slotNamespace = uuid.Must(uuid.FromString("5c4d3d5e-8a0b-4c55-9d53-3a7c2ef7e0a1"))
+
+// Slot is a logical replication slot.
+type Slot struct {
+	Name       string    `json:"name"`
+	Plugin     string    `json:"plugin"`
+	DatabaseID descpb.ID `json:"database_id"`
+	Database   string    `json:"database"`
+
+	// ConfirmedFlush is the timestamp up to which the client of the slot has
+	// confirmed receipt of all changes. It is the timestamp of the slot's
+	// protected timestamp record.
+	ConfirmedFlush hlc.Timestamp `json:"-"`
+}
+
+// ConfirmedFlushLSN returns the confirmed flush position of the slot.
+func (s *Slot) ConfirmedFlushLSN() lsn.LSN {
+	return lsnutil.HLCToLSN(s.ConfirmedFlush)
+}
+
+func recordID(name string) uuid.UUID {
+	return uuid.NewV5(slotNamespace, name)
+}
+
+func errSlotDoesNotExist(name string) error {
+	return pgerror.Newf(pgcode.UndefinedObject, "replication slot %q does not exist", name)
+}
+
+// Create persists a new replication slot.
+func Create(ctx context.Context, pts protectedts.Storage, slot Slot) error {
+	meta, err := json.Marshal(slot)
+	if err != nil {
+		return err
+	}
+	id := recordID(slot.Name)
+	if err := pts.Protect(ctx, &ptpb.Record{
+		ID:        id.GetBytesMut(),
+		Timestamp: slot.ConfirmedFlush,
+		Mode:      ptpb.PROTECT_AFTER,
+		MetaType:  MetaType,
+		Meta:      meta,
+		Target:    ptpb.MakeSchemaObjectsTarget(descpb.IDs{slot.DatabaseID}),
+	}); err != nil {
+		if errors.Is(err, protectedts.ErrExists) {
+			return pgerror.Newf(pgcode.DuplicateObject, "replication slot %q already exists", slot.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// Get returns the replication slot with the given name.
+func Get(ctx context.Context, pts protectedts.Storage, name string) (Slot, error) {
+	rec, err := pts.GetRecord(ctx, recordID(name))
+	if err != nil {
+		if errors.Is(err, protectedts.ErrNotExists) {
+			return Slot{}, errSlotDoesNotExist(name)
+		}
+		return Slot{}, err
+	}
+	if rec.MetaType != MetaType {
+		return Slot{}, errSlotDoesNotExist(name)
+	}
+	return slotFromRecord(rec)
+}
+
+// List returns all replication slots.
+func List(ctx context.Context, pts protectedts.Storage) ([]Slot, error) {
+	state, err := pts.GetState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var slots []Slot
+	for i := range state.Records {
+		if state.Records[i].MetaType != MetaType {
+			continue
+		}
+		slot, err := slotFromRecord(&state.Records[i])
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
+
+// Drop removes the replication slot with the given name, allowing the
+// history it retained to be garbage collected.
+func Drop(ctx context.Context, pts protectedts.Storage, name string) error {
+	if _, err := Get(ctx, pts, name); err != nil {
+		return err
+	}
+	return pts.Release(ctx, recordID(name))
+}
+
+// Advance moves the confirmed flush position of the slot forward to ts. The
+// position never moves backwards; attempts to do so are ignored.
+func Advance(ctx context.Context, pts protectedts.Storage, name string, ts hlc.Timestamp) error {
+	slot, err := Get(ctx, pts, name)
+	if err != nil {
+		return err
+	}
+	if ts.LessEq(slot.ConfirmedFlush) {
+		return nil
+	}
+	return pts.UpdateTimestamp(ctx, recordID(name), ts)
+}
+
+func slotFromRecord(rec *ptpb.Record) (Slot, error) {
+	var slot Slot
+	if err := json.Unmarshal(rec.Meta, &slot); err != nil {
+		return Slot{}, errors.Wrapf(err, "decoding replication slot record %s", rec.ID)
+	}
+	slot.ConfirmedFlush = rec.Timestamp
+	return slot, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -55,6 +56,7 @@ type commandResult struct {
 	buffer struct {
 		notices            []pgnotice.Notice
 		paramStatusUpdates []paramStatusUpdate
+		notifications      []pgnotify.Notification
 	}
 
 	err error
@@ -83,6 +85,11 @@ type commandResult struct {
 	// to have an entry for every column.
 	formatCodes []pgwirebase.FormatCode
 
+	// defaultFormatCode is the format used for all columns when formatCodes is
+	// nil. It is only changed from FormatText for rows fetched from a BINARY
+	// cursor.
+	defaultFormatCode pgwirebase.FormatCode
+
 	// types is a map from result column index to its type T, similar to formatCodes
 	// (except types must always be set).
 	types []*types.T
@@ -143,6 +150,12 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		}
 	}
 
+	for _, notification := range r.buffer.notifications {
+		if err := r.conn.bufferNotification(notification); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err when sending notification"))
+		}
+	}
+
 	// Send a completion message, specific to the type of result.
 	switch r.typ {
 	case commandComplete:
@@ -213,7 +226,7 @@ func (r *commandResult) SetError(err error) {
 
 // GetFormatCode is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) GetFormatCode(colIdx int) (pgwirebase.FormatCode, error) {
-	fmtCode := pgwirebase.FormatText
+	fmtCode := r.defaultFormatCode
 	if r.formatCodes != nil {
 		if colIdx >= len(r.formatCodes) {
 			if len(r.formatCodes) == 1 && r.cmdCompleteTag == "EXPLAIN" {
@@ -234,6 +247,12 @@ func (r *commandResult) GetFormatCode(colIdx int) (pgwirebase.FormatCode, error)
 	return fmtCode, nil
 }
 
+// SetDefaultFormatCode is part of the sql.RestrictedCommandResult interface.
+func (r *commandResult) SetDefaultFormatCode(fmtCode pgwirebase.FormatCode) {
+	r.assertNotReleased()
+	r.defaultFormatCode = fmtCode
+}
+
 // beforeAdd should be called before rows are buffered.
 func (r *commandResult) beforeAdd() error {
 	r.assertNotReleased()
@@ -327,6 +346,11 @@ func (r *commandResult) BufferNotice(notice pgnotice.Notice) {
 	r.buffer.notices = append(r.buffer.notices, notice)
 }
 
+// BufferNotification is part of the sql.NotificationResult interface.
+func (r *commandResult) BufferNotification(n pgnotify.Notification) {
+	r.buffer.notifications = append(r.buffer.notifications, n)
+}
+
 // SendNotice is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SendNotice(
 	ctx context.Context, notice pgnotice.Notice, immediateFlush bool,
@@ -346,6 +370,12 @@ func (r *commandResult) SendNotice(
 func (r *commandResult) SetColumns(ctx context.Context, cols colinfo.ResultColumns) {
 	r.assertNotReleased()
 	r.conn.writerState.fi.registerCmd(r.pos)
+	if r.formatCodes == nil && r.defaultFormatCode != pgwirebase.FormatText {
+		r.formatCodes = make([]pgwirebase.FormatCode, len(cols))
+		for i := range r.formatCodes {
+			r.formatCodes[i] = r.defaultFormatCode
+		}
+	}
 	if r.descOpt == sql.NeedRowDesc {
 		_ /* err */ = r.conn.writeRowDescription(ctx, cols, r.formatCodes, &r.conn.writerState.buf)
 	}
@@ -415,6 +445,30 @@ func (r *commandResult) SendCopyDone(ctx context.Context) error {
 	return r.conn.bufferCopyDone()
 }
 
+// SendCopyBoth is part of the sql.StartReplicationResult interface.
+func (r *commandResult) SendCopyBoth(ctx context.Context) error {
+	r.assertNotReleased()
+	r.conn.writerState.fi.registerCmd(r.pos)
+	if err := r.conn.bufferCopyBoth(); err != nil {
+		return err
+	}
+	return r.conn.Flush(r.pos)
+}
+
+// SendReplicationData is part of the sql.StartReplicationResult interface.
+func (r *commandResult) SendReplicationData(ctx context.Context, data []byte) error {
+	if err := r.beforeAdd(); err != nil {
+		return err
+	}
+	return r.conn.bufferCopyData(data, r)
+}
+
+// FlushReplicationData is part of the sql.StartReplicationResult interface.
+func (r *commandResult) FlushReplicationData(ctx context.Context) error {
+	r.assertNotReleased()
+	return r.conn.Flush(r.pos)
+}
+
 // SetRowsAffected is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SetRowsAffected(ctx context.Context, n int) {
 	r.assertNotReleased()
@@ -644,6 +698,10 @@ func (r *limitedCommandResult) moreResultsNeeded(ctx context.Context) error {
 			if err := r.conn.Flush(r.pos); err != nil {
 				return err
 			}
+		case sql.DeliverNotifications:
+			// Notifications are not delivered while a portal is open. They stay
+			// queued until the transaction ends.
+			r.conn.stmtBuf.AdvanceOne()
 		default:
 			// If the portal is immediately followed by a COMMIT, we can proceed and
 			// let the portal be destroyed at the end of the transaction.
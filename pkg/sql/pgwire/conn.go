@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgreplparser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -111,6 +112,11 @@ type conn struct {
 
 	// alwaysLogAuthActivity is used force-enables logging of authn events.
 	alwaysLogAuthActivity bool
+
+	// replication is set once a START_REPLICATION command has been received,
+	// and is used to forward the client's messages to the replication stream.
+	// It is only accessed by the network routine.
+	replication *replicationFeedback
 }
 
 func (c *conn) setErr(err error) {
@@ -269,6 +275,14 @@ func (c *conn) bufferNotice(ctx context.Context, noticeErr pgnotice.Notice) erro
 	return c.writeErrFields(ctx, noticeErr, &c.writerState.buf)
 }
 
+func (c *conn) bufferNotification(n pgnotify.Notification) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	c.msgBuilder.putInt32(n.PID)
+	c.msgBuilder.writeTerminatedString(n.Channel)
+	c.msgBuilder.writeTerminatedString(n.Payload)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) sendInitialConnData(
 	ctx context.Context,
 	sqlServer *sql.Server,
@@ -359,8 +373,26 @@ func (c *conn) handleSimpleQuery(
 			log.SqlExec.Infof(ctx, "could not parse simple query in replication protocol: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
 		}
-		switch stmt.AST.(type) {
-		case *pgrepltree.IdentifySystem:
+		switch n := stmt.AST.(type) {
+		case *pgrepltree.IdentifySystem, *pgrepltree.CreateReplicationSlot, *pgrepltree.DropReplicationSlot:
+		case *pgrepltree.StartReplication:
+			// START_REPLICATION takes over the write side of the connection until
+			// the stream ends. Messages sent by the client in the meantime are
+			// forwarded to the stream by the network routine.
+			if c.replication != nil {
+				c.replication.finish()
+			}
+			c.replication = newReplicationFeedback()
+			endParse := crtime.NowMono()
+			return c.stmtBuf.Push(ctx, sql.StartReplication{
+				ParsedStmt:   stmt,
+				Stmt:         n,
+				Feedback:     c.replication.feedback,
+				ClientDone:   c.replication.clientDone,
+				TimeReceived: timeReceived,
+				ParseStart:   startParse,
+				ParseEnd:     endParse,
+			})
 		default:
 			log.SqlExec.Infof(ctx, "unhandled replication protocol query: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{
@@ -867,6 +899,9 @@ func cookTag(
 			tag = strconv.AppendUint(tag, uint64(rowsAffected), 10)
 		}
 
+	case tree.Replication:
+		// Replication commands report their tag without a row count.
+
 	case tree.Ack, tree.DDL:
 		if tagStr == "SELECT" {
 			tag = append(tag, ' ')
@@ -1174,6 +1209,15 @@ func (c *conn) bufferCopyOut(columns []colinfo.ResultColumn, format pgwirebase.F
 	return c.msgBuilder.finishMsg(&c.writerState.buf)
 }
 
+// bufferCopyBoth buffers a CopyBothResponse message, which starts a
+// replication stream. Like Postgres, we report the text format and no columns.
+func (c *conn) bufferCopyBoth() error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyBothResponse)
+	c.msgBuilder.writeByte(byte(pgwirebase.FormatText))
+	c.msgBuilder.putInt16(0)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) bufferCopyData(copyData []byte, res *commandResult) error {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDataCommand)
 	if _, err := c.msgBuilder.Write(copyData); err != nil {
@@ -1364,6 +1408,11 @@ func (c *conn) CreateDrainResult(pos sql.CmdPos) sql.DrainResult {
 	return c.newMiscResult(pos, noCompletionMsg)
 }
 
+// CreateNotificationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateNotificationResult(pos sql.CmdPos) sql.NotificationResult {
+	return c.newMiscResult(pos, flush)
+}
+
 // CreateBindResult is part of the sql.ClientComm interface.
 func (c *conn) CreateBindResult(pos sql.CmdPos) sql.BindResult {
 	return c.newMiscResult(pos, bindComplete)
@@ -1412,6 +1461,16 @@ func (c *conn) CreateCopyOutResult(cmd sql.CopyOut, pos sql.CmdPos) sql.CopyOutR
 	return res
 }
 
+// CreateStartReplicationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateStartReplicationResult(
+	cmd sql.StartReplication, pos sql.CmdPos,
+) sql.StartReplicationResult {
+	res := c.newMiscResult(pos, commandComplete)
+	res.stmtType = cmd.Stmt.StatementReturnType()
+	res.cmdCompleteTag = cmd.Stmt.StatementTag()
+	return res
+}
+
 // pgwireReader is an io.Reader that wraps a conn, maintaining its metrics as
 // it is consumed.
 type pgwireReader struct {
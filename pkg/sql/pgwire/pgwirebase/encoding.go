@@ -495,6 +495,18 @@ func DecodeDatum(
 			return &tree.DPGVector{T: ret}, nil
 		}
 		switch typ.Family() {
+		case types.RangeFamily:
+			d, _, err := tree.ParseDRangeFromString(evalCtx, bs, typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		case types.MultirangeFamily:
+			d, _, err := tree.ParseDMultirangeFromString(evalCtx, bs, typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
 		case types.ArrayFamily, types.TupleFamily:
 			// Arrays and tuples come in in their string form, so we parse them
 			// as such and later convert them to their actual datum form.
@@ -827,6 +839,12 @@ func DecodeDatum(
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b, da)
 			}
+			if typ.Family() == types.RangeFamily {
+				return decodeBinaryRange(ctx, evalCtx, typ, b, da)
+			}
+			if typ.Family() == types.MultirangeFamily {
+				return decodeBinaryMultirange(ctx, evalCtx, typ, b, da)
+			}
 			if typ.Family() == types.OidFamily {
 				if len(b) < 4 {
 					return nil, pgerror.Newf(pgcode.ProtocolViolation, "oid requires 4 bytes for binary format")
@@ -1145,6 +1163,82 @@ func decodeBinaryTuple(
 
 }
 
+// decodeBinaryRange decodes the Postgres binary format of a range, which is a
+// flags byte followed by the length-prefixed binary encodings of the finite
+// bounds.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte, da *tree.DatumAlloc,
+) (*tree.DRange, error) {
+	if len(b) < 1 {
+		return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&PGBinaryRangeEmpty != 0 {
+		return tree.NewEmptyDRange(t), nil
+	}
+	decodeBound := func(inf bool) (tree.Datum, error) {
+		if inf {
+			return nil, nil
+		}
+		if len(b) < 4 {
+			return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
+		}
+		n := int(int32(binary.BigEndian.Uint32(b)))
+		b = b[4:]
+		if n < 0 || n > len(b) {
+			return nil, NewProtocolViolationErrorf("invalid range bound length: %d", n)
+		}
+		d, err := DecodeDatum(ctx, evalCtx, t.RangeSubtype(), FormatBinary, b[:n], da)
+		b = b[n:]
+		return d, err
+	}
+	lower := tree.RangeBound{Inclusive: flags&PGBinaryRangeLowerInc != 0}
+	upper := tree.RangeBound{Inclusive: flags&PGBinaryRangeUpperInc != 0}
+	var err error
+	if lower.Val, err = decodeBound(flags&PGBinaryRangeLowerInf != 0); err != nil {
+		return nil, err
+	}
+	if upper.Val, err = decodeBound(flags&PGBinaryRangeUpperInf != 0); err != nil {
+		return nil, err
+	}
+	return tree.NewDRange(t, lower, upper)
+}
+
+// decodeBinaryMultirange decodes the Postgres binary format of a multirange,
+// which is the number of ranges followed by the length-prefixed binary
+// encoding of each range.
+func decodeBinaryMultirange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte, da *tree.DatumAlloc,
+) (tree.Datum, error) {
+	if len(b) < 4 {
+		return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
+	}
+	count := int(int32(binary.BigEndian.Uint32(b)))
+	b = b[4:]
+	if count < 0 {
+		return nil, NewProtocolViolationErrorf("invalid multirange length: %d", count)
+	}
+	ranges := make([]*tree.DRange, 0, count)
+	for i := 0; i < count; i++ {
+		if len(b) < 4 {
+			return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
+		}
+		n := int(int32(binary.BigEndian.Uint32(b)))
+		b = b[4:]
+		if n < 0 || n > len(b) {
+			return nil, NewProtocolViolationErrorf("invalid range length: %d", n)
+		}
+		r, err := decodeBinaryRange(ctx, evalCtx, t.MultirangeContents(), b[:n], da)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+		b = b[n:]
+	}
+	return tree.NewDMultirange(t, ranges)
+}
+
 var invalidUTF8Error = pgerror.Newf(pgcode.CharacterNotInRepertoire, "invalid UTF-8 sequence")
 
 var (
@@ -1160,3 +1254,18 @@ const (
 	// AF_NET + 1.
 	PGBinaryIPv6family byte = 3
 )
+
+// The flags used in the Postgres binary format of a range. They are defined
+// as the RANGE_* flags in rangetypes.h.
+const (
+	// PGBinaryRangeEmpty indicates that the range is empty.
+	PGBinaryRangeEmpty byte = 0x01
+	// PGBinaryRangeLowerInc indicates that the lower bound is inclusive.
+	PGBinaryRangeLowerInc byte = 0x02
+	// PGBinaryRangeUpperInc indicates that the upper bound is inclusive.
+	PGBinaryRangeUpperInc byte = 0x04
+	// PGBinaryRangeLowerInf indicates that the lower bound is infinite.
+	PGBinaryRangeLowerInf byte = 0x08
+	// PGBinaryRangeUpperInf indicates that the upper bound is infinite.
+	PGBinaryRangeUpperInf byte = 0x10
+)
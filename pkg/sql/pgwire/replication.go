@@ -0,0 +1,75 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package pgwire
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+)
+
+// replicationFeedback forwards the messages sent by the client of a
+// replication stream to the connExecutor running the stream.
+type replicationFeedback struct {
+	// feedback holds the latest flush position reported by the client which
+	// has not been consumed by the stream yet.
+	feedback chan lsn.LSN
+	// clientDone is closed when the client ends the stream.
+	clientDone chan struct{}
+	once       sync.Once
+}
+
+func newReplicationFeedback() *replicationFeedback {
+	return &replicationFeedback{
+		feedback:   make(chan lsn.LSN, 1),
+		clientDone: make(chan struct{}),
+	}
+}
+
+// handleCopyData processes the payload of a CopyData message received from
+// the client.
+func (r *replicationFeedback) handleCopyData(data []byte) error {
+	if len(data) == 0 {
+		return pgwirebase.NewProtocolViolationErrorf("empty replication message")
+	}
+	switch data[0] {
+	case pgoutput.StandbyStatusUpdateMessage:
+		u, err := pgoutput.ParseStandbyStatusUpdate(data)
+		if err != nil {
+			return err
+		}
+		// Only the latest position matters, so replace any position the stream
+		// has not consumed yet. The network routine is the only sender.
+		select {
+		case <-r.feedback:
+		default:
+		}
+		r.feedback <- u.Flushed
+	case pgoutput.HotStandbyFeedbackMessage:
+		// Hot standby feedback is only meaningful for physical replication.
+	default:
+		return pgwirebase.NewProtocolViolationErrorf("unexpected replication message type %q", data[0])
+	}
+	return nil
+}
+
+// finish signals to the stream that the client is done with it.
+func (r *replicationFeedback) finish() {
+	r.once.Do(func() { close(r.clientDone) })
+}
+
+// handleReplicationMessage processes a message sent by the client while a
+// replication stream is running. CopyDone and CopyFail end the stream.
+func (c *conn) handleReplicationMessage(typ pgwirebase.ClientMessageType) error {
+	if typ == pgwirebase.ClientMsgCopyData {
+		return c.replication.handleCopyData(c.readBuf.Msg)
+	}
+	c.replication.finish()
+	c.replication = nil
+	return nil
+}
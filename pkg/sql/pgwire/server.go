@@ -1393,6 +1393,9 @@ func (s *Server) serveImpl(
 				return false, isSimpleQuery, c.handleFlush(ctx)
 
 			case pgwirebase.ClientMsgCopyData, pgwirebase.ClientMsgCopyDone, pgwirebase.ClientMsgCopyFail:
+				if c.replication != nil {
+					return false, isSimpleQuery, c.handleReplicationMessage(typ)
+				}
 				// We're supposed to ignore these messages, per the protocol spec. This
 				// state will happen when an error occurs on the server-side during a copy
 				// operation: the server will send an error and a ready message back to
@@ -1443,6 +1446,10 @@ func (s *Server) serveImpl(
 	c.stmtBuf.Close()
 	// Cancel the processor's context.
 	c.cancelConn()
+	// Stop any replication stream waiting for messages from the client.
+	if c.replication != nil {
+		c.replication.finish()
+	}
 	// In case the authenticator is blocked on waiting for data from the client,
 	// tell it that there's no more data coming. This is a no-op if authentication
 	// was completed already.
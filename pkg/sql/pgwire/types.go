@@ -55,6 +55,8 @@ type pgType struct {
 }
 
 func pgTypeForParserType(t *types.T) pgType {
+	// Postgres describes the values of a domain as values of its base type.
+	t = t.DomainBaseType()
 	size := tree.PGWireTypeSize(t)
 	tOid := t.Oid()
 	if tOid == oid.T_text && t.Width() > 0 {
@@ -128,6 +130,15 @@ func writeTextTimestampTZ(b *writeBuffer, v time.Time, sessionLoc *time.Location
 	b.write(s)
 }
 
+// domainBaseType returns the base type of t if t is a domain, since the values
+// of a domain are encoded as values of its base type. t may be nil.
+func domainBaseType(t *types.T) *types.T {
+	if t == nil {
+		return nil
+	}
+	return t.DomainBaseType()
+}
+
 // writeTextDatum writes d to the buffer. Type t must be specified for types
 // that have various width encodings and therefore need padding (chars).
 // It is ignored (and can be nil) for types which do not need padding.
@@ -160,7 +171,7 @@ func writeTextDatumNotNull(
 	sessionLoc *time.Location,
 	t *types.T,
 ) {
-
+	t = domainBaseType(t)
 	oldDCC := b.textFormatter.SetDataConversionConfig(conv)
 	oldLoc := b.textFormatter.SetLocation(sessionLoc)
 	defer func() {
@@ -272,6 +283,14 @@ func writeTextDatumNotNull(
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
+	case *tree.DMultirange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DArray:
 		// Arrays have custom formatting depending on their OID.
 		b.textFormatter.FormatNode(d)
@@ -321,7 +340,7 @@ func (b *writeBuffer) writeTextColumnarElement(
 		b.textFormatter.SetDataConversionConfig(oldDCC)
 		b.textFormatter.SetLocation(oldLoc)
 	}()
-	typ := vecs.Vecs[vecIdx].Type()
+	typ := vecs.Vecs[vecIdx].Type().DomainBaseType()
 	if log.V(2) {
 		log.Infof(ctx, "pgwire writing TEXT columnar element of type: %s", typ)
 	}
@@ -604,6 +623,44 @@ func writeBinaryJSON(b *writeBuffer, v json.JSON, t *types.T) {
 	b.writeString(s)
 }
 
+// writeBinaryRange writes the Postgres binary format of a range, which is a
+// flags byte followed by the length-prefixed binary encodings of the finite
+// bounds.
+func writeBinaryRange(
+	ctx context.Context, b *writeBuffer, r *tree.DRange, sessionLoc *time.Location,
+) {
+	initialLen := b.Len()
+	// Reserve bytes for writing length later.
+	b.putInt32(int32(0))
+	var flags byte
+	if r.Empty {
+		flags |= pgwirebase.PGBinaryRangeEmpty
+	} else {
+		if r.Lower.Inclusive {
+			flags |= pgwirebase.PGBinaryRangeLowerInc
+		}
+		if r.Upper.Inclusive {
+			flags |= pgwirebase.PGBinaryRangeUpperInc
+		}
+		if r.Lower.IsInf() {
+			flags |= pgwirebase.PGBinaryRangeLowerInf
+		}
+		if r.Upper.IsInf() {
+			flags |= pgwirebase.PGBinaryRangeUpperInf
+		}
+	}
+	b.writeByte(flags)
+	subtype := r.ResolvedType().RangeSubtype()
+	if !r.Empty && !r.Lower.IsInf() {
+		b.writeBinaryDatum(ctx, r.Lower.Val, sessionLoc, subtype)
+	}
+	if !r.Empty && !r.Upper.IsInf() {
+		b.writeBinaryDatum(ctx, r.Upper.Val, sessionLoc, subtype)
+	}
+	lengthToWrite := b.Len() - (initialLen + 4)
+	b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+}
+
 // writeBinaryDatum writes d to the buffer. Type t must be specified for types
 // that have various width encodings (floats, ints, chars). It is ignored
 // (and can be nil) for types with a 1:1 datum:type mapping.
@@ -628,6 +685,7 @@ func (b *writeBuffer) writeBinaryDatum(
 func writeBinaryDatumNotNull(
 	ctx context.Context, b *writeBuffer, d tree.Datum, sessionLoc *time.Location, t *types.T,
 ) {
+	t = domainBaseType(t)
 	switch v := tree.UnwrapDOidWrapper(d).(type) {
 	case *tree.DBitArray:
 		words, lastBitsUsed := v.EncodingParts()
@@ -820,6 +878,20 @@ func writeBinaryDatumNotNull(
 			b.putInt32(int32(math.Float32bits(f)))
 		}
 
+	case *tree.DRange:
+		writeBinaryRange(ctx, b, v, sessionLoc)
+
+	case *tree.DMultirange:
+		initialLen := b.Len()
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		b.putInt32(int32(len(v.Ranges)))
+		for _, r := range v.Ranges {
+			writeBinaryRange(ctx, b, r, sessionLoc)
+		}
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DArray:
 		if v.ParamTyp.Family() == types.ArrayFamily {
 			b.setError(unimplemented.NewWithIssueDetail(32552,
@@ -881,7 +953,7 @@ func writeBinaryDatumNotNull(
 func (b *writeBuffer) writeBinaryColumnarElement(
 	ctx context.Context, vecs *coldata.TypedVecs, vecIdx int, rowIdx int, sessionLoc *time.Location,
 ) {
-	typ := vecs.Vecs[vecIdx].Type()
+	typ := vecs.Vecs[vecIdx].Type().DomainBaseType()
 	if log.V(2) {
 		log.Infof(ctx, "pgwire writing BINARY columnar element of type: %s", typ)
 	}
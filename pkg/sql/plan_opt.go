@@ -72,14 +72,14 @@ func (p *planner) prepareUsingOptimizer(
 		*tree.CreateSequence,
 		*tree.CreateStats,
 		*tree.Deallocate, *tree.Discard, *tree.DropDatabase, *tree.DropIndex,
-		*tree.DropTable, *tree.DropView, *tree.DropSequence, *tree.DropType,
+		*tree.DropTable, *tree.DropView, *tree.DropSequence, *tree.DropType, *tree.DropDomain,
 		*tree.Grant, *tree.GrantRole,
 		*tree.Prepare, *tree.PrepareTransaction,
 		*tree.ReleaseSavepoint, *tree.RenameColumn, *tree.RenameDatabase,
 		*tree.RenameIndex, *tree.RenameTable, *tree.Revoke, *tree.RevokeRole,
 		*tree.RollbackPrepared, *tree.RollbackToSavepoint, *tree.RollbackTransaction,
 		*tree.Savepoint, *tree.SetTransaction, *tree.SetTracing, *tree.SetSessionAuthorizationDefault,
-		*tree.SetSessionCharacteristics:
+		*tree.SetSessionCharacteristics, *tree.SetConstraints:
 		// These statements do not have result columns and do not support placeholders
 		// so there is no need to do anything during prepare.
 		//
@@ -256,6 +256,7 @@ func (p *planner) makeOptimizerPlan(ctx context.Context) error {
 
 	opc := &p.optPlanningCtx
 	opc.reset(ctx)
+	p.eventTriggers = nil
 
 	execMemo, err := opc.buildExecMemo(ctx)
 	if err != nil {
@@ -846,6 +847,12 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 	if err := bld.Build(); err != nil {
 		return nil, err
 	}
+	if bld.HasEventTriggers {
+		// Capture the descriptors modified in the transaction before the
+		// statement, so that the event trigger functions can determine the
+		// objects it creates, alters and drops. DDL memos are never reused.
+		p.eventTriggers = newEventTriggerState(p.Descriptors(), opc.p.stmt.AST.StatementTag())
+	}
 
 	// For index recommendations, after building we must interrupt the flow to
 	// find potential index candidates in the memo.
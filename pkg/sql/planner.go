@@ -235,10 +235,18 @@ type planner struct {
 
 	sqlCursors sqlCursors
 
+	sessionListener sessionListener
+
+	deferredConstraints deferredConstraints
+
 	storedProcTxnState storedProcTxnStateAccessor
 
 	createdSequences createdSequences
 
+	// eventTriggers is set while executing a DDL statement which fires event
+	// triggers. It is reset when the next statement is planned.
+	eventTriggers *eventTriggerState
+
 	// autoCommit indicates whether the plan is allowed (but not required) to
 	// commit the transaction along with other KV operations. Committing the txn
 	// might be beneficial because it may enable the 1PC optimization. Note that
@@ -452,6 +460,8 @@ func newInternalPlanner(
 	p.queryCacheSession.Init()
 	p.optPlanningCtx.init(p)
 	p.sqlCursors = emptySqlCursors{}
+	p.sessionListener = emptySessionListener{}
+	p.deferredConstraints = emptyDeferredConstraints{}
 	p.preparedStatements = emptyPreparedStatements{}
 	p.createdSequences = emptyCreatedSequences{}
 
@@ -360,6 +360,85 @@ func (l *lexer) ReadIntegerForLoopControl() (plpgsqltree.ForLoopControl, error)
 	}, err
 }
 
+// ReadQueryForLoopControl reads the query of a FOR loop over the rows of a
+// query or a bound cursor, up to and including the LOOP keyword. The loop
+// iterates over a cursor when the query starts with an identifier rather than a
+// keyword. It returns nil if arguments are supplied for the cursor.
+func (l *lexer) ReadQueryForLoopControl() (plpgsqltree.ForLoopControl, error) {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it can be included.
+		l.PushBack(1)
+	}
+	startPos, endPos, _, err := l.readSQLConstruct(false /* isExpr */, false /* allowEmpty */, LOOP)
+	if err != nil {
+		return nil, err
+	}
+	// Move past the LOOP keyword.
+	l.lastPos++
+	if l.tokens[startPos].id == IDENT {
+		if endPos-startPos > 1 {
+			if l.tokens[startPos+1].id == '(' {
+				return nil, nil
+			}
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"syntax error at or near \"%s\"", l.tokens[startPos+1].str,
+			)
+		}
+		return &plpgsqltree.CursorForLoopControl{
+			CursorVar: plpgsqltree.Variable(l.tokens[startPos].str),
+		}, nil
+	}
+	sqlStmt, err := parser.ParseOne(l.getStr(startPos, endPos))
+	if err != nil {
+		return nil, err
+	}
+	if sqlStmt.AST.StatementReturnType() != tree.Rows {
+		return nil, pgerror.New(pgcode.Syntax, "FOR loop query must return rows")
+	}
+	return &plpgsqltree.QueryForLoopControl{Query: sqlStmt.AST}, nil
+}
+
+// ReadDynamicQueryForLoopControl reads the query string and parameters of a FOR
+// loop over the rows of a dynamic query, up to and including the LOOP keyword.
+// Syntax:
+//
+//	EXECUTE query_string [ USING expression [, ...] ] LOOP
+func (l *lexer) ReadDynamicQueryForLoopControl() (plpgsqltree.ForLoopControl, error) {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it can be included.
+		l.PushBack(1)
+	}
+	// Move past the EXECUTE keyword.
+	l.lastPos++
+	queryStr, terminator, err := l.ReadSqlExpr(LOOP, USING)
+	if err != nil {
+		return nil, err
+	}
+	l.lastPos++
+	query, err := l.ParseExpr(queryStr)
+	if err != nil {
+		return nil, err
+	}
+	var params []plpgsqltree.Expr
+	for terminator == USING || terminator == ',' {
+		var paramStr string
+		paramStr, terminator, err = l.ReadSqlExpr(LOOP, ',')
+		if err != nil {
+			return nil, err
+		}
+		l.lastPos++
+		param, err := l.ParseExpr(paramStr)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+	}
+	if terminator != LOOP {
+		return nil, errors.New("missing LOOP keyword")
+	}
+	return &plpgsqltree.DynamicQueryForLoopControl{Query: query, Params: params}, nil
+}
+
 // makeDoStmt analyzes and parses the options supplied to a DO statement.
 func makeDoStmt(options tree.DoBlockOptions) (*plpgsqltree.DoBlock, error) {
 	doBlockBodyStr, err := tree.AnalyzeDoBlockOptions(options)
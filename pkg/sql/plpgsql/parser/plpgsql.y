@@ -1106,7 +1106,24 @@ for_control:
 	    }
 	    $$.val = forLoopControl
 	  case LOOP:
-	    return unimplemented(plpgsqllex, "for loop over query or cursor")
+	    if plpgsqllex.(*lexer).Peek().id == EXECUTE {
+	      // This is an iteration over the rows of a dynamic query.
+	      forLoopControl, err := plpgsqllex.(*lexer).ReadDynamicQueryForLoopControl()
+	      if err != nil {
+	        return setErr(plpgsqllex, err)
+	      }
+	      $$.val = forLoopControl
+	    } else {
+	      // This is an iteration over the rows of a query or cursor.
+	      forLoopControl, err := plpgsqllex.(*lexer).ReadQueryForLoopControl()
+	      if err != nil {
+	        return setErr(plpgsqllex, err)
+	      }
+	      if forLoopControl == nil {
+	        return unimplemented(plpgsqllex, "cursor arguments")
+	      }
+	      $$.val = forLoopControl
+	    }
 	  default:
 	    return setErr(plpgsqllex, errors.New("unterminated FOR loop definition"))
 	  }
@@ -0,0 +1,261 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+)
+
+type createPublicationNode struct {
+	zeroInputPlanNode
+	n    *tree.CreatePublication
+	desc *dbdesc.Mutable
+}
+
+// CreatePublication creates a publication in the current database. Publications
+// are stored in the database descriptor.
+func (p *planner) CreatePublication(
+	ctx context.Context, n *tree.CreatePublication,
+) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V25_2_AddPublicationsAndSubscriptions) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE PUBLICATION unsupported in mixed-version cluster")
+	}
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), "CREATE PUBLICATION"); err != nil {
+		return nil, err
+	}
+	desc, err := p.mutableCurrentDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &createPublicationNode{n: n, desc: desc}, nil
+}
+
+func (n *createPublicationNode) startExec(params runParams) error {
+	p := params.p
+	ctx := params.ctx
+	name := string(n.n.Name)
+
+	if err := p.CheckPrivilege(ctx, n.desc, privilege.CREATE); err != nil {
+		return err
+	}
+	if _, ok := n.desc.GetPublication(name); ok {
+		return pgerror.Newf(pgcode.DuplicateObject, "publication %q already exists", name)
+	}
+
+	pub := descpb.DatabaseDescriptor_PublicationInfo{
+		AllTables:  n.n.AllTables,
+		OwnerProto: p.User().EncodeProto(),
+	}
+	if n.n.AllTables {
+		// As in PostgreSQL, publishing every table, including tables which do not
+		// exist yet, requires superuser privileges.
+		isAdmin, err := p.HasAdminRole(ctx)
+		if err != nil {
+			return err
+		}
+		if !isAdmin {
+			return pgerror.New(pgcode.InsufficientPrivilege,
+				"must be admin to create FOR ALL TABLES publication")
+		}
+	}
+	var seen catalog.DescriptorIDSet
+	for i := range n.n.Tables {
+		tn := n.n.Tables[i].ToUnresolvedObjectName()
+		table, err := p.ResolveExistingObjectEx(ctx, tn, true /* required */, tree.ResolveRequireTableDesc)
+		if err != nil {
+			return err
+		}
+		if table.GetParentID() != n.desc.GetID() {
+			return pgerror.Newf(pgcode.InvalidObjectDefinition,
+				"cannot add relation %q to publication: it is not in database %q",
+				table.GetName(), n.desc.GetName())
+		}
+		if table.IsTemporary() {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"cannot add relation %q to publication: temporary tables cannot be replicated",
+				table.GetName())
+		}
+		if err := checkReplicatedTable(table); err != nil {
+			return err
+		}
+		hasOwnership, err := p.HasOwnership(ctx, table)
+		if err != nil {
+			return err
+		}
+		if !hasOwnership {
+			return pgerror.Newf(pgcode.InsufficientPrivilege,
+				"must be owner of table %s", table.GetName())
+		}
+		if seen.Contains(table.GetID()) {
+			continue
+		}
+		seen.Add(table.GetID())
+		pub.TableIDs = append(pub.TableIDs, table.GetID())
+	}
+
+	n.desc.AddPublication(name, pub)
+	if err := p.writeNonDropDatabaseChange(
+		ctx, n.desc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("publication"))
+	return nil
+}
+
+func (n *createPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *createPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *createPublicationNode) Close(context.Context)        {}
+
+type dropPublicationNode struct {
+	zeroInputPlanNode
+	n    *tree.DropPublication
+	desc *dbdesc.Mutable
+}
+
+// DropPublication drops publications from the current database.
+func (p *planner) DropPublication(
+	ctx context.Context, n *tree.DropPublication,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), "DROP PUBLICATION"); err != nil {
+		return nil, err
+	}
+	desc, err := p.mutableCurrentDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dropPublicationNode{n: n, desc: desc}, nil
+}
+
+func (n *dropPublicationNode) startExec(params runParams) error {
+	p := params.p
+	ctx := params.ctx
+
+	var dropped bool
+	for _, tn := range n.n.Names {
+		name := string(tn)
+		pub, ok := n.desc.GetPublication(name)
+		if !ok {
+			if n.n.IfExists {
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject, "publication %q does not exist", name)
+		}
+		if owner := pub.OwnerProto.Decode(); owner != p.User() {
+			isAdmin, err := p.HasAdminRole(ctx)
+			if err != nil {
+				return err
+			}
+			if !isAdmin {
+				return pgerror.Newf(pgcode.InsufficientPrivilege,
+					"must be owner of publication %s", name)
+			}
+		}
+		n.desc.RemovePublication(name)
+		dropped = true
+	}
+	if !dropped {
+		return nil
+	}
+
+	if err := p.writeNonDropDatabaseChange(
+		ctx, n.desc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("publication"))
+	return nil
+}
+
+func (n *dropPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropPublicationNode) Close(context.Context)        {}
+
+// removeTableFromPublications removes a table which is being dropped from the
+// publications of its database which list it.
+func (p *planner) removeTableFromPublications(
+	ctx context.Context, tableDesc catalog.TableDescriptor,
+) error {
+	db, err := p.Descriptors().ByIDWithoutLeased(p.txn).Get().Database(ctx, tableDesc.GetParentID())
+	if err != nil {
+		return err
+	}
+	if db.Dropped() || !db.IsTableInPublications(tableDesc.GetID()) {
+		return nil
+	}
+	mut, err := p.Descriptors().MutableByID(p.txn).Database(ctx, db.GetID())
+	if err != nil {
+		return err
+	}
+	mut.RemoveTableFromPublications(tableDesc.GetID())
+	b := p.Txn().NewBatch()
+	if err := p.writeDatabaseChangeToBatch(ctx, mut, b); err != nil {
+		return err
+	}
+	return p.Txn().Run(ctx, b)
+}
+
+// mutableCurrentDatabase returns a mutable descriptor for the current
+// database.
+func (p *planner) mutableCurrentDatabase(ctx context.Context) (*dbdesc.Mutable, error) {
+	if p.CurrentDatabase() == "" {
+		return nil, sqlerrors.ErrNoDatabase
+	}
+	return p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+}
+
+// isPublishableTable returns whether changes to the table can be published.
+func isPublishableTable(table catalog.TableDescriptor) bool {
+	return table.IsTable() && !table.IsVirtualTable() && !table.IsTemporary() && table.Public()
+}
+
+// publicationTables returns the tables of the database included in the
+// publication. Tables which are not public are skipped.
+func publicationTables(
+	ctx context.Context,
+	col *descs.Collection,
+	txn *kv.Txn,
+	db catalog.DatabaseDescriptor,
+	pub descpb.DatabaseDescriptor_PublicationInfo,
+) ([]catalog.TableDescriptor, error) {
+	all, err := col.GetAllTablesInDatabase(ctx, txn, db)
+	if err != nil {
+		return nil, err
+	}
+	var ids catalog.DescriptorIDSet
+	for _, id := range pub.TableIDs {
+		ids.Add(id)
+	}
+	var tables []catalog.TableDescriptor
+	if err := all.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		table, ok := desc.(catalog.TableDescriptor)
+		if !ok || !isPublishableTable(table) {
+			return nil
+		}
+		if pub.AllTables || ids.Contains(table.GetID()) {
+			tables = append(tables, table)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
@@ -344,11 +344,51 @@ func RandDatumWithNullChance(
 			maxDim = 50
 		}
 		return tree.NewDPGVector(vector.Random(rng, maxDim))
+	case types.RangeFamily:
+		return randRange(rng, typ)
+	case types.MultirangeFamily:
+		ranges := make([]*tree.DRange, rng.Intn(4))
+		for i := range ranges {
+			ranges[i] = randRange(rng, typ.MultirangeContents())
+		}
+		d, err := tree.NewDMultirange(typ, ranges)
+		if err != nil {
+			panic(err)
+		}
+		return d
 	default:
 		panic(errors.AssertionFailedf("invalid type %v", typ.DebugString()))
 	}
 }
 
+// randRange generates a random DRange of the given range type. Each bound is
+// infinite with a 1 in 8 chance.
+func randRange(rng *rand.Rand, typ *types.T) *tree.DRange {
+	if rng.Intn(10) == 0 {
+		return tree.NewEmptyDRange(typ)
+	}
+	randBound := func() tree.RangeBound {
+		if rng.Intn(8) == 0 {
+			return tree.RangeBound{}
+		}
+		return tree.RangeBound{
+			Val:       RandDatum(rng, typ.RangeSubtype(), false /* nullOk */),
+			Inclusive: rng.Intn(2) == 0,
+		}
+	}
+	lower, upper := randBound(), randBound()
+	if r, err := tree.NewDRange(typ, lower, upper); err == nil {
+		return r
+	}
+	// The bounds are out of order, or canonicalizing them overflowed the
+	// subtype.
+	lower.Val, upper.Val = upper.Val, lower.Val
+	if r, err := tree.NewDRange(typ, lower, upper); err == nil {
+		return r
+	}
+	return tree.NewEmptyDRange(typ)
+}
+
 // RandArray generates a random DArray where the contents have nullChance
 // of being null.
 func RandArray(rng *rand.Rand, typ *types.T, nullChance int) tree.Datum {
@@ -41,7 +41,7 @@ func init() {
 			// Temporarily don't include this.
 			// TODO(msirek): Remove this exclusion once
 			// https://github.com/cockroachdb/cockroach/issues/55791 is fixed.
-		case oid.T_unknown, oid.T_anyelement, oid.T_any, oid.T_trigger:
+		case oid.T_unknown, oid.T_anyelement, oid.T_any, oid.T_trigger, oid.T_event_trigger:
 			// Don't include these.
 		case oid.T_float4:
 			// Don't include FLOAT4 due to known bugs that cause test failures.
@@ -0,0 +1,265 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package rangefeedfilter implements the evaluation of the row filters and
+// projections of rangefeeds (kvpb.RangeFeedFilter). It's injected into
+// kvserver/rangefeed, which can't depend on the SQL layer.
+package rangefeedfilter
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	rangefeed.NewRowFilter = newRowFilter
+}
+
+// MakeFilter returns a kvpb.RangeFeedFilter on the primary index of the given
+// table. The predicate refers to the given columns by ordinal, with @1 being
+// the first one. The returned filter can be further restricted to some column
+// families, and to a projection of the columns, by setting its FamilyIDs and
+// ColumnIDs.
+func MakeFilter(
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	fetchColumnIDs []descpb.ColumnID,
+	predicate string,
+) (*kvpb.RangeFeedFilter, error) {
+	f := &kvpb.RangeFeedFilter{Predicate: predicate}
+	if err := rowenc.InitIndexFetchSpec(
+		&f.IndexFetchSpec, codec, desc, desc.GetPrimaryIndex(), fetchColumnIDs,
+	); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// rowFilter implements rangefeed.RowFilter.
+type rowFilter struct {
+	// indexPrefix is the prefix of the keys of the index, without the tenant
+	// prefix. The keys outside of the index are never filtered nor projected.
+	indexPrefix roachpb.Key
+	// families is the set of column families to emit, if not empty.
+	families map[catid.FamilyID]struct{}
+	// columns is the projection, if not empty.
+	columns map[catid.ColumnID]struct{}
+
+	// mu protects the decoding and the evaluation of the predicate, which are
+	// not safe for concurrent use. It's nil if there is no predicate.
+	mu *struct {
+		syncutil.Mutex
+		fetcher row.Fetcher
+		expr    execinfrapb.ExprHelper
+	}
+}
+
+var _ rangefeed.RowFilter = (*rowFilter)(nil)
+
+func newRowFilter(
+	ctx context.Context, st *cluster.Settings, filter *kvpb.RangeFeedFilter,
+) (rangefeed.RowFilter, error) {
+	spec := &filter.IndexFetchSpec
+	if spec.IsSecondaryIndex {
+		return nil, errors.Newf(
+			"rangefeed filter on secondary index %s@%s is not supported", spec.TableName, spec.IndexName,
+		)
+	}
+	f := &rowFilter{
+		indexPrefix: keys.SystemSQLCodec.IndexPrefix(uint32(spec.TableID), uint32(spec.IndexID)),
+	}
+	if len(filter.FamilyIDs) > 0 {
+		f.families = make(map[catid.FamilyID]struct{}, len(filter.FamilyIDs))
+		for _, id := range filter.FamilyIDs {
+			f.families[id] = struct{}{}
+		}
+	}
+	if len(filter.ColumnIDs) > 0 {
+		f.columns = make(map[catid.ColumnID]struct{}, len(filter.ColumnIDs))
+		for _, id := range filter.ColumnIDs {
+			f.columns[id] = struct{}{}
+		}
+	}
+	if filter.Predicate == "" {
+		return f, nil
+	}
+
+	f.mu = &struct {
+		syncutil.Mutex
+		fetcher row.Fetcher
+		expr    execinfrapb.ExprHelper
+	}{}
+	// Only the columns of the family of a value are decoded, so the other
+	// non-nullable columns are NULL.
+	f.mu.fetcher.IgnoreUnexpectedNulls = true
+	if err := f.mu.fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &tree.DatumAlloc{},
+		Spec:              spec,
+	}); err != nil {
+		return nil, err
+	}
+	colTypes := make([]*types.T, len(spec.FetchedColumns))
+	for i := range spec.FetchedColumns {
+		colTypes[i] = spec.FetchedColumns[i].Type
+	}
+	if err := validateOrdinals(filter.Predicate, len(colTypes)); err != nil {
+		return nil, err
+	}
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	semaCtx.Properties.Require("rangefeed filters",
+		tree.RejectSpecial|tree.RejectSubqueries|tree.RejectVolatileFunctions|tree.RejectStableOperators)
+	evalCtx := &eval.Context{
+		SessionDataStack: sessiondata.NewStack(&sessiondata.SessionData{}),
+		Settings:         st,
+	}
+	if err := f.mu.expr.Init(
+		ctx, execinfrapb.Expression{Expr: filter.Predicate}, colTypes, &semaCtx, evalCtx,
+	); err != nil {
+		return nil, errors.Wrapf(err, "invalid rangefeed filter predicate %q", filter.Predicate)
+	}
+	if typ := f.mu.expr.Expr().ResolvedType(); typ.Family() != types.BoolFamily &&
+		typ.Family() != types.UnknownFamily {
+		return nil, errors.Newf(
+			"rangefeed filter predicate %q must be of type bool, not %s", filter.Predicate, typ,
+		)
+	}
+	return f, nil
+}
+
+// validateOrdinals returns an error if the given predicate refers to columns
+// that are not fetched, which the ExprHelper doesn't check.
+func validateOrdinals(predicate string, numCols int) error {
+	expr, err := parser.ParseExpr(predicate)
+	if err != nil {
+		return errors.Wrapf(err, "invalid rangefeed filter predicate %q", predicate)
+	}
+	v := ordinalValidator{numCols: numCols}
+	tree.WalkExprConst(&v, expr)
+	return v.err
+}
+
+type ordinalValidator struct {
+	numCols int
+	err     error
+}
+
+var _ tree.Visitor = (*ordinalValidator)(nil)
+
+// VisitPre implements the tree.Visitor interface.
+func (v *ordinalValidator) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if iv, ok := expr.(*tree.IndexedVar); ok && (iv.Idx < 0 || iv.Idx >= v.numCols) {
+		v.err = errors.Newf("rangefeed filter predicate refers to column @%d, but only "+
+			"%d columns are fetched", iv.Idx+1, v.numCols)
+	}
+	return v.err == nil, expr
+}
+
+// VisitPost implements the tree.Visitor interface.
+func (v *ordinalValidator) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// inIndex returns whether the given key belongs to the filtered index.
+func (f *rowFilter) inIndex(key roachpb.Key) bool {
+	sqlKey, _, err := keys.DecodeTenantPrefix(key)
+	return err == nil && bytes.HasPrefix(sqlKey, f.indexPrefix)
+}
+
+// Matches implements the rangefeed.RowFilter interface.
+func (f *rowFilter) Matches(ctx context.Context, key roachpb.Key, value roachpb.Value) (bool, error) {
+	if !f.inIndex(key) {
+		return true, nil
+	}
+	if f.families != nil {
+		familyID, err := keys.DecodeFamilyKey(key)
+		if err != nil {
+			return false, err
+		}
+		if _, ok := f.families[catid.FamilyID(familyID)]; !ok {
+			return false, nil
+		}
+	}
+	if f.mu == nil || !value.IsPresent() {
+		return true, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.mu.fetcher.ConsumeKVProvider(ctx, &row.KVProvider{
+		KVs: []roachpb.KeyValue{{Key: key, Value: value}},
+	}); err != nil {
+		return false, err
+	}
+	encRow, _, err := f.mu.fetcher.NextRow(ctx)
+	if err != nil || encRow == nil {
+		return true, err
+	}
+	return f.mu.expr.EvalFilter(ctx, encRow)
+}
+
+// Project implements the rangefeed.RowFilter interface.
+func (f *rowFilter) Project(
+	ctx context.Context, key roachpb.Key, value roachpb.Value,
+) (roachpb.Value, error) {
+	if f.columns == nil || value.GetTag() != roachpb.ValueType_TUPLE || !f.inIndex(key) {
+		return value, nil
+	}
+	b, err := value.GetTuple()
+	if err != nil {
+		return roachpb.Value{}, err
+	}
+	projected, err := projectTuple(b, f.columns)
+	if err != nil {
+		return roachpb.Value{}, err
+	}
+	ret := roachpb.Value{Timestamp: value.Timestamp}
+	ret.SetTuple(projected)
+	return ret, nil
+}
+
+// projectTuple returns the given tuple-encoded column family value with only
+// the given columns. The values of the columns are copied as is, only the
+// column ID deltas of their tags are re-encoded.
+func projectTuple(b []byte, columns map[catid.ColumnID]struct{}) ([]byte, error) {
+	var ret []byte
+	var colID, lastColID catid.ColumnID
+	for len(b) > 0 {
+		_, dataOffset, colIDDelta, typ, err := encoding.DecodeValueTag(b)
+		if err != nil {
+			return nil, err
+		}
+		n, err := encoding.PeekValueLengthWithOffsetsAndType(b, dataOffset, typ)
+		if err != nil {
+			return nil, err
+		}
+		colID += catid.ColumnID(colIDDelta)
+		if _, ok := columns[colID]; ok {
+			ret = encoding.EncodeValueTag(ret, uint32(colID-lastColID), typ)
+			ret = append(ret, b[dataOffset:n]...)
+			lastColID = colID
+		}
+		b = b[n:]
+	}
+	return ret, nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package rangefeedfilter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+const testTableID = 104
+
+// makeTestTable returns the descriptor of the following table:
+//
+//	CREATE TABLE t (
+//	  a INT PRIMARY KEY, b STRING, c INT, d INT,
+//	  FAMILY f0 (a, b, c), FAMILY f1 (d)
+//	)
+func makeTestTable() catalog.TableDescriptor {
+	return tabledesc.NewBuilder(&descpb.TableDescriptor{
+		ID:   testTableID,
+		Name: "t",
+		Columns: []descpb.ColumnDescriptor{
+			{ID: 1, Name: "a", Type: types.Int},
+			{ID: 2, Name: "b", Type: types.String, Nullable: true},
+			{ID: 3, Name: "c", Type: types.Int, Nullable: true},
+			{ID: 4, Name: "d", Type: types.Int, Nullable: true},
+		},
+		NextColumnID: 5,
+		Families: []descpb.ColumnFamilyDescriptor{
+			{ID: 0, Name: "f0", ColumnIDs: []descpb.ColumnID{1, 2, 3}, ColumnNames: []string{"a", "b", "c"}},
+			{ID: 1, Name: "f1", ColumnIDs: []descpb.ColumnID{4}, ColumnNames: []string{"d"}, DefaultColumnID: 4},
+		},
+		NextFamilyID: 2,
+		PrimaryIndex: descpb.IndexDescriptor{
+			ID:                  1,
+			Name:                "t_pkey",
+			Unique:              true,
+			KeyColumnIDs:        []descpb.ColumnID{1},
+			KeyColumnNames:      []string{"a"},
+			KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC},
+			StoreColumnIDs:      []descpb.ColumnID{2, 3, 4},
+			StoreColumnNames:    []string{"b", "c", "d"},
+			EncodingType:        catenumpb.PrimaryIndexEncoding,
+		},
+		NextIndexID: 2,
+	}).BuildImmutableTable()
+}
+
+// makeKey returns the key of the given column family of the row a.
+func makeKey(a int64, familyID uint32) roachpb.Key {
+	key := keys.SystemSQLCodec.IndexPrefix(testTableID, 1)
+	key = encoding.EncodeVarintAscending(key, a)
+	return keys.MakeFamilyKey(key, familyID)
+}
+
+// makeF0Value returns the value of family f0 of a row, omitting the NULL
+// columns.
+func makeF0Value(t *testing.T, b tree.Datum, c tree.Datum) roachpb.Value {
+	var buf []byte
+	var lastColID descpb.ColumnID
+	var err error
+	for i, d := range []tree.Datum{b, c} {
+		if d == tree.DNull {
+			continue
+		}
+		colID := descpb.ColumnID(i + 2)
+		buf, err = valueside.Encode(buf, valueside.MakeColumnIDDelta(lastColID, colID), d)
+		require.NoError(t, err)
+		lastColID = colID
+	}
+	v := roachpb.Value{Timestamp: hlc.Timestamp{WallTime: 10}}
+	v.SetTuple(buf)
+	return v
+}
+
+func TestRowFilter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	desc := makeTestTable()
+
+	spec, err := MakeFilter(keys.SystemSQLCodec, desc, []descpb.ColumnID{1, 3, 4}, "@1 > 1 AND @2 = 42")
+	require.NoError(t, err)
+	spec.FamilyIDs = []descpb.FamilyID{0}
+	spec.ColumnIDs = []descpb.ColumnID{3}
+	f, err := newRowFilter(ctx, st, spec)
+	require.NoError(t, err)
+
+	matching := makeF0Value(t, tree.NewDString("foo"), tree.NewDInt(42))
+	for _, tc := range []struct {
+		name     string
+		key      roachpb.Key
+		value    roachpb.Value
+		expected bool
+	}{
+		{name: "match", key: makeKey(2, 0), value: matching, expected: true},
+		{name: "key mismatch", key: makeKey(1, 0), value: matching, expected: false},
+		{
+			name:     "value mismatch",
+			key:      makeKey(2, 0),
+			value:    makeF0Value(t, tree.NewDString("foo"), tree.NewDInt(43)),
+			expected: false,
+		},
+		{
+			name:     "null",
+			key:      makeKey(2, 0),
+			value:    makeF0Value(t, tree.NewDString("foo"), tree.DNull),
+			expected: false,
+		},
+		{name: "deletion", key: makeKey(1, 0), value: roachpb.Value{}, expected: true},
+		{name: "other family", key: makeKey(2, 1), value: roachpb.MakeValueFromString("x"), expected: false},
+		{name: "other family deletion", key: makeKey(2, 1), value: roachpb.Value{}, expected: false},
+		{
+			name:     "other index",
+			key:      keys.SystemSQLCodec.IndexPrefix(testTableID, 2),
+			value:    roachpb.MakeValueFromString("x"),
+			expected: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			matches, err := f.Matches(ctx, tc.key, tc.value)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, matches)
+		})
+	}
+
+	t.Run("project", func(t *testing.T) {
+		projected, err := f.Project(ctx, makeKey(2, 0), matching)
+		require.NoError(t, err)
+		require.Equal(t, matching.Timestamp, projected.Timestamp)
+		require.Equal(t, makeF0Value(t, tree.DNull, tree.NewDInt(42)), projected)
+	})
+
+	t.Run("invalid predicate", func(t *testing.T) {
+		for _, predicate := range []string{"@2", "@5 = 1", "random() > 0.5", "@2 = (SELECT 1)"} {
+			spec.Predicate = predicate
+			_, err := newRowFilter(ctx, st, spec)
+			require.Error(t, err, predicate)
+		}
+	})
+}
+
+func TestProjectTuple(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var b []byte
+	var err error
+	datums := []tree.Datum{
+		tree.NewDInt(1), tree.NewDString("foo"), tree.DNull, tree.NewDFloat(1.5), tree.DBoolTrue,
+	}
+	for i, d := range datums {
+		var delta valueside.ColumnIDDelta = 1
+		if i == 0 {
+			delta = 2
+		}
+		b, err = valueside.Encode(b, delta, d)
+		require.NoError(t, err)
+	}
+
+	for _, tc := range []struct {
+		columns  []descpb.ColumnID
+		expected []tree.Datum
+	}{
+		{columns: []descpb.ColumnID{2, 3, 4, 5, 6}, expected: datums},
+		{columns: []descpb.ColumnID{3, 6}, expected: []tree.Datum{tree.NewDString("foo"), tree.DBoolTrue}},
+		{columns: []descpb.ColumnID{4}, expected: []tree.Datum{tree.DNull}},
+		{columns: []descpb.ColumnID{7}, expected: nil},
+	} {
+		columns := make(map[descpb.ColumnID]struct{})
+		for _, id := range tc.columns {
+			columns[id] = struct{}{}
+		}
+		projected, err := projectTuple(b, columns)
+		require.NoError(t, err)
+
+		var expected []byte
+		var lastColID descpb.ColumnID
+		for i, d := range tc.expected {
+			expected, err = valueside.Encode(
+				expected, valueside.MakeColumnIDDelta(lastColID, tc.columns[i]), d,
+			)
+			require.NoError(t, err)
+			lastColID = tc.columns[i]
+		}
+		require.Equal(t, expected, projected, "%v", tc.columns)
+	}
+}
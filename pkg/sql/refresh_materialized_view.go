@@ -32,6 +32,10 @@ func (p *planner) RefreshMaterializedView(
 	if !desc.MaterializedView() {
 		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a materialized view", desc.Name)
 	}
+	if n.RefreshDataOption == tree.RefreshDataIncremental && n.AsOf.Expr != nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"AS OF SYSTEM TIME cannot be used with an incremental refresh")
+	}
 
 	hasOwnership, err := p.HasOwnership(ctx, desc)
 	if err != nil {
@@ -85,6 +89,15 @@ func (n *refreshMaterializedViewNode) startExec(params runParams) error {
 		}
 	}
 
+	// An incremental refresh applies the changes to the base tables of the view
+	// directly to its existing indexes. If the view cannot be refreshed that
+	// way, we fall back to recomputing it in full.
+	if n.n.RefreshDataOption == tree.RefreshDataIncremental {
+		if refreshed, err := params.p.refreshMaterializedViewIncrementally(params.ctx, desc); err != nil || refreshed {
+			return err
+		}
+	}
+
 	// Prepare the new set of indexes by cloning all existing indexes on the view.
 	newPrimaryIndex := desc.GetPrimaryIndex().IndexDescDeepCopy()
 	newIndexes := make([]descpb.IndexDescriptor, len(desc.PublicNonPrimaryIndexes()))
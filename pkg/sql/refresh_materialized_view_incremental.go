@@ -0,0 +1,816 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// incrementalRefreshMaxChangedRows bounds the number of changed base table
+// rows that an incremental refresh of a materialized view applies as a delta.
+// The deltas are buffered in memory, so refreshes that would need to process
+// more changed rows than this recompute the full view query instead.
+var incrementalRefreshMaxChangedRows = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.materialized_view.incremental_refresh.max_changed_rows",
+	"the maximum number of changed base table rows that an incremental "+
+		"materialized view refresh applies as a delta before falling back to a "+
+		"full refresh",
+	10000,
+	settings.NonNegativeInt,
+)
+
+// materializedViewMutationOverride is the session data override used by the
+// statements that write the deltas of an incremental refresh into the view.
+var materializedViewMutationOverride = sessiondata.InternalExecutorOverride{
+	User:                           username.NodeUserName(),
+	AllowMaterializedViewMutations: true,
+}
+
+// incrementalViewShape describes the query of a materialized view that can
+// be refreshed incrementally: a single SELECT block over tables combined with
+// inner joins, with optional filters, projections, GROUP BY and aggregates.
+type incrementalViewShape struct {
+	// sel is the SELECT block of the view query.
+	sel *tree.SelectClause
+	// tables are the tables in the FROM clause, in order of appearance. A table
+	// that is joined with itself appears once per reference.
+	tables []incrementalViewTable
+	// grouped is true if the view query groups or aggregates its input.
+	grouped bool
+	// groupCols are the ordinals of the view columns that hold the GROUP BY
+	// expressions, in the order of sel.GroupBy.
+	groupCols []int
+}
+
+// incrementalViewTable is a reference to a table in the FROM clause of an
+// incrementally refreshable view query.
+type incrementalViewTable struct {
+	desc catalog.TableDescriptor
+	// qualifier is the name that qualifies the columns of this reference to the
+	// table within the view query; either its alias or its table name.
+	qualifier *tree.UnresolvedObjectName
+}
+
+// refreshMaterializedViewIncrementally brings the contents of a materialized
+// view up to date by applying only the changes made to its base tables since
+// the view was last refreshed, within the current transaction.
+//
+// The changed rows are found with an incremental export of the primary index
+// of each base table, which only visits the MVCC versions written since the
+// last refresh. Every row of the view query that is derived from a changed
+// base row is recomputed as of the last refresh and as of now; the rows of a
+// join that only involve unchanged base rows are the same at both timestamps
+// and need not be visited. For a grouped view, the groups that contain a
+// changed row before or after the change are recomputed in full.
+//
+// If the view cannot be refreshed from its deltas, for instance because it
+// has never been populated, the primary index of a base table was replaced,
+// or too many rows changed, a notice is sent and false is returned, in which case the caller
+// must fall back to a full refresh.
+func (p *planner) refreshMaterializedViewIncrementally(
+	ctx context.Context, view *tabledesc.Mutable,
+) (refreshed bool, _ error) {
+	shape, err := p.analyzeIncrementalView(ctx, view)
+	if err != nil {
+		return false, err
+	}
+	fullRefresh := func(reason string) (bool, error) {
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"materialized view %q will be fully refreshed: %s", view.GetName(), reason,
+		))
+		return false, nil
+	}
+	if view.IsRefreshViewRequired() || view.MaterializedViewRefreshedAt == nil {
+		return fullRefresh("the view has no recorded refresh timestamp")
+	}
+	from := *view.MaterializedViewRefreshedAt
+	to := p.Txn().ReadTimestamp()
+	if to.LessEq(from) {
+		return true, nil
+	}
+	for _, tab := range shape.tables {
+		if replaced, err := p.primaryIndexReplacedSince(ctx, tab.desc, from); err != nil {
+			return false, err
+		} else if replaced {
+			return fullRefresh(fmt.Sprintf(
+				"the primary index of table %q changed since the last refresh", tab.desc.GetName(),
+			))
+		}
+	}
+
+	limit := int(incrementalRefreshMaxChangedRows.Get(&p.ExecCfg().Settings.SV))
+	changed := make(map[descpb.ID][]tree.Datums, len(shape.tables))
+	numChanged := 0
+	for _, tab := range shape.tables {
+		if _, ok := changed[tab.desc.GetID()]; ok {
+			continue
+		}
+		pks, reason, err := p.changedPrimaryKeys(ctx, tab.desc, from, to, limit-numChanged)
+		if err != nil {
+			return false, err
+		}
+		if reason != "" {
+			return fullRefresh(reason)
+		}
+		changed[tab.desc.GetID()] = pks
+		numChanged += len(pks)
+	}
+
+	view.MaterializedViewRefreshedAt = &to
+	if err := p.writeTableDesc(ctx, view); err != nil {
+		return false, err
+	}
+	if numChanged == 0 {
+		return true, nil
+	}
+	if shape.grouped {
+		return true, p.applyGroupedViewDelta(ctx, view, shape, changed, from, to)
+	}
+	return true, p.applyViewDelta(ctx, view, shape, changed, from, to)
+}
+
+// analyzeIncrementalView returns the shape of the view query, or an error if
+// the view cannot be refreshed incrementally.
+func (p *planner) analyzeIncrementalView(
+	ctx context.Context, view catalog.TableDescriptor,
+) (*incrementalViewShape, error) {
+	stmt, err := parser.ParseOne(view.GetViewQuery())
+	if err != nil {
+		return nil, err
+	}
+	a := incrementalViewAnalyzer{p: p, view: view}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok || sel.With != nil || sel.Limit != nil || sel.Locking != nil {
+		return nil, a.unsupported("the view query must be a single SELECT block without WITH, LIMIT or locking clauses")
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok {
+		return nil, a.unsupported("the view query must be a single SELECT block")
+	}
+	switch {
+	case clause.Distinct || clause.DistinctOn != nil:
+		return nil, a.unsupported("DISTINCT is not supported")
+	case clause.Window != nil:
+		return nil, a.unsupported("window functions are not supported")
+	case len(clause.From.Tables) == 0:
+		return nil, a.unsupported("the view query must read from a table")
+	}
+	a.shape = &incrementalViewShape{sel: clause}
+	for _, t := range clause.From.Tables {
+		if err := a.addTables(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+	for i := range clause.Exprs {
+		if err := a.checkExpr(ctx, clause.Exprs[i].Expr); err != nil {
+			return nil, err
+		}
+	}
+	if clause.Where != nil {
+		if err := a.checkExpr(ctx, clause.Where.Expr); err != nil {
+			return nil, err
+		}
+	}
+	if clause.Having != nil {
+		if err := a.checkExpr(ctx, clause.Having.Expr); err != nil {
+			return nil, err
+		}
+	}
+	for _, g := range clause.GroupBy {
+		if err := a.checkExpr(ctx, g); err != nil {
+			return nil, err
+		}
+	}
+	if len(clause.GroupBy) == 0 && clause.Having == nil && !a.hasAggregate {
+		return a.shape, nil
+	}
+
+	// The view rows of a group are found by the values of its grouping
+	// expressions, so each of them must be one of the view columns.
+	a.shape.grouped = true
+	for i := range clause.Exprs {
+		switch t := clause.Exprs[i].Expr.(type) {
+		case tree.UnqualifiedStar, *tree.AllColumnsSelector:
+			return nil, a.unsupported("star expressions are not supported in grouped views")
+		case *tree.UnresolvedName:
+			if t.Star {
+				return nil, a.unsupported("star expressions are not supported in grouped views")
+			}
+		}
+	}
+	for _, g := range clause.GroupBy {
+		ord, ok := findGroupingTarget(clause.Exprs, g)
+		if !ok {
+			return nil, a.unsupported(fmt.Sprintf(
+				"GROUP BY expression %s must appear in the select list", tree.AsString(g),
+			))
+		}
+		a.shape.groupCols = append(a.shape.groupCols, ord)
+	}
+	return a.shape, nil
+}
+
+// incrementalViewAnalyzer checks that a view query has a shape supported by
+// incremental refresh.
+type incrementalViewAnalyzer struct {
+	p            *planner
+	view         catalog.TableDescriptor
+	shape        *incrementalViewShape
+	hasAggregate bool
+}
+
+func (a *incrementalViewAnalyzer) unsupported(reason string) error {
+	return errors.WithHint(
+		pgerror.Newf(pgcode.FeatureNotSupported,
+			"materialized view %q cannot be refreshed incrementally: %s", a.view.GetName(), reason,
+		),
+		"Incremental refresh supports a single SELECT block over tables combined with "+
+			"inner joins, with filters, projections, GROUP BY and aggregates. Use "+
+			"REFRESH MATERIALIZED VIEW without INCREMENTAL to recompute the view.",
+	)
+}
+
+// addTables adds the tables referenced by the given FROM clause item to the
+// shape of the view.
+func (a *incrementalViewAnalyzer) addTables(ctx context.Context, expr tree.TableExpr) error {
+	switch t := expr.(type) {
+	case *tree.ParenTableExpr:
+		return a.addTables(ctx, t.Expr)
+
+	case *tree.JoinTableExpr:
+		if t.JoinType != "" && t.JoinType != tree.AstInner && t.JoinType != tree.AstCross {
+			return a.unsupported(fmt.Sprintf("%s JOIN is not supported", t.JoinType))
+		}
+		if on, ok := t.Cond.(*tree.OnJoinCond); ok {
+			if err := a.checkExpr(ctx, on.Expr); err != nil {
+				return err
+			}
+		}
+		if err := a.addTables(ctx, t.Left); err != nil {
+			return err
+		}
+		return a.addTables(ctx, t.Right)
+
+	case *tree.AliasedTableExpr:
+		tn, ok := t.Expr.(*tree.TableName)
+		if !ok || t.Lateral || t.Ordinality {
+			return a.unsupported("only tables may appear in the FROM clause")
+		}
+		if len(t.As.Cols) > 0 {
+			return a.unsupported("column aliases in the FROM clause are not supported")
+		}
+		tab, err := a.p.ResolveExistingObjectEx(
+			ctx, tn.ToUnresolvedObjectName(), true /* required */, tree.ResolveAnyTableKind,
+		)
+		if err != nil {
+			return err
+		}
+		if !tab.IsTable() || tab.IsVirtualTable() {
+			return a.unsupported(fmt.Sprintf("%q is not a table", tab.GetName()))
+		}
+		idx := tab.GetPrimaryIndex()
+		for i := 0; i < idx.NumKeyColumns(); i++ {
+			col, err := catalog.MustFindColumnByID(tab, idx.GetKeyColumnID(i))
+			if err != nil {
+				return err
+			}
+			if col.GetType().Family() == types.CollatedStringFamily {
+				return a.unsupported(fmt.Sprintf(
+					"the primary key of table %q has a collated string column", tab.GetName(),
+				))
+			}
+		}
+		qualifier := tn.ToUnresolvedObjectName()
+		if t.As.Alias != "" {
+			qualifier = tree.NewUnqualifiedTableName(t.As.Alias).ToUnresolvedObjectName()
+		}
+		a.shape.tables = append(a.shape.tables, incrementalViewTable{desc: tab, qualifier: qualifier})
+		return nil
+
+	default:
+		return a.unsupported("only tables may appear in the FROM clause")
+	}
+}
+
+// checkExpr checks that the given expression evaluates to the same result
+// whenever it is evaluated on the same input. It also records whether the
+// expression contains an aggregate function.
+func (a *incrementalViewAnalyzer) checkExpr(ctx context.Context, expr tree.Expr) error {
+	_, err := tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		switch t := expr.(type) {
+		case *tree.Subquery:
+			return false, expr, a.unsupported("subqueries are not supported")
+		case *tree.UnresolvedName:
+			if t.Parts[0] == colinfo.MVCCTimestampColumnName {
+				return false, expr, a.unsupported(fmt.Sprintf("%s is not supported", colinfo.MVCCTimestampColumnName))
+			}
+		case *tree.FuncExpr:
+			if t.WindowDef != nil {
+				return false, expr, a.unsupported("window functions are not supported")
+			}
+			if err := a.checkFunc(ctx, t); err != nil {
+				return false, expr, err
+			}
+		}
+		return true, expr, nil
+	})
+	return err
+}
+
+func (a *incrementalViewAnalyzer) checkFunc(ctx context.Context, f *tree.FuncExpr) error {
+	name, ok := f.Func.FunctionReference.(*tree.UnresolvedName)
+	if !ok {
+		return a.unsupported("user-defined functions are not supported")
+	}
+	path := a.p.CurrentSearchPath()
+	def, err := a.p.ResolveFunction(ctx, tree.MakeUnresolvedFunctionName(name), &path)
+	if err != nil {
+		return err
+	}
+	for _, o := range def.Overloads {
+		switch {
+		case o.Type != tree.BuiltinRoutine:
+			return a.unsupported(fmt.Sprintf("user-defined function %s is not supported", def.Name))
+		case o.Class == tree.AggregateClass:
+			a.hasAggregate = true
+		case o.Class != tree.NormalClass:
+			return a.unsupported(fmt.Sprintf("function %s is not supported", def.Name))
+		case o.Volatility > volatility.Immutable:
+			return a.unsupported(fmt.Sprintf("non-immutable function %s is not supported", def.Name))
+		}
+	}
+	return nil
+}
+
+// primaryIndexReplacedSince returns whether the primary index of the table
+// was, or is being, replaced since the given timestamp. The changes made
+// before a new primary index was built are not visible as changes to it, so
+// they cannot be found by an incremental export.
+func (p *planner) primaryIndexReplacedSince(
+	ctx context.Context, tab catalog.TableDescriptor, ts hlc.Timestamp,
+) (bool, error) {
+	if len(tab.AllMutations()) > 0 {
+		return true, nil
+	}
+	leased, err := p.ExecCfg().LeaseManager.Acquire(ctx, ts, tab.GetID())
+	if err != nil {
+		if errors.Is(err, catalog.ErrDescriptorNotFound) ||
+			errors.HasType(err, &kvpb.BatchTimestampBeforeGCError{}) {
+			return true, nil
+		}
+		return false, err
+	}
+	defer leased.Release(ctx)
+	old, ok := leased.Underlying().(catalog.TableDescriptor)
+	return !ok || old.GetPrimaryIndexID() != tab.GetPrimaryIndexID(), nil
+}
+
+// findGroupingTarget returns the ordinal of the select list entry that holds
+// the given GROUP BY expression.
+func findGroupingTarget(targets tree.SelectExprs, g tree.Expr) (int, bool) {
+	if n, ok := g.(*tree.NumVal); ok {
+		i, err := n.AsInt64()
+		if err != nil || i < 1 || int(i) > len(targets) {
+			return 0, false
+		}
+		return int(i) - 1, true
+	}
+	s := tree.AsString(g)
+	for i := range targets {
+		if tree.AsString(targets[i].Expr) == s {
+			return i, true
+		}
+	}
+	if n, ok := g.(*tree.UnresolvedName); ok && n.NumParts == 1 {
+		for i := range targets {
+			if string(targets[i].As) == n.Parts[0] {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// changedPrimaryKeys returns the primary keys of the rows of the table that
+// were written in the time interval (from, to], including deleted rows. The
+// keys are found with an incremental export of the table's primary index,
+// which only visits the MVCC versions newer than from. A non-empty reason is
+// returned instead if the changes cannot be enumerated, or if more than limit
+// rows changed.
+func (p *planner) changedPrimaryKeys(
+	ctx context.Context, tab catalog.TableDescriptor, from, to hlc.Timestamp, limit int,
+) (pks []tree.Datums, reason string, _ error) {
+	codec := p.ExecCfg().Codec
+	idx := tab.GetPrimaryIndex()
+	colTypes := make([]*types.T, idx.NumKeyColumns())
+	colDirs := make([]catenumpb.IndexColumn_Direction, idx.NumKeyColumns())
+	for i := range colTypes {
+		col, err := catalog.MustFindColumnByID(tab, idx.GetKeyColumnID(i))
+		if err != nil {
+			return nil, "", err
+		}
+		colTypes[i] = col.GetType()
+		colDirs[i] = idx.GetKeyColumnDirection(i)
+	}
+
+	var alloc tree.DatumAlloc
+	vals := make([]rowenc.EncDatum, len(colTypes))
+	seen := make(map[string]struct{})
+	// addRow decodes the primary key of the row that the given key belongs to.
+	// The rows of tables with multiple column families span several keys.
+	addRow := func(key roachpb.Key) error {
+		rowKey, err := keys.EnsureSafeSplitKey(key)
+		if err != nil {
+			return err
+		}
+		if _, ok := seen[string(rowKey)]; ok {
+			return nil
+		}
+		seen[string(rowKey)] = struct{}{}
+		if _, err := rowenc.DecodeIndexKey(codec, vals, colDirs, rowKey); err != nil {
+			return err
+		}
+		pk := make(tree.Datums, len(vals))
+		for i := range vals {
+			if err := vals[i].EnsureDecoded(colTypes[i], &alloc); err != nil {
+				return err
+			}
+			pk[i] = vals[i].Datum
+		}
+		pks = append(pks, pk)
+		return nil
+	}
+
+	span := tab.PrimaryIndexSpan(codec)
+	header := kvpb.Header{Timestamp: to, ReturnElasticCPUResumeSpans: true}
+	for len(span.Key) != 0 {
+		req := &kvpb.ExportRequest{
+			RequestHeader: kvpb.RequestHeader{Key: span.Key, EndKey: span.EndKey},
+			StartTime:     from,
+			MVCCFilter:    kvpb.MVCCFilter_Latest,
+		}
+		res, pErr := kv.SendWrappedWith(ctx, p.ExecCfg().DB.NonTransactionalSender(), header, req)
+		if pErr != nil {
+			if err := pErr.GoError(); errors.HasType(err, &kvpb.BatchTimestampBeforeGCError{}) {
+				return nil, "the changes since the last refresh have been garbage collected", nil
+			}
+			return nil, "", errors.Wrapf(pErr.GoError(),
+				"error retrieving the changes to table %q since %s", tab.GetName(), from)
+		}
+		resp := res.(*kvpb.ExportResponse)
+		for _, file := range resp.Files {
+			if reason, err := func() (string, error) {
+				it, err := storage.NewMemSSTIterator(file.SST, false, /* verify */
+					storage.IterOptions{
+						KeyTypes:   storage.IterKeyTypePointsAndRanges,
+						LowerBound: keys.MinKey,
+						UpperBound: keys.MaxKey,
+					})
+				if err != nil {
+					return "", err
+				}
+				defer it.Close()
+				for it.SeekGE(storage.NilKey); ; it.Next() {
+					if ok, err := it.Valid(); err != nil || !ok {
+						return "", err
+					}
+					// MVCC range tombstones delete rows without naming them.
+					if _, hasRange := it.HasPointAndRange(); hasRange {
+						return fmt.Sprintf("rows of table %q were removed by a range deletion", tab.GetName()), nil
+					}
+					if err := addRow(it.UnsafeKey().Key); err != nil {
+						return "", err
+					}
+					if len(pks) > limit {
+						return "too many rows changed since the last refresh", nil
+					}
+				}
+			}(); err != nil || reason != "" {
+				return nil, reason, err
+			}
+		}
+		span = roachpb.Span{}
+		if resp.ResumeSpan != nil {
+			span = *resp.ResumeSpan
+		}
+	}
+	return pks, "", nil
+}
+
+// applyViewDelta applies the changes to the base tables of an ungrouped view
+// by deleting the view rows that were derived from the old versions of the
+// changed rows, and inserting the rows derived from their new versions.
+func (p *planner) applyViewDelta(
+	ctx context.Context,
+	view catalog.TableDescriptor,
+	shape *incrementalViewShape,
+	changed map[descpb.ID][]tree.Datums,
+	from, to hlc.Timestamp,
+) error {
+	delta := *shape.sel
+	delta.Where = andWhere(delta.Where, shape.changedRowsFilter(changed))
+	oldRows, err := p.queryAsOf(ctx, delta, from)
+	if err != nil {
+		return err
+	}
+	newRows, err := p.queryAsOf(ctx, delta, to)
+	if err != nil {
+		return err
+	}
+
+	// Rows that are derived from both the old and new versions of the changed
+	// rows, for instance when a column the view doesn't use was updated, cancel
+	// out.
+	removed := make(map[string]int, len(oldRows))
+	for _, row := range oldRows {
+		removed[datumsKey(row)]++
+	}
+	var added []tree.Datums
+	for _, row := range newRows {
+		if k := datumsKey(row); removed[k] > 0 {
+			removed[k]--
+		} else {
+			added = append(added, row)
+		}
+	}
+	allCols := make([]int, len(view.VisibleColumns()))
+	for i := range allCols {
+		allCols[i] = i
+	}
+	for _, row := range oldRows {
+		k := datumsKey(row)
+		if removed[k] == 0 {
+			continue
+		}
+		removed[k]--
+		// The view may contain duplicate rows, only one of which is derived
+		// from this combination of base rows.
+		if err := p.deleteViewRows(ctx, view, allCols, row, true /* limitOne */); err != nil {
+			return err
+		}
+	}
+	return p.insertViewRows(ctx, view, added)
+}
+
+// applyGroupedViewDelta applies the changes to the base tables of a grouped
+// view by recomputing every group that contains a changed row, either before
+// or after the change.
+func (p *planner) applyGroupedViewDelta(
+	ctx context.Context,
+	view catalog.TableDescriptor,
+	shape *incrementalViewShape,
+	changed map[descpb.ID][]tree.Datums,
+	from, to hlc.Timestamp,
+) error {
+	var groups []tree.Datums
+	if len(shape.groupCols) == 0 {
+		// A scalar aggregation has a single group.
+		groups = []tree.Datums{{}}
+	} else {
+		groupKeys := *shape.sel
+		groupKeys.Distinct = true
+		groupKeys.Exprs = make(tree.SelectExprs, len(shape.groupCols))
+		for i, ord := range shape.groupCols {
+			groupKeys.Exprs[i] = tree.SelectExpr{Expr: shape.sel.Exprs[ord].Expr}
+		}
+		groupKeys.GroupBy = nil
+		groupKeys.Having = nil
+		groupKeys.Where = andWhere(groupKeys.Where, shape.changedRowsFilter(changed))
+		seen := make(map[string]struct{})
+		for _, ts := range []hlc.Timestamp{from, to} {
+			rows, err := p.queryAsOf(ctx, groupKeys, ts)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				k := datumsKey(row)
+				if _, ok := seen[k]; !ok {
+					seen[k] = struct{}{}
+					groups = append(groups, row)
+				}
+			}
+		}
+		if len(groups) == 0 {
+			return nil
+		}
+	}
+
+	recompute := *shape.sel
+	if len(shape.groupCols) > 0 {
+		recompute.Where = andWhere(recompute.Where, shape.groupsFilter(groups))
+	}
+	rows, err := p.queryAsOf(ctx, recompute, to)
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		if err := p.deleteViewRows(ctx, view, shape.groupCols, group, false /* limitOne */); err != nil {
+			return err
+		}
+	}
+	return p.insertViewRows(ctx, view, rows)
+}
+
+// changedRowsFilter returns a filter that restricts the FROM clause of the
+// view query to the combinations of base rows that include a changed row.
+func (s *incrementalViewShape) changedRowsFilter(changed map[descpb.ID][]tree.Datums) tree.Expr {
+	var filter tree.Expr
+	for _, tab := range s.tables {
+		pks := changed[tab.desc.GetID()]
+		if len(pks) == 0 {
+			continue
+		}
+		idx := tab.desc.GetPrimaryIndex()
+		cols := make(tree.Exprs, idx.NumKeyColumns())
+		for i := range cols {
+			cols[i] = &tree.ColumnItem{
+				TableName:  tab.qualifier,
+				ColumnName: tree.Name(idx.GetKeyColumnName(i)),
+			}
+		}
+		rows := make(tree.Exprs, len(pks))
+		for i, pk := range pks {
+			row := make(tree.Exprs, len(pk))
+			for j := range pk {
+				row[j] = pk[j]
+			}
+			rows[i] = &tree.Tuple{Exprs: row}
+		}
+		filter = orExpr(filter, &tree.ComparisonExpr{
+			Operator: treecmp.MakeComparisonOperator(treecmp.In),
+			Left:     &tree.Tuple{Exprs: cols},
+			Right:    &tree.Tuple{Exprs: rows},
+		})
+	}
+	return filter
+}
+
+// groupsFilter returns a filter that restricts the input of a grouped view
+// query to the given groups.
+func (s *incrementalViewShape) groupsFilter(groups []tree.Datums) tree.Expr {
+	var filter tree.Expr
+	for _, group := range groups {
+		var pred tree.Expr
+		for i, d := range group {
+			cmp := &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(treecmp.IsNotDistinctFrom),
+				Left:     &tree.ParenExpr{Expr: s.sel.Exprs[s.groupCols[i]].Expr},
+				Right:    d,
+			}
+			if pred == nil {
+				pred = cmp
+			} else {
+				pred = &tree.AndExpr{Left: pred, Right: cmp}
+			}
+		}
+		filter = orExpr(filter, pred)
+	}
+	return filter
+}
+
+// queryAsOf runs the given SELECT block as of the given timestamp.
+func (p *planner) queryAsOf(
+	ctx context.Context, sel tree.SelectClause, ts hlc.Timestamp,
+) ([]tree.Datums, error) {
+	sel.From.AsOf = tree.AsOfClause{Expr: tree.NewStrVal(ts.AsOfSystemTime())}
+	stmt := tree.AsStringWithFlags(&tree.Select{Select: &sel}, tree.FmtParsable)
+	return p.ExecCfg().InternalDB.Executor().QueryBufferedEx(
+		ctx, "refresh-view-delta", nil /* txn */, sessiondata.NodeUserSessionDataOverride, stmt,
+	)
+}
+
+// deleteViewRows deletes the rows of the view whose columns with the given
+// ordinals hold the given values. If limitOne is true, at most one row is
+// deleted.
+func (p *planner) deleteViewRows(
+	ctx context.Context, view catalog.TableDescriptor, ords []int, vals tree.Datums, limitOne bool,
+) error {
+	cols := view.VisibleColumns()
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "DELETE FROM [%d AS v]", view.GetID())
+	args := make([]interface{}, len(vals))
+	for i, ord := range ords {
+		if i == 0 {
+			buf.WriteString(" WHERE ")
+		} else {
+			buf.WriteString(" AND ")
+		}
+		fmt.Fprintf(&buf, "%s IS NOT DISTINCT FROM $%d", tree.NameString(cols[ord].GetName()), i+1)
+		args[i] = vals[i]
+	}
+	if limitOne {
+		buf.WriteString(" LIMIT 1")
+	}
+	_, err := p.ExecEx(ctx, "refresh-view-delete", materializedViewMutationOverride, buf.String(), args...)
+	return err
+}
+
+// insertViewRows inserts the given rows into the view.
+func (p *planner) insertViewRows(
+	ctx context.Context, view catalog.TableDescriptor, rows []tree.Datums,
+) error {
+	const batchSize = 100
+	cols := view.VisibleColumns()
+	for len(rows) > 0 {
+		batch := rows
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		rows = rows[len(batch):]
+
+		var buf strings.Builder
+		fmt.Fprintf(&buf, "INSERT INTO [%d AS v] (", view.GetID())
+		for i, col := range cols {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(tree.NameString(col.GetName()))
+		}
+		buf.WriteString(") VALUES ")
+		args := make([]interface{}, 0, len(batch)*len(cols))
+		for i, row := range batch {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteByte('(')
+			for j := range row {
+				if j > 0 {
+					buf.WriteString(", ")
+				}
+				args = append(args, row[j])
+				fmt.Fprintf(&buf, "$%d", len(args))
+			}
+			buf.WriteByte(')')
+		}
+		if _, err := p.ExecEx(
+			ctx, "refresh-view-insert", materializedViewMutationOverride, buf.String(), args...,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// andWhere returns a WHERE clause that is the conjunction of the given WHERE
+// clause, which may be nil, and the given filter.
+func andWhere(where *tree.Where, filter tree.Expr) *tree.Where {
+	if where == nil {
+		return tree.NewWhere(tree.AstWhere, filter)
+	}
+	return tree.NewWhere(tree.AstWhere, &tree.AndExpr{
+		Left:  &tree.ParenExpr{Expr: where.Expr},
+		Right: &tree.ParenExpr{Expr: filter},
+	})
+}
+
+// orExpr returns the disjunction of the given expressions. The left
+// expression may be nil.
+func orExpr(left, right tree.Expr) tree.Expr {
+	if left == nil {
+		return right
+	}
+	return &tree.OrExpr{Left: left, Right: &tree.ParenExpr{Expr: right}}
+}
+
+// datumsKey returns a string that identifies the values of the given row.
+func datumsKey(row tree.Datums) string {
+	f := tree.NewFmtCtx(tree.FmtParsable)
+	for i := range row {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		f.FormatNode(row[i])
+	}
+	return f.CloseAndGetString()
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/replslot"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+type createReplicationSlotNode struct {
+	zeroInputPlanNode
+	optColumnsSlot
+	n *pgrepltree.CreateReplicationSlot
+
+	run struct {
+		slot  replslot.Slot
+		shown bool
+	}
+}
+
+// CreateReplicationSlot creates a logical replication slot in the current
+// database.
+func (p *planner) CreateReplicationSlot(
+	ctx context.Context, n *pgrepltree.CreateReplicationSlot,
+) (planNode, error) {
+	if err := checkReplicationSlotsActive(ctx, p.ExecCfg()); err != nil {
+		return nil, err
+	}
+	if n.Kind != pgrepltree.LogicalReplication {
+		return nil, unimplemented.New("physical replication slots", "physical replication slots are not supported")
+	}
+	if n.Temporary {
+		return nil, unimplemented.New("temporary replication slots", "temporary replication slots are not supported")
+	}
+	if p.SessionData().ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE {
+		return nil, pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical replication slots require a database connection")
+	}
+	if string(n.Plugin) != pgoutput.PluginName {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"output plugin %q is not supported; only %q is available", n.Plugin, pgoutput.PluginName)
+	}
+	return &createReplicationSlotNode{n: n}, nil
+}
+
+// checkReplicationSlotsActive returns an error if replication slots cannot be
+// used until the cluster is upgraded.
+func checkReplicationSlotsActive(ctx context.Context, cfg *ExecutorConfig) error {
+	if !cfg.Settings.Version.IsActive(ctx, clusterversion.V25_2_PGReplicationSlots) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"replication slots unsupported in mixed-version cluster")
+	}
+	return nil
+}
+
+func (n *createReplicationSlotNode) startExec(params runParams) error {
+	p := params.p
+	db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(params.ctx, p.CurrentDatabase())
+	if err != nil {
+		return err
+	}
+	// The slot starts just before the wall time of the transaction's read
+	// timestamp, so that every change streamed from it has an LSN greater than
+	// the slot's consistent point.
+	n.run.slot = replslot.Slot{
+		Name:           string(n.n.Slot),
+		Plugin:         string(n.n.Plugin),
+		DatabaseID:     db.GetID(),
+		Database:       db.GetName(),
+		ConfirmedFlush: lsnutil.LSNToHLC(lsnutil.HLCToLSN(p.txn.ReadTimestamp()) - 1),
+	}
+	pts := p.ExecCfg().ProtectedTimestampProvider.WithTxn(p.InternalSQLTxn())
+	return replslot.Create(params.ctx, pts, n.run.slot)
+}
+
+func (n *createReplicationSlotNode) Next(params runParams) (bool, error) {
+	if n.run.shown {
+		return false, nil
+	}
+	n.run.shown = true
+	return true, nil
+}
+
+func (n *createReplicationSlotNode) Values() tree.Datums {
+	return tree.Datums{
+		tree.NewDString(n.run.slot.Name),
+		tree.NewDString(n.run.slot.ConfirmedFlushLSN().String()),
+		tree.DNull, // snapshot_name; snapshots are not exported.
+		tree.NewDString(n.run.slot.Plugin),
+	}
+}
+
+func (n *createReplicationSlotNode) Close(ctx context.Context) {}
+
+type dropReplicationSlotNode struct {
+	zeroInputPlanNode
+	n *pgrepltree.DropReplicationSlot
+}
+
+// DropReplicationSlot drops a replication slot, allowing the history it
+// retained to be garbage collected.
+func (p *planner) DropReplicationSlot(
+	ctx context.Context, n *pgrepltree.DropReplicationSlot,
+) (planNode, error) {
+	if err := checkReplicationSlotsActive(ctx, p.ExecCfg()); err != nil {
+		return nil, err
+	}
+	return &dropReplicationSlotNode{n: n}, nil
+}
+
+func (n *dropReplicationSlotNode) startExec(params runParams) error {
+	pts := params.ExecCfg().ProtectedTimestampProvider.WithTxn(params.p.InternalSQLTxn())
+	return replslot.Drop(params.ctx, pts, string(n.n.Slot))
+}
+
+func (n *dropReplicationSlotNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *dropReplicationSlotNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *dropReplicationSlotNode) Close(ctx context.Context)           {}
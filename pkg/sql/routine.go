@@ -582,6 +582,7 @@ func (g *routineGenerator) newCursorHelper(plan *planComponents) (*plpgsqlCursor
 		cursorName: cursorName,
 		cursorSql:  open.CursorSQL,
 		withHold:   withHold,
+		scroll:     open.Scroll == tree.Scroll,
 	}
 	// Use context.Background(), since the cursor can outlive the context in which
 	// it was created.
@@ -618,14 +619,28 @@ type plpgsqlCursorHelper struct {
 	cursorSql   string
 	addedCursor bool
 	withHold    bool
+	scroll      bool
 }
 
 var _ isql.Rows = &plpgsqlCursorHelper{}
 
 func (h *plpgsqlCursorHelper) createCursor(p *planner) error {
 	h.iter = newRowContainerIterator(h.ctx, h.container)
+	if err := p.checkIfCursorExists(h.cursorName); err != nil {
+		return err
+	}
+	var rows isql.Rows = h
+	if h.scroll {
+		// Rows are copied into an indexed buffer as the cursor moves forward, so
+		// that they can be re-read when it moves backward.
+		scrollRows, err := p.newScrollableCursorRows(h, h.withHold)
+		if err != nil {
+			return err
+		}
+		rows = scrollRows
+	}
 	cursor := &sqlCursor{
-		Rows:       h,
+		Rows:       rows,
 		readSeqNum: p.txn.GetReadSeqNum(),
 		txn:        p.txn,
 		statement:  h.cursorSql,
@@ -633,11 +648,10 @@ func (h *plpgsqlCursorHelper) createCursor(p *planner) error {
 		withHold:   h.withHold,
 		persisted:  true,
 	}
-	if err := p.checkIfCursorExists(h.cursorName); err != nil {
-		return err
-	}
 	if err := p.sqlCursors.addCursor(h.cursorName, cursor); err != nil {
-		return err
+		// Closing the helper more than once is safe, so it's fine that the caller
+		// will also close it.
+		return errors.CombineErrors(err, rows.Close())
 	}
 	h.addedCursor = true
 	return nil
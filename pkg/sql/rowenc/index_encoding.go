@@ -697,6 +697,11 @@ func EncodeInvertedIndexTableKeys(
 		return encodeTrigramInvertedIndexTableKeys(string(*datum.(*tree.DString)), inKey, version, true /* pad */)
 	case types.TSVectorFamily:
 		return tsearch.EncodeInvertedIndexKeys(inKey, val.(*tree.DTSVector).TSVector)
+	case types.RangeFamily:
+		return encodeRangeInvertedIndexTableKeys(datum.(*tree.DRange), inKey)
+	case types.MultirangeFamily:
+		// Multiranges are indexed by the smallest range that contains them.
+		return encodeRangeInvertedIndexTableKeys(datum.(*tree.DMultirange).Span(), inKey)
 	}
 	return nil, errors.AssertionFailedf("trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError())
 }
@@ -819,15 +824,15 @@ func EncodeExistsInvertedIndexSpans(
 
 // EncodeOverlapsInvertedIndexSpans returns the spans that must be scanned in
 // the inverted index to evaluate an overlaps (&&) predicate with the given
-// datum, which should be an Array. These spans should be used to find the
-// objects in the index that could overlap with the given array. In other
-// words, if we have a predicate x && y, this function should use the value of
-// y to find the spans to scan in an inverted index on x.
+// datum, which should be an Array, a range or a multirange. These spans should
+// be used to find the objects in the index that could overlap with the given
+// datum. In other words, if we have a predicate x && y, this function should
+// use the value of y to find the spans to scan in an inverted index on x.
 //
 // The spans are returned in an inverted.SpanExpression, which represents the
 // set operations that must be applied on the spans read during execution. The
-// span expression returned will be tight. See comments in the
-// SpanExpression definition for details.
+// span expression returned will be tight for arrays, but not for ranges and
+// multiranges. See comments in the SpanExpression definition for details.
 func EncodeOverlapsInvertedIndexSpans(
 	ctx context.Context, evalCtx *eval.Context, val tree.Datum,
 ) (invertedExpr inverted.Expression, err error) {
@@ -838,6 +843,10 @@ func EncodeOverlapsInvertedIndexSpans(
 	switch val.ResolvedType().Family() {
 	case types.ArrayFamily:
 		return encodeOverlapsArrayInvertedIndexSpans(val.(*tree.DArray), nil /* inKey */)
+	case types.RangeFamily:
+		return encodeOverlapsRangeInvertedIndexSpans(datum.(*tree.DRange), nil /* inKey */)
+	case types.MultirangeFamily:
+		return encodeOverlapsRangeInvertedIndexSpans(datum.(*tree.DMultirange).Span(), nil /* inKey */)
 	default:
 		return nil, errors.AssertionFailedf(
 			"trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError(),
@@ -290,6 +290,10 @@ func Decode(
 			return nil, nil, err
 		}
 		return a.NewDEnum(tree.DEnum{EnumTyp: valType, PhysicalRep: phys, LogicalRep: log}), rkey, nil
+	case types.RangeFamily:
+		return decodeRangeKey(a, valType, key, dir)
+	case types.MultirangeFamily:
+		return decodeMultirangeKey(a, valType, key, dir)
 	case types.EncodedKeyFamily:
 		// We don't actually decode anything; we wrap the raw key bytes into a
 		// DEncodedKey.
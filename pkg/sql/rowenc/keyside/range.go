@@ -0,0 +1,215 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The markers used in the key encoding of ranges. They are chosen so that the
+// encoding sorts in the same order as tree.DRange.Compare: the empty range
+// sorts first, an infinite lower bound sorts before any finite lower bound,
+// and an infinite upper bound sorts after any finite upper bound. At the same
+// value, an inclusive lower bound sorts before an exclusive one, while an
+// exclusive upper bound sorts before an inclusive one.
+const (
+	rangeEmptyMarker    = 0x00
+	rangeNonEmptyMarker = 0x01
+
+	rangeBoundNegInfMarker = 0x00
+	rangeBoundFiniteMarker = 0x01
+	rangeBoundPosInfMarker = 0x02
+
+	rangeBoundLowMarker  = 0x00
+	rangeBoundHighMarker = 0x01
+
+	multirangeTerminator  = 0x00
+	multirangeRangeMarker = 0x01
+)
+
+// encodeRangeKey generates an ordered key encoding of a range. The bounds are
+// encoded in ascending order within a byte string, which is then encoded in
+// the given direction. This keeps the encoding self-delimiting, so that it can
+// be skipped without knowing the type of the range.
+//
+// The encoding of the empty range is [rangeEmptyMarker], and the encoding of
+// a non-empty range is [rangeNonEmptyMarker, enc(lower), enc(upper)], where
+// the encoding of a finite bound is [rangeBoundFiniteMarker, enc(val), marker]
+// and the marker orders inclusive and exclusive bounds at the same value.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	inner, err := appendRangeKey(nil, r)
+	if err != nil {
+		return nil, err
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+// encodeMultirangeKey generates an ordered key encoding of a multirange. The
+// encoding within the byte string is [multirangeRangeMarker, enc(r)] for each
+// range r, followed by multirangeTerminator, so that a multirange sorts before
+// any multirange it is a prefix of.
+func encodeMultirangeKey(b []byte, m *tree.DMultirange, dir encoding.Direction) ([]byte, error) {
+	var inner []byte
+	var err error
+	for _, r := range m.Ranges {
+		inner = append(inner, multirangeRangeMarker)
+		if inner, err = appendRangeKey(inner, r); err != nil {
+			return nil, err
+		}
+	}
+	inner = append(inner, multirangeTerminator)
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+func appendRangeKey(b []byte, r *tree.DRange) ([]byte, error) {
+	if r.Empty {
+		return append(b, rangeEmptyMarker), nil
+	}
+	b = append(b, rangeNonEmptyMarker)
+	var err error
+	if r.Lower.IsInf() {
+		b = append(b, rangeBoundNegInfMarker)
+	} else {
+		b = append(b, rangeBoundFiniteMarker)
+		if b, err = Encode(b, r.Lower.Val, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		if r.Lower.Inclusive {
+			b = append(b, rangeBoundLowMarker)
+		} else {
+			b = append(b, rangeBoundHighMarker)
+		}
+	}
+	if r.Upper.IsInf() {
+		b = append(b, rangeBoundPosInfMarker)
+	} else {
+		b = append(b, rangeBoundFiniteMarker)
+		if b, err = Encode(b, r.Upper.Val, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		if r.Upper.Inclusive {
+			b = append(b, rangeBoundHighMarker)
+		} else {
+			b = append(b, rangeBoundLowMarker)
+		}
+	}
+	return b, nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	rkey, inner, err := decodeRangeKeyBytes(key, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, inner, err := decodeRangeKeyInner(a, t, inner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(inner) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (trailing bytes)")
+	}
+	return r, rkey, nil
+}
+
+// decodeMultirangeKey decodes a multirange key generated by
+// encodeMultirangeKey.
+func decodeMultirangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	rkey, inner, err := decodeRangeKeyBytes(key, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	var ranges []*tree.DRange
+	for {
+		if len(inner) == 0 {
+			return nil, nil, errors.AssertionFailedf("invalid multirange encoding (unterminated)")
+		}
+		marker := inner[0]
+		inner = inner[1:]
+		if marker == multirangeTerminator {
+			break
+		}
+		var r *tree.DRange
+		if r, inner, err = decodeRangeKeyInner(a, t.MultirangeContents(), inner); err != nil {
+			return nil, nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	m, err := tree.NewDMultirange(t, ranges)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, rkey, nil
+}
+
+func decodeRangeKeyBytes(key []byte, dir encoding.Direction) (rkey, inner []byte, err error) {
+	if dir == encoding.Ascending {
+		return encoding.DecodeBytesAscending(key, nil)
+	}
+	return encoding.DecodeBytesDescending(key, nil)
+}
+
+func decodeRangeKeyInner(
+	a *tree.DatumAlloc, t *types.T, b []byte,
+) (*tree.DRange, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	marker := b[0]
+	b = b[1:]
+	if marker == rangeEmptyMarker {
+		return tree.NewEmptyDRange(t), b, nil
+	}
+	var lower, upper tree.RangeBound
+	var err error
+	if lower, b, err = decodeRangeKeyBound(a, t, b, true /* isLower */); err != nil {
+		return nil, nil, err
+	}
+	if upper, b, err = decodeRangeKeyBound(a, t, b, false /* isLower */); err != nil {
+		return nil, nil, err
+	}
+	r, err := tree.NewDRange(t, lower, upper)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, b, nil
+}
+
+func decodeRangeKeyBound(
+	a *tree.DatumAlloc, t *types.T, b []byte, isLower bool,
+) (tree.RangeBound, []byte, error) {
+	if len(b) == 0 {
+		return tree.RangeBound{}, nil, errors.AssertionFailedf("invalid range encoding (missing bound)")
+	}
+	marker := b[0]
+	b = b[1:]
+	if marker != rangeBoundFiniteMarker {
+		// An infinite bound.
+		return tree.RangeBound{}, b, nil
+	}
+	val, b, err := Decode(a, t.RangeSubtype(), b, encoding.Ascending)
+	if err != nil {
+		return tree.RangeBound{}, nil, err
+	}
+	if len(b) == 0 {
+		return tree.RangeBound{}, nil, errors.AssertionFailedf("invalid range encoding (missing bound marker)")
+	}
+	inclusive := (b[0] == rangeBoundLowMarker) == isLower
+	return tree.RangeBound{Val: val, Inclusive: inclusive}, b[1:], nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package keyside_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/randgen"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEncodeDecodeRange checks that the key encoding of ranges and multiranges
+// round-trips, can be skipped, and sorts like their Compare method.
+func TestEncodeDecodeRange(t *testing.T) {
+	ctx := context.Background()
+	evalCtx := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+	a := &tree.DatumAlloc{}
+
+	parse := func(t *testing.T, typ *types.T, s string) tree.Datum {
+		var d tree.Datum
+		var err error
+		if typ.Family() == types.MultirangeFamily {
+			d, _, err = tree.ParseDMultirangeFromString(evalCtx, s, typ)
+		} else {
+			d, _, err = tree.ParseDRangeFromString(evalCtx, s, typ)
+		}
+		require.NoError(t, err)
+		return d
+	}
+
+	// checkEncoding checks the round trip of the encoding of the given datums,
+	// and that the order of their encodings matches the order of the datums.
+	checkEncoding := func(t *testing.T, datums []tree.Datum, dir encoding.Direction) {
+		encoded := make([][]byte, len(datums))
+		for i, d := range datums {
+			b, err := keyside.Encode(nil, d, dir)
+			require.NoError(t, err)
+			encoded[i] = b
+
+			decoded, rem, err := keyside.Decode(a, d.ResolvedType(), b, dir)
+			require.NoError(t, err)
+			require.Empty(t, rem)
+			cmp, err := decoded.Compare(ctx, evalCtx, d)
+			require.NoError(t, err)
+			require.Zero(t, cmp, "%s decoded as %s", d, decoded)
+
+			rem, err = keyside.Skip(b)
+			require.NoError(t, err)
+			require.Empty(t, rem)
+		}
+		for i := range datums {
+			for j := range datums {
+				expected, err := datums[i].Compare(ctx, evalCtx, datums[j])
+				require.NoError(t, err)
+				if dir == encoding.Descending {
+					expected = -expected
+				}
+				require.Equal(t, expected, bytes.Compare(encoded[i], encoded[j]),
+					"%s vs %s", datums[i], datums[j])
+			}
+		}
+	}
+
+	// Each of the test cases is sorted in ascending order.
+	for _, tc := range []struct {
+		typ    *types.T
+		values []string
+	}{
+		{
+			typ: types.Int4Range,
+			values: []string{
+				"empty", "(,1)", "(,5)", "(,)", "[1,2)", "[1,5)", "[1,)", "[2,3)",
+			},
+		},
+		{
+			// Inclusive and exclusive bounds at the same value.
+			typ: types.NumRange,
+			values: []string{
+				"empty", "(,1.5)", "(,1.5]", "(,)", "[1.5,2)", "[1.5,2]", "[1.5,)", "(1.5,2)", "(1.5,2]",
+			},
+		},
+		{
+			typ: types.TSTZRange,
+			values: []string{
+				"empty",
+				"(,2020-01-01 10:00+00)",
+				"[2020-01-01 09:00+00,2020-01-01 10:00+00)",
+				"[2020-01-01 10:00+00,2020-01-01 11:00+00)",
+				"[2020-01-01 10:00+00,)",
+			},
+		},
+		{
+			// A multirange sorts before the multiranges it's a prefix of.
+			typ: types.Int4Multirange,
+			values: []string{
+				"{}", "{[1,2)}", "{[1,2),[3,4)}", "{[1,2),[5,6)}", "{[1,3)}", "{[2,3)}",
+			},
+		},
+	} {
+		t.Run(tc.typ.String(), func(t *testing.T) {
+			datums := make([]tree.Datum, len(tc.values))
+			for i, s := range tc.values {
+				datums[i] = parse(t, tc.typ, s)
+			}
+			for i := 1; i < len(datums); i++ {
+				cmp, err := datums[i-1].Compare(ctx, evalCtx, datums[i])
+				require.NoError(t, err)
+				require.Equal(t, -1, cmp, "%s vs %s", datums[i-1], datums[i])
+			}
+			for _, dir := range []encoding.Direction{encoding.Ascending, encoding.Descending} {
+				t.Run(fmt.Sprintf("direction=%d", dir), func(t *testing.T) {
+					checkEncoding(t, datums, dir)
+				})
+			}
+		})
+	}
+
+	t.Run("random", func(t *testing.T) {
+		rng, _ := randutil.NewTestRand()
+		for _, typ := range append(append([]*types.T(nil), types.RangeTypes...), types.MultirangeTypes...) {
+			subtype := typ
+			if typ.Family() == types.MultirangeFamily {
+				subtype = typ.MultirangeContents()
+			}
+			if !hasKeyEncoding(subtype.RangeSubtype()) {
+				// The values of the subtype don't round-trip.
+				continue
+			}
+			t.Run(typ.String(), func(t *testing.T) {
+				datums := make([]tree.Datum, 100)
+				for i := range datums {
+					datums[i] = randgen.RandDatum(rng, typ, false /* nullOk */)
+				}
+				for _, dir := range []encoding.Direction{encoding.Ascending, encoding.Descending} {
+					checkEncoding(t, datums, dir)
+				}
+			})
+		}
+	})
+}
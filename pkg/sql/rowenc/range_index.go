@@ -0,0 +1,114 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package rowenc
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/keysbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+)
+
+// An inverted index on a range column stores two keys for each non-empty
+// range: one for its lower bound and one for its upper bound. A range overlaps
+// a non-empty query range q only if its lower bound is at most the upper bound
+// of q and its upper bound is at least the lower bound of q, so an overlaps
+// (&&) predicate can be evaluated by intersecting a scan of the lower bound
+// keys with a scan of the upper bound keys. Empty ranges, which overlap
+// nothing, are stored under a single key so that every row has an entry.
+//
+// The keys have the form [prefix, marker, enc(val)], where the value is only
+// present for finite bounds and is key-encoded in ascending order.
+const (
+	rangeInvertedEmptyPrefix = 0x00
+	rangeInvertedLowerPrefix = 0x01
+	rangeInvertedUpperPrefix = 0x02
+
+	rangeInvertedNegInfMarker = 0x00
+	rangeInvertedFiniteMarker = 0x01
+	rangeInvertedPosInfMarker = 0x02
+)
+
+// encodeRangeInvertedIndexTableKeys returns the inverted index keys for the
+// given range. The input inKey is prefixed to all returned keys.
+func encodeRangeInvertedIndexTableKeys(r *tree.DRange, inKey []byte) ([][]byte, error) {
+	if r.Empty {
+		return [][]byte{append(inKey[:len(inKey):len(inKey)], rangeInvertedEmptyPrefix)}, nil
+	}
+	lowerKey, err := encodeRangeInvertedBoundKey(inKey, rangeInvertedLowerPrefix, r.Lower, rangeInvertedNegInfMarker)
+	if err != nil {
+		return nil, err
+	}
+	upperKey, err := encodeRangeInvertedBoundKey(inKey, rangeInvertedUpperPrefix, r.Upper, rangeInvertedPosInfMarker)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{lowerKey, upperKey}, nil
+}
+
+func encodeRangeInvertedBoundKey(
+	inKey []byte, prefix byte, b tree.RangeBound, infMarker byte,
+) ([]byte, error) {
+	key := make([]byte, len(inKey), len(inKey)+2)
+	copy(key, inKey)
+	key = append(key, prefix)
+	if b.IsInf() {
+		return append(key, infMarker), nil
+	}
+	key = append(key, rangeInvertedFiniteMarker)
+	return keyside.Encode(key, b.Val, encoding.Ascending)
+}
+
+// encodeOverlapsRangeInvertedIndexSpans returns the spans that must be
+// scanned in the inverted index to evaluate an overlaps (&&) predicate with
+// the given range. The input inKey is prefixed to all returned keys.
+//
+// The span expression returned is not tight, since the spans include the
+// rows with bounds equal to the bounds of the given range, which only overlap
+// it if the bounds are inclusive.
+func encodeOverlapsRangeInvertedIndexSpans(
+	r *tree.DRange, inKey []byte,
+) (inverted.Expression, error) {
+	if r.Empty {
+		// The empty range does not overlap any range.
+		return &inverted.SpanExpression{Tight: true, Unique: true}, nil
+	}
+
+	// Scan the ranges whose lower bound is at most the upper bound of r.
+	lowerPrefix := append(inKey[:len(inKey):len(inKey)], rangeInvertedLowerPrefix)
+	lowerSpan := inverted.Span{
+		Start: lowerPrefix,
+		End:   keysbase.PrefixEnd(lowerPrefix),
+	}
+	if !r.Upper.IsInf() {
+		end, err := encodeRangeInvertedBoundKey(inKey, rangeInvertedLowerPrefix, r.Upper, rangeInvertedPosInfMarker)
+		if err != nil {
+			return nil, err
+		}
+		lowerSpan.End = keysbase.PrefixEnd(end)
+	}
+
+	// Scan the ranges whose upper bound is at least the lower bound of r.
+	upperPrefix := append(inKey[:len(inKey):len(inKey)], rangeInvertedUpperPrefix)
+	upperSpan := inverted.Span{
+		Start: upperPrefix,
+		End:   keysbase.PrefixEnd(upperPrefix),
+	}
+	if !r.Lower.IsInf() {
+		start, err := encodeRangeInvertedBoundKey(inKey, rangeInvertedUpperPrefix, r.Lower, rangeInvertedNegInfMarker)
+		if err != nil {
+			return nil, err
+		}
+		upperSpan.Start = start
+	}
+
+	lowerExpr := inverted.ExprForSpan(lowerSpan, false /* tight */)
+	lowerExpr.Unique = true
+	upperExpr := inverted.ExprForSpan(upperSpan, false /* tight */)
+	upperExpr.Unique = true
+	return inverted.And(lowerExpr, upperExpr), nil
+}
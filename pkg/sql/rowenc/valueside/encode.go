@@ -113,6 +113,18 @@ func EncodeWithScratch(
 			return nil, nil, err
 		}
 		return encoding.EncodePGVectorValue(appendTo, uint32(colID), scratch), scratch, nil
+	case *tree.DRange:
+		scratch, err = encodeRange(scratch[:0], t)
+		if err != nil {
+			return nil, nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), scratch), scratch, nil
+	case *tree.DMultirange:
+		scratch, err = encodeMultirange(scratch[:0], t)
+		if err != nil {
+			return nil, nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), scratch), scratch, nil
 	case *tree.DArray:
 		scratch, err = encodeArray(t, scratch[:0])
 		if err != nil {
@@ -0,0 +1,122 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The flags stored in the first byte of the value encoding of a range. They
+// match the RANGE_* flags used by Postgres.
+const (
+	rangeFlagEmpty    = 0x01
+	rangeFlagLowerInc = 0x02
+	rangeFlagUpperInc = 0x04
+	rangeFlagLowerInf = 0x08
+	rangeFlagUpperInf = 0x10
+)
+
+// encodeRange produces the value encoding of a range: a flags byte followed
+// by the untagged encodings of its finite bounds.
+func encodeRange(b []byte, r *tree.DRange) ([]byte, error) {
+	if r.Empty {
+		return append(b, rangeFlagEmpty), nil
+	}
+	var flags byte
+	if r.Lower.Inclusive {
+		flags |= rangeFlagLowerInc
+	}
+	if r.Upper.Inclusive {
+		flags |= rangeFlagUpperInc
+	}
+	if r.Lower.IsInf() {
+		flags |= rangeFlagLowerInf
+	}
+	if r.Upper.IsInf() {
+		flags |= rangeFlagUpperInf
+	}
+	b = append(b, flags)
+	var err error
+	if !r.Lower.IsInf() {
+		if b, err = encodeArrayElement(b, r.Lower.Val); err != nil {
+			return nil, err
+		}
+	}
+	if !r.Upper.IsInf() {
+		if b, err = encodeArrayElement(b, r.Upper.Val); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// encodeMultirange produces the value encoding of a multirange: the number of
+// ranges followed by the encoding of each range.
+func encodeMultirange(b []byte, m *tree.DMultirange) ([]byte, error) {
+	b = encoding.EncodeNonsortingUvarint(b, uint64(len(m.Ranges)))
+	var err error
+	for _, r := range m.Ranges {
+		if b, err = encodeRange(b, r); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// decodeRange decodes a range of the given type encoded by encodeRange.
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (*tree.DRange, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&rangeFlagEmpty != 0 {
+		return tree.NewEmptyDRange(t), b, nil
+	}
+	lower := tree.RangeBound{Inclusive: flags&rangeFlagLowerInc != 0}
+	upper := tree.RangeBound{Inclusive: flags&rangeFlagUpperInc != 0}
+	var err error
+	if flags&rangeFlagLowerInf == 0 {
+		if lower.Val, b, err = DecodeUntaggedDatum(a, t.RangeSubtype(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	if flags&rangeFlagUpperInf == 0 {
+		if upper.Val, b, err = DecodeUntaggedDatum(a, t.RangeSubtype(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	r, err := tree.NewDRange(t, lower, upper)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, b, nil
+}
+
+// decodeMultirange decodes a multirange of the given type encoded by
+// encodeMultirange.
+func decodeMultirange(
+	a *tree.DatumAlloc, t *types.T, b []byte,
+) (*tree.DMultirange, []byte, error) {
+	b, _, n, err := encoding.DecodeNonsortingUvarint(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranges := make([]*tree.DRange, n)
+	for i := range ranges {
+		if ranges[i], b, err = decodeRange(a, t.MultirangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	m, err := tree.NewDMultirange(t, ranges)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, b, nil
+}
@@ -7,9 +7,12 @@ package rowexec
 
 import (
 	"context"
+	"hash/fnv"
+	"math/rand"
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
@@ -23,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/optional"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -45,6 +49,11 @@ type tableReader struct {
 
 	ignoreMisplannedRanges bool
 
+	// If sampler is set, each row read is returned with probability
+	// sampleFraction. See TableReaderSpec.BernoulliSample.
+	sampler        *rand.Rand
+	sampleFraction float64
+
 	// fetcher wraps a row.Fetcher, allowing the tableReader to add a stat
 	// collection layer.
 	fetcher rowFetcher
@@ -162,6 +171,10 @@ func newTableReader(
 	}
 
 	tr.Spans = spec.Spans
+	if sample := spec.BernoulliSample; sample != nil {
+		tr.sampler = newBernoulliSampler(sample, spec.Spans)
+		tr.sampleFraction = sample.Fraction
+	}
 	if !tr.ignoreMisplannedRanges {
 		// Make a copy of the spans so that we could get the misplanned ranges
 		// info.
@@ -181,6 +194,22 @@ func newTableReader(
 	return tr, nil
 }
 
+// newBernoulliSampler returns the random number generator used to sample the
+// rows read from the given spans. A REPEATABLE sample is seeded by the seed of
+// the sample and the start of the spans, so that the same rows are sampled
+// when the same spans of an unchanged table are read again.
+func newBernoulliSampler(sample *execinfrapb.BernoulliSampleSpec, spans roachpb.Spans) *rand.Rand {
+	if !sample.Repeatable {
+		rng, _ := randutil.NewPseudoRand()
+		return rng
+	}
+	h := fnv.New64a()
+	if len(spans) > 0 {
+		_, _ = h.Write(spans[0].Key)
+	}
+	return rand.New(rand.NewSource(sample.Seed ^ int64(h.Sum64())))
+}
+
 func (tr *tableReader) generateTrailingMeta() []execinfrapb.ProducerMetadata {
 	// We need to generate metadata before closing the processor because
 	// InternalClose() updates tr.Ctx to the "original" context.
@@ -284,6 +313,9 @@ func (tr *tableReader) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata
 		// case can avoid tracking of the stall time which gives a noticeable
 		// performance hit.
 		tr.rowsRead++
+		if tr.sampler != nil && tr.sampler.Float64() >= tr.sampleFraction {
+			continue
+		}
 		if outRow := tr.ProcessRowHelper(row); outRow != nil {
 			return outRow, nil
 		}
@@ -130,13 +130,26 @@ func newWindower(
 	// column for each of window functions it is computing.
 	w.outputTypes = make([]*types.T, len(w.inputTypes)+len(windowFns))
 	copy(w.outputTypes, w.inputTypes)
+	var semaCtx *tree.SemaContext
 	for _, windowFn := range windowFns {
 		// Check for out of bounds arguments has been done during planning step.
 		argTypes := make([]*types.T, len(windowFn.ArgsIdxs))
 		for i, argIdx := range windowFn.ArgsIdxs {
 			argTypes[i] = w.inputTypes[argIdx]
 		}
-		windowConstructor, outputType, err := execagg.GetWindowFunctionInfo(windowFn.Func, argTypes...)
+		var windowConstructor func(*eval.Context) eval.WindowFunc
+		var outputType *types.T
+		var err error
+		if windowFn.UserDefined != nil {
+			if semaCtx == nil {
+				semaCtx = flowCtx.NewSemaContext(flowCtx.Txn)
+			}
+			windowConstructor, outputType, err = execagg.GetUserDefinedWindowFunctionInfo(
+				ctx, w.evalCtx, semaCtx, windowFn.UserDefined, argTypes...,
+			)
+		} else {
+			windowConstructor, outputType, err = execagg.GetWindowFunctionInfo(windowFn.Func, argTypes...)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -442,6 +442,14 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.AND_AND)
 			return
+		case '<': // &<
+			s.pos++
+			lval.SetID(lexbase.NOT_EXTEND_RIGHT)
+			return
+		case '>': // &>
+			s.pos++
+			lval.SetID(lexbase.NOT_EXTEND_LEFT)
+			return
 		}
 		return
 
@@ -457,6 +465,13 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 			s.pos++
 			lval.SetID(lexbase.FETCHVAL)
 			return
+		case '|': // -|
+			if s.peekN(1) == '-' {
+				// -|-
+				s.pos += 2
+				lval.SetID(lexbase.ADJACENT)
+				return
+			}
 		}
 		return
 
@@ -579,6 +579,10 @@ func (sc *SchemaChanger) maybeMakeAddTablePublic(
 			return nil
 		}
 		mut.State = descpb.DescriptorState_PUBLIC
+		if mut.MaterializedView() && !mut.IsRefreshViewRequired() {
+			refreshedAt := mut.GetCreateAsOfTime()
+			mut.MaterializedViewRefreshedAt = &refreshedAt
+		}
 		return txn.Descriptors().WriteDesc(ctx, true /* kvTrace */, mut, txn.KV())
 	})
 }
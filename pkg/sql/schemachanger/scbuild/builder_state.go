@@ -1087,7 +1087,7 @@ func (b *builderState) ResolveUserDefinedTypeType(
 	case descpb.TypeDescriptor_ENUM:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
-	case descpb.TypeDescriptor_COMPOSITE:
+	case descpb.TypeDescriptor_COMPOSITE, descpb.TypeDescriptor_DOMAIN:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
@@ -1825,6 +1825,23 @@ func (b *builderState) ReplaceSeqTypeNamesInStatements(
 	return b.serializeUserDefinedTypes(newQueryStr, lang)
 }
 
+// EventTriggerUsingFunction implements the scbuildstmt.FunctionHelpers
+// interface.
+func (b *builderState) EventTriggerUsingFunction(fnID descpb.ID) (name string, ok bool) {
+	fn := b.readDescriptor(fnID)
+	db, isDB := b.readDescriptor(fn.GetParentID()).(catalog.DatabaseDescriptor)
+	if !isDB {
+		return "", false
+	}
+	_ = db.ForEachEventTrigger(func(trigName string, trig descpb.DatabaseDescriptor_EventTriggerInfo) error {
+		if trig.FuncID == fnID && !ok {
+			name, ok = trigName, true
+		}
+		return nil
+	})
+	return name, ok
+}
+
 func (b *builderState) replaceSeqNamesWithIDs(
 	queryStr string, lang catpb.Function_Language,
 ) string {
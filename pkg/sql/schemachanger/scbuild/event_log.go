@@ -406,6 +406,14 @@ func (pb payloadBuilder) build(b buildCtx) logpb.EventPayload {
 				TypeName: fullyQualifiedName(b, e),
 			}
 		}
+	case *scpb.DomainType:
+		if pb.TargetStatus == scpb.Status_PUBLIC {
+			return nil
+		} else {
+			return &eventpb.DropType{
+				TypeName: fullyQualifiedName(b, e),
+			}
+		}
 	case *scpb.SecondaryIndex:
 		if pb.TargetStatus == scpb.Status_PUBLIC {
 			return &eventpb.CreateIndex{
@@ -541,5 +549,10 @@ func (pb payloadBuilder) build(b buildCtx) logpb.EventPayload {
 			CascadeDroppedViews: pb.cascadeDroppedViews(b),
 		}
 	}
+	if _, _, domain := scpb.FindDomainType(b.QueryByID(screl.GetDescID(pb.Element()))); domain != nil {
+		return &eventpb.AlterType{
+			TypeName: fullyQualifiedName(b, pb.Element()),
+		}
+	}
 	return nil
 }
@@ -0,0 +1,255 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package scbuildstmt
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// AlterDomain implements ALTER DOMAIN.
+func AlterDomain(b BuildCtx, n *tree.AlterDomain) {
+	b.IncrementSchemaChangeAlterCounter("domain", n.Cmd.TelemetryName())
+	elts := b.ResolveUserDefinedTypeType(n.Domain, ResolveParams{
+		RequireOwnership: true,
+	})
+	_, _, domain := scpb.FindDomainType(elts)
+	if domain == nil {
+		panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", n.Domain.Object()))
+	}
+	// Mutate the AST to have the fully resolved name, which will be used for
+	// both event logging and errors.
+	tn := tree.MakeTypeNameWithPrefix(b.NamePrefix(domain), n.Domain.Object())
+	b.SetUnresolvedNameAnnotation(n.Domain, &tn)
+	elts = elts.Filter(publicTargetFilter)
+
+	var changed scpb.Element
+	switch t := n.Cmd.(type) {
+	case *tree.AlterDomainSetDefault:
+		changed = alterDomainSetDefault(b, domain, elts, t)
+	case *tree.AlterDomainSetNotNull:
+		changed = alterDomainSetNotNull(b, &tn, domain, elts, t)
+	case *tree.AlterDomainAddConstraint:
+		changed = alterDomainAddConstraint(b, &tn, domain, elts, t)
+	case *tree.AlterDomainDropConstraint:
+		changed = alterDomainDropConstraint(b, &tn, elts, t)
+	case *tree.AlterDomainRenameConstraint:
+		changed = alterDomainRenameConstraint(b, &tn, elts, t)
+	case *tree.AlterDomainValidateConstraint:
+		alterDomainValidateConstraint(b, &tn, domain, elts, t)
+	default:
+		panic(errors.AssertionFailedf("unsupported ALTER DOMAIN command %T", t))
+	}
+	if changed != nil {
+		b.LogEventForExistingTarget(changed)
+	}
+}
+
+func alterDomainSetDefault(
+	b BuildCtx, domain *scpb.DomainType, elts ElementResultSet, t *tree.AlterDomainSetDefault,
+) scpb.Element {
+	oldDefault := elts.FilterDomainTypeDefault().MustGetZeroOrOneElement()
+	if oldDefault != nil {
+		b.Drop(oldDefault)
+	}
+	if t.Default == nil || t.Default == tree.DNull {
+		if oldDefault == nil {
+			return nil
+		}
+		return oldDefault
+	}
+	expr, err := schemaexpr.ValidateDomainDefaultExpr(
+		b, t.Default, domainBaseType(b, domain), b.SemaCtx(),
+		b.EvalCtx().Settings.Version.ActiveVersion(b),
+	)
+	if err != nil {
+		panic(err)
+	}
+	newDefault := &scpb.DomainTypeDefault{
+		TypeID: domain.TypeID,
+		Expr:   catpb.Expression(expr),
+	}
+	b.Add(newDefault)
+	return newDefault
+}
+
+func alterDomainSetNotNull(
+	b BuildCtx,
+	tn *tree.TypeName,
+	domain *scpb.DomainType,
+	elts ElementResultSet,
+	t *tree.AlterDomainSetNotNull,
+) scpb.Element {
+	notNull := elts.FilterDomainTypeNotNull().MustGetZeroOrOneElement()
+	if !t.NotNull {
+		if notNull == nil {
+			return nil
+		}
+		b.Drop(notNull)
+		return notNull
+	}
+	if notNull != nil {
+		return nil
+	}
+	panicIfDomainIsReferenced(b, tn, domain, "SET NOT NULL")
+	notNull = &scpb.DomainTypeNotNull{TypeID: domain.TypeID}
+	b.Add(notNull)
+	return notNull
+}
+
+func alterDomainAddConstraint(
+	b BuildCtx,
+	tn *tree.TypeName,
+	domain *scpb.DomainType,
+	elts ElementResultSet,
+	t *tree.AlterDomainAddConstraint,
+) scpb.Element {
+	checks := elts.FilterDomainTypeCheck()
+	name := string(t.Check.Name)
+	if name == "" {
+		name = generateDomainCheckName(tn.Object(), checks)
+	} else if findDomainCheck(checks, name) != nil {
+		panic(pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", name, tn.Object()))
+	}
+	// A NOT VALID constraint is only enforced on new values, so there is no need
+	// to check existing data.
+	if t.ValidationBehavior != tree.ValidationSkip {
+		panicIfDomainIsReferenced(b, tn, domain, "ADD CONSTRAINT")
+	}
+	expr, err := schemaexpr.ValidateDomainCheckExpr(
+		b, t.Check.Expr, domainBaseType(b, domain), b.SemaCtx(),
+		b.EvalCtx().Settings.Version.ActiveVersion(b),
+	)
+	if err != nil {
+		panic(err)
+	}
+	check := &scpb.DomainTypeCheck{
+		TypeID: domain.TypeID,
+		Name:   name,
+		Expr:   catpb.Expression(expr),
+	}
+	b.Add(check)
+	return check
+}
+
+func alterDomainDropConstraint(
+	b BuildCtx, tn *tree.TypeName, elts ElementResultSet, t *tree.AlterDomainDropConstraint,
+) scpb.Element {
+	check := findDomainCheck(elts.FilterDomainTypeCheck(), string(t.Constraint))
+	if check == nil {
+		if t.IfExists {
+			b.EvalCtx().ClientNoticeSender.BufferClientNotice(b,
+				pgnotice.Newf("constraint %q of domain %q does not exist, skipping",
+					t.Constraint, tn.Object()),
+			)
+			return nil
+		}
+		panic(undefinedDomainConstraintError(tn, t.Constraint))
+	}
+	b.Drop(check)
+	return check
+}
+
+func alterDomainRenameConstraint(
+	b BuildCtx, tn *tree.TypeName, elts ElementResultSet, t *tree.AlterDomainRenameConstraint,
+) scpb.Element {
+	checks := elts.FilterDomainTypeCheck()
+	check := findDomainCheck(checks, string(t.Constraint))
+	if check == nil {
+		panic(undefinedDomainConstraintError(tn, t.Constraint))
+	}
+	if t.Constraint == t.NewName {
+		return nil
+	}
+	if findDomainCheck(checks, string(t.NewName)) != nil {
+		panic(pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", t.NewName, tn.Object()))
+	}
+	b.Drop(check)
+	renamed := &scpb.DomainTypeCheck{
+		TypeID: check.TypeID,
+		Name:   string(t.NewName),
+		Expr:   check.Expr,
+	}
+	b.Add(renamed)
+	return renamed
+}
+
+func alterDomainValidateConstraint(
+	b BuildCtx,
+	tn *tree.TypeName,
+	domain *scpb.DomainType,
+	elts ElementResultSet,
+	t *tree.AlterDomainValidateConstraint,
+) {
+	if findDomainCheck(elts.FilterDomainTypeCheck(), string(t.Constraint)) == nil {
+		panic(undefinedDomainConstraintError(tn, t.Constraint))
+	}
+	// Constraints of a domain which is not used anywhere are trivially valid.
+	panicIfDomainIsReferenced(b, tn, domain, "VALIDATE CONSTRAINT")
+}
+
+// domainBaseType returns the base type of the domain.
+func domainBaseType(b BuildCtx, domain *scpb.DomainType) *types.T {
+	typ := b.ResolveTypeRef(&tree.OIDTypeReference{OID: catid.TypeIDToOID(domain.TypeID)})
+	return typ.Type.DomainBaseType()
+}
+
+// panicIfDomainIsReferenced panics with an unimplemented error if the domain
+// or its array type are used by other descriptors, since validating the
+// existing values of a domain is not supported.
+func panicIfDomainIsReferenced(
+	b BuildCtx, tn *tree.TypeName, domain *scpb.DomainType, op string,
+) {
+	if undroppedBackrefs(b, domain.TypeID).IsEmpty() &&
+		undroppedBackrefs(b, domain.ArrayTypeID).IsEmpty() {
+		return
+	}
+	panic(unimplemented.Newf("alter domain validate",
+		"ALTER DOMAIN %s is not supported for domain %q, which is in use",
+		op, tn.Object()))
+}
+
+func findDomainCheck(
+	checks *scpb.ElementCollection[*scpb.DomainTypeCheck], name string,
+) (ret *scpb.DomainTypeCheck) {
+	checks.ForEach(func(_ scpb.Status, _ scpb.TargetStatus, e *scpb.DomainTypeCheck) {
+		if e.Name == name {
+			ret = e
+		}
+	})
+	return ret
+}
+
+// generateDomainCheckName generates a name for an unnamed CHECK constraint of
+// a domain the way Postgres does, i.e. <domain>_check, followed by a number if
+// that name is taken.
+func generateDomainCheckName(
+	domainName string, checks *scpb.ElementCollection[*scpb.DomainTypeCheck],
+) string {
+	name := domainName + "_check"
+	for i := 1; findDomainCheck(checks, name) != nil; i++ {
+		name = fmt.Sprintf("%s_check%d", domainName, i)
+	}
+	return name
+}
+
+func undefinedDomainConstraintError(tn *tree.TypeName, constraint tree.Name) error {
+	return pgerror.Newf(pgcode.UndefinedObject,
+		"constraint %q of domain %q does not exist", constraint, tn.Object())
+}
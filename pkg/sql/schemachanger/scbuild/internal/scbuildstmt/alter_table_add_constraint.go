@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -24,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
@@ -39,6 +42,10 @@ func alterTableAddConstraint(
 	stmt tree.Statement,
 	t *tree.AlterTableAddConstraint,
 ) {
+	if tree.IsDeferrableConstraintDef(t.ConstraintDef) &&
+		!b.EvalCtx().Settings.Version.ActiveVersion(b).IsActive(clusterversion.V25_2_DeferrableConstraints) {
+		panic(sqlerrors.NewDeferrableConstraintsNotSupportedError())
+	}
 	switch d := t.ConstraintDef.(type) {
 	case *tree.UniqueConstraintTableDef:
 		if d.PrimaryKey {
@@ -49,6 +56,9 @@ func alterTableAddConstraint(
 			if t.ValidationBehavior == tree.ValidationSkip {
 				panic(sqlerrors.NewUnsupportedUnvalidatedConstraintError(catconstants.ConstraintTypeUnique))
 			}
+			if d.Deferrability.IsDeferrable() {
+				panic(sqlerrors.NewDeferrableUniqueIndexError())
+			}
 			CreateIndex(b, &tree.CreateIndex{
 				Name:        d.Name,
 				Table:       *tn,
@@ -62,6 +72,8 @@ func alterTableAddConstraint(
 		alterTableAddCheck(b, tn, tbl, t)
 	case *tree.ForeignKeyConstraintTableDef:
 		alterTableAddForeignKey(b, tn, tbl, stmt, t)
+	case *tree.ExclusionConstraintTableDef:
+		alterTableAddExclusion(b, tn, tbl, t)
 	}
 }
 
@@ -423,6 +435,8 @@ func alterTableAddForeignKey(
 			OnDeleteAction:          tree.ForeignKeyReferenceActionValue[fkDef.Actions.Delete],
 			CompositeKeyMatchMethod: tree.CompositeKeyMatchMethodValue[fkDef.Match],
 			IndexIDForValidation:    getIndexIDForValidationForConstraint(b, tbl.TableID),
+			Deferrable:              fkDef.Deferrability.IsDeferrable(),
+			InitiallyDeferred:       fkDef.Deferrability.IsInitiallyDeferred(),
 		}
 		b.Add(fk)
 		b.LogEventForExistingTarget(fk)
@@ -436,6 +450,8 @@ func alterTableAddForeignKey(
 			OnUpdateAction:          tree.ForeignKeyReferenceActionValue[fkDef.Actions.Update],
 			OnDeleteAction:          tree.ForeignKeyReferenceActionValue[fkDef.Actions.Delete],
 			CompositeKeyMatchMethod: tree.CompositeKeyMatchMethodValue[fkDef.Match],
+			Deferrable:              fkDef.Deferrability.IsDeferrable(),
+			InitiallyDeferred:       fkDef.Deferrability.IsInitiallyDeferred(),
 		}
 		b.Add(fk)
 		b.LogEventForExistingTarget(fk)
@@ -479,40 +495,155 @@ func alterTableAddUniqueWithoutIndex(
 		))
 	}
 
-	// 2. Check that columns that we want to have uniqueness should have no duplicate.
+	columns := make([]tree.Name, len(d.Columns))
+	for i, col := range d.Columns {
+		columns[i] = col.Column
+	}
+	addUniqueWithoutIndexConstraint(b, tn, tbl, t, uniqueWithoutIndexSpec{
+		name:          &d.Name,
+		columns:       columns,
+		predicate:     &d.Predicate,
+		deferrability: d.Deferrability,
+	})
+}
+
+// alterTableAddExclusion contains logic for building
+// `ALTER TABLE ... ADD CONSTRAINT ... EXCLUDE ...`.
+// It assumes `t` is such a command.
+//
+// Exclusion constraints are added as UNIQUE WITHOUT INDEX constraints with
+// exclusion operators, so no index is created.
+func alterTableAddExclusion(
+	b BuildCtx, tn *tree.TableName, tbl *scpb.Table, t *tree.AlterTableAddConstraint,
+) {
+	d := t.ConstraintDef.(*tree.ExclusionConstraintTableDef)
+	if !b.EvalCtx().Settings.Version.ActiveVersion(b).IsActive(clusterversion.V25_2_ExclusionConstraints) {
+		panic(sqlerrors.NewExclusionConstraintsNotSupportedError())
+	}
+	// The checks of exclusion constraints don't lock the conflicting rows, so
+	// the constraints can only be enforced under serializable isolation.
+	if b.EvalCtx().TxnIsoLevel != isolation.Serializable {
+		panic(sqlerrors.NewExclusionConstraintIsolationError())
+	}
+	colType := func(name tree.Name) (*types.T, error) {
+		colID := getColumnIDFromColumnName(b, tbl.TableID, name, true /* required */)
+		return mustRetrieveColumnTypeElem(b, tbl.TableID, colID).Type, nil
+	}
+	ops, err := schemaexpr.ValidateExclusionConstraintElems(d.Method, d.Elems, colType)
+	if err != nil {
+		panic(err)
+	}
+	indexColumns, indexType, err := schemaexpr.ExclusionConstraintIndexColumns(d.Elems, colType)
+	if err != nil {
+		panic(err)
+	}
+	if len(indexColumns) > 0 && !hasExclusionConstraintIndex(b, tbl.TableID, indexColumns, indexType) {
+		CreateIndex(b, &tree.CreateIndex{Table: *tn, Type: indexType, Columns: indexColumns})
+	}
+	columns := make([]tree.Name, len(d.Elems))
+	for i, elem := range d.Elems {
+		columns[i] = elem.Column
+	}
+	addUniqueWithoutIndexConstraint(b, tn, tbl, t, uniqueWithoutIndexSpec{
+		name:               &d.Name,
+		columns:            columns,
+		predicate:          &d.Predicate,
+		exclusionOperators: ops,
+		deferrability:      d.Deferrability,
+	})
+}
+
+// hasExclusionConstraintIndex returns whether the table has a non-partial
+// secondary index of the given type on the given columns, which can back an
+// exclusion constraint.
+func hasExclusionConstraintIndex(
+	b BuildCtx, tableID catid.DescID, columns tree.IndexElemList, typ idxtype.T,
+) bool {
+	found := false
+	b.QueryByID(tableID).Filter(publicTargetFilter).FilterSecondaryIndex().
+		ForEach(func(_ scpb.Status, _ scpb.TargetStatus, idx *scpb.SecondaryIndex) {
+			if found || idx.Type != typ || idx.EmbeddedExpr != nil {
+				return
+			}
+			keyColumnIDs, _, _ := getSortedColumnIDsInIndexByKind(b, tableID, idx.IndexID)
+			if len(keyColumnIDs) != len(columns) {
+				return
+			}
+			for i := range columns {
+				if keyColumnIDs[i] != getColumnIDFromColumnName(b, tableID, columns[i].Column, true /* required */) {
+					return
+				}
+			}
+			found = true
+		})
+	return found
+}
+
+// uniqueWithoutIndexSpec describes a UNIQUE WITHOUT INDEX or EXCLUDE
+// constraint to add to a table.
+type uniqueWithoutIndexSpec struct {
+	// name and predicate point to the fields of the constraint definition,
+	// which are updated with the generated name and the validated predicate.
+	name      *tree.Name
+	columns   []tree.Name
+	predicate *tree.Expr
+	// exclusionOperators is only set for EXCLUDE constraints.
+	exclusionOperators []string
+	deferrability      tree.ConstraintDeferrability
+}
+
+// addUniqueWithoutIndexConstraint adds the elements of the given UNIQUE
+// WITHOUT INDEX or EXCLUDE constraint to the builder state.
+func addUniqueWithoutIndexConstraint(
+	b BuildCtx,
+	tn *tree.TableName,
+	tbl *scpb.Table,
+	t *tree.AlterTableAddConstraint,
+	spec uniqueWithoutIndexSpec,
+) {
+	kind := "unique"
+	if len(spec.exclusionOperators) > 0 {
+		kind = "exclusion"
+	}
+
+	// 1. Check that columns that we want to have uniqueness should have no duplicate.
 	var colSet catalog.TableColSet
 	var colIDs []catid.ColumnID
 	var colNames []string
-	for _, col := range d.Columns {
-		colID := getColumnIDFromColumnName(b, tbl.TableID, col.Column, true /*required*/)
+	for _, col := range spec.columns {
+		colID := getColumnIDFromColumnName(b, tbl.TableID, col, true /*required*/)
 		if colSet.Contains(colID) {
 			panic(pgerror.Newf(pgcode.DuplicateColumn,
-				"column %q appears twice in unique constraint", col.Column))
+				"column %q appears twice in %s constraint", col, kind))
 		}
 		colSet.Add(colID)
 		colIDs = append(colIDs, colID)
-		colNames = append(colNames, string(col.Column))
+		colNames = append(colNames, string(col))
 	}
 
-	// 3. If a name is provided, check that this name is not used; Otherwise, generate
+	// 2. If a name is provided, check that this name is not used; Otherwise, generate
 	// a unique name for it.
 	if skip, err := validateConstraintNameIsNotUsed(b, tn, tbl, t); err != nil {
 		panic(err)
 	} else if skip {
 		return
 	}
-	if d.Name == "" {
-		d.Name = tree.Name(tabledesc.GenerateUniqueName(
-			fmt.Sprintf("unique_%s", strings.Join(colNames, "_")),
+	if *spec.name == "" {
+		prefix := fmt.Sprintf("unique_%s", strings.Join(colNames, "_"))
+		if len(spec.exclusionOperators) > 0 {
+			prefix = fmt.Sprintf("%s_%s_excl", tn.Object(), strings.Join(colNames, "_"))
+		}
+		*spec.name = tree.Name(tabledesc.GenerateUniqueName(
+			prefix,
 			func(name string) bool {
 				return constraintNameInUse(b, tbl.TableID, name)
 			},
 		))
 	}
 
-	// 4. If there is a predicate, validate it.
-	if d.Predicate != nil {
-		predicate, _, _, err := schemaexpr.DequalifyAndValidateExprImpl(b, d.Predicate, types.Bool,
+	// 3. If there is a predicate, validate it.
+	if *spec.predicate != nil {
+		predicate, _, _, err := schemaexpr.DequalifyAndValidateExprImpl(b, *spec.predicate, types.Bool,
 			tree.UniqueWithoutIndexPredicateExpr, b.SemaCtx(), volatility.Immutable, tn, b.ClusterSettings().Version.ActiveVersion(b),
 			func() colinfo.ResultColumns {
 				return getNonDropResultColumns(b, tbl.TableID)
@@ -528,10 +659,10 @@ func alterTableAddUniqueWithoutIndex(
 		if err != nil {
 			panic(err)
 		}
-		d.Predicate = typedPredicate
+		*spec.predicate = typedPredicate
 	}
 
-	// 5. (Finally!) Add a UniqueWithoutIndex, ConstraintName element to builder state.
+	// 4. (Finally!) Add a UniqueWithoutIndex, ConstraintName element to builder state.
 	constraintID := b.NextTableConstraintID(tbl.TableID)
 	if t.ValidationBehavior == tree.ValidationDefault {
 		uwi := &scpb.UniqueWithoutIndexConstraint{
@@ -539,20 +670,26 @@ func alterTableAddUniqueWithoutIndex(
 			ConstraintID:         constraintID,
 			ColumnIDs:            colIDs,
 			IndexIDForValidation: getIndexIDForValidationForConstraint(b, tbl.TableID),
+			Deferrable:           spec.deferrability.IsDeferrable(),
+			InitiallyDeferred:    spec.deferrability.IsInitiallyDeferred(),
+			ExclusionOperators:   spec.exclusionOperators,
 		}
-		if d.Predicate != nil {
-			uwi.Predicate = b.WrapExpression(tbl.TableID, d.Predicate)
+		if *spec.predicate != nil {
+			uwi.Predicate = b.WrapExpression(tbl.TableID, *spec.predicate)
 		}
 		b.Add(uwi)
 		b.LogEventForExistingTarget(uwi)
 	} else {
 		uwi := &scpb.UniqueWithoutIndexConstraintUnvalidated{
-			TableID:      tbl.TableID,
-			ConstraintID: constraintID,
-			ColumnIDs:    colIDs,
+			TableID:            tbl.TableID,
+			ConstraintID:       constraintID,
+			ColumnIDs:          colIDs,
+			Deferrable:         spec.deferrability.IsDeferrable(),
+			InitiallyDeferred:  spec.deferrability.IsInitiallyDeferred(),
+			ExclusionOperators: spec.exclusionOperators,
 		}
-		if d.Predicate != nil {
-			uwi.Predicate = b.WrapExpression(tbl.TableID, d.Predicate)
+		if *spec.predicate != nil {
+			uwi.Predicate = b.WrapExpression(tbl.TableID, *spec.predicate)
 		}
 		b.Add(uwi)
 		b.LogEventForExistingTarget(uwi)
@@ -560,7 +697,7 @@ func alterTableAddUniqueWithoutIndex(
 	b.Add(&scpb.ConstraintWithoutIndexName{
 		TableID:      tbl.TableID,
 		ConstraintID: constraintID,
-		Name:         string(d.Name),
+		Name:         string(*spec.name),
 	})
 }
 
@@ -626,6 +763,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		ifNotExists = d.IfNotExists
+	case *tree.ExclusionConstraintTableDef:
+		name = d.Name
+		ifNotExists = d.IfNotExists
 	default:
 		return false, errors.AssertionFailedf(
 			"unsupported constraint: %T", t.ConstraintDef)
@@ -128,10 +128,13 @@ func validateConstraint(b BuildCtx, tableID catid.DescID, spec validateConstrain
 	if spec.uwiNotValidElem != nil {
 		b.Drop(spec.uwiNotValidElem)
 		b.Add(&scpb.UniqueWithoutIndexConstraint{
-			TableID:      tableID,
-			ConstraintID: nextConstraintID,
-			ColumnIDs:    spec.uwiNotValidElem.ColumnIDs,
-			Predicate:    spec.uwiNotValidElem.Predicate,
+			TableID:            tableID,
+			ConstraintID:       nextConstraintID,
+			ColumnIDs:          spec.uwiNotValidElem.ColumnIDs,
+			Predicate:          spec.uwiNotValidElem.Predicate,
+			Deferrable:         spec.uwiNotValidElem.Deferrable,
+			InitiallyDeferred:  spec.uwiNotValidElem.InitiallyDeferred,
+			ExclusionOperators: spec.uwiNotValidElem.ExclusionOperators,
 		})
 	}
 	if spec.fkNotValidElem != nil {
@@ -146,6 +149,8 @@ func validateConstraint(b BuildCtx, tableID catid.DescID, spec validateConstrain
 			OnDeleteAction:          spec.fkNotValidElem.OnDeleteAction,
 			CompositeKeyMatchMethod: spec.fkNotValidElem.CompositeKeyMatchMethod,
 			IndexIDForValidation:    getIndexIDForValidationForConstraint(b, tableID),
+			Deferrable:              spec.fkNotValidElem.Deferrable,
+			InitiallyDeferred:       spec.fkNotValidElem.InitiallyDeferred,
 		})
 	}
 	b.Drop(spec.constraintNameElem)
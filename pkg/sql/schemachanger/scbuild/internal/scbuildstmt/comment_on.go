@@ -77,6 +77,8 @@ func CommentOnType(b BuildCtx, statement *tree.CommentOnType) {
 		name.ObjectNamePrefix = b.NamePrefix(enumType)
 	} else if _, _, compositeType := scpb.FindCompositeType(typeElements); compositeType != nil {
 		name.ObjectNamePrefix = b.NamePrefix(compositeType)
+	} else if _, _, domainType := scpb.FindDomainType(typeElements); domainType != nil {
+		name.ObjectNamePrefix = b.NamePrefix(domainType)
 	} else {
 		panic(pgerror.New(pgcode.Syntax, "did not find composite type or enumerated type"))
 	}
@@ -158,6 +160,8 @@ func addComment(b BuildCtx, elements ElementResultSet, comment scpb.Element) {
 			comment.(*scpb.TypeComment).TypeID = object.TypeID
 		case *scpb.CompositeType:
 			comment.(*scpb.TypeComment).TypeID = object.TypeID
+		case *scpb.DomainType:
+			comment.(*scpb.TypeComment).TypeID = object.TypeID
 		case *scpb.Table:
 			comment.(*scpb.TableComment).TableID = object.TableID
 		case *scpb.Column:
@@ -27,6 +27,9 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 	if n.Replace {
 		panic(scerrors.NotImplementedError(n))
 	}
+	if n.Aggregate != nil {
+		panic(scerrors.NotImplementedErrorf(n, "CREATE AGGREGATE"))
+	}
 	b.IncrementSchemaChangeCreateCounter("function")
 
 	dbElts, scElts := b.ResolveTargetObject(n.Name.ToUnresolvedObjectName(), privilege.CREATE)
@@ -329,6 +329,10 @@ type FunctionHelpers interface {
 	WrapFunctionBody(fnID descpb.ID, bodyStr string, lang catpb.Function_Language,
 		returnType tree.ResolvableTypeReference, provider ReferenceProvider) *scpb.FunctionBody
 	ReplaceSeqTypeNamesInStatements(queryStr string, lang catpb.Function_Language) string
+
+	// EventTriggerUsingFunction returns the name of an event trigger which
+	// invokes the given function, if there is one.
+	EventTriggerUsingFunction(fnID descpb.ID) (name string, ok bool)
 }
 
 type SchemaHelpers interface {
@@ -0,0 +1,19 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// DropDomain implements DROP DOMAIN.
+func DropDomain(b BuildCtx, n *tree.DropDomain) {
+	if n.DropBehavior == tree.DropCascade {
+		panic(scerrors.NotImplementedErrorf(n, "DROP DOMAIN CASCADE is not yet supported"))
+	}
+	dropTypes(b, n.Names, n.IfExists, n.DropBehavior, true /* domainsOnly */)
+}
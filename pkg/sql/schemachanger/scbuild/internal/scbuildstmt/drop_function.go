@@ -14,6 +14,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
 )
 
 func DropFunction(b BuildCtx, n *tree.DropRoutine) {
@@ -39,6 +40,27 @@ func DropFunction(b BuildCtx, n *tree.DropRoutine) {
 		if fn == nil {
 			continue
 		}
+		if n.Aggregate && !fn.IsAggregate {
+			_, _, fnName := scpb.FindFunctionName(elts)
+			panic(pgerror.Newf(
+				pgcode.WrongObjectType, "function %s is not an aggregate", fnName.Name,
+			))
+		}
+		if !n.Aggregate && fn.IsAggregate {
+			_, _, fnName := scpb.FindFunctionName(elts)
+			panic(errors.WithHint(
+				pgerror.Newf(pgcode.WrongObjectType, "%s is an aggregate function", fnName.Name),
+				"Use DROP AGGREGATE to drop aggregate functions.",
+			))
+		}
+		if trigName, ok := b.EventTriggerUsingFunction(fn.FunctionID); ok {
+			_, _, fnName := scpb.FindFunctionName(elts)
+			panic(pgerror.Newf(
+				pgcode.DependentObjectsStillExist,
+				"cannot drop function %q because event trigger %q depends on it",
+				fnName.Name, trigName,
+			))
+		}
 		f.FuncName.ObjectNamePrefix = b.NamePrefix(fn)
 		if dropRestrictDescriptor(b, fn.FunctionID) {
 			toCheckBackRefs = append(toCheckBackRefs, fn.FunctionID)
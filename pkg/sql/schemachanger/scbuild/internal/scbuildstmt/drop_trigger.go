@@ -6,8 +6,11 @@
 package scbuildstmt
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
@@ -18,20 +21,29 @@ func DropTrigger(b BuildCtx, n *tree.DropTrigger) {
 		panic(unimplemented.NewWithIssue(128151, "cascade dropping triggers"))
 	}
 
-	// NOTE: DROP TRIGGER requires the user to have ownership of the table.
-	tableElems := b.ResolveTable(n.Table, ResolveParams{
+	// NOTE: DROP TRIGGER requires the user to have ownership of the table or
+	// view.
+	relationElems := b.ResolveRelation(n.Table, ResolveParams{
 		IsExistenceOptional: n.IfExists,
 		RequireOwnership:    true,
 	})
-	_, _, tbl := scpb.FindTable(tableElems)
-	if tbl == nil {
+	if relationElems == nil {
 		// IF EXISTS was true and the table was not found.
 		noticeSender.BufferClientNotice(b,
 			pgnotice.Newf("relation \"%v\" does not exist, skipping", n.Table))
 		return
 	}
+	var tableID catid.DescID
+	if _, _, tbl := scpb.FindTable(relationElems); tbl != nil {
+		tableID = tbl.TableID
+	} else if _, _, view := scpb.FindView(relationElems); view != nil {
+		tableID = view.ViewID
+	} else {
+		_, _, ns := scpb.FindNamespace(relationElems)
+		panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a table or view", ns.Name))
+	}
 
-	triggerElems := b.ResolveTrigger(tbl.TableID, n.Trigger, ResolveParams{
+	triggerElems := b.ResolveTrigger(tableID, n.Trigger, ResolveParams{
 		IsExistenceOptional: n.IfExists,
 	})
 	_, _, trigger := scpb.FindTrigger(triggerElems)
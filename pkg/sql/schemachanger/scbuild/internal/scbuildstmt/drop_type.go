@@ -22,13 +22,30 @@ func DropType(b BuildCtx, n *tree.DropType) {
 	if n.DropBehavior == tree.DropCascade {
 		panic(scerrors.NotImplementedErrorf(n, "DROP TYPE CASCADE is not yet supported"))
 	}
+	dropTypes(b, n.Names, n.IfExists, n.DropBehavior, false /* domainsOnly */)
+}
+
+// dropTypes drops the types with the given names. If domainsOnly is set, all
+// the types must be domains.
+func dropTypes(
+	b BuildCtx,
+	names []*tree.UnresolvedObjectName,
+	ifExists bool,
+	behavior tree.DropBehavior,
+	domainsOnly bool,
+) {
 	var toCheckBackrefs []catid.DescID
 	arrayTypesToAlsoCheck := make(map[catid.DescID]catid.DescID)
-	for _, name := range n.Names {
+	for _, name := range names {
 		elts := b.ResolveUserDefinedTypeType(name, ResolveParams{
-			IsExistenceOptional: n.IfExists,
+			IsExistenceOptional: ifExists,
 			RequiredPrivilege:   privilege.DROP,
 		})
+		if domainsOnly && !elts.IsEmpty() {
+			if _, _, domain := scpb.FindDomainType(elts); domain == nil {
+				panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name.Object()))
+			}
+		}
 		var typ scpb.Element
 		var typeID, arrayTypeID catid.DescID
 		if _, _, enum := scpb.FindEnumType(elts); enum != nil {
@@ -38,6 +55,9 @@ func DropType(b BuildCtx, n *tree.DropType) {
 		} else if _, _, composite := scpb.FindCompositeType(elts); composite != nil {
 			typeID, arrayTypeID = composite.TypeID, composite.ArrayTypeID
 			typ = composite
+		} else if _, _, domain := scpb.FindDomainType(elts); domain != nil {
+			typeID, arrayTypeID = domain.TypeID, domain.ArrayTypeID
+			typ = domain
 		} else {
 			continue
 		}
@@ -47,7 +67,7 @@ func DropType(b BuildCtx, n *tree.DropType) {
 		tn := tree.MakeTypeNameWithPrefix(prefix, name.Object())
 		b.SetUnresolvedNameAnnotation(name, &tn)
 		// Drop the type.
-		if n.DropBehavior == tree.DropCascade {
+		if behavior == tree.DropCascade {
 			dropCascadeDescriptor(b, typeID)
 		} else {
 			if dropRestrictDescriptor(b, typeID) {
@@ -214,6 +214,29 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				Name:            comp.GetElementLabel(i),
 			})
 		}
+	} else if domain := typ.AsDomainTypeDescriptor(); domain != nil {
+		w.ev(descriptorStatus(typ), &scpb.DomainType{
+			TypeID:      domain.GetID(),
+			ArrayTypeID: domain.GetArrayTypeID(),
+		})
+		if domain.IsNotNull() {
+			w.ev(descriptorStatus(typ), &scpb.DomainTypeNotNull{
+				TypeID: domain.GetID(),
+			})
+		}
+		if expr, ok := domain.GetDefaultExpr(); ok {
+			w.ev(descriptorStatus(typ), &scpb.DomainTypeDefault{
+				TypeID: domain.GetID(),
+				Expr:   catpb.Expression(expr),
+			})
+		}
+		for i := 0; i < domain.NumChecks(); i++ {
+			w.ev(descriptorStatus(typ), &scpb.DomainTypeCheck{
+				TypeID: domain.GetID(),
+				Name:   domain.GetCheckName(i),
+				Expr:   catpb.Expression(domain.GetCheckExpr(i)),
+			})
+		}
 	} else {
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -755,20 +778,31 @@ func (w *walkCtx) walkUniqueWithoutIndexConstraint(
 				c.GetName(), tbl.GetName(), tbl.GetID()))
 		}
 	}
+	columnIDs := c.CollectKeyColumnIDs().Ordered()
+	if c.IsExclusion() {
+		// The exclusion operators are in the order of the constraint's columns.
+		columnIDs = c.UniqueWithoutIndexDesc().ColumnIDs
+	}
 	if c.IsConstraintUnvalidated() {
 		uwi := &scpb.UniqueWithoutIndexConstraintUnvalidated{
-			TableID:      tbl.GetID(),
-			ConstraintID: c.GetConstraintID(),
-			ColumnIDs:    c.CollectKeyColumnIDs().Ordered(),
-			Predicate:    expr,
+			TableID:            tbl.GetID(),
+			ConstraintID:       c.GetConstraintID(),
+			ColumnIDs:          columnIDs,
+			Predicate:          expr,
+			Deferrable:         c.IsDeferrable(),
+			InitiallyDeferred:  c.IsInitiallyDeferred(),
+			ExclusionOperators: c.UniqueWithoutIndexDesc().ExclusionOperators,
 		}
 		w.ev(scpb.Status_PUBLIC, uwi)
 	} else {
 		uwi := &scpb.UniqueWithoutIndexConstraint{
-			TableID:      tbl.GetID(),
-			ConstraintID: c.GetConstraintID(),
-			ColumnIDs:    c.CollectKeyColumnIDs().Ordered(),
-			Predicate:    expr,
+			TableID:            tbl.GetID(),
+			ConstraintID:       c.GetConstraintID(),
+			ColumnIDs:          columnIDs,
+			Predicate:          expr,
+			Deferrable:         c.IsDeferrable(),
+			InitiallyDeferred:  c.IsInitiallyDeferred(),
+			ExclusionOperators: c.UniqueWithoutIndexDesc().ExclusionOperators,
 		}
 		w.ev(scpb.Status_PUBLIC, uwi)
 	}
@@ -953,6 +987,8 @@ func (w *walkCtx) walkForeignKeyConstraint(
 			OnUpdateAction:          c.OnUpdate(),
 			OnDeleteAction:          c.OnDelete(),
 			CompositeKeyMatchMethod: c.Match(),
+			Deferrable:              c.IsDeferrable(),
+			InitiallyDeferred:       c.IsInitiallyDeferred(),
 		})
 	} else {
 		w.ev(scpb.Status_PUBLIC, &scpb.ForeignKeyConstraint{
@@ -964,6 +1000,8 @@ func (w *walkCtx) walkForeignKeyConstraint(
 			OnUpdateAction:          c.OnUpdate(),
 			OnDeleteAction:          c.OnDelete(),
 			CompositeKeyMatchMethod: c.Match(),
+			Deferrable:              c.IsDeferrable(),
+			InitiallyDeferred:       c.IsInitiallyDeferred(),
 		})
 	}
 	w.ev(scpb.Status_PUBLIC, &scpb.ConstraintWithoutIndexName{
@@ -983,10 +1021,11 @@ func (w *walkCtx) walkForeignKeyConstraint(
 func (w *walkCtx) walkFunction(fnDesc catalog.FunctionDescriptor) {
 	typeT := newTypeT(fnDesc.GetReturnType().Type)
 	fn := &scpb.Function{
-		FunctionID: fnDesc.GetID(),
-		ReturnSet:  fnDesc.GetReturnType().ReturnSet,
-		ReturnType: *typeT,
-		Params:     make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
+		FunctionID:  fnDesc.GetID(),
+		ReturnSet:   fnDesc.GetReturnType().ReturnSet,
+		ReturnType:  *typeT,
+		Params:      make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
+		IsAggregate: fnDesc.IsAggregate(),
 	}
 	for i, param := range fnDesc.GetParams() {
 		typeT := newTypeT(param.Type)
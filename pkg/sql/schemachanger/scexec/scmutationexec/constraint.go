@@ -390,6 +390,8 @@ func (i *immediateVisitor) AddForeignKeyConstraint(
 		OnUpdate:            op.OnUpdateAction,
 		Match:               op.CompositeKeyMatchMethod,
 		ConstraintID:        op.ConstraintID,
+		Deferrable:          op.Deferrable,
+		InitiallyDeferred:   op.InitiallyDeferred,
 	}
 	if op.Validity == descpb.ConstraintValidity_Unvalidated {
 		// Unvalidated constraint doesn't need to transition through an intermediate
@@ -514,12 +516,15 @@ func (i *immediateVisitor) AddUniqueWithoutIndexConstraint(
 	}
 
 	uwi := &descpb.UniqueWithoutIndexConstraint{
-		TableID:      op.TableID,
-		ColumnIDs:    op.ColumnIDs,
-		Name:         tabledesc.ConstraintNamePlaceholder(op.ConstraintID),
-		Validity:     op.Validity,
-		ConstraintID: op.ConstraintID,
-		Predicate:    string(op.PartialExpr),
+		TableID:            op.TableID,
+		ColumnIDs:          op.ColumnIDs,
+		Name:               tabledesc.ConstraintNamePlaceholder(op.ConstraintID),
+		Validity:           op.Validity,
+		ConstraintID:       op.ConstraintID,
+		Predicate:          string(op.PartialExpr),
+		Deferrable:         op.Deferrable,
+		InitiallyDeferred:  op.InitiallyDeferred,
+		ExclusionOperators: op.ExclusionOperators,
 	}
 	if op.Validity == descpb.ConstraintValidity_Unvalidated {
 		// Unvalidated constraint doesn't need to transition through an intermediate
@@ -0,0 +1,113 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package scmutationexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/errors"
+)
+
+func (i *immediateVisitor) SetDomainTypeNotNull(
+	ctx context.Context, op scop.SetDomainTypeNotNull,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	typ.Domain.NotNull = true
+	return nil
+}
+
+func (i *immediateVisitor) RemoveDomainTypeNotNull(
+	ctx context.Context, op scop.RemoveDomainTypeNotNull,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	typ.Domain.NotNull = false
+	return nil
+}
+
+func (i *immediateVisitor) SetDomainTypeDefault(
+	ctx context.Context, op scop.SetDomainTypeDefault,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	expr := op.Expr
+	typ.Domain.DefaultExpr = &expr
+	return nil
+}
+
+func (i *immediateVisitor) RemoveDomainTypeDefault(
+	ctx context.Context, op scop.RemoveDomainTypeDefault,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	// The default may already have been replaced by a new one in the same
+	// stage, in which case it must be left as is.
+	if typ.Domain.DefaultExpr != nil && *typ.Domain.DefaultExpr == op.Expr {
+		typ.Domain.DefaultExpr = nil
+	}
+	return nil
+}
+
+func (i *immediateVisitor) AddDomainTypeCheck(ctx context.Context, op scop.AddDomainTypeCheck) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil {
+		return err
+	}
+	for _, c := range typ.Domain.Checks {
+		if c.Name == op.Name {
+			return errors.AssertionFailedf(
+				"domain %d already has a check constraint named %q", typ.GetID(), op.Name)
+		}
+	}
+	typ.Domain.Checks = append(typ.Domain.Checks, descpb.TypeDescriptor_Domain_DomainCheck{
+		Name: op.Name,
+		Expr: op.Expr,
+	})
+	return nil
+}
+
+func (i *immediateVisitor) RemoveDomainTypeCheck(
+	ctx context.Context, op scop.RemoveDomainTypeCheck,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ.Dropped() {
+		return err
+	}
+	for idx, c := range typ.Domain.Checks {
+		if c.Name == op.Name {
+			typ.Domain.Checks = append(typ.Domain.Checks[:idx], typ.Domain.Checks[idx+1:]...)
+			return nil
+		}
+	}
+	return errors.AssertionFailedf(
+		"domain %d has no check constraint named %q", typ.GetID(), op.Name)
+}
+
+func (i *immediateVisitor) checkOutDomain(
+	ctx context.Context, id descpb.ID,
+) (*typedesc.Mutable, error) {
+	typ, err := i.checkOutType(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if typ.Kind != descpb.TypeDescriptor_DOMAIN || typ.Domain == nil {
+		return nil, catalog.WrapTypeDescRefErr(id, errors.AssertionFailedf("type is not a domain"))
+	}
+	return typ, nil
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
@@ -48,10 +49,35 @@ func (i *immediateVisitor) MarkDescriptorAsDropped(
 	// After marking a table as dropped we will populate the drop time.
 	if tableDesc, ok := desc.(*tabledesc.Mutable); ok && tableDesc.IsTable() {
 		tableDesc.DropTime = timeutil.Now().UnixNano()
+		return i.removeTableFromPublications(ctx, tableDesc)
 	}
 	return nil
 }
 
+// removeTableFromPublications removes a dropped table from the publications of
+// its database which list it.
+func (i *immediateVisitor) removeTableFromPublications(
+	ctx context.Context, tableDesc *tabledesc.Mutable,
+) error {
+	desc, err := i.getDescriptor(ctx, tableDesc.GetParentID())
+	if err != nil {
+		return err
+	}
+	db, ok := desc.(catalog.DatabaseDescriptor)
+	if !ok {
+		return catalog.WrapDatabaseDescRefErr(desc.GetID(), catalog.NewDescriptorTypeError(desc))
+	}
+	if db.Dropped() || !db.IsTableInPublications(tableDesc.GetID()) {
+		return nil
+	}
+	mut, err := i.checkOutDatabase(ctx, db.GetID())
+	if err != nil {
+		return err
+	}
+	mut.RemoveTableFromPublications(tableDesc.GetID())
+	return nil
+}
+
 func (i *immediateVisitor) DrainDescriptorName(
 	_ context.Context, op scop.DrainDescriptorName,
 ) error {
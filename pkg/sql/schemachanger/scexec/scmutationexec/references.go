@@ -737,6 +737,7 @@ func (i *immediateVisitor) SetObjectParentID(ctx context.Context, op scop.SetObj
 			ReturnType:  t.GetReturnType().Type,
 			ReturnSet:   t.GetReturnType().ReturnSet,
 			IsProcedure: t.IsProcedure(),
+			IsAggregate: t.IsAggregate(),
 		}
 		for pIdx, p := range t.Params {
 			class := funcdesc.ToTreeRoutineParamClass(p.Class)
@@ -392,6 +392,8 @@ type AddForeignKeyConstraint struct {
 	OnDeleteAction          semenumpb.ForeignKeyAction
 	CompositeKeyMatchMethod semenumpb.Match
 	Validity                descpb.ConstraintValidity
+	Deferrable              bool
+	InitiallyDeferred       bool
 }
 
 // MakeValidatedForeignKeyConstraintPublic moves a new, validated foreign key
@@ -431,11 +433,14 @@ type RemoveForeignKeyBackReference struct {
 // unique_without_index constraint to the table.
 type AddUniqueWithoutIndexConstraint struct {
 	immediateMutationOp
-	TableID      descpb.ID
-	ConstraintID descpb.ConstraintID
-	ColumnIDs    []descpb.ColumnID
-	PartialExpr  catpb.Expression
-	Validity     descpb.ConstraintValidity
+	TableID            descpb.ID
+	ConstraintID       descpb.ConstraintID
+	ColumnIDs          []descpb.ColumnID
+	PartialExpr        catpb.Expression
+	Validity           descpb.ConstraintValidity
+	Deferrable         bool
+	InitiallyDeferred  bool
+	ExclusionOperators []string
 }
 
 // MakeValidatedUniqueWithoutIndexConstraintPublic moves a new, validated unique_without_index
@@ -1174,3 +1179,45 @@ type SetTableSchemaLocked struct {
 	TableID descpb.ID
 	Locked  bool
 }
+
+// SetDomainTypeNotNull is used to make a domain reject NULL values.
+type SetDomainTypeNotNull struct {
+	immediateMutationOp
+	TypeID descpb.ID
+}
+
+// RemoveDomainTypeNotNull is used to make a domain accept NULL values.
+type RemoveDomainTypeNotNull struct {
+	immediateMutationOp
+	TypeID descpb.ID
+}
+
+// SetDomainTypeDefault is used to set the default expression of a domain.
+type SetDomainTypeDefault struct {
+	immediateMutationOp
+	TypeID descpb.ID
+	Expr   string
+}
+
+// RemoveDomainTypeDefault is used to remove the default expression of a
+// domain, if it is still Expr.
+type RemoveDomainTypeDefault struct {
+	immediateMutationOp
+	TypeID descpb.ID
+	Expr   string
+}
+
+// AddDomainTypeCheck is used to add a CHECK constraint to a domain.
+type AddDomainTypeCheck struct {
+	immediateMutationOp
+	TypeID descpb.ID
+	Name   string
+	Expr   string
+}
+
+// RemoveDomainTypeCheck is used to remove a CHECK constraint from a domain.
+type RemoveDomainTypeCheck struct {
+	immediateMutationOp
+	TypeID descpb.ID
+	Name   string
+}
@@ -169,6 +169,12 @@ type ImmediateMutationVisitor interface {
 	MarkRecreatedIndexAsInvisible(context.Context, MarkRecreatedIndexAsInvisible) error
 	MarkRecreatedIndexesAsVisible(context.Context, MarkRecreatedIndexesAsVisible) error
 	SetTableSchemaLocked(context.Context, SetTableSchemaLocked) error
+	SetDomainTypeNotNull(context.Context, SetDomainTypeNotNull) error
+	RemoveDomainTypeNotNull(context.Context, RemoveDomainTypeNotNull) error
+	SetDomainTypeDefault(context.Context, SetDomainTypeDefault) error
+	RemoveDomainTypeDefault(context.Context, RemoveDomainTypeDefault) error
+	AddDomainTypeCheck(context.Context, AddDomainTypeCheck) error
+	RemoveDomainTypeCheck(context.Context, RemoveDomainTypeCheck) error
 }
 
 // Visit is part of the ImmediateMutationOp interface.
@@ -930,3 +936,33 @@ func (op MarkRecreatedIndexesAsVisible) Visit(ctx context.Context, v ImmediateMu
 func (op SetTableSchemaLocked) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.SetTableSchemaLocked(ctx, op)
 }
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetDomainTypeNotNull) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetDomainTypeNotNull(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveDomainTypeNotNull) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveDomainTypeNotNull(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetDomainTypeDefault) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetDomainTypeDefault(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveDomainTypeDefault) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveDomainTypeDefault(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddDomainTypeCheck) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddDomainTypeCheck(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveDomainTypeCheck) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveDomainTypeCheck(ctx, op)
+}
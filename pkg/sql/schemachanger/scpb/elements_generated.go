@@ -861,6 +861,154 @@ func (c *ElementCollection[E]) FilterDatabaseZoneConfig() *ElementCollection[*Da
 	return (*ElementCollection[*DatabaseZoneConfig])(ret)
 }
 
+func (e DomainType) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainType) Element() Element {
+	return e.DomainType
+}
+
+// ForEachDomainType iterates over elements of type DomainType.
+// Deprecated
+func ForEachDomainType(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainType),
+) {
+  c.FilterDomainType().ForEach(fn)
+}
+
+// FindDomainType finds the first element of type DomainType.
+// Deprecated
+func FindDomainType(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainType) {
+	if tc := c.FilterDomainType(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainType)
+	}
+	return current, target, element
+}
+
+// DomainTypeElements filters elements of type DomainType.
+func (c *ElementCollection[E]) FilterDomainType() *ElementCollection[*DomainType] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainType)
+		return ok
+	})
+	return (*ElementCollection[*DomainType])(ret)
+}
+
+func (e DomainTypeCheck) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainTypeCheck) Element() Element {
+	return e.DomainTypeCheck
+}
+
+// ForEachDomainTypeCheck iterates over elements of type DomainTypeCheck.
+// Deprecated
+func ForEachDomainTypeCheck(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainTypeCheck),
+) {
+  c.FilterDomainTypeCheck().ForEach(fn)
+}
+
+// FindDomainTypeCheck finds the first element of type DomainTypeCheck.
+// Deprecated
+func FindDomainTypeCheck(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainTypeCheck) {
+	if tc := c.FilterDomainTypeCheck(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainTypeCheck)
+	}
+	return current, target, element
+}
+
+// DomainTypeCheckElements filters elements of type DomainTypeCheck.
+func (c *ElementCollection[E]) FilterDomainTypeCheck() *ElementCollection[*DomainTypeCheck] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainTypeCheck)
+		return ok
+	})
+	return (*ElementCollection[*DomainTypeCheck])(ret)
+}
+
+func (e DomainTypeDefault) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainTypeDefault) Element() Element {
+	return e.DomainTypeDefault
+}
+
+// ForEachDomainTypeDefault iterates over elements of type DomainTypeDefault.
+// Deprecated
+func ForEachDomainTypeDefault(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainTypeDefault),
+) {
+  c.FilterDomainTypeDefault().ForEach(fn)
+}
+
+// FindDomainTypeDefault finds the first element of type DomainTypeDefault.
+// Deprecated
+func FindDomainTypeDefault(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainTypeDefault) {
+	if tc := c.FilterDomainTypeDefault(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainTypeDefault)
+	}
+	return current, target, element
+}
+
+// DomainTypeDefaultElements filters elements of type DomainTypeDefault.
+func (c *ElementCollection[E]) FilterDomainTypeDefault() *ElementCollection[*DomainTypeDefault] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainTypeDefault)
+		return ok
+	})
+	return (*ElementCollection[*DomainTypeDefault])(ret)
+}
+
+func (e DomainTypeNotNull) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainTypeNotNull) Element() Element {
+	return e.DomainTypeNotNull
+}
+
+// ForEachDomainTypeNotNull iterates over elements of type DomainTypeNotNull.
+// Deprecated
+func ForEachDomainTypeNotNull(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainTypeNotNull),
+) {
+  c.FilterDomainTypeNotNull().ForEach(fn)
+}
+
+// FindDomainTypeNotNull finds the first element of type DomainTypeNotNull.
+// Deprecated
+func FindDomainTypeNotNull(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainTypeNotNull) {
+	if tc := c.FilterDomainTypeNotNull(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainTypeNotNull)
+	}
+	return current, target, element
+}
+
+// DomainTypeNotNullElements filters elements of type DomainTypeNotNull.
+func (c *ElementCollection[E]) FilterDomainTypeNotNull() *ElementCollection[*DomainTypeNotNull] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainTypeNotNull)
+		return ok
+	})
+	return (*ElementCollection[*DomainTypeNotNull])(ret)
+}
+
 func (e EnumType) element() {}
 
 // Element implements ElementGetter.
@@ -3354,6 +3502,14 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_DatabaseRoleSetting{ DatabaseRoleSetting: t}
 		case *DatabaseZoneConfig:
 			e.ElementOneOf = &ElementProto_DatabaseZoneConfig{ DatabaseZoneConfig: t}
+		case *DomainType:
+			e.ElementOneOf = &ElementProto_DomainType{ DomainType: t}
+		case *DomainTypeCheck:
+			e.ElementOneOf = &ElementProto_DomainTypeCheck{ DomainTypeCheck: t}
+		case *DomainTypeDefault:
+			e.ElementOneOf = &ElementProto_DomainTypeDefault{ DomainTypeDefault: t}
+		case *DomainTypeNotNull:
+			e.ElementOneOf = &ElementProto_DomainTypeNotNull{ DomainTypeNotNull: t}
 		case *EnumType:
 			e.ElementOneOf = &ElementProto_EnumType{ EnumType: t}
 		case *EnumTypeValue:
@@ -3515,6 +3671,10 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_DatabaseRegionConfig)(nil)),
 	((*ElementProto_DatabaseRoleSetting)(nil)),
 	((*ElementProto_DatabaseZoneConfig)(nil)),
+	((*ElementProto_DomainType)(nil)),
+	((*ElementProto_DomainTypeCheck)(nil)),
+	((*ElementProto_DomainTypeDefault)(nil)),
+	((*ElementProto_DomainTypeNotNull)(nil)),
 	((*ElementProto_EnumType)(nil)),
 	((*ElementProto_EnumTypeValue)(nil)),
 	((*ElementProto_ForeignKeyConstraint)(nil)),
@@ -3611,6 +3771,10 @@ func GetElementTypes() []interface{} {
 	((*DatabaseRegionConfig)(nil)),
 	((*DatabaseRoleSetting)(nil)),
 	((*DatabaseZoneConfig)(nil)),
+	((*DomainType)(nil)),
+	((*DomainTypeCheck)(nil)),
+	((*DomainTypeDefault)(nil)),
+	((*DomainTypeNotNull)(nil)),
 	((*EnumType)(nil)),
 	((*EnumTypeValue)(nil)),
 	((*ForeignKeyConstraint)(nil)),
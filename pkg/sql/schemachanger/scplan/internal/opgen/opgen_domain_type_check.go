@@ -0,0 +1,39 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.DomainTypeCheck)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.DomainTypeCheck) *scop.AddDomainTypeCheck {
+					return &scop.AddDomainTypeCheck{
+						TypeID: this.TypeID,
+						Name:   this.Name,
+						Expr:   string(this.Expr),
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				emit(func(this *scpb.DomainTypeCheck) *scop.RemoveDomainTypeCheck {
+					return &scop.RemoveDomainTypeCheck{
+						TypeID: this.TypeID,
+						Name:   this.Name,
+					}
+				}),
+			),
+		),
+	)
+}
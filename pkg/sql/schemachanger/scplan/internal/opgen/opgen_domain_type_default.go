@@ -0,0 +1,38 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.DomainTypeDefault)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.DomainTypeDefault) *scop.SetDomainTypeDefault {
+					return &scop.SetDomainTypeDefault{
+						TypeID: this.TypeID,
+						Expr:   string(this.Expr),
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				emit(func(this *scpb.DomainTypeDefault) *scop.RemoveDomainTypeDefault {
+					return &scop.RemoveDomainTypeDefault{
+						TypeID: this.TypeID,
+						Expr:   string(this.Expr),
+					}
+				}),
+			),
+		),
+	)
+}
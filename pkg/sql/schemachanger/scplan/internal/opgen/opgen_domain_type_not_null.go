@@ -0,0 +1,36 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.DomainTypeNotNull)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.DomainTypeNotNull) *scop.SetDomainTypeNotNull {
+					return &scop.SetDomainTypeNotNull{
+						TypeID: this.TypeID,
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				emit(func(this *scpb.DomainTypeNotNull) *scop.RemoveDomainTypeNotNull {
+					return &scop.RemoveDomainTypeNotNull{
+						TypeID: this.TypeID,
+					}
+				}),
+			),
+		),
+	)
+}
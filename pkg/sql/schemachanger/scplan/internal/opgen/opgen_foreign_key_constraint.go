@@ -27,6 +27,8 @@ func init() {
 						OnDeleteAction:          this.OnDeleteAction,
 						CompositeKeyMatchMethod: this.CompositeKeyMatchMethod,
 						Validity:                descpb.ConstraintValidity_Validating,
+						Deferrable:              this.Deferrable,
+						InitiallyDeferred:       this.InitiallyDeferred,
 					}
 				}),
 			),
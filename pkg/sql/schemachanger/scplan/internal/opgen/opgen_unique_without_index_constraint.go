@@ -23,11 +23,14 @@ func init() {
 						partialExpr = this.Predicate.Expr
 					}
 					return &scop.AddUniqueWithoutIndexConstraint{
-						TableID:      this.TableID,
-						ConstraintID: this.ConstraintID,
-						ColumnIDs:    this.ColumnIDs,
-						PartialExpr:  partialExpr,
-						Validity:     descpb.ConstraintValidity_Validating,
+						TableID:            this.TableID,
+						ConstraintID:       this.ConstraintID,
+						ColumnIDs:          this.ColumnIDs,
+						PartialExpr:        partialExpr,
+						Validity:           descpb.ConstraintValidity_Validating,
+						Deferrable:         this.Deferrable,
+						InitiallyDeferred:  this.InitiallyDeferred,
+						ExclusionOperators: this.ExclusionOperators,
 					}
 				}),
 				emit(func(this *scpb.UniqueWithoutIndexConstraint) *scop.UpdateTableBackReferencesInTypes {
@@ -68,7 +68,7 @@ var descriptorDataIsNotBeingAdded = screl.Schema.DefNotJoin1(
 func isDescriptor(e scpb.Element) bool {
 	switch e.(type) {
 	case *scpb.Database, *scpb.Schema, *scpb.Table, *scpb.View, *scpb.Sequence,
-		*scpb.AliasType, *scpb.EnumType, *scpb.CompositeType, *scpb.Function:
+		*scpb.AliasType, *scpb.EnumType, *scpb.CompositeType, *scpb.DomainType, *scpb.Function:
 		return true
 	}
 	return false
@@ -209,7 +209,7 @@ func isWithExpression(element scpb.Element) bool {
 
 func isTypeDescriptor(element scpb.Element) bool {
 	switch element.(type) {
-	case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType:
+	case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType, *scpb.DomainType:
 		return true
 	default:
 		return false
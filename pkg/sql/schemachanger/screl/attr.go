@@ -162,6 +162,21 @@ var elementSchemaOptions = []rel.SchemaOption{
 		rel.EntityAttr(DescID, "CompositeTypeID"),
 		rel.EntityAttr(ReferencedTypeIDs, "ClosedTypeIDs"),
 	),
+	rel.EntityMapping(t((*scpb.DomainType)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+	),
+	rel.EntityMapping(t((*scpb.DomainTypeNotNull)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+	),
+	rel.EntityMapping(t((*scpb.DomainTypeDefault)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+		rel.EntityAttr(Expr, "Expr"),
+	),
+	rel.EntityMapping(t((*scpb.DomainTypeCheck)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+		rel.EntityAttr(Name, "Name"),
+		rel.EntityAttr(Expr, "Expr"),
+	),
 	rel.EntityMapping(t((*scpb.View)(nil)),
 		rel.EntityAttr(DescID, "ViewID"),
 	),
@@ -306,14 +306,16 @@ var regularBuiltins = map[string]builtinDefinition{
 	// TODO(pmattis): What string functions should also support types.Bytes?
 
 	"lower": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
-			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
-				return tree.NewDString(strings.ToLower(s)), nil
-			},
-			types.String,
-			"Converts all characters in `val` to their lower-case equivalents.",
-			volatility.Immutable,
-		),
+		append([]tree.Overload{
+			preferredOverload(stringOverload1(
+				func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+					return tree.NewDString(strings.ToLower(s)), nil
+				},
+				types.String,
+				"Converts all characters in `val` to their lower-case equivalents.",
+				volatility.Immutable,
+			)),
+		}, rangeBoundOverloads(true /* lower */)...)...,
 	),
 
 	"unaccent": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -337,14 +339,16 @@ var regularBuiltins = map[string]builtinDefinition{
 	),
 
 	"upper": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
-			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
-				return tree.NewDString(strings.ToUpper(s)), nil
-			},
-			types.String,
-			"Converts all characters in `val` to their to their upper-case equivalents.",
-			volatility.Immutable,
-		),
+		append([]tree.Overload{
+			preferredOverload(stringOverload1(
+				func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+					return tree.NewDString(strings.ToUpper(s)), nil
+				},
+				types.String,
+				"Converts all characters in `val` to their to their upper-case equivalents.",
+				volatility.Immutable,
+			)),
+		}, rangeBoundOverloads(false /* lower */)...)...,
 	),
 
 	"prettify_statement": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -6695,6 +6699,40 @@ SELECT
 		},
 	),
 
+	"crdb_internal.domain_check": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategorySystemInfo,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "val", Typ: types.AnyElement},
+				{Name: "ok", Typ: types.Bool},
+				{Name: "domain", Typ: types.String},
+				{Name: "constraint", Typ: types.String},
+			},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[1] == tree.DBoolTrue {
+					return args[0], nil
+				}
+				domain := tree.MustBeDString(args[2])
+				constraint := tree.MustBeDString(args[3])
+				if constraint == "" {
+					return nil, pgerror.Newf(pgcode.NotNullViolation,
+						"domain %s does not allow null values", domain)
+				}
+				return nil, pgerror.Newf(pgcode.CheckViolation,
+					"value for domain %s violates check constraint %q", domain, constraint)
+			},
+			Info: "This function is used internally to check the constraints of a domain. " +
+				"It returns val if ok is true, and otherwise raises an error for the " +
+				"violated constraint, which is the NOT NULL constraint if constraint is empty.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	),
+
 	"crdb_internal.round_decimal_values": makeBuiltin(
 		tree.FunctionProperties{
 			Category: builtinconstants.CategorySystemInfo,
@@ -8951,6 +8989,141 @@ specified store on the node it's run from. One of 'mvccGC', 'merge', 'split',
 			CalledOnNullInput: true,
 		},
 	),
+	"crdb_internal.plpgsql_fetch_record": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "name", Typ: types.RefCursor},
+				{Name: "direction", Typ: types.Int},
+				{Name: "count", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.AnyTuple),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				for i := range args {
+					if args[i] == tree.DNull {
+						return nil, pgerror.New(
+							pgcode.NullValueNotAllowed, "FETCH statement option cannot be null",
+						)
+					}
+				}
+				cursorDir := tree.MustBeDInt(args[1])
+				if cursorDir < 0 || cursorDir > tree.DInt(tree.FetchBackwardAll) {
+					return nil, pgerror.Newf(pgcode.InvalidParameterValue, "invalid fetch/move direction: %d", cursorDir)
+				}
+				return evalCtx.Planner.PLpgSQLFetchCursorRecord(ctx, &tree.CursorStmt{
+					Name:      tree.Name(tree.MustBeDString(args[0])),
+					FetchType: tree.FetchType(cursorDir),
+					Count:     int64(tree.MustBeDInt(args[2])),
+				})
+			},
+			Info: "This function is used internally to fetch rows into PLpgSQL RECORD variables " +
+				"whose structure is only known at execution time.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+	"crdb_internal.plpgsql_open_query": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "name", Typ: types.RefCursor},
+				{Name: "query", Typ: types.String},
+				{Name: "params", Typ: types.AnyTuple},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return nil, errors.AssertionFailedf("expected non-null cursor name")
+				}
+				if args[1] == tree.DNull {
+					return nil, pgerror.New(
+						pgcode.NullValueNotAllowed, "query string argument of EXECUTE is null",
+					)
+				}
+				return tree.DNull, evalCtx.Planner.PLpgSQLOpenQueryCursor(
+					ctx,
+					tree.Name(tree.MustBeDString(args[0])),
+					string(tree.MustBeDString(args[1])),
+					tree.MustBeDTuple(args[2]).D,
+				)
+			},
+			Info:              "This function is used internally to implement PLpgSQL FOR loops over dynamic queries.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+	"crdb_internal.plpgsql_record_field": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "record", Typ: types.AnyTuple},
+				{Name: "record_name", Typ: types.String},
+				{Name: "field", Typ: types.String},
+				{Name: "type", Typ: types.AnyElement},
+			},
+			ReturnType:  tree.IdentityReturnType(3),
+			FnWithExprs: eval.FnWithExprsOverload(plpgsqlRecordField),
+			Info: "This function is used internally to access a field of a PLpgSQL RECORD " +
+				"variable by name.",
+			// The field is cast to the type of the last argument, so we set the
+			// volatility to the maximum volatility of all casts.
+			Volatility: volatility.Stable,
+			// The idiomatic usage of this function is to "pass" the type of the
+			// field by passing NULL::T, and the record may not be assigned yet, so
+			// we must allow NULL arguments.
+			CalledOnNullInput: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "record", Typ: types.AnyTuple},
+				{Name: "record_name", Typ: types.String},
+				{Name: "field", Typ: types.Int},
+				{Name: "type", Typ: types.AnyElement},
+			},
+			ReturnType:  tree.IdentityReturnType(3),
+			FnWithExprs: eval.FnWithExprsOverload(plpgsqlRecordField),
+			Info: "This function is used internally to access a field of a PLpgSQL RECORD " +
+				"variable by its zero-based position.",
+			Volatility:        volatility.Stable,
+			CalledOnNullInput: true,
+		},
+	),
+	"crdb_internal.plpgsql_record_set_field": makeBuiltin(tree.FunctionProperties{
+		Category:     builtinconstants.CategoryString,
+		Undocumented: true,
+	},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "record", Typ: types.AnyTuple},
+				{Name: "record_name", Typ: types.String},
+				{Name: "field", Typ: types.String},
+				{Name: "value", Typ: types.AnyElement},
+			},
+			ReturnType: tree.FixedReturnType(types.AnyTuple),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				tup, idx, err := resolvePLpgSQLRecordField(args[0], args[1], args[2])
+				if err != nil {
+					return nil, err
+				}
+				val, err := eval.PerformCast(ctx, evalCtx, args[3], tup.ResolvedType().TupleContents()[idx])
+				if err != nil {
+					return nil, err
+				}
+				newElems := append(tree.Datums(nil), tup.D...)
+				newElems[idx] = val
+				return tree.NewDTuple(tup.ResolvedType(), newElems...), nil
+			},
+			Info:              "This function is used internally to assign to a field of a PLpgSQL RECORD variable.",
+			Volatility:        volatility.Stable,
+			CalledOnNullInput: true,
+		},
+	),
 	"crdb_internal.protect_mvcc_history": makeBuiltin(
 		tree.FunctionProperties{
 			Category:     builtinconstants.CategoryClusterReplication,
@@ -10418,6 +10591,14 @@ func jsonOverload1(
 	}
 }
 
+// preferredOverload marks the given overload as preferred, so that it is
+// chosen when the types of the arguments are ambiguous, such as for untyped
+// placeholders.
+func preferredOverload(o tree.Overload) tree.Overload {
+	o.OverloadPreference = tree.OverloadPreferencePreferred
+	return o
+}
+
 func stringOverload1(
 	f func(context.Context, *eval.Context, string) (tree.Datum, error),
 	returnType *types.T,
@@ -12201,3 +12382,64 @@ func makeJsonpathExists(_ context.Context, _ *eval.Context, args tree.Datums) (t
 	}
 	return tree.MakeDBool(exists), nil
 }
+
+// plpgsqlRecordField implements the crdb_internal.plpgsql_record_field builtin.
+// The field is cast to the type of the last argument, which is the type that
+// was expected for the field when the routine was built. A positional field
+// that is out of range is NULL, like a FETCH target without a corresponding
+// column.
+func plpgsqlRecordField(
+	ctx context.Context, evalCtx *eval.Context, args tree.Exprs,
+) (tree.Datum, error) {
+	var datums [3]tree.Datum
+	for i := range datums {
+		d, err := eval.Expr(ctx, evalCtx, args[i].(tree.TypedExpr))
+		if err != nil {
+			return nil, err
+		}
+		datums[i] = d
+	}
+	tup, idx, err := resolvePLpgSQLRecordField(datums[0], datums[1], datums[2])
+	if err != nil {
+		return nil, err
+	}
+	if idx < 0 {
+		return tree.DNull, nil
+	}
+	return eval.PerformCast(ctx, evalCtx, tup.D[idx], args[3].(tree.TypedExpr).ResolvedType())
+}
+
+// resolvePLpgSQLRecordField returns the value of a PLpgSQL RECORD variable and
+// the index of the field with the given name or zero-based position, or -1 if
+// the position is out of range. The structure of a RECORD variable is
+// determined by the row that was last assigned to it, so its fields can only be
+// resolved at execution time.
+func resolvePLpgSQLRecordField(
+	record, recordName, field tree.Datum,
+) (_ *tree.DTuple, idx int, _ error) {
+	name := tree.MustBeDString(recordName)
+	if record == tree.DNull {
+		return nil, 0, errors.WithDetail(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "record \"%s\" is not assigned yet", name),
+			"The tuple structure of a not-yet-assigned record is indeterminate.",
+		)
+	}
+	tup := tree.MustBeDTuple(record)
+	switch t := field.(type) {
+	case *tree.DString:
+		for i, label := range tup.ResolvedType().TupleLabels() {
+			if label == string(*t) {
+				return tup, i, nil
+			}
+		}
+		return nil, 0, pgerror.Newf(pgcode.UndefinedColumn,
+			"record \"%s\" has no field \"%s\"", name, string(*t),
+		)
+	case *tree.DInt:
+		if *t < 0 || int(*t) >= len(tup.D) {
+			return tup, -1, nil
+		}
+		return tup, int(*t), nil
+	}
+	return nil, 0, errors.AssertionFailedf("unexpected record field %s", field)
+}
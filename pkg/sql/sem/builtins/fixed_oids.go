@@ -2653,6 +2653,184 @@ var builtinOidsArray = []string{
 	2690: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
 	2691: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
 	2692: `st_3dlength(geometry: geometry) -> float`,
+	2693: `crdb_internal.domain_check(val: anyelement, ok: bool, domain: string, constraint: string) -> anyelement`,
+	2694: `pg_notify(channel: string, payload: string) -> void`,
+	2695: `pg_listening_channels() -> string`,
+	2696: `int4rangesend(int4range: int4range) -> bytes`,
+	2697: `int4rangerecv(input: anyelement) -> int4range`,
+	2698: `int4rangeout(int4range: int4range) -> bytes`,
+	2699: `int4rangein(input: anyelement) -> int4range`,
+	2700: `int4multirangesend(int4multirange: int4multirange) -> bytes`,
+	2701: `int4multirangerecv(input: anyelement) -> int4multirange`,
+	2702: `int4multirangeout(int4multirange: int4multirange) -> bytes`,
+	2703: `int4multirangein(input: anyelement) -> int4multirange`,
+	2704: `int8rangesend(int8range: int8range) -> bytes`,
+	2705: `int8rangerecv(input: anyelement) -> int8range`,
+	2706: `int8rangeout(int8range: int8range) -> bytes`,
+	2707: `int8rangein(input: anyelement) -> int8range`,
+	2708: `int8multirangesend(int8multirange: int8multirange) -> bytes`,
+	2709: `int8multirangerecv(input: anyelement) -> int8multirange`,
+	2710: `int8multirangeout(int8multirange: int8multirange) -> bytes`,
+	2711: `int8multirangein(input: anyelement) -> int8multirange`,
+	2712: `numrangesend(numrange: numrange) -> bytes`,
+	2713: `numrangerecv(input: anyelement) -> numrange`,
+	2714: `numrangeout(numrange: numrange) -> bytes`,
+	2715: `numrangein(input: anyelement) -> numrange`,
+	2716: `nummultirangesend(nummultirange: nummultirange) -> bytes`,
+	2717: `nummultirangerecv(input: anyelement) -> nummultirange`,
+	2718: `nummultirangeout(nummultirange: nummultirange) -> bytes`,
+	2719: `nummultirangein(input: anyelement) -> nummultirange`,
+	2720: `tsrangesend(tsrange: tsrange) -> bytes`,
+	2721: `tsrangerecv(input: anyelement) -> tsrange`,
+	2722: `tsrangeout(tsrange: tsrange) -> bytes`,
+	2723: `tsrangein(input: anyelement) -> tsrange`,
+	2724: `tsmultirangesend(tsmultirange: tsmultirange) -> bytes`,
+	2725: `tsmultirangerecv(input: anyelement) -> tsmultirange`,
+	2726: `tsmultirangeout(tsmultirange: tsmultirange) -> bytes`,
+	2727: `tsmultirangein(input: anyelement) -> tsmultirange`,
+	2728: `tstzrangesend(tstzrange: tstzrange) -> bytes`,
+	2729: `tstzrangerecv(input: anyelement) -> tstzrange`,
+	2730: `tstzrangeout(tstzrange: tstzrange) -> bytes`,
+	2731: `tstzrangein(input: anyelement) -> tstzrange`,
+	2732: `tstzmultirangesend(tstzmultirange: tstzmultirange) -> bytes`,
+	2733: `tstzmultirangerecv(input: anyelement) -> tstzmultirange`,
+	2734: `tstzmultirangeout(tstzmultirange: tstzmultirange) -> bytes`,
+	2735: `tstzmultirangein(input: anyelement) -> tstzmultirange`,
+	2736: `daterangesend(daterange: daterange) -> bytes`,
+	2737: `daterangerecv(input: anyelement) -> daterange`,
+	2738: `daterangeout(daterange: daterange) -> bytes`,
+	2739: `daterangein(input: anyelement) -> daterange`,
+	2740: `datemultirangesend(datemultirange: datemultirange) -> bytes`,
+	2741: `datemultirangerecv(input: anyelement) -> datemultirange`,
+	2742: `datemultirangeout(datemultirange: datemultirange) -> bytes`,
+	2743: `datemultirangein(input: anyelement) -> datemultirange`,
+	2744: `int4range(lower: int4, upper: int4) -> int4range`,
+	2745: `int4range(lower: int4, upper: int4, bounds: string) -> int4range`,
+	2746: `int4multirange(int4range...) -> int4multirange`,
+	2747: `int8range(lower: int, upper: int) -> int8range`,
+	2748: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2749: `int8multirange(int8range...) -> int8multirange`,
+	2750: `numrange(lower: decimal, upper: decimal) -> numrange`,
+	2751: `numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`,
+	2752: `nummultirange(numrange...) -> nummultirange`,
+	2753: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2754: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2755: `tsmultirange(tsrange...) -> tsmultirange`,
+	2756: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2757: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2758: `tstzmultirange(tstzrange...) -> tstzmultirange`,
+	2759: `daterange(lower: date, upper: date) -> daterange`,
+	2760: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2761: `datemultirange(daterange...) -> datemultirange`,
+	2762: `lower(val: int4range) -> int4`,
+	2763: `lower(val: int4multirange) -> int4`,
+	2764: `upper(val: int4range) -> int4`,
+	2765: `upper(val: int4multirange) -> int4`,
+	2766: `lower(val: int8range) -> int`,
+	2767: `lower(val: int8multirange) -> int`,
+	2768: `upper(val: int8range) -> int`,
+	2769: `upper(val: int8multirange) -> int`,
+	2770: `lower(val: numrange) -> decimal`,
+	2771: `lower(val: nummultirange) -> decimal`,
+	2772: `upper(val: numrange) -> decimal`,
+	2773: `upper(val: nummultirange) -> decimal`,
+	2774: `lower(val: tsrange) -> timestamp`,
+	2775: `lower(val: tsmultirange) -> timestamp`,
+	2776: `upper(val: tsrange) -> timestamp`,
+	2777: `upper(val: tsmultirange) -> timestamp`,
+	2778: `lower(val: tstzrange) -> timestamptz`,
+	2779: `lower(val: tstzmultirange) -> timestamptz`,
+	2780: `upper(val: tstzrange) -> timestamptz`,
+	2781: `upper(val: tstzmultirange) -> timestamptz`,
+	2782: `lower(val: daterange) -> date`,
+	2783: `lower(val: datemultirange) -> date`,
+	2784: `upper(val: daterange) -> date`,
+	2785: `upper(val: datemultirange) -> date`,
+	2786: `isempty(val: int4range) -> bool`,
+	2787: `isempty(val: int8range) -> bool`,
+	2788: `isempty(val: numrange) -> bool`,
+	2789: `isempty(val: tsrange) -> bool`,
+	2790: `isempty(val: tstzrange) -> bool`,
+	2791: `isempty(val: daterange) -> bool`,
+	2792: `isempty(val: int4multirange) -> bool`,
+	2793: `isempty(val: int8multirange) -> bool`,
+	2794: `isempty(val: nummultirange) -> bool`,
+	2795: `isempty(val: tsmultirange) -> bool`,
+	2796: `isempty(val: tstzmultirange) -> bool`,
+	2797: `isempty(val: datemultirange) -> bool`,
+	2798: `lower_inc(val: int4range) -> bool`,
+	2799: `lower_inc(val: int8range) -> bool`,
+	2800: `lower_inc(val: numrange) -> bool`,
+	2801: `lower_inc(val: tsrange) -> bool`,
+	2802: `lower_inc(val: tstzrange) -> bool`,
+	2803: `lower_inc(val: daterange) -> bool`,
+	2804: `lower_inc(val: int4multirange) -> bool`,
+	2805: `lower_inc(val: int8multirange) -> bool`,
+	2806: `lower_inc(val: nummultirange) -> bool`,
+	2807: `lower_inc(val: tsmultirange) -> bool`,
+	2808: `lower_inc(val: tstzmultirange) -> bool`,
+	2809: `lower_inc(val: datemultirange) -> bool`,
+	2810: `upper_inc(val: int4range) -> bool`,
+	2811: `upper_inc(val: int8range) -> bool`,
+	2812: `upper_inc(val: numrange) -> bool`,
+	2813: `upper_inc(val: tsrange) -> bool`,
+	2814: `upper_inc(val: tstzrange) -> bool`,
+	2815: `upper_inc(val: daterange) -> bool`,
+	2816: `upper_inc(val: int4multirange) -> bool`,
+	2817: `upper_inc(val: int8multirange) -> bool`,
+	2818: `upper_inc(val: nummultirange) -> bool`,
+	2819: `upper_inc(val: tsmultirange) -> bool`,
+	2820: `upper_inc(val: tstzmultirange) -> bool`,
+	2821: `upper_inc(val: datemultirange) -> bool`,
+	2822: `lower_inf(val: int4range) -> bool`,
+	2823: `lower_inf(val: int8range) -> bool`,
+	2824: `lower_inf(val: numrange) -> bool`,
+	2825: `lower_inf(val: tsrange) -> bool`,
+	2826: `lower_inf(val: tstzrange) -> bool`,
+	2827: `lower_inf(val: daterange) -> bool`,
+	2828: `lower_inf(val: int4multirange) -> bool`,
+	2829: `lower_inf(val: int8multirange) -> bool`,
+	2830: `lower_inf(val: nummultirange) -> bool`,
+	2831: `lower_inf(val: tsmultirange) -> bool`,
+	2832: `lower_inf(val: tstzmultirange) -> bool`,
+	2833: `lower_inf(val: datemultirange) -> bool`,
+	2834: `upper_inf(val: int4range) -> bool`,
+	2835: `upper_inf(val: int8range) -> bool`,
+	2836: `upper_inf(val: numrange) -> bool`,
+	2837: `upper_inf(val: tsrange) -> bool`,
+	2838: `upper_inf(val: tstzrange) -> bool`,
+	2839: `upper_inf(val: daterange) -> bool`,
+	2840: `upper_inf(val: int4multirange) -> bool`,
+	2841: `upper_inf(val: int8multirange) -> bool`,
+	2842: `upper_inf(val: nummultirange) -> bool`,
+	2843: `upper_inf(val: tsmultirange) -> bool`,
+	2844: `upper_inf(val: tstzmultirange) -> bool`,
+	2845: `upper_inf(val: datemultirange) -> bool`,
+	2846: `range_merge(a: int4range, b: int4range) -> int4range`,
+	2847: `range_merge(a: int8range, b: int8range) -> int8range`,
+	2848: `range_merge(a: numrange, b: numrange) -> numrange`,
+	2849: `range_merge(a: tsrange, b: tsrange) -> tsrange`,
+	2850: `range_merge(a: tstzrange, b: tstzrange) -> tstzrange`,
+	2851: `range_merge(a: daterange, b: daterange) -> daterange`,
+	2852: `range_merge(val: int4multirange) -> int4range`,
+	2853: `range_merge(val: int8multirange) -> int8range`,
+	2854: `range_merge(val: nummultirange) -> numrange`,
+	2855: `range_merge(val: tsmultirange) -> tsrange`,
+	2856: `range_merge(val: tstzmultirange) -> tstzrange`,
+	2857: `range_merge(val: datemultirange) -> daterange`,
+	2858: `multirange(val: int4range) -> int4multirange`,
+	2859: `multirange(val: int8range) -> int8multirange`,
+	2860: `multirange(val: numrange) -> nummultirange`,
+	2861: `multirange(val: tsrange) -> tsmultirange`,
+	2862: `multirange(val: tstzrange) -> tstzmultirange`,
+	2863: `multirange(val: daterange) -> datemultirange`,
+	2864: `pg_event_trigger_ddl_commands() -> tuple{oid AS classid, oid AS objid, int AS objsubid, string AS command_tag, string AS object_type, string AS schema_name, string AS object_identity, bool AS in_extension}`,
+	2865: `pg_event_trigger_dropped_objects() -> tuple{oid AS classid, oid AS objid, int AS objsubid, bool AS original, bool AS normal, bool AS is_temporary, string AS object_type, string AS schema_name, string AS object_name, string AS object_identity, string[] AS address_names, string[] AS address_args}`,
+	2866: `crdb_internal.plpgsql_fetch_record(name: refcursor, direction: int, count: int) -> tuple`,
+	2867: `crdb_internal.plpgsql_open_query(name: refcursor, query: string, params: tuple) -> int`,
+	2868: `crdb_internal.plpgsql_record_field(record: tuple, record_name: string, field: string, type: anyelement) -> anyelement`,
+	2869: `crdb_internal.plpgsql_record_field(record: tuple, record_name: string, field: int, type: anyelement) -> anyelement`,
+	2870: `crdb_internal.plpgsql_record_set_field(record: tuple, record_name: string, field: string, value: anyelement) -> tuple`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
@@ -256,6 +256,47 @@ var generators = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 	),
+	"pg_event_trigger_ddl_commands": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategoryGenerator,
+			DistsqlBlocklist: true,
+		},
+		makeGeneratorOverload(
+			tree.ParamTypes{},
+			eventTriggerDDLCommandsGeneratorType,
+			makeEventTriggerDDLCommandsGenerator,
+			"Produces the objects created or altered by the DDL command that fired "+
+				"the current event trigger. Can only be called in an event trigger function.",
+			volatility.Volatile,
+		),
+	),
+	"pg_event_trigger_dropped_objects": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategoryGenerator,
+			DistsqlBlocklist: true,
+		},
+		makeGeneratorOverload(
+			tree.ParamTypes{},
+			eventTriggerDroppedObjectsGeneratorType,
+			makeEventTriggerDroppedObjectsGenerator,
+			"Produces the objects dropped by the DDL command that fired the current "+
+				"event trigger. Can only be called in an event trigger function.",
+			volatility.Volatile,
+		),
+	),
+	"pg_listening_channels": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategoryGenerator,
+			DistsqlBlocklist: true,
+		},
+		makeGeneratorOverload(
+			tree.ParamTypes{},
+			types.String,
+			makeListeningChannelsGenerator,
+			"Produces the names of the notification channels the current session listens on.",
+			volatility.Stable,
+		),
+	),
 	`pg_options_to_table`: makeBuiltin(
 		genProps(),
 		makeGeneratorOverload(
@@ -1274,6 +1315,92 @@ func makeWorkloadIndexRecsGeneratorFactory(hasTimestamp bool) eval.GeneratorOver
 	}
 }
 
+func makeListeningChannelsGenerator(
+	_ context.Context, evalCtx *eval.Context, _ tree.Datums,
+) (eval.ValueGenerator, error) {
+	arr := tree.NewDArray(types.String)
+	for _, channel := range evalCtx.Planner.ListeningChannels() {
+		if err := arr.Append(tree.NewDString(channel)); err != nil {
+			return nil, err
+		}
+	}
+	return &arrayValueGenerator{array: arr}, nil
+}
+
+var eventTriggerDDLCommandsGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{
+		types.Oid, types.Oid, types.Int, types.String, types.String, types.String,
+		types.String, types.Bool,
+	},
+	[]string{
+		"classid", "objid", "objsubid", "command_tag", "object_type", "schema_name",
+		"object_identity", "in_extension",
+	},
+)
+
+var eventTriggerDroppedObjectsGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{
+		types.Oid, types.Oid, types.Int, types.Bool, types.Bool, types.Bool,
+		types.String, types.String, types.String, types.String, types.StringArray,
+		types.StringArray,
+	},
+	[]string{
+		"classid", "objid", "objsubid", "original", "normal", "is_temporary",
+		"object_type", "schema_name", "object_name", "object_identity",
+		"address_names", "address_args",
+	},
+)
+
+func makeEventTriggerDDLCommandsGenerator(
+	ctx context.Context, evalCtx *eval.Context, _ tree.Datums,
+) (eval.ValueGenerator, error) {
+	rows, err := evalCtx.Planner.EventTriggerDDLCommands(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &rowsValueGenerator{typ: eventTriggerDDLCommandsGeneratorType, rows: rows}, nil
+}
+
+func makeEventTriggerDroppedObjectsGenerator(
+	ctx context.Context, evalCtx *eval.Context, _ tree.Datums,
+) (eval.ValueGenerator, error) {
+	rows, err := evalCtx.Planner.EventTriggerDroppedObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &rowsValueGenerator{typ: eventTriggerDroppedObjectsGeneratorType, rows: rows}, nil
+}
+
+// rowsValueGenerator produces a precomputed list of rows.
+type rowsValueGenerator struct {
+	typ    *types.T
+	rows   []tree.Datums
+	curRow int
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *rowsValueGenerator) ResolvedType() *types.T { return g.typ }
+
+// Close implements the eval.ValueGenerator interface.
+func (*rowsValueGenerator) Close(_ context.Context) {}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *rowsValueGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	g.curRow = -1
+	return nil
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *rowsValueGenerator) Next(_ context.Context) (bool, error) {
+	g.curRow++
+	return g.curRow < len(g.rows), nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *rowsValueGenerator) Values() (tree.Datums, error) {
+	return g.rows[g.curRow], nil
+}
+
 func makeArrayGenerator(
 	_ context.Context, _ *eval.Context, args tree.Datums,
 ) (eval.ValueGenerator, error) {
@@ -113,9 +113,9 @@ func init() {
 			// Postgres doesn't have any_send or any_recv. It does have any_in and any_out,
 			// but they always error, so let's just skip these builtins altogether.
 			continue
-		case oid.T_trigger:
-			// TRIGGER is not valid in any context apart from the return-type of a
-			// trigger function.
+		case oid.T_trigger, oid.T_event_trigger:
+			// TRIGGER and EVENT_TRIGGER are not valid in any context apart from the
+			// return-type of a trigger function.
 			continue
 		case oid.T_int2vector, oid.T_oidvector:
 			// Handled separately below.
@@ -156,6 +156,11 @@ func init() {
 		if !ok {
 			return
 		}
+		if toType.Family() == types.RangeFamily || toType.Family() == types.MultirangeFamily {
+			// Range and multirange types have constructor builtins with the same
+			// name as the type, defined in range_builtins.go.
+			return
+		}
 		distSQLBlockList := toType.Family() == types.OidFamily
 		if _, ok := castBuiltins[toOID]; !ok {
 			castBuiltins[toOID] = &builtinDefinition{
@@ -265,6 +270,10 @@ func shouldMakeFromCastBuiltin(in *types.T) bool {
 	case in.Family() == types.TriggerFamily:
 		// TRIGGER is not a valid cast target.
 		return false
+	case in.Family() == types.RangeFamily || in.Family() == types.MultirangeFamily:
+		// There are several range types per family, and ranges are only cast
+		// to and from strings, so only the cast expressions are supported.
+		return false
 	}
 	return true
 }
@@ -1369,6 +1378,33 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	// See https://www.postgresql.org/docs/current/sql-notify.html.
+	"pg_notify": makeBuiltin(
+		tree.FunctionProperties{DistsqlBlocklist: true},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "channel", Typ: types.String}, {Name: "payload", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Void),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return nil, pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+				}
+				var payload string
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				channel := string(tree.MustBeDString(args[0]))
+				if err := evalCtx.Planner.SendNotification(ctx, channel, payload); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
+			},
+			Info: "Sends a notification with the given payload on the channel when " +
+				"the current transaction commits, like the NOTIFY statement.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+
 	"pg_sleep": makeBuiltin(
 		tree.FunctionProperties{},
 		tree.Overload{
@@ -0,0 +1,304 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	for k, v := range makeRangeBuiltins() {
+		v.props.Category = builtinconstants.CategoryRange
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+// makeRangeBuiltins returns the constructors of the built-in range and
+// multirange types, which are named after the types, as well as the functions
+// that inspect ranges. The lower and upper functions are defined alongside
+// their string overloads in builtins.go.
+func makeRangeBuiltins() map[string]builtinDefinition {
+	b := map[string]builtinDefinition{
+		"isempty": collectOverloads(defProps(), types.RangeTypes,
+			makeRangeInspectOverload(
+				types.Bool, "Returns true if the range is empty.",
+				func(r *tree.DRange) tree.Datum { return tree.MakeDBool(tree.DBool(r.Empty)) },
+			),
+			makeMultirangeInspectOverload(
+				types.Bool, "Returns true if the multirange is empty.",
+				func(m *tree.DMultirange) tree.Datum { return tree.MakeDBool(tree.DBool(m.IsEmpty())) },
+			),
+		),
+		"lower_inc": collectOverloads(defProps(), types.RangeTypes,
+			makeRangeInspectOverload(
+				types.Bool, "Returns true if the lower bound of the range is inclusive.",
+				func(r *tree.DRange) tree.Datum { return tree.MakeDBool(tree.DBool(r.Lower.Inclusive)) },
+			),
+			makeMultirangeInspectOverload(
+				types.Bool, "Returns true if the lower bound of the multirange is inclusive.",
+				func(m *tree.DMultirange) tree.Datum {
+					return tree.MakeDBool(tree.DBool(m.Span().Lower.Inclusive))
+				},
+			),
+		),
+		"upper_inc": collectOverloads(defProps(), types.RangeTypes,
+			makeRangeInspectOverload(
+				types.Bool, "Returns true if the upper bound of the range is inclusive.",
+				func(r *tree.DRange) tree.Datum { return tree.MakeDBool(tree.DBool(r.Upper.Inclusive)) },
+			),
+			makeMultirangeInspectOverload(
+				types.Bool, "Returns true if the upper bound of the multirange is inclusive.",
+				func(m *tree.DMultirange) tree.Datum {
+					return tree.MakeDBool(tree.DBool(m.Span().Upper.Inclusive))
+				},
+			),
+		),
+		"lower_inf": collectOverloads(defProps(), types.RangeTypes,
+			makeRangeInspectOverload(
+				types.Bool, "Returns true if the range has no lower bound.",
+				func(r *tree.DRange) tree.Datum {
+					return tree.MakeDBool(tree.DBool(!r.Empty && r.Lower.IsInf()))
+				},
+			),
+			makeMultirangeInspectOverload(
+				types.Bool, "Returns true if the multirange has no lower bound.",
+				func(m *tree.DMultirange) tree.Datum {
+					s := m.Span()
+					return tree.MakeDBool(tree.DBool(!s.Empty && s.Lower.IsInf()))
+				},
+			),
+		),
+		"upper_inf": collectOverloads(defProps(), types.RangeTypes,
+			makeRangeInspectOverload(
+				types.Bool, "Returns true if the range has no upper bound.",
+				func(r *tree.DRange) tree.Datum {
+					return tree.MakeDBool(tree.DBool(!r.Empty && r.Upper.IsInf()))
+				},
+			),
+			makeMultirangeInspectOverload(
+				types.Bool, "Returns true if the multirange has no upper bound.",
+				func(m *tree.DMultirange) tree.Datum {
+					s := m.Span()
+					return tree.MakeDBool(tree.DBool(!s.Empty && s.Upper.IsInf()))
+				},
+			),
+		),
+		"range_merge": collectOverloads(defProps(), types.RangeTypes,
+			func(t *types.T) tree.Overload {
+				return tree.Overload{
+					Types:      tree.ParamTypes{{Name: "a", Typ: t}, {Name: "b", Typ: t}},
+					ReturnType: tree.FixedReturnType(t),
+					Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+						return tree.MustBeDRange(args[0]).Merge(tree.MustBeDRange(args[1]))
+					},
+					Info:       "Returns the smallest range that includes both of the given ranges.",
+					Volatility: volatility.Immutable,
+				}
+			},
+			func(t *types.T) tree.Overload {
+				mt := types.MakeMultirange(t)
+				return tree.Overload{
+					Types:      tree.ParamTypes{{Name: "val", Typ: mt}},
+					ReturnType: tree.FixedReturnType(t),
+					Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+						return tree.MustBeDMultirange(args[0]).Span(), nil
+					},
+					Info:       "Returns the smallest range that includes the entire multirange.",
+					Volatility: volatility.Immutable,
+				}
+			},
+		),
+		"multirange": collectOverloads(defProps(), types.RangeTypes,
+			func(t *types.T) tree.Overload {
+				mt := types.MakeMultirange(t)
+				return tree.Overload{
+					Types:      tree.ParamTypes{{Name: "val", Typ: t}},
+					ReturnType: tree.FixedReturnType(mt),
+					Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+						return tree.NewDMultirange(mt, []*tree.DRange{tree.MustBeDRange(args[0])})
+					},
+					Info:       "Returns a multirange containing just the given range.",
+					Volatility: volatility.Immutable,
+				}
+			},
+		),
+	}
+	for i, t := range types.RangeTypes {
+		b[t.PGName()] = makeRangeConstructor(t)
+		mt := types.MultirangeTypes[i]
+		b[mt.PGName()] = makeMultirangeConstructor(mt)
+	}
+	return b
+}
+
+// makeRangeConstructor returns the constructor builtin of the given range
+// type, which makes a range from its bounds.
+func makeRangeConstructor(t *types.T) builtinDefinition {
+	subtype := t.RangeSubtype()
+	return makeBuiltin(defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: subtype},
+				{Name: "upper", Typ: subtype},
+			},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return makeDRangeFromBounds(t, args[0], args[1], "[)")
+			},
+			Info: fmt.Sprintf("Constructs a %s with an inclusive lower bound and an exclusive "+
+				"upper bound. A NULL bound means that the range is unbounded on that side.", t.SQLString()),
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: subtype},
+				{Name: "upper", Typ: subtype},
+				{Name: "bounds", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[2] == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed,
+						"range constructor flags argument must not be null")
+				}
+				return makeDRangeFromBounds(t, args[0], args[1], string(tree.MustBeDString(args[2])))
+			},
+			Info: fmt.Sprintf("Constructs a %s with the inclusivity of the bounds given by `bounds`, "+
+				"which is one of `[]`, `[)`, `(]` or `()`. A NULL bound means that the range is "+
+				"unbounded on that side.", t.SQLString()),
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	)
+}
+
+// makeMultirangeConstructor returns the constructor builtin of the given
+// multirange type, which makes a multirange from any number of ranges.
+func makeMultirangeConstructor(t *types.T) builtinDefinition {
+	return makeBuiltin(defProps(),
+		tree.Overload{
+			Types:      tree.VariadicType{VarType: t.MultirangeContents()},
+			ReturnType: tree.FixedReturnType(t),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				ranges := make([]*tree.DRange, len(args))
+				for i := range args {
+					ranges[i] = tree.MustBeDRange(args[i])
+				}
+				return tree.NewDMultirange(t, ranges)
+			},
+			Info:       fmt.Sprintf("Constructs a %s containing the given ranges.", t.SQLString()),
+			Volatility: volatility.Immutable,
+		},
+	)
+}
+
+// makeDRangeFromBounds makes a range from the arguments of a range
+// constructor. NULL bounds are infinite.
+func makeDRangeFromBounds(t *types.T, lower, upper tree.Datum, flags string) (tree.Datum, error) {
+	if len(flags) != 2 || (flags[0] != '[' && flags[0] != '(') || (flags[1] != ']' && flags[1] != ')') {
+		return nil, errors.WithHint(
+			pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+			`Valid values are "[]", "[)", "(]", and "()".`,
+		)
+	}
+	lb := tree.RangeBound{Inclusive: flags[0] == '['}
+	if lower != tree.DNull {
+		lb.Val = lower
+	}
+	ub := tree.RangeBound{Inclusive: flags[1] == ']'}
+	if upper != tree.DNull {
+		ub.Val = upper
+	}
+	return tree.NewDRange(t, lb, ub)
+}
+
+// rangeBoundOverloads returns the overloads of the lower and upper functions
+// for the range and multirange types. The result is NULL if the range is
+// empty or unbounded on that side.
+func rangeBoundOverloads(lower bool) []tree.Overload {
+	side := "upper"
+	if lower {
+		side = "lower"
+	}
+	bound := func(r *tree.DRange) tree.Datum {
+		b := r.Upper
+		if lower {
+			b = r.Lower
+		}
+		if r.Empty || b.IsInf() {
+			return tree.DNull
+		}
+		return b.Val
+	}
+	overloads := make([]tree.Overload, 0, 2*len(types.RangeTypes))
+	for _, t := range types.RangeTypes {
+		overloads = append(overloads,
+			tree.Overload{
+				Types:      tree.ParamTypes{{Name: "val", Typ: t}},
+				ReturnType: tree.FixedReturnType(t.RangeSubtype()),
+				Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+					return bound(tree.MustBeDRange(args[0])), nil
+				},
+				Info:       fmt.Sprintf("Returns the %s bound of the range.", side),
+				Volatility: volatility.Immutable,
+			},
+			tree.Overload{
+				Types:      tree.ParamTypes{{Name: "val", Typ: types.MakeMultirange(t)}},
+				ReturnType: tree.FixedReturnType(t.RangeSubtype()),
+				Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+					return bound(tree.MustBeDMultirange(args[0]).Span()), nil
+				},
+				Info:       fmt.Sprintf("Returns the %s bound of the multirange.", side),
+				Volatility: volatility.Immutable,
+			},
+		)
+	}
+	return overloads
+}
+
+func makeRangeInspectOverload(
+	returnType *types.T, info string, f func(*tree.DRange) tree.Datum,
+) func(*types.T) tree.Overload {
+	return func(t *types.T) tree.Overload {
+		return tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: t}},
+			ReturnType: tree.FixedReturnType(returnType),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return f(tree.MustBeDRange(args[0])), nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		}
+	}
+}
+
+func makeMultirangeInspectOverload(
+	returnType *types.T, info string, f func(*tree.DMultirange) tree.Datum,
+) func(*types.T) tree.Overload {
+	return func(t *types.T) tree.Overload {
+		return tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.MakeMultirange(t)}},
+			ReturnType: tree.FixedReturnType(returnType),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return f(tree.MustBeDMultirange(args[0])), nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		}
+	}
+}
@@ -317,6 +317,18 @@ type framableAggregateWindowFunc struct {
 	shouldReset    bool
 }
 
+// NewFramableAggregateWindowFunc creates a constructor of a window function
+// that computes the aggregate created by aggConstructor over the window frame.
+// Unlike NewAggregateWindowFunc, it supports frames other than the default
+// one.
+func NewFramableAggregateWindowFunc(
+	aggConstructor func(*eval.Context, tree.Datums) eval.AggregateFunc,
+) func(*eval.Context) eval.WindowFunc {
+	return func(evalCtx *eval.Context) eval.WindowFunc {
+		return newFramableAggregateWindow(aggConstructor(evalCtx, nil /* arguments */), aggConstructor)
+	}
+}
+
 func newFramableAggregateWindow(
 	agg eval.AggregateFunc, aggConstructor func(*eval.Context, tree.Datums) eval.AggregateFunc,
 ) eval.WindowFunc {
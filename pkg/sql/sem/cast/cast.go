@@ -227,6 +227,14 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Domains have dynamic OIDs, so they can't be populated in castMap. A
+	// domain can be cast to and from anything its base type can be cast to and
+	// from. Casts to a domain additionally check the constraints of the domain,
+	// which is done by the optimizer.
+	if src.IsDomain() || tgt.IsDomain() {
+		return LookupCast(src.DomainBaseType(), tgt.DomainBaseType())
+	}
+
 	// Enums have dynamic OIDs, so they can't be populated in castMap. Instead,
 	// we dynamically create cast structs for valid enum casts.
 	if srcFamily == types.EnumFamily && tgtFamily == types.StringFamily {
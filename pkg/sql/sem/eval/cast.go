@@ -96,6 +96,9 @@ func PerformAssignmentCast(
 func performCast(
 	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T, truncateWidth bool,
 ) (tree.Datum, error) {
+	// The values of a domain are values of its base type. The constraints of
+	// the domain are checked separately.
+	t = t.DomainBaseType()
 	d, err := performCastWithoutPrecisionTruncation(ctx, evalCtx, d, t, truncateWidth)
 	if err != nil {
 		return nil, err
@@ -462,6 +465,13 @@ func performCastWithoutPrecisionTruncation(
 				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
 				tree.FmtLocation(evalCtx.GetLocation()),
 			)
+		case *tree.DRange, *tree.DMultirange:
+			s = tree.AsStringWithFlags(
+				d,
+				tree.FmtPgwireText,
+				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
+				tree.FmtLocation(evalCtx.GetLocation()),
+			)
 		case *tree.DInterval:
 			// When converting an interval to string, we need a string representation
 			// of the duration (e.g. "5s") and not of the interval itself (e.g.
@@ -628,6 +638,32 @@ func performCastWithoutPrecisionTruncation(
 			return d, nil
 		}
 
+	case types.RangeFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*d), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, d.Contents, t)
+			return res, err
+		case *tree.DRange:
+			return d, nil
+		}
+
+	case types.MultirangeFamily:
+		switch d := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDMultirangeFromString(evalCtx, string(*d), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDMultirangeFromString(evalCtx, d.Contents, t)
+			return res, err
+		case *tree.DRange:
+			return tree.NewDMultirange(t, []*tree.DRange{d})
+		case *tree.DMultirange:
+			return d, nil
+		}
+
 	case types.RefCursorFamily:
 		switch d := d.(type) {
 		case *tree.DString:
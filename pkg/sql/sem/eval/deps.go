@@ -352,6 +352,24 @@ type Planner interface {
 	// it is invalid.
 	RepairTTLScheduledJobForTable(ctx context.Context, tableID int64) error
 
+	// SendNotification sends a notification on the given channel when the
+	// current transaction commits. It is used to implement pg_notify.
+	SendNotification(ctx context.Context, channel, payload string) error
+
+	// ListeningChannels returns the notification channels the session listens
+	// on. It is used to implement pg_listening_channels.
+	ListeningChannels() []string
+
+	// EventTriggerDDLCommands returns the rows of
+	// pg_event_trigger_ddl_commands(), which describe the objects created or
+	// altered by the DDL command that fired the current event trigger.
+	EventTriggerDDLCommands(ctx context.Context) ([]tree.Datums, error)
+
+	// EventTriggerDroppedObjects returns the rows of
+	// pg_event_trigger_dropped_objects(), which describe the objects dropped by
+	// the DDL command that fired the current event trigger.
+	EventTriggerDroppedObjects(ctx context.Context) ([]tree.Datums, error)
+
 	// FingerprintSpan calculates a fingerprint for the given span. If a
 	// startTime is passed and allRevisions is true, then the fingerprint
 	// includes the MVCC history between startTime and the read timestamp of
@@ -425,6 +443,19 @@ type Planner interface {
 	// PLpgSQL FETCH statement.
 	PLpgSQLFetchCursor(ctx context.Context, cursor *tree.CursorStmt) (res tree.Datums, err error)
 
+	// PLpgSQLFetchCursorRecord is similar to PLpgSQLFetchCursor, but returns the
+	// row as a tuple labeled with the names of the cursor's columns, or DNull if
+	// no such row exists. It is used to fetch rows into PLpgSQL RECORD variables
+	// whose structure is only known at execution time.
+	PLpgSQLFetchCursorRecord(ctx context.Context, cursor *tree.CursorStmt) (tree.Datum, error)
+
+	// PLpgSQLOpenQueryCursor opens a cursor with the given name for the given
+	// query string, using the given values for its placeholders. It is used to
+	// implement PLpgSQL FOR loops over dynamic queries.
+	PLpgSQLOpenQueryCursor(
+		ctx context.Context, cursorName tree.Name, query string, params tree.Datums,
+	) error
+
 	// AutoCommit indicates whether the Planner has flagged the current statement
 	// as eligible for transaction auto-commit.
 	AutoCommit() bool
@@ -636,6 +636,59 @@ func (c *IntForLoopControl) Format(ctx *tree.FmtCtx) {
 	}
 }
 
+// QueryForLoopControl is the control structure of a FOR loop that iterates
+// over the rows of a query.
+type QueryForLoopControl struct {
+	Query tree.Statement
+}
+
+var _ ForLoopControl = &QueryForLoopControl{}
+
+func (c *QueryForLoopControl) isForLoopControl() {}
+
+func (c *QueryForLoopControl) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(c.Query)
+}
+
+// CursorForLoopControl is the control structure of a FOR loop that iterates
+// over the rows of a bound cursor.
+type CursorForLoopControl struct {
+	CursorVar Variable
+}
+
+var _ ForLoopControl = &CursorForLoopControl{}
+
+func (c *CursorForLoopControl) isForLoopControl() {}
+
+func (c *CursorForLoopControl) Format(ctx *tree.FmtCtx) {
+	ctx.FormatName(string(c.CursorVar))
+}
+
+// DynamicQueryForLoopControl is the control structure of a FOR loop that
+// iterates over the rows of a query string, which is planned and executed
+// each time the loop starts.
+type DynamicQueryForLoopControl struct {
+	Query  Expr
+	Params []Expr
+}
+
+var _ ForLoopControl = &DynamicQueryForLoopControl{}
+
+func (c *DynamicQueryForLoopControl) isForLoopControl() {}
+
+func (c *DynamicQueryForLoopControl) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("EXECUTE ")
+	ctx.FormatNode(c.Query)
+	for i := range c.Params {
+		if i == 0 {
+			ctx.WriteString(" USING ")
+		} else {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(c.Params[i])
+	}
+}
+
 // stmt_for
 type ForLoop struct {
 	StatementImpl
@@ -682,6 +735,12 @@ func (s *ForLoop) PlpgSQLStatementTag() string {
 	switch s.Control.(type) {
 	case *IntForLoopControl:
 		return "stmt_for_int_loop"
+	case *QueryForLoopControl:
+		return "stmt_for_query_loop"
+	case *CursorForLoopControl:
+		return "stmt_for_cursor_loop"
+	case *DynamicQueryForLoopControl:
+		return "stmt_for_dynamic_query_loop"
 	}
 	return "stmt_for_unknown"
 }
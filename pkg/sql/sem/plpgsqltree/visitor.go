@@ -285,6 +285,36 @@ func (v *SQLStmtVisitor) Visit(stmt Statement) (newStmt Statement, recurse bool)
 				}
 				newStmt = cpy
 			}
+		case *QueryForLoopControl:
+			s, v.Err = v.visitStmt(c.Query)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if c.Query != s {
+				cpy := t.CopyNode()
+				cpy.Control = &QueryForLoopControl{Query: s}
+				newStmt = cpy
+			}
+		case *DynamicQueryForLoopControl:
+			var newQuery tree.Expr
+			newQuery, v.Err = v.visitExpr(c.Query)
+			if v.Err != nil {
+				return stmt, false
+			}
+			changed := newQuery != c.Query
+			newParams := make([]Expr, len(c.Params))
+			for i := range c.Params {
+				newParams[i], v.Err = v.visitExpr(c.Params[i])
+				if v.Err != nil {
+					return stmt, false
+				}
+				changed = changed || newParams[i] != c.Params[i]
+			}
+			if changed {
+				cpy := t.CopyNode()
+				cpy.Control = &DynamicQueryForLoopControl{Query: newQuery, Params: newParams}
+				newStmt = cpy
+			}
 		}
 
 	case *ForEachArray, *ReturnNext,
@@ -150,6 +150,9 @@ func ComputeColNameInternal(
 	case *CoalesceExpr:
 		return 2, "coalesce", nil
 
+	case *GroupingExpr:
+		return 2, "grouping", nil
+
 		// CockroachDB-specific nodes follow.
 	case *IfErrExpr:
 		if e.Else == nil {
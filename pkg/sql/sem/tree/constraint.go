@@ -138,3 +138,82 @@ func (x CompositeKeyMatchMethod) String() string {
 		return strconv.Itoa(int(x))
 	}
 }
+
+// ConstraintDeferrability is whether the checks of a constraint can be
+// deferred to the end of the transaction.
+// See https://www.postgresql.org/docs/current/sql-set-constraints.html for
+// details.
+type ConstraintDeferrability int
+
+// The values for ConstraintDeferrability.
+const (
+	// NotDeferrable constraints are checked at the end of each statement.
+	NotDeferrable ConstraintDeferrability = iota
+	// DeferrableInitiallyImmediate constraints are checked at the end of each
+	// statement, unless SET CONSTRAINTS defers them.
+	DeferrableInitiallyImmediate
+	// DeferrableInitiallyDeferred constraints are checked at the end of the
+	// transaction, unless SET CONSTRAINTS makes them immediate.
+	DeferrableInitiallyDeferred
+)
+
+// MakeConstraintDeferrability returns the ConstraintDeferrability described by
+// the deferrable and initially deferred attributes of a constraint.
+func MakeConstraintDeferrability(deferrable, initiallyDeferred bool) ConstraintDeferrability {
+	switch {
+	case initiallyDeferred:
+		return DeferrableInitiallyDeferred
+	case deferrable:
+		return DeferrableInitiallyImmediate
+	default:
+		return NotDeferrable
+	}
+}
+
+// IsDeferrable returns whether the checks of the constraint can be deferred.
+func (x ConstraintDeferrability) IsDeferrable() bool {
+	return x != NotDeferrable
+}
+
+// IsInitiallyDeferred returns whether the checks of the constraint are
+// deferred by default.
+func (x ConstraintDeferrability) IsInitiallyDeferred() bool {
+	return x == DeferrableInitiallyDeferred
+}
+
+// String implements the fmt.Stringer interface.
+func (x ConstraintDeferrability) String() string {
+	switch x {
+	case NotDeferrable:
+		return ""
+	case DeferrableInitiallyImmediate:
+		return "DEFERRABLE"
+	case DeferrableInitiallyDeferred:
+		return "DEFERRABLE INITIALLY DEFERRED"
+	default:
+		return strconv.Itoa(int(x))
+	}
+}
+
+// Format implements the NodeFormatter interface. Nothing is written for
+// constraints which are not deferrable, which is the default.
+func (x *ConstraintDeferrability) Format(ctx *FmtCtx) {
+	if x.IsDeferrable() {
+		ctx.WriteByte(' ')
+		ctx.WriteString(x.String())
+	}
+}
+
+// IsDeferrableConstraintDef returns whether the given constraint definition is
+// of a deferrable constraint.
+func IsDeferrableConstraintDef(def ConstraintTableDef) bool {
+	switch d := def.(type) {
+	case *UniqueConstraintTableDef:
+		return d.Deferrability.IsDeferrable()
+	case *ForeignKeyConstraintTableDef:
+		return d.Deferrability.IsDeferrable()
+	case *ExclusionConstraintTableDef:
+		return d.Deferrability.IsDeferrable()
+	}
+	return false
+}
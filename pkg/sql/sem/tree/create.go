@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/idxtype"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
@@ -411,6 +412,110 @@ func (node *CreateType) String() string {
 	return AsString(node)
 }
 
+// CreateDomain represents a CREATE DOMAIN statement.
+type CreateDomain struct {
+	Name    *UnresolvedObjectName
+	Type    ResolvableTypeReference
+	NotNull bool
+	// Default is the default expression of the domain, or nil if there is
+	// none.
+	Default Expr
+	Checks  []DomainCheck
+}
+
+// DomainCheck represents a CHECK constraint of a domain. Its expression refers
+// to the checked value as VALUE.
+type DomainCheck struct {
+	// Name is the name of the constraint, or empty if none was given.
+	Name Name
+	Expr Expr
+}
+
+var _ Statement = &CreateDomain{}
+
+// AddQualifications adds the constraints given after the base type of a
+// CREATE DOMAIN statement, rejecting those which do not apply to domains.
+func (node *CreateDomain) AddQualifications(quals []NamedColumnQualification) error {
+	sawNull := false
+	for _, q := range quals {
+		switch t := q.Qualification.(type) {
+		case NotNullConstraint, NullConstraint:
+			_, notNull := t.(NotNullConstraint)
+			if sawNull && node.NotNull != notNull {
+				return pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			sawNull, node.NotNull = true, notNull
+		case *ColumnDefault:
+			if node.Default != nil {
+				return pgerror.New(pgcode.Syntax, "multiple default expressions")
+			}
+			node.Default = t.Expr
+		case *ColumnCheckConstraint:
+			node.Checks = append(node.Checks, DomainCheck{Name: q.Name, Expr: t.Expr})
+		case ColumnCollation:
+			locale := string(t)
+			if locale != collatedstring.DefaultCollationTag {
+				if _, err := language.Parse(locale); err != nil {
+					return pgerror.Wrapf(err, pgcode.Syntax, "invalid locale %s", locale)
+				}
+				collatedTyp, err := processCollationOnType(Name(node.Name.Object()), node.Type, t)
+				if err != nil {
+					return err
+				}
+				node.Type = collatedTyp
+			}
+		case UniqueConstraint:
+			return pgerror.New(pgcode.Syntax, "unique constraints not possible for domains")
+		case PrimaryKeyConstraint, ShardedPrimaryKeyConstraint:
+			return pgerror.New(pgcode.Syntax, "primary key constraints not possible for domains")
+		case *ColumnFKConstraint:
+			return pgerror.New(pgcode.Syntax, "foreign key constraints not possible for domains")
+		case *ColumnComputedDef, *GeneratedAlwaysAsIdentity, *GeneratedByDefAsIdentity:
+			return pgerror.New(pgcode.Syntax, "generated columns not possible for domains")
+		case *ColumnOnUpdate:
+			return pgerror.New(pgcode.Syntax, "ON UPDATE expressions not possible for domains")
+		case HiddenConstraint:
+			return pgerror.New(pgcode.Syntax, "NOT VISIBLE not possible for domains")
+		case *ColumnFamilyConstraint:
+			return pgerror.New(pgcode.Syntax, "column families not possible for domains")
+		default:
+			return errors.AssertionFailedf("unexpected column qualification %T", t)
+		}
+	}
+	return nil
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE DOMAIN ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.Type)
+	if node.Default != nil {
+		ctx.WriteString(" DEFAULT ")
+		ctx.FormatNode(node.Default)
+	}
+	if node.NotNull {
+		ctx.WriteString(" NOT NULL")
+	}
+	for i := range node.Checks {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Checks[i])
+	}
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DomainCheck) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("CHECK (")
+	ctx.FormatNode(node.Expr)
+	ctx.WriteByte(')')
+}
+
 // TableDef represents a column, index or constraint definition within a CREATE
 // TABLE statement.
 type TableDef interface {
@@ -425,6 +530,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExclusionConstraintTableDef) tableDef()  {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -517,6 +623,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrability  ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
@@ -700,6 +807,7 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			d.References.Deferrability = t.Deferrability
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -870,6 +978,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(&node.References.Deferrability)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -1008,10 +1117,11 @@ type ColumnCheckConstraint struct {
 
 // ColumnFKConstraint represents a FK-constaint on a column.
 type ColumnFKConstraint struct {
-	Table   TableName
-	Col     Name // empty-string means use PK
-	Actions ReferenceActions
-	Match   CompositeKeyMatchMethod
+	Table         TableName
+	Col           Name // empty-string means use PK
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	Deferrability ConstraintDeferrability
 }
 
 // ColumnComputedDef represents the description of a computed column.
@@ -1104,14 +1214,16 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExclusionConstraintTableDef) constraintTableDef()  {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
 type UniqueConstraintTableDef struct {
 	IndexTableDef
-	PrimaryKey   bool
-	WithoutIndex bool
-	IfNotExists  bool
+	PrimaryKey    bool
+	WithoutIndex  bool
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // SetName implements the TableDef interface.
@@ -1156,6 +1268,7 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	ctx.FormatNode(&node.Deferrability)
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1175,13 +1288,14 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
-	Name        Name
-	Table       TableName
-	FromCols    NameList
-	ToCols      NameList
-	Actions     ReferenceActions
-	Match       CompositeKeyMatchMethod
-	IfNotExists bool
+	Name          Name
+	Table         TableName
+	FromCols      NameList
+	ToCols        NameList
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -1212,6 +1326,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(&node.Deferrability)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1258,6 +1373,84 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// ExclusionConstraintTableDef represents an EXCLUDE constraint within a CREATE
+// TABLE statement.
+type ExclusionConstraintTableDef struct {
+	Name Name
+	// Method is the index access method given with USING, if any.
+	Method        Name
+	Elems         ExclusionElemList
+	Predicate     Expr
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
+}
+
+// ExclusionElem is a column of an exclusion constraint, with the operator
+// used to compare its values.
+type ExclusionElem struct {
+	Column   Name
+	Operator treecmp.ComparisonOperator
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.Column)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// ExclusionElemList is a list of ExclusionElem.
+type ExclusionElemList []ExclusionElem
+
+// Format implements the NodeFormatter interface.
+func (l *ExclusionElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE ")
+	if node.Method != "" {
+		ctx.WriteString("USING ")
+		// NB: the access method is not anonymized, like a keyword.
+		ctx.WithFlags(ctx.flags&^FmtAnonymize, func() {
+			ctx.FormatNode(&node.Method)
+		})
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	ctx.FormatNode(&node.Deferrability)
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE ")
+		ctx.FormatNode(node.Predicate)
+	}
+}
+
 // FamilyTableDef represents a family definition within a CREATE TABLE
 // statement.
 type FamilyTableDef struct {
@@ -1622,12 +1815,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:         *col.References.Table,
+					FromCols:      NameList{col.Name},
+					ToCols:        targetCol,
+					Name:          col.References.ConstraintName,
+					Actions:       col.References.Actions,
+					Match:         col.References.Match,
+					Deferrability: col.References.Deferrability,
 				})
 				col.References.Table = nil
 			}
@@ -1952,6 +2146,31 @@ type CreateView struct {
 	Replace      bool
 	Materialized bool
 	WithData     bool
+	CheckOption  ViewCheckOption
+}
+
+// ViewCheckOption represents the WITH CHECK OPTION clause of a CREATE VIEW
+// statement.
+type ViewCheckOption int
+
+const (
+	// ViewCheckOptionNone indicates that no WITH CHECK OPTION clause was
+	// specified.
+	ViewCheckOptionNone ViewCheckOption = iota
+	// ViewCheckOptionLocal refers to WITH LOCAL CHECK OPTION.
+	ViewCheckOptionLocal
+	// ViewCheckOptionCascaded refers to WITH [CASCADED] CHECK OPTION.
+	ViewCheckOptionCascaded
+)
+
+// Format implements the NodeFormatter interface.
+func (node *ViewCheckOption) Format(ctx *FmtCtx) {
+	switch *node {
+	case ViewCheckOptionLocal:
+		ctx.WriteString("WITH LOCAL CHECK OPTION")
+	case ViewCheckOptionCascaded:
+		ctx.WriteString("WITH CASCADED CHECK OPTION")
+	}
 }
 
 // Format implements the NodeFormatter interface.
@@ -1991,6 +2210,10 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 	} else if node.Materialized && !node.WithData {
 		ctx.WriteString(" WITH NO DATA")
 	}
+	if node.CheckOption != ViewCheckOptionNone {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.CheckOption)
+	}
 }
 
 // RefreshMaterializedView represents a REFRESH MATERIALIZED VIEW statement.
@@ -2015,6 +2238,9 @@ const (
 	// RefreshDataClear refers to the WITH NO DATA option provided to the REFRESH
 	// MATERIALIZED VIEW statement.
 	RefreshDataClear
+	// RefreshDataIncremental refers to the INCREMENTAL option provided to the
+	// REFRESH MATERIALIZED VIEW statement.
+	RefreshDataIncremental
 )
 
 // Format implements the NodeFormatter interface.
@@ -2033,6 +2259,8 @@ func (node *RefreshMaterializedView) Format(ctx *FmtCtx) {
 		ctx.WriteString(" WITH DATA")
 	case RefreshDataClear:
 		ctx.WriteString(" WITH NO DATA")
+	case RefreshDataIncremental:
+		ctx.WriteString(" INCREMENTAL")
 	}
 }
 
@@ -2187,6 +2415,54 @@ func (node *CreateExternalConnection) Format(ctx *FmtCtx) {
 	ctx.FormatURI(node.As)
 }
 
+// CreatePublication represents a CREATE PUBLICATION statement.
+type CreatePublication struct {
+	Name Name
+	// AllTables is set for FOR ALL TABLES publications, which include every
+	// table in the database, including tables created later.
+	AllTables bool
+	Tables    TableNames
+}
+
+var _ Statement = &CreatePublication{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePublication) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE PUBLICATION ")
+	ctx.FormatNode(&node.Name)
+	if node.AllTables {
+		ctx.WriteString(" FOR ALL TABLES")
+	} else if len(node.Tables) > 0 {
+		ctx.WriteString(" FOR TABLE ")
+		ctx.FormatNode(&node.Tables)
+	}
+}
+
+// CreateSubscription represents a CREATE SUBSCRIPTION statement.
+type CreateSubscription struct {
+	Name         Name
+	Connection   Expr
+	Publications NameList
+	Options      KVOptions
+}
+
+var _ Statement = &CreateSubscription{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateSubscription) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SUBSCRIPTION ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" CONNECTION ")
+	ctx.FormatURI(node.Connection)
+	ctx.WriteString(" PUBLICATION ")
+	ctx.FormatNode(&node.Publications)
+	if node.Options != nil {
+		ctx.WriteString(" WITH (")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteString(")")
+	}
+}
+
 // CreateTenant represents a CREATE VIRTUAL CLUSTER statement.
 type CreateTenant struct {
 	IfNotExists bool
@@ -8,9 +8,12 @@ package tree
 import (
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // ErrConflictingRoutineOption indicates that there are conflicting or
@@ -72,7 +75,8 @@ func (f *RoutineName) FQString() string {
 
 func (f *RoutineName) objectName() {}
 
-// CreateRoutine represents a CREATE FUNCTION or CREATE PROCEDURE statement.
+// CreateRoutine represents a CREATE FUNCTION, CREATE PROCEDURE or CREATE
+// AGGREGATE statement.
 type CreateRoutine struct {
 	IsProcedure bool
 	Replace     bool
@@ -81,6 +85,9 @@ type CreateRoutine struct {
 	ReturnType  *RoutineReturnType
 	Options     RoutineOptions
 	RoutineBody *RoutineBody
+	// Aggregate is set for CREATE AGGREGATE statements. The opt builder fills in
+	// the return type, options and body of the routine from it.
+	Aggregate *RoutineAggregate
 	// BodyStatements is not assigned during initial parsing of user input. It's
 	// assigned during opt builder for logging purpose at the moment. It stores
 	// all parsed AST nodes of body statements with all expression in original
@@ -98,6 +105,20 @@ func (node *CreateRoutine) Format(ctx *FmtCtx) {
 	if node.Replace {
 		ctx.WriteString("OR REPLACE ")
 	}
+	if node.Aggregate != nil {
+		ctx.WriteString("AGGREGATE ")
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte('(')
+		if len(node.Params) == 0 {
+			// An aggregate without arguments is declared with a '*'.
+			ctx.WriteByte('*')
+		} else {
+			ctx.FormatNode(node.Params)
+		}
+		ctx.WriteString(") ")
+		ctx.FormatNode(node.Aggregate)
+		return
+	}
 	if node.IsProcedure {
 		ctx.WriteString("PROCEDURE ")
 	} else {
@@ -166,6 +187,107 @@ func (node *CreateRoutine) Format(ctx *FmtCtx) {
 	}
 }
 
+// RoutineAggregate represents the attributes of a CREATE AGGREGATE statement.
+type RoutineAggregate struct {
+	// SFunc is the state transition function. It is called with the current
+	// state and the arguments of each aggregated row, and returns the new state.
+	SFunc *RoutineName
+	// SType is the type of the aggregate state.
+	SType ResolvableTypeReference
+	// FinalFunc, if set, is called with the final state to compute the result
+	// of the aggregate. Otherwise, the final state is the result.
+	FinalFunc *RoutineName
+	// CombineFunc, if set, combines two partial aggregate states, which allows
+	// the aggregate to be evaluated in multiple stages.
+	CombineFunc *RoutineName
+	// InitCond, if set, is the string representation of the initial state.
+	// Otherwise, the initial state is NULL.
+	InitCond *string
+
+	// The fields below are not assigned during parsing. They are assigned by
+	// the opt builder once the support functions and the state type have been
+	// resolved.
+	SFuncOID       oid.Oid
+	FinalFuncOID   oid.Oid
+	CombineFuncOID oid.Oid
+	StateType      *types.T
+}
+
+// Format implements the NodeFormatter interface.
+func (node *RoutineAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteByte('(')
+	sep := ""
+	if node.SFunc != nil {
+		ctx.WriteString("SFUNC = ")
+		ctx.FormatNode(node.SFunc)
+		sep = ", "
+	}
+	if node.SType != nil {
+		ctx.WriteString(sep)
+		ctx.WriteString("STYPE = ")
+		ctx.FormatTypeReference(node.SType)
+		sep = ", "
+	}
+	if node.FinalFunc != nil {
+		ctx.WriteString(sep)
+		ctx.WriteString("FINALFUNC = ")
+		ctx.FormatNode(node.FinalFunc)
+		sep = ", "
+	}
+	if node.CombineFunc != nil {
+		ctx.WriteString(sep)
+		ctx.WriteString("COMBINEFUNC = ")
+		ctx.FormatNode(node.CombineFunc)
+		sep = ", "
+	}
+	if node.InitCond != nil {
+		ctx.WriteString(sep)
+		ctx.WriteString("INITCOND = ")
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, *node.InitCond, ctx.flags.EncodeFlags())
+		}
+	}
+	ctx.WriteByte(')')
+}
+
+// CombineWith merges the attributes of other into node. An error is returned
+// if an attribute is specified more than once.
+func (node *RoutineAggregate) CombineWith(other *RoutineAggregate) error {
+	if other.SFunc != nil {
+		if node.SFunc != nil {
+			return ErrConflictingRoutineOption
+		}
+		node.SFunc = other.SFunc
+	}
+	if other.SType != nil {
+		if node.SType != nil {
+			return ErrConflictingRoutineOption
+		}
+		node.SType = other.SType
+	}
+	if other.FinalFunc != nil {
+		if node.FinalFunc != nil {
+			return ErrConflictingRoutineOption
+		}
+		node.FinalFunc = other.FinalFunc
+	}
+	if other.CombineFunc != nil {
+		if node.CombineFunc != nil {
+			return ErrConflictingRoutineOption
+		}
+		node.CombineFunc = other.CombineFunc
+	}
+	if other.InitCond != nil {
+		if node.InitCond != nil {
+			return ErrConflictingRoutineOption
+		}
+		node.InitCond = other.InitCond
+	}
+	return nil
+}
+
 // RoutineBody represent a list of statements in a UDF body.
 type RoutineBody struct {
 	// Stmts is populated during parsing. Unlike BodyStatements, we don't need
@@ -446,19 +568,24 @@ type RoutineReturnType struct {
 	SetOf bool
 }
 
-// DropRoutine represents a DROP FUNCTION or DROP PROCEDURE statement.
+// DropRoutine represents a DROP FUNCTION, DROP PROCEDURE or DROP AGGREGATE
+// statement.
 type DropRoutine struct {
 	IfExists     bool
 	Procedure    bool
+	Aggregate    bool
 	Routines     RoutineObjs
 	DropBehavior DropBehavior
 }
 
 // Format implements the NodeFormatter interface.
 func (node *DropRoutine) Format(ctx *FmtCtx) {
-	if node.Procedure {
+	switch {
+	case node.Procedure:
 		ctx.WriteString("DROP PROCEDURE ")
-	} else {
+	case node.Aggregate:
+		ctx.WriteString("DROP AGGREGATE ")
+	default:
 		ctx.WriteString("DROP FUNCTION ")
 	}
 	if node.IfExists {
@@ -517,61 +644,71 @@ func (node *AlterFunctionOptions) Format(ctx *FmtCtx) {
 	}
 }
 
-// AlterRoutineRename represents a ALTER FUNCTION...RENAME or
-// ALTER PROCEDURE...RENAME statement.
+// routineKeyword returns the keyword that identifies the kind of routine in
+// ALTER statements.
+func routineKeyword(procedure, aggregate bool) string {
+	switch {
+	case procedure:
+		return "PROCEDURE"
+	case aggregate:
+		return "AGGREGATE"
+	default:
+		return "FUNCTION"
+	}
+}
+
+// AlterRoutineRename represents a ALTER FUNCTION...RENAME,
+// ALTER PROCEDURE...RENAME or ALTER AGGREGATE...RENAME statement.
 type AlterRoutineRename struct {
 	Function  RoutineObj
 	NewName   Name
 	Procedure bool
+	Aggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineRename) Format(ctx *FmtCtx) {
-	if node.Procedure {
-		ctx.WriteString("ALTER PROCEDURE ")
-	} else {
-		ctx.WriteString("ALTER FUNCTION ")
-	}
+	ctx.WriteString("ALTER ")
+	ctx.WriteString(routineKeyword(node.Procedure, node.Aggregate))
+	ctx.WriteByte(' ')
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" RENAME TO ")
 	ctx.FormatNode(&node.NewName)
 }
 
-// AlterRoutineSetSchema represents a ALTER FUNCTION...SET SCHEMA or
-// ALTER PROCEDURE...SET SCHEMA statement.
+// AlterRoutineSetSchema represents a ALTER FUNCTION...SET SCHEMA,
+// ALTER PROCEDURE...SET SCHEMA or ALTER AGGREGATE...SET SCHEMA statement.
 type AlterRoutineSetSchema struct {
 	Function      RoutineObj
 	NewSchemaName Name
 	Procedure     bool
+	Aggregate     bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineSetSchema) Format(ctx *FmtCtx) {
-	if node.Procedure {
-		ctx.WriteString("ALTER PROCEDURE ")
-	} else {
-		ctx.WriteString("ALTER FUNCTION ")
-	}
+	ctx.WriteString("ALTER ")
+	ctx.WriteString(routineKeyword(node.Procedure, node.Aggregate))
+	ctx.WriteByte(' ')
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" SET SCHEMA ")
 	ctx.FormatNode(&node.NewSchemaName)
 }
 
-// AlterRoutineSetOwner represents the ALTER FUNCTION...OWNER TO or
-// ALTER PROCEDURE...OWNER TO statement.
+// AlterRoutineSetOwner represents the ALTER FUNCTION...OWNER TO,
+// ALTER PROCEDURE...OWNER TO or ALTER AGGREGATE...OWNER TO statement.
 type AlterRoutineSetOwner struct {
 	Function  RoutineObj
 	NewOwner  RoleSpec
 	Procedure bool
+	Aggregate bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterRoutineSetOwner) Format(ctx *FmtCtx) {
-	if node.Procedure {
-		ctx.WriteString("ALTER PROCEDURE ")
-	} else {
-		ctx.WriteString("ALTER FUNCTION ")
-	}
+	ctx.WriteString("ALTER ")
+	ctx.WriteString(routineKeyword(node.Procedure, node.Aggregate))
+	ctx.WriteByte(' ')
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" OWNER TO ")
 	ctx.FormatNode(&node.NewOwner)
@@ -639,6 +776,8 @@ const (
 	TTLUpdateExpr                   SchemaExprContext = "TTL UPDATE"
 	PolicyUsingExpr                 SchemaExprContext = "POLICY USING"
 	PolicyWithCheckExpr             SchemaExprContext = "POLICY WITH CHECK"
+	DomainDefaultExpr               SchemaExprContext = "DOMAIN DEFAULT"
+	DomainCheckExpr                 SchemaExprContext = "DOMAIN CHECK"
 )
 
 func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
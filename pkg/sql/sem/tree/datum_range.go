@@ -0,0 +1,870 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var errRangeBoundOrder = pgerror.New(pgcode.DataException,
+	"range lower bound must be less than or equal to range upper bound")
+var errRangeUnionNotContiguous = pgerror.New(pgcode.DataException,
+	"result of range union would not be contiguous")
+var errRangeDifferenceNotContiguous = pgerror.New(pgcode.DataException,
+	"result of range difference would not be contiguous")
+
+// RangeBound is the lower or upper bound of a DRange.
+type RangeBound struct {
+	// Val is the value of the bound, or nil if the bound is infinite.
+	Val Datum
+	// Inclusive is true if Val is contained in the range. It is always false
+	// for an infinite bound.
+	Inclusive bool
+}
+
+// IsInf returns true if the bound is infinite.
+func (b RangeBound) IsInf() bool {
+	return b.Val == nil
+}
+
+// DRange is the Datum representation of the built-in range types, such as
+// INT8RANGE and TSTZRANGE.
+//
+// Ranges of discrete subtypes (INT4, INT8 and DATE) are always stored in the
+// canonical form with an inclusive lower bound and an exclusive upper bound,
+// so that equal ranges have equal bounds. An empty range has no bounds.
+type DRange struct {
+	typ *types.T
+	// Lower and Upper are the bounds of a non-empty range.
+	Lower, Upper RangeBound
+	// Empty is true if the range contains no values.
+	Empty bool
+}
+
+// NewEmptyDRange returns an empty range of the given range type.
+func NewEmptyDRange(typ *types.T) *DRange {
+	return &DRange{typ: typ, Empty: true}
+}
+
+// NewDRange returns a range of the given range type with the given bounds.
+// The bounds are canonicalized for discrete subtypes, which may result in an
+// empty range. An error is returned if the lower bound is greater than the
+// upper bound.
+func NewDRange(typ *types.T, lower, upper RangeBound) (*DRange, error) {
+	if lower.IsInf() {
+		lower.Inclusive = false
+	} else {
+		lower.Val = UnwrapDOidWrapper(lower.Val)
+	}
+	if upper.IsInf() {
+		upper.Inclusive = false
+	} else {
+		upper.Val = UnwrapDOidWrapper(upper.Val)
+	}
+	if !lower.IsInf() && !upper.IsInf() {
+		c := compareRangeValues(lower.Val, upper.Val)
+		if c > 0 {
+			return nil, errRangeBoundOrder
+		}
+		if c == 0 && !(lower.Inclusive && upper.Inclusive) {
+			return NewEmptyDRange(typ), nil
+		}
+	}
+	if rangeSubtypeIsDiscrete(typ) {
+		var err error
+		if !lower.IsInf() && !lower.Inclusive {
+			if lower.Val, err = nextRangeValue(typ, lower.Val); err != nil {
+				return nil, err
+			}
+			lower.Inclusive = true
+		}
+		if !upper.IsInf() && upper.Inclusive {
+			if upper.Val, err = nextRangeValue(typ, upper.Val); err != nil {
+				return nil, err
+			}
+			upper.Inclusive = false
+		}
+		if !lower.IsInf() && !upper.IsInf() && compareRangeValues(lower.Val, upper.Val) >= 0 {
+			return NewEmptyDRange(typ), nil
+		}
+	}
+	return &DRange{typ: typ, Lower: lower, Upper: upper}, nil
+}
+
+// rangeSubtypeIsDiscrete returns true if the subtype of the given range type
+// is discrete, in which case ranges are canonicalized to the [) form.
+func rangeSubtypeIsDiscrete(typ *types.T) bool {
+	switch typ.RangeSubtype().Family() {
+	case types.IntFamily, types.DateFamily:
+		return true
+	}
+	return false
+}
+
+// nextRangeValue returns the value following v in the subtype of the given
+// discrete range type.
+func nextRangeValue(typ *types.T, v Datum) (Datum, error) {
+	switch t := v.(type) {
+	case *DInt:
+		limit := int64(math.MaxInt64)
+		if typ.RangeSubtype().Width() == 32 {
+			limit = math.MaxInt32
+		}
+		if int64(*t) >= limit {
+			return nil, ErrIntOutOfRange
+		}
+		return NewDInt(*t + 1), nil
+	case *DDate:
+		if !t.IsFinite() {
+			return t, nil
+		}
+		d, err := t.AddDays(1)
+		if err != nil {
+			return nil, err
+		}
+		return NewDDate(d), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected discrete range value %T", v)
+}
+
+// compareRangeValues compares two non-NULL values of the subtype of a range.
+func compareRangeValues(a, b Datum) int {
+	switch t := a.(type) {
+	case *DInt:
+		o := *b.(*DInt)
+		if *t < o {
+			return -1
+		} else if *t > o {
+			return 1
+		}
+		return 0
+	case *DDecimal:
+		return CompareDecimals(&t.Decimal, &b.(*DDecimal).Decimal)
+	case *DDate:
+		return t.Date.Compare(b.(*DDate).Date)
+	case *DTimestamp:
+		return t.Time.Compare(b.(*DTimestamp).Time)
+	case *DTimestampTZ:
+		return t.Time.Compare(b.(*DTimestampTZ).Time)
+	}
+	panic(errors.AssertionFailedf("unexpected range value %T", a))
+}
+
+// compareRangeBounds compares two range bounds, each of which is either a
+// lower bound or an upper bound. An infinite lower bound is less than, and an
+// infinite upper bound is greater than, any finite bound. For equal values, an
+// exclusive lower bound sorts after and an exclusive upper bound sorts before
+// an inclusive bound.
+func compareRangeBounds(b1 RangeBound, isLower1 bool, b2 RangeBound, isLower2 bool) int {
+	switch {
+	case b1.IsInf() && b2.IsInf():
+		if isLower1 == isLower2 {
+			return 0
+		}
+		if isLower1 {
+			return -1
+		}
+		return 1
+	case b1.IsInf():
+		if isLower1 {
+			return -1
+		}
+		return 1
+	case b2.IsInf():
+		if isLower2 {
+			return 1
+		}
+		return -1
+	}
+	c := compareRangeValues(b1.Val, b2.Val)
+	if c != 0 {
+		return c
+	}
+	switch {
+	case !b1.Inclusive && !b2.Inclusive:
+		if isLower1 == isLower2 {
+			return 0
+		}
+		if isLower1 {
+			return 1
+		}
+		return -1
+	case !b1.Inclusive:
+		if isLower1 {
+			return 1
+		}
+		return -1
+	case !b2.Inclusive:
+		if isLower2 {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// AsDRange attempts to retrieve a *DRange from an Expr, returning a *DRange
+// and a flag signifying whether the assertion was successful. The function
+// should be used instead of direct type assertions wherever a *DRange wrapped
+// by a *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a *DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	r, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return r
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// compareRange compares two ranges of the same type. An empty range is less
+// than any non-empty range. Non-empty ranges are ordered by their lower
+// bounds, and then by their upper bounds.
+func (d *DRange) compareRange(o *DRange) int {
+	switch {
+	case d.Empty && o.Empty:
+		return 0
+	case d.Empty:
+		return -1
+	case o.Empty:
+		return 1
+	}
+	if c := compareRangeBounds(d.Lower, true, o.Lower, true); c != 0 {
+		return c
+	}
+	return compareRangeBounds(d.Upper, false, o.Upper, false)
+}
+
+// Compare implements the Datum interface.
+func (d *DRange) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DRange)
+	if !ok || v.typ.Oid() != d.typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return d.compareRange(v), nil
+}
+
+// ContainsValue returns true if the range contains the given value of its
+// subtype.
+func (d *DRange) ContainsValue(v Datum) bool {
+	if d.Empty {
+		return false
+	}
+	v = UnwrapDOidWrapper(v)
+	if !d.Lower.IsInf() {
+		c := compareRangeValues(d.Lower.Val, v)
+		if c > 0 || (c == 0 && !d.Lower.Inclusive) {
+			return false
+		}
+	}
+	if !d.Upper.IsInf() {
+		c := compareRangeValues(d.Upper.Val, v)
+		if c < 0 || (c == 0 && !d.Upper.Inclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsRange returns true if the range contains every value of the other
+// range. Any range contains the empty range.
+func (d *DRange) ContainsRange(o *DRange) bool {
+	if o.Empty {
+		return true
+	}
+	if d.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true, o.Lower, true) <= 0 &&
+		compareRangeBounds(d.Upper, false, o.Upper, false) >= 0
+}
+
+// Overlaps returns true if the ranges have a value in common.
+func (d *DRange) Overlaps(o *DRange) bool {
+	if d.Empty || o.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true, o.Upper, false) <= 0 &&
+		compareRangeBounds(o.Lower, true, d.Upper, false) <= 0
+}
+
+// StrictlyLeftOf returns true if every value of the range is less than every
+// value of the other range (the << operator).
+func (d *DRange) StrictlyLeftOf(o *DRange) bool {
+	if d.Empty || o.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Upper, false, o.Lower, true) < 0
+}
+
+// StrictlyRightOf returns true if every value of the range is greater than
+// every value of the other range (the >> operator).
+func (d *DRange) StrictlyRightOf(o *DRange) bool {
+	if d.Empty || o.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true, o.Upper, false) > 0
+}
+
+// NotExtendRightOf returns true if the range does not extend to the right of
+// the other range (the &< operator).
+func (d *DRange) NotExtendRightOf(o *DRange) bool {
+	if d.Empty || o.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Upper, false, o.Upper, false) <= 0
+}
+
+// NotExtendLeftOf returns true if the range does not extend to the left of
+// the other range (the &> operator).
+func (d *DRange) NotExtendLeftOf(o *DRange) bool {
+	if d.Empty || o.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, true, o.Lower, true) >= 0
+}
+
+// Adjacent returns true if the ranges do not overlap, but there are no values
+// between them (the -|- operator).
+func (d *DRange) Adjacent(o *DRange) bool {
+	if d.Empty || o.Empty {
+		return false
+	}
+	adjacent := func(upper, lower RangeBound) bool {
+		if upper.IsInf() || lower.IsInf() {
+			return false
+		}
+		return compareRangeValues(upper.Val, lower.Val) == 0 && upper.Inclusive != lower.Inclusive
+	}
+	return adjacent(d.Upper, o.Lower) || adjacent(o.Upper, d.Lower)
+}
+
+// Merge returns the smallest range that contains both ranges.
+func (d *DRange) Merge(o *DRange) (*DRange, error) {
+	if d.Empty {
+		return o, nil
+	}
+	if o.Empty {
+		return d, nil
+	}
+	lower, upper := d.Lower, d.Upper
+	if compareRangeBounds(o.Lower, true, lower, true) < 0 {
+		lower = o.Lower
+	}
+	if compareRangeBounds(o.Upper, false, upper, false) > 0 {
+		upper = o.Upper
+	}
+	return NewDRange(d.typ, lower, upper)
+}
+
+// Union returns the union of the ranges (the + operator). An error is
+// returned if the ranges neither overlap nor are adjacent.
+func (d *DRange) Union(o *DRange) (*DRange, error) {
+	if !d.Empty && !o.Empty && !d.Overlaps(o) && !d.Adjacent(o) {
+		return nil, errRangeUnionNotContiguous
+	}
+	return d.Merge(o)
+}
+
+// Intersect returns the intersection of the ranges (the * operator).
+func (d *DRange) Intersect(o *DRange) (*DRange, error) {
+	if !d.Overlaps(o) {
+		return NewEmptyDRange(d.typ), nil
+	}
+	lower, upper := d.Lower, d.Upper
+	if compareRangeBounds(o.Lower, true, lower, true) > 0 {
+		lower = o.Lower
+	}
+	if compareRangeBounds(o.Upper, false, upper, false) < 0 {
+		upper = o.Upper
+	}
+	return NewDRange(d.typ, lower, upper)
+}
+
+// Difference returns the values of the range that are not in the other range
+// (the - operator). An error is returned if the result is not contiguous.
+func (d *DRange) Difference(o *DRange) (*DRange, error) {
+	res, err := d.split(o)
+	if err != nil {
+		return nil, err
+	}
+	switch len(res) {
+	case 0:
+		return NewEmptyDRange(d.typ), nil
+	case 1:
+		return res[0], nil
+	}
+	return nil, errRangeDifferenceNotContiguous
+}
+
+// split returns the non-empty ranges that make up the values of the range
+// that are not in the other range. There are at most two of them.
+func (d *DRange) split(o *DRange) ([]*DRange, error) {
+	if d.Empty {
+		return nil, nil
+	}
+	if !d.Overlaps(o) {
+		return []*DRange{d}, nil
+	}
+	var res []*DRange
+	if compareRangeBounds(d.Lower, true, o.Lower, true) < 0 {
+		// The values of d below the lower bound of o.
+		upper := RangeBound{Val: o.Lower.Val, Inclusive: !o.Lower.Inclusive}
+		r, err := NewDRange(d.typ, d.Lower, upper)
+		if err != nil {
+			return nil, err
+		}
+		if !r.Empty {
+			res = append(res, r)
+		}
+	}
+	if compareRangeBounds(d.Upper, false, o.Upper, false) > 0 {
+		// The values of d above the upper bound of o.
+		lower := RangeBound{Val: o.Upper.Val, Inclusive: !o.Upper.Inclusive}
+		r, err := NewDRange(d.typ, lower, d.Upper)
+		if err != nil {
+			return nil, err
+		}
+		if !r.Empty {
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.Empty
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return NewEmptyDRange(d.typ), true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool { return true }
+
+// IsComposite implements the CompositeDatum interface. A NUMRANGE is
+// composite if one of its bounds is.
+func (d *DRange) IsComposite() bool {
+	for _, b := range []RangeBound{d.Lower, d.Upper} {
+		if cd, ok := b.Val.(CompositeDatum); ok && cd.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	formatRangeLiteral(ctx, func(buf *bytes.Buffer) { d.writeText(ctx, buf) })
+}
+
+// writeText writes the text representation of the range, for example
+// [1,10) or empty, to buf.
+func (d *DRange) writeText(ctx *FmtCtx, buf *bytes.Buffer) {
+	if d.Empty {
+		buf.WriteString("empty")
+		return
+	}
+	if d.Lower.Inclusive {
+		buf.WriteByte('[')
+	} else {
+		buf.WriteByte('(')
+	}
+	writeRangeBoundText(ctx, buf, d.Lower)
+	buf.WriteByte(',')
+	writeRangeBoundText(ctx, buf, d.Upper)
+	if d.Upper.Inclusive {
+		buf.WriteByte(']')
+	} else {
+		buf.WriteByte(')')
+	}
+}
+
+// writeRangeBoundText writes the text representation of a range bound to
+// buf. Infinite bounds are written as the empty string, and values are quoted
+// if they would otherwise be parsed differently.
+func writeRangeBoundText(ctx *FmtCtx, buf *bytes.Buffer, b RangeBound) {
+	if b.IsInf() {
+		return
+	}
+	s := AsStringWithFlags(b.Val, FmtPgwireText,
+		FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location))
+	if s != "" && !strings.ContainsAny(s, " \t\n\r\v\f()[],\"\\") {
+		buf.WriteString(s)
+		return
+	}
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			buf.WriteByte(s[i])
+		}
+		buf.WriteByte(s[i])
+	}
+	buf.WriteByte('"')
+}
+
+// formatRangeLiteral formats the text written by writeText as a string
+// literal, unless the text is formatted for pgwire or bare strings are
+// requested.
+func formatRangeLiteral(ctx *FmtCtx, writeText func(buf *bytes.Buffer)) {
+	if ctx.HasFlags(fmtPgwireFormat) || ctx.HasFlags(FmtFlags(lexbase.EncBareStrings)) {
+		writeText(&ctx.Buffer)
+		return
+	}
+	var buf bytes.Buffer
+	writeText(&buf)
+	ctx.WriteByte('\'')
+	ctx.WriteString(strings.ReplaceAll(buf.String(), `'`, `''`))
+	ctx.WriteByte('\'')
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if d.Lower.Val != nil {
+		sz += d.Lower.Val.Size()
+	}
+	if d.Upper.Val != nil {
+		sz += d.Upper.Val.Size()
+	}
+	return sz
+}
+
+// DMultirange is the Datum representation of the built-in multirange types,
+// such as INT8MULTIRANGE and TSTZMULTIRANGE. A multirange is an ordered list
+// of non-empty ranges that neither overlap nor are adjacent.
+type DMultirange struct {
+	typ    *types.T
+	Ranges []*DRange
+}
+
+// NewDMultirange returns a multirange of the given multirange type that
+// contains the values of the given ranges. Empty ranges are ignored, and
+// ranges that overlap or are adjacent are merged.
+func NewDMultirange(typ *types.T, ranges []*DRange) (*DMultirange, error) {
+	sorted := make([]*DRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.Empty {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].compareRange(sorted[j]) < 0
+	})
+	res := &DMultirange{typ: typ, Ranges: make([]*DRange, 0, len(sorted))}
+	for _, r := range sorted {
+		if n := len(res.Ranges); n > 0 {
+			if last := res.Ranges[n-1]; last.Overlaps(r) || last.Adjacent(r) {
+				merged, err := last.Merge(r)
+				if err != nil {
+					return nil, err
+				}
+				res.Ranges[n-1] = merged
+				continue
+			}
+		}
+		res.Ranges = append(res.Ranges, r)
+	}
+	return res, nil
+}
+
+// AsDMultirange attempts to retrieve a *DMultirange from an Expr, returning a
+// *DMultirange and a flag signifying whether the assertion was successful.
+// The function should be used instead of direct type assertions wherever a
+// *DMultirange wrapped by a *DOidWrapper is possible.
+func AsDMultirange(e Expr) (*DMultirange, bool) {
+	switch t := e.(type) {
+	case *DMultirange:
+		return t, true
+	case *DOidWrapper:
+		return AsDMultirange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMultirange attempts to retrieve a *DMultirange from an Expr,
+// panicking if the assertion fails.
+func MustBeDMultirange(e Expr) *DMultirange {
+	m, ok := AsDMultirange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMultirange, found %T", e))
+	}
+	return m
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMultirange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// RangeType returns the type of the ranges of the multirange.
+func (d *DMultirange) RangeType() *types.T {
+	return d.typ.MultirangeContents()
+}
+
+// IsEmpty returns true if the multirange contains no values.
+func (d *DMultirange) IsEmpty() bool {
+	return len(d.Ranges) == 0
+}
+
+// Span returns the smallest range that contains every value of the
+// multirange.
+func (d *DMultirange) Span() *DRange {
+	if d.IsEmpty() {
+		return NewEmptyDRange(d.RangeType())
+	}
+	return &DRange{
+		typ:   d.RangeType(),
+		Lower: d.Ranges[0].Lower,
+		Upper: d.Ranges[len(d.Ranges)-1].Upper,
+	}
+}
+
+// Compare implements the Datum interface. Multiranges are compared range by
+// range, and a multirange that is a prefix of another sorts first.
+func (d *DMultirange) Compare(
+	ctx context.Context, cmpCtx CompareContext, other Datum,
+) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DMultirange)
+	if !ok || v.typ.Oid() != d.typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	for i := 0; i < len(d.Ranges) && i < len(v.Ranges); i++ {
+		if c := d.Ranges[i].compareRange(v.Ranges[i]); c != 0 {
+			return c, nil
+		}
+	}
+	switch {
+	case len(d.Ranges) < len(v.Ranges):
+		return -1, nil
+	case len(d.Ranges) > len(v.Ranges):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// ContainsValue returns true if the multirange contains the given value of
+// its subtype.
+func (d *DMultirange) ContainsValue(v Datum) bool {
+	for _, r := range d.Ranges {
+		if r.ContainsValue(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsRange returns true if the multirange contains every value of the
+// given range.
+func (d *DMultirange) ContainsRange(o *DRange) bool {
+	if o.Empty {
+		return true
+	}
+	// The ranges of a multirange are not adjacent, so a non-empty range is
+	// contained in the multirange only if it is contained in one of them.
+	for _, r := range d.Ranges {
+		if r.ContainsRange(o) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsMultirange returns true if the multirange contains every value of
+// the other multirange.
+func (d *DMultirange) ContainsMultirange(o *DMultirange) bool {
+	for _, r := range o.Ranges {
+		if !d.ContainsRange(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// OverlapsRange returns true if the multirange and the range have a value in
+// common.
+func (d *DMultirange) OverlapsRange(o *DRange) bool {
+	for _, r := range d.Ranges {
+		if r.Overlaps(o) {
+			return true
+		}
+	}
+	return false
+}
+
+// OverlapsMultirange returns true if the multiranges have a value in common.
+func (d *DMultirange) OverlapsMultirange(o *DMultirange) bool {
+	for _, r := range o.Ranges {
+		if d.OverlapsRange(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns the union of the multiranges (the + operator).
+func (d *DMultirange) Union(o *DMultirange) (*DMultirange, error) {
+	ranges := make([]*DRange, 0, len(d.Ranges)+len(o.Ranges))
+	ranges = append(ranges, d.Ranges...)
+	ranges = append(ranges, o.Ranges...)
+	return NewDMultirange(d.typ, ranges)
+}
+
+// Intersect returns the intersection of the multiranges (the * operator).
+func (d *DMultirange) Intersect(o *DMultirange) (*DMultirange, error) {
+	var ranges []*DRange
+	for _, r1 := range d.Ranges {
+		for _, r2 := range o.Ranges {
+			r, err := r1.Intersect(r2)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r)
+		}
+	}
+	return NewDMultirange(d.typ, ranges)
+}
+
+// Difference returns the values of the multirange that are not in the other
+// multirange (the - operator).
+func (d *DMultirange) Difference(o *DMultirange) (*DMultirange, error) {
+	ranges := d.Ranges
+	for _, r2 := range o.Ranges {
+		var next []*DRange
+		for _, r1 := range ranges {
+			parts, err := r1.split(r2)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, parts...)
+		}
+		ranges = next
+	}
+	return NewDMultirange(d.typ, ranges)
+}
+
+// Prev implements the Datum interface.
+func (d *DMultirange) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DMultirange) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DMultirange) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DMultirange) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.IsEmpty()
+}
+
+// Max implements the Datum interface.
+func (d *DMultirange) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DMultirange) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return &DMultirange{typ: d.typ}, true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DMultirange) AmbiguousFormat() bool { return true }
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DMultirange) IsComposite() bool {
+	for _, r := range d.Ranges {
+		if r.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DMultirange) Format(ctx *FmtCtx) {
+	formatRangeLiteral(ctx, func(buf *bytes.Buffer) {
+		buf.WriteByte('{')
+		for i, r := range d.Ranges {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			r.writeText(ctx, buf)
+		}
+		buf.WriteByte('}')
+	})
+}
+
+// Size implements the Datum interface.
+func (d *DMultirange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	for _, r := range d.Ranges {
+		sz += r.Size()
+	}
+	return sz
+}
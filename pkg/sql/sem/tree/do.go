@@ -11,8 +11,9 @@ import (
 	"github.com/cockroachdb/errors"
 )
 
-// DoBlock represents a SQL DO statement. It can only exist as a top-level
-// statement.
+// DoBlock represents a SQL DO statement. It can exist as a top-level
+// statement or as a statement within a routine body, but cannot be nested
+// within another SQL statement.
 type DoBlock struct {
 	Code DoBlockBody
 }
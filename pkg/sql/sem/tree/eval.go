@@ -472,10 +472,69 @@ func initNonArrayToNonArrayConcatenation() {
 	}
 }
 
+// initRangeOperators initializes the set operators of ranges and multiranges
+// (+, - and *), and the << and >> operators, which return whether a range is
+// strictly left or right of another.
+func initRangeOperators() {
+	addSetOp := func(sym treebin.BinaryOperatorSymbol, typ *types.T, op func(left, right Datum) (Datum, error)) {
+		addBinOp(sym, &BinOp{
+			LeftType:   typ,
+			RightType:  typ,
+			ReturnType: typ,
+			EvalOp:     &RangeSetOp{Op: op},
+			Volatility: volatility.Immutable,
+		})
+	}
+	for i, rangeTyp := range types.RangeTypes {
+		multirangeTyp := types.MultirangeTypes[i]
+		addSetOp(treebin.Plus, rangeTyp, func(left, right Datum) (Datum, error) {
+			return MustBeDRange(left).Union(MustBeDRange(right))
+		})
+		addSetOp(treebin.Minus, rangeTyp, func(left, right Datum) (Datum, error) {
+			return MustBeDRange(left).Difference(MustBeDRange(right))
+		})
+		addSetOp(treebin.Mult, rangeTyp, func(left, right Datum) (Datum, error) {
+			return MustBeDRange(left).Intersect(MustBeDRange(right))
+		})
+		addSetOp(treebin.Plus, multirangeTyp, func(left, right Datum) (Datum, error) {
+			return MustBeDMultirange(left).Union(MustBeDMultirange(right))
+		})
+		addSetOp(treebin.Minus, multirangeTyp, func(left, right Datum) (Datum, error) {
+			return MustBeDMultirange(left).Difference(MustBeDMultirange(right))
+		})
+		addSetOp(treebin.Mult, multirangeTyp, func(left, right Datum) (Datum, error) {
+			return MustBeDMultirange(left).Intersect(MustBeDMultirange(right))
+		})
+		for _, left := range []*types.T{rangeTyp, multirangeTyp} {
+			for _, right := range []*types.T{rangeTyp, multirangeTyp} {
+				addBinOp(treebin.LShift, &BinOp{
+					LeftType:   left,
+					RightType:  right,
+					ReturnType: types.Bool,
+					EvalOp: &CompareRangeOp{Op: func(left, right Datum) bool {
+						return rangeOrMultirangeSpan(left).StrictlyLeftOf(rangeOrMultirangeSpan(right))
+					}},
+					Volatility: volatility.Immutable,
+				})
+				addBinOp(treebin.RShift, &BinOp{
+					LeftType:   left,
+					RightType:  right,
+					ReturnType: types.Bool,
+					EvalOp: &CompareRangeOp{Op: func(left, right Datum) bool {
+						return rangeOrMultirangeSpan(left).StrictlyRightOf(rangeOrMultirangeSpan(right))
+					}},
+					Volatility: volatility.Immutable,
+				})
+			}
+		}
+	}
+}
+
 func init() {
 	initArrayElementConcatenation()
 	initArrayToArrayConcatenation()
 	initNonArrayToNonArrayConcatenation()
+	initRangeOperators()
 }
 
 func init() {
@@ -1489,16 +1548,17 @@ func cmpOpFixups(
 		))
 	}
 
+	appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
+		s, ok := cmpOps[sym]
+		if !ok {
+			s = new(CmpOpOverloads)
+			cmpOps[sym] = s
+		}
+		s.overloads = append(s.overloads, cmpOp)
+	}
+
 	// Array equality comparisons.
 	for _, t := range append(types.Scalar, types.AnyEnum, types.AnyCollatedString) {
-		appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
-			s, ok := cmpOps[sym]
-			if !ok {
-				s = new(CmpOpOverloads)
-				cmpOps[sym] = s
-			}
-			s.overloads = append(s.overloads, cmpOp)
-		}
 		appendCmpOp(treecmp.EQ, &CmpOp{
 			LeftType:   types.MakeArray(t),
 			RightType:  types.MakeArray(t),
@@ -1526,6 +1586,17 @@ func cmpOpFixups(
 		})
 	}
 
+	// Range and multirange comparisons.
+	for _, t := range append(types.RangeTypes, types.MultirangeTypes...) {
+		appendCmpOp(treecmp.EQ, makeEqFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.LT, makeLtFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.LE, makeLeFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.IsNotDistinctFrom, makeIsFn(t, t, volatility.Immutable))
+	}
+	for i, rangeTyp := range types.RangeTypes {
+		addRangeComparisonOperators(appendCmpOp, rangeTyp, types.MultirangeTypes[i])
+	}
+
 	for _, overloads := range cmpOps {
 		_ = overloads.ForEachCmpOp(func(op *CmpOp) error {
 			op.types = ParamTypes{{"left", op.LeftType}, {"right", op.RightType}}
@@ -2116,6 +2187,85 @@ func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bo
 	}
 }
 
+// rangeOrMultirangeSpan returns the given range, or the smallest range that
+// contains every value of the given multirange.
+func rangeOrMultirangeSpan(d Datum) *DRange {
+	if m, ok := AsDMultirange(d); ok {
+		return m.Span()
+	}
+	return MustBeDRange(d)
+}
+
+// addRangeComparisonOperators adds the comparison operators other than the
+// ordering operators for the given range type and the corresponding
+// multirange type.
+func addRangeComparisonOperators(
+	appendCmpOp func(treecmp.ComparisonOperatorSymbol, *CmpOp), rangeTyp, multirangeTyp *types.T,
+) {
+	subtype := rangeTyp.RangeSubtype()
+	add := func(sym treecmp.ComparisonOperatorSymbol, left, right *types.T, op func(left, right Datum) bool) {
+		appendCmpOp(sym, &CmpOp{
+			LeftType:   left,
+			RightType:  right,
+			EvalOp:     &CompareRangeOp{Op: op},
+			Volatility: volatility.Immutable,
+		})
+	}
+	// addContains adds the @> operator and the commuted <@ operator.
+	addContains := func(left, right *types.T, contains func(left, right Datum) bool) {
+		add(treecmp.Contains, left, right, contains)
+		add(treecmp.ContainedBy, right, left, func(left, right Datum) bool {
+			return contains(right, left)
+		})
+	}
+	addContains(rangeTyp, subtype, func(left, right Datum) bool {
+		return MustBeDRange(left).ContainsValue(right)
+	})
+	addContains(rangeTyp, rangeTyp, func(left, right Datum) bool {
+		return MustBeDRange(left).ContainsRange(MustBeDRange(right))
+	})
+	addContains(rangeTyp, multirangeTyp, func(left, right Datum) bool {
+		return MustBeDRange(left).ContainsRange(MustBeDMultirange(right).Span())
+	})
+	addContains(multirangeTyp, subtype, func(left, right Datum) bool {
+		return MustBeDMultirange(left).ContainsValue(right)
+	})
+	addContains(multirangeTyp, rangeTyp, func(left, right Datum) bool {
+		return MustBeDMultirange(left).ContainsRange(MustBeDRange(right))
+	})
+	addContains(multirangeTyp, multirangeTyp, func(left, right Datum) bool {
+		return MustBeDMultirange(left).ContainsMultirange(MustBeDMultirange(right))
+	})
+
+	add(treecmp.Overlaps, rangeTyp, rangeTyp, func(left, right Datum) bool {
+		return MustBeDRange(left).Overlaps(MustBeDRange(right))
+	})
+	add(treecmp.Overlaps, rangeTyp, multirangeTyp, func(left, right Datum) bool {
+		return MustBeDMultirange(right).OverlapsRange(MustBeDRange(left))
+	})
+	add(treecmp.Overlaps, multirangeTyp, rangeTyp, func(left, right Datum) bool {
+		return MustBeDMultirange(left).OverlapsRange(MustBeDRange(right))
+	})
+	add(treecmp.Overlaps, multirangeTyp, multirangeTyp, func(left, right Datum) bool {
+		return MustBeDMultirange(left).OverlapsMultirange(MustBeDMultirange(right))
+	})
+
+	// The remaining operators only depend on the outer bounds of multiranges.
+	for _, left := range []*types.T{rangeTyp, multirangeTyp} {
+		for _, right := range []*types.T{rangeTyp, multirangeTyp} {
+			add(treecmp.NotExtendRight, left, right, func(left, right Datum) bool {
+				return rangeOrMultirangeSpan(left).NotExtendRightOf(rangeOrMultirangeSpan(right))
+			})
+			add(treecmp.NotExtendLeft, left, right, func(left, right Datum) bool {
+				return rangeOrMultirangeSpan(left).NotExtendLeftOf(rangeOrMultirangeSpan(right))
+			})
+			add(treecmp.Adjacent, left, right, func(left, right Datum) bool {
+				return rangeOrMultirangeSpan(left).Adjacent(rangeOrMultirangeSpan(right))
+			})
+		}
+	}
+}
+
 // This map contains the inverses for operators in the CmpOps map that have
 // inverses.
 var cmpOpsInverse map[treecmp.ComparisonOperatorSymbol]treecmp.ComparisonOperatorSymbol
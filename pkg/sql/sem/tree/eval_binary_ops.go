@@ -27,6 +27,16 @@ type CompareBox2DOp struct {
 	Op func(left, right Datum) bool
 }
 
+// CompareRangeOp is a BinaryEvalOp.
+type CompareRangeOp struct {
+	Op func(left, right Datum) bool
+}
+
+// RangeSetOp is a BinaryEvalOp.
+type RangeSetOp struct {
+	Op func(left, right Datum) (Datum, error)
+}
+
 // InTupleOp is a BinaryEvalOp.
 type InTupleOp struct{}
 
@@ -32,6 +32,7 @@ type ExprEvaluator interface {
 	EvalComparisonExpr(context.Context, *ComparisonExpr) (Datum, error)
 	EvalDefaultVal(context.Context, *DefaultVal) (Datum, error)
 	EvalFuncExpr(context.Context, *FuncExpr) (Datum, error)
+	EvalGroupingExpr(context.Context, *GroupingExpr) (Datum, error)
 	EvalIfErrExpr(context.Context, *IfErrExpr) (Datum, error)
 	EvalIfExpr(context.Context, *IfExpr) (Datum, error)
 	EvalIndexedVar(context.Context, *IndexedVar) (Datum, error)
@@ -207,6 +208,11 @@ func (node *DJsonpath) Eval(ctx context.Context, v ExprEvaluator) (Datum, error)
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DMultirange) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DOid) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -227,6 +233,11 @@ func (node *DPGVector) Eval(ctx context.Context, v ExprEvaluator) (Datum, error)
 	return node, nil
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *DRange) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return node, nil
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *DString) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return node, nil
@@ -287,6 +298,11 @@ func (node *FuncExpr) Eval(ctx context.Context, v ExprEvaluator) (Datum, error)
 	return v.EvalFuncExpr(ctx, node)
 }
 
+// Eval is part of the TypedExpr interface.
+func (node *GroupingExpr) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
+	return v.EvalGroupingExpr(ctx, node)
+}
+
 // Eval is part of the TypedExpr interface.
 func (node *IfErrExpr) Eval(ctx context.Context, v ExprEvaluator) (Datum, error) {
 	return v.EvalIfErrExpr(ctx, node)
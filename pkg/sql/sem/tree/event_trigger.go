@@ -0,0 +1,170 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// The events on which an event trigger can fire.
+const (
+	// EventTriggerDDLCommandStart fires before a DDL command is executed.
+	EventTriggerDDLCommandStart = "ddl_command_start"
+	// EventTriggerDDLCommandEnd fires after a DDL command has been executed,
+	// before the transaction commits.
+	EventTriggerDDLCommandEnd = "ddl_command_end"
+	// EventTriggerSQLDrop fires just before ddl_command_end for commands that
+	// drop database objects.
+	EventTriggerSQLDrop = "sql_drop"
+)
+
+// IsValidEventTriggerEvent returns true if the given event is one on which an
+// event trigger can fire.
+func IsValidEventTriggerEvent(event string) bool {
+	switch event {
+	case EventTriggerDDLCommandStart, EventTriggerDDLCommandEnd, EventTriggerSQLDrop:
+		return true
+	}
+	return false
+}
+
+// eventTriggerUnsupportedTagWords lists words which, when present in a command
+// tag, indicate that the command targets a shared or cluster-level object and
+// therefore does not fire event triggers. This matches Postgres, where event
+// triggers do not fire for commands on databases, roles, tablespaces, or event
+// triggers themselves.
+var eventTriggerUnsupportedTagWords = []string{
+	"DATABASE", "EVENT TRIGGER", "ROLE", "USER", "CHANGEFEED", "EXTERNAL CONNECTION",
+	"STATISTICS", "SCHEDULE", "VIRTUAL CLUSTER", "TENANT", "JOB", "TABLESPACE",
+}
+
+// CheckEventTriggerTag returns an error if DDL commands with the given tag do
+// not fire event triggers.
+func CheckEventTriggerTag(tag string) error {
+	upper := strings.ToUpper(tag)
+	if !strings.HasPrefix(upper, "CREATE ") && !strings.HasPrefix(upper, "ALTER ") &&
+		!strings.HasPrefix(upper, "DROP ") && !strings.HasPrefix(upper, "COMMENT ") &&
+		upper != "REFRESH MATERIALIZED VIEW" {
+		return pgerror.Newf(pgcode.Syntax,
+			"filter value %q not recognized for filter variable \"tag\"", tag)
+	}
+	for _, w := range eventTriggerUnsupportedTagWords {
+		if strings.Contains(upper, w) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"event triggers are not supported for %s", upper)
+		}
+	}
+	return nil
+}
+
+// EventTriggerSupportsTag returns true if DDL commands with the given tag fire
+// event triggers.
+func EventTriggerSupportsTag(tag string) bool {
+	return CheckEventTriggerTag(tag) == nil
+}
+
+// CreateEventTrigger represents a CREATE EVENT TRIGGER statement.
+type CreateEventTrigger struct {
+	Name  Name
+	Event Name
+	// Tags is the list of command tags in the WHEN TAG IN (...) filter. If it is
+	// empty, the trigger fires for all supported commands.
+	Tags     []string
+	FuncName *UnresolvedName
+}
+
+var _ Statement = &CreateEventTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateEventTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE EVENT TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Event)
+	if len(node.Tags) > 0 {
+		ctx.WriteString(" WHEN TAG IN (")
+		for i, tag := range node.Tags {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatStringConstant(tag)
+		}
+		ctx.WriteString(")")
+	}
+	ctx.WriteString(" EXECUTE FUNCTION ")
+	ctx.FormatNode(node.FuncName)
+	ctx.WriteString("()")
+}
+
+// DropEventTrigger represents a DROP EVENT TRIGGER statement.
+type DropEventTrigger struct {
+	Name         Name
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropEventTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropEventTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP EVENT TRIGGER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// AlterEventTriggerCmd is the kind of change made by an ALTER EVENT TRIGGER
+// statement.
+type AlterEventTriggerCmd uint8
+
+const (
+	// AlterEventTriggerEnable enables the event trigger.
+	AlterEventTriggerEnable AlterEventTriggerCmd = iota
+	// AlterEventTriggerDisable disables the event trigger.
+	AlterEventTriggerDisable
+	// AlterEventTriggerRename renames the event trigger.
+	AlterEventTriggerRename
+	// AlterEventTriggerOwner changes the owner of the event trigger.
+	AlterEventTriggerOwner
+)
+
+// AlterEventTrigger represents an ALTER EVENT TRIGGER statement.
+type AlterEventTrigger struct {
+	Name Name
+	Cmd  AlterEventTriggerCmd
+	// NewName is set for ALTER EVENT TRIGGER ... RENAME TO.
+	NewName Name
+	// Owner is set for ALTER EVENT TRIGGER ... OWNER TO.
+	Owner RoleSpec
+}
+
+var _ Statement = &AlterEventTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterEventTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER EVENT TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	switch node.Cmd {
+	case AlterEventTriggerEnable:
+		ctx.WriteString(" ENABLE")
+	case AlterEventTriggerDisable:
+		ctx.WriteString(" DISABLE")
+	case AlterEventTriggerRename:
+		ctx.WriteString(" RENAME TO ")
+		ctx.FormatNode(&node.NewName)
+	case AlterEventTriggerOwner:
+		ctx.WriteString(" OWNER TO ")
+		ctx.FormatNode(&node.Owner)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+// Merge represents a MERGE statement.
+type Merge struct {
+	With      *With
+	Table     TableExpr
+	Source    TableExpr
+	On        Expr
+	Whens     MergeWhens
+	Returning ReturningClause
+}
+
+// Format implements the NodeFormatter interface.
+func (node *Merge) Format(ctx *FmtCtx) {
+	ctx.FormatNode(node.With)
+	ctx.WriteString("MERGE INTO ")
+	ctx.FormatNode(node.Table)
+	ctx.WriteString(" USING ")
+	ctx.FormatNode(node.Source)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.On)
+	for _, when := range node.Whens {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(when)
+	}
+	if HasReturningClause(node.Returning) {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Returning)
+	}
+}
+
+// MergeMatch indicates which rows a WHEN clause of a MERGE statement applies
+// to.
+type MergeMatch int8
+
+const (
+	// MergeMatched applies to target rows joined with a source row.
+	MergeMatched MergeMatch = iota
+	// MergeNotMatchedByTarget applies to source rows that are not joined with
+	// any target row.
+	MergeNotMatchedByTarget
+	// MergeNotMatchedBySource applies to target rows that are not joined with
+	// any source row.
+	MergeNotMatchedBySource
+)
+
+// String implements the fmt.Stringer interface.
+func (m MergeMatch) String() string {
+	switch m {
+	case MergeMatched:
+		return "MATCHED"
+	case MergeNotMatchedByTarget:
+		return "NOT MATCHED"
+	case MergeNotMatchedBySource:
+		return "NOT MATCHED BY SOURCE"
+	}
+	return "<unknown>"
+}
+
+// MergeAction is the action of a WHEN clause of a MERGE statement.
+type MergeAction int8
+
+const (
+	// MergeDoNothing leaves the row as is.
+	MergeDoNothing MergeAction = iota
+	// MergeUpdate updates the target row.
+	MergeUpdate
+	// MergeDelete deletes the target row.
+	MergeDelete
+	// MergeInsert inserts a row into the target table.
+	MergeInsert
+)
+
+// MergeWhen represents a WHEN clause of a MERGE statement.
+type MergeWhen struct {
+	Match MergeMatch
+	// Cond is the optional AND condition of the clause.
+	Cond   Expr
+	Action MergeAction
+	// Exprs is the SET list of an UPDATE action.
+	Exprs UpdateExprs
+	// Columns and Values are the target columns and the values of an INSERT
+	// action. Values is nil for INSERT DEFAULT VALUES.
+	Columns NameList
+	Values  Exprs
+}
+
+// MergeWhens represents the WHEN clauses of a MERGE statement.
+type MergeWhens []*MergeWhen
+
+// Format implements the NodeFormatter interface.
+func (node *MergeWhen) Format(ctx *FmtCtx) {
+	ctx.WriteString("WHEN ")
+	ctx.WriteString(node.Match.String())
+	if node.Cond != nil {
+		ctx.WriteString(" AND ")
+		ctx.FormatNode(node.Cond)
+	}
+	ctx.WriteString(" THEN ")
+	switch node.Action {
+	case MergeDoNothing:
+		ctx.WriteString("DO NOTHING")
+	case MergeUpdate:
+		ctx.WriteString("UPDATE SET ")
+		ctx.FormatNode(&node.Exprs)
+	case MergeDelete:
+		ctx.WriteString("DELETE")
+	case MergeInsert:
+		ctx.WriteString("INSERT")
+		if node.Columns != nil {
+			ctx.WriteString(" (")
+			ctx.FormatNode(&node.Columns)
+			ctx.WriteByte(')')
+		}
+		if node.Values == nil {
+			ctx.WriteString(" DEFAULT VALUES")
+		} else {
+			ctx.WriteString(" VALUES (")
+			ctx.FormatNode(&node.Values)
+			ctx.WriteByte(')')
+		}
+	}
+}
@@ -260,6 +260,10 @@ type Overload struct {
 	// Body is the SQL string body of a function. It can be set even if Type is
 	// BuiltinRoutine if a builtin function is defined using a SQL string.
 	Body string
+	// Aggregate is set for user-defined aggregates when the full descriptor
+	// is available (see UDFContainsOnlySignature). Only InitCond and the
+	// resolved support functions and state type are set.
+	Aggregate *RoutineAggregate
 	// UDFContainsOnlySignature is only set to true for Overload signatures cached
 	// in a Schema descriptor, which means that the full UDF descriptor need to be
 	// fetched to get more info, e.g. function Body.
@@ -0,0 +1,230 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tree
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func makeMalformedRangeError(s string, detail string) error {
+	return errors.WithDetail(
+		pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed range literal: %q", s), detail,
+	)
+}
+
+func makeMalformedMultirangeError(s string, detail string) error {
+	return errors.WithDetail(
+		pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed multirange literal: %q", s), detail,
+	)
+}
+
+// rangeParseState is the state of the parser of the text representation of
+// a range, which mirrors range_parse in Postgres.
+type rangeParseState struct {
+	s string
+	i int
+}
+
+func (p *rangeParseState) eatWhitespace() {
+	for p.i < len(p.s) && unicode.IsSpace(rune(p.s[p.i])) {
+		p.i++
+	}
+}
+
+func (p *rangeParseState) eof() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *rangeParseState) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+// parseEmpty consumes the "empty" keyword and returns true if it is next in
+// the input.
+func (p *rangeParseState) parseEmpty() bool {
+	const empty = "empty"
+	if len(p.s)-p.i >= len(empty) && strings.EqualFold(p.s[p.i:p.i+len(empty)], empty) {
+		p.i += len(empty)
+		return true
+	}
+	return false
+}
+
+// parseBound parses the text of a bound, which ends at an unquoted comma or
+// closing bracket or parenthesis. Double quotes and backslashes can be used to
+// include these characters. The returned bool is false if the bound is
+// infinite, which is represented by an unquoted empty string.
+func (p *rangeParseState) parseBound() (string, bool, error) {
+	if c := p.peek(); c == ',' || c == ')' || c == ']' {
+		return "", false, nil
+	}
+	var buf bytes.Buffer
+	inQuote := false
+	for {
+		if p.eof() {
+			return "", false, errors.New("Unexpected end of input.")
+		}
+		c := p.s[p.i]
+		if !inQuote && (c == ',' || c == ')' || c == ']') {
+			return buf.String(), true, nil
+		}
+		p.i++
+		switch c {
+		case '\\':
+			if p.eof() {
+				return "", false, errors.New("Unexpected end of input.")
+			}
+			buf.WriteByte(p.s[p.i])
+			p.i++
+		case '"':
+			if inQuote && p.peek() == '"' {
+				// A doubled quote within quotes is a literal quote.
+				buf.WriteByte('"')
+				p.i++
+			} else {
+				inQuote = !inQuote
+			}
+		default:
+			buf.WriteByte(c)
+		}
+	}
+}
+
+// parseRange parses a range at the current position of the input. The range
+// is either the "empty" keyword or two bounds enclosed in brackets or
+// parentheses.
+func (p *rangeParseState) parseRange(
+	ctx ParseContext, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	if p.parseEmpty() {
+		return NewEmptyDRange(t), false, nil
+	}
+	var lower, upper RangeBound
+	switch p.peek() {
+	case '[':
+		lower.Inclusive = true
+	case '(':
+	default:
+		return nil, false, errors.New("Missing left parenthesis or bracket.")
+	}
+	p.i++
+	parseBoundValue := func() (Datum, error) {
+		s, ok, err := p.parseBound()
+		if err != nil || !ok {
+			return nil, err
+		}
+		d, dep, err := ParseAndRequireString(t.RangeSubtype(), s, ctx)
+		if err != nil {
+			return nil, err
+		}
+		dependsOnContext = dependsOnContext || dep
+		return d, nil
+	}
+	var err error
+	if lower.Val, err = parseBoundValue(); err != nil {
+		return nil, false, err
+	}
+	if p.peek() != ',' {
+		return nil, false, errors.New("Missing comma after lower bound.")
+	}
+	p.i++
+	if upper.Val, err = parseBoundValue(); err != nil {
+		return nil, false, err
+	}
+	switch p.peek() {
+	case ']':
+		upper.Inclusive = true
+	case ')':
+	default:
+		return nil, false, errors.New("Too many commas.")
+	}
+	p.i++
+	r, err := NewDRange(t, lower, upper)
+	return r, dependsOnContext, err
+}
+
+// ParseDRangeFromString parses the text representation of a range of the
+// given range type, for example '[1,10)' or 'empty'.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s}
+	p.eatWhitespace()
+	r, dependsOnContext, err := p.parseRange(ctx, t)
+	if err != nil {
+		if pgerror.HasCandidateCode(err) {
+			return nil, false, err
+		}
+		return nil, false, makeMalformedRangeError(s, err.Error())
+	}
+	p.eatWhitespace()
+	if !p.eof() {
+		return nil, false, makeMalformedRangeError(s, "Junk after right parenthesis or bracket.")
+	}
+	return r, dependsOnContext, nil
+}
+
+// ParseDMultirangeFromString parses the text representation of a multirange
+// of the given multirange type, for example '{[1,3), [5,7)}'.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDMultirangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DMultirange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s}
+	p.eatWhitespace()
+	if p.peek() != '{' {
+		return nil, false, makeMalformedMultirangeError(s, "Missing left brace.")
+	}
+	p.i++
+	p.eatWhitespace()
+	var ranges []*DRange
+	if p.peek() == '}' {
+		p.i++
+	} else {
+		for {
+			r, dep, err := p.parseRange(ctx, t.MultirangeContents())
+			if err != nil {
+				if pgerror.HasCandidateCode(err) {
+					return nil, false, err
+				}
+				return nil, false, makeMalformedMultirangeError(s, err.Error())
+			}
+			dependsOnContext = dependsOnContext || dep
+			ranges = append(ranges, r)
+			p.eatWhitespace()
+			if p.peek() == '}' {
+				p.i++
+				break
+			}
+			if p.peek() != ',' {
+				return nil, false, makeMalformedMultirangeError(s, "Expected comma or end of multirange.")
+			}
+			p.i++
+			p.eatWhitespace()
+		}
+	}
+	p.eatWhitespace()
+	if !p.eof() {
+		return nil, false, makeMalformedMultirangeError(s, "Junk after closing right brace.")
+	}
+	m, err := NewDMultirange(t, ranges)
+	return m, dependsOnContext, err
+}
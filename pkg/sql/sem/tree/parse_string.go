@@ -66,6 +66,10 @@ func ParseAndRequireString(
 		d, err = ParseDPGLSN(s)
 	case types.PGVectorFamily:
 		d, err = ParseDPGVector(s)
+	case types.RangeFamily:
+		d, dependsOnContext, err = ParseDRangeFromString(ctx, s, t)
+	case types.MultirangeFamily:
+		d, dependsOnContext, err = ParseDMultirangeFromString(ctx, s, t)
 	case types.RefCursorFamily:
 		d = NewDRefCursor(s)
 	case types.Box2DFamily:
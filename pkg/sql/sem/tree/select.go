@@ -598,11 +598,12 @@ func (ih *IndexFlags) zigzagForced() bool {
 // AliasedTableExpr represents a table expression coupled with an optional
 // alias.
 type AliasedTableExpr struct {
-	Expr       TableExpr
-	IndexFlags *IndexFlags
-	Ordinality bool
-	Lateral    bool
-	As         AliasClause
+	Expr        TableExpr
+	IndexFlags  *IndexFlags
+	Ordinality  bool
+	Lateral     bool
+	As          AliasClause
+	TableSample *TableSample
 }
 
 // Format implements the NodeFormatter interface.
@@ -621,6 +622,59 @@ func (node *AliasedTableExpr) Format(ctx *FmtCtx) {
 		ctx.WriteString(" AS ")
 		ctx.FormatNode(&node.As)
 	}
+	if node.TableSample != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.TableSample)
+	}
+}
+
+// TableSampleMethod is the sampling method of a TABLESAMPLE clause.
+type TableSampleMethod uint8
+
+const (
+	// TableSampleNone indicates that the table is not sampled.
+	TableSampleNone TableSampleMethod = iota
+	// TableSampleBernoulli selects each row of the table independently with
+	// the given probability.
+	TableSampleBernoulli
+	// TableSampleSystem selects each range of the table independently with the
+	// given probability, and returns all rows of the selected ranges.
+	TableSampleSystem
+)
+
+var tableSampleMethodName = [...]string{
+	TableSampleNone:      "",
+	TableSampleBernoulli: "BERNOULLI",
+	TableSampleSystem:    "SYSTEM",
+}
+
+func (m TableSampleMethod) String() string {
+	return tableSampleMethodName[m]
+}
+
+// TableSample represents a TABLESAMPLE clause:
+//
+//	TABLESAMPLE { BERNOULLI | SYSTEM } ( <percent> ) [ REPEATABLE ( <seed> ) ]
+type TableSample struct {
+	Method  TableSampleMethod
+	Percent Expr
+	// Repeatable is the seed of the REPEATABLE clause, or nil if there is no
+	// REPEATABLE clause.
+	Repeatable Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TableSample) Format(ctx *FmtCtx) {
+	ctx.WriteString("TABLESAMPLE ")
+	ctx.WriteString(node.Method.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(node.Percent)
+	ctx.WriteByte(')')
+	if node.Repeatable != nil {
+		ctx.WriteString(" REPEATABLE (")
+		ctx.FormatNode(node.Repeatable)
+		ctx.WriteByte(')')
+	}
 }
 
 // ParenTableExpr represents a parenthesized TableExpr.
@@ -789,6 +843,57 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetType is the type of a GroupingSet.
+type GroupingSetType int
+
+const (
+	// RollupGroupingSet represents ROLLUP (...).
+	RollupGroupingSet GroupingSetType = iota
+	// CubeGroupingSet represents CUBE (...).
+	CubeGroupingSet
+	// GroupingSetsGroupingSet represents GROUPING SETS (...).
+	GroupingSetsGroupingSet
+)
+
+var groupingSetTypeName = [...]string{
+	RollupGroupingSet:       "ROLLUP",
+	CubeGroupingSet:         "CUBE",
+	GroupingSetsGroupingSet: "GROUPING SETS",
+}
+
+// String implements the fmt.Stringer interface.
+func (t GroupingSetType) String() string {
+	return groupingSetTypeName[t]
+}
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item of a GROUP BY
+// clause. Each of its elements is an expression, a parenthesized list of
+// expressions represented by a *Tuple, or, for GROUPING SETS, a nested
+// *GroupingSet.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.Type.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
+// HasGroupingSets returns true if the GROUP BY clause contains a ROLLUP, CUBE
+// or GROUPING SETS item.
+func (node GroupBy) HasGroupingSets() bool {
+	for _, e := range node {
+		if _, ok := e.(*GroupingSet); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
@@ -78,6 +78,12 @@ func SampleDatum(t *types.T) Datum {
 		return NewDOidWithType(1009, t)
 	case types.PGLSNFamily:
 		return NewDPGLSN(0x1000000100)
+	case types.RangeFamily:
+		r, _ := NewDRange(t, RangeBound{Val: SampleDatum(t.RangeSubtype()), Inclusive: true}, RangeBound{})
+		return r
+	case types.MultirangeFamily:
+		m, _ := NewDMultirange(t, []*DRange{SampleDatum(t.MultirangeContents()).(*DRange)})
+		return m
 	case types.RefCursorFamily:
 		return NewDRefCursor("Wheezer")
 	case types.Box2DFamily:
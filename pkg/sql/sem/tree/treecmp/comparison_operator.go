@@ -65,6 +65,9 @@ const (
 	JSONAllExists
 	Overlaps
 	TSMatches
+	NotExtendRight
+	NotExtendLeft
+	Adjacent
 
 	// The following operators will always be used with an associated SubOperator.
 	// If Go had algebraic data types they would be defined in a self-contained
@@ -117,6 +120,9 @@ var comparisonOpName = [...]string{
 	JSONAllExists:     "?&",
 	Overlaps:          "&&",
 	TSMatches:         "@@",
+	NotExtendRight:    "&<",
+	NotExtendLeft:     "&>",
+	Adjacent:          "-|-",
 	Any:               "ANY",
 	Some:              "SOME",
 	All:               "ALL",
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
+	"github.com/lib/pq/oid"
 	"golang.org/x/text/language"
 )
 
@@ -696,10 +697,10 @@ func (expr *CastExpr) TypeCheck(
 	if err != nil {
 		return nil, err
 	}
-	if exprType.Identical(types.Trigger) {
+	if err := CheckTriggerType(exprType); err != nil {
 		// Trigger is not allowed in casts. This happens after resolving the cast to
 		// ensure that we return an "invalid cast" error when postgres does.
-		return nil, CannotAcceptTriggerErr
+		return nil, err
 	}
 	expr.Expr = typedSubExpr
 	expr.Type = exprType
@@ -781,8 +782,8 @@ func (expr *AnnotateTypeExpr) TypeCheck(
 	if err != nil {
 		return nil, err
 	}
-	if annotateType.Identical(types.Trigger) {
-		return nil, CannotAcceptTriggerErr
+	if err := CheckTriggerType(annotateType); err != nil {
+		return nil, err
 	}
 	if err = CheckUnsupportedType(ctx, semaCtx, annotateType); err != nil {
 		return nil, err
@@ -1505,6 +1506,32 @@ func (expr *FuncExpr) TypeCheck(
 	return expr, nil
 }
 
+// TypeCheck implements the Expr interface.
+func (expr *GroupingExpr) TypeCheck(
+	ctx context.Context, semaCtx *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	if semaCtx != nil && semaCtx.Properties.IsSet(RejectAggregates) {
+		return nil, pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in %s", semaCtx.Properties.required.context)
+	}
+	for i, e := range expr.Exprs {
+		typedExpr, err := e.TypeCheck(ctx, semaCtx, types.AnyElement)
+		if err != nil {
+			return nil, err
+		}
+		expr.Exprs[i] = typedExpr
+	}
+	expr.typ = types.Int
+	return expr, nil
+}
+
+// TypeCheck implements the Expr interface.
+func (expr *GroupingSet) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, pgerror.Newf(pgcode.Syntax, "%s is only allowed in GROUP BY", expr.Type)
+}
+
 // TypeCheck implements the Expr interface.
 func (expr *IfErrExpr) TypeCheck(
 	ctx context.Context, semaCtx *SemaContext, desired *types.T,
@@ -2149,6 +2176,18 @@ func (d *DPGVector) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (Ty
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DMultirange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DGeography) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -3774,6 +3813,23 @@ var CannotAcceptTriggerErr = pgerror.New(pgcode.FeatureNotSupported,
 	"cannot accept a value of type trigger",
 )
 
+var CannotAcceptEventTriggerErr = pgerror.New(pgcode.FeatureNotSupported,
+	"cannot accept a value of type event_trigger",
+)
+
+// CheckTriggerType returns an error if the given type is the trigger or
+// event_trigger pseudo-type, which are only allowed as the return type of a
+// trigger function.
+func CheckTriggerType(typ *types.T) error {
+	if typ.Family() != types.TriggerFamily {
+		return nil
+	}
+	if typ.Oid() == oid.T_event_trigger {
+		return CannotAcceptEventTriggerErr
+	}
+	return CannotAcceptTriggerErr
+}
+
 // checkComparison checks whether the given types are or contain the
 // given family, which is invalid for comparison. We don't simply remove
 // the relevant comparison overloads because we rely on their existence in
@@ -339,6 +339,28 @@ func (expr *IfExpr) Walk(v Visitor) Expr {
 	return expr
 }
 
+// Walk implements the Expr interface.
+func (expr *GroupingExpr) Walk(v Visitor) Expr {
+	exprs, changed := walkExprSlice(v, expr.Exprs)
+	if changed {
+		exprCopy := *expr
+		exprCopy.Exprs = exprs
+		return &exprCopy
+	}
+	return expr
+}
+
+// Walk implements the Expr interface.
+func (expr *GroupingSet) Walk(v Visitor) Expr {
+	exprs, changed := walkExprSlice(v, expr.Exprs)
+	if changed {
+		exprCopy := *expr
+		exprCopy.Exprs = exprs
+		return &exprCopy
+	}
+	return expr
+}
+
 // Walk implements the Expr interface.
 func (expr *IfErrExpr) Walk(v Visitor) Expr {
 	c, changedC := WalkExpr(v, expr.Cond)
@@ -530,9 +552,27 @@ func (expr *Subquery) WalkTableExpr(v Visitor) TableExpr {
 // WalkTableExpr implements the TableExpr interface.
 func (expr *AliasedTableExpr) WalkTableExpr(v Visitor) TableExpr {
 	newExpr, changed := walkTableExpr(v, expr.Expr)
-	if changed {
+	var sample *TableSample
+	if expr.TableSample != nil {
+		percent, changedPercent := WalkExpr(v, expr.TableSample.Percent)
+		repeatable, changedRepeatable := expr.TableSample.Repeatable, false
+		if repeatable != nil {
+			repeatable, changedRepeatable = WalkExpr(v, repeatable)
+		}
+		if changedPercent || changedRepeatable {
+			sample = &TableSample{
+				Method:     expr.TableSample.Method,
+				Percent:    percent,
+				Repeatable: repeatable,
+			}
+		}
+	}
+	if changed || sample != nil {
 		exprCopy := *expr
 		exprCopy.Expr = newExpr
+		if sample != nil {
+			exprCopy.TableSample = sample
+		}
 		return &exprCopy
 	}
 	return expr
@@ -760,6 +800,12 @@ func (expr *DPGLSN) Walk(_ Visitor) Expr { return expr }
 // Walk implements the Expr interface.
 func (expr *DPGVector) Walk(_ Visitor) Expr { return expr }
 
+// Walk implements the Expr interface.
+func (expr *DRange) Walk(_ Visitor) Expr { return expr }
+
+// Walk implements the Expr interface.
+func (expr *DMultirange) Walk(_ Visitor) Expr { return expr }
+
 // Walk implements the Expr interface.
 func (expr *DGeography) Walk(_ Visitor) Expr { return expr }
 
@@ -1456,6 +1502,63 @@ func (stmt *Insert) walkStmt(v Visitor) Statement {
 	return ret
 }
 
+// copyNode makes a copy of this Statement without recursing in any child Statements.
+func (stmt *Merge) copyNode() *Merge {
+	stmtCopy := *stmt
+	stmtCopy.Whens = make(MergeWhens, len(stmt.Whens))
+	for i, when := range stmt.Whens {
+		whenCopy := *when
+		whenCopy.Exprs = make(UpdateExprs, len(when.Exprs))
+		for j, e := range when.Exprs {
+			exprCopy := *e
+			whenCopy.Exprs[j] = &exprCopy
+		}
+		whenCopy.Values = append(Exprs(nil), when.Values...)
+		stmtCopy.Whens[i] = &whenCopy
+	}
+	return &stmtCopy
+}
+
+// walkStmt is part of the walkableStmt interface.
+func (stmt *Merge) walkStmt(v Visitor) Statement {
+	ret := stmt
+	copyOnce := func() {
+		if ret == stmt {
+			ret = stmt.copyNode()
+		}
+	}
+	if e, changed := WalkExpr(v, stmt.On); changed {
+		copyOnce()
+		ret.On = e
+	}
+	for i, when := range stmt.Whens {
+		if when.Cond != nil {
+			if e, changed := WalkExpr(v, when.Cond); changed {
+				copyOnce()
+				ret.Whens[i].Cond = e
+			}
+		}
+		for j, expr := range when.Exprs {
+			if e, changed := WalkExpr(v, expr.Expr); changed {
+				copyOnce()
+				ret.Whens[i].Exprs[j].Expr = e
+			}
+		}
+		for j, expr := range when.Values {
+			if e, changed := WalkExpr(v, expr); changed {
+				copyOnce()
+				ret.Whens[i].Values[j] = e
+			}
+		}
+	}
+	returning, changed := walkReturningClause(v, stmt.Returning)
+	if changed {
+		copyOnce()
+		ret.Returning = returning
+	}
+	return ret
+}
+
 // copyNode makes a copy of this Statement without recursing in any child Statements.
 func (stmt *CreateTable) copyNode() *CreateTable {
 	stmtCopy := *stmt
@@ -2060,6 +2163,7 @@ var _ walkableStmt = &DropTenant{}
 var _ walkableStmt = &Explain{}
 var _ walkableStmt = &Import{}
 var _ walkableStmt = &Insert{}
+var _ walkableStmt = &Merge{}
 var _ walkableStmt = &ParenSelect{}
 var _ walkableStmt = &Restore{}
 var _ walkableStmt = &SelectClause{}
@@ -84,6 +84,9 @@ type InternalExecutorOverride struct {
 	GrowStackSize bool
 	// DisablePlanGists, if true, overrides the disable_plan_gists session var.
 	DisablePlanGists bool
+	// AllowMaterializedViewMutations, if true, allows the statement to write
+	// into materialized views.
+	AllowMaterializedViewMutations bool
 }
 
 // NoSessionDataOverride is the empty InternalExecutorOverride which does not
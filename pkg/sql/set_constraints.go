@@ -0,0 +1,558 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/errors"
+)
+
+// constraintKey identifies a deferrable constraint. For foreign keys, tableID
+// is the origin (referencing) table.
+type constraintKey struct {
+	tableID descpb.ID
+	name    string
+}
+
+// constraintModes are the constraint check modes set with SET CONSTRAINTS in
+// the current transaction.
+type constraintModes struct {
+	// allSet is true if SET CONSTRAINTS ALL was executed, in which case
+	// allDeferred is the mode it set.
+	allSet      bool
+	allDeferred bool
+	// byConstraint contains the modes set for individual constraints since the
+	// last SET CONSTRAINTS ALL.
+	byConstraint map[constraintKey]bool
+}
+
+// isDeferred returns true if the check of the given constraint is deferred to
+// the end of the transaction.
+func (m *constraintModes) isDeferred(key constraintKey, initiallyDeferred bool) bool {
+	if deferred, ok := m.byConstraint[key]; ok {
+		return deferred
+	}
+	if m.allSet {
+		return m.allDeferred
+	}
+	return initiallyDeferred
+}
+
+// set records the mode of the given constraints, or of all the constraints if
+// keys is empty.
+func (m *constraintModes) set(keys []constraintKey, deferred bool) {
+	if len(keys) == 0 {
+		*m = constraintModes{allSet: true, allDeferred: deferred}
+		return
+	}
+	if m.byConstraint == nil {
+		m.byConstraint = make(map[constraintKey]bool, len(keys))
+	}
+	for _, k := range keys {
+		m.byConstraint[k] = deferred
+	}
+}
+
+// clone returns a copy of m which can be modified independently.
+func (m constraintModes) clone() constraintModes {
+	if m.byConstraint != nil {
+		byConstraint := make(map[constraintKey]bool, len(m.byConstraint))
+		for k, v := range m.byConstraint {
+			byConstraint[k] = v
+		}
+		m.byConstraint = byConstraint
+	}
+	return m
+}
+
+// maxDeferredConstraintKeys is the maximum number of violating keys recorded
+// for a deferred constraint. Beyond that, the whole constraint is validated.
+const maxDeferredConstraintKeys = 1000
+
+// pendingConstraint is a deferred constraint whose check found a violation in
+// the current transaction.
+type pendingConstraint struct {
+	constraintKey
+	// keys are the values of the constraint columns of the rows which violated
+	// the constraint (for foreign keys, of the origin columns). Since every
+	// write checks the constraint again, these are the only keys which can
+	// still violate it. keys is nil if validateAll is set.
+	keys []tree.Datums
+	// seen contains the encoded keys, to skip duplicate keys.
+	seen map[string]struct{}
+	// validateAll is set if the violating keys are not all known, in which
+	// case the whole constraint is validated.
+	validateAll bool
+}
+
+// add records a violating key. keyVals is nil if the key is not known.
+func (pc *pendingConstraint) add(keyVals tree.Datums) {
+	if pc.validateAll {
+		return
+	}
+	hasNull := false
+	for _, d := range keyVals {
+		hasNull = hasNull || d == tree.DNull
+	}
+	// Keys with NULLs only violate MATCH FULL foreign keys, which the full
+	// validation checks.
+	if keyVals == nil || hasNull || len(pc.keys) >= maxDeferredConstraintKeys {
+		*pc = pendingConstraint{constraintKey: pc.constraintKey, validateAll: true}
+		return
+	}
+	var buf strings.Builder
+	for _, d := range keyVals {
+		buf.WriteString(tree.AsStringWithFlags(d, tree.FmtParsable))
+		buf.WriteByte(0)
+	}
+	enc := buf.String()
+	if _, ok := pc.seen[enc]; ok {
+		return
+	}
+	if pc.seen == nil {
+		pc.seen = make(map[string]struct{})
+	}
+	pc.seen[enc] = struct{}{}
+	pc.keys = append(pc.keys, keyVals)
+}
+
+// deferredConstraintsState is the state of the deferrable constraints in the
+// current transaction.
+type deferredConstraintsState struct {
+	modes constraintModes
+	// pending contains the deferred constraints whose check found a violation
+	// in the current transaction. They are validated again when they become
+	// immediate or when the transaction commits.
+	pending map[constraintKey]*pendingConstraint
+}
+
+// takePending removes the pending constraints for which the given function
+// returns true and returns them, sorted to make the validation order
+// deterministic.
+func (s *deferredConstraintsState) takePending(
+	fn func(constraintKey) bool,
+) []*pendingConstraint {
+	var pcs []*pendingConstraint
+	for k, pc := range s.pending {
+		if fn(k) {
+			pcs = append(pcs, pc)
+			delete(s.pending, k)
+		}
+	}
+	sort.Slice(pcs, func(i, j int) bool {
+		if pcs[i].tableID != pcs[j].tableID {
+			return pcs[i].tableID < pcs[j].tableID
+		}
+		return pcs[i].name < pcs[j].name
+	})
+	return pcs
+}
+
+// deferredConstraints gives a planner access to the deferrable constraints
+// state of its transaction.
+type deferredConstraints interface {
+	// setConstraintModes implements SET CONSTRAINTS. keys is empty for SET
+	// CONSTRAINTS ALL. It returns the pending constraints which became
+	// immediate and must be validated.
+	setConstraintModes(keys []constraintKey, deferred bool) []*pendingConstraint
+	// deferCheck returns true if the check of the given constraint is deferred,
+	// in which case the constraint is validated later in the transaction.
+	// keyVals is the violating key, or nil if it is not known.
+	deferCheck(key constraintKey, initiallyDeferred bool, keyVals tree.Datums) bool
+}
+
+// emptyDeferredConstraints is the deferredConstraints of planners which are
+// not associated with a client session. All constraints are checked
+// immediately.
+type emptyDeferredConstraints struct{}
+
+func (emptyDeferredConstraints) setConstraintModes([]constraintKey, bool) []*pendingConstraint {
+	return nil
+}
+
+func (emptyDeferredConstraints) deferCheck(constraintKey, bool, tree.Datums) bool {
+	return false
+}
+
+// connExDeferredConstraintsAccessor is a deferredConstraints that delegates to
+// a connExecutor.
+type connExDeferredConstraintsAccessor struct {
+	ex *connExecutor
+}
+
+func (c connExDeferredConstraintsAccessor) setConstraintModes(
+	keys []constraintKey, deferred bool,
+) []*pendingConstraint {
+	if c.ex.executorType == executorTypeInternal {
+		return nil
+	}
+	s := &c.ex.extraTxnState.deferredConstraints
+	s.modes.set(keys, deferred)
+	if deferred {
+		return nil
+	}
+	return s.takePending(func(k constraintKey) bool {
+		return !s.modes.isDeferred(k, true /* initiallyDeferred */)
+	})
+}
+
+func (c connExDeferredConstraintsAccessor) deferCheck(
+	key constraintKey, initiallyDeferred bool, keyVals tree.Datums,
+) bool {
+	if c.ex.executorType == executorTypeInternal {
+		return false
+	}
+	s := &c.ex.extraTxnState.deferredConstraints
+	if !s.modes.isDeferred(key, initiallyDeferred) {
+		return false
+	}
+	pc, ok := s.pending[key]
+	if !ok {
+		if s.pending == nil {
+			s.pending = make(map[constraintKey]*pendingConstraint)
+		}
+		pc = &pendingConstraint{constraintKey: key}
+		s.pending[key] = pc
+	}
+	pc.add(keyVals)
+	return true
+}
+
+// maybeDeferConstraintCheck is called with the error generated by a failed
+// uniqueness or foreign key check. If the check is of a deferrable constraint
+// which is currently deferred, the violation is ignored and nil is returned.
+func (p *planner) maybeDeferConstraintCheck(err error) error {
+	var dErr *exec.DeferrableCheckError
+	if !errors.As(err, &dErr) {
+		return err
+	}
+	key := constraintKey{tableID: descpb.ID(dErr.TableID), name: dErr.Constraint}
+	if p.deferredConstraints.deferCheck(key, dErr.InitiallyDeferred, dErr.KeyVals) {
+		return nil
+	}
+	return dErr.Err
+}
+
+// validateDeferredConstraints validates the given constraints in the current
+// transaction. Only the rows with the violating keys recorded by the deferred
+// checks are validated, unless the keys are not all known. The constraints
+// which were dropped in the meantime are ignored.
+func (p *planner) validateDeferredConstraints(
+	ctx context.Context, pcs []*pendingConstraint,
+) error {
+	for _, pc := range pcs {
+		desc, err := p.Descriptors().ByIDWithoutLeased(p.txn).Get().Table(ctx, pc.tableID)
+		if err != nil {
+			return err
+		}
+		if desc.Dropped() {
+			continue
+		}
+		c := catalog.FindConstraintByName(desc, pc.name)
+		if c == nil || c.Dropped() || !isDeferrableConstraint(c) {
+			continue
+		}
+		if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+			uc := uwi.UniqueWithoutIndexDesc()
+			if pc.validateAll || uwi.IsExclusion() {
+				err = validateUniqueConstraint(
+					ctx, desc, uc.Name, uc.ColumnIDs, uc.Predicate, 0, /* indexIDForValidation */
+					p.InternalSQLTxn(), p.User(), true, /* preExisting */
+				)
+			} else {
+				err = validateUniqueConstraintKeys(ctx, p.InternalSQLTxn(), p.User(), desc, uc, pc.keys)
+			}
+		} else {
+			mut := tabledesc.NewBuilder(desc.TableDesc()).BuildExistingMutableTable()
+			if pc.validateAll {
+				err = validateFkInTxn(ctx, p.InternalSQLTxn(), mut, pc.name)
+			} else {
+				err = validateFkKeysInTxn(ctx, p.InternalSQLTxn(), mut, pc.name, pc.keys)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateUniqueConstraintKeys is like validateUniqueConstraint, but only
+// checks the rows with the given keys.
+func validateUniqueConstraintKeys(
+	ctx context.Context,
+	txn isql.Txn,
+	user username.SQLUsername,
+	desc catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	keys []tree.Datums,
+) error {
+	colNames, err := catalog.ColumnNamesForIDs(desc, uc.ColumnIDs)
+	if err != nil {
+		return err
+	}
+	pred, args := keyFilter("", colNames, keys)
+	if uc.Predicate != "" {
+		pred = fmt.Sprintf("%s AND (%s)", pred, uc.Predicate)
+	}
+	query, _, err := duplicateRowQuery(
+		desc, uc.ColumnIDs, pred, 0 /* indexIDForValidation */, true, /* limitResults */
+	)
+	if err != nil {
+		return err
+	}
+	override := sessiondata.NoSessionDataOverride
+	override.User = user
+	values, err := txn.QueryRowEx(
+		ctx, "validate deferred unique constraint", txn.KV(), override, query, args...,
+	)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		return uniqueViolationError(uc.Name, colNames, values, true /* preExisting */)
+	}
+	return nil
+}
+
+// validateFkKeysInTxn is like validateFkInTxn, but only checks the origin
+// rows with the given keys. For example, for a foreign key from child (a, b)
+// to parent (x, y), with child primary key k, it runs:
+//
+// SELECT a, b, k FROM child
+// WHERE (a, b) IN (($1, $2), ...)
+// AND NOT EXISTS (SELECT 1 FROM parent AS t WHERE t.x = a AND t.y = b)
+// LIMIT 1
+func validateFkKeysInTxn(
+	ctx context.Context, txn descs.Txn, srcTable *tabledesc.Mutable, fkName string, keys []tree.Datums,
+) error {
+	syntheticDescs, fk, targetTable, err := getTargetTablesAndFk(ctx, srcTable, txn, fkName)
+	if err != nil {
+		return err
+	}
+	originColNames, err := catalog.ColumnNamesForIDs(srcTable, fk.OriginColumnIDs)
+	if err != nil {
+		return err
+	}
+	referencedColNames, err := catalog.ColumnNamesForIDs(targetTable, fk.ReferencedColumnIDs)
+	if err != nil {
+		return err
+	}
+	// The primary key columns are returned too, to identify the row.
+	colNames := append([]string(nil), originColNames...)
+	for _, id := range srcTable.GetPrimaryIndex().IndexDesc().KeyColumnIDs {
+		found := false
+		for _, fkColID := range fk.OriginColumnIDs {
+			found = found || fkColID == id
+		}
+		if !found {
+			col, err := catalog.MustFindPublicColumnByID(srcTable, id)
+			if err != nil {
+				return err
+			}
+			colNames = append(colNames, col.GetName())
+		}
+	}
+	srcCols := make([]string, len(colNames))
+	for i, n := range colNames {
+		srcCols[i] = fmt.Sprintf("src.%s", tree.NameString(n))
+	}
+	on := make([]string, len(originColNames))
+	for i := range originColNames {
+		on[i] = fmt.Sprintf(
+			"t.%s = src.%s", tree.NameString(referencedColNames[i]), tree.NameString(originColNames[i]),
+		)
+	}
+	filter, args := keyFilter("src.", originColNames, keys)
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM [%[2]d AS src]@{IGNORE_FOREIGN_KEYS}
+		 WHERE %[3]s AND NOT EXISTS (SELECT 1 FROM [%[4]d AS target] AS t WHERE %[5]s)
+		 LIMIT 1`,
+		strings.Join(srcCols, ", "), // 1
+		srcTable.GetID(),            // 2
+		filter,                      // 3
+		targetTable.GetID(),         // 4
+		strings.Join(on, " AND "),   // 5
+	)
+	return txn.WithSyntheticDescriptors(syntheticDescs, func() error {
+		values, err := txn.QueryRowEx(ctx, "validate deferred fk constraint", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride, query, args...)
+		if err != nil {
+			return err
+		}
+		if values.Len() > 0 {
+			return nonMatchingRowError(srcTable.GetName(), colNames, values, targetTable.GetName(), fk.Name)
+		}
+		return nil
+	})
+}
+
+// keyFilter returns a filter matching the rows whose given columns have one
+// of the given keys, e.g. (a, b) IN (($1, $2), ($3, $4)), along with the
+// values of its placeholders. The column names are prefixed with prefix.
+func keyFilter(prefix string, colNames []string, keys []tree.Datums) (string, []interface{}) {
+	var buf strings.Builder
+	buf.WriteByte('(')
+	for i, n := range colNames {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(prefix)
+		buf.WriteString(tree.NameString(n))
+	}
+	buf.WriteString(") IN (")
+	args := make([]interface{}, 0, len(keys)*len(colNames))
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteByte('(')
+		for j, d := range key {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			args = append(args, d)
+			fmt.Fprintf(&buf, "$%d", len(args))
+		}
+		buf.WriteByte(')')
+	}
+	buf.WriteByte(')')
+	return buf.String(), args
+}
+
+// validatePendingConstraints validates the deferred constraints of the
+// transaction which found a violation. It is called before the transaction
+// commits or is prepared.
+func (ex *connExecutor) validatePendingConstraints(ctx context.Context) error {
+	pcs := ex.extraTxnState.deferredConstraints.takePending(func(constraintKey) bool { return true })
+	if len(pcs) == 0 {
+		return nil
+	}
+	return ex.planner.validateDeferredConstraints(ctx, pcs)
+}
+
+// setConstraintsNode implements SET CONSTRAINTS.
+type setConstraintsNode struct {
+	zeroInputPlanNode
+	// keys is empty for SET CONSTRAINTS ALL.
+	keys     []constraintKey
+	deferred bool
+}
+
+// SetConstraints implements the SET CONSTRAINTS statement.
+// See https://www.postgresql.org/docs/current/sql-set-constraints.html for
+// details.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	node := &setConstraintsNode{deferred: n.Deferred}
+	for _, name := range n.Names {
+		keys, err := p.resolveDeferrableConstraint(ctx, string(name))
+		if err != nil {
+			return nil, err
+		}
+		node.keys = append(node.keys, keys...)
+	}
+	return node, nil
+}
+
+// resolveDeferrableConstraint returns the deferrable constraints with the
+// given name in the first schema of the search path which has a constraint of
+// that name. Constraint names are not unique in a schema, so there may be
+// more than one.
+func (p *planner) resolveDeferrableConstraint(
+	ctx context.Context, name string,
+) ([]constraintKey, error) {
+	db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	iter := p.CurrentSearchPath().IterWithoutImplicitPGSchemas()
+	for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+		sc, err := p.Descriptors().ByName(p.txn).MaybeGet().Schema(ctx, db, scName)
+		if err != nil {
+			return nil, err
+		}
+		if sc == nil {
+			continue
+		}
+		objs, err := p.Descriptors().GetAllObjectsInSchema(ctx, p.txn, db, sc)
+		if err != nil {
+			return nil, err
+		}
+		var keys []constraintKey
+		var found bool
+		if err := objs.ForEachDescriptor(func(desc catalog.Descriptor) error {
+			tbl, ok := desc.(catalog.TableDescriptor)
+			if !ok || tbl.Dropped() {
+				return nil
+			}
+			c := catalog.FindConstraintByName(tbl, name)
+			if c == nil {
+				return nil
+			}
+			found = true
+			if !isDeferrableConstraint(c) {
+				return pgerror.Newf(pgcode.WrongObjectType, "constraint %q is not deferrable", name)
+			}
+			keys = append(keys, constraintKey{tableID: tbl.GetID(), name: name})
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		if found {
+			return keys, nil
+		}
+	}
+	return nil, pgerror.Newf(pgcode.UndefinedObject, "constraint %q does not exist", name)
+}
+
+// isDeferrableConstraint returns true if the constraint is deferrable.
+func isDeferrableConstraint(c catalog.Constraint) bool {
+	if fk := c.AsForeignKey(); fk != nil {
+		return fk.IsDeferrable()
+	}
+	if uwi := c.AsUniqueWithoutIndex(); uwi != nil {
+		return uwi.IsDeferrable()
+	}
+	return false
+}
+
+func (n *setConstraintsNode) startExec(params runParams) error {
+	// Like SET LOCAL, SET CONSTRAINTS has no effect outside of transaction
+	// blocks.
+	if params.p.extendedEvalCtx.TxnImplicit {
+		params.p.BufferClientNotice(
+			params.ctx,
+			pgnotice.NewWithSeverityf(
+				"WARNING",
+				"SET CONSTRAINTS can only be used in transaction blocks",
+			),
+		)
+		return nil
+	}
+	// The pending constraints which become immediate are validated right away.
+	pcs := params.p.deferredConstraints.setConstraintModes(n.keys, n.deferred)
+	return params.p.validateDeferredConstraints(params.ctx, pcs)
+}
+
+func (n *setConstraintsNode) Next(runParams) (bool, error) { return false, nil }
+func (n *setConstraintsNode) Values() tree.Datums          { return nil }
+func (n *setConstraintsNode) Close(context.Context)        {}
@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -151,6 +152,12 @@ func ShowCreateView(
 		}
 		f.WriteString(line)
 	}
+	switch desc.TableDesc().ViewCheckOption {
+	case descpb.TableDescriptor_LOCAL:
+		f.WriteString("\n\tWITH LOCAL CHECK OPTION")
+	case descpb.TableDescriptor_CASCADED:
+		f.WriteString("\n\tWITH CASCADED CHECK OPTION")
+	}
 	return f.CloseAndGetString(), nil
 }
 
@@ -587,6 +594,10 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	if fk.Deferrable {
+		buf.WriteByte(' ')
+		buf.WriteString(tree.MakeConstraintDeferrability(fk.Deferrable, fk.InitiallyDeferred).String())
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -627,6 +638,37 @@ func ShowCreateSequence(
 
 // showFamilyClause creates the FAMILY clauses for a CREATE statement, writing them
 // to tree.FmtCtx f
+// showExclusionConstraintElems writes the EXCLUDE clause of an exclusion
+// constraint, without its name, deferrability and predicate. The access method
+// is not stored, so gist is shown when it is required by the && operator.
+func showExclusionConstraintElems(
+	buf *bytes.Buffer, desc catalog.TableDescriptor, c catalog.UniqueWithoutIndexConstraint,
+) error {
+	colIDs := c.UniqueWithoutIndexDesc().ColumnIDs
+	colNames, err := catalog.ColumnNamesForIDs(desc, colIDs)
+	if err != nil {
+		return err
+	}
+	buf.WriteString("EXCLUDE ")
+	for i := range colIDs {
+		if c.GetExclusionOperator(i) != treecmp.EQ.String() {
+			buf.WriteString("USING gist ")
+			break
+		}
+	}
+	buf.WriteString("(")
+	for i, name := range colNames {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(name)
+		buf.WriteString(" WITH ")
+		buf.WriteString(c.GetExclusionOperator(i))
+	}
+	buf.WriteString(")")
+	return nil
+}
+
 func showFamilyClause(desc catalog.TableDescriptor, f *tree.FmtCtx) {
 	// Do not show family in SHOW CREATE TABLE if there is only one and
 	// it is named "primary".
@@ -852,13 +894,23 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.GetName())
 			f.WriteString(" ")
 		}
-		f.WriteString("UNIQUE WITHOUT INDEX (")
-		colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
-		if err != nil {
-			return err
+		if c.IsExclusion() {
+			if err := showExclusionConstraintElems(&f.Buffer, desc, c); err != nil {
+				return err
+			}
+		} else {
+			f.WriteString("UNIQUE WITHOUT INDEX (")
+			colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
+			if err != nil {
+				return err
+			}
+			f.WriteString(strings.Join(colNames, ", "))
+			f.WriteString(")")
+		}
+		if c.IsDeferrable() {
+			f.WriteString(" ")
+			f.WriteString(tree.MakeConstraintDeferrability(c.IsDeferrable(), c.IsInitiallyDeferred()).String())
 		}
-		f.WriteString(strings.Join(colNames, ", "))
-		f.WriteString(")")
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(
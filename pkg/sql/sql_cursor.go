@@ -13,14 +13,20 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -28,13 +34,6 @@ import (
 // DeclareCursor implements the DECLARE statement.
 // See https://www.postgresql.org/docs/current/sql-declare.html for details.
 func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (planNode, error) {
-	if s.Binary {
-		return nil, unimplemented.NewWithIssue(77099, "DECLARE BINARY CURSOR")
-	}
-	if s.Scroll == tree.Scroll {
-		return nil, unimplemented.NewWithIssue(77102, "DECLARE SCROLL CURSOR")
-	}
-
 	return &delayedNode{
 		name: s.String(),
 		constructor: func(ctx context.Context, p *planner) (_ planNode, _ error) {
@@ -42,27 +41,7 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 				return nil, pgerror.Newf(pgcode.NoActiveSQLTransaction, "DECLARE CURSOR can only be used in transaction blocks")
 			}
 
-			sd := p.SessionData()
-			// This session variable was introduced as a workaround to #96322.
-			// Today, if a timeout is set, FETCH's timeout is from the point
-			// DECLARE CURSOR is executed rather than the FETCH itself.
-			// The setting allows us to override the setting without affecting
-			// third-party applications.
-			if !p.SessionData().DeclareCursorStatementTimeoutEnabled {
-				sd = sd.Clone()
-				sd.StmtTimeout = 0
-			}
-			// We avoid using the internal executor provided by p.InternalSQLTxn()
-			// since we want to customize the session data used by the cursor.
-			ief := p.ExecCfg().InternalDB
-			ie := MakeInternalExecutor(ief.server, ief.memMetrics, ief.monitor)
-			ie.SetSessionData(sd)
-			ie.extraTxnState = &extraTxnState{
-				txn:                p.Txn(),
-				descCollection:     p.Descriptors(),
-				jobs:               p.extendedEvalCtx.jobs,
-				schemaChangerState: p.extendedEvalCtx.SchemaChangerState,
-			}
+			ie := p.makeCursorInternalExecutor()
 			if err := p.checkIfCursorExists(s.Name); err != nil {
 				return nil, err
 			}
@@ -100,6 +79,13 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 					"Holdable cursors must be READ ONLY.",
 				)
 			}
+			if s.Scroll == tree.Scroll && pt.flags.IsSet(planFlagContainsLocking) {
+				return nil, errors.WithDetail(
+					pgerror.Newf(pgcode.FeatureNotSupported,
+						"DECLARE SCROLL CURSOR must not contain locking"),
+					"Scrollable cursors must be READ ONLY.",
+				)
+			}
 			if pt.flags.IsSet(planFlagContainsMutation) {
 				// Cursors with mutations are invalid.
 				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
@@ -112,6 +98,16 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to DECLARE CURSOR")
 			}
+			if s.Scroll == tree.Scroll {
+				// Scrollable cursors buffer the rows they have read so that they can
+				// be re-read when the cursor moves backward.
+				scrollRows, err := p.newScrollableCursorRows(rows, s.Hold)
+				if err != nil {
+					_ = rows.Close()
+					return nil, err
+				}
+				rows = scrollRows
+			}
 			cursor := &sqlCursor{
 				Rows:       rows,
 				readSeqNum: p.txn.GetReadSeqNum(),
@@ -119,6 +115,7 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 				statement:  statement,
 				created:    timeutil.Now(),
 				withHold:   s.Hold,
+				binary:     s.Binary,
 			}
 			if err := p.sqlCursors.addCursor(s.Name, cursor); err != nil {
 				// This case shouldn't happen because cursor names are scoped to a session,
@@ -132,6 +129,33 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 	}, nil
 }
 
+// makeCursorInternalExecutor returns an internal executor that runs the query
+// of a cursor in the planner's transaction.
+func (p *planner) makeCursorInternalExecutor() InternalExecutor {
+	sd := p.SessionData()
+	// This session variable was introduced as a workaround to #96322.
+	// Today, if a timeout is set, FETCH's timeout is from the point
+	// DECLARE CURSOR is executed rather than the FETCH itself.
+	// The setting allows us to override the setting without affecting
+	// third-party applications.
+	if !p.SessionData().DeclareCursorStatementTimeoutEnabled {
+		sd = sd.Clone()
+		sd.StmtTimeout = 0
+	}
+	// We avoid using the internal executor provided by p.InternalSQLTxn()
+	// since we want to customize the session data used by the cursor.
+	ief := p.ExecCfg().InternalDB
+	ie := MakeInternalExecutor(ief.server, ief.memMetrics, ief.monitor)
+	ie.SetSessionData(sd)
+	ie.extraTxnState = &extraTxnState{
+		txn:                p.Txn(),
+		descCollection:     p.Descriptors(),
+		jobs:               p.extendedEvalCtx.jobs,
+		schemaChangerState: p.extendedEvalCtx.SchemaChangerState,
+	}
+	return ie
+}
+
 // checkIfCursorExists checks whether a cursor or portal with the given name
 // already exists, and returns an error if one does.
 func (p *planner) checkIfCursorExists(name tree.Name) error {
@@ -144,7 +168,10 @@ func (p *planner) checkIfCursorExists(name tree.Name) error {
 	return nil
 }
 
-var errBackwardScan = pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cursor can only scan forward")
+var errBackwardScan = errors.WithHint(
+	pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cursor can only scan forward"),
+	"Declare it with SCROLL option to enable backward scan.",
+)
 
 // FetchCursor implements the FETCH and MOVE statements.
 // See https://www.postgresql.org/docs/current/sql-fetch.html for details.
@@ -161,6 +188,9 @@ func (p *planner) newFetchNode(s *tree.CursorStmt) (*fetchNode, error) {
 			pgcode.InvalidCursorName, "cursor %q does not exist", s.Name,
 		)
 	}
+	if scrollRows := cursor.scrollRows(); scrollRows != nil {
+		return newScrollFetchNode(s, cursor, scrollRows), nil
+	}
 	if s.Count < 0 || s.FetchType == tree.FetchBackwardAll {
 		return nil, errBackwardScan
 	}
@@ -176,6 +206,39 @@ func (p *planner) newFetchNode(s *tree.CursorStmt) (*fetchNode, error) {
 	return node, nil
 }
 
+// newScrollFetchNode creates a fetchNode for a SCROLL cursor. Unlike for other
+// cursors, every direction is supported.
+func newScrollFetchNode(
+	s *tree.CursorStmt, cursor *sqlCursor, scrollRows *scrollableCursorRows,
+) *fetchNode {
+	node := &fetchNode{
+		fetchType:  s.FetchType,
+		cursor:     cursor,
+		scrollRows: scrollRows,
+	}
+	switch s.FetchType {
+	case tree.FetchNormal:
+		switch {
+		case s.Count == 0:
+			// FORWARD 0 and BACKWARD 0 re-fetch the current row.
+			node.fetchType = tree.FetchRelative
+		case s.Count < 0:
+			node.n = -s.Count
+			node.backward = true
+		default:
+			node.n = s.Count
+		}
+	case tree.FetchAll:
+		node.n = -1
+	case tree.FetchBackwardAll:
+		node.n = -1
+		node.backward = true
+	default:
+		node.offset = s.Count
+	}
+	return node
+}
+
 type fetchNode struct {
 	zeroInputPlanNode
 	cursor *sqlCursor
@@ -188,6 +251,12 @@ type fetchNode struct {
 
 	seeked bool
 
+	// scrollRows is set if the cursor is a SCROLL cursor. In that case n is the
+	// number of rows to step over in the direction indicated by backward, or -1
+	// to step until the cursor runs out of rows.
+	scrollRows *scrollableCursorRows
+	backward   bool
+
 	// origTxnSeqNum is the transaction sequence number of the user's transaction
 	// before the fetch began.
 	origTxnSeqNum enginepb.TxnSeq
@@ -208,6 +277,9 @@ func (f *fetchNode) startInternal() error {
 }
 
 func (f *fetchNode) nextInternal(ctx context.Context) (bool, error) {
+	if f.scrollRows != nil {
+		return f.nextScroll(ctx)
+	}
 	if f.fetchType == tree.FetchAll {
 		return f.cursor.Next(ctx)
 	}
@@ -260,6 +332,43 @@ func (f *fetchNode) nextInternal(ctx context.Context) (bool, error) {
 	return f.cursor.Next(ctx)
 }
 
+// nextScroll implements nextInternal for SCROLL cursors.
+func (f *fetchNode) nextScroll(ctx context.Context) (bool, error) {
+	s := f.scrollRows
+	switch f.fetchType {
+	case tree.FetchNormal, tree.FetchAll, tree.FetchBackwardAll:
+		if f.n == 0 {
+			return false, nil
+		}
+		if f.n > 0 {
+			f.n--
+		}
+		if f.backward {
+			return s.seek(ctx, s.pos-1)
+		}
+		return s.seek(ctx, s.pos+1)
+	}
+	// FIRST, LAST, ABSOLUTE, and RELATIVE return at most one row.
+	if f.seeked {
+		return false, nil
+	}
+	f.seeked = true
+	switch f.fetchType {
+	case tree.FetchFirst:
+		return s.seek(ctx, 1)
+	case tree.FetchLast:
+		return s.seekFromEnd(ctx, 1)
+	case tree.FetchAbsolute:
+		if f.offset < 0 {
+			return s.seekFromEnd(ctx, -f.offset)
+		}
+		return s.seek(ctx, f.offset)
+	case tree.FetchRelative:
+		return s.seek(ctx, s.pos+f.offset)
+	}
+	return false, errors.AssertionFailedf("unexpected fetch type %v", f.fetchType)
+}
+
 func (f *fetchNode) startExec(params runParams) error {
 	return f.startInternal()
 }
@@ -337,6 +446,66 @@ func (p *planner) PLpgSQLFetchCursor(
 	return res, err
 }
 
+// PLpgSQLFetchCursorRecord implements the eval.Planner interface.
+func (p *planner) PLpgSQLFetchCursorRecord(
+	ctx context.Context, cursorStmt *tree.CursorStmt,
+) (tree.Datum, error) {
+	row, err := p.PLpgSQLFetchCursor(ctx, cursorStmt)
+	if err != nil || row == nil {
+		return tree.DNull, err
+	}
+	cols := p.sqlCursors.getCursor(cursorStmt.Name).Types()
+	contents := make([]*types.T, len(cols))
+	labels := make([]string, len(cols))
+	for i := range cols {
+		contents[i] = cols[i].Typ
+		labels[i] = cols[i].Name
+	}
+	// The row may be reused by the cursor, so it must be copied.
+	return tree.NewDTuple(types.MakeLabeledTuple(contents, labels), append(tree.Datums(nil), row...)...), nil
+}
+
+// PLpgSQLOpenQueryCursor implements the eval.Planner interface. Like a cursor
+// opened by DECLARE, the cursor reads the rows of the query lazily, and it is
+// closed when the transaction finishes.
+func (p *planner) PLpgSQLOpenQueryCursor(
+	ctx context.Context, cursorName tree.Name, query string, params tree.Datums,
+) error {
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return err
+	}
+	if _, ok := stmt.AST.(*tree.Select); !ok {
+		return pgerror.Newf(
+			pgcode.InvalidCursorDefinition, "cannot open %s query as cursor", stmt.AST.StatementTag(),
+		)
+	}
+	if err := p.checkIfCursorExists(cursorName); err != nil {
+		return err
+	}
+	qargs := make([]interface{}, len(params))
+	for i := range params {
+		qargs[i] = params[i]
+	}
+	ie := p.makeCursorInternalExecutor()
+	rows, err := ie.QueryIterator(context.Background(), "plpgsql-cursor", p.txn, query, qargs...)
+	if err != nil {
+		return err
+	}
+	cursor := &sqlCursor{
+		Rows:       rows,
+		readSeqNum: p.txn.GetReadSeqNum(),
+		txn:        p.txn,
+		statement:  query,
+		created:    timeutil.Now(),
+	}
+	if err := p.sqlCursors.addCursor(cursorName, cursor); err != nil {
+		_ = cursor.Close()
+		return err
+	}
+	return nil
+}
+
 type sqlCursor struct {
 	isql.Rows
 	// txn is the transaction object that the internal executor for this cursor
@@ -349,6 +518,9 @@ type sqlCursor struct {
 	created    time.Time
 	curRow     int64
 	withHold   bool
+	// binary is set for cursors declared using BINARY, whose rows are returned
+	// in the binary format by FETCH.
+	binary bool
 	// persisted indicates that the cursor's query was executed to completion and
 	// the result stored in a row container. If true, there is no need to set the
 	// transaction sequence number, since the query is no longer active.
@@ -371,6 +543,13 @@ func (s *sqlCursor) Next(ctx context.Context) (bool, error) {
 	return more, err
 }
 
+// scrollRows returns the row buffer of a SCROLL cursor, or nil if the cursor
+// can only scan forward.
+func (s *sqlCursor) scrollRows() *scrollableCursorRows {
+	scrollRows, _ := s.Rows.(*scrollableCursorRows)
+	return scrollRows
+}
+
 // sqlCursors contains a set of active cursors for a session.
 type sqlCursors interface {
 	// closeAll closes cursors in the set according to the following rules:
@@ -584,6 +763,11 @@ func (p *planner) checkNoConflictingCursors(stmt tree.Statement) error {
 // persistCursor runs the given cursor to completion and stores the result in a
 // row container that can outlive the cursor's transaction.
 func persistCursor(p *planner, cursor *sqlCursor) error {
+	if scrollRows := cursor.scrollRows(); scrollRows != nil {
+		// The row buffer of a SCROLL cursor already outlives the transaction, so
+		// it only needs to be filled with the rest of the query's result.
+		return scrollRows.fill(context.Background(), -1 /* n */)
+	}
 	// Use context.Background() because the cursor can outlive the context in
 	// which it was created.
 	helper := persistedCursorHelper{
@@ -681,3 +865,175 @@ func (h *persistedCursorHelper) Types() colinfo.ResultColumns {
 func (h *persistedCursorHelper) HasResults() bool {
 	return h.lastRow != nil
 }
+
+// scrollableCursorRows implements the isql.Rows of a SCROLL cursor. Rows are
+// lazily read from the cursor's query and buffered in a disk-backed row
+// container, so that the cursor can be moved to any row that has already been
+// read without re-executing the query.
+type scrollableCursorRows struct {
+	ctx context.Context
+
+	// input produces the rows of the cursor's query. It is closed and set to
+	// nil once it is exhausted.
+	input      isql.Rows
+	resultCols colinfo.ResultColumns
+
+	memMonitor          *mon.BytesMonitor
+	unlimitedMemMonitor *mon.BytesMonitor
+	diskMonitor         *mon.BytesMonitor
+	rows                *rowcontainer.DiskBackedIndexedRowContainer
+	scratch             rowenc.EncDatumRow
+
+	// pos is the 1-based position of the cursor. Zero means that the cursor is
+	// positioned before the first row, and rows.Len()+1 means that it is
+	// positioned after the last row (which is only possible once input has been
+	// exhausted).
+	pos     int64
+	lastRow tree.Datums
+}
+
+var _ isql.Rows = &scrollableCursorRows{}
+
+// newScrollableCursorRows returns a scrollableCursorRows that buffers the rows
+// produced by the given input. The buffer is accounted against the session if
+// the cursor is holdable, and against the current transaction otherwise.
+func (p *planner) newScrollableCursorRows(
+	input isql.Rows, withHold bool,
+) (*scrollableCursorRows, error) {
+	parent := p.Mon()
+	if withHold {
+		parent = p.sessionMonitor
+		if parent == nil {
+			return nil, errors.AssertionFailedf("cannot open cursor WITH HOLD without an active session")
+		}
+	}
+	const opName = "scroll_cursor"
+	// Use context.Background() because the cursor can outlive the context in
+	// which it was created.
+	ctx := context.Background()
+	evalCtx := p.ExtendedEvalContextCopy()
+	distSQLCfg := &evalCtx.DistSQLPlanner.distSQLSrv.ServerConfig
+	s := &scrollableCursorRows{
+		ctx:        ctx,
+		input:      input,
+		resultCols: input.Types(),
+	}
+	s.memMonitor = execinfra.NewLimitedMonitorNoFlowCtx(
+		ctx, parent, distSQLCfg, evalCtx.SessionData(), mon.MakeName(opName).Limited(),
+	)
+	s.unlimitedMemMonitor = execinfra.NewMonitor(ctx, parent, mon.MakeName(opName).Unlimited())
+	s.diskMonitor = execinfra.NewMonitor(
+		ctx, distSQLCfg.ParentDiskMonitor, mon.MakeName(opName).Disk(),
+	)
+	s.rows = rowcontainer.NewDiskBackedIndexedRowContainer(
+		colinfo.NoOrdering, getTypesFromResultColumns(s.resultCols), &evalCtx.Context,
+		distSQLCfg.TempStorage, s.memMonitor, s.unlimitedMemMonitor, s.diskMonitor,
+	)
+	s.scratch = make(rowenc.EncDatumRow, len(s.resultCols))
+	return s, nil
+}
+
+// fill reads rows from the input into the buffer until it holds at least n
+// rows or the input is exhausted. If n is negative, the input is read to
+// completion.
+func (s *scrollableCursorRows) fill(ctx context.Context, n int64) error {
+	for s.input != nil && (n < 0 || int64(s.rows.Len()) < n) {
+		ok, err := s.input.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			err = s.input.Close()
+			s.input = nil
+			return err
+		}
+		for i, d := range s.input.Cur() {
+			s.scratch[i].Datum = d
+		}
+		if err = s.rows.AddRow(ctx, s.scratch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seek moves the cursor to the row at the given 1-based position and returns
+// whether that row exists. Seeking before the first row leaves the cursor
+// positioned before the first row, and seeking past the last row leaves it
+// positioned after the last row.
+func (s *scrollableCursorRows) seek(ctx context.Context, target int64) (bool, error) {
+	s.lastRow = nil
+	if target <= 0 {
+		s.pos = 0
+		return false, nil
+	}
+	if err := s.fill(ctx, target); err != nil {
+		return false, err
+	}
+	if n := int64(s.rows.Len()); target > n {
+		s.pos = n + 1
+		return false, nil
+	}
+	row, err := s.rows.GetRow(ctx, int(target-1))
+	if err != nil {
+		return false, err
+	}
+	// GetDatums allocates a new slice, so the row is safe to hold on to.
+	s.lastRow, err = row.GetDatums(0, len(s.resultCols))
+	if err != nil {
+		return false, err
+	}
+	s.pos = target
+	return true, nil
+}
+
+// seekFromEnd moves the cursor to the n-th row counting back from the last
+// one, reading the rest of the input if necessary.
+func (s *scrollableCursorRows) seekFromEnd(ctx context.Context, n int64) (bool, error) {
+	if err := s.fill(ctx, -1 /* n */); err != nil {
+		return false, err
+	}
+	return s.seek(ctx, int64(s.rows.Len())+1-n)
+}
+
+// Next implements the isql.Rows interface.
+func (s *scrollableCursorRows) Next(ctx context.Context) (bool, error) {
+	return s.seek(ctx, s.pos+1)
+}
+
+// Cur implements the isql.Rows interface.
+func (s *scrollableCursorRows) Cur() tree.Datums {
+	return s.lastRow
+}
+
+// RowsAffected implements the isql.Rows interface.
+func (s *scrollableCursorRows) RowsAffected() int {
+	return s.rows.Len()
+}
+
+// Close implements the isql.Rows interface.
+func (s *scrollableCursorRows) Close() error {
+	var err error
+	if s.input != nil {
+		err = s.input.Close()
+		s.input = nil
+	}
+	if s.rows != nil {
+		s.rows.Close(s.ctx)
+		s.memMonitor.Stop(s.ctx)
+		s.unlimitedMemMonitor.Stop(s.ctx)
+		s.diskMonitor.Stop(s.ctx)
+		s.rows = nil
+	}
+	return err
+}
+
+// Types implements the isql.Rows interface.
+func (s *scrollableCursorRows) Types() colinfo.ResultColumns {
+	return s.resultCols
+}
+
+// HasResults implements the isql.Rows interface.
+func (s *scrollableCursorRows) HasResults() bool {
+	return s.lastRow != nil
+}
@@ -244,6 +244,60 @@ func NewUnsupportedUnvalidatedConstraintError(constraintType catconstants.Constr
 		"%v constraints cannot be marked NOT VALID", constraintType)
 }
 
+// NewDeferrableUniqueIndexError creates an error for a deferrable unique
+// constraint which would be backed by an index. Only unique constraints
+// without an index are checked by queries, so only they can be deferred.
+func NewDeferrableUniqueIndexError() error {
+	return errors.WithHint(
+		unimplemented.NewWithIssue(31632, "deferrable unique constraints with an index are not supported"),
+		"use UNIQUE WITHOUT INDEX to create a deferrable unique constraint",
+	)
+}
+
+// NewDeferrableConstraintsNotSupportedError creates an error for a deferrable
+// constraint created before the cluster is upgraded.
+func NewDeferrableConstraintsNotSupportedError() error {
+	return pgerror.New(pgcode.FeatureNotSupported,
+		"deferrable constraints are not supported until the cluster upgrade is finalized")
+}
+
+// NewExclusionConstraintsNotSupportedError creates an error for an exclusion
+// constraint created before the cluster is upgraded.
+func NewExclusionConstraintsNotSupportedError() error {
+	return pgerror.New(pgcode.FeatureNotSupported,
+		"exclusion constraints are not supported until the cluster upgrade is finalized")
+}
+
+// NewExclusionConstraintIsolationError creates an error for an exclusion
+// constraint that is added or enforced under a weaker isolation level than
+// serializable. The check of the constraint doesn't lock the conflicting rows,
+// so concurrent transactions could both insert conflicting rows.
+func NewExclusionConstraintIsolationError() error {
+	return unimplemented.NewWithIssue(46657,
+		"exclusion constraint under non-serializable isolation levels")
+}
+
+// NewUserDefinedAggregatesNotSupportedError creates an error for a
+// user-defined aggregate created before the cluster is upgraded.
+func NewUserDefinedAggregatesNotSupportedError() error {
+	return pgerror.New(pgcode.FeatureNotSupported,
+		"user-defined aggregates are not supported until the cluster upgrade is finalized")
+}
+
+// NewRangeTypesNotSupportedError creates an error for a column of a range or
+// multirange type created before the cluster is upgraded.
+func NewRangeTypesNotSupportedError() error {
+	return pgerror.New(pgcode.FeatureNotSupported,
+		"range types are not supported until the cluster upgrade is finalized")
+}
+
+// NewTableSampleNotSupportedError creates an error for a TABLESAMPLE clause
+// used before the cluster is upgraded.
+func NewTableSampleNotSupportedError() error {
+	return pgerror.New(pgcode.FeatureNotSupported,
+		"TABLESAMPLE is not supported until the cluster upgrade is finalized")
+}
+
 // NewInvalidActionOnComputedFKColumnError creates an error when there is an
 // attempt to have an unsupported action on a FK over a computed column.
 func NewInvalidActionOnComputedFKColumnError(onUpdateAction bool) error {
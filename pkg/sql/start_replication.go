@@ -0,0 +1,887 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package sql
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/replslot"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/fsm"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// replicationKeepaliveInterval is the interval at which keepalive messages
+// are sent to the client of a replication stream.
+const replicationKeepaliveInterval = 10 * time.Second
+
+// execStartReplication runs a logical replication stream for the
+// START_REPLICATION command. The stream runs until the client ends it with
+// CopyDone, the connection is closed, or an error occurs.
+func (ex *connExecutor) execStartReplication(
+	ctx context.Context, cmd StartReplication, res StartReplicationResult,
+) (fsm.Event, fsm.EventPayload) {
+	if _, isNoTxn := ex.machine.CurState().(stateNoTxn); !isNoTxn {
+		return ex.makeErrEvent(pgerror.New(pgcode.ActiveSQLTransaction,
+			"START_REPLICATION cannot be executed inside a transaction"), cmd.ParsedStmt.AST)
+	}
+
+	ex.incrementStartedStmtCounter(cmd.Stmt)
+	ex.metrics.EngineMetrics.SQLActiveStatements.Inc(1)
+	defer ex.metrics.EngineMetrics.SQLActiveStatements.Dec(1)
+
+	s, err := newReplicationStream(ctx, ex.server.cfg, ex.sessionMon, ex.sessionData().Database, cmd)
+	if err == nil {
+		err = s.run(ctx, cmd, res)
+	}
+	if err != nil {
+		log.SqlExec.Errorf(ctx, "error executing %s: %+v", cmd, err)
+		return eventNonRetriableErr{IsCommit: fsm.False}, eventNonRetriableErrPayload{err: err}
+	}
+	ex.incrementExecutedStmtCounter(cmd.Stmt)
+	return nil, nil
+}
+
+// replicationBufferSize limits the memory used by a replication stream to
+// buffer changes until they can be sent.
+var replicationBufferSize = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.pgrepl.max_buffer_size",
+	"maximum memory used by a logical replication stream to buffer the changes "+
+		"which cannot be sent to the client yet",
+	64<<20, /* 64 MiB */
+	settings.PositiveInt,
+)
+
+// descriptorFeedGen is the generation of the events emitted by the descriptor
+// rangefeed of a replication stream.
+const descriptorFeedGen = 0
+
+// replicationEvent is a value or a frontier advance emitted by one of the
+// rangefeeds backing a replication stream. The events of a rangefeed are
+// delivered through the same channel so that a frontier advance is never
+// observed before the values below it.
+type replicationEvent struct {
+	// gen is the generation of the table rangefeed which emitted the event, or
+	// descriptorFeedGen.
+	gen      int
+	value    *kvpb.RangeFeedValue
+	frontier hlc.Timestamp
+	err      error
+}
+
+// replicationRelation holds the state needed to decode and describe the rows
+// of a replicated table at a given descriptor version.
+type replicationRelation struct {
+	version descpb.DescriptorVersion
+	rel     pgoutput.Relation
+	// sent is set once the Relation message for this version has been sent to
+	// the client.
+	sent    bool
+	alloc   tree.DatumAlloc
+	fetcher row.Fetcher
+}
+
+// replicationStream streams the changes to the tables of the publications
+// requested by the client of a replication slot.
+//
+// Changes are grouped into synthetic transactions by the wall time of their
+// MVCC timestamps, which is also their LSN (see lsnutil). A transaction is
+// only sent once the rangefeed frontier guarantees that no more changes with
+// the same wall time will arrive.
+//
+// The stream runs two rangefeeds: the table rangefeed over the primary indexes
+// of the replicated tables, and the descriptor rangefeed over the descriptor
+// table. When a descriptor changes, including the database descriptor which
+// holds the publications, the set of replicated tables is read again and, if
+// it changed, the table rangefeed is restarted from the last position sent to
+// the client. Since changes are only sent once both frontiers have passed
+// them, the restarted rangefeed picks up the changes to a new table from its
+// creation.
+type replicationStream struct {
+	cfg   *ExecutorConfig
+	slot  replslot.Slot
+	start hlc.Timestamp
+	// publications are the names of the publications requested by the client.
+	// The replicated tables are the union of their tables.
+	publications []string
+	// spans are the primary index spans of the replicated tables, as of
+	// tablesAsOf, in key order.
+	spans      []roachpb.Span
+	tablesAsOf hlc.Timestamp
+
+	events        chan replicationEvent
+	tableFeed     *rangefeed.RangeFeed
+	tableFeedGen  int
+	tableFrontier hlc.Timestamp
+	descFrontier  hlc.Timestamp
+
+	relations map[descpb.ID]*replicationRelation
+	// leases caches the descriptor leases acquired while sending changes.
+	// They are released after each flush so that the stream doesn't hold back
+	// schema changes on tables which are not written to.
+	leases map[descpb.ID]lease.LeasedDescriptor
+
+	// pending holds the values received from the table rangefeed which have
+	// not been sent to the client yet. Their memory is accounted for in acc.
+	pending []*kvpb.RangeFeedValue
+	mon     *mon.BytesMonitor
+	acc     mon.BoundAccount
+	// cutoff, if set, is the timestamp above which values are dropped rather
+	// than buffered because the buffer ran out of memory. Once all the
+	// changes up to the cutoff have been sent, the table rangefeed is restarted
+	// from it, which delivers the dropped values again.
+	cutoff hlc.Timestamp
+
+	// walEnd is the position up to which all changes have been sent to the
+	// client.
+	walEnd lsn.LSN
+	xid    uint32
+	buf    []byte
+}
+
+func newReplicationStream(
+	ctx context.Context,
+	cfg *ExecutorConfig,
+	parentMon *mon.BytesMonitor,
+	database string,
+	cmd StartReplication,
+) (*replicationStream, error) {
+	if err := checkReplicationSlotsActive(ctx, cfg); err != nil {
+		return nil, err
+	}
+	if cmd.Stmt.Kind != pgrepltree.LogicalReplication {
+		return nil, unimplemented.New("physical replication", "physical replication is not supported")
+	}
+	publications, err := validateReplicationOptions(cmd.Stmt.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &replicationStream{
+		cfg:          cfg,
+		publications: publications,
+		relations:    make(map[descpb.ID]*replicationRelation),
+		leases:       make(map[descpb.ID]lease.LeasedDescriptor),
+	}
+	if err := cfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+		s.slot, err = replslot.Get(ctx, cfg.ProtectedTimestampProvider.WithTxn(txn), string(cmd.Stmt.Slot))
+		if err != nil {
+			return err
+		}
+		if s.slot.Database != database {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"replication slot %q was not created in this database", s.slot.Name)
+		}
+		s.tablesAsOf = txn.KV().ReadTimestamp()
+		s.spans, err = replicatedTableSpans(ctx, cfg.Codec, txn, s.slot.DatabaseID, s.publications)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// The stream starts after the later of the slot's confirmed flush position
+	// and the requested position. Positions before the confirmed flush
+	// position cannot be replayed.
+	s.start = s.slot.ConfirmedFlush
+	if cmd.Stmt.LSN > s.slot.ConfirmedFlushLSN() {
+		s.start = lsnutil.LSNToHLC(cmd.Stmt.LSN)
+	}
+	s.walEnd = lsnutil.HLCToLSN(s.start)
+	s.descFrontier = s.tablesAsOf
+
+	s.mon = mon.NewMonitorInheritWithLimit(
+		mon.MakeName("pg-replication"), replicationBufferSize.Get(&cfg.Settings.SV), parentMon,
+		false, /* longLiving */
+	)
+	s.mon.StartNoReserved(ctx, parentMon)
+	s.acc = s.mon.MakeBoundAccount()
+	return s, nil
+}
+
+// replicatedTableSpans returns the primary index spans of the tables of the
+// given publications of the database, in key order.
+func replicatedTableSpans(
+	ctx context.Context, codec keys.SQLCodec, txn descs.Txn, dbID descpb.ID, publications []string,
+) ([]roachpb.Span, error) {
+	db, err := txn.Descriptors().ByIDWithLeased(txn.KV()).Get().Database(ctx, dbID)
+	if err != nil {
+		return nil, err
+	}
+	var seen catalog.DescriptorIDSet
+	var spans []roachpb.Span
+	for _, name := range publications {
+		pub, ok := db.GetPublication(name)
+		if !ok {
+			return nil, pgerror.Newf(pgcode.UndefinedObject, "publication %q does not exist", name)
+		}
+		tables, err := publicationTables(ctx, txn.Descriptors(), txn.KV(), db, pub)
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range tables {
+			if seen.Contains(table.GetID()) {
+				continue
+			}
+			seen.Add(table.GetID())
+			if err := checkReplicatedTable(table); err != nil {
+				return nil, err
+			}
+			spans = append(spans, table.PrimaryIndexSpan(codec))
+		}
+	}
+	slices.SortFunc(spans, func(a, b roachpb.Span) int { return a.Key.Compare(b.Key) })
+	return spans, nil
+}
+
+// validateReplicationOptions validates the options of the pgoutput plugin and
+// returns the names of the requested publications.
+func validateReplicationOptions(opts pgrepltree.Options) (publications []string, _ error) {
+	var hasPublications bool
+	for _, opt := range opts {
+		var val string
+		if s, ok := opt.Value.(*tree.StrVal); ok {
+			val = s.RawString()
+		}
+		switch opt.Key {
+		case "proto_version":
+			if val != "1" {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"client sent proto_version=%s but server only supports protocol %d", val, pgoutput.ProtoVersion)
+			}
+		case "publication_names":
+			names, err := parsePublicationNames(val)
+			if err != nil {
+				return nil, err
+			}
+			hasPublications = true
+			publications = append(publications, names...)
+		case "binary":
+			if val == "true" || val == "on" || val == "1" {
+				return nil, unimplemented.New("pgoutput binary", "binary output is not supported")
+			}
+		case "messages", "streaming", "origin":
+			// Neither logical decoding messages nor streaming of in-progress
+			// transactions are produced, so these options have no effect.
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized pgoutput option: %s", opt.Key)
+		}
+	}
+	if !hasPublications {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "publication_names parameter missing")
+	}
+	return publications, nil
+}
+
+// parsePublicationNames parses the comma-separated list of publication names
+// of the publication_names option. As in PostgreSQL, names may be double
+// quoted, and unquoted names are folded to lower case.
+func parsePublicationNames(val string) ([]string, error) {
+	invalid := func() error {
+		return pgerror.New(pgcode.InvalidName, "invalid publication_names syntax")
+	}
+	var names []string
+	for i := 0; ; {
+		for i < len(val) && unicode.IsSpace(rune(val[i])) {
+			i++
+		}
+		var name string
+		if i < len(val) && val[i] == '"' {
+			var sb strings.Builder
+			for i++; ; i++ {
+				if i >= len(val) {
+					return nil, invalid()
+				}
+				if val[i] == '"' {
+					if i+1 < len(val) && val[i+1] == '"' {
+						sb.WriteByte('"')
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				sb.WriteByte(val[i])
+			}
+			name = sb.String()
+		} else {
+			start := i
+			for i < len(val) && val[i] != ',' && !unicode.IsSpace(rune(val[i])) {
+				i++
+			}
+			name = strings.ToLower(val[start:i])
+		}
+		if name == "" {
+			return nil, invalid()
+		}
+		names = append(names, name)
+		for i < len(val) && unicode.IsSpace(rune(val[i])) {
+			i++
+		}
+		if i == len(val) {
+			return names, nil
+		}
+		if val[i] != ',' {
+			return nil, invalid()
+		}
+		i++
+	}
+}
+
+// checkReplicatedTable returns an error if the changes to the table cannot be
+// replicated.
+func checkReplicatedTable(table catalog.TableDescriptor) error {
+	if table.NumFamilies() > 1 {
+		return unimplemented.Newf("replication of column families",
+			"cannot replicate table %q with multiple column families", table.GetName())
+	}
+	return nil
+}
+
+// run streams changes to the client until the client ends the stream.
+func (s *replicationStream) run(
+	ctx context.Context, cmd StartReplication, res StartReplicationResult,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer s.close(ctx)
+
+	s.events = make(chan replicationEvent, 1024)
+	descFeed, err := s.cfg.RangeFeedFactory.RangeFeed(ctx, "pg-replication-descriptors-"+s.slot.Name,
+		[]roachpb.Span{s.cfg.Codec.TableSpan(keys.DescriptorTableID)}, s.tablesAsOf,
+		func(ctx context.Context, value *kvpb.RangeFeedValue) {
+			s.send(ctx, replicationEvent{gen: descriptorFeedGen, value: value})
+		},
+		rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+			s.send(ctx, replicationEvent{gen: descriptorFeedGen, frontier: ts})
+		}),
+		rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+			s.send(ctx, replicationEvent{gen: descriptorFeedGen, err: err})
+		}),
+	)
+	if err != nil {
+		return err
+	}
+	defer descFeed.Close()
+	if err := s.startTableFeed(ctx, s.start); err != nil {
+		return err
+	}
+
+	if err := res.SendCopyBoth(ctx); err != nil {
+		return err
+	}
+
+	var keepalive timeutil.Timer
+	defer keepalive.Stop()
+	keepalive.Reset(replicationKeepaliveInterval)
+	for {
+		select {
+		case ev := <-s.events:
+			if err := s.handleEvent(ctx, res, ev); err != nil {
+				return err
+			}
+		case flushed := <-cmd.Feedback:
+			if err := s.advanceSlot(ctx, flushed); err != nil {
+				return err
+			}
+		case <-keepalive.C:
+			keepalive.Read = true
+			s.buf = pgoutput.AppendPrimaryKeepalive(s.buf[:0], s.walEnd, timeutil.Now(), false /* replyRequested */)
+			if err := res.SendReplicationData(ctx, s.buf); err != nil {
+				return err
+			}
+			if err := res.FlushReplicationData(ctx); err != nil {
+				return err
+			}
+			keepalive.Reset(replicationKeepaliveInterval)
+		case <-cmd.ClientDone:
+			// Feedback sent right before CopyDone must not be lost.
+			select {
+			case flushed := <-cmd.Feedback:
+				if err := s.advanceSlot(ctx, flushed); err != nil {
+					return err
+				}
+			default:
+			}
+			return res.SendCopyDone(ctx)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// send delivers an event emitted by one of the rangefeeds of the stream.
+func (s *replicationStream) send(ctx context.Context, ev replicationEvent) {
+	select {
+	case s.events <- ev:
+	case <-ctx.Done():
+	}
+}
+
+// close releases the resources held by the stream.
+func (s *replicationStream) close(ctx context.Context) {
+	if s.tableFeed != nil {
+		s.tableFeed.Close()
+	}
+	s.releaseLeases(ctx)
+	s.acc.Close(ctx)
+	s.mon.Stop(ctx)
+}
+
+// startTableFeed starts the table rangefeed from the given timestamp,
+// replacing the current one. The pending values are discarded, since the new
+// rangefeed delivers them again, and so are the events of the previous
+// rangefeeds which are still buffered.
+func (s *replicationStream) startTableFeed(ctx context.Context, start hlc.Timestamp) error {
+	if s.tableFeed != nil {
+		s.tableFeed.Close()
+		s.tableFeed = nil
+	}
+	s.tableFeedGen++
+	s.tableFrontier = start
+	s.pending = nil
+	s.acc.Clear(ctx)
+	s.cutoff = hlc.Timestamp{}
+	if len(s.spans) == 0 {
+		return nil
+	}
+
+	gen := s.tableFeedGen
+	rf, err := s.cfg.RangeFeedFactory.RangeFeed(ctx, "pg-replication-"+s.slot.Name, s.spans, start,
+		func(ctx context.Context, value *kvpb.RangeFeedValue) {
+			s.send(ctx, replicationEvent{gen: gen, value: value})
+		},
+		rangefeed.WithDiff(true),
+		rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+			s.send(ctx, replicationEvent{gen: gen, frontier: ts})
+		}),
+		rangefeed.WithOnSSTable(func(ctx context.Context, _ *kvpb.RangeFeedSSTable, _ roachpb.Span) {
+			s.send(ctx, replicationEvent{gen: gen, err: unimplemented.New("replication of ingested data",
+				"cannot replicate data ingested by a bulk operation")})
+		}),
+		rangefeed.WithOnDeleteRange(func(ctx context.Context, _ *kvpb.RangeFeedDeleteRange) {
+			// MVCC range deletions are only written when dropping or truncating
+			// a table, which are not replicated.
+			log.VInfof(ctx, 2, "ignoring range deletion in replication slot %s", s.slot.Name)
+		}),
+		rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+			s.send(ctx, replicationEvent{gen: gen, err: err})
+		}),
+	)
+	if err != nil {
+		return err
+	}
+	s.tableFeed = rf
+	return nil
+}
+
+// handleEvent processes an event emitted by one of the rangefeeds.
+func (s *replicationStream) handleEvent(
+	ctx context.Context, res StartReplicationResult, ev replicationEvent,
+) error {
+	isDescEvent := ev.gen == descriptorFeedGen
+	if !isDescEvent && ev.gen != s.tableFeedGen {
+		// The event was emitted by a table rangefeed which has been replaced.
+		return nil
+	}
+	switch {
+	case ev.err != nil:
+		return ev.err
+	case ev.value != nil && isDescEvent:
+		return s.refreshTables(ctx, ev.value.Timestamp())
+	case ev.value != nil:
+		return s.buffer(ctx, ev.value)
+	case isDescEvent:
+		s.descFrontier.Forward(ev.frontier)
+	default:
+		s.tableFrontier.Forward(ev.frontier)
+	}
+	return s.flush(ctx, res)
+}
+
+// refreshTables reads the set of replicated tables as of ts, the timestamp of
+// a descriptor change, and restarts the table rangefeed if it changed. The
+// descriptor frontier has not reached ts yet, so neither have the changes
+// sent to the client, and the restarted rangefeed delivers all the changes to
+// a new table.
+func (s *replicationStream) refreshTables(ctx context.Context, ts hlc.Timestamp) error {
+	if ts.LessEq(s.tablesAsOf) {
+		return nil
+	}
+	var spans []roachpb.Span
+	if err := s.cfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+		if err := txn.KV().SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		spans, err = replicatedTableSpans(ctx, s.cfg.Codec, txn, s.slot.DatabaseID, s.publications)
+		return err
+	}); err != nil {
+		return err
+	}
+	s.tablesAsOf = ts
+	if slices.EqualFunc(spans, s.spans, roachpb.Span.Equal) {
+		return nil
+	}
+	s.spans = spans
+	return s.startTableFeed(ctx, lsnutil.LSNToHLC(s.walEnd))
+}
+
+// buffer adds a value received from the table rangefeed to the pending values.
+// If the value doesn't fit in the memory budget, the most recent pending
+// values are shed to make room for it.
+func (s *replicationStream) buffer(ctx context.Context, value *kvpb.RangeFeedValue) error {
+	for {
+		if !s.cutoff.IsEmpty() && s.cutoff.Less(value.Timestamp()) {
+			return nil
+		}
+		err := s.acc.Grow(ctx, int64(value.Size()))
+		if err == nil {
+			break
+		}
+		if !s.shed(ctx) {
+			return errors.Wrapf(err, "buffering changes for replication slot %s", s.slot.Name)
+		}
+	}
+	s.pending = append(s.pending, value)
+	return nil
+}
+
+// shed releases the memory used by about half of the pending values by
+// lowering the cutoff to the end of the transaction in their middle. This
+// applies backpressure to the stream: the dropped values are delivered again
+// once the client has been sent the changes below the cutoff. It returns
+// false if there is nothing to shed because all the pending values belong to
+// the oldest pending transaction.
+func (s *replicationStream) shed(ctx context.Context) bool {
+	if len(s.pending) == 0 {
+		return false
+	}
+	s.sortPending()
+	lsnAt := func(i int) lsn.LSN {
+		return lsnutil.HLCToLSN(s.pending[i].Timestamp())
+	}
+	n := len(s.pending) / 2
+	for n > 0 && lsnAt(n-1) == lsnAt(n) {
+		n--
+	}
+	if n == 0 {
+		// Keep the oldest transaction, which must be sent as a whole.
+		n = sort.Search(len(s.pending), func(i int) bool { return lsnAt(i) > lsnAt(0) })
+	}
+	if n == len(s.pending) {
+		return false
+	}
+	s.cutoff = lsnutil.LSNToHLC(lsnAt(n - 1))
+	var shed int64
+	for i := n; i < len(s.pending); i++ {
+		shed += int64(s.pending[i].Size())
+		s.pending[i] = nil
+	}
+	s.pending = s.pending[:n]
+	s.acc.Shrink(ctx, shed)
+	return true
+}
+
+func (s *replicationStream) sortPending() {
+	sort.Slice(s.pending, func(i, j int) bool {
+		return s.pending[i].Timestamp().Less(s.pending[j].Timestamp())
+	})
+}
+
+// advanceSlot moves the slot's confirmed flush position to the position
+// reported by the client. The position is capped at the end of what has been
+// sent to the client, since it can only confirm changes it has received.
+func (s *replicationStream) advanceSlot(ctx context.Context, flushed lsn.LSN) error {
+	if flushed > s.walEnd {
+		flushed = s.walEnd
+	}
+	ts := lsnutil.LSNToHLC(flushed)
+	if ts.LessEq(s.slot.ConfirmedFlush) {
+		return nil
+	}
+	if err := s.cfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return replslot.Advance(ctx, s.cfg.ProtectedTimestampProvider.WithTxn(txn), s.slot.Name, ts)
+	}); err != nil {
+		return err
+	}
+	s.slot.ConfirmedFlush = ts
+	return nil
+}
+
+// flush sends the transactions which are complete given the frontiers of the
+// rangefeeds.
+func (s *replicationStream) flush(ctx context.Context, res StartReplicationResult) error {
+	frontier := s.descFrontier
+	if s.tableFeed != nil && s.tableFrontier.Less(frontier) {
+		frontier = s.tableFrontier
+	}
+	// More changes with the frontier's wall time may still arrive, so only the
+	// transactions before it are complete.
+	safeLSN := lsnutil.HLCToLSN(frontier) - 1
+	if !s.cutoff.IsEmpty() && lsnutil.HLCToLSN(s.cutoff) < safeLSN {
+		safeLSN = lsnutil.HLCToLSN(s.cutoff)
+	}
+	if safeLSN <= s.walEnd {
+		return nil
+	}
+	safe := lsnutil.LSNToHLC(safeLSN)
+	s.sortPending()
+	n := sort.Search(len(s.pending), func(i int) bool {
+		return safe.Less(s.pending[i].Timestamp())
+	})
+	var sent int64
+	for i := 0; i < n; {
+		wallTime := s.pending[i].Timestamp().WallTime
+		j := i + 1
+		for j < n && s.pending[j].Timestamp().WallTime == wallTime {
+			j++
+		}
+		if err := s.sendTransaction(ctx, res, s.pending[i:j]); err != nil {
+			return err
+		}
+		for _, v := range s.pending[i:j] {
+			sent += int64(v.Size())
+		}
+		i = j
+	}
+	s.releaseLeases(ctx)
+	s.pending = append(s.pending[:0], s.pending[n:]...)
+	s.acc.Shrink(ctx, sent)
+	s.walEnd = safeLSN
+	if err := res.FlushReplicationData(ctx); err != nil {
+		return err
+	}
+	if !s.cutoff.IsEmpty() && s.walEnd == lsnutil.HLCToLSN(s.cutoff) {
+		// Everything up to the cutoff has been sent; restart the table
+		// rangefeed to receive the values which were shed.
+		return s.startTableFeed(ctx, s.cutoff)
+	}
+	return nil
+}
+
+// sendTransaction sends a transaction made of the given values, which all
+// share the same wall time.
+func (s *replicationStream) sendTransaction(
+	ctx context.Context, res StartReplicationResult, values []*kvpb.RangeFeedValue,
+) error {
+	ts := values[0].Timestamp()
+	txnLSN := lsnutil.HLCToLSN(ts)
+	commitTime := timeutil.Unix(0, ts.WallTime)
+	s.xid++
+
+	sendMessage := func(appendMsg func([]byte) []byte) error {
+		s.buf = pgoutput.AppendXLogDataHeader(s.buf[:0], txnLSN, txnLSN, timeutil.Now())
+		s.buf = appendMsg(s.buf)
+		return res.SendReplicationData(ctx, s.buf)
+	}
+
+	if err := sendMessage(func(buf []byte) []byte {
+		return pgoutput.AppendBegin(buf, txnLSN, commitTime, s.xid)
+	}); err != nil {
+		return err
+	}
+	for _, v := range values {
+		r, err := s.relation(ctx, v.Key, v.Timestamp())
+		if err != nil {
+			return err
+		}
+		if !r.sent {
+			if err := sendMessage(func(buf []byte) []byte {
+				return pgoutput.AppendRelation(buf, &r.rel)
+			}); err != nil {
+				return err
+			}
+			r.sent = true
+		}
+		datums, deleted, err := r.decode(ctx, roachpb.KeyValue{Key: v.Key, Value: v.Value})
+		if err != nil {
+			return err
+		}
+		if err := sendMessage(func(buf []byte) []byte {
+			switch {
+			case deleted:
+				return pgoutput.AppendDelete(buf, r.rel.ID, r.keyOnly(datums))
+			case v.PrevValue.IsPresent():
+				// The primary key cannot change without writing a different KV, so
+				// the old key is never sent.
+				return pgoutput.AppendUpdate(buf, r.rel.ID, nil /* oldKey */, datums)
+			default:
+				return pgoutput.AppendInsert(buf, r.rel.ID, datums)
+			}
+		}); err != nil {
+			return err
+		}
+	}
+	return sendMessage(func(buf []byte) []byte {
+		return pgoutput.AppendCommit(buf, txnLSN, txnLSN, commitTime)
+	})
+}
+
+// relation returns the relation of the table containing key, as of ts.
+func (s *replicationStream) relation(
+	ctx context.Context, key roachpb.Key, ts hlc.Timestamp,
+) (*replicationRelation, error) {
+	_, tableID, err := s.cfg.Codec.DecodeTablePrefix(key)
+	if err != nil {
+		return nil, err
+	}
+	id := descpb.ID(tableID)
+	leased, ok := s.leases[id]
+	if !ok || !leaseValidAt(ctx, leased, ts) {
+		if ok {
+			leased.Release(ctx)
+			delete(s.leases, id)
+		}
+		if leased, err = s.cfg.LeaseManager.Acquire(ctx, ts, id); err != nil {
+			return nil, err
+		}
+		s.leases[id] = leased
+	}
+	version := leased.Underlying().GetVersion()
+	if r, ok := s.relations[id]; ok && r.version == version {
+		return r, nil
+	}
+
+	// The descriptor is read through a descs.Collection so that its
+	// user-defined types are hydrated.
+	var table catalog.TableDescriptor
+	var schema catalog.SchemaDescriptor
+	if err := s.cfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) (err error) {
+		if err := txn.KV().SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		g := txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get()
+		if table, err = g.Table(ctx, id); err != nil {
+			return err
+		}
+		schema, err = g.Schema(ctx, table.GetParentSchemaID())
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if err := checkReplicatedTable(table); err != nil {
+		return nil, err
+	}
+	r, err := newReplicationRelation(ctx, s.cfg.Codec, table, schema)
+	if err != nil {
+		return nil, err
+	}
+	s.relations[id] = r
+	return r, nil
+}
+
+// leaseValidAt returns whether the leased descriptor is the version of the
+// descriptor at ts.
+func leaseValidAt(ctx context.Context, leased lease.LeasedDescriptor, ts hlc.Timestamp) bool {
+	return leased.Underlying().GetModificationTime().LessEq(ts) && ts.Less(leased.Expiration(ctx))
+}
+
+// releaseLeases releases the cached descriptor leases.
+func (s *replicationStream) releaseLeases(ctx context.Context) {
+	for id, leased := range s.leases {
+		leased.Release(ctx)
+		delete(s.leases, id)
+	}
+}
+
+func newReplicationRelation(
+	ctx context.Context,
+	codec keys.SQLCodec,
+	table catalog.TableDescriptor,
+	schema catalog.SchemaDescriptor,
+) (*replicationRelation, error) {
+	r := &replicationRelation{
+		version: table.GetVersion(),
+		rel: pgoutput.Relation{
+			ID:        oid.Oid(table.GetID()),
+			Namespace: schema.GetName(),
+			Name:      table.GetName(),
+		},
+	}
+	keyCols := table.GetPrimaryIndex().CollectKeyColumnIDs()
+	var colIDs []descpb.ColumnID
+	for _, col := range table.PublicColumns() {
+		if col.IsVirtual() || col.IsInaccessible() {
+			continue
+		}
+		colIDs = append(colIDs, col.GetID())
+		r.rel.Columns = append(r.rel.Columns, pgoutput.Column{
+			Name: col.GetName(),
+			Type: col.GetType(),
+			Key:  keyCols.Contains(col.GetID()),
+		})
+	}
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(&spec, codec, table, table.GetPrimaryIndex(), colIDs); err != nil {
+		return nil, err
+	}
+	if err := r.fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &r.alloc,
+		Spec:              &spec,
+	}); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// decode decodes the row stored in kv, and reports whether it was deleted.
+func (r *replicationRelation) decode(
+	ctx context.Context, kv roachpb.KeyValue,
+) (tree.Datums, bool, error) {
+	if err := r.fetcher.ConsumeKVProvider(ctx, &row.KVProvider{KVs: []roachpb.KeyValue{kv}}); err != nil {
+		return nil, false, err
+	}
+	datums, err := r.fetcher.NextRowDecoded(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if datums == nil {
+		return nil, false, errors.AssertionFailedf("no row decoded from key %s", kv.Key)
+	}
+	// Copy the datums since the fetcher reuses them.
+	return append(tree.Datums(nil), datums...), r.fetcher.RowIsDeleted(), nil
+}
+
+// keyOnly replaces the values of the non-key columns of datums with NULL.
+func (r *replicationRelation) keyOnly(datums tree.Datums) tree.Datums {
+	for i := range datums {
+		if !r.rel.Columns[i].Key {
+			datums[i] = tree.DNull
+		}
+	}
+	return datums
+}
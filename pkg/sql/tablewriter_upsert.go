@@ -69,12 +69,22 @@ type tableUpserter struct {
 	// an update is performed. This column will always be one of the fetchCols.
 	canaryOrdinal int
 
+	// deleteOrdinal is the ordinal position of the column within the input row
+	// that is used to decide whether to delete an existing row rather than
+	// update it. It is only set for MERGE statements with DELETE actions, and is
+	// -1 otherwise.
+	deleteOrdinal int
+
 	// resultRow is a reusable slice of Datums used to store result rows.
 	resultRow tree.Datums
 
 	// ru is used when updating rows.
 	ru row.Updater
 
+	// rd is used when deleting rows. It is only initialized if deleteOrdinal is
+	// not -1.
+	rd row.Deleter
+
 	// tabColIdxToRetIdx is the mapping from the columns in the table to the
 	// columns in the resultRowBuffer. A value of -1 is used to indicate
 	// that the table column at that index is not part of the resultRowBuffer
@@ -193,8 +203,13 @@ func (tu *tableUpserter) row(
 		return tu.insertNonConflictingRow(ctx, datums[:insertEnd], pm, vh, row.CPutOp, traceKV)
 	}
 
-	// If no columns need to be updated, then possibly collect the unchanged row.
+	// The existing row may be deleted by a MERGE statement.
 	fetchEnd := insertEnd + len(tu.fetchCols)
+	if tu.deleteOrdinal != -1 && datums[tu.deleteOrdinal] == tree.DBoolTrue {
+		return tu.deleteConflictingRow(ctx, tu.b, datums[insertEnd:fetchEnd], pm, vh, traceKV)
+	}
+
+	// If no columns need to be updated, then possibly collect the unchanged row.
 	if len(tu.updateCols) == 0 {
 		if !tu.rowsNeeded {
 			return nil
@@ -323,6 +338,37 @@ func (tu *tableUpserter) updateConflictingRow(
 	return err
 }
 
+// deleteConflictingRow deletes an existing row from the table for a MERGE
+// statement. The existing values from the row are provided in fetchRow. If the
+// RETURNING clause was specified, then the deleted row is stored in the
+// rowsUpserted collection.
+func (tu *tableUpserter) deleteConflictingRow(
+	ctx context.Context,
+	b *kv.Batch,
+	fetchRow tree.Datums,
+	pm row.PartialIndexUpdateHelper,
+	vh row.VectorIndexUpdateHelper,
+	traceKV bool,
+) error {
+	if err := tu.rd.DeleteRow(ctx, b, fetchRow, pm, vh, nil /* oth */, traceKV); err != nil {
+		return err
+	}
+
+	if !tu.rowsNeeded {
+		return nil
+	}
+
+	// Return the values of the row before it was deleted.
+	tableRow := tu.makeResultFromRow(fetchRow, tu.rd.FetchColIDtoRowIndex)
+	for tabIdx := range tableRow {
+		if retIdx := tu.tabColIdxToRetIdx[tabIdx]; retIdx >= 0 {
+			tu.resultRow[retIdx] = tableRow[tabIdx]
+		}
+	}
+	_, err := tu.rows.AddRow(ctx, tu.resultRow)
+	return err
+}
+
 // tableDesc returns the TableDescriptor for the table that the optTableInserter
 // will modify.
 func (tu *tableUpserter) tableDesc() catalog.TableDescriptor {
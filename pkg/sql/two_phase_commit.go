@@ -88,6 +88,13 @@ func (ex *connExecutor) execPrepareTransactionInOpenStateInternal(
 		return pgerror.Newf(pgcode.InvalidTransactionState,
 			"cannot prepare a transaction that has already performed schema changes")
 	}
+	if len(ex.extraTxnState.pendingListens) > 0 {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"cannot prepare a transaction that has executed LISTEN or UNLISTEN")
+	}
+	if err := ex.validatePendingConstraints(ctx); err != nil {
+		return err
+	}
 
 	txn := ex.state.mu.txn
 	txnID := txn.ID()
@@ -70,6 +70,12 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_int8:       Int,
 	oid.T_inet:       INet,
 	oid.T_interval:   Interval,
+	oid.T_int4range:  Int4Range,
+	oid.T_int8range:  Int8Range,
+	oid.T_numrange:   NumRange,
+	oid.T_tsrange:    TSRange,
+	oid.T_tstzrange:  TSTZRange,
+	oid.T_daterange:  DateRange,
 	// NOTE(sql-exp): Uncomment the line below if we support the JSON type.
 	// This would potentially require us to convert the type descriptors of
 	// existing tables.
@@ -102,11 +108,20 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_varchar:      VarChar,
 	oid.T_void:         Void,
 
+	oid.T_event_trigger: EventTrigger,
+
 	oidext.T_geometry:  Geometry,
 	oidext.T_geography: Geography,
 	oidext.T_box2d:     Box2D,
 	oidext.T_pgvector:  PGVector,
 	oidext.T_jsonpath:  Jsonpath,
+
+	oidext.T_int4multirange: Int4Multirange,
+	oidext.T_int8multirange: Int8Multirange,
+	oidext.T_nummultirange:  NumMultirange,
+	oidext.T_tsmultirange:   TSMultirange,
+	oidext.T_tstzmultirange: TSTZMultirange,
+	oidext.T_datemultirange: DateMultirange,
 }
 
 // oidToArrayOid maps scalar type Oids to their corresponding array type Oid.
@@ -126,6 +141,12 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_int4:         oid.T__int4,
 	oid.T_int8:         oid.T__int8,
 	oid.T_interval:     oid.T__interval,
+	oid.T_int4range:    oid.T__int4range,
+	oid.T_int8range:    oid.T__int8range,
+	oid.T_numrange:     oid.T__numrange,
+	oid.T_tsrange:      oid.T__tsrange,
+	oid.T_tstzrange:    oid.T__tstzrange,
+	oid.T_daterange:    oid.T__daterange,
 	oid.T_jsonb:        oid.T__jsonb,
 	oid.T_name:         oid.T__name,
 	oid.T_numeric:      oid.T__numeric,
@@ -156,6 +177,13 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oidext.T_box2d:     oidext.T__box2d,
 	oidext.T_pgvector:  oidext.T__pgvector,
 	oidext.T_jsonpath:  oidext.T__jsonpath,
+
+	oidext.T_int4multirange: oidext.T__int4multirange,
+	oidext.T_int8multirange: oidext.T__int8multirange,
+	oidext.T_nummultirange:  oidext.T__nummultirange,
+	oidext.T_tsmultirange:   oidext.T__tsmultirange,
+	oidext.T_tstzmultirange: oidext.T__tstzmultirange,
+	oidext.T_datemultirange: oidext.T__datemultirange,
 }
 
 // familyToOid maps each type family to a default OID value that is used when
@@ -210,6 +238,10 @@ func init() {
 // type.
 func CalcArrayOid(elemTyp *T) oid.Oid {
 	o := elemTyp.Oid()
+	if elemTyp.IsDomain() {
+		// A domain has the family of its base type, but an array type of its own.
+		return elemTyp.UserDefinedArrayOID()
+	}
 	switch elemTyp.Family() {
 	case ArrayFamily:
 		// Postgres nested arrays return the OID of the nested array (i.e. the
@@ -221,6 +221,24 @@ type UserDefinedTypeMetadata struct {
 	// for a table. Note: this can be deleted if we migrate implicit record types
 	// to ordinary persisted composite types.
 	ImplicitRecordType bool
+
+	// DomainData is non-nil iff the metadata is for a domain.
+	DomainData *DomainMetadata
+}
+
+// DomainMetadata is metadata about the constraints of a domain needed to
+// enforce them.
+type DomainMetadata struct {
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// DefaultExpr is the serialized default expression of the domain, or nil
+	// if it has none.
+	DefaultExpr *string
+	// CheckNames and CheckExprs are the names and serialized expressions of the
+	// CHECK constraints of the domain. The expressions refer to the value being
+	// checked as VALUE.
+	CheckNames []string
+	CheckExprs []string
 }
 
 // EnumMetadata is metadata about an ENUM needed for evaluation.
@@ -644,6 +662,11 @@ var (
 	Trigger = &T{InternalType: InternalType{
 		Family: TriggerFamily, Oid: oid.T_trigger, Locale: &emptyLocale}}
 
+	// EventTrigger is a special type used for event trigger functions, which are
+	// invoked in response to DDL commands and do not return a value.
+	EventTrigger = &T{InternalType: InternalType{
+		Family: TriggerFamily, Oid: oid.T_event_trigger, Locale: &emptyLocale}}
+
 	// StringArray is the type of an array value having String-typed elements.
 	StringArray = &T{InternalType: InternalType{
 		Family: ArrayFamily, ArrayContents: String, Oid: oid.T__text, Locale: &emptyLocale}}
@@ -744,6 +767,66 @@ var (
 	}
 )
 
+// Built-in range and multirange types.
+var (
+	// Int4Range is the type of a range of INT4 values.
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of INT8 values.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// NumRange is the type of a range of DECIMAL values.
+	NumRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_numrange, Locale: &emptyLocale}}
+
+	// TSRange is the type of a range of TIMESTAMP values.
+	TSRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TSTZRange is the type of a range of TIMESTAMPTZ values.
+	TSTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of DATE values.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// Int4Multirange is the type of a multirange of INT4 values.
+	Int4Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int4multirange, Locale: &emptyLocale}}
+
+	// Int8Multirange is the type of a multirange of INT8 values.
+	Int8Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int8multirange, Locale: &emptyLocale}}
+
+	// NumMultirange is the type of a multirange of DECIMAL values.
+	NumMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_nummultirange, Locale: &emptyLocale}}
+
+	// TSMultirange is the type of a multirange of TIMESTAMP values.
+	TSMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tsmultirange, Locale: &emptyLocale}}
+
+	// TSTZMultirange is the type of a multirange of TIMESTAMPTZ values.
+	TSTZMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tstzmultirange, Locale: &emptyLocale}}
+
+	// DateMultirange is the type of a multirange of DATE values.
+	DateMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_datemultirange, Locale: &emptyLocale}}
+
+	// RangeTypes contains all of the built-in range types.
+	RangeTypes = []*T{Int4Range, Int8Range, NumRange, TSRange, TSTZRange, DateRange}
+
+	// MultirangeTypes contains all of the built-in multirange types, in the
+	// same order as RangeTypes.
+	MultirangeTypes = []*T{
+		Int4Multirange, Int8Multirange, NumMultirange, TSMultirange, TSTZMultirange, DateMultirange,
+	}
+)
+
 // Unexported wrapper types.
 var (
 	// typeBit is the SQL BIT type with an unspecified width. It is not exported
@@ -1225,6 +1308,20 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeDomain constructs a new instance of a domain type with the given stable
+// type ID, over the given base type. The domain has the family and the other
+// attributes of its base type. Note that it does not hydrate cached fields on
+// the type.
+func MakeDomain(typeOID, arrayTypeOID oid.Oid, base *T) *T {
+	internalType := base.InternalType
+	internalType.Oid = typeOID
+	internalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID:  arrayTypeOID,
+		DomainBaseOID: base.Oid(),
+	}
+	return &T{InternalType: internalType}
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1244,6 +1341,48 @@ func MakeArray(typ *T) *T {
 	return arr
 }
 
+// RangeSubtype returns the type of the bounds of a RangeFamily type, or of the
+// bounds of the ranges in a MultirangeFamily type.
+func (t *T) RangeSubtype() *T {
+	switch t.Oid() {
+	case oid.T_int4range, oidext.T_int4multirange:
+		return Int4
+	case oid.T_int8range, oidext.T_int8multirange:
+		return Int
+	case oid.T_numrange, oidext.T_nummultirange:
+		return Decimal
+	case oid.T_tsrange, oidext.T_tsmultirange:
+		return Timestamp
+	case oid.T_tstzrange, oidext.T_tstzmultirange:
+		return TimestampTZ
+	case oid.T_daterange, oidext.T_datemultirange:
+		return Date
+	}
+	panic(errors.AssertionFailedf("unexpected range type %s", t.SQLStringForError()))
+}
+
+// MultirangeContents returns the range type of the elements of a
+// MultirangeFamily type.
+func (t *T) MultirangeContents() *T {
+	for i, mr := range MultirangeTypes {
+		if mr.Oid() == t.Oid() {
+			return RangeTypes[i]
+		}
+	}
+	panic(errors.AssertionFailedf("unexpected multirange type %s", t.SQLStringForError()))
+}
+
+// MakeMultirange returns the multirange type whose elements have the given
+// RangeFamily type.
+func MakeMultirange(rangeTyp *T) *T {
+	for i, r := range RangeTypes {
+		if r.Oid() == rangeTyp.Oid() {
+			return MultirangeTypes[i]
+		}
+	}
+	panic(errors.AssertionFailedf("unexpected range type %s", rangeTyp.SQLStringForError()))
+}
+
 // MakeTuple constructs a new instance of a TupleFamily type with the given
 // field types (some/all of which may be other TupleFamily types).
 //
@@ -1530,6 +1669,23 @@ func (t *T) UserDefinedArrayOID() oid.Oid {
 	return t.InternalType.UDTMetadata.ArrayTypeOID
 }
 
+// IsDomain returns true if t is a domain.
+func (t *T) IsDomain() bool {
+	return t.InternalType.UDTMetadata != nil && t.InternalType.UDTMetadata.DomainBaseOID != 0
+}
+
+// DomainBaseType returns the base type of a domain. It returns t itself if t
+// is not a domain.
+func (t *T) DomainBaseType() *T {
+	if !t.IsDomain() {
+		return t
+	}
+	base := &T{InternalType: t.InternalType}
+	base.InternalType.Oid = t.InternalType.UDTMetadata.DomainBaseOID
+	base.InternalType.UDTMetadata = nil
+	return base
+}
+
 // RemapUserDefinedTypeOIDs is used to remap OIDs stored within a types.T
 // that is a user defined type. The newArrayOID argument is ignored if the
 // input type is an Array type. It mutates the input types.T and should only
@@ -1591,6 +1747,8 @@ var familyNames = map[Family]redact.SafeString{
 	VoidFamily:           "void",
 	EncodedKeyFamily:     "encodedkey",
 	JsonpathFamily:       "jsonpath",
+	RangeFamily:          "range",
+	MultirangeFamily:     "multirange",
 }
 
 // Name returns a user-friendly word indicating the family type.
@@ -1612,6 +1770,9 @@ func (f Family) Name() redact.SafeString {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.Basename()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		switch t.Oid() {
@@ -1685,6 +1846,15 @@ func (t *T) Name() string {
 	case TupleFamily:
 		return t.SQLStandardName()
 
+	case RangeFamily, MultirangeFamily:
+		return t.PGName()
+
+	case TriggerFamily:
+		if t.Oid() == oid.T_event_trigger {
+			return "event_trigger"
+		}
+		return "trigger"
+
 	case EnumFamily:
 		if t.Oid() == oid.T_anyenum {
 			return "anyenum"
@@ -1759,6 +1929,9 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.Basename()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
@@ -1871,6 +2044,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "pg_lsn"
 	case PGVectorFamily:
 		return "vector"
+	case RangeFamily, MultirangeFamily:
+		return t.PGName()
 	case RefCursorFamily:
 		return "refcursor"
 	case StringFamily, CollatedStringFamily:
@@ -1931,6 +2106,9 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		}
 		return fmt.Sprintf("timestamp(%d) with time zone", typmod)
 	case TriggerFamily:
+		if t.Oid() == oid.T_event_trigger {
+			return "event_trigger"
+		}
 		return "trigger"
 	case TSQueryFamily:
 		return "tsquery"
@@ -1976,6 +2154,12 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() {
+		if t.TypeMeta.Name == nil {
+			return fmt.Sprintf("@%d", t.Oid())
+		}
+		return t.TypeMeta.Name.FQName(false /* explicitCatalog */)
+	}
 	switch t.Family() {
 	case BitFamily:
 		switch t.Oid() {
@@ -2169,7 +2353,8 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		IntervalFamily, StringFamily, BytesFamily, TimestampTZFamily, CollatedStringFamily, OidFamily,
 		UnknownFamily, UuidFamily, INetFamily, TimeFamily, JsonFamily, TimeTZFamily, BitFamily,
 		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, EncodedKeyFamily, TSQueryFamily,
-		TSVectorFamily, AnyFamily, PGLSNFamily, PGVectorFamily, RefCursorFamily:
+		TSVectorFamily, AnyFamily, PGLSNFamily, PGVectorFamily, RefCursorFamily, RangeFamily,
+		MultirangeFamily:
 		// These types do not contain other types, and do not require redaction.
 		return redact.Sprint(redact.SafeString(t.SQLString()))
 	}
@@ -2244,6 +2429,12 @@ func (t *T) Equivalent(other *T) bool {
 		if t.Oid() != other.Oid() {
 			return false
 		}
+
+	case RangeFamily, MultirangeFamily:
+		// Ranges over different subtypes are not equivalent.
+		if t.Oid() != other.Oid() {
+			return false
+		}
 	}
 
 	return true
@@ -2332,7 +2523,7 @@ func (t *T) IsPolymorphicType() bool {
 
 // IsPseudoType returns true if the type is a pseudotype.
 func (t *T) IsPseudoType() bool {
-	return t.Identical(Trigger) || t.IsPolymorphicType()
+	return t.Family() == TriggerFamily || t.IsPolymorphicType()
 }
 
 // Size returns the size, in bytes, of this type once it has been marshaled to
@@ -2422,7 +2613,8 @@ func (t *InternalType) Identical(other *InternalType) bool {
 		}
 	}
 	if t.UDTMetadata != nil && other.UDTMetadata != nil {
-		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID {
+		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID ||
+			t.UDTMetadata.DomainBaseOID != other.UDTMetadata.DomainBaseOID {
 			return false
 		}
 	} else if t.UDTMetadata != nil {
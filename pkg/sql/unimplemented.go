@@ -19,6 +19,10 @@ import (
 // The below methods are ordered in alphabetical order. They represent statements
 // which are UNIMPLEMENTED for the legacy schema changer.
 
+func (p *planner) AlterDomain(ctx context.Context, n *tree.AlterDomain) (planNode, error) {
+	return nil, makeUnimplementedLegacyError("ALTER DOMAIN")
+}
+
 func (p *planner) AlterPolicy(ctx context.Context, n *tree.AlterPolicy) (planNode, error) {
 	return nil, makeUnimplementedLegacyError("ALTER POLICY")
 }
@@ -35,6 +39,10 @@ func (p *planner) CreateTrigger(_ context.Context, _ *tree.CreateTrigger) (planN
 	return nil, makeUnimplementedLegacyError("CREATE TRIGGER")
 }
 
+func (p *planner) DropDomain(ctx context.Context, n *tree.DropDomain) (planNode, error) {
+	return nil, makeUnimplementedLegacyError("DROP DOMAIN")
+}
+
 func (p *planner) DropOwnedBy(ctx context.Context) (planNode, error) {
 	if err := checkSchemaChangeEnabled(
 		ctx,
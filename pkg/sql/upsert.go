@@ -160,6 +160,9 @@ func (u *upsertNode) processSourceRow(params runParams, rowVals tree.Datums) err
 	if u.run.tw.canaryOrdinal != -1 {
 		lastUpsertCol++
 	}
+	if u.run.tw.deleteOrdinal != -1 {
+		lastUpsertCol++
+	}
 	upsertVals := rowVals[:lastUpsertCol]
 	rowVals = rowVals[lastUpsertCol:]
 
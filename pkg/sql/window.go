@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -77,6 +78,9 @@ type windowFuncHolder struct {
 	outputColIdx int      // index of the column that the output should be put into
 
 	frame *tree.WindowFrame
+
+	// userDefined is set if the function is a user-defined aggregate.
+	userDefined *exec.UserDefinedAggInfo
 }
 
 func (*windowFuncHolder) Variable() {}
@@ -0,0 +1,406 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+// Package tsprom implements the core of the Prometheus HTTP query API
+// on top of the internal time series database, so that tools such as
+// Grafana can query the metrics recorded by a cluster without an
+// external Prometheus server.
+package tsprom
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+const (
+	// URLPrefix is the prefix of the endpoints of the query API. It is
+	// the URL to configure as a Prometheus data source, e.g. in Grafana.
+	URLPrefix = "/ts/prometheus/"
+
+	// maxPointsPerSeries is the maximum number of steps of a range query,
+	// as in Prometheus.
+	maxPointsPerSeries = 11000
+
+	// defaultSeriesLookback is the timespan used by the series endpoint
+	// when the request does not specify a start time.
+	defaultSeriesLookback = time.Hour
+)
+
+// The error types reported by the API.
+const (
+	errorBadData   = "bad_data"
+	errorExecution = "execution"
+)
+
+// apiError is an error reported to the client with its type.
+type apiError struct {
+	typ string
+	err error
+}
+
+func badData(err error) *apiError {
+	return &apiError{typ: errorBadData, err: err}
+}
+
+// Handler serves the query API of Prometheus over the time series
+// database. The following endpoints are supported:
+//   - /api/v1/query: evaluation of an instant query;
+//   - /api/v1/query_range: evaluation of a range query;
+//   - /api/v1/series: label sets of the series matching selectors;
+//   - /api/v1/label/__name__/values: names of the recorded metrics.
+//
+// The metrics are named as when they are scraped from the Prometheus
+// endpoint of a node (e.g. sql_select_count), and their series are
+// labeled with the ID of the node or store that recorded them.
+type Handler struct {
+	querier     Querier
+	metricNames MetricNamesFn
+	mux         *http.ServeMux
+}
+
+var _ http.Handler = &Handler{}
+
+// NewHandler creates a Handler which evaluates queries using the given
+// time series server.
+func NewHandler(querier Querier, metricNames MetricNamesFn) *Handler {
+	h := &Handler{
+		querier:     querier,
+		metricNames: metricNames,
+		mux:         http.NewServeMux(),
+	}
+	h.mux.HandleFunc(URLPrefix+"api/v1/query", h.handleQuery)
+	h.mux.HandleFunc(URLPrefix+"api/v1/query_range", h.handleQueryRange)
+	h.mux.HandleFunc(URLPrefix+"api/v1/series", h.handleSeries)
+	h.mux.HandleFunc(URLPrefix+"api/v1/label/"+labels.MetricName+"/values", h.handleMetricNames)
+	return h
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) storage() *storage {
+	return &storage{querier: h.querier, index: makeMetricIndex(h.metricNames())}
+}
+
+func (h *Handler) handleQuery(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, badData(err))
+		return
+	}
+	t := timeutil.Now().UnixNano()
+	if s := r.Form.Get("time"); s != "" {
+		var err error
+		if t, err = parseTime(s); err != nil {
+			writeError(w, badData(errors.Wrap(err, "invalid parameter \"time\"")))
+			return
+		}
+	}
+	res, apiErr := h.eval(r.Context(), r.Form.Get("query"), t, t, 1 /* step */, false /* rangeQuery */)
+	writeResult(w, res, apiErr)
+}
+
+func (h *Handler) handleQueryRange(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, badData(err))
+		return
+	}
+	start, err := parseTime(r.Form.Get("start"))
+	if err != nil {
+		writeError(w, badData(errors.Wrap(err, "invalid parameter \"start\"")))
+		return
+	}
+	end, err := parseTime(r.Form.Get("end"))
+	if err != nil {
+		writeError(w, badData(errors.Wrap(err, "invalid parameter \"end\"")))
+		return
+	}
+	if end < start {
+		writeError(w, badData(errors.New("end timestamp must not be before start time")))
+		return
+	}
+	step, err := parseDuration(r.Form.Get("step"))
+	if err != nil {
+		writeError(w, badData(errors.Wrap(err, "invalid parameter \"step\"")))
+		return
+	}
+	if step <= 0 {
+		writeError(w, badData(errors.New(
+			"zero or negative query resolution step widths are not accepted. Try a positive integer")))
+		return
+	}
+	if (end-start)/step >= maxPointsPerSeries {
+		writeError(w, badData(errors.Newf(
+			"exceeded maximum resolution of %d points per timeseries. "+
+				"Try decreasing the query resolution (?step=XX)", maxPointsPerSeries)))
+		return
+	}
+	res, apiErr := h.eval(r.Context(), r.Form.Get("query"), start, end, step, true /* rangeQuery */)
+	writeResult(w, res, apiErr)
+}
+
+func (h *Handler) handleSeries(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, badData(err))
+		return
+	}
+	selectors := r.Form["match[]"]
+	if len(selectors) == 0 {
+		writeError(w, badData(errors.New("no match[] parameter provided")))
+		return
+	}
+	end := timeutil.Now().UnixNano()
+	if s := r.Form.Get("end"); s != "" {
+		var err error
+		if end, err = parseTime(s); err != nil {
+			writeError(w, badData(errors.Wrap(err, "invalid parameter \"end\"")))
+			return
+		}
+	}
+	start := end - int64(defaultSeriesLookback)
+	if s := r.Form.Get("start"); s != "" {
+		var err error
+		if start, err = parseTime(s); err != nil {
+			writeError(w, badData(errors.Wrap(err, "invalid parameter \"start\"")))
+			return
+		}
+	}
+
+	db := h.storage()
+	res := []labels.Labels{}
+	seen := make(map[string]struct{})
+	for _, sel := range selectors {
+		matchers, err := parser.ParseMetricSelector(sel)
+		if err != nil {
+			writeError(w, badData(err))
+			return
+		}
+		series, err := db.series(r.Context(), matchers, start, end)
+		if err != nil {
+			writeError(w, &apiError{typ: errorExecution, err: err})
+			return
+		}
+		for _, ls := range series {
+			if _, ok := seen[ls.String()]; !ok {
+				seen[ls.String()] = struct{}{}
+				res = append(res, ls)
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return labels.Compare(res[i], res[j]) < 0 })
+	writeData(w, res)
+}
+
+func (h *Handler) handleMetricNames(w http.ResponseWriter, r *http.Request) {
+	writeData(w, makeMetricIndex(h.metricNames()).names())
+}
+
+// eval parses and evaluates a query at the steps between start and end.
+func (h *Handler) eval(
+	ctx context.Context, query string, start, end, step int64, rangeQuery bool,
+) (*queryData, *apiError) {
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return nil, badData(err)
+	}
+	ev := &evaluator{
+		ctx:        ctx,
+		db:         h.storage(),
+		start:      start,
+		step:       step,
+		steps:      int((end-start)/step) + 1,
+		rangeQuery: rangeQuery,
+	}
+	v, err := ev.eval(expr)
+	if err != nil {
+		return nil, &apiError{typ: errorExecution, err: err}
+	}
+
+	if rangeQuery {
+		// The result of a range query is always a matrix.
+		switch v := v.(type) {
+		case scalarValue:
+			s := newSeries(labels.Labels{}, ev.steps)
+			for i, val := range v {
+				s.set(i, val)
+			}
+			return ev.matrixData(vectorValue{s}), nil
+		case vectorValue:
+			return ev.matrixData(v), nil
+		default:
+			return nil, badData(errors.Newf(
+				"invalid expression type %q for range query, must be scalar or instant vector", expr.Type()))
+		}
+	}
+
+	t := ev.stepTime(0)
+	switch v := v.(type) {
+	case scalarValue:
+		return &queryData{ResultType: "scalar", Result: point{t: t, v: v[0]}}, nil
+	case stringValue:
+		return &queryData{ResultType: "string", Result: []interface{}{formatTime(t), string(v)}}, nil
+	case vectorValue:
+		res := []vectorSample{}
+		for _, s := range sortSeries(v) {
+			if s.ok[0] {
+				res = append(res, vectorSample{Metric: s.labels, Value: point{t: t, v: s.values[0]}})
+			}
+		}
+		return &queryData{ResultType: "vector", Result: res}, nil
+	case matrixValue:
+		res := []matrixSeries{}
+		for _, rs := range v {
+			ms := matrixSeries{Metric: rs.labels, Values: make([]point, len(rs.samples))}
+			for i, s := range rs.samples {
+				ms.Values[i] = point{t: s.t, v: s.v}
+			}
+			res = append(res, ms)
+		}
+		sort.Slice(res, func(i, j int) bool { return labels.Compare(res[i].Metric, res[j].Metric) < 0 })
+		return &queryData{ResultType: "matrix", Result: res}, nil
+	default:
+		return nil, &apiError{typ: errorExecution, err: errors.AssertionFailedf("unexpected value %T", v)}
+	}
+}
+
+// matrixData returns the result of a range query.
+func (ev *evaluator) matrixData(vec vectorValue) *queryData {
+	res := []matrixSeries{}
+	for _, s := range sortSeries(vec) {
+		ms := matrixSeries{Metric: s.labels}
+		for i := 0; i < ev.steps; i++ {
+			if s.ok[i] {
+				ms.Values = append(ms.Values, point{t: ev.stepTime(i), v: s.values[i]})
+			}
+		}
+		if len(ms.Values) > 0 {
+			res = append(res, ms)
+		}
+	}
+	return &queryData{ResultType: "matrix", Result: res}
+}
+
+func sortSeries(vec vectorValue) vectorValue {
+	sort.SliceStable(vec, func(i, j int) bool { return labels.Compare(vec[i].labels, vec[j].labels) < 0 })
+	return vec
+}
+
+// response is the envelope of all the responses of the API.
+type response struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+// queryData is the result of a query.
+type queryData struct {
+	ResultType string      `json:"resultType"`
+	Result     interface{} `json:"result"`
+}
+
+type vectorSample struct {
+	Metric labels.Labels `json:"metric"`
+	Value  point         `json:"value"`
+}
+
+type matrixSeries struct {
+	Metric labels.Labels `json:"metric"`
+	Values []point       `json:"values"`
+}
+
+// point is a datapoint of a result, encoded as a [<time>, "<value>"]
+// pair as in the Prometheus API.
+type point struct {
+	t int64 // nanoseconds
+	v float64
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p point) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{formatTime(p.t), formatValue(p.v)})
+}
+
+// formatTime returns a timestamp in seconds with millisecond precision.
+func formatTime(t int64) json.Number {
+	return json.Number(strconv.FormatFloat(float64(t/int64(time.Millisecond))/1e3, 'f', -1, 64))
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, +1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+}
+
+// parseTime parses a timestamp given either in seconds since the epoch
+// or in RFC 3339 format, and returns it in nanoseconds.
+func parseTime(s string) (int64, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		sec, frac := math.Modf(f)
+		return int64(sec)*int64(time.Second) + int64(math.Round(frac*1e3))*int64(time.Millisecond), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.UnixNano(), nil
+	}
+	return 0, errors.Newf("cannot parse %q to a valid timestamp", s)
+}
+
+// parseDuration parses a duration given either in seconds or in the
+// Prometheus duration format (e.g. 5m), and returns it in nanoseconds.
+func parseDuration(s string) (int64, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		if math.IsNaN(f) || math.IsInf(f, 0) || f >= float64(math.MaxInt64)/1e9 {
+			return 0, errors.Newf("cannot parse %q to a valid duration", s)
+		}
+		return int64(math.Round(f*1e3)) * int64(time.Millisecond), nil
+	}
+	if d, err := model.ParseDuration(s); err == nil {
+		return int64(d), nil
+	}
+	return 0, errors.Newf("cannot parse %q to a valid duration", s)
+}
+
+func writeResult(w http.ResponseWriter, data *queryData, err *apiError) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeData(w, data)
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	writeResponse(w, http.StatusOK, response{Status: "success", Data: data})
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	code := http.StatusUnprocessableEntity
+	if err.typ == errorBadData {
+		code = http.StatusBadRequest
+	}
+	writeResponse(w, code, response{Status: "error", ErrorType: err.typ, Error: err.err.Error()})
+}
+
+func writeResponse(w http.ResponseWriter, code int, resp response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
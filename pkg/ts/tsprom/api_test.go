@@ -0,0 +1,176 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsprom
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	h := makeTestHandler()
+	for _, tc := range []struct {
+		name     string
+		path     string
+		params   url.Values
+		code     int
+		expected string
+	}{
+		{
+			name: "instant vector",
+			path: "api/v1/query",
+			params: url.Values{
+				"query": {`sum by (node_id) (rate(sql_select_count[1m]))`},
+				"time":  {"1700000600"},
+			},
+			code: http.StatusOK,
+			expected: `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{"node_id":"1"},"value":[1700000600,"1"]},` +
+				`{"metric":{"node_id":"2"},"value":[1700000600,"2"]}]}}`,
+		},
+		{
+			name:     "instant scalar",
+			path:     "api/v1/query",
+			params:   url.Values{"query": {`1 / 0`}, "time": {"2023-11-14T22:13:20.5Z"}},
+			code:     http.StatusOK,
+			expected: `{"status":"success","data":{"resultType":"scalar","result":[1700000000.5,"+Inf"]}}`,
+		},
+		{
+			name: "instant matrix",
+			path: "api/v1/query",
+			params: url.Values{
+				"query": {`sql_conns{node_id="2"}[20s]`},
+				"time":  {"1700000600"},
+			},
+			code: http.StatusOK,
+			expected: `{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"metric":{"__name__":"sql_conns","node_id":"2"},` +
+				`"values":[[1700000580,"10"],[1700000590,"10"],[1700000600,"10"]]}]}}`,
+		},
+		{
+			name: "range",
+			path: "api/v1/query_range",
+			params: url.Values{
+				"query": {`histogram_quantile(0.99, sum(rate(sql_exec_latency_bucket[1m])) by (le))`},
+				"start": {"1700000300"},
+				"end":   {"1700000360"},
+				"step":  {"30s"},
+			},
+			code: http.StatusOK,
+			expected: `{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"metric":{},"values":[[1700000300,"300"],[1700000330,"300"],[1700000360,"300"]]}]}}`,
+		},
+		{
+			name: "range without data",
+			path: "api/v1/query_range",
+			params: url.Values{
+				"query": {`sql_conns`},
+				"start": {"1600000000"},
+				"end":   {"1600000060"},
+				"step":  {"10"},
+			},
+			code:     http.StatusOK,
+			expected: `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		},
+		{
+			name: "series",
+			path: "api/v1/series",
+			params: url.Values{
+				"match[]": {`{__name__=~"capacity|sql_conns", node_id!="1"}`, `capacity{store="1"}`},
+				"start":   {"1700000000"},
+				"end":     {"1700000600"},
+			},
+			code: http.StatusOK,
+			expected: `{"status":"success","data":[` +
+				`{"__name__":"capacity","store":"1"},` +
+				`{"__name__":"capacity","store":"2"},` +
+				`{"__name__":"sql_conns","node_id":"2"}]}`,
+		},
+		{
+			name: "metric names",
+			path: "api/v1/label/__name__/values",
+			code: http.StatusOK,
+			expected: `{"status":"success","data":["capacity","sql_conns",` +
+				`"sql_exec_latency_p50","sql_exec_latency_p99","sql_select_count"]}`,
+		},
+		{
+			name:     "parse error",
+			path:     "api/v1/query",
+			params:   url.Values{"query": {`sum(`}},
+			code:     http.StatusBadRequest,
+			expected: `"errorType":"bad_data"`,
+		},
+		{
+			name:     "invalid time",
+			path:     "api/v1/query",
+			params:   url.Values{"query": {`1`}, "time": {"yesterday"}},
+			code:     http.StatusBadRequest,
+			expected: `invalid parameter \"time\"`,
+		},
+		{
+			name:     "unsupported function",
+			path:     "api/v1/query",
+			params:   url.Values{"query": {`predict_linear(sql_conns[5m], 60)`}},
+			code:     http.StatusUnprocessableEntity,
+			expected: `"errorType":"execution","error":"function predict_linear is not supported"`,
+		},
+		{
+			name: "invalid step",
+			path: "api/v1/query_range",
+			params: url.Values{
+				"query": {`sql_conns`}, "start": {"1700000000"}, "end": {"1700000600"}, "step": {"0"},
+			},
+			code:     http.StatusBadRequest,
+			expected: `zero or negative query resolution step widths are not accepted`,
+		},
+		{
+			name: "too many points",
+			path: "api/v1/query_range",
+			params: url.Values{
+				"query": {`sql_conns`}, "start": {"1600000000"}, "end": {"1700000000"}, "step": {"1s"},
+			},
+			code:     http.StatusBadRequest,
+			expected: `exceeded maximum resolution of 11000 points per timeseries`,
+		},
+		{
+			name: "matrix range query",
+			path: "api/v1/query_range",
+			params: url.Values{
+				"query": {`sql_conns[1m]`}, "start": {"1700000000"}, "end": {"1700000600"}, "step": {"60"},
+			},
+			code:     http.StatusUnprocessableEntity,
+			expected: `"errorType":"execution"`,
+		},
+		{
+			name:     "series without match",
+			path:     "api/v1/series",
+			code:     http.StatusBadRequest,
+			expected: `no match[] parameter provided`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, URLPrefix+tc.path, strings.NewReader(tc.params.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			require.Equal(t, tc.code, rec.Code, rec.Body.String())
+			require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			if tc.code == http.StatusOK {
+				require.JSONEq(t, tc.expected, rec.Body.String())
+			} else {
+				require.Contains(t, rec.Body.String(), tc.expected)
+			}
+		})
+	}
+}
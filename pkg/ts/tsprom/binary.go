@@ -0,0 +1,319 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsprom
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// evalBinary evaluates a binary operation between scalars and instant
+// vectors.
+func (ev *evaluator) evalBinary(e *parser.BinaryExpr) (interface{}, error) {
+	switch e.Op {
+	case parser.ADD, parser.SUB, parser.MUL, parser.DIV, parser.MOD, parser.POW,
+		parser.EQLC, parser.NEQ, parser.GTR, parser.LSS, parser.GTE, parser.LTE,
+		parser.LAND, parser.LOR, parser.LUNLESS:
+	default:
+		return nil, errors.Newf("operator %s is not supported", e.Op)
+	}
+	lhs, err := ev.eval(e.LHS)
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := ev.eval(e.RHS)
+	if err != nil {
+		return nil, err
+	}
+	switch l := lhs.(type) {
+	case scalarValue:
+		switch r := rhs.(type) {
+		case scalarValue:
+			res := make(scalarValue, ev.steps)
+			for i := range res {
+				v, keep := binop(e.Op, l[i], r[i])
+				if e.Op.IsComparisonOperator() {
+					v = boolValue(keep)
+				}
+				res[i] = v
+			}
+			return res, nil
+		case vectorValue:
+			return ev.binaryScalar(e.Op, r, l, true /* scalarLeft */, e.ReturnBool), nil
+		}
+	case vectorValue:
+		switch r := rhs.(type) {
+		case scalarValue:
+			return ev.binaryScalar(e.Op, l, r, false /* scalarLeft */, e.ReturnBool), nil
+		case vectorValue:
+			return ev.binaryVector(e, l, r)
+		}
+	}
+	return nil, errors.Newf("unsupported operands for %s: %s", e.Op, e)
+}
+
+// binop applies an arithmetic or comparison operator. For comparisons,
+// it returns the left operand and whether the comparison holds.
+func binop(op parser.ItemType, l, r float64) (float64, bool) {
+	switch op {
+	case parser.ADD:
+		return l + r, true
+	case parser.SUB:
+		return l - r, true
+	case parser.MUL:
+		return l * r, true
+	case parser.DIV:
+		return l / r, true
+	case parser.MOD:
+		return math.Mod(l, r), true
+	case parser.POW:
+		return math.Pow(l, r), true
+	case parser.EQLC:
+		return l, l == r
+	case parser.NEQ:
+		return l, l != r
+	case parser.GTR:
+		return l, l > r
+	case parser.LSS:
+		return l, l < r
+	case parser.GTE:
+		return l, l >= r
+	case parser.LTE:
+		return l, l <= r
+	default:
+		panic(errors.AssertionFailedf("unexpected operator %s", op))
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// binaryScalar applies an operator between every series of a vector and
+// a scalar. Comparisons filter the series, unless returnBool is set.
+func (ev *evaluator) binaryScalar(
+	op parser.ItemType, vec vectorValue, s scalarValue, scalarLeft, returnBool bool,
+) vectorValue {
+	comparison := op.IsComparisonOperator()
+	res := make(vectorValue, 0, len(vec))
+	for _, in := range vec {
+		ls := in.labels
+		if !comparison || returnBool {
+			ls = dropMetricName(ls)
+		}
+		out := newSeries(ls, ev.steps)
+		for i := 0; i < ev.steps; i++ {
+			if !in.ok[i] {
+				continue
+			}
+			l, r := in.values[i], s[i]
+			if scalarLeft {
+				l, r = r, l
+			}
+			v, keep := binop(op, l, r)
+			if comparison {
+				// Comparisons keep the value of the vector, even when the
+				// scalar is on the left side.
+				v = in.values[i]
+			}
+			if returnBool {
+				v, keep = boolValue(keep), true
+			}
+			if keep {
+				out.set(i, v)
+			}
+		}
+		if !out.empty() {
+			res = append(res, out)
+		}
+	}
+	return res
+}
+
+// binaryVector applies an operator between the series of two vectors
+// that have the same matching labels.
+func (ev *evaluator) binaryVector(e *parser.BinaryExpr, lhs, rhs vectorValue) (vectorValue, error) {
+	m := e.VectorMatching
+	if m == nil {
+		m = &parser.VectorMatching{Card: parser.CardOneToOne}
+	}
+	matching := append([]string(nil), m.MatchingLabels...)
+	sort.Strings(matching)
+	signatures := func(vec vectorValue) []string {
+		sigs := make([]string, len(vec))
+		for i, s := range vec {
+			if m.On {
+				sigs[i] = s.labels.WithLabels(matching...).String()
+			} else {
+				sigs[i] = s.labels.WithoutLabels(matching...).String()
+			}
+		}
+		return sigs
+	}
+	if e.Op.IsSetOperator() {
+		return ev.setOperation(e.Op, lhs, rhs, signatures(lhs), signatures(rhs)), nil
+	}
+
+	// The series of the "one" side are looked up by signature for every
+	// series of the "many" side.
+	many, one := lhs, rhs
+	if m.Card == parser.CardOneToMany {
+		many, one = rhs, lhs
+	}
+	manySigs, oneSigs := signatures(many), signatures(one)
+	dropName := !e.Op.IsComparisonOperator() || e.ReturnBool
+
+	var res vectorValue
+	resBySig := make(map[string]*series)
+	for i := 0; i < ev.steps; i++ {
+		oneBySig := make(map[string]*series, len(one))
+		for j, s := range one {
+			if !s.ok[i] {
+				continue
+			}
+			if _, ok := oneBySig[oneSigs[j]]; ok {
+				side := "right"
+				if m.Card == parser.CardOneToMany {
+					side = "left"
+				}
+				return nil, errors.Newf("found duplicate series for the match group %s "+
+					"on the %s side of the operation: many-to-many matching not allowed", oneSigs[j], side)
+			}
+			oneBySig[oneSigs[j]] = s
+		}
+		var matched map[string]struct{}
+		if m.Card == parser.CardOneToOne {
+			matched = make(map[string]struct{})
+		}
+		for j, s := range many {
+			if !s.ok[i] {
+				continue
+			}
+			o, ok := oneBySig[manySigs[j]]
+			if !ok {
+				continue
+			}
+			if matched != nil {
+				if _, ok := matched[manySigs[j]]; ok {
+					return nil, errors.Newf("multiple matches for labels %s: "+
+						"many-to-one matching must be explicit (group_left/group_right)", manySigs[j])
+				}
+				matched[manySigs[j]] = struct{}{}
+			}
+			l, r := s.values[i], o.values[i]
+			if m.Card == parser.CardOneToMany {
+				l, r = r, l
+			}
+			v, keep := binop(e.Op, l, r)
+			if e.ReturnBool {
+				v, keep = boolValue(keep), true
+			}
+			if !keep {
+				continue
+			}
+			ls := resultLabels(s.labels, o.labels, m, matching, dropName)
+			key := ls.String()
+			out, ok := resBySig[key]
+			if !ok {
+				out = newSeries(ls, ev.steps)
+				resBySig[key] = out
+				res = append(res, out)
+			} else if out.ok[i] {
+				return nil, errors.Newf("multiple matches for labels %s", key)
+			}
+			out.set(i, v)
+		}
+	}
+	return res, nil
+}
+
+// resultLabels returns the labels of the result of a binary operation
+// between two matching series.
+func resultLabels(
+	many, one labels.Labels, m *parser.VectorMatching, matching []string, dropName bool,
+) labels.Labels {
+	b := labels.NewBuilder(many)
+	if dropName {
+		b.Del(labels.MetricName)
+	}
+	if m.Card == parser.CardOneToOne {
+		if m.On {
+			for _, l := range many {
+				if i := sort.SearchStrings(matching, l.Name); i == len(matching) || matching[i] != l.Name {
+					b.Del(l.Name)
+				}
+			}
+		} else {
+			b.Del(matching...)
+		}
+	}
+	for _, name := range m.Include {
+		if v := one.Get(name); v != "" {
+			b.Set(name, v)
+		} else {
+			b.Del(name)
+		}
+	}
+	return b.Labels()
+}
+
+// setOperation evaluates the and, or and unless operators.
+func (ev *evaluator) setOperation(
+	op parser.ItemType, lhs, rhs vectorValue, lhsSigs, rhsSigs []string,
+) vectorValue {
+	present := func(vec vectorValue, sigs []string, i int) map[string]struct{} {
+		set := make(map[string]struct{}, len(vec))
+		for j, s := range vec {
+			if s.ok[i] {
+				set[sigs[j]] = struct{}{}
+			}
+		}
+		return set
+	}
+	filter := func(vec vectorValue, sigs []string, keep func(i int, sig string) bool) vectorValue {
+		res := make(vectorValue, 0, len(vec))
+		for j, in := range vec {
+			out := newSeries(in.labels, ev.steps)
+			for i := 0; i < ev.steps; i++ {
+				if in.ok[i] && keep(i, sigs[j]) {
+					out.set(i, in.values[i])
+				}
+			}
+			if !out.empty() {
+				res = append(res, out)
+			}
+		}
+		return res
+	}
+	inSet := func(sets []map[string]struct{}, want bool) func(int, string) bool {
+		return func(i int, sig string) bool {
+			_, ok := sets[i][sig]
+			return ok == want
+		}
+	}
+	lhsSets := make([]map[string]struct{}, ev.steps)
+	rhsSets := make([]map[string]struct{}, ev.steps)
+	for i := 0; i < ev.steps; i++ {
+		lhsSets[i] = present(lhs, lhsSigs, i)
+		rhsSets[i] = present(rhs, rhsSigs, i)
+	}
+	switch op {
+	case parser.LAND:
+		return filter(lhs, lhsSigs, inSet(rhsSets, true))
+	case parser.LUNLESS:
+		return filter(lhs, lhsSigs, inSet(rhsSets, false))
+	default:
+		res := filter(lhs, lhsSigs, func(int, string) bool { return true })
+		return append(res, filter(rhs, rhsSigs, inSet(lhsSets, false))...)
+	}
+}
@@ -0,0 +1,450 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsprom
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// lookbackNanos is how far back an instant vector selector looks for
+// the most recent datapoint of a series. This is the default lookback
+// delta of Prometheus.
+const lookbackNanos = int64(5 * time.Minute)
+
+// The values produced by the evaluation of an expression. Every
+// expression is evaluated at all the steps of the query at once.
+type (
+	// scalarValue holds the value of a scalar at every step.
+	scalarValue []float64
+	// vectorValue holds the values of an instant vector at every step.
+	vectorValue []*series
+	// matrixValue holds the datapoints selected by a range vector
+	// selector. It is only produced by instant queries.
+	matrixValue []rawSeries
+	// stringValue holds a string literal.
+	stringValue string
+)
+
+// series is an element of an instant vector. It holds the value of the
+// series at every step where the series is present.
+type series struct {
+	labels labels.Labels
+	values []float64
+	ok     []bool
+}
+
+func newSeries(ls labels.Labels, steps int) *series {
+	return &series{labels: ls, values: make([]float64, steps), ok: make([]bool, steps)}
+}
+
+func (s *series) set(i int, v float64) {
+	s.values[i] = v
+	s.ok[i] = true
+}
+
+func (s *series) empty() bool {
+	for _, ok := range s.ok {
+		if ok {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluator evaluates PromQL expressions at regular steps between
+// start and end, using the datapoints read from the time series
+// database.
+type evaluator struct {
+	ctx   context.Context
+	db    *storage
+	start int64 // nanoseconds
+	step  int64 // nanoseconds
+	steps int
+	// rangeQuery is set when the query is a range query rather than an
+	// instant query.
+	rangeQuery bool
+}
+
+// stepTime returns the timestamp of the i-th step.
+func (ev *evaluator) stepTime(i int) int64 {
+	return ev.start + int64(i)*ev.step
+}
+
+func (ev *evaluator) eval(expr parser.Expr) (interface{}, error) {
+	if err := ev.ctx.Err(); err != nil {
+		return nil, err
+	}
+	switch e := expr.(type) {
+	case *parser.NumberLiteral:
+		return ev.constant(e.Val), nil
+	case *parser.StringLiteral:
+		return stringValue(e.Val), nil
+	case *parser.ParenExpr:
+		return ev.eval(e.Expr)
+	case *parser.StepInvariantExpr:
+		return ev.eval(e.Expr)
+	case *parser.UnaryExpr:
+		v, err := ev.eval(e.Expr)
+		if err != nil || e.Op != parser.SUB {
+			return v, err
+		}
+		switch v := v.(type) {
+		case scalarValue:
+			for i := range v {
+				v[i] = -v[i]
+			}
+			return v, nil
+		case vectorValue:
+			return ev.binaryScalar(parser.MUL, v, ev.constant(-1), false /* scalarLeft */, false /* returnBool */), nil
+		}
+		return nil, errors.Newf("unsupported operand for %s: %s", e.Op, e)
+	case *parser.VectorSelector:
+		return ev.evalVectorSelector(e)
+	case *parser.MatrixSelector:
+		if ev.rangeQuery {
+			return nil, errors.Newf("range vector %s is not supported in range queries", e)
+		}
+		return ev.evalMatrixSelector(e)
+	case *parser.AggregateExpr:
+		return ev.evalAggregate(e)
+	case *parser.BinaryExpr:
+		return ev.evalBinary(e)
+	case *parser.Call:
+		return ev.evalCall(e)
+	default:
+		return nil, errors.Newf("unsupported expression: %s", expr)
+	}
+}
+
+// evalVector evaluates an expression which must produce an instant
+// vector.
+func (ev *evaluator) evalVector(expr parser.Expr) (vectorValue, error) {
+	v, err := ev.eval(expr)
+	if err != nil {
+		return nil, err
+	}
+	vec, ok := v.(vectorValue)
+	if !ok {
+		return nil, errors.Newf("expected instant vector, got %s", expr.Type())
+	}
+	return vec, nil
+}
+
+// evalScalar evaluates an expression which must produce a scalar.
+func (ev *evaluator) evalScalar(expr parser.Expr) (scalarValue, error) {
+	v, err := ev.eval(expr)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := v.(scalarValue)
+	if !ok {
+		return nil, errors.Newf("expected scalar, got %s", expr.Type())
+	}
+	return s, nil
+}
+
+func (ev *evaluator) constant(v float64) scalarValue {
+	s := make(scalarValue, ev.steps)
+	for i := range s {
+		s[i] = v
+	}
+	return s
+}
+
+// checkSelector rejects the selector modifiers that are not supported.
+func checkSelector(vs *parser.VectorSelector) error {
+	if vs.Timestamp != nil || vs.StartOrEnd != 0 {
+		return errors.Newf("the @ modifier is not supported: %s", vs)
+	}
+	return nil
+}
+
+// evalVectorSelector returns, at every step, the most recent datapoint
+// of each selected series within the lookback window.
+func (ev *evaluator) evalVectorSelector(vs *parser.VectorSelector) (vectorValue, error) {
+	if err := checkSelector(vs); err != nil {
+		return nil, err
+	}
+	offset := int64(vs.OriginalOffset)
+	raw, err := ev.db.fetch(ev.ctx, vs.LabelMatchers,
+		ev.stepTime(0)-offset-lookbackNanos, ev.stepTime(ev.steps-1)-offset)
+	if err != nil {
+		return nil, err
+	}
+	res := make(vectorValue, 0, len(raw))
+	for _, rs := range raw {
+		s := newSeries(rs.labels, ev.steps)
+		j := -1
+		for i := 0; i < ev.steps; i++ {
+			t := ev.stepTime(i) - offset
+			for j+1 < len(rs.samples) && rs.samples[j+1].t <= t {
+				j++
+			}
+			if j >= 0 && rs.samples[j].t > t-lookbackNanos {
+				s.set(i, rs.samples[j].v)
+			}
+		}
+		if !s.empty() {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+// fetchRange reads the datapoints needed to evaluate a range vector
+// selector at every step.
+func (ev *evaluator) fetchRange(ms *parser.MatrixSelector) ([]rawSeries, int64, error) {
+	vs, ok := ms.VectorSelector.(*parser.VectorSelector)
+	if !ok {
+		return nil, 0, errors.Newf("unsupported range vector: %s", ms)
+	}
+	if err := checkSelector(vs); err != nil {
+		return nil, 0, err
+	}
+	offset := int64(vs.OriginalOffset)
+	raw, err := ev.db.fetch(ev.ctx, vs.LabelMatchers,
+		ev.stepTime(0)-offset-int64(ms.Range), ev.stepTime(ev.steps-1)-offset)
+	return raw, offset, err
+}
+
+func (ev *evaluator) evalMatrixSelector(ms *parser.MatrixSelector) (matrixValue, error) {
+	raw, _, err := ev.fetchRange(ms)
+	return matrixValue(raw), err
+}
+
+// evalRangeFunc applies fn, at every step, to the datapoints of each
+// series within the range of the selector. fn returns false if it
+// cannot produce a value from the datapoints.
+func (ev *evaluator) evalRangeFunc(
+	ms *parser.MatrixSelector,
+	keepName bool,
+	fn func(samples []sample, rangeStart, rangeEnd int64) (float64, bool),
+) (vectorValue, error) {
+	raw, offset, err := ev.fetchRange(ms)
+	if err != nil {
+		return nil, err
+	}
+	rangeNanos := int64(ms.Range)
+	res := make(vectorValue, 0, len(raw))
+	for _, rs := range raw {
+		ls := rs.labels
+		if !keepName {
+			ls = dropMetricName(ls)
+		}
+		s := newSeries(ls, ev.steps)
+		lo, hi := 0, 0
+		for i := 0; i < ev.steps; i++ {
+			rangeEnd := ev.stepTime(i) - offset
+			rangeStart := rangeEnd - rangeNanos
+			for lo < len(rs.samples) && rs.samples[lo].t < rangeStart {
+				lo++
+			}
+			if hi < lo {
+				hi = lo
+			}
+			for hi < len(rs.samples) && rs.samples[hi].t <= rangeEnd {
+				hi++
+			}
+			if v, ok := fn(rs.samples[lo:hi], rangeStart, rangeEnd); ok {
+				s.set(i, v)
+			}
+		}
+		if !s.empty() {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
+func dropMetricName(ls labels.Labels) labels.Labels {
+	return labels.NewBuilder(ls).Del(labels.MetricName).Labels()
+}
+
+// evalAggregate evaluates an aggregation over the series of an instant
+// vector, grouped by the labels of the expression.
+func (ev *evaluator) evalAggregate(e *parser.AggregateExpr) (interface{}, error) {
+	vec, err := ev.evalVector(e.Expr)
+	if err != nil {
+		return nil, err
+	}
+	var param scalarValue
+	switch e.Op {
+	case parser.TOPK, parser.BOTTOMK, parser.QUANTILE:
+		if param, err = ev.evalScalar(e.Param); err != nil {
+			return nil, err
+		}
+	case parser.SUM, parser.AVG, parser.MIN, parser.MAX, parser.COUNT,
+		parser.GROUP, parser.STDDEV, parser.STDVAR:
+	default:
+		return nil, errors.Newf("aggregation %s is not supported", e.Op)
+	}
+
+	grouping := append([]string(nil), e.Grouping...)
+	sort.Strings(grouping)
+	type group struct {
+		labels  labels.Labels
+		members vectorValue
+	}
+	var groups []*group
+	groupsByKey := make(map[string]*group)
+	for _, s := range vec {
+		var ls labels.Labels
+		if e.Without {
+			ls = s.labels.WithoutLabels(grouping...)
+		} else {
+			ls = s.labels.WithLabels(grouping...)
+		}
+		key := ls.String()
+		g, ok := groupsByKey[key]
+		if !ok {
+			g = &group{labels: ls}
+			groupsByKey[key] = g
+			groups = append(groups, g)
+		}
+		g.members = append(g.members, s)
+	}
+
+	var res vectorValue
+	if e.Op == parser.TOPK || e.Op == parser.BOTTOMK {
+		// topk and bottomk select series rather than compute new ones.
+		for _, g := range groups {
+			selected := make([]*series, len(g.members))
+			for i, s := range g.members {
+				selected[i] = newSeries(s.labels, ev.steps)
+			}
+			for i := 0; i < ev.steps; i++ {
+				var idx []int
+				for j, s := range g.members {
+					if s.ok[i] && !math.IsNaN(s.values[i]) {
+						idx = append(idx, j)
+					}
+				}
+				sort.SliceStable(idx, func(a, b int) bool {
+					va, vb := g.members[idx[a]].values[i], g.members[idx[b]].values[i]
+					if e.Op == parser.TOPK {
+						return va > vb
+					}
+					return va < vb
+				})
+				k := int(param[i])
+				if k > len(idx) {
+					k = len(idx)
+				}
+				for _, j := range idx[:max(k, 0)] {
+					selected[j].set(i, g.members[j].values[i])
+				}
+			}
+			for _, s := range selected {
+				if !s.empty() {
+					res = append(res, s)
+				}
+			}
+		}
+		return res, nil
+	}
+
+	values := make([]float64, 0, len(vec))
+	for _, g := range groups {
+		out := newSeries(g.labels, ev.steps)
+		for i := 0; i < ev.steps; i++ {
+			values = values[:0]
+			for _, s := range g.members {
+				if s.ok[i] {
+					values = append(values, s.values[i])
+				}
+			}
+			if len(values) == 0 {
+				continue
+			}
+			var p float64
+			if param != nil {
+				p = param[i]
+			}
+			out.set(i, aggregate(e.Op, p, values))
+		}
+		if !out.empty() {
+			res = append(res, out)
+		}
+	}
+	return res, nil
+}
+
+// aggregate computes an aggregation over a non-empty set of values.
+func aggregate(op parser.ItemType, param float64, values []float64) float64 {
+	switch op {
+	case parser.SUM:
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum
+	case parser.AVG:
+		return aggregate(parser.SUM, param, values) / float64(len(values))
+	case parser.MIN:
+		res := values[0]
+		for _, v := range values[1:] {
+			if v < res || math.IsNaN(res) {
+				res = v
+			}
+		}
+		return res
+	case parser.MAX:
+		res := values[0]
+		for _, v := range values[1:] {
+			if v > res || math.IsNaN(res) {
+				res = v
+			}
+		}
+		return res
+	case parser.COUNT:
+		return float64(len(values))
+	case parser.GROUP:
+		return 1
+	case parser.STDVAR, parser.STDDEV:
+		mean := aggregate(parser.AVG, param, values)
+		var variance float64
+		for _, v := range values {
+			variance += (v - mean) * (v - mean)
+		}
+		variance /= float64(len(values))
+		if op == parser.STDDEV {
+			return math.Sqrt(variance)
+		}
+		return variance
+	case parser.QUANTILE:
+		return quantile(param, values)
+	default:
+		panic(errors.AssertionFailedf("unexpected aggregation %s", op))
+	}
+}
+
+// quantile computes the φ-quantile of the values, interpolating
+// between the two nearest values like the quantile aggregation of
+// Prometheus.
+func quantile(phi float64, values []float64) float64 {
+	switch {
+	case math.IsNaN(phi):
+		return math.NaN()
+	case phi < 0:
+		return math.Inf(-1)
+	case phi > 1:
+		return math.Inf(+1)
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := phi * float64(len(sorted)-1)
+	lower := math.Max(0, math.Floor(rank))
+	upper := math.Min(float64(len(sorted)-1), lower+1)
+	weight := rank - math.Floor(rank)
+	return sorted[int(lower)]*(1-weight) + sorted[int(upper)]*weight
+}
@@ -0,0 +1,304 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsprom
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/require"
+)
+
+// testStart is the timestamp of the first datapoint of the test data.
+var testStart = time.Unix(1700000000, 0).UnixNano()
+
+// fakeQuerier serves queries from in-memory datapoints, indexed by
+// series name and source. Like the time series server, it returns the
+// sources with data in the queried timespan when a query does not
+// specify any source.
+type fakeQuerier struct {
+	data map[string]map[string][]tspb.TimeSeriesDatapoint
+}
+
+func (q *fakeQuerier) Query(
+	_ context.Context, req *tspb.TimeSeriesQueryRequest,
+) (*tspb.TimeSeriesQueryResponse, error) {
+	resp := &tspb.TimeSeriesQueryResponse{}
+	for _, query := range req.Queries {
+		r := tspb.TimeSeriesQueryResponse_Result{Query: query}
+		sources := query.Sources
+		if len(sources) == 0 {
+			for source := range q.data[query.Name] {
+				sources = append(sources, source)
+			}
+			sort.Strings(sources)
+		}
+		r.Sources = nil
+		for _, source := range sources {
+			var datapoints []tspb.TimeSeriesDatapoint
+			for _, dp := range q.data[query.Name][source] {
+				if dp.TimestampNanos >= req.StartNanos && dp.TimestampNanos <= req.EndNanos {
+					datapoints = append(datapoints, dp)
+				}
+			}
+			if len(datapoints) > 0 {
+				r.Sources = append(r.Sources, source)
+				r.Datapoints = append(r.Datapoints, datapoints...)
+			}
+		}
+		resp.Results = append(resp.Results, r)
+	}
+	return resp, nil
+}
+
+// makeTestHandler returns a Handler over ten minutes of datapoints,
+// recorded every 10s, of the following metrics:
+//   - sql.select.count, a counter increasing by 1/s on n1 and 2/s on n2;
+//   - sql.conns, a gauge set to 5 on n1 and 10 on n2;
+//   - capacity, a gauge of stores s1 and s2;
+//   - sql.exec.latency, a histogram with a p99 of 100 on n1 and 300 on
+//     n2, recorded for tenant 2 on n2.
+func makeTestHandler() *Handler {
+	q := &fakeQuerier{data: map[string]map[string][]tspb.TimeSeriesDatapoint{}}
+	record := func(name, source string, value func(i int) float64) {
+		if q.data[name] == nil {
+			q.data[name] = map[string][]tspb.TimeSeriesDatapoint{}
+		}
+		for i := 0; i <= 60; i++ {
+			q.data[name][source] = append(q.data[name][source], tspb.TimeSeriesDatapoint{
+				TimestampNanos: testStart + int64(i)*int64(10*time.Second),
+				Value:          value(i),
+			})
+		}
+	}
+	record("cr.node.sql.select.count", "1", func(i int) float64 { return float64(10 * i) })
+	record("cr.node.sql.select.count", "2", func(i int) float64 { return float64(20 * i) })
+	record("cr.node.sql.conns", "1", func(int) float64 { return 5 })
+	record("cr.node.sql.conns", "2", func(int) float64 { return 10 })
+	record("cr.store.capacity", "1", func(int) float64 { return 1000 })
+	record("cr.store.capacity", "2", func(int) float64 { return 2000 })
+	record("cr.node.sql.exec.latency-p99", "1", func(int) float64 { return 100 })
+	record("cr.node.sql.exec.latency-p99", "2-2", func(int) float64 { return 300 })
+	record("cr.node.sql.exec.latency-p50", "1", func(int) float64 { return 10 })
+
+	return NewHandler(q, func() map[string]string {
+		return map[string]string{
+			"sql.select.count":     "cr.node.sql.select.count",
+			"sql.conns":            "cr.node.sql.conns",
+			"capacity":             "cr.store.capacity",
+			"sql.exec.latency-p99": "cr.node.sql.exec.latency-p99",
+			"sql.exec.latency-p50": "cr.node.sql.exec.latency-p50",
+		}
+	})
+}
+
+// formatVector returns the series of an instant vector at the first
+// step, one per line, sorted by labels.
+func formatVector(vec vectorValue) string {
+	var lines []string
+	for _, s := range sortSeries(vec) {
+		if s.ok[0] {
+			lines = append(lines, s.labels.String()+" "+formatValue(s.values[0]))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestEvalInstant(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	h := makeTestHandler()
+	// The time of the last datapoint.
+	at := testStart + int64(10*time.Minute)
+
+	for _, tc := range []struct {
+		query    string
+		expected string
+	}{
+		{
+			query: `sql_select_count`,
+			expected: `{__name__="sql_select_count", node_id="1"} 600
+{__name__="sql_select_count", node_id="2"} 1200`,
+		},
+		{
+			query:    `sql_select_count{node_id="2"} offset 1m`,
+			expected: `{__name__="sql_select_count", node_id="2"} 1080`,
+		},
+		{
+			query: `{__name__=~"sql_.*", node_id="1"}`,
+			expected: `{__name__="sql_conns", node_id="1"} 5
+{__name__="sql_exec_latency_p50", node_id="1"} 10
+{__name__="sql_exec_latency_p99", node_id="1"} 100
+{__name__="sql_select_count", node_id="1"} 600`,
+		},
+		{
+			query: `capacity`,
+			expected: `{__name__="capacity", store="1"} 1000
+{__name__="capacity", store="2"} 2000`,
+		},
+		{
+			query: `rate(sql_select_count[1m])`,
+			expected: `{node_id="1"} 1
+{node_id="2"} 2`,
+		},
+		{
+			query: `increase(sql_select_count[1m])`,
+			expected: `{node_id="1"} 60
+{node_id="2"} 120`,
+		},
+		{
+			query: `irate(sql_select_count[1m])`,
+			expected: `{node_id="1"} 1
+{node_id="2"} 2`,
+		},
+		{
+			query:    `sum(rate(sql_select_count[1m]))`,
+			expected: `{} 3`,
+		},
+		{
+			query:    `avg(sql_conns)`,
+			expected: `{} 7.5`,
+		},
+		{
+			query: `sum by (node_id) (sql_conns)`,
+			expected: `{node_id="1"} 5
+{node_id="2"} 10`,
+		},
+		{
+			query:    `max without (node_id) (sql_conns)`,
+			expected: `{} 10`,
+		},
+		{
+			query:    `count(sql_conns)`,
+			expected: `{} 2`,
+		},
+		{
+			query:    `topk(1, sql_conns)`,
+			expected: `{__name__="sql_conns", node_id="2"} 10`,
+		},
+		{
+			query:    `max_over_time(sql_select_count{node_id="1"}[1m])`,
+			expected: `{node_id="1"} 600`,
+		},
+		{
+			query:    `sql_conns > 7`,
+			expected: `{__name__="sql_conns", node_id="2"} 10`,
+		},
+		{
+			query: `sql_conns > bool 7`,
+			expected: `{node_id="1"} 0
+{node_id="2"} 1`,
+		},
+		{
+			query: `-sql_conns + 1`,
+			expected: `{node_id="1"} -4
+{node_id="2"} -9`,
+		},
+		{
+			query: `rate(sql_select_count[1m]) / sql_conns`,
+			expected: `{node_id="1"} 0.2
+{node_id="2"} 0.2`,
+		},
+		{
+			query: `sql_conns / on() group_left sum(sql_conns)`,
+			expected: `{node_id="1"} 0.3333333333333333
+{node_id="2"} 0.6666666666666666`,
+		},
+		{
+			query:    `sql_conns and on(node_id) sql_exec_latency_p50`,
+			expected: `{__name__="sql_conns", node_id="1"} 5`,
+		},
+		{
+			query:    `sql_conns unless on(node_id) sql_exec_latency_p50`,
+			expected: `{__name__="sql_conns", node_id="2"} 10`,
+		},
+		{
+			query: `clamp_max(sql_conns, 6)`,
+			expected: `{node_id="1"} 5
+{node_id="2"} 6`,
+		},
+		{
+			query:    `vector(2) * 3`,
+			expected: `{} 6`,
+		},
+		{
+			query: `histogram_quantile(0.99, sum by (le) (rate(sql_exec_latency_bucket[5m])))`,
+			// The maximum of the p99 of each node.
+			expected: `{} 300`,
+		},
+		{
+			query: `histogram_quantile(0.99, rate(sql_exec_latency_bucket[5m]))`,
+			expected: `{node_id="1"} 100
+{node_id="2", tenant_id="2"} 300`,
+		},
+		{
+			query:    `histogram_quantile(0.5, sql_exec_latency_bucket{node_id="1"})`,
+			expected: `{node_id="1"} 10`,
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			expr, err := parser.ParseExpr(tc.query)
+			require.NoError(t, err)
+			ev := &evaluator{ctx: context.Background(), db: h.storage(), start: at, step: 1, steps: 1}
+			v, err := ev.eval(expr)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, formatVector(v.(vectorValue)))
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	h := makeTestHandler()
+	at := testStart + int64(10*time.Minute)
+	for _, tc := range []struct {
+		query    string
+		expected string
+	}{
+		{`histogram_quantile(0.42, rate(sql_exec_latency_bucket[5m]))`, `unsupported quantile 0.42`},
+		{`histogram_quantile(0.99, sql_conns)`, `requires a selector of histogram buckets`},
+		{`label_replace(sql_conns, "a", "b", "c", "d")`, `function label_replace is not supported`},
+		{`rate(sql_select_count[5m:1m])`, `unsupported argument for rate`},
+		{`sql_conns @ 1700000000`, `the @ modifier is not supported`},
+		{`sql_conns + on() sql_conns`, `many-to-many matching not allowed`},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			expr, err := parser.ParseExpr(tc.query)
+			require.NoError(t, err)
+			ev := &evaluator{ctx: context.Background(), db: h.storage(), start: at, step: 1, steps: 1}
+			_, err = ev.eval(expr)
+			require.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
+
+func TestExtrapolatedRate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	sec := int64(time.Second)
+	// A counter reset between the second and third datapoints.
+	samples := []sample{{t: 10 * sec, v: 10}, {t: 20 * sec, v: 20}, {t: 30 * sec, v: 5}, {t: 40 * sec, v: 15}}
+	v, ok := extrapolatedRate(samples, 10*sec, 40*sec, true /* isCounter */, false /* isRate */)
+	require.True(t, ok)
+	require.Equal(t, 25.0, v)
+
+	// The datapoints cover half of the range: the increase is
+	// extrapolated by half the average interval on each side.
+	samples = []sample{{t: 20 * sec, v: 0}, {t: 30 * sec, v: 10}}
+	v, ok = extrapolatedRate(samples, 0, 60*sec, false /* isCounter */, true /* isRate */)
+	require.True(t, ok)
+	require.InDelta(t, 20.0/60, v, 1e-9)
+
+	_, ok = extrapolatedRate(samples[:1], 0, 60*sec, true /* isCounter */, true /* isRate */)
+	require.False(t, ok)
+}
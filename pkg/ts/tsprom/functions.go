@@ -0,0 +1,415 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsprom
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/prometheus/prometheus/pkg/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// rangeFunc computes the value of a function of a range vector from
+// the datapoints of a series within a range.
+type rangeFunc func(samples []sample, rangeStart, rangeEnd int64) (float64, bool)
+
+// rangeFuncs are the supported functions of range vectors.
+var rangeFuncs = map[string]rangeFunc{
+	"rate": func(samples []sample, rangeStart, rangeEnd int64) (float64, bool) {
+		return extrapolatedRate(samples, rangeStart, rangeEnd, true /* isCounter */, true /* isRate */)
+	},
+	"increase": func(samples []sample, rangeStart, rangeEnd int64) (float64, bool) {
+		return extrapolatedRate(samples, rangeStart, rangeEnd, true /* isCounter */, false /* isRate */)
+	},
+	"delta": func(samples []sample, rangeStart, rangeEnd int64) (float64, bool) {
+		return extrapolatedRate(samples, rangeStart, rangeEnd, false /* isCounter */, false /* isRate */)
+	},
+	"irate":           instantRate,
+	"avg_over_time":   overTime(parser.AVG),
+	"min_over_time":   overTime(parser.MIN),
+	"max_over_time":   overTime(parser.MAX),
+	"sum_over_time":   overTime(parser.SUM),
+	"count_over_time": overTime(parser.COUNT),
+	"last_over_time": func(samples []sample, _, _ int64) (float64, bool) {
+		if len(samples) == 0 {
+			return 0, false
+		}
+		return samples[len(samples)-1].v, true
+	},
+}
+
+// mathFuncs are the supported functions that transform every value of
+// an instant vector.
+var mathFuncs = map[string]func(float64) float64{
+	"abs":   math.Abs,
+	"ceil":  math.Ceil,
+	"floor": math.Floor,
+	"sqrt":  math.Sqrt,
+	"exp":   math.Exp,
+	"ln":    math.Log,
+	"log2":  math.Log2,
+	"log10": math.Log10,
+}
+
+// histogramQuantileSuffixes maps the quantiles supported by
+// histogram_quantile to the suffix of the Prometheus name of the series
+// that record them. See metric.HistogramMetricComputers.
+var histogramQuantileSuffixes = map[float64]string{
+	1:       "_max",
+	0.99999: "_p99_999",
+	0.9999:  "_p99_99",
+	0.999:   "_p99_9",
+	0.99:    "_p99",
+	0.9:     "_p90",
+	0.75:    "_p75",
+	0.5:     "_p50",
+}
+
+const bucketSuffix = "_bucket"
+
+func (ev *evaluator) evalCall(e *parser.Call) (interface{}, error) {
+	name := e.Func.Name
+	if fn, ok := rangeFuncs[name]; ok {
+		ms, ok := e.Args[0].(*parser.MatrixSelector)
+		if !ok {
+			return nil, errors.Newf("unsupported argument for %s: %s", name, e.Args[0])
+		}
+		// Like in Prometheus, the metric name is only preserved by the
+		// functions which do not transform the datapoints.
+		return ev.evalRangeFunc(ms, name == "last_over_time", fn)
+	}
+	if fn, ok := mathFuncs[name]; ok {
+		vec, err := ev.evalVector(e.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		return ev.mapVector(vec, func(_ int, v float64) float64 { return fn(v) }), nil
+	}
+
+	switch name {
+	case "clamp", "clamp_min", "clamp_max":
+		vec, err := ev.evalVector(e.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		bounds := make([]scalarValue, len(e.Args)-1)
+		for i, arg := range e.Args[1:] {
+			if bounds[i], err = ev.evalScalar(arg); err != nil {
+				return nil, err
+			}
+		}
+		return ev.mapVector(vec, func(i int, v float64) float64 {
+			switch name {
+			case "clamp_min":
+				return math.Max(v, bounds[0][i])
+			case "clamp_max":
+				return math.Min(v, bounds[0][i])
+			default:
+				return math.Max(bounds[0][i], math.Min(v, bounds[1][i]))
+			}
+		}), nil
+
+	case "time":
+		res := make(scalarValue, ev.steps)
+		for i := range res {
+			res[i] = float64(ev.stepTime(i)) / 1e9
+		}
+		return res, nil
+
+	case "vector":
+		s, err := ev.evalScalar(e.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		out := newSeries(labels.Labels{}, ev.steps)
+		for i, v := range s {
+			out.set(i, v)
+		}
+		return vectorValue{out}, nil
+
+	case "scalar":
+		vec, err := ev.evalVector(e.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		res := make(scalarValue, ev.steps)
+		for i := range res {
+			res[i] = math.NaN()
+			count := 0
+			for _, s := range vec {
+				if s.ok[i] {
+					res[i] = s.values[i]
+					count++
+				}
+			}
+			if count != 1 {
+				res[i] = math.NaN()
+			}
+		}
+		return res, nil
+
+	case "histogram_quantile":
+		return ev.evalHistogramQuantile(e)
+	}
+	return nil, errors.Newf("function %s is not supported", name)
+}
+
+// mapVector transforms every value of a vector. The metric name is
+// dropped from the resulting series.
+func (ev *evaluator) mapVector(vec vectorValue, fn func(i int, v float64) float64) vectorValue {
+	res := make(vectorValue, len(vec))
+	for j, in := range vec {
+		out := newSeries(dropMetricName(in.labels), ev.steps)
+		for i := 0; i < ev.steps; i++ {
+			if in.ok[i] {
+				out.set(i, fn(i, in.values[i]))
+			}
+		}
+		res[j] = out
+	}
+	return res
+}
+
+// evalHistogramQuantile evaluates histogram_quantile(φ, expr).
+//
+// The time series database does not record the buckets of histograms.
+// Instead, it records a set of quantiles computed by each node over a
+// recent window (e.g. sql.exec.latency-p99). The function is therefore
+// evaluated by rewriting expr to select the recorded quantile in place
+// of the buckets:
+//   - the selectors of `<name>_bucket` select `<name>_p99` (for φ=0.99)
+//     instead, ignoring matchers on the le label;
+//   - rate, irate and increase of these selectors are replaced by the
+//     selectors themselves, since the quantiles are already computed
+//     over a recent window;
+//   - the le label is removed from the grouping of aggregations, and the
+//     sum of quantiles is replaced by their maximum, since quantiles
+//     cannot be added.
+//
+// For example, histogram_quantile(0.99, sum by (le) (rate(
+// sql_exec_latency_bucket[5m]))) is evaluated as
+// max(sql_exec_latency_p99).
+func (ev *evaluator) evalHistogramQuantile(e *parser.Call) (interface{}, error) {
+	phi, ok := unwrapParens(e.Args[0]).(*parser.NumberLiteral)
+	if !ok {
+		return nil, errors.Newf("histogram_quantile requires a constant quantile, got %s", e.Args[0])
+	}
+	suffix, ok := histogramQuantileSuffixes[phi.Val]
+	if !ok {
+		var supported []float64
+		for q := range histogramQuantileSuffixes {
+			supported = append(supported, q)
+		}
+		sort.Float64s(supported)
+		return nil, errors.Newf("histogram_quantile: unsupported quantile %g; supported quantiles are %v",
+			phi.Val, supported)
+	}
+	// Rewrite a copy of the expression, which is also used by the caller.
+	arg, err := parser.ParseExpr(e.Args[1].String())
+	if err != nil {
+		return nil, err
+	}
+	arg, found, err := rewriteHistogramBuckets(arg, suffix)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.Newf("histogram_quantile requires a selector of histogram buckets "+
+			"(e.g. sql_exec_latency%s), got %s", bucketSuffix, e.Args[1])
+	}
+	vec, err := ev.evalVector(arg)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range vec {
+		s.labels = labels.NewBuilder(s.labels).Del(labels.MetricName, labels.BucketLabel).Labels()
+	}
+	return vec, nil
+}
+
+// rewriteHistogramBuckets rewrites expr to select the series of a
+// recorded quantile in place of histogram buckets. See
+// evalHistogramQuantile. It returns whether any selector was rewritten.
+func rewriteHistogramBuckets(expr parser.Expr, suffix string) (parser.Expr, bool, error) {
+	switch e := expr.(type) {
+	case *parser.VectorSelector:
+		if !strings.HasSuffix(e.Name, bucketSuffix) {
+			return e, false, nil
+		}
+		e.Name = strings.TrimSuffix(e.Name, bucketSuffix) + suffix
+		nameMatcher, err := labels.NewMatcher(labels.MatchEqual, labels.MetricName, e.Name)
+		if err != nil {
+			return nil, false, err
+		}
+		matchers := []*labels.Matcher{nameMatcher}
+		for _, m := range e.LabelMatchers {
+			if m.Name != labels.MetricName && m.Name != labels.BucketLabel {
+				matchers = append(matchers, m)
+			}
+		}
+		e.LabelMatchers = matchers
+		return e, true, nil
+
+	case *parser.MatrixSelector:
+		vs, found, err := rewriteHistogramBuckets(e.VectorSelector, suffix)
+		e.VectorSelector = vs
+		return e, found, err
+
+	case *parser.ParenExpr:
+		inner, found, err := rewriteHistogramBuckets(e.Expr, suffix)
+		e.Expr = inner
+		return e, found, err
+
+	case *parser.Call:
+		found := false
+		for i, arg := range e.Args {
+			rewritten, ok, err := rewriteHistogramBuckets(arg, suffix)
+			if err != nil {
+				return nil, false, err
+			}
+			e.Args[i] = rewritten
+			found = found || ok
+		}
+		switch e.Func.Name {
+		case "rate", "irate", "increase":
+			if ms, ok := e.Args[0].(*parser.MatrixSelector); ok && found {
+				return ms.VectorSelector, true, nil
+			}
+		}
+		return e, found, nil
+
+	case *parser.AggregateExpr:
+		inner, found, err := rewriteHistogramBuckets(e.Expr, suffix)
+		if err != nil || !found {
+			return e, found, err
+		}
+		e.Expr = inner
+		if !e.Without {
+			grouping := e.Grouping[:0]
+			for _, l := range e.Grouping {
+				if l != labels.BucketLabel {
+					grouping = append(grouping, l)
+				}
+			}
+			e.Grouping = grouping
+		}
+		if e.Op == parser.SUM {
+			e.Op = parser.MAX
+		}
+		return e, true, nil
+
+	case *parser.BinaryExpr:
+		lhs, lfound, err := rewriteHistogramBuckets(e.LHS, suffix)
+		if err != nil {
+			return nil, false, err
+		}
+		rhs, rfound, err := rewriteHistogramBuckets(e.RHS, suffix)
+		if err != nil {
+			return nil, false, err
+		}
+		e.LHS, e.RHS = lhs, rhs
+		return e, lfound || rfound, nil
+
+	default:
+		return expr, false, nil
+	}
+}
+
+func unwrapParens(expr parser.Expr) parser.Expr {
+	for {
+		p, ok := expr.(*parser.ParenExpr)
+		if !ok {
+			return expr
+		}
+		expr = p.Expr
+	}
+}
+
+// extrapolatedRate computes rate, increase and delta like Prometheus:
+// the difference between the first and last datapoints in the range is
+// extrapolated to the edges of the range, unless the datapoints stop
+// too far from the edges. For counters, decreases are considered as
+// resets of the counter (e.g. after a node restart).
+func extrapolatedRate(
+	samples []sample, rangeStart, rangeEnd int64, isCounter, isRate bool,
+) (float64, bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+	first, last := samples[0], samples[len(samples)-1]
+	result := last.v - first.v
+	if isCounter {
+		for i := 1; i < len(samples); i++ {
+			if samples[i].v < samples[i-1].v {
+				result += samples[i-1].v
+			}
+		}
+	}
+
+	durationToStart := float64(first.t-rangeStart) / 1e9
+	durationToEnd := float64(rangeEnd-last.t) / 1e9
+	sampledInterval := float64(last.t-first.t) / 1e9
+	averageDurationBetweenSamples := sampledInterval / float64(len(samples)-1)
+	if isCounter && result > 0 && first.v >= 0 {
+		// Counters cannot be negative: do not extrapolate the start of the
+		// range beyond the point where the counter would be zero.
+		if durationToZero := sampledInterval * (first.v / result); durationToZero < durationToStart {
+			durationToStart = durationToZero
+		}
+	}
+
+	// Extrapolate to the edges of the range if the datapoints are close
+	// enough to them, and by half the average interval otherwise.
+	extrapolationThreshold := averageDurationBetweenSamples * 1.1
+	extrapolateToInterval := sampledInterval
+	if durationToStart < extrapolationThreshold {
+		extrapolateToInterval += durationToStart
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+	if durationToEnd < extrapolationThreshold {
+		extrapolateToInterval += durationToEnd
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+	result *= extrapolateToInterval / sampledInterval
+	if isRate {
+		result /= float64(rangeEnd-rangeStart) / 1e9
+	}
+	return result, true
+}
+
+// instantRate computes irate from the last two datapoints in the range.
+func instantRate(samples []sample, _, _ int64) (float64, bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+	prev, last := samples[len(samples)-2], samples[len(samples)-1]
+	delta := last.v - prev.v
+	if last.v < prev.v {
+		// Counter reset.
+		delta = last.v
+	}
+	return delta / (float64(last.t-prev.t) / 1e9), true
+}
+
+// overTime returns a function which aggregates the datapoints in the
+// range.
+func overTime(op parser.ItemType) rangeFunc {
+	return func(samples []sample, _, _ int64) (float64, bool) {
+		if len(samples) == 0 {
+			return 0, false
+		}
+		values := make([]float64, len(samples))
+		for i, s := range samples {
+			values[i] = s.v
+		}
+		return aggregate(op, 0 /* param */, values), true
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package tsprom
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ts/tspb"
+	"github.com/cockroachdb/cockroach/pkg/ts/tsutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/prometheus/prometheus/pkg/labels"
+)
+
+const (
+	// sampleNanos is the sample duration requested from the time series
+	// database. This is the sample duration of the highest resolution
+	// stored by the database (ts.Resolution10s).
+	sampleNanos = int64(10 * time.Second)
+
+	// storeSeriesPrefix is the prefix of the names of the time series
+	// recorded for each store. The source of these series is a store ID;
+	// the source of every other series is a node ID.
+	storeSeriesPrefix = "cr.store."
+
+	// nodeLabel and storeLabel are the labels that identify the source of
+	// a series. They match the labels of the metrics scraped from the
+	// Prometheus endpoint of each node.
+	nodeLabel  = "node_id"
+	storeLabel = "store"
+	// tenantLabel identifies the tenant of the series recorded by
+	// secondary tenants.
+	tenantLabel = "tenant_id"
+)
+
+// Querier is the interface to the time series database used to
+// evaluate queries. It is implemented by ts.Server and ts.TenantServer.
+type Querier interface {
+	Query(context.Context, *tspb.TimeSeriesQueryRequest) (*tspb.TimeSeriesQueryResponse, error)
+}
+
+// MetricNamesFn returns the names of the metrics recorded in the time
+// series database, as a map from the name of each metric to the name
+// of its time series, e.g. "sql.select.count" to
+// "cr.node.sql.select.count". See
+// status.MetricsRecorder.GetRecordedMetricNames.
+type MetricNamesFn func() map[string]string
+
+// prometheusNameReplaceRE matches the characters that are not allowed in
+// Prometheus metric names. It mirrors the name conversion performed by
+// metric.PrometheusExporter, so that the names used in queries are the
+// same as the names of the metrics scraped from the nodes.
+var prometheusNameReplaceRE = regexp.MustCompile("^[^a-zA-Z_:]|[^a-zA-Z0-9_:]")
+
+func exportedName(name string) string {
+	return prometheusNameReplaceRE.ReplaceAllString(name, "_")
+}
+
+// metricIndex maps the Prometheus names of the recorded metrics to the
+// names of their time series.
+type metricIndex map[string]string
+
+func makeMetricIndex(recorded map[string]string) metricIndex {
+	idx := make(metricIndex, len(recorded))
+	for name, tsName := range recorded {
+		idx[exportedName(name)] = tsName
+	}
+	return idx
+}
+
+// names returns the sorted Prometheus names of all the metrics.
+func (idx metricIndex) names() []string {
+	names := make([]string, 0, len(idx))
+	for name := range idx {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// match returns the sorted names of the metrics that satisfy the
+// matchers on the metric name.
+func (idx metricIndex) match(matchers []*labels.Matcher) []string {
+	var res []string
+	for _, name := range idx.names() {
+		ok := true
+		for _, m := range matchers {
+			if m.Name == labels.MetricName && !m.Matches(name) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// sample is a single datapoint of a time series.
+type sample struct {
+	t int64 // nanoseconds
+	v float64
+}
+
+// rawSeries holds the datapoints of one series over a timespan.
+type rawSeries struct {
+	labels  labels.Labels
+	samples []sample
+}
+
+// storage reads the series selected by PromQL selectors from the time
+// series database.
+//
+// A series of the time series database is identified by a name and a
+// source. The series exposed to queries have a __name__ label set to
+// the Prometheus name of the metric, and a node_id or store label set
+// to the source.
+type storage struct {
+	querier Querier
+	index   metricIndex
+}
+
+// seriesLabels returns the labels of the series with the given name
+// and source.
+func seriesLabels(name, tsName, source string) labels.Labels {
+	sourceLabel := nodeLabel
+	if strings.HasPrefix(tsName, storeSeriesPrefix) {
+		sourceLabel = storeLabel
+	}
+	primary, tenant := tsutil.DecodeSource(source)
+	ls := []labels.Label{
+		{Name: labels.MetricName, Value: name},
+		{Name: sourceLabel, Value: primary},
+	}
+	if tenant != "" {
+		ls = append(ls, labels.Label{Name: tenantLabel, Value: tenant})
+	}
+	return labels.New(ls...)
+}
+
+func matchesAll(matchers []*labels.Matcher, ls labels.Labels) bool {
+	for _, m := range matchers {
+		if !m.Matches(ls.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// clampTimespan limits the end of the timespan to the last complete
+// sample period, since the time series database rejects queries in the
+// future. It returns false if no data can exist in the timespan.
+func clampTimespan(start, end int64) (int64, int64, bool) {
+	if cutoff := timeutil.Now().UnixNano() - sampleNanos; end > cutoff {
+		end = cutoff
+	}
+	return start, end, start <= end
+}
+
+// sources returns the sorted sources of the series with the given name
+// that have data between start and end.
+func (s *storage) sources(ctx context.Context, tsName string, start, end int64) ([]string, error) {
+	resp, err := s.querier.Query(ctx, &tspb.TimeSeriesQueryRequest{
+		StartNanos:  start,
+		EndNanos:    end,
+		SampleNanos: sampleNanos,
+		Queries:     []tspb.Query{{Name: tsName}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var sources []string
+	for _, r := range resp.Results {
+		sources = append(sources, r.Sources...)
+	}
+	sort.Strings(sources)
+	return sources, nil
+}
+
+// series returns the labels of the series selected by the matchers that
+// have data between start and end, without reading their datapoints.
+func (s *storage) series(
+	ctx context.Context, matchers []*labels.Matcher, start, end int64,
+) ([]labels.Labels, error) {
+	start, end, ok := clampTimespan(start, end)
+	if !ok {
+		return nil, nil
+	}
+	var res []labels.Labels
+	for _, name := range s.index.match(matchers) {
+		tsName := s.index[name]
+		sources, err := s.sources(ctx, tsName, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range sources {
+			if ls := seriesLabels(name, tsName, source); matchesAll(matchers, ls) {
+				res = append(res, ls)
+			}
+		}
+	}
+	return res, nil
+}
+
+// fetch returns the datapoints between start and end of the series
+// selected by the matchers. The datapoints of each source are read
+// separately, so that they are not aggregated by the time series
+// database.
+func (s *storage) fetch(
+	ctx context.Context, matchers []*labels.Matcher, start, end int64,
+) ([]rawSeries, error) {
+	start, end, ok := clampTimespan(start, end)
+	if !ok {
+		return nil, nil
+	}
+	var res []rawSeries
+	for _, name := range s.index.match(matchers) {
+		tsName := s.index[name]
+		sources, err := s.sources(ctx, tsName, start, end)
+		if err != nil {
+			return nil, err
+		}
+		req := &tspb.TimeSeriesQueryRequest{
+			StartNanos:  start,
+			EndNanos:    end,
+			SampleNanos: sampleNanos,
+		}
+		var selected []labels.Labels
+		for _, source := range sources {
+			ls := seriesLabels(name, tsName, source)
+			if !matchesAll(matchers, ls) {
+				continue
+			}
+			selected = append(selected, ls)
+			req.Queries = append(req.Queries, tspb.Query{
+				Name:        tsName,
+				Downsampler: tspb.TimeSeriesQueryAggregator_AVG.Enum(),
+				Sources:     []string{source},
+			})
+		}
+		if len(req.Queries) == 0 {
+			continue
+		}
+		resp, err := s.querier.Query(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for i, r := range resp.Results {
+			if len(r.Datapoints) == 0 {
+				continue
+			}
+			rs := rawSeries{labels: selected[i], samples: make([]sample, len(r.Datapoints))}
+			for j, dp := range r.Datapoints {
+				rs.samples[j] = sample{t: dp.TimestampNanos, v: dp.Value}
+			}
+			res = append(res, rs)
+		}
+	}
+	return res, nil
+}
@@ -101,6 +101,14 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.RestoreActionNotRequired("cluster restore does not restore this job"),
 	),
 
+	upgrade.NewTenantUpgrade(
+		"create notifications table",
+		clusterversion.V25_2_AddNotificationsTable.Version(),
+		upgrade.NoPrecondition,
+		createNotificationsTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
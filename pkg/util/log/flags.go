@@ -366,6 +366,32 @@ func ApplyConfig(
 		attachSinkInfo(httpSinkInfo, &fc.Channels)
 	}
 
+	// Create the OpenTelemetry sinks.
+	for _, fc := range config.Sinks.OTLPServers {
+		if fc.Filter == severity.NONE {
+			continue
+		}
+		otlpSinkInfo, err := newOTLPSinkInfo(*fc)
+		if err != nil {
+			return nil, err
+		}
+		attachBufferWrapper(otlpSinkInfo, fc.CommonSinkConfig.Buffering, closer)
+		attachSinkInfo(otlpSinkInfo, &fc.Channels)
+	}
+
+	// Create the syslog sinks.
+	for _, fc := range config.Sinks.SyslogServers {
+		if fc.Filter == severity.NONE {
+			continue
+		}
+		syslogSinkInfo, err := newSyslogSinkInfo(*fc)
+		if err != nil {
+			return nil, err
+		}
+		attachBufferWrapper(syslogSinkInfo, fc.CommonSinkConfig.Buffering, closer)
+		attachSinkInfo(syslogSinkInfo, &fc.Channels)
+	}
+
 	// Prepend the interceptor sink to all channels.
 	// We prepend it because we want the interceptors
 	// to see every event before they make their way to disk/network.
@@ -433,6 +459,52 @@ func newHTTPSinkInfo(c logconfig.HTTPSinkConfig) (*sinkInfo, error) {
 	return info, nil
 }
 
+// newOTLPSinkInfo creates a new otlpSink and its accompanying sinkInfo
+// from the provided configuration.
+func newOTLPSinkInfo(c logconfig.OTLPSinkConfig) (*sinkInfo, error) {
+	info := &sinkInfo{}
+	if err := info.applyConfig(c.CommonSinkConfig); err != nil {
+		return nil, err
+	}
+	info.applyFilters(c.Channels)
+
+	// The sink needs to know which field names the JSON formatter uses
+	// to decode the entries.
+	f, ok := info.formatter.(*formatJSONFull)
+	if !ok {
+		return nil, errors.Newf("unsupported format for OTLP sink: %q", *c.Format)
+	}
+	otlpSink, err := newOTLPSink(c, f.tags == tagCompact)
+	if err != nil {
+		return nil, err
+	}
+	info.sink = otlpSink
+	return info, nil
+}
+
+// newSyslogSinkInfo creates a new syslogSink and its accompanying
+// sinkInfo from the provided configuration.
+func newSyslogSinkInfo(c logconfig.SyslogSinkConfig) (*sinkInfo, error) {
+	info := &sinkInfo{}
+	if err := info.applyConfig(c.CommonSinkConfig); err != nil {
+		return nil, err
+	}
+	info.applyFilters(c.Channels)
+
+	// The sink needs to know which field names the JSON formatter uses
+	// to decode the entries.
+	f, ok := info.formatter.(*formatJSONFull)
+	if !ok {
+		return nil, errors.Newf("unsupported format for syslog sink: %q", *c.Format)
+	}
+	syslogSink, err := newSyslogSink(c, f.tags == tagCompact)
+	if err != nil {
+		return nil, err
+	}
+	info.sink = syslogSink
+	return info, nil
+}
+
 // applyFilters applies the channel filters to a sinkInfo.
 func (l *sinkInfo) applyFilters(chs logconfig.ChannelFilters) {
 	for ch, threshold := range chs.ChannelFilters {
@@ -662,6 +734,50 @@ func DescribeAppliedConfig() string {
 		return nil
 	})
 
+	// Describe the OpenTelemetry sinks.
+	config.Sinks.OTLPServers = make(map[string]*logconfig.OTLPSinkConfig)
+	sIdx = 1
+	_ = logging.allSinkInfos.iter(func(l *sinkInfo) error {
+		oSink, ok := l.sink.(*otlpSink)
+		if !ok {
+			// Check to see if it's an otlpSink wrapped in a bufferedSink.
+			bufferedSink, ok := l.sink.(*bufferedSink)
+			if !ok {
+				return nil
+			}
+			oSink, ok = bufferedSink.child.(*otlpSink)
+			if !ok {
+				return nil
+			}
+		}
+		skey := fmt.Sprintf("s%d", sIdx)
+		sIdx++
+		config.Sinks.OTLPServers[skey] = oSink.config
+		return nil
+	})
+
+	// Describe the syslog sinks.
+	config.Sinks.SyslogServers = make(map[string]*logconfig.SyslogSinkConfig)
+	sIdx = 1
+	_ = logging.allSinkInfos.iter(func(l *sinkInfo) error {
+		sSink, ok := l.sink.(*syslogSink)
+		if !ok {
+			// Check to see if it's a syslogSink wrapped in a bufferedSink.
+			bufferedSink, ok := l.sink.(*bufferedSink)
+			if !ok {
+				return nil
+			}
+			sSink, ok = bufferedSink.child.(*syslogSink)
+			if !ok {
+				return nil
+			}
+		}
+		skey := fmt.Sprintf("s%d", sIdx)
+		sIdx++
+		config.Sinks.SyslogServers[skey] = sSink.config
+		return nil
+	})
+
 	// Note: we cannot return 'config' directly, because this captures
 	// certain variables from the loggers by reference and thus could be
 	// invalidated by concurrent uses of ApplyConfig().
@@ -647,6 +647,28 @@ func (e *JSONCompactEntry) toEntry(entry *JSONEntry) {
 	entry.TenantName = e.TenantName
 }
 
+// decodeJSONEntry decodes a single JSON-formatted log entry, as
+// produced by the JSON formatters. The compact argument indicates
+// whether the entry uses the compact field names. Numbers in the tags
+// and event payload are decoded as json.Number.
+func decodeJSONEntry(line []byte, compact bool) (*JSONEntry, error) {
+	d := json.NewDecoder(bytes.NewReader(line))
+	d.UseNumber()
+	var e JSONEntry
+	if compact {
+		var c JSONCompactEntry
+		if err := d.Decode(&c); err != nil {
+			return nil, err
+		}
+		c.toEntry(&e)
+	} else {
+		if err := d.Decode(&e); err != nil {
+			return nil, err
+		}
+	}
+	return &e, nil
+}
+
 // Decode decodes the next log entry into the provided protobuf message.
 func (d *entryDecoderJSON) Decode(entry *logpb.Entry) (err error) {
 	defer func() {
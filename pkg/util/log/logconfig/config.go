@@ -35,6 +35,20 @@ const DefaultFluentFormat = `json-fluent-compact`
 // when not specified in a configuration.
 const DefaultHTTPFormat = `json-compact`
 
+// DefaultOTLPFormat is the entry format for OpenTelemetry sinks
+// when not specified in a configuration.
+const DefaultOTLPFormat = `json`
+
+// DefaultSyslogFormat is the entry format for syslog sinks
+// when not specified in a configuration.
+const DefaultSyslogFormat = `json`
+
+// DefaultSyslogStructuredDataID is the SD-ID of the structured data
+// element emitted by syslog sinks when not specified in a
+// configuration. 32473 is the private enterprise number reserved for
+// documentation by RFC 5612.
+const DefaultSyslogStructuredDataID = `cockroach@32473`
+
 // DefaultFilePerms is the default permissions used in file-defaults. It
 // is applied literally via os.Chmod, without considering the umask.
 const DefaultFilePerms = FilePermissions(0o640)
@@ -72,6 +86,26 @@ http-defaults:
       max-staleness: 5s	
       flush-trigger-size: 1mib
       max-buffer-size: 50mib
+otlp-defaults:
+    filter: INFO
+    format: ` + DefaultOTLPFormat + `
+    redactable: true
+    exit-on-error: false
+    timeout: 2s
+    buffering:
+      max-staleness: 5s
+      flush-trigger-size: 1mib
+      max-buffer-size: 50mib
+syslog-defaults:
+    filter: INFO
+    format: ` + DefaultSyslogFormat + `
+    redactable: true
+    exit-on-error: false
+    timeout: 5s
+    buffering:
+      max-staleness: 5s
+      flush-trigger-size: 1mib
+      max-buffer-size: 50mib
 sinks:
   stderr:
     filter: NONE
@@ -125,6 +159,16 @@ type Config struct {
 	// configuration value.
 	HTTPDefaults HTTPDefaults `yaml:"http-defaults,omitempty"`
 
+	// OTLPDefaults represents the default configuration for OpenTelemetry
+	// sinks, inherited when a specific OpenTelemetry sink config does not
+	// provide a configuration value.
+	OTLPDefaults OTLPDefaults `yaml:"otlp-defaults,omitempty"`
+
+	// SyslogDefaults represents the default configuration for syslog
+	// sinks, inherited when a specific syslog sink config does not
+	// provide a configuration value.
+	SyslogDefaults SyslogDefaults `yaml:"syslog-defaults,omitempty"`
+
 	// Sinks represents the sink configurations.
 	Sinks SinkConfig `yaml:",omitempty"`
 
@@ -249,6 +293,10 @@ type SinkConfig struct {
 	FluentServers map[string]*FluentSinkConfig `yaml:"fluent-servers,omitempty"`
 	// HTTPServers represents the list of configured http sinks.
 	HTTPServers map[string]*HTTPSinkConfig `yaml:"http-servers,omitempty"`
+	// OTLPServers represents the list of configured OpenTelemetry sinks.
+	OTLPServers map[string]*OTLPSinkConfig `yaml:"otlp-servers,omitempty"`
+	// SyslogServers represents the list of configured syslog sinks.
+	SyslogServers map[string]*SyslogSinkConfig `yaml:"syslog-servers,omitempty"`
 	// Stderr represents the configuration for the stderr sink.
 	Stderr StderrSinkConfig `yaml:",omitempty"`
 }
@@ -569,6 +617,234 @@ type HTTPSinkConfig struct {
 	sinkName string
 }
 
+// OTLPDefaults represents the configuration defaults for OpenTelemetry
+// sinks.
+type OTLPDefaults struct {
+	// Address is the network address of the OpenTelemetry collector. When
+	// the protocol is "grpc", this is a host/address and port pair
+	// separated with a colon, e.g. 127.0.0.1:4317. When the protocol is
+	// "http", this is the URL of the collector's logs endpoint, e.g.
+	// http://127.0.0.1:4318/v1/logs.
+	Address *string `yaml:",omitempty"`
+
+	// Protocol is the OTLP transport used to export log records. "grpc"
+	// and "http" (protobuf-encoded requests over HTTP) are supported;
+	// defaults to "grpc".
+	Protocol *OTLPSinkProtocol `yaml:",omitempty"`
+
+	// Insecure disables TLS for the connection to the collector when the
+	// protocol is "grpc". With the "http" protocol, the scheme of the
+	// address determines whether TLS is used. Defaults to false.
+	Insecure *bool `yaml:",omitempty"`
+
+	// UnsafeTLS enables certificate authentication to be bypassed.
+	// Defaults to false.
+	UnsafeTLS *bool `yaml:"unsafe-tls,omitempty"`
+
+	// Timeout is the timeout for each export request. Set to 0 for
+	// no timeout. Defaults to 2s.
+	Timeout *time.Duration `yaml:",omitempty"`
+
+	// Headers is a list of headers to attach to each export request. With
+	// the "grpc" protocol, the headers are sent as request metadata.
+	Headers map[string]string `yaml:",omitempty,flow"`
+
+	// Compression can be "none" or "gzip" to enable gzip compression.
+	// Set to "gzip" by default.
+	Compression *string `yaml:",omitempty"`
+
+	CommonSinkConfig `yaml:",inline"`
+}
+
+// OTLPSinkConfig represents the configuration for one OpenTelemetry sink.
+//
+// User-facing documentation follows.
+// TITLE: Output to OpenTelemetry collectors
+//
+// This sink type causes logging data to be exported over the network
+// to a log collector that ingests the [OpenTelemetry
+// protocol](https://opentelemetry.io/docs/specs/otlp/) (OTLP), using
+// either gRPC or protobuf-encoded requests over HTTP.
+//
+// Each logging event is exported as an OTLP log record. The severity,
+// timestamp and message of the event are mapped to the corresponding
+// fields of the log record. The logging channel, the source location,
+// the logging tags and the server identifiers are reported as log
+// record attributes with the `cockroach.` prefix. For structured events,
+// the event type is reported as the name of the log record and each
+// field of the event payload is reported as an attribute with the
+// `cockroach.event.` prefix.
+//
+// The configuration key under the `sinks` key in the YAML
+// configuration is `otlp-servers`. Example configuration:
+//
+//	sinks:
+//	   otlp-servers:
+//	      health:
+//	         channels: HEALTH
+//	         address: 127.0.0.1:4317
+//
+// Every new server sink configured automatically inherits the configuration set in the `otlp-defaults` section.
+//
+// For example:
+//
+//	otlp-defaults:
+//	    redactable: false # default: disable redaction markers
+//	sinks:
+//	  otlp-servers:
+//	    health:
+//	       channels: HEALTH
+//	       # This sink has redactable set to false,
+//	       # as the setting is inherited from otlp-defaults
+//	       # unless overridden here.
+//
+// The default output format for OpenTelemetry sinks is `json`. Only
+// the JSON [formats](log-formats.html) are supported, since the sink
+// extracts the record fields and attributes from the formatted event.
+//
+// {{site.data.alerts.callout_info}}
+// Run `cockroach debug check-log-config` to verify the effect of defaults inheritance.
+// {{site.data.alerts.end}}
+type OTLPSinkConfig struct {
+	// Channels is the list of logging channels that use this sink.
+	Channels ChannelFilters `yaml:",omitempty,flow"`
+
+	OTLPDefaults `yaml:",inline"`
+
+	// sinkName is populated during validation.
+	sinkName string
+}
+
+// SyslogDefaults represents the configuration defaults for syslog
+// sinks.
+type SyslogDefaults struct {
+	// Address is the network address of the syslog collector. The
+	// host/address and port parts are separated with a colon. IPv6
+	// numeric addresses should be included within square brackets,
+	// e.g.: [::1]:6514.
+	Address *string `yaml:",omitempty"`
+
+	// Net is the transport used to reach the collector. Can be "tcp",
+	// "udp", "tcp4", etc. Defaults to "tcp".
+	Net *string `yaml:",omitempty"`
+
+	// TLS enables TLS on the connection to the collector. Only
+	// supported with TCP. Defaults to false.
+	TLS *bool `yaml:"tls,omitempty"`
+
+	// UnsafeTLS enables certificate authentication to be bypassed.
+	// Defaults to false.
+	UnsafeTLS *bool `yaml:"unsafe-tls,omitempty"`
+
+	// CACert is the path to a PEM file containing the certificate
+	// authorities used to verify the collector's certificate. Defaults
+	// to the system's certificate authorities.
+	CACert *string `yaml:"ca-cert,omitempty"`
+
+	// ClientCert is the path to a PEM certificate presented to the
+	// collector for mutual TLS authentication. Requires client-key.
+	ClientCert *string `yaml:"client-cert,omitempty"`
+
+	// ClientKey is the path to the PEM private key of the client
+	// certificate. Requires client-cert.
+	ClientKey *string `yaml:"client-key,omitempty"`
+
+	// Timeout is the timeout for connecting to the collector and for
+	// writing each batch of messages. Set to 0 for no timeout. Defaults
+	// to 5s.
+	Timeout *time.Duration `yaml:",omitempty"`
+
+	// AppName is reported as the APP-NAME field of every message.
+	// Defaults to "cockroach".
+	AppName *string `yaml:"app-name,omitempty"`
+
+	// Facility is the syslog facility of the messages, e.g. "user",
+	// "auth" or "local0". Defaults to "user".
+	Facility *SyslogFacility `yaml:",omitempty"`
+
+	// ChannelFacilities overrides the facility for specific logging
+	// channels, e.g. {SENSITIVE_ACCESS: authpriv}.
+	ChannelFacilities map[string]SyslogFacility `yaml:"channel-facilities,omitempty,flow"`
+
+	// StructuredDataID is the SD-ID of the structured data element
+	// that carries the logging tags and server identifiers. It must
+	// have the form name@<private enterprise number>. Defaults to
+	// "cockroach@32473"; organizations with their own private
+	// enterprise number may prefer to use it instead.
+	StructuredDataID *string `yaml:"structured-data-id,omitempty"`
+
+	CommonSinkConfig `yaml:",inline"`
+}
+
+// SyslogSinkConfig represents the configuration for one syslog sink.
+//
+// User-facing documentation follows.
+// TITLE: Output to syslog collectors
+//
+// This sink type causes logging data to be sent over the network to
+// a syslog collector, as [RFC 5424](https://www.rfc-editor.org/rfc/rfc5424)
+// messages. Over TCP, with or without TLS, the messages are framed
+// using octet counting as described in [RFC
+// 6587](https://www.rfc-editor.org/rfc/rfc6587). Over UDP, each
+// message is sent in its own datagram.
+//
+// The header of each message carries the timestamp and severity of
+// the logging event, and the logging channel as MSGID. The logging
+// tags and the server identifiers are reported in a structured data
+// element, by default `[cockroach@32473 ...]`. The body of each message
+// is the logging event, formatted as configured.
+//
+// The severity of the logging event determines the syslog severity.
+// The facility is configured using `facility`, and can be overridden
+// for specific channels using `channel-facilities`.
+//
+// If the connection to the collector fails, it is re-established the
+// next time an event is written.
+//
+// The configuration key under the `sinks` key in the YAML
+// configuration is `syslog-servers`. Example configuration:
+//
+//	sinks:
+//	   syslog-servers:
+//	      audit:
+//	         channels: [SENSITIVE_ACCESS, SESSIONS, PRIVILEGES]
+//	         address: syslog.example.com:6514
+//	         tls: true
+//	         facility: auth
+//	         channel-facilities: {SENSITIVE_ACCESS: authpriv}
+//
+// Every new server sink configured automatically inherits the configuration set in the `syslog-defaults` section.
+//
+// For example:
+//
+//	syslog-defaults:
+//	    redactable: false # default: disable redaction markers
+//	sinks:
+//	  syslog-servers:
+//	    health:
+//	       channels: HEALTH
+//	       # This sink has redactable set to false,
+//	       # as the setting is inherited from syslog-defaults
+//	       # unless overridden here.
+//
+// The default output format for syslog sinks is `json`. Only the
+// JSON [formats](log-formats.html) are supported, since the sink
+// extracts the message header and structured data from the formatted
+// event.
+//
+// {{site.data.alerts.callout_info}}
+// Run `cockroach debug check-log-config` to verify the effect of defaults inheritance.
+// {{site.data.alerts.end}}
+type SyslogSinkConfig struct {
+	// Channels is the list of logging channels that use this sink.
+	Channels ChannelFilters `yaml:",omitempty,flow"`
+
+	SyslogDefaults `yaml:",inline"`
+
+	// sinkName is populated during validation.
+	sinkName string
+}
+
 // IterateDirectories calls the provided fn on every directory linked to
 // by the configuration.
 func (c *Config) IterateDirectories(fn func(d string) error) error {
@@ -1157,6 +1433,90 @@ func (hsm *HTTPSinkMethod) UnmarshalYAML(fn func(interface{}) error) error {
 	return unmarshalYAMLConstrainedString(hsm, fn)
 }
 
+// OTLPSinkProtocol is a string restricted to "grpc" and "http".
+type OTLPSinkProtocol string
+
+const (
+	OTLPProtocolGRPC OTLPSinkProtocol = "grpc"
+	OTLPProtocolHTTP OTLPSinkProtocol = "http"
+)
+
+var _ constrainedString = (*OTLPSinkProtocol)(nil)
+
+// Accept implements the constrainedString interface.
+func (p *OTLPSinkProtocol) Accept(s string) {
+	*p = OTLPSinkProtocol(s)
+}
+
+// Canonicalize implements the constrainedString interface.
+func (OTLPSinkProtocol) Canonicalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// AllowedSet implements the constrainedString interface.
+func (OTLPSinkProtocol) AllowedSet() []string {
+	return []string{string(OTLPProtocolGRPC), string(OTLPProtocolHTTP)}
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (p OTLPSinkProtocol) MarshalYAML() (interface{}, error) {
+	return string(p), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (p *OTLPSinkProtocol) UnmarshalYAML(fn func(interface{}) error) error {
+	return unmarshalYAMLConstrainedString(p, fn)
+}
+
+// SyslogFacility is a string restricted to the syslog facility
+// keywords.
+type SyslogFacility string
+
+// syslogFacilities lists the facility keywords in the order of their
+// numerical code, as defined in RFC 5424.
+var syslogFacilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+// Code returns the numerical code of the facility.
+func (f SyslogFacility) Code() int {
+	for i, name := range syslogFacilities {
+		if string(f) == name {
+			return i
+		}
+	}
+	return 1 // user
+}
+
+var _ constrainedString = (*SyslogFacility)(nil)
+
+// Accept implements the constrainedString interface.
+func (f *SyslogFacility) Accept(s string) {
+	*f = SyslogFacility(s)
+}
+
+// Canonicalize implements the constrainedString interface.
+func (SyslogFacility) Canonicalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// AllowedSet implements the constrainedString interface.
+func (SyslogFacility) AllowedSet() []string {
+	return syslogFacilities
+}
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (f SyslogFacility) MarshalYAML() (interface{}, error) {
+	return string(f), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (f *SyslogFacility) UnmarshalYAML(fn func(interface{}) error) error {
+	return unmarshalYAMLConstrainedString(f, fn)
+}
+
 // constrainedString is an interface to make it easy to unmarshal
 // a string constrained to a small set of accepted values.
 type constrainedString interface {
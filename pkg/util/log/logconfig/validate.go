@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"reflect"
 	"sort"
@@ -111,14 +112,62 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		}(),
 		Compression: &GzipCompression,
 	}
+	baseOTLPDefaults := OTLPDefaults{
+		CommonSinkConfig: CommonSinkConfig{
+			Format: func() *string { s := DefaultOTLPFormat; return &s }(),
+			Buffering: CommonBufferSinkConfigWrapper{
+				CommonBufferSinkConfig: CommonBufferSinkConfig{
+					MaxStaleness:     &defaultBufferedStaleness,
+					FlushTriggerSize: &defaultFlushTriggerSize,
+					MaxBufferSize:    &defaultMaxBufferSize,
+					Format:           &bufferFmt,
+				},
+			},
+		},
+		Protocol:  func() *OTLPSinkProtocol { p := OTLPProtocolGRPC; return &p }(),
+		Insecure:  &bf,
+		UnsafeTLS: &bf,
+		Timeout: func() *time.Duration {
+			twoS := 2 * time.Second
+			return &twoS
+		}(),
+		Compression: &GzipCompression,
+	}
+	baseSyslogDefaults := SyslogDefaults{
+		CommonSinkConfig: CommonSinkConfig{
+			Format: func() *string { s := DefaultSyslogFormat; return &s }(),
+			Buffering: CommonBufferSinkConfigWrapper{
+				CommonBufferSinkConfig: CommonBufferSinkConfig{
+					MaxStaleness:     &defaultBufferedStaleness,
+					FlushTriggerSize: &defaultFlushTriggerSize,
+					MaxBufferSize:    &defaultMaxBufferSize,
+					Format:           &bufferFmt,
+				},
+			},
+		},
+		Net:       func() *string { s := "tcp"; return &s }(),
+		TLS:       &bf,
+		UnsafeTLS: &bf,
+		Timeout: func() *time.Duration {
+			fiveS := 5 * time.Second
+			return &fiveS
+		}(),
+		AppName:          func() *string { s := "cockroach"; return &s }(),
+		Facility:         func() *SyslogFacility { f := SyslogFacility("user"); return &f }(),
+		StructuredDataID: func() *string { s := DefaultSyslogStructuredDataID; return &s }(),
+	}
 
 	propagateCommonDefaults(&baseFileDefaults.CommonSinkConfig, baseCommonSinkConfig)
 	propagateCommonDefaults(&baseFluentDefaults.CommonSinkConfig, baseCommonSinkConfig)
 	propagateCommonDefaults(&baseHTTPDefaults.CommonSinkConfig, baseCommonSinkConfig)
+	propagateCommonDefaults(&baseOTLPDefaults.CommonSinkConfig, baseCommonSinkConfig)
+	propagateCommonDefaults(&baseSyslogDefaults.CommonSinkConfig, baseCommonSinkConfig)
 
 	propagateFileDefaults(&c.FileDefaults, baseFileDefaults)
 	propagateFluentDefaults(&c.FluentDefaults, baseFluentDefaults)
 	propagateHTTPDefaults(&c.HTTPDefaults, baseHTTPDefaults)
+	propagateOTLPDefaults(&c.OTLPDefaults, baseOTLPDefaults)
+	propagateSyslogDefaults(&c.SyslogDefaults, baseSyslogDefaults)
 
 	// Normalize the directory.
 	if err := normalizeDir(&c.FileDefaults.Dir); err != nil {
@@ -160,6 +209,28 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		}
 	}
 
+	for sinkName, fc := range c.Sinks.OTLPServers {
+		if fc == nil {
+			fc = &OTLPSinkConfig{Channels: SelectChannels()}
+			c.Sinks.OTLPServers[sinkName] = fc
+		}
+		fc.sinkName = sinkName
+		if err := c.validateOTLPSinkConfig(fc); err != nil {
+			fmt.Fprintf(&errBuf, "otlp server %q: %v\n", sinkName, err)
+		}
+	}
+
+	for sinkName, fc := range c.Sinks.SyslogServers {
+		if fc == nil {
+			fc = &SyslogSinkConfig{Channels: SelectChannels()}
+			c.Sinks.SyslogServers[sinkName] = fc
+		}
+		fc.sinkName = sinkName
+		if err := c.validateSyslogSinkConfig(fc); err != nil {
+			fmt.Fprintf(&errBuf, "syslog server %q: %v\n", sinkName, err)
+		}
+	}
+
 	// Defaults for stderr.
 	if c.Sinks.Stderr.Filter == logpb.Severity_UNKNOWN {
 		c.Sinks.Stderr.Filter = logpb.Severity_NONE
@@ -245,6 +316,32 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		}
 	}
 
+	for sinkName, fc := range c.Sinks.OTLPServers {
+		if len(fc.Channels.Filters) == 0 {
+			fmt.Fprintf(&errBuf, "otlp server %q: no channel selected\n", sinkName)
+			continue
+		}
+		// Propagate the sink-wide default filter to all channels that don't
+		// have a filter yet.
+		if err := fc.Channels.Validate(fc.Filter); err != nil {
+			fmt.Fprintf(&errBuf, "otlp server %q: %v\n", sinkName, err)
+			continue
+		}
+	}
+
+	for sinkName, fc := range c.Sinks.SyslogServers {
+		if len(fc.Channels.Filters) == 0 {
+			fmt.Fprintf(&errBuf, "syslog server %q: no channel selected\n", sinkName)
+			continue
+		}
+		// Propagate the sink-wide default filter to all channels that don't
+		// have a filter yet.
+		if err := fc.Channels.Validate(fc.Filter); err != nil {
+			fmt.Fprintf(&errBuf, "syslog server %q: %v\n", sinkName, err)
+			continue
+		}
+	}
+
 	// If capture-stray-errors was enabled, then perform some additional
 	// validation on it.
 	if c.CaptureFd2.Enable {
@@ -350,6 +447,22 @@ func (c *Config) Validate(defaultLogDir *string) (resErr error) {
 		}
 	}
 
+	// Elide all the OpenTelemetry sinks where all channels have
+	// severity set to NONE.
+	for serverName, fc := range c.Sinks.OTLPServers {
+		if fc.Channels.noChannelsSelected() {
+			delete(c.Sinks.OTLPServers, serverName)
+		}
+	}
+
+	// Elide all the syslog sinks where all channels have severity set
+	// to NONE.
+	for serverName, fc := range c.Sinks.SyslogServers {
+		if fc.Channels.noChannelsSelected() {
+			delete(c.Sinks.SyslogServers, serverName)
+		}
+	}
+
 	return nil
 }
 
@@ -478,6 +591,97 @@ func (c *Config) validateHTTPSinkConfig(hsc *HTTPSinkConfig) error {
 	return c.ValidateCommonSinkConfig(hsc.CommonSinkConfig)
 }
 
+func (c *Config) validateOTLPSinkConfig(osc *OTLPSinkConfig) error {
+	propagateOTLPDefaults(&osc.OTLPDefaults, c.OTLPDefaults)
+	if osc.Address == nil || len(strings.TrimSpace(*osc.Address)) == 0 {
+		return errors.New("address cannot be empty")
+	}
+	if *osc.Protocol == OTLPProtocolHTTP {
+		u, err := url.Parse(*osc.Address)
+		if err != nil {
+			return errors.Wrap(err, "invalid address")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Newf("address must be an http or https URL when using protocol %q", OTLPProtocolHTTP)
+		}
+	}
+	if *osc.Compression != GzipCompression && *osc.Compression != NoneCompression {
+		return errors.New("compression must be 'gzip' or 'none'")
+	}
+	// The sink extracts the log record fields from the formatted
+	// entries, so only the JSON formats can be used.
+	if !strings.HasPrefix(*osc.Format, "json") {
+		return errors.Newf("unsupported format %q: only the JSON formats can be used", *osc.Format)
+	}
+	return c.ValidateCommonSinkConfig(osc.CommonSinkConfig)
+}
+
+func (c *Config) validateSyslogSinkConfig(ssc *SyslogSinkConfig) error {
+	propagateSyslogDefaults(&ssc.SyslogDefaults, c.SyslogDefaults)
+	if ssc.Address == nil || len(strings.TrimSpace(*ssc.Address)) == 0 {
+		return errors.New("address cannot be empty")
+	}
+	net := strings.ToLower(strings.TrimSpace(*ssc.Net))
+	ssc.Net = &net
+	switch net {
+	case "tcp", "tcp4", "tcp6":
+	case "udp", "udp4", "udp6":
+		if *ssc.TLS {
+			return errors.New("TLS is only supported over TCP")
+		}
+	default:
+		return errors.Newf("unknown protocol: %q", *ssc.Net)
+	}
+	if (ssc.ClientCert == nil) != (ssc.ClientKey == nil) {
+		return errors.New("client-cert and client-key must be specified together")
+	}
+	if !*ssc.TLS && (ssc.CACert != nil || ssc.ClientCert != nil) {
+		return errors.New("ca-cert, client-cert and client-key require tls to be enabled")
+	}
+	if err := validateSyslogStructuredDataID(*ssc.StructuredDataID); err != nil {
+		return err
+	}
+	if len(ssc.ChannelFacilities) > 0 {
+		// Normalize the channel names, so that the sink can look them up
+		// by the canonical channel name.
+		facilities := make(map[string]SyslogFacility, len(ssc.ChannelFacilities))
+		for chName, f := range ssc.ChannelFacilities {
+			ch, ok := logpb.Channel_value[strings.ToUpper(strings.TrimSpace(chName))]
+			if !ok {
+				return errors.Newf("unknown channel in channel-facilities: %q", chName)
+			}
+			facilities[logpb.Channel(ch).String()] = f
+		}
+		ssc.ChannelFacilities = facilities
+	}
+	// The sink extracts the message header from the formatted entries,
+	// so only the JSON formats can be used.
+	if !strings.HasPrefix(*ssc.Format, "json") {
+		return errors.Newf("unsupported format %q: only the JSON formats can be used", *ssc.Format)
+	}
+	return c.ValidateCommonSinkConfig(ssc.CommonSinkConfig)
+}
+
+// validateSyslogStructuredDataID checks that the SD-ID is a valid
+// custom SD-ID as per RFC 5424: up to 32 printable ASCII characters,
+// excluding '=', space, ']' and '"', containing an '@' followed by a
+// private enterprise number.
+func validateSyslogStructuredDataID(id string) error {
+	if len(id) == 0 || len(id) > 32 {
+		return errors.Newf("invalid structured-data-id %q: must contain between 1 and 32 characters", id)
+	}
+	for _, r := range id {
+		if r <= ' ' || r > '~' || r == '=' || r == ']' || r == '"' {
+			return errors.Newf("invalid structured-data-id %q: invalid character %q", id, r)
+		}
+	}
+	at := strings.IndexByte(id, '@')
+	if at <= 0 || at == len(id)-1 || strings.IndexByte(id[at+1:], '@') >= 0 {
+		return errors.Newf("invalid structured-data-id %q: must have the form name@<enterprise number>", id)
+	}
+	return nil
+}
+
 func normalizeDir(dir **string) error {
 	if *dir == nil {
 		return nil
@@ -512,6 +716,14 @@ func propagateHTTPDefaults(target *HTTPDefaults, source HTTPDefaults) {
 	propagateDefaults(target, source)
 }
 
+func propagateOTLPDefaults(target *OTLPDefaults, source OTLPDefaults) {
+	propagateDefaults(target, source)
+}
+
+func propagateSyslogDefaults(target *SyslogDefaults, source SyslogDefaults) {
+	propagateDefaults(target, source)
+}
+
 // propagateDefaults takes (target *T, source T) where T is a struct
 // and sets zero-valued exported fields in target to the values
 // from source (recursively for struct-valued fields).
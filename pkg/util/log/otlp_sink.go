@@ -0,0 +1,409 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/build"
+	"github.com/cockroachdb/cockroach/pkg/cli/exit"
+	"github.com/cockroachdb/cockroach/pkg/util/httputil"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logconfig"
+	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
+	"github.com/cockroachdb/errors"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcgzip "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// otlpAttributePrefix is the prefix used for all the log record
+// attributes populated by the OpenTelemetry sink.
+const otlpAttributePrefix = "cockroach."
+
+// otlpSink exports log entries as OpenTelemetry log records, either
+// over gRPC or as protobuf-encoded HTTP requests.
+type otlpSink struct {
+	config *logconfig.OTLPSinkConfig
+	// compact is true when the entries are formatted using the compact
+	// JSON field names.
+	compact bool
+	// resource describes the process emitting the log records.
+	resource *resourcepb.Resource
+	// export sends one batch of log records to the collector.
+	export func(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error
+
+	// grpcClient is set when the sink uses the gRPC protocol.
+	grpcClient collogspb.LogsServiceClient
+	// httpClient is used when the sink uses the HTTP protocol.
+	httpClient http.Client
+}
+
+// newOTLPSink creates a new OpenTelemetry sink. The compact argument
+// indicates which JSON field names the sink's formatter produces.
+func newOTLPSink(c logconfig.OTLPSinkConfig, compact bool) (*otlpSink, error) {
+	s := &otlpSink{
+		config:  &c,
+		compact: compact,
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{
+				otlpStringAttr("service.name", fileNameConstants.program),
+				otlpStringAttr("service.version", build.BinaryVersion()),
+				otlpStringAttr("host.name", fullHostName),
+				otlpIntAttr("process.pid", int64(fileNameConstants.pid)),
+			},
+		},
+	}
+
+	var tlsConfig *tls.Config
+	if *c.UnsafeTLS {
+		tlsConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	switch *c.Protocol {
+	case logconfig.OTLPProtocolGRPC:
+		creds := credentials.NewTLS(tlsConfig)
+		if *c.Insecure {
+			creds = insecure.NewCredentials()
+		}
+		// The connection is established lazily, so that the sink can be
+		// created even if the collector is not (yet) reachable.
+		conn, err := grpc.Dial(*c.Address, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, errors.Wrapf(err, "connecting to %s", *c.Address)
+		}
+		s.grpcClient = collogspb.NewLogsServiceClient(conn)
+		s.export = s.exportGRPC
+
+	case logconfig.OTLPProtocolHTTP:
+		transport, ok := http.DefaultTransport.(*http.Transport)
+		if !ok {
+			return nil, errors.AssertionFailedf("http.DefaultTransport is not a http.Transport: %T", http.DefaultTransport)
+		}
+		transport = transport.Clone()
+		transport.TLSClientConfig = tlsConfig
+		s.httpClient = http.Client{Transport: transport}
+		s.export = s.exportHTTP
+
+	default:
+		return nil, errors.AssertionFailedf("unknown OTLP protocol: %q", *c.Protocol)
+	}
+	return s, nil
+}
+
+// output emits some formatted bytes to this sink.
+//
+// The bytes contain one or more JSON-formatted entries, separated by
+// newlines. They are all sent to the collector in a single request.
+//
+// The parent logger's outputMu is held during this operation: log
+// sinks must not recursively call into logging when implementing
+// this method.
+func (s *otlpSink) output(b []byte, opts sinkOutputOptions) error {
+	records := s.decodeRecords(b)
+	if len(records) == 0 {
+		return nil
+	}
+	req := &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: s.resource,
+			InstrumentationLibraryLogs: []*logspb.InstrumentationLibraryLogs{{
+				InstrumentationLibrary: &commonpb.InstrumentationLibrary{
+					Name:    "github.com/cockroachdb/cockroach/pkg/util/log",
+					Version: build.BinaryVersion(),
+				},
+				Logs: records,
+			}},
+		}},
+	}
+
+	ctx := context.Background()
+	if timeout := *s.config.Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return s.export(ctx, req)
+}
+
+func (s *otlpSink) exportGRPC(
+	ctx context.Context, req *collogspb.ExportLogsServiceRequest,
+) error {
+	for k, v := range s.config.Headers {
+		ctx = metadata.AppendToOutgoingContext(ctx, k, v)
+	}
+	var callOpts []grpc.CallOption
+	if *s.config.Compression == logconfig.GzipCompression {
+		callOpts = append(callOpts, grpc.UseCompressor(grpcgzip.Name))
+	}
+	_, err := s.grpcClient.Export(ctx, req, callOpts...)
+	return err
+}
+
+func (s *otlpSink) exportHTTP(
+	ctx context.Context, req *collogspb.ExportLogsServiceRequest,
+) error {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if *s.config.Compression == logconfig.GzipCompression {
+		g := gzip.NewWriter(&buf)
+		if _, err := g.Write(data); err != nil {
+			return err
+		}
+		if err := g.Close(); err != nil {
+			return err
+		}
+	} else {
+		buf.Write(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, *s.config.Address, &buf)
+	if err != nil {
+		return err
+	}
+	for k, v := range s.config.Headers {
+		httpReq.Header.Add(k, v)
+	}
+	if *s.config.Compression == logconfig.GzipCompression {
+		httpReq.Header.Add(httputil.ContentEncodingHeader, httputil.GzipEncoding)
+	}
+	httpReq.Header.Add(httputil.ContentTypeHeader, httputil.ProtoContentType)
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close() // don't care about content
+	if resp.StatusCode >= 400 {
+		return HTTPLogError{
+			StatusCode: resp.StatusCode,
+			Address:    *s.config.Address,
+		}
+	}
+	return nil
+}
+
+// decodeRecords converts the JSON-formatted entries in b into
+// OpenTelemetry log records. Lines that cannot be decoded are exported
+// verbatim as the body of a log record, so that no logging data is
+// lost.
+func (s *otlpSink) decodeRecords(b []byte) []*logspb.LogRecord {
+	var records []*logspb.LogRecord
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(nil, len(b)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		e, err := decodeJSONEntry(line, s.compact)
+		if err != nil {
+			records = append(records, &logspb.LogRecord{
+				Body: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: string(line)}},
+			})
+			continue
+		}
+		records = append(records, makeOTLPLogRecord(e))
+	}
+	return records
+}
+
+// makeOTLPLogRecord maps a decoded JSON entry onto an OpenTelemetry
+// log record.
+func makeOTLPLogRecord(e *JSONEntry) *logspb.LogRecord {
+	r := &logspb.LogRecord{}
+	if ts, err := fromFluent(e.Timestamp); err == nil {
+		r.TimeUnixNano = uint64(ts)
+	}
+	if e.Header == 0 {
+		sev := Severity(e.SeverityNumeric)
+		r.SeverityNumber = otlpSeverityNumber(sev)
+		r.SeverityText = sev.String()
+		r.Attributes = append(r.Attributes,
+			otlpStringAttr(otlpAttributePrefix+"channel", Channel(e.ChannelNumeric).String()),
+			otlpIntAttr(otlpAttributePrefix+"entry_counter", int64(e.EntryCounter)))
+	}
+	if e.File != "" {
+		r.Attributes = append(r.Attributes,
+			otlpStringAttr("code.filepath", e.File),
+			otlpIntAttr("code.lineno", e.Line))
+	}
+	r.Attributes = append(r.Attributes,
+		otlpIntAttr(otlpAttributePrefix+"goroutine", e.Goroutine),
+		otlpBoolAttr(otlpAttributePrefix+"redactable", e.Redactable == 1))
+
+	// Server identifiers.
+	if e.ClusterID != "" {
+		r.Attributes = append(r.Attributes, otlpStringAttr(otlpAttributePrefix+"cluster_id", e.ClusterID))
+	}
+	if e.NodeID != 0 {
+		r.Attributes = append(r.Attributes, otlpIntAttr(otlpAttributePrefix+"node_id", e.NodeID))
+	}
+	if e.InstanceID != 0 {
+		r.Attributes = append(r.Attributes, otlpIntAttr(otlpAttributePrefix+"instance_id", e.InstanceID))
+	}
+	if e.TenantID != 0 {
+		r.Attributes = append(r.Attributes, otlpIntAttr(otlpAttributePrefix+"tenant_id", e.TenantID))
+	}
+	if e.TenantName != "" {
+		r.Attributes = append(r.Attributes, otlpStringAttr(otlpAttributePrefix+"tenant_name", e.TenantName))
+	}
+	if e.Version != "" {
+		r.Attributes = append(r.Attributes, otlpStringAttr(otlpAttributePrefix+"version", e.Version))
+	}
+
+	for _, k := range otlpSortedKeys(e.Tags) {
+		r.Attributes = append(r.Attributes, &commonpb.KeyValue{
+			Key:   otlpAttributePrefix + "tag." + k,
+			Value: otlpAnyValue(e.Tags[k]),
+		})
+	}
+
+	if e.Event != nil {
+		// Structured events are reported with their type as the record
+		// name and their payload fields as attributes. The body contains
+		// the full payload, for collectors that ignore attributes.
+		if typ, ok := e.Event["EventType"].(string); ok {
+			r.Name = typ
+		}
+		for _, k := range otlpSortedKeys(e.Event) {
+			r.Attributes = append(r.Attributes, &commonpb.KeyValue{
+				Key:   otlpAttributePrefix + "event." + k,
+				Value: otlpAnyValue(e.Event[k]),
+			})
+		}
+		r.Body = otlpAnyValue(e.Event)
+	} else {
+		r.Body = &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: e.Message}}
+	}
+
+	if e.Stacks != "" {
+		r.Attributes = append(r.Attributes, otlpStringAttr("exception.stacktrace", e.Stacks))
+	}
+	return r
+}
+
+// otlpSeverityNumber maps a CockroachDB severity to the corresponding
+// OpenTelemetry severity number.
+func otlpSeverityNumber(sev Severity) logspb.SeverityNumber {
+	switch sev {
+	case severity.INFO:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO
+	case severity.WARNING:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_WARN
+	case severity.ERROR:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_ERROR
+	case severity.FATAL:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_FATAL
+	default:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED
+	}
+}
+
+// otlpAnyValue converts a value decoded from JSON into an
+// OpenTelemetry value.
+func otlpAnyValue(v interface{}) *commonpb.AnyValue {
+	switch t := v.(type) {
+	case nil:
+		return &commonpb.AnyValue{}
+	case string:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: t}}
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: t}}
+	case json.Number:
+		if i, err := strconv.ParseInt(string(t), 10, 64); err == nil {
+			return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: i}}
+		}
+		if f, err := strconv.ParseFloat(string(t), 64); err == nil {
+			return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: f}}
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: string(t)}}
+	case float64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: t}}
+	case []interface{}:
+		values := make([]*commonpb.AnyValue, len(t))
+		for i := range t {
+			values[i] = otlpAnyValue(t[i])
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{
+			ArrayValue: &commonpb.ArrayValue{Values: values},
+		}}
+	case map[string]interface{}:
+		kvs := make([]*commonpb.KeyValue, 0, len(t))
+		for _, k := range otlpSortedKeys(t) {
+			kvs = append(kvs, &commonpb.KeyValue{Key: k, Value: otlpAnyValue(t[k])})
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{
+			KvlistValue: &commonpb.KeyValueList{Values: kvs},
+		}}
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: fmt.Sprint(t)}}
+	}
+}
+
+func otlpSortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func otlpStringAttr(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func otlpIntAttr(key string, value int64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: value}},
+	}
+}
+
+func otlpBoolAttr(key string, value bool) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: value}},
+	}
+}
+
+// active returns true if this sink is currently active.
+func (*otlpSink) active() bool {
+	return true
+}
+
+// attachHints attaches some hints about the location of the message
+// to the stack message.
+func (*otlpSink) attachHints(stacks []byte) []byte {
+	return stacks
+}
+
+// exitCode returns the exit code to use if the logger decides
+// to terminate because of an error in output().
+func (*otlpSink) exitCode() exit.Code {
+	return exit.LoggingNetCollectorUnavailable()
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package log
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/httputil"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log/channel"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logconfig"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logpb"
+	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
+	"github.com/cockroachdb/logtags"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestOTLPSinkHTTP checks that log entries are exported as OTLP log
+// records to a collector using the HTTP protocol.
+func TestOTLPSinkHTTP(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	sc := ScopeWithoutShowLogs(t)
+	defer sc.Close(t)
+
+	records := make(chan *logspb.LogRecord, 100)
+	handler := func(rw http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get(httputil.ContentTypeHeader); ct != httputil.ProtoContentType {
+			t.Errorf("unexpected content type: %q", ct)
+		}
+		body := r.Body
+		if r.Header.Get(httputil.ContentEncodingHeader) == httputil.GzipEncoding {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			body = gz
+		}
+		data, err := io.ReadAll(body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		var req collogspb.ExportLogsServiceRequest
+		if err := proto.Unmarshal(data, &req); err != nil {
+			t.Error(err)
+			return
+		}
+		for _, rl := range req.ResourceLogs {
+			for _, il := range rl.InstrumentationLibraryLogs {
+				for _, rec := range il.Logs {
+					records <- rec
+				}
+			}
+		}
+	}
+	s := httptest.NewServer(http.HandlerFunc(handler))
+	defer s.Close()
+
+	address := s.URL + "/v1/logs"
+	protocol := logconfig.OTLPProtocolHTTP
+	cfg := logconfig.DefaultConfig()
+	cfg.Sinks.OTLPServers = map[string]*logconfig.OTLPSinkConfig{
+		"ops": {
+			OTLPDefaults: logconfig.OTLPDefaults{
+				Address:  &address,
+				Protocol: &protocol,
+				CommonSinkConfig: logconfig.CommonSinkConfig{
+					Buffering: disabledBufferingCfg,
+				},
+			},
+			Channels: logconfig.SelectChannels(channel.OPS),
+		},
+	}
+	require.NoError(t, cfg.Validate(&sc.logDir))
+
+	TestingResetActive()
+	cleanup, err := ApplyConfig(cfg, nil /* fileSinkMetricsForDir */, nil /* fatalOnLogStall */)
+	require.NoError(t, err)
+	defer cleanup()
+
+	ctx := logtags.AddTag(context.Background(), "foo", "bar")
+	Ops.Warningf(ctx, "hello world")
+
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case rec := <-records:
+			if rec.Body.GetStringValue() != "hello world" {
+				continue
+			}
+			require.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_WARN, rec.SeverityNumber)
+			require.Equal(t, "WARNING", rec.SeverityText)
+			require.NotZero(t, rec.TimeUnixNano)
+			attrs := otlpAttrsToMap(rec.Attributes)
+			require.Equal(t, "OPS", attrs["cockroach.channel"].GetStringValue())
+			require.Equal(t, "‹bar›", attrs["cockroach.tag.foo"].GetStringValue())
+			require.Equal(t, "otlp_sink_test.go", attrs["code.filepath"].GetStringValue())
+			return
+		case <-timeout:
+			t.Fatal("timed out waiting for the log record")
+		}
+	}
+}
+
+// TestOTLPSinkDecodeEvent checks that structured events are mapped
+// onto log record attributes.
+func TestOTLPSinkDecodeEvent(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, compact := range []bool{false, true} {
+		f := formatJSONFull{tags: tagVerbose}
+		if compact {
+			f.tags = tagCompact
+		}
+		entry := makeStructuredEntry(context.Background(), severity.INFO, channel.SESSIONS, 0,
+			&logpb.TestingStructuredLogEvent{
+				CommonEventDetails: logpb.CommonEventDetails{
+					Timestamp: 123,
+					EventType: "client_connection_start",
+				},
+				Channel: logpb.Channel_SESSIONS,
+				Event:   "hello",
+			})
+		buf := f.formatEntry(entry)
+		s := &otlpSink{compact: compact}
+		recs := s.decodeRecords(buf.Bytes())
+		putBuffer(buf)
+
+		require.Len(t, recs, 1)
+		rec := recs[0]
+		require.Equal(t, "client_connection_start", rec.Name)
+		require.Equal(t, logspb.SeverityNumber_SEVERITY_NUMBER_INFO, rec.SeverityNumber)
+		attrs := otlpAttrsToMap(rec.Attributes)
+		require.Equal(t, "SESSIONS", attrs["cockroach.channel"].GetStringValue())
+		require.Equal(t, int64(123), attrs["cockroach.event.Timestamp"].GetIntValue())
+		require.Equal(t, "‹hello›", attrs["cockroach.event.Event"].GetStringValue())
+		require.NotNil(t, rec.Body.GetKvlistValue())
+	}
+
+	// Lines that are not valid JSON are exported verbatim.
+	s := &otlpSink{}
+	recs := s.decodeRecords([]byte("not json\n"))
+	require.Len(t, recs, 1)
+	require.Equal(t, "not json", recs[0].Body.GetStringValue())
+}
+
+func otlpAttrsToMap(kvs []*commonpb.KeyValue) map[string]*commonpb.AnyValue {
+	m := make(map[string]*commonpb.AnyValue, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
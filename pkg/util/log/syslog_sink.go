@@ -0,0 +1,428 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/exit"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logconfig"
+	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// syslogNilValue is the NILVALUE of RFC 5424, used for header
+	// fields that have no value.
+	syslogNilValue = "-"
+	// syslogTimestampFormat is the RFC 3339 profile required by RFC
+	// 5424, with the maximum allowed precision of microseconds.
+	syslogTimestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+	// syslogBOM precedes the UTF-8 encoded MSG part of the messages.
+	syslogBOM = "\xef\xbb\xbf"
+
+	// Maximum lengths of the header fields, as per RFC 5424.
+	syslogMaxHostname = 255
+	syslogMaxAppName  = 48
+	syslogMaxProcID   = 128
+	syslogMaxMsgID    = 32
+	syslogMaxParamLen = 32
+
+	// syslogMinReconnectBackoff and syslogMaxReconnectBackoff bound the
+	// delay between connection attempts after a failure to connect.
+	syslogMinReconnectBackoff = 100 * time.Millisecond
+	syslogMaxReconnectBackoff = 10 * time.Second
+)
+
+// syslogSink sends log entries to a syslog collector, as RFC 5424
+// messages. Over TCP, the messages are framed using octet counting
+// (RFC 6587).
+type syslogSink struct {
+	config *logconfig.SyslogSinkConfig
+	// compact is true when the entries are formatted using the compact
+	// JSON field names.
+	compact bool
+	// stream is true when the transport is a byte stream (TCP), in which
+	// case the messages are framed using octet counting.
+	stream bool
+	// tlsConfig is set when TLS is enabled.
+	tlsConfig *tls.Config
+
+	// The constant parts of the message headers.
+	hostname string
+	appName  string
+	procID   string
+
+	mu struct {
+		syncutil.Mutex
+		conn net.Conn
+		// nextDial is the earliest time at which the sink tries to
+		// connect again after a failed attempt.
+		nextDial time.Time
+		// backoff is the delay before the next connection attempt,
+		// should the current one fail.
+		backoff time.Duration
+	}
+}
+
+// newSyslogSink creates a new syslog sink. The compact argument
+// indicates which JSON field names the sink's formatter produces.
+func newSyslogSink(c logconfig.SyslogSinkConfig, compact bool) (*syslogSink, error) {
+	s := &syslogSink{
+		config:   &c,
+		compact:  compact,
+		stream:   strings.HasPrefix(*c.Net, "tcp"),
+		hostname: syslogHeaderField(fullHostName, syslogMaxHostname),
+		appName:  syslogHeaderField(*c.AppName, syslogMaxAppName),
+		procID:   syslogHeaderField(strconv.Itoa(fileNameConstants.pid), syslogMaxProcID),
+	}
+	s.mu.backoff = syslogMinReconnectBackoff
+
+	if *c.TLS {
+		host, _, err := net.SplitHostPort(*c.Address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid address %q", *c.Address)
+		}
+		s.tlsConfig = &tls.Config{
+			ServerName:         host,
+			InsecureSkipVerify: *c.UnsafeTLS,
+			MinVersion:         tls.VersionTLS12,
+		}
+		if c.CACert != nil {
+			pem, err := os.ReadFile(*c.CACert)
+			if err != nil {
+				return nil, errors.Wrap(err, "reading CA certificate")
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, errors.Newf("no certificate found in %s", *c.CACert)
+			}
+			s.tlsConfig.RootCAs = pool
+		}
+		if c.ClientCert != nil {
+			cert, err := tls.LoadX509KeyPair(*c.ClientCert, *c.ClientKey)
+			if err != nil {
+				return nil, errors.Wrap(err, "loading client certificate")
+			}
+			s.tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+	}
+	return s, nil
+}
+
+func (s *syslogSink) String() string {
+	scheme := *s.config.Net
+	if s.tlsConfig != nil {
+		scheme += "+tls"
+	}
+	return fmt.Sprintf("syslog:%s://%s", scheme, *s.config.Address)
+}
+
+// output emits some formatted bytes to this sink.
+//
+// The bytes contain one or more JSON-formatted entries, separated by
+// newlines. Each entry is sent as one syslog message.
+//
+// The parent logger's outputMu is held during this operation: log
+// sinks must not recursively call into logging when implementing
+// this method.
+func (s *syslogSink) output(b []byte, opts sinkOutputOptions) error {
+	msgs := s.makeMessages(b)
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hadConn := s.mu.conn != nil
+	err := s.writeLocked(msgs)
+	if err != nil && hadConn {
+		// The collector may have closed an idle connection, or restarted.
+		// Try again once on a new connection.
+		err = s.writeLocked(msgs)
+	}
+	return err
+}
+
+// writeLocked sends the messages, connecting to the collector first
+// if needed. The connection is closed if the write fails.
+func (s *syslogSink) writeLocked(msgs [][]byte) error {
+	if err := s.ensureConnLocked(); err != nil {
+		return err
+	}
+	if timeout := *s.config.Timeout; timeout > 0 {
+		if err := s.mu.conn.SetWriteDeadline(timeutil.Now().Add(timeout)); err != nil {
+			s.closeLocked()
+			return err
+		}
+	}
+	if s.stream {
+		// Over a byte stream, the framed messages can be written at once.
+		var buf bytes.Buffer
+		for _, msg := range msgs {
+			buf.WriteString(strconv.Itoa(len(msg)))
+			buf.WriteByte(' ')
+			buf.Write(msg)
+		}
+		msgs = [][]byte{buf.Bytes()}
+	}
+	for _, msg := range msgs {
+		if _, err := s.mu.conn.Write(msg); err != nil {
+			fmt.Fprintf(OrigStderr, "%s: logging error: %v\n", s, err)
+			s.closeLocked()
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureConnLocked connects to the collector if there is no open
+// connection. After a failed attempt, further attempts are delayed with
+// exponential backoff, so that logging is not slowed down by repeated
+// connection timeouts while the collector is unavailable.
+func (s *syslogSink) ensureConnLocked() error {
+	if s.mu.conn != nil {
+		return nil
+	}
+	now := timeutil.Now()
+	if now.Before(s.mu.nextDial) {
+		return errors.Newf("%s: collector unavailable, next connection attempt in %s",
+			s, s.mu.nextDial.Sub(now))
+	}
+
+	dialer := &net.Dialer{Timeout: *s.config.Timeout}
+	var conn net.Conn
+	var err error
+	if s.tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, *s.config.Net, *s.config.Address, s.tlsConfig)
+	} else {
+		conn, err = dialer.Dial(*s.config.Net, *s.config.Address)
+	}
+	if err != nil {
+		fmt.Fprintf(OrigStderr, "%s: error dialing syslog collector: %v\n", s, err)
+		s.mu.nextDial = now.Add(s.mu.backoff)
+		s.mu.backoff *= 2
+		if s.mu.backoff > syslogMaxReconnectBackoff {
+			s.mu.backoff = syslogMaxReconnectBackoff
+		}
+		return err
+	}
+	if !s.mu.nextDial.IsZero() {
+		fmt.Fprintf(OrigStderr, "%s: connection to syslog collector resumed\n", s)
+	}
+	s.mu.conn = conn
+	s.mu.nextDial = time.Time{}
+	s.mu.backoff = syslogMinReconnectBackoff
+	return nil
+}
+
+func (s *syslogSink) closeLocked() {
+	if s.mu.conn != nil {
+		if err := s.mu.conn.Close(); err != nil {
+			fmt.Fprintf(OrigStderr, "%s: error closing connection: %v\n", s, err)
+		}
+		s.mu.conn = nil
+	}
+}
+
+// makeMessages converts the JSON-formatted entries in b into RFC 5424
+// messages. Lines that cannot be decoded are sent with a minimal
+// header, so that no logging data is lost.
+func (s *syslogSink) makeMessages(b []byte) [][]byte {
+	var msgs [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(nil, len(b)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		// e is nil if the line cannot be decoded.
+		e, _ := decodeJSONEntry(line, s.compact)
+		msgs = append(msgs, s.makeMessage(e, line))
+	}
+	return msgs
+}
+
+// makeMessage formats one RFC 5424 message:
+//
+//	<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD-ID PARAM="VALUE" ...] MSG
+//
+// The MSG part is the formatted entry. e may be nil if the entry could
+// not be decoded.
+func (s *syslogSink) makeMessage(e *JSONEntry, line []byte) []byte {
+	var buf bytes.Buffer
+	sev := severity.INFO
+	msgID := syslogNilValue
+	timestamp := syslogNilValue
+	facility := *s.config.Facility
+	if e != nil {
+		if e.Header == 0 {
+			sev = Severity(e.SeverityNumeric)
+			msgID = Channel(e.ChannelNumeric).String()
+			if f, ok := s.config.ChannelFacilities[msgID]; ok {
+				facility = f
+			}
+			msgID = syslogHeaderField(msgID, syslogMaxMsgID)
+		}
+		if ts, err := fromFluent(e.Timestamp); err == nil {
+			timestamp = timeutil.Unix(0, ts).UTC().Format(syslogTimestampFormat)
+		}
+	}
+
+	fmt.Fprintf(&buf, "<%d>1 %s %s %s %s %s ",
+		facility.Code()*8+syslogSeverity(sev), timestamp, s.hostname, s.appName, s.procID, msgID)
+	if e != nil {
+		s.writeStructuredData(&buf, e)
+	} else {
+		buf.WriteString(syslogNilValue)
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(syslogBOM)
+	buf.Write(line)
+	return buf.Bytes()
+}
+
+// writeStructuredData writes the structured data element carrying the
+// server identifiers and the logging tags of the entry.
+func (s *syslogSink) writeStructuredData(buf *bytes.Buffer, e *JSONEntry) {
+	buf.WriteByte('[')
+	buf.WriteString(*s.config.StructuredDataID)
+	param := func(name, value string) {
+		buf.WriteByte(' ')
+		buf.WriteString(syslogParamName(name))
+		buf.WriteString(`="`)
+		syslogEscapeParamValue(buf, value)
+		buf.WriteByte('"')
+	}
+	if e.ClusterID != "" {
+		param("cluster_id", e.ClusterID)
+	}
+	if e.NodeID != 0 {
+		param("node_id", strconv.FormatInt(e.NodeID, 10))
+	}
+	if e.InstanceID != 0 {
+		param("instance_id", strconv.FormatInt(e.InstanceID, 10))
+	}
+	if e.TenantID != 0 {
+		param("tenant_id", strconv.FormatInt(e.TenantID, 10))
+	}
+	if e.TenantName != "" {
+		param("tenant_name", e.TenantName)
+	}
+	if typ, ok := e.Event["EventType"].(string); ok {
+		param("event_type", typ)
+	}
+	tags := make([]string, 0, len(e.Tags))
+	for k := range e.Tags {
+		tags = append(tags, k)
+	}
+	sort.Strings(tags)
+	for _, k := range tags {
+		var v string
+		if e.Tags[k] != nil {
+			v = fmt.Sprint(e.Tags[k])
+		}
+		param("tag."+k, v)
+	}
+	buf.WriteByte(']')
+}
+
+// syslogSeverity maps a CockroachDB severity to the corresponding
+// syslog severity code.
+func syslogSeverity(sev Severity) int {
+	switch sev {
+	case severity.INFO:
+		return 6 // informational
+	case severity.WARNING:
+		return 4 // warning
+	case severity.ERROR:
+		return 3 // error
+	case severity.FATAL:
+		return 2 // critical
+	default:
+		return 5 // notice
+	}
+}
+
+// syslogHeaderField returns the value truncated to maxLen and stripped
+// of the characters that are not allowed in header fields (anything but
+// printable US-ASCII). The NILVALUE is returned for empty values.
+func syslogHeaderField(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, value)
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+	if value == "" {
+		return syslogNilValue
+	}
+	return value
+}
+
+// syslogParamName returns a valid PARAM-NAME for the given name: the
+// characters that are not allowed are replaced with underscores and
+// the result is truncated to 32 characters.
+func syslogParamName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, name)
+	if len(name) > syslogMaxParamLen {
+		name = name[:syslogMaxParamLen]
+	}
+	return name
+}
+
+// syslogEscapeParamValue writes a PARAM-VALUE, escaping the characters
+// that must be escaped as per RFC 5424.
+func syslogEscapeParamValue(buf *bytes.Buffer, value string) {
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '"', '\\', ']':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+}
+
+// active returns true if this sink is currently active.
+func (*syslogSink) active() bool {
+	return true
+}
+
+// attachHints attaches some hints about the location of the message
+// to the stack message.
+func (*syslogSink) attachHints(stacks []byte) []byte {
+	return stacks
+}
+
+// exitCode returns the exit code to use if the logger decides
+// to terminate because of an error in output().
+func (*syslogSink) exitCode() exit.Code {
+	return exit.LoggingNetCollectorUnavailable()
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package log
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log/channel"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logconfig"
+	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/logtags"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSyslogSinkTLS checks that log entries are sent as octet-counted
+// RFC 5424 messages to a collector over TLS, and that the sink
+// reconnects when the collector closes the connection.
+func TestSyslogSinkTLS(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	sc := ScopeWithoutShowLogs(t)
+	defer sc.Close(t)
+
+	serverCert, caFile := makeSyslogTestCert(t, sc.logDir)
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{serverCert}})
+	require.NoError(t, err)
+	defer l.Close()
+
+	address := l.Addr().String()
+	tlsEnabled := true
+	facility := logconfig.SyslogFacility("auth")
+	cfg := logconfig.DefaultConfig()
+	cfg.Sinks.SyslogServers = map[string]*logconfig.SyslogSinkConfig{
+		"audit": {
+			SyslogDefaults: logconfig.SyslogDefaults{
+				Address:           &address,
+				TLS:               &tlsEnabled,
+				CACert:            &caFile,
+				Facility:          &facility,
+				ChannelFacilities: map[string]logconfig.SyslogFacility{"sensitive_access": "authpriv"},
+				CommonSinkConfig: logconfig.CommonSinkConfig{
+					Buffering: disabledBufferingCfg,
+				},
+			},
+			Channels: logconfig.SelectChannels(channel.OPS, channel.SENSITIVE_ACCESS),
+		},
+	}
+	require.NoError(t, cfg.Validate(&sc.logDir))
+
+	TestingResetActive()
+	cleanup, err := ApplyConfig(cfg, nil /* fileSinkMetricsForDir */, nil /* fatalOnLogStall */)
+	require.NoError(t, err)
+	defer cleanup()
+
+	// accept returns a reader over the next connection to the collector.
+	accept := func() (net.Conn, *bufio.Reader) {
+		conn, err := l.Accept()
+		require.NoError(t, err)
+		require.NoError(t, conn.SetReadDeadline(timeutil.Now().Add(10*time.Second)))
+		return conn, bufio.NewReader(conn)
+	}
+
+	// The TLS handshake completes once the collector reads from the
+	// connection, so the events are logged asynchronously.
+	ctx := logtags.AddTag(context.Background(), "foo", "bar")
+	logDone := make(chan struct{})
+	go func() {
+		defer close(logDone)
+		Ops.Warningf(ctx, "hello world")
+	}()
+	conn, r := accept()
+	msg := readSyslogFrame(t, r)
+	<-logDone
+	// auth (4) * 8 + warning (4)
+	require.True(t, strings.HasPrefix(msg, "<36>1 "), msg)
+	fields := strings.SplitN(msg, " ", 7)
+	require.Equal(t, "OPS", fields[5])
+	require.True(t, strings.HasPrefix(fields[6], `[cockroach@32473 `), msg)
+	require.Contains(t, fields[6], ` tag.foo="‹bar›"]`)
+	require.Contains(t, fields[6], `"message":"hello world"`)
+
+	// Close the connection from the collector side. The sink reconnects
+	// on a subsequent write. The first writes after the close may be
+	// accepted by the kernel before the sink notices that the
+	// connection is gone, so events are logged until the collector sees
+	// a new connection.
+	require.NoError(t, conn.Close())
+	stop := make(chan struct{})
+	logDone = make(chan struct{})
+	go func() {
+		defer close(logDone)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			SensitiveAccess.Infof(context.Background(), "audit event")
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	conn, r = accept()
+	defer conn.Close()
+	msg = readSyslogFrame(t, r)
+	close(stop)
+	<-logDone
+	// authpriv (10) * 8 + informational (6)
+	require.True(t, strings.HasPrefix(msg, "<86>1 "), msg)
+	require.Contains(t, msg, " SENSITIVE_ACCESS ")
+}
+
+// readSyslogFrame reads one octet-counted frame (RFC 6587).
+func readSyslogFrame(t *testing.T, r *bufio.Reader) string {
+	lenStr, err := r.ReadString(' ')
+	require.NoError(t, err)
+	n, err := strconv.Atoi(strings.TrimSuffix(lenStr, " "))
+	require.NoError(t, err)
+	buf := make([]byte, n)
+	_, err = io.ReadFull(r, buf)
+	require.NoError(t, err)
+	return string(buf)
+}
+
+// makeSyslogTestCert creates a self-signed certificate for 127.0.0.1
+// and writes it to a CA file in dir.
+func makeSyslogTestCert(t *testing.T, dir string) (tls.Certificate, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "syslog-test"},
+		NotBefore:             timeutil.Now().Add(-time.Hour),
+		NotAfter:              timeutil.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	caFile := filepath.Join(dir, "syslog-ca.crt")
+	require.NoError(t, os.WriteFile(caFile, certPEM, 0600))
+	return cert, caFile
+}
+
+// TestSyslogSinkMessage checks the formatting of the RFC 5424
+// messages.
+func TestSyslogSinkMessage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	address := "127.0.0.1:514"
+	network := "udp"
+	appName := "crdb app"
+	facility := logconfig.SyslogFacility("local3")
+	sdID := logconfig.DefaultSyslogStructuredDataID
+	tlsEnabled := false
+	s, err := newSyslogSink(logconfig.SyslogSinkConfig{
+		SyslogDefaults: logconfig.SyslogDefaults{
+			Address:          &address,
+			Net:              &network,
+			TLS:              &tlsEnabled,
+			AppName:          &appName,
+			Facility:         &facility,
+			StructuredDataID: &sdID,
+		},
+	}, false /* compact */)
+	require.NoError(t, err)
+	require.False(t, s.stream)
+	s.hostname = "myhost"
+	s.procID = "123"
+
+	e := &JSONEntry{
+		ChannelNumeric:  int64(channel.SESSIONS),
+		SeverityNumeric: int64(severity.ERROR),
+		Timestamp:       "1136214245.654321000",
+		NodeID:          1,
+		ClusterID:       "abc",
+	}
+	e.Tags = map[string]interface{}{
+		"peer":   `a"b]c\d`,
+		"n":      "1",
+		"weird=": nil,
+	}
+	e.Event = map[string]interface{}{"EventType": "client_session_end"}
+	msgs := s.makeMessages([]byte(`{"message":"x"}` + "\nnot json\n"))
+	require.Len(t, msgs, 2)
+
+	msg := string(s.makeMessage(e, []byte(`{"message":"x"}`)))
+	// local3 (19) * 8 + error (3)
+	require.Equal(t, `<155>1 2006-01-02T15:04:05.654321Z myhost crdbapp 123 SESSIONS `+
+		`[cockroach@32473 cluster_id="abc" node_id="1" event_type="client_session_end" `+
+		`tag.n="1" tag.peer="a\"b\]c\\d" tag.weird_=""] `+syslogBOM+`{"message":"x"}`, msg)
+
+	// Lines that are not JSON are sent with a minimal header.
+	msg = string(s.makeMessage(nil, []byte("not json")))
+	require.Equal(t, `<158>1 - myhost crdbapp 123 - - `+syslogBOM+`not json`, msg)
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package metric
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	prometheusgo "github.com/prometheus/client_model/go"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var errNoOTLPEndpoint = errors.New("external.otlp_metrics.endpoint is not set")
+
+// OTLPExporter pushes the metrics scraped by a PrometheusExporter to an
+// OpenTelemetry collector, using the OTLP/HTTP protocol with protobuf
+// encoding.
+//
+// Counters are exported as cumulative monotonic sums, gauges as gauges
+// and histograms as cumulative explicit-bucket histograms.
+type OTLPExporter struct {
+	client  http.Client
+	metrics *PushExporterMetrics
+	// startTime is reported as the start of the cumulative data points.
+	startTime uint64
+}
+
+// MakeOTLPExporter returns an initialized OTLP exporter. The provided
+// metrics are updated on every push.
+func MakeOTLPExporter(metrics *PushExporterMetrics) OTLPExporter {
+	return OTLPExporter{
+		metrics:   metrics,
+		startTime: uint64(timeutil.Now().UnixNano()),
+	}
+}
+
+// Push sends the metrics gathered by pm to the configured endpoint. The
+// data points are split into requests of at most cfg.BatchSize points.
+func (oe *OTLPExporter) Push(ctx context.Context, pm *PrometheusExporter, cfg PushConfig) error {
+	if cfg.Endpoint == "" {
+		return errNoOTLPEndpoint
+	}
+	// Regardless of whether the push errors, clear metrics. Only the latest
+	// values are pushed, like for the Graphite exporter.
+	defer pm.clearMetrics()
+	oe.metrics.Pushes.Inc(1)
+
+	now := uint64(timeutil.Now().UnixNano())
+	resource := &resourcepb.Resource{
+		Attributes: []*commonpb.KeyValue{otlpStringKV("service.name", "cockroachdb")},
+	}
+	for _, k := range sortedLabelNames(cfg.Labels) {
+		resource.Attributes = append(resource.Attributes, otlpStringKV(k, cfg.Labels[k]))
+	}
+
+	var batch []*metricspb.Metric
+	var batchPoints int
+	flush := func() error {
+		if batchPoints == 0 {
+			return nil
+		}
+		req := &colmetricspb.ExportMetricsServiceRequest{
+			ResourceMetrics: []*metricspb.ResourceMetrics{{
+				Resource: resource,
+				InstrumentationLibraryMetrics: []*metricspb.InstrumentationLibraryMetrics{{
+					InstrumentationLibrary: &commonpb.InstrumentationLibrary{
+						Name: "github.com/cockroachdb/cockroach/pkg/util/metric",
+					},
+					Metrics: batch,
+				}},
+			}},
+		}
+		body, err := proto.Marshal(req)
+		if err != nil {
+			return err
+		}
+		numPoints := batchPoints
+		batch, batchPoints = nil, 0
+		return sendWithRetry(ctx, cfg, oe.metrics, numPoints, func(ctx context.Context) error {
+			return postProto(ctx, &oe.client, cfg.Endpoint, body, nil /* headers */)
+		})
+	}
+
+	for _, family := range gatherSorted(pm) {
+		// cur is the OTLP metric for this family in the current batch. A new
+		// one is created when the family's points span multiple batches.
+		var cur *metricspb.Metric
+		for _, m := range family.Metric {
+			if cur == nil {
+				cur = oe.newMetric(family)
+				if cur == nil {
+					// Unsupported metric type.
+					break
+				}
+				batch = append(batch, cur)
+			}
+			oe.appendPoint(cur, family.GetType(), m, now)
+			batchPoints++
+			if cfg.BatchSize > 0 && batchPoints >= cfg.BatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+				cur = nil
+			}
+		}
+	}
+	return flush()
+}
+
+// newMetric returns an empty OTLP metric for the given family, or nil
+// if the family type cannot be exported.
+func (oe *OTLPExporter) newMetric(family *prometheusgo.MetricFamily) *metricspb.Metric {
+	m := &metricspb.Metric{
+		Name:        family.GetName(),
+		Description: family.GetHelp(),
+	}
+	switch family.GetType() {
+	case prometheusgo.MetricType_COUNTER:
+		m.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			IsMonotonic:            true,
+		}}
+	case prometheusgo.MetricType_GAUGE:
+		m.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{}}
+	case prometheusgo.MetricType_HISTOGRAM:
+		m.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}}
+	case prometheusgo.MetricType_SUMMARY:
+		m.Data = &metricspb.Metric_Summary{Summary: &metricspb.Summary{}}
+	default:
+		return nil
+	}
+	return m
+}
+
+// appendPoint converts the Prometheus metric into an OTLP data point
+// and appends it to the OTLP metric.
+func (oe *OTLPExporter) appendPoint(
+	dst *metricspb.Metric, typ prometheusgo.MetricType, m *prometheusgo.Metric, now uint64,
+) {
+	attrs := otlpAttributes(m.GetLabel())
+	switch typ {
+	case prometheusgo.MetricType_COUNTER:
+		sum := dst.GetSum()
+		sum.DataPoints = append(sum.DataPoints, &metricspb.NumberDataPoint{
+			Attributes:        attrs,
+			StartTimeUnixNano: oe.startTime,
+			TimeUnixNano:      now,
+			Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: m.GetCounter().GetValue()},
+		})
+	case prometheusgo.MetricType_GAUGE:
+		g := dst.GetGauge()
+		g.DataPoints = append(g.DataPoints, &metricspb.NumberDataPoint{
+			Attributes:   attrs,
+			TimeUnixNano: now,
+			Value:        &metricspb.NumberDataPoint_AsDouble{AsDouble: m.GetGauge().GetValue()},
+		})
+	case prometheusgo.MetricType_HISTOGRAM:
+		h := m.GetHistogram()
+		bounds, counts := histogramBuckets(h)
+		hist := dst.GetHistogram()
+		hist.DataPoints = append(hist.DataPoints, &metricspb.HistogramDataPoint{
+			Attributes:        attrs,
+			StartTimeUnixNano: oe.startTime,
+			TimeUnixNano:      now,
+			Count:             h.GetSampleCount(),
+			Sum:               h.GetSampleSum(),
+			BucketCounts:      counts,
+			ExplicitBounds:    bounds,
+		})
+	case prometheusgo.MetricType_SUMMARY:
+		s := m.GetSummary()
+		dp := &metricspb.SummaryDataPoint{
+			Attributes:        attrs,
+			StartTimeUnixNano: oe.startTime,
+			TimeUnixNano:      now,
+			Count:             s.GetSampleCount(),
+			Sum:               s.GetSampleSum(),
+		}
+		for _, q := range s.GetQuantile() {
+			dp.QuantileValues = append(dp.QuantileValues, &metricspb.SummaryDataPoint_ValueAtQuantile{
+				Quantile: q.GetQuantile(),
+				Value:    q.GetValue(),
+			})
+		}
+		summary := dst.GetSummary()
+		summary.DataPoints = append(summary.DataPoints, dp)
+	}
+}
+
+func otlpAttributes(labels []*prometheusgo.LabelPair) []*commonpb.KeyValue {
+	if len(labels) == 0 {
+		return nil
+	}
+	attrs := make([]*commonpb.KeyValue, 0, len(labels))
+	for _, l := range labels {
+		attrs = append(attrs, otlpStringKV(l.GetName(), l.GetValue()))
+	}
+	return attrs
+}
+
+func otlpStringKV(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func sortedLabelNames(labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
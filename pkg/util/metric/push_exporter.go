@@ -0,0 +1,214 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package metric
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/cockroachdb/errors"
+	prometheusgo "github.com/prometheus/client_model/go"
+)
+
+// PushConfig configures one push of metrics to an external system.
+type PushConfig struct {
+	// Endpoint is the URL the metrics are sent to.
+	Endpoint string
+	// BatchSize is the maximum number of data points (OTLP) or time
+	// series (Prometheus remote-write) sent in a single request. Zero
+	// means no limit.
+	BatchSize int
+	// Labels are added to every exported series, for example to identify
+	// the process that produced them.
+	Labels map[string]string
+	// Timeout bounds each request sent to the endpoint.
+	Timeout time.Duration
+	// Retry configures how failed requests are retried.
+	Retry retry.Options
+}
+
+// DefaultPushRetryOptions are the retry options used by the push
+// exporters when the caller does not override them.
+var DefaultPushRetryOptions = retry.Options{
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+	MaxRetries:     3,
+}
+
+// PushExporterMetrics are the metrics describing the behavior of a push
+// exporter itself.
+type PushExporterMetrics struct {
+	Pushes  *Counter
+	Batches *Counter
+	Series  *Counter
+	Retries *Counter
+	Errors  *Counter
+}
+
+// MetricStruct implements the metric.Struct interface.
+func (*PushExporterMetrics) MetricStruct() {}
+
+// MakePushExporterMetrics returns the self-metrics of the push exporter
+// with the given name. The name is used as part of the metric names.
+func MakePushExporterMetrics(exporter string, description string) PushExporterMetrics {
+	return PushExporterMetrics{
+		Pushes: NewCounter(Metadata{
+			Name:        fmt.Sprintf("metrics.export.%s.pushes", exporter),
+			Help:        fmt.Sprintf("Number of times metrics were pushed to %s", description),
+			Measurement: "Pushes",
+			Unit:        Unit_COUNT,
+		}),
+		Batches: NewCounter(Metadata{
+			Name:        fmt.Sprintf("metrics.export.%s.batches", exporter),
+			Help:        fmt.Sprintf("Number of batches of metrics successfully sent to %s", description),
+			Measurement: "Batches",
+			Unit:        Unit_COUNT,
+		}),
+		Series: NewCounter(Metadata{
+			Name:        fmt.Sprintf("metrics.export.%s.series", exporter),
+			Help:        fmt.Sprintf("Number of series successfully sent to %s", description),
+			Measurement: "Series",
+			Unit:        Unit_COUNT,
+		}),
+		Retries: NewCounter(Metadata{
+			Name:        fmt.Sprintf("metrics.export.%s.retries", exporter),
+			Help:        fmt.Sprintf("Number of retried requests to %s", description),
+			Measurement: "Requests",
+			Unit:        Unit_COUNT,
+		}),
+		Errors: NewCounter(Metadata{
+			Name:        fmt.Sprintf("metrics.export.%s.errors", exporter),
+			Help:        fmt.Sprintf("Number of batches of metrics that could not be sent to %s", description),
+			Measurement: "Batches",
+			Unit:        Unit_COUNT,
+		}),
+	}
+}
+
+// PushError is returned when the endpoint rejects a batch of metrics.
+type PushError struct {
+	StatusCode int
+	Endpoint   string
+}
+
+func (e *PushError) Error() string {
+	return fmt.Sprintf("received %d response pushing metrics to %s", e.StatusCode, e.Endpoint)
+}
+
+// retryable returns whether the request that produced the error should
+// be retried. Client errors, apart from throttling, are not retried
+// since the same request would be rejected again.
+func (e *PushError) retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// sendWithRetry sends one batch using the provided function, retrying
+// on transient errors, and records the outcome in the exporter metrics.
+func sendWithRetry(
+	ctx context.Context,
+	cfg PushConfig,
+	m *PushExporterMetrics,
+	numSeries int,
+	send func(ctx context.Context) error,
+) error {
+	var err error
+	for r := retry.StartWithCtx(ctx, cfg.Retry); r.Next(); {
+		if r.CurrentAttempt() > 0 {
+			m.Retries.Inc(1)
+		}
+		err = func() error {
+			ctx := ctx
+			if cfg.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+				defer cancel()
+			}
+			return send(ctx)
+		}()
+		if err == nil {
+			m.Batches.Inc(1)
+			m.Series.Inc(int64(numSeries))
+			return nil
+		}
+		var pe *PushError
+		if errors.As(err, &pe) && !pe.retryable() {
+			break
+		}
+	}
+	if err == nil {
+		// The retry loop was interrupted before the first attempt.
+		err = ctx.Err()
+	}
+	m.Errors.Inc(1)
+	return err
+}
+
+// postProto sends a serialized protobuf request to the endpoint.
+func postProto(
+	ctx context.Context, client *http.Client, endpoint string, body []byte, headers map[string]string,
+) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return &PushError{StatusCode: resp.StatusCode, Endpoint: endpoint}
+	}
+	return nil
+}
+
+// gatherSorted returns the metric families gathered by the exporter,
+// sorted by name so that the pushed batches are deterministic.
+func gatherSorted(pm *PrometheusExporter) []*prometheusgo.MetricFamily {
+	families, _ := pm.Gather()
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].GetName() < families[j].GetName()
+	})
+	return families
+}
+
+// histogramBuckets converts the cumulative buckets of a Prometheus
+// histogram into explicit upper bounds and per-bucket counts. The
+// returned counts have one more element than the bounds: the last one
+// counts the samples above the largest finite bound.
+func histogramBuckets(h *prometheusgo.Histogram) (bounds []float64, counts []uint64) {
+	var prev uint64
+	for _, b := range h.GetBucket() {
+		ub := b.GetUpperBound()
+		if math.IsInf(ub, +1) {
+			// The +Inf bucket is implied by the sample count.
+			continue
+		}
+		cum := b.GetCumulativeCount()
+		bounds = append(bounds, ub)
+		counts = append(counts, cum-prev)
+		prev = cum
+	}
+	var overflow uint64
+	if total := h.GetSampleCount(); total > prev {
+		overflow = total - prev
+	}
+	counts = append(counts, overflow)
+	return bounds, counts
+}
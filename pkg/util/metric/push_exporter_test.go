@@ -0,0 +1,246 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package metric
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/retry"
+	"github.com/golang/snappy"
+	prometheusgo "github.com/prometheus/client_model/go"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/require"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPushHistogramBuckets(t *testing.T) {
+	u := func(v uint64) *uint64 { return &v }
+	f := func(v float64) *float64 { return &v }
+	h := &prometheusgo.Histogram{
+		SampleCount: u(10),
+		Bucket: []*prometheusgo.Bucket{
+			{UpperBound: f(1), CumulativeCount: u(2)},
+			{UpperBound: f(5), CumulativeCount: u(2)},
+			{UpperBound: f(10), CumulativeCount: u(7)},
+		},
+	}
+	bounds, counts := histogramBuckets(h)
+	require.Equal(t, []float64{1, 5, 10}, bounds)
+	require.Equal(t, []uint64{2, 0, 5, 3}, counts)
+
+	// An empty histogram has a single overflow bucket.
+	bounds, counts = histogramBuckets(&prometheusgo.Histogram{})
+	require.Empty(t, bounds)
+	require.Equal(t, []uint64{0}, counts)
+}
+
+// pushTestServer records the request bodies received by an HTTP
+// endpoint. The first failures requests are rejected with failStatus.
+type pushTestServer struct {
+	*httptest.Server
+	mu struct {
+		sync.Mutex
+		bodies   [][]byte
+		headers  []http.Header
+		failures int
+	}
+}
+
+func newPushTestServer(t *testing.T, failures int, failStatus int) *pushTestServer {
+	s := &pushTestServer{}
+	s.mu.failures = failures
+	s.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.mu.failures > 0 {
+			s.mu.failures--
+			rw.WriteHeader(failStatus)
+			return
+		}
+		s.mu.bodies = append(s.mu.bodies, body)
+		s.mu.headers = append(s.mu.headers, r.Header.Clone())
+	}))
+	return s
+}
+
+func (s *pushTestServer) received() ([][]byte, []http.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mu.bodies, s.mu.headers
+}
+
+func makePushTestExporter() *PrometheusExporter {
+	r := NewRegistry()
+	c := NewCounter(Metadata{Name: "push.counter", Help: "A counter"})
+	c.Inc(3)
+	r.AddMetric(c)
+	g := NewGauge(Metadata{Name: "push.gauge", Help: "A gauge"})
+	g.Update(7)
+	r.AddMetric(g)
+	h := NewHistogram(HistogramOptions{
+		Metadata: Metadata{Name: "push.histogram", Help: "A histogram"},
+		Duration: time.Minute,
+		Buckets:  []float64{1, 10},
+		Mode:     HistogramModePrometheus,
+	})
+	h.RecordValue(0)
+	h.RecordValue(5)
+	h.RecordValue(50)
+	r.AddMetric(h)
+
+	pm := MakePrometheusExporter()
+	pm.ScrapeRegistry(r, false /* includeChildMetrics */, true /* includeAggregateMetrics */)
+	return &pm
+}
+
+var testPushRetryOptions = retry.Options{
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+	MaxRetries:     2,
+}
+
+func TestRemoteWriteExporter(t *testing.T) {
+	s := newPushTestServer(t, 1 /* failures */, http.StatusServiceUnavailable)
+	defer s.Close()
+
+	m := MakePushExporterMetrics("test", "the test endpoint")
+	e := MakeRemoteWriteExporter(&m)
+	cfg := PushConfig{
+		Endpoint:  s.URL,
+		BatchSize: 4,
+		Labels:    map[string]string{"instance": "n1"},
+		Retry:     testPushRetryOptions,
+	}
+	require.NoError(t, e.Push(context.Background(), makePushTestExporter(), cfg))
+
+	bodies, headers := s.received()
+	// 7 series: one counter, one gauge, and three buckets, the sum and the
+	// count of the histogram, sent in batches of at most 4.
+	require.Len(t, bodies, 2)
+	series := map[string]float64{}
+	for i, body := range bodies {
+		require.Equal(t, "snappy", headers[i].Get("Content-Encoding"))
+		require.Equal(t, "application/x-protobuf", headers[i].Get("Content-Type"))
+		data, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		var req prompb.WriteRequest
+		require.NoError(t, req.Unmarshal(data))
+		for _, ts := range req.Timeseries {
+			var name, le, instance string
+			for _, l := range ts.Labels {
+				switch l.Name {
+				case "__name__":
+					name = l.Value
+				case "le":
+					le = l.Value
+				case "instance":
+					instance = l.Value
+				}
+			}
+			require.Equal(t, "n1", instance)
+			require.Len(t, ts.Samples, 1)
+			if le != "" {
+				name += "{le=" + le + "}"
+			}
+			series[name] = ts.Samples[0].Value
+		}
+	}
+	require.Equal(t, map[string]float64{
+		"push_counter":                   3,
+		"push_gauge":                     7,
+		"push_histogram_bucket{le=1}":    1,
+		"push_histogram_bucket{le=10}":   2,
+		"push_histogram_bucket{le=+Inf}": 3,
+		"push_histogram_sum":             55,
+		"push_histogram_count":           3,
+	}, series)
+
+	require.Equal(t, int64(1), m.Pushes.Count())
+	require.Equal(t, int64(2), m.Batches.Count())
+	require.Equal(t, int64(7), m.Series.Count())
+	require.Equal(t, int64(1), m.Retries.Count())
+	require.Equal(t, int64(0), m.Errors.Count())
+}
+
+func TestRemoteWriteExporterNonRetryableError(t *testing.T) {
+	s := newPushTestServer(t, 10 /* failures */, http.StatusBadRequest)
+	defer s.Close()
+
+	m := MakePushExporterMetrics("test", "the test endpoint")
+	e := MakeRemoteWriteExporter(&m)
+	cfg := PushConfig{Endpoint: s.URL, Retry: testPushRetryOptions}
+	err := e.Push(context.Background(), makePushTestExporter(), cfg)
+	var pe *PushError
+	require.ErrorAs(t, err, &pe)
+	require.Equal(t, http.StatusBadRequest, pe.StatusCode)
+	require.Equal(t, int64(0), m.Retries.Count())
+	require.Equal(t, int64(1), m.Errors.Count())
+}
+
+func TestOTLPExporter(t *testing.T) {
+	s := newPushTestServer(t, 0 /* failures */, 0 /* failStatus */)
+	defer s.Close()
+
+	m := MakePushExporterMetrics("test", "the test endpoint")
+	e := MakeOTLPExporter(&m)
+	cfg := PushConfig{
+		Endpoint:  s.URL,
+		BatchSize: 2,
+		Labels:    map[string]string{"instance": "n1"},
+		Retry:     testPushRetryOptions,
+	}
+	require.NoError(t, e.Push(context.Background(), makePushTestExporter(), cfg))
+
+	bodies, _ := s.received()
+	require.Len(t, bodies, 2)
+	metrics := map[string]*metricspb.Metric{}
+	for _, body := range bodies {
+		var req colmetricspb.ExportMetricsServiceRequest
+		require.NoError(t, proto.Unmarshal(body, &req))
+		require.Len(t, req.ResourceMetrics, 1)
+		attrs := map[string]string{}
+		for _, kv := range req.ResourceMetrics[0].Resource.Attributes {
+			attrs[kv.Key] = kv.Value.GetStringValue()
+		}
+		require.Equal(t, map[string]string{"service.name": "cockroachdb", "instance": "n1"}, attrs)
+		for _, il := range req.ResourceMetrics[0].InstrumentationLibraryMetrics {
+			for _, metric := range il.Metrics {
+				metrics[metric.Name] = metric
+			}
+		}
+	}
+	require.Len(t, metrics, 3)
+
+	sum := metrics["push_counter"].GetSum()
+	require.True(t, sum.IsMonotonic)
+	require.Equal(t, 3.0, sum.DataPoints[0].GetAsDouble())
+	require.Equal(t, 7.0, metrics["push_gauge"].GetGauge().DataPoints[0].GetAsDouble())
+
+	hist := metrics["push_histogram"].GetHistogram()
+	require.Equal(t, metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		hist.AggregationTemporality)
+	dp := hist.DataPoints[0]
+	require.Equal(t, []float64{1, 10}, dp.ExplicitBounds)
+	require.Equal(t, []uint64{1, 1, 1}, dp.BucketCounts)
+	require.Equal(t, uint64(3), dp.Count)
+	require.Equal(t, 55.0, dp.Sum)
+
+	require.Equal(t, int64(2), m.Batches.Count())
+	require.Equal(t, int64(3), m.Series.Count())
+}
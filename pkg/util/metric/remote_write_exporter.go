@@ -0,0 +1,182 @@
+// Copyright 2025 The Cockroach Authors.
+//
+// Use of this software is governed by the CockroachDB Software License
+// included in the /LICENSE file.
+
+package metric
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/golang/snappy"
+	prometheusgo "github.com/prometheus/client_model/go"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+var errNoRemoteWriteEndpoint = errors.New("external.prometheus_remote_write.endpoint is not set")
+
+// remoteWriteHeaders are the headers required by the Prometheus
+// remote-write protocol, in addition to the content type.
+var remoteWriteHeaders = map[string]string{
+	"Content-Encoding":                  "snappy",
+	"X-Prometheus-Remote-Write-Version": "0.1.0",
+}
+
+// RemoteWriteExporter pushes the metrics scraped by a PrometheusExporter
+// to an endpoint implementing the Prometheus remote-write protocol.
+//
+// The series are named as they are when scraped from the Prometheus
+// endpoint. Histograms and summaries are expanded into the same series
+// as in the Prometheus text format: `_bucket` series with an `le` label
+// (or a `quantile` label for summaries), `_sum` and `_count`.
+type RemoteWriteExporter struct {
+	client  http.Client
+	metrics *PushExporterMetrics
+}
+
+// MakeRemoteWriteExporter returns an initialized remote-write exporter.
+// The provided metrics are updated on every push.
+func MakeRemoteWriteExporter(metrics *PushExporterMetrics) RemoteWriteExporter {
+	return RemoteWriteExporter{metrics: metrics}
+}
+
+// Push sends the metrics gathered by pm to the configured endpoint. The
+// series are split into requests of at most cfg.BatchSize series.
+func (re *RemoteWriteExporter) Push(
+	ctx context.Context, pm *PrometheusExporter, cfg PushConfig,
+) error {
+	if cfg.Endpoint == "" {
+		return errNoRemoteWriteEndpoint
+	}
+	// Regardless of whether the push errors, clear metrics. Only the latest
+	// values are pushed, like for the Graphite exporter.
+	defer pm.clearMetrics()
+	re.metrics.Pushes.Inc(1)
+
+	ts := timeutil.Now().UnixMilli()
+	var batch []prompb.TimeSeries
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		req := &prompb.WriteRequest{Timeseries: batch}
+		data, err := req.Marshal()
+		if err != nil {
+			return err
+		}
+		body := snappy.Encode(nil, data)
+		numSeries := len(batch)
+		batch = nil
+		return sendWithRetry(ctx, cfg, re.metrics, numSeries, func(ctx context.Context) error {
+			return postProto(ctx, &re.client, cfg.Endpoint, body, remoteWriteHeaders)
+		})
+	}
+	add := func(name string, labels []prompb.Label, value float64) error {
+		batch = append(batch, prompb.TimeSeries{
+			Labels:  remoteWriteLabels(name, labels),
+			Samples: []prompb.Sample{{Value: value, Timestamp: ts}},
+		})
+		if cfg.BatchSize > 0 && len(batch) >= cfg.BatchSize {
+			return flush()
+		}
+		return nil
+	}
+
+	for _, family := range gatherSorted(pm) {
+		name := family.GetName()
+		for _, m := range family.Metric {
+			labels := make([]prompb.Label, 0, len(m.GetLabel())+len(cfg.Labels)+1)
+			seen := make(map[string]struct{}, len(m.GetLabel()))
+			for _, l := range m.GetLabel() {
+				labels = append(labels, prompb.Label{Name: l.GetName(), Value: l.GetValue()})
+				seen[l.GetName()] = struct{}{}
+			}
+			// The labels of the metric take precedence over the labels
+			// added by the configuration.
+			for _, k := range sortedLabelNames(cfg.Labels) {
+				if _, ok := seen[k]; !ok {
+					labels = append(labels, prompb.Label{Name: k, Value: cfg.Labels[k]})
+				}
+			}
+			if err := re.addSeries(name, family.GetType(), m, labels, add); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// addSeries expands one Prometheus metric into remote-write series.
+func (re *RemoteWriteExporter) addSeries(
+	name string,
+	typ prometheusgo.MetricType,
+	m *prometheusgo.Metric,
+	labels []prompb.Label,
+	add func(name string, labels []prompb.Label, value float64) error,
+) error {
+	switch typ {
+	case prometheusgo.MetricType_COUNTER:
+		return add(name, labels, m.GetCounter().GetValue())
+	case prometheusgo.MetricType_GAUGE:
+		return add(name, labels, m.GetGauge().GetValue())
+	case prometheusgo.MetricType_HISTOGRAM:
+		h := m.GetHistogram()
+		bounds, counts := histogramBuckets(h)
+		var cum uint64
+		for i, ub := range bounds {
+			cum += counts[i]
+			if err := add(name+"_bucket", withLabel(labels, "le", formatFloat(ub)), float64(cum)); err != nil {
+				return err
+			}
+		}
+		if err := add(name+"_bucket", withLabel(labels, "le", "+Inf"), float64(h.GetSampleCount())); err != nil {
+			return err
+		}
+		if err := add(name+"_sum", labels, h.GetSampleSum()); err != nil {
+			return err
+		}
+		return add(name+"_count", labels, float64(h.GetSampleCount()))
+	case prometheusgo.MetricType_SUMMARY:
+		s := m.GetSummary()
+		for _, q := range s.GetQuantile() {
+			if err := add(name, withLabel(labels, "quantile", formatFloat(q.GetQuantile())), q.GetValue()); err != nil {
+				return err
+			}
+		}
+		if err := add(name+"_sum", labels, s.GetSampleSum()); err != nil {
+			return err
+		}
+		return add(name+"_count", labels, float64(s.GetSampleCount()))
+	default:
+		return nil
+	}
+}
+
+// remoteWriteLabels returns the labels of a series, including the
+// metric name, sorted by name as required by the protocol.
+func remoteWriteLabels(name string, labels []prompb.Label) []prompb.Label {
+	res := make([]prompb.Label, 0, len(labels)+1)
+	res = append(res, prompb.Label{Name: "__name__", Value: name})
+	res = append(res, labels...)
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func withLabel(labels []prompb.Label, name, value string) []prompb.Label {
+	res := make([]prompb.Label, 0, len(labels)+1)
+	res = append(res, labels...)
+	return append(res, prompb.Label{Name: name, Value: value})
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, +1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}